@@ -47,7 +47,9 @@ type Namespace interface {
 }
 
 // ManifestServiceOption is a function argument for Manifest Service methods
-type ManifestServiceOption func(ManifestService) error
+type ManifestServiceOption interface {
+	Apply(ManifestService) error
+}
 
 // Repository is a named collection of manifests and layers.
 type Repository interface {
@@ -116,6 +118,52 @@ type ManifestService interface {
 	//       really be concerned with the storage format.
 }
 
+// RawManifestService may be implemented by manifest services which can store
+// and retrieve manifests of other media types than signed schema1 manifests,
+// such as schema2 manifests. Manifests are exchanged as raw content along
+// with their media type.
+type RawManifestService interface {
+	// GetRaw retrieves the manifest identified by the digest, or by the tag
+	// passed with WithTag, in one of the media types passed with
+	// WithManifestMediaTypes. Only signed schema1 manifests are returned when
+	// no media types are passed.
+	GetRaw(dgst digest.Digest, options ...ManifestServiceOption) (mediaType string, content []byte, err error)
+
+	// PutRaw stores the manifest content of the given media type, under the
+	// tag passed with WithTag if any.
+	PutRaw(mediaType string, content []byte, options ...ManifestServiceOption) error
+}
+
+// WithTag allows a tag to be passed into Put which enables the client
+// to build a correct URL.
+func WithTag(tag string) ManifestServiceOption {
+	return WithTagOption{tag}
+}
+
+// WithTagOption holds a tag
+type WithTagOption struct{ Tag string }
+
+// Apply conforms to the ManifestServiceOption interface
+func (o WithTagOption) Apply(m ManifestService) error {
+	// no implementation
+	return nil
+}
+
+// WithManifestMediaTypes lists the media types the client wishes
+// the server to provide.
+func WithManifestMediaTypes(mediaTypes []string) ManifestServiceOption {
+	return WithManifestMediaTypesOption{mediaTypes}
+}
+
+// WithManifestMediaTypesOption holds a list of accepted media types
+type WithManifestMediaTypesOption struct{ MediaTypes []string }
+
+// Apply conforms to the ManifestServiceOption interface
+func (o WithManifestMediaTypesOption) Apply(m ManifestService) error {
+	// no implementation
+	return nil
+}
+
 // SignatureService provides operations on signatures.
 type SignatureService interface {
 	// Get retrieves all of the signature blobs for the specified digest.
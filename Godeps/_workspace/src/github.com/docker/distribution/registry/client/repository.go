@@ -260,18 +260,22 @@ func (ms *manifests) Get(dgst digest.Digest) (*schema1.SignedManifest, error) {
 // and nil error will be returned. etag is automatically quoted when added to
 // this map.
 func AddEtagToTag(tag, etag string) distribution.ManifestServiceOption {
-	return func(ms distribution.ManifestService) error {
-		if ms, ok := ms.(*manifests); ok {
-			ms.etags[tag] = fmt.Sprintf(`"%s"`, etag)
-			return nil
-		}
-		return fmt.Errorf("etag options is a client-only option")
+	return etagOption{tag, etag}
+}
+
+type etagOption struct{ tag, etag string }
+
+func (o etagOption) Apply(ms distribution.ManifestService) error {
+	if ms, ok := ms.(*manifests); ok {
+		ms.etags[o.tag] = fmt.Sprintf(`"%s"`, o.etag)
+		return nil
 	}
+	return fmt.Errorf("etag options is a client-only option")
 }
 
 func (ms *manifests) GetByTag(tag string, options ...distribution.ManifestServiceOption) (*schema1.SignedManifest, error) {
 	for _, option := range options {
-		err := option(ms)
+		err := option.Apply(ms)
 		if err != nil {
 			return nil, err
 		}
@@ -308,6 +312,56 @@ func (ms *manifests) GetByTag(tag string, options ...distribution.ManifestServic
 	return nil, handleErrorResponse(resp)
 }
 
+// GetRaw retrieves the manifest identified by the digest, or by the tag passed
+// with WithTag, in one of the media types passed with WithManifestMediaTypes.
+func (ms *manifests) GetRaw(dgst digest.Digest, options ...distribution.ManifestServiceOption) (string, []byte, error) {
+	ref := dgst.String()
+	var mediaTypes []string
+	for _, option := range options {
+		switch opt := option.(type) {
+		case distribution.WithTagOption:
+			ref = opt.Tag
+		case distribution.WithManifestMediaTypesOption:
+			mediaTypes = opt.MediaTypes
+		default:
+			if err := option.Apply(ms); err != nil {
+				return "", nil, err
+			}
+		}
+	}
+
+	u, err := ms.ub.BuildManifestURL(ms.name, ref)
+	if err != nil {
+		return "", nil, err
+	}
+	req, err := http.NewRequest("GET", u, nil)
+	if err != nil {
+		return "", nil, err
+	}
+	for _, mediaType := range mediaTypes {
+		req.Header.Add("Accept", mediaType)
+	}
+
+	if _, ok := ms.etags[ref]; ok {
+		req.Header.Set("If-None-Match", ms.etags[ref])
+	}
+	resp, err := ms.client.Do(req)
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotModified {
+		return "", nil, distribution.ErrManifestNotModified
+	} else if SuccessStatus(resp.StatusCode) {
+		content, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return "", nil, err
+		}
+		return resp.Header.Get("Content-Type"), content, nil
+	}
+	return "", nil, handleErrorResponse(resp)
+}
+
 func (ms *manifests) Enumerate() ([]digest.Digest, error) {
 	return nil, distribution.ErrUnsupported
 }
@@ -338,6 +392,48 @@ func (ms *manifests) Put(m *schema1.SignedManifest) error {
 	return handleErrorResponse(resp)
 }
 
+// PutRaw stores the manifest content of the given media type, under the tag
+// passed with WithTag if any.
+func (ms *manifests) PutRaw(mediaType string, content []byte, options ...distribution.ManifestServiceOption) error {
+	var ref string
+	for _, option := range options {
+		if opt, ok := option.(distribution.WithTagOption); ok {
+			ref = opt.Tag
+		} else if err := option.Apply(ms); err != nil {
+			return err
+		}
+	}
+	if len(ref) == 0 {
+		dgst, err := digest.FromBytes(content)
+		if err != nil {
+			return err
+		}
+		ref = dgst.String()
+	}
+
+	manifestURL, err := ms.ub.BuildManifestURL(ms.name, ref)
+	if err != nil {
+		return err
+	}
+
+	putRequest, err := http.NewRequest("PUT", manifestURL, bytes.NewReader(content))
+	if err != nil {
+		return err
+	}
+	putRequest.Header.Set("Content-Type", mediaType)
+
+	resp, err := ms.client.Do(putRequest)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if SuccessStatus(resp.StatusCode) {
+		return nil
+	}
+	return handleErrorResponse(resp)
+}
+
 func (ms *manifests) Delete(dgst digest.Digest) error {
 	u, err := ms.ub.BuildManifestURL(ms.name, dgst.String())
 	if err != nil {
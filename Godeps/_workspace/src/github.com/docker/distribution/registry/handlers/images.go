@@ -62,6 +62,11 @@ func (imh *imageManifestHandler) GetImageManifest(w http.ResponseWriter, r *http
 		return
 	}
 
+	if raw, ok := manifests.(distribution.RawManifestService); ok {
+		imh.getRawManifest(w, r, raw)
+		return
+	}
+
 	var sm *schema1.SignedManifest
 	if imh.Tag != "" {
 		sm, err = manifests.GetByTag(imh.Tag)
@@ -100,6 +105,62 @@ func (imh *imageManifestHandler) GetImageManifest(w http.ResponseWriter, r *http
 	w.Write(sm.Raw)
 }
 
+// getRawManifest serves the manifest in one of the media types accepted by
+// the client.
+func (imh *imageManifestHandler) getRawManifest(w http.ResponseWriter, r *http.Request, manifests distribution.RawManifestService) {
+	options := []distribution.ManifestServiceOption{distribution.WithManifestMediaTypes(acceptedMediaTypes(r))}
+	if imh.Tag != "" {
+		options = append(options, distribution.WithTag(imh.Tag))
+	} else if etagMatch(r, imh.Digest.String()) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	mediaType, content, err := manifests.GetRaw(imh.Digest, options...)
+	if err != nil {
+		imh.Errors = append(imh.Errors, v2.ErrorCodeManifestUnknown.WithDetail(err))
+		return
+	}
+
+	// Get the digest, if we don't already have it.
+	if imh.Digest == "" {
+		dgst, err := digestRawManifest(imh, mediaType, content)
+		if err != nil {
+			imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid.WithDetail(err))
+			return
+		}
+		if etagMatch(r, dgst.String()) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+
+		imh.Digest = dgst
+	}
+
+	w.Header().Set("Content-Type", mediaType)
+	w.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	w.Header().Set("Docker-Content-Digest", imh.Digest.String())
+	w.Header().Set("Etag", fmt.Sprintf(`"%s"`, imh.Digest))
+	w.Write(content)
+}
+
+// acceptedMediaTypes returns the media types listed in the Accept headers of
+// the request.
+func acceptedMediaTypes(r *http.Request) []string {
+	var mediaTypes []string
+	for _, header := range r.Header["Accept"] {
+		for _, mediaType := range strings.Split(header, ",") {
+			if i := strings.Index(mediaType, ";"); i != -1 {
+				mediaType = mediaType[:i]
+			}
+			if mediaType = strings.TrimSpace(mediaType); mediaType != "" {
+				mediaTypes = append(mediaTypes, mediaType)
+			}
+		}
+	}
+	return mediaTypes
+}
+
 func etagMatch(r *http.Request, etag string) bool {
 	for _, headerVal := range r.Header["If-None-Match"] {
 		if headerVal == etag || headerVal == fmt.Sprintf(`"%s"`, etag) { // allow quoted or unquoted
@@ -124,6 +185,13 @@ func (imh *imageManifestHandler) PutImageManifest(w http.ResponseWriter, r *http
 		return
 	}
 
+	if raw, ok := manifests.(distribution.RawManifestService); ok {
+		if mediaType := rawManifestMediaType(r, jsonBuf.Bytes()); mediaType != "" {
+			imh.putRawManifest(w, raw, mediaType, jsonBuf.Bytes())
+			return
+		}
+	}
+
 	var manifest schema1.SignedManifest
 	if err := json.Unmarshal(jsonBuf.Bytes(), &manifest); err != nil {
 		imh.Errors = append(imh.Errors, v2.ErrorCodeManifestInvalid.WithDetail(err))
@@ -157,41 +225,15 @@ func (imh *imageManifestHandler) PutImageManifest(w http.ResponseWriter, r *http
 	}
 
 	if err := manifests.Put(&manifest); err != nil {
-		// TODO(stevvooe): These error handling switches really need to be
-		// handled by an app global mapper.
-		if err == distribution.ErrUnsupported {
-			imh.Errors = append(imh.Errors, errcode.ErrorCodeUnsupported)
-			return
-		}
-		if err == distribution.ErrAccessDenied {
-			imh.Errors = append(imh.Errors, errcode.ErrorCodeDenied)
-			return
-		}
-		switch err := err.(type) {
-		case distribution.ErrManifestVerification:
-			for _, verificationError := range err {
-				switch verificationError := verificationError.(type) {
-				case distribution.ErrManifestBlobUnknown:
-					imh.Errors = append(imh.Errors, v2.ErrorCodeManifestBlobUnknown.WithDetail(verificationError.Digest))
-				case distribution.ErrManifestNameInvalid:
-					imh.Errors = append(imh.Errors, v2.ErrorCodeNameInvalid.WithDetail(err))
-				case distribution.ErrManifestUnverified:
-					imh.Errors = append(imh.Errors, v2.ErrorCodeManifestUnverified)
-				default:
-					if verificationError == digest.ErrDigestInvalidFormat {
-						imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid)
-					} else {
-						imh.Errors = append(imh.Errors, errcode.ErrorCodeUnknown, verificationError)
-					}
-				}
-			}
-		default:
-			imh.Errors = append(imh.Errors, errcode.ErrorCodeUnknown.WithDetail(err))
-		}
-
+		imh.appendPutError(err)
 		return
 	}
 
+	imh.manifestCreated(w)
+}
+
+// manifestCreated writes the response to a successful manifest PUT.
+func (imh *imageManifestHandler) manifestCreated(w http.ResponseWriter) {
 	// Construct a canonical url for the uploaded manifest.
 	location, err := imh.urlBuilder.BuildManifestURL(imh.Repository.Name(), imh.Digest.String())
 	if err != nil {
@@ -206,6 +248,94 @@ func (imh *imageManifestHandler) PutImageManifest(w http.ResponseWriter, r *http
 	w.WriteHeader(http.StatusCreated)
 }
 
+// appendPutError records the errors of a failed manifest PUT.
+func (imh *imageManifestHandler) appendPutError(err error) {
+	// TODO(stevvooe): These error handling switches really need to be
+	// handled by an app global mapper.
+	if err == distribution.ErrUnsupported {
+		imh.Errors = append(imh.Errors, errcode.ErrorCodeUnsupported)
+		return
+	}
+	if err == distribution.ErrAccessDenied {
+		imh.Errors = append(imh.Errors, errcode.ErrorCodeDenied)
+		return
+	}
+	switch err := err.(type) {
+	case errcode.Error:
+		imh.Errors = append(imh.Errors, err)
+	case distribution.ErrManifestVerification:
+		for _, verificationError := range err {
+			switch verificationError := verificationError.(type) {
+			case distribution.ErrManifestBlobUnknown:
+				imh.Errors = append(imh.Errors, v2.ErrorCodeManifestBlobUnknown.WithDetail(verificationError.Digest))
+			case distribution.ErrManifestNameInvalid:
+				imh.Errors = append(imh.Errors, v2.ErrorCodeNameInvalid.WithDetail(err))
+			case distribution.ErrManifestUnverified:
+				imh.Errors = append(imh.Errors, v2.ErrorCodeManifestUnverified)
+			default:
+				if verificationError == digest.ErrDigestInvalidFormat {
+					imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid)
+				} else {
+					imh.Errors = append(imh.Errors, errcode.ErrorCodeUnknown, verificationError)
+				}
+			}
+		}
+	default:
+		imh.Errors = append(imh.Errors, errcode.ErrorCodeUnknown.WithDetail(err))
+	}
+}
+
+// rawManifestMediaType returns the media type of a manifest which isn't a
+// schema1 manifest, or an empty string if content is a schema1 manifest.
+func rawManifestMediaType(r *http.Request, content []byte) string {
+	var versioned struct {
+		SchemaVersion int    `json:"schemaVersion"`
+		MediaType     string `json:"mediaType"`
+	}
+	if err := json.Unmarshal(content, &versioned); err != nil || versioned.SchemaVersion < 2 {
+		return ""
+	}
+	if versioned.MediaType != "" {
+		return versioned.MediaType
+	}
+	mediaType := r.Header.Get("Content-Type")
+	if i := strings.Index(mediaType, ";"); i != -1 {
+		mediaType = mediaType[:i]
+	}
+	return strings.TrimSpace(mediaType)
+}
+
+// putRawManifest stores a manifest which isn't a schema1 manifest.
+func (imh *imageManifestHandler) putRawManifest(w http.ResponseWriter, manifests distribution.RawManifestService, mediaType string, content []byte) {
+	dgst, err := digest.FromBytes(content)
+	if err != nil {
+		imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid.WithDetail(err))
+		return
+	}
+	if imh.Tag != "" {
+		imh.Digest = dgst
+	} else if imh.Digest != "" {
+		if dgst != imh.Digest {
+			ctxu.GetLogger(imh).Errorf("payload digest does match: %q != %q", dgst, imh.Digest)
+			imh.Errors = append(imh.Errors, v2.ErrorCodeDigestInvalid)
+			return
+		}
+	} else {
+		imh.Errors = append(imh.Errors, v2.ErrorCodeTagInvalid.WithDetail("no tag or digest specified"))
+		return
+	}
+
+	var options []distribution.ManifestServiceOption
+	if imh.Tag != "" {
+		options = append(options, distribution.WithTag(imh.Tag))
+	}
+	if err := manifests.PutRaw(mediaType, content, options...); err != nil {
+		imh.appendPutError(err)
+		return
+	}
+	imh.manifestCreated(w)
+}
+
 // DeleteImageManifest removes the manifest with the given digest from the registry.
 func (imh *imageManifestHandler) DeleteImageManifest(w http.ResponseWriter, r *http.Request) {
 	ctxu.GetLogger(imh).Debug("DeleteImageManifest")
@@ -262,3 +392,16 @@ func digestManifest(ctx context.Context, sm *schema1.SignedManifest) (digest.Dig
 
 	return dgst, err
 }
+
+// digestRawManifest takes the digest of the given manifest content. The
+// digest of a signed schema1 manifest is the one of its payload.
+func digestRawManifest(ctx context.Context, mediaType string, content []byte) (digest.Digest, error) {
+	if strings.HasPrefix(mediaType, "application/vnd.docker.distribution.manifest.v1+") || strings.HasPrefix(mediaType, "application/json") {
+		var sm schema1.SignedManifest
+		if err := json.Unmarshal(content, &sm); err != nil {
+			return "", err
+		}
+		return digestManifest(ctx, &sm)
+	}
+	return digest.FromBytes(content)
+}
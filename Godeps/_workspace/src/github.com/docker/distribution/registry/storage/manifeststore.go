@@ -45,8 +45,14 @@ func (ms *manifestStore) Get(dgst digest.Digest) (*schema1.SignedManifest, error
 
 // SkipLayerVerification allows a manifest to be Put before it's
 // layers are on the filesystem
-func SkipLayerVerification(ms distribution.ManifestService) error {
-	if ms, ok := ms.(*manifestStore); ok {
+func SkipLayerVerification() distribution.ManifestServiceOption {
+	return skipLayerOption{}
+}
+
+type skipLayerOption struct{}
+
+func (o skipLayerOption) Apply(m distribution.ManifestService) error {
+	if ms, ok := m.(*manifestStore); ok {
 		ms.skipDependencyVerification = true
 		return nil
 	}
@@ -98,7 +104,7 @@ func (ms *manifestStore) ExistsByTag(tag string) (bool, error) {
 
 func (ms *manifestStore) GetByTag(tag string, options ...distribution.ManifestServiceOption) (*schema1.SignedManifest, error) {
 	for _, option := range options {
-		err := option(ms)
+		err := option.Apply(ms)
 		if err != nil {
 			return nil, err
 		}
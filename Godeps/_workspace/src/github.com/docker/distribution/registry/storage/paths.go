@@ -501,6 +501,18 @@ type uploadHashStatePathSpec struct {
 
 func (uploadHashStatePathSpec) pathSpec() {}
 
+// BlobDataPath returns the path of the data of the blob in the registry global
+// blob store.
+func BlobDataPath(dgst digest.Digest) (string, error) {
+	return pathFor(blobDataPathSpec{digest: dgst})
+}
+
+// RepositoriesRootPath returns the path of the directory holding all the
+// repositories.
+func RepositoriesRootPath() (string, error) {
+	return pathFor(repositoriesRootPathSpec{})
+}
+
 // repositoriesRootPathSpec returns the root of repositories
 type repositoriesRootPathSpec struct {
 }
@@ -228,7 +228,7 @@ func (repo *repository) Manifests(ctx context.Context, options ...distribution.M
 
 	// Apply options
 	for _, option := range options {
-		err := option(ms)
+		err := option.Apply(ms)
 		if err != nil {
 			return nil, err
 		}
@@ -259,6 +259,14 @@ func fuzzInternalObject(t *testing.T, forVersion unversioned.GroupVersion, item
 		func(j *deploy.DeploymentConfig, c fuzz.Continue) {
 			c.FuzzNoCustom(j)
 			j.Spec.Triggers = []deploy.DeploymentTriggerPolicy{{Type: deploy.DeploymentTriggerOnConfigChange}}
+			if forVersion == v1beta3.SchemeGroupVersion {
+				// v1beta3 does not contain automatic rollbacks, history limits, deployment windows or conditions.
+				j.Spec.AutoRollback = false
+				j.Spec.RevisionHistoryLimit = nil
+				j.Spec.DeploymentWindow = nil
+				j.Status.Conditions = nil
+				j.Status.PendingDeployment = nil
+			}
 			if j.Spec.Template != nil && len(j.Spec.Template.Spec.Containers) == 1 {
 				containerName := j.Spec.Template.Spec.Containers[0].Name
 				if p := j.Spec.Strategy.RecreateParams; p != nil {
@@ -270,12 +278,29 @@ func fuzzInternalObject(t *testing.T, forVersion unversioned.GroupVersion, item
 					defaultHookContainerName(p.Pre, containerName)
 					defaultHookContainerName(p.Post, containerName)
 				}
+				if p := j.Spec.Strategy.CanaryParams; p != nil {
+					defaultHookContainerName(p.Pre, containerName)
+					defaultHookContainerName(p.Post, containerName)
+				}
+				if p := j.Spec.Strategy.BlueGreenParams; p != nil {
+					defaultHookContainerName(p.Pre, containerName)
+					defaultHookContainerName(p.Mid, containerName)
+					defaultHookContainerName(p.Post, containerName)
+				}
+				if p := j.Spec.Strategy.OrderedParams; p != nil {
+					defaultHookContainerName(p.Pre, containerName)
+					defaultHookContainerName(p.Post, containerName)
+				}
 			}
 		},
 		func(j *deploy.DeploymentStrategy, c fuzz.Continue) {
 			c.FuzzNoCustom(j)
-			j.RecreateParams, j.RollingParams, j.CustomParams = nil, nil, nil
+			j.RecreateParams, j.RollingParams, j.CanaryParams, j.BlueGreenParams, j.OrderedParams, j.CustomParams = nil, nil, nil, nil, nil, nil
 			strategyTypes := []deploy.DeploymentStrategyType{deploy.DeploymentStrategyTypeRecreate, deploy.DeploymentStrategyTypeRolling, deploy.DeploymentStrategyTypeCustom}
+			if forVersion != v1beta3.SchemeGroupVersion {
+				// v1beta3 does not contain the Canary, BlueGreen or Ordered strategies.
+				strategyTypes = append(strategyTypes, deploy.DeploymentStrategyTypeCanary, deploy.DeploymentStrategyTypeBlueGreen, deploy.DeploymentStrategyTypeOrdered)
+			}
 			j.Type = strategyTypes[c.Rand.Intn(len(strategyTypes))]
 			switch j.Type {
 			case deploy.DeploymentStrategyTypeRecreate:
@@ -325,6 +350,73 @@ func fuzzInternalObject(t *testing.T, forVersion unversioned.GroupVersion, item
 					params.MaxUnavailable = intstr.FromString(fmt.Sprintf("%d%%", c.RandUint64()))
 				}
 				j.RollingParams = params
+			case deploy.DeploymentStrategyTypeCanary:
+				params := &deploy.CanaryDeploymentStrategyParams{}
+				c.Fuzz(params)
+				if params.Percent == nil {
+					percent := deploy.DefaultCanaryPercent
+					params.Percent = &percent
+				}
+				if params.BakeSeconds == nil {
+					s := deploy.DefaultCanaryBakeSeconds
+					params.BakeSeconds = &s
+				}
+				if params.TimeoutSeconds == nil {
+					s := deploy.DefaultRollingTimeoutSeconds
+					params.TimeoutSeconds = &s
+				}
+				if params.HealthGate != nil && params.HealthGate.ExecNewPod != nil && len(params.HealthGate.ExecNewPod.ContainerName) == 0 {
+					params.HealthGate.ExecNewPod.ContainerName = "container"
+				}
+				j.CanaryParams = params
+			case deploy.DeploymentStrategyTypeBlueGreen:
+				params := &deploy.BlueGreenDeploymentStrategyParams{}
+				c.Fuzz(params)
+				if params.TimeoutSeconds == nil {
+					s := deploy.DefaultRollingTimeoutSeconds
+					params.TimeoutSeconds = &s
+				}
+				if params.KeepPreviousSeconds == nil {
+					s := deploy.DefaultBlueGreenKeepPreviousSeconds
+					params.KeepPreviousSeconds = &s
+				}
+				j.BlueGreenParams = params
+			case deploy.DeploymentStrategyTypeOrdered:
+				params := &deploy.OrderedDeploymentStrategyParams{}
+				c.Fuzz(params)
+				if params.TimeoutSeconds == nil {
+					s := deploy.DefaultRollingTimeoutSeconds
+					params.TimeoutSeconds = &s
+				}
+				if params.ReadinessCheck != nil && len(params.ReadinessCheck.ContainerName) == 0 {
+					params.ReadinessCheck.ContainerName = c.RandString()
+				}
+				j.OrderedParams = params
+			}
+		},
+		func(j *deploy.DeploymentCause, c fuzz.Continue) {
+			c.FuzzNoCustom(j)
+			if forVersion == v1beta3.SchemeGroupVersion {
+				// v1beta3 does not contain automatic rollbacks or resource change triggers.
+				j.AutoRollback = nil
+				j.ResourceTrigger = nil
+			}
+		},
+		func(j *deploy.LifecycleHook, c fuzz.Continue) {
+			c.FuzzNoCustom(j)
+			if forVersion == v1beta3.SchemeGroupVersion {
+				// v1beta3 does not contain in-place exec hooks.
+				j.ExecInPod = nil
+			}
+		},
+		func(j *deploy.ExecInPodHook, c fuzz.Continue) {
+			c.FuzzNoCustom(j)
+			if len(j.Target) == 0 {
+				j.Target = deploy.ExecInPodTargetNew
+			}
+			if j.TimeoutSeconds == nil {
+				s := deploy.DefaultExecInPodTimeoutSeconds
+				j.TimeoutSeconds = &s
 			}
 		},
 		func(j *deploy.DeploymentCauseImageTrigger, c fuzz.Continue) {
@@ -440,6 +532,11 @@ func defaultHookContainerName(hook *deploy.LifecycleHook, containerName string)
 			hook.ExecNewPod.ContainerName = containerName
 		}
 	}
+	if hook.ExecInPod != nil {
+		if len(hook.ExecInPod.ContainerName) == 0 {
+			hook.ExecInPod.ContainerName = containerName
+		}
+	}
 }
 
 func roundTrip(t *testing.T, codec runtime.Codec, originalItem runtime.Object) {
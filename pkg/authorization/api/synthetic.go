@@ -6,6 +6,7 @@ const (
 	SourceBuildResource          = "builds/source"
 	CustomBuildResource          = "builds/custom"
 	JenkinsPipelineBuildResource = "builds/jenkinspipeline"
+	PipelineBuildResource        = "builds/pipeline"
 
 	NodeMetricsResource = "nodes/metrics"
 	NodeStatsResource   = "nodes/stats"
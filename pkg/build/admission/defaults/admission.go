@@ -1,6 +1,7 @@
 package defaults
 
 import (
+	"fmt"
 	"io"
 
 	"github.com/golang/glog"
@@ -12,6 +13,8 @@ import (
 	defaultsapi "github.com/openshift/origin/pkg/build/admission/defaults/api"
 	"github.com/openshift/origin/pkg/build/admission/defaults/api/validation"
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client"
+	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
 )
 
 func init() {
@@ -43,8 +46,12 @@ func getConfig(in io.Reader) (*defaultsapi.BuildDefaultsConfig, error) {
 type buildDefaults struct {
 	*admission.Handler
 	defaultsConfig *defaultsapi.BuildDefaultsConfig
+	client         client.Interface
 }
 
+var _ = oadmission.WantsOpenshiftClient(&buildDefaults{})
+var _ = oadmission.Validator(&buildDefaults{})
+
 // NewBuildDefaults returns an admission control for builds that sets build defaults
 // based on the plugin configuration
 func NewBuildDefaults(defaultsConfig *defaultsapi.BuildDefaultsConfig) admission.Interface {
@@ -72,9 +79,28 @@ func (a *buildDefaults) Admit(attributes admission.Attributes) error {
 
 	a.applyBuildDefaults(build)
 
+	if a.defaultsConfig.ResourceAutoSizing != nil {
+		pod, err := buildadmission.GetPod(attributes)
+		if err != nil {
+			return err
+		}
+		a.applyResourceAutoSizing(build, pod)
+	}
+
 	return buildadmission.SetBuild(attributes, build, version)
 }
 
+func (a *buildDefaults) SetOpenshiftClient(c client.Interface) {
+	a.client = c
+}
+
+func (a *buildDefaults) Validate() error {
+	if a.defaultsConfig != nil && a.defaultsConfig.ResourceAutoSizing != nil && a.client == nil {
+		return fmt.Errorf("BuildDefaults needs an Openshift client to size builds")
+	}
+	return nil
+}
+
 func (a *buildDefaults) applyBuildDefaults(build *buildapi.Build) {
 	// Apply default env
 	buildEnv := getBuildEnv(build)
@@ -112,6 +138,8 @@ func getBuildEnv(build *buildapi.Build) *[]kapi.EnvVar {
 		return &build.Spec.Strategy.SourceStrategy.Env
 	case build.Spec.Strategy.CustomStrategy != nil:
 		return &build.Spec.Strategy.CustomStrategy.Env
+	case build.Spec.Strategy.PipelineStrategy != nil:
+		return &build.Spec.Strategy.PipelineStrategy.Env
 	}
 	return nil
 }
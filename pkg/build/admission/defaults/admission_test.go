@@ -2,12 +2,19 @@ package defaults
 
 import (
 	"testing"
+	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 
 	buildadmission "github.com/openshift/origin/pkg/build/admission"
 	defaultsapi "github.com/openshift/origin/pkg/build/admission/defaults/api"
 	u "github.com/openshift/origin/pkg/build/admission/testutil"
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildstrategy "github.com/openshift/origin/pkg/build/controller/strategy"
+	"github.com/openshift/origin/pkg/client/testclient"
+	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
 
 	_ "github.com/openshift/origin/pkg/api/install"
 )
@@ -85,3 +92,170 @@ func TestEnvDefaults(t *testing.T) {
 		t.Errorf("VAR2 not found")
 	}
 }
+
+func completedBuild(name string, age time.Duration, cpu, memory string) *buildapi.Build {
+	return &buildapi.Build{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:              name,
+			Namespace:         "default",
+			Labels:            map[string]string{buildapi.BuildConfigLabel: "app"},
+			CreationTimestamp: unversioned.NewTime(time.Now().Add(-age)),
+		},
+		Status: buildapi.BuildStatus{
+			Phase: buildapi.BuildPhaseComplete,
+			ResourceUsage: &buildapi.BuildResourceUsage{
+				CPU:    resource.MustParse(cpu),
+				Memory: resource.MustParse(memory),
+			},
+		},
+	}
+}
+
+func TestResourceAutoSizing(t *testing.T) {
+	history := &buildapi.BuildList{Items: []buildapi.Build{
+		*completedBuild("app-1", 3*time.Hour, "4", "4Gi"),
+		*completedBuild("app-2", 2*time.Hour, "500m", "1Gi"),
+		*completedBuild("app-3", time.Hour, "250m", "512Mi"),
+	}}
+	tests := []struct {
+		name      string
+		config    *defaultsapi.ResourceAutoSizingConfig
+		resources kapi.ResourceRequirements
+		expected  kapi.ResourceRequirements
+	}{
+		{
+			name:   "sized from the most recent builds",
+			config: &defaultsapi.ResourceAutoSizingConfig{BuildHistory: 2},
+			expected: kapi.ResourceRequirements{
+				Requests: kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("500m"), kapi.ResourceMemory: resource.MustParse("1Gi")},
+				Limits:   kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("750m"), kapi.ResourceMemory: resource.MustParse("1536Mi")},
+			},
+		},
+		{
+			name: "bounded",
+			config: &defaultsapi.ResourceAutoSizingConfig{
+				LimitPercent: 200,
+				Min:          kapi.ResourceList{kapi.ResourceMemory: resource.MustParse("8Gi")},
+				Max:          kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("2"), kapi.ResourceMemory: resource.MustParse("10Gi")},
+			},
+			expected: kapi.ResourceRequirements{
+				Requests: kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("2"), kapi.ResourceMemory: resource.MustParse("8Gi")},
+				Limits:   kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("2"), kapi.ResourceMemory: resource.MustParse("8Gi")},
+			},
+		},
+		{
+			name:      "resources set on the build are kept",
+			config:    &defaultsapi.ResourceAutoSizingConfig{BuildHistory: 1},
+			resources: kapi.ResourceRequirements{Limits: kapi.ResourceList{kapi.ResourceMemory: resource.MustParse("2Gi")}},
+			expected: kapi.ResourceRequirements{
+				Requests: kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("250m")},
+				Limits:   kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("375m"), kapi.ResourceMemory: resource.MustParse("2Gi")},
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		admitter := NewBuildDefaults(&defaultsapi.BuildDefaultsConfig{ResourceAutoSizing: tc.config})
+		admitter.(oadmission.WantsOpenshiftClient).SetOpenshiftClient(testclient.NewSimpleFake(history))
+		if err := admitter.(oadmission.Validator).Validate(); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+
+		build := u.Build().WithDockerStrategy().AsBuild()
+		build.Namespace = "default"
+		build.Labels = map[string]string{buildapi.BuildConfigLabel: "app"}
+		build.Spec.Resources = tc.resources
+		pod := u.Pod().WithBuild(t, build, "v1")
+		pod.Spec.Containers[0].Resources = tc.resources
+		if err := admitter.Admit(pod.ToAttributes()); err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		build, _, err := buildadmission.GetBuild(pod.ToAttributes())
+		if err != nil {
+			t.Fatalf("%s: unexpected error: %v", tc.name, err)
+		}
+		for _, resources := range []kapi.ResourceRequirements{build.Spec.Resources, pod.Spec.Containers[0].Resources} {
+			if !kapi.Semantic.DeepEqual(resources, tc.expected) {
+				t.Errorf("%s: expected resources %#v, got %#v", tc.name, tc.expected, resources)
+			}
+		}
+	}
+}
+
+func TestResourceAutoSizingOutOfMemory(t *testing.T) {
+	outOfMemory := completedBuild("app-2", time.Hour, "0", "1Gi")
+	outOfMemory.Status.Phase = buildapi.BuildPhaseFailed
+	outOfMemory.Status.ResourceUsage.OutOfMemory = true
+	failed := completedBuild("app-3", 30*time.Minute, "4", "4Gi")
+	failed.Status.Phase = buildapi.BuildPhaseFailed
+	history := &buildapi.BuildList{Items: []buildapi.Build{
+		*completedBuild("app-1", 2*time.Hour, "500m", "512Mi"),
+		*outOfMemory,
+		*failed,
+	}}
+
+	admitter := NewBuildDefaults(&defaultsapi.BuildDefaultsConfig{ResourceAutoSizing: &defaultsapi.ResourceAutoSizingConfig{}})
+	admitter.(oadmission.WantsOpenshiftClient).SetOpenshiftClient(testclient.NewSimpleFake(history))
+	build := u.Build().WithDockerStrategy().AsBuild()
+	build.Namespace = "default"
+	build.Labels = map[string]string{buildapi.BuildConfigLabel: "app"}
+	pod := u.Pod().WithBuild(t, build, "v1")
+	if err := admitter.Admit(pod.ToAttributes()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// the memory limit of the build which ran out of memory grows, the
+	// failed build is ignored
+	expected := kapi.ResourceRequirements{
+		Requests: kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("500m"), kapi.ResourceMemory: resource.MustParse("1Gi")},
+		Limits:   kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("750m"), kapi.ResourceMemory: resource.MustParse("1536Mi")},
+	}
+	if resources := pod.Spec.Containers[0].Resources; !kapi.Semantic.DeepEqual(resources, expected) {
+		t.Errorf("expected resources %#v, got %#v", expected, resources)
+	}
+}
+
+func TestResourceAutoSizingPipelineStage(t *testing.T) {
+	history := &buildapi.BuildList{Items: []buildapi.Build{
+		*completedBuild("app-1", time.Hour, "500m", "1Gi"),
+	}}
+	admitter := NewBuildDefaults(&defaultsapi.BuildDefaultsConfig{
+		Env:                []kapi.EnvVar{{Name: "FOO", Value: "bar"}},
+		ResourceAutoSizing: &defaultsapi.ResourceAutoSizingConfig{},
+	})
+	admitter.(oadmission.WantsOpenshiftClient).SetOpenshiftClient(testclient.NewSimpleFake(history))
+
+	build := u.Build().WithPipelineStrategy().AsBuild()
+	build.Namespace = "default"
+	build.Labels = map[string]string{buildapi.BuildConfigLabel: "app"}
+	strategy := &buildstrategy.PipelineBuildStrategy{Codec: kapi.Codecs.LegacyCodec(buildapi.SchemeGroupVersion)}
+	stagePod, err := strategy.CreateBuildPod(build)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	pod := (*u.TestPod)(stagePod)
+	if !buildadmission.IsBuildPod(pod.ToAttributes()) {
+		t.Fatalf("expected the stage pod to be a build pod")
+	}
+	if err := admitter.Admit(pod.ToAttributes()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := kapi.ResourceRequirements{
+		Requests: kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("500m"), kapi.ResourceMemory: resource.MustParse("1Gi")},
+		Limits:   kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("750m"), kapi.ResourceMemory: resource.MustParse("1536Mi")},
+	}
+	if resources := pod.Spec.Containers[0].Resources; !kapi.Semantic.DeepEqual(resources, expected) {
+		t.Errorf("expected resources %#v, got %#v", expected, resources)
+	}
+	if env := pod.GetBuild(t).Spec.Strategy.PipelineStrategy.Env; len(env) != 1 || env[0].Name != "FOO" {
+		t.Errorf("expected the default environment to be added to the pipeline strategy, got %v", env)
+	}
+}
+
+func TestResourceAutoSizingRequiresClient(t *testing.T) {
+	admitter := NewBuildDefaults(&defaultsapi.BuildDefaultsConfig{ResourceAutoSizing: &defaultsapi.ResourceAutoSizingConfig{}})
+	if err := admitter.(oadmission.Validator).Validate(); err == nil {
+		t.Errorf("expected an error without an Openshift client")
+	}
+}
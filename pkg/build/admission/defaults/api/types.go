@@ -18,4 +18,26 @@ type BuildDefaultsConfig struct {
 	// Env is a set of default environment variables that will be applied to the
 	// build if the specified variables do not exist on the build
 	Env []kapi.EnvVar
+
+	// ResourceAutoSizing, if set, sizes the CPU and memory of builds that do not
+	// set them from the resources used by recent builds of the same BuildConfig
+	ResourceAutoSizing *ResourceAutoSizingConfig
+}
+
+// ResourceAutoSizingConfig controls how the compute resources of builds are
+// computed from the peak resource usage of recent builds
+type ResourceAutoSizingConfig struct {
+	// BuildHistory is the number of recent completed builds of a BuildConfig
+	// whose resource usage is considered. Defaults to 5.
+	BuildHistory int
+
+	// LimitPercent is the limit set on a build as a percentage of the peak usage
+	// of the recent builds, which is used as the request. Defaults to 150.
+	LimitPercent int
+
+	// Min is the minimum CPU and memory requested for a build
+	Min kapi.ResourceList
+
+	// Max is the maximum CPU and memory requested for, or limiting, a build
+	Max kapi.ResourceList
 }
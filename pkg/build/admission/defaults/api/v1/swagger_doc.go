@@ -6,12 +6,25 @@ package v1
 // ==== DO NOT EDIT THIS FILE MANUALLY ====
 
 var map_BuildDefaultsConfig = map[string]string{
-	"":              "BuildDefaultsConfig controls the default information for Builds",
-	"gitHTTPProxy":  "GitHTTPProxy is the location of the HTTPProxy for Git source",
-	"gitHTTPSProxy": "GitHTTPSProxy is the location of the HTTPSProxy for Git source",
-	"env":           "Env is a set of default environment variables that will be applied to the build if the specified variables do not exist on the build",
+	"":                   "BuildDefaultsConfig controls the default information for Builds",
+	"gitHTTPProxy":       "GitHTTPProxy is the location of the HTTPProxy for Git source",
+	"gitHTTPSProxy":      "GitHTTPSProxy is the location of the HTTPSProxy for Git source",
+	"env":                "Env is a set of default environment variables that will be applied to the build if the specified variables do not exist on the build",
+	"resourceAutoSizing": "ResourceAutoSizing, if set, sizes the CPU and memory of builds that do not set them from the resources used by recent builds of the same BuildConfig",
 }
 
 func (BuildDefaultsConfig) SwaggerDoc() map[string]string {
 	return map_BuildDefaultsConfig
 }
+
+var map_ResourceAutoSizingConfig = map[string]string{
+	"":             "ResourceAutoSizingConfig controls how the compute resources of builds are computed from the peak resource usage of recent builds",
+	"buildHistory": "BuildHistory is the number of recent completed builds of a BuildConfig whose resource usage is considered. Defaults to 5.",
+	"limitPercent": "LimitPercent is the limit set on a build as a percentage of the peak usage of the recent builds, which is used as the request. Defaults to 150.",
+	"min":          "Min is the minimum CPU and memory requested for a build",
+	"max":          "Max is the maximum CPU and memory requested for, or limiting, a build",
+}
+
+func (ResourceAutoSizingConfig) SwaggerDoc() map[string]string {
+	return map_ResourceAutoSizingConfig
+}
@@ -1,6 +1,9 @@
 package validation
 
 import (
+	"fmt"
+
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	"github.com/openshift/origin/pkg/build/admission/defaults/api"
@@ -13,6 +16,41 @@ func ValidateBuildDefaultsConfig(config *api.BuildDefaultsConfig) field.ErrorLis
 	allErrs = append(allErrs, validateURL(config.GitHTTPProxy, field.NewPath("gitHTTPProxy"))...)
 	allErrs = append(allErrs, validateURL(config.GitHTTPSProxy, field.NewPath("gitHTTPSProxy"))...)
 	allErrs = append(allErrs, buildvalidation.ValidateStrategyEnv(config.Env, field.NewPath("env"))...)
+	if config.ResourceAutoSizing != nil {
+		allErrs = append(allErrs, validateResourceAutoSizing(config.ResourceAutoSizing, field.NewPath("resourceAutoSizing"))...)
+	}
+	return allErrs
+}
+
+func validateResourceAutoSizing(config *api.ResourceAutoSizingConfig, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if config.BuildHistory < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("buildHistory"), config.BuildHistory, "must be greater than or equal to 0"))
+	}
+	if config.LimitPercent != 0 && config.LimitPercent < 100 {
+		allErrs = append(allErrs, field.Invalid(path.Child("limitPercent"), config.LimitPercent, "must be greater than or equal to 100"))
+	}
+	allErrs = append(allErrs, validateResourceBounds(config.Min, path.Child("min"))...)
+	allErrs = append(allErrs, validateResourceBounds(config.Max, path.Child("max"))...)
+	for name, min := range config.Min {
+		if max, ok := config.Max[name]; ok && min.Cmp(max) > 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("min").Key(string(name)), min.String(), fmt.Sprintf("must be less than or equal to the max of %s", max.String())))
+		}
+	}
+	return allErrs
+}
+
+func validateResourceBounds(bounds kapi.ResourceList, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for name, quantity := range bounds {
+		if name != kapi.ResourceCPU && name != kapi.ResourceMemory {
+			allErrs = append(allErrs, field.NotSupported(path.Key(string(name)), name, []string{string(kapi.ResourceCPU), string(kapi.ResourceMemory)}))
+			continue
+		}
+		if quantity.MilliValue() < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Key(string(name)), quantity.String(), "must be greater than or equal to 0"))
+		}
+	}
 	return allErrs
 }
 
@@ -4,6 +4,7 @@ import (
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/util/validation/field"
 
 	defaultsapi "github.com/openshift/origin/pkg/build/admission/defaults/api"
@@ -97,6 +98,50 @@ func TestValidateBuildDefaultsConfig(t *testing.T) {
 			errField:    "env[0].valueFrom",
 			errType:     field.ErrorTypeInvalid,
 		},
+		// 6: valid resource auto-sizing
+		{
+			config: &defaultsapi.BuildDefaultsConfig{
+				ResourceAutoSizing: &defaultsapi.ResourceAutoSizingConfig{
+					BuildHistory: 3,
+					LimitPercent: 200,
+					Min:          kapi.ResourceList{kapi.ResourceMemory: resource.MustParse("128Mi")},
+					Max:          kapi.ResourceList{kapi.ResourceMemory: resource.MustParse("4Gi"), kapi.ResourceCPU: resource.MustParse("2")},
+				},
+			},
+			errExpected: false,
+		},
+		// 7: limit below the request
+		{
+			config: &defaultsapi.BuildDefaultsConfig{
+				ResourceAutoSizing: &defaultsapi.ResourceAutoSizingConfig{LimitPercent: 50},
+			},
+			errExpected: true,
+			errField:    "resourceAutoSizing.limitPercent",
+			errType:     field.ErrorTypeInvalid,
+		},
+		// 8: unsupported resource
+		{
+			config: &defaultsapi.BuildDefaultsConfig{
+				ResourceAutoSizing: &defaultsapi.ResourceAutoSizingConfig{
+					Max: kapi.ResourceList{kapi.ResourcePods: resource.MustParse("1")},
+				},
+			},
+			errExpected: true,
+			errField:    "resourceAutoSizing.max[pods]",
+			errType:     field.ErrorTypeNotSupported,
+		},
+		// 9: min greater than max
+		{
+			config: &defaultsapi.BuildDefaultsConfig{
+				ResourceAutoSizing: &defaultsapi.ResourceAutoSizingConfig{
+					Min: kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("2")},
+					Max: kapi.ResourceList{kapi.ResourceCPU: resource.MustParse("1")},
+				},
+			},
+			errExpected: true,
+			errField:    "resourceAutoSizing.min[cpu]",
+			errType:     field.ErrorTypeInvalid,
+		},
 	}
 
 	for i, tc := range tests {
@@ -0,0 +1,147 @@
+package defaults
+
+import (
+	"sort"
+
+	"github.com/golang/glog"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/labels"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildutil "github.com/openshift/origin/pkg/build/util"
+)
+
+const (
+	// defaultBuildHistory is the number of recent builds whose resource usage is
+	// considered when none is configured.
+	defaultBuildHistory = 5
+	// defaultLimitPercent is the limit of a build as a percentage of the peak
+	// usage of recent builds when none is configured.
+	defaultLimitPercent = 150
+)
+
+// applyResourceAutoSizing sets the CPU and memory requests and limits that a
+// build does not set from the peak resource usage of recent builds of the same
+// BuildConfig. The pod running the build gets the same resources.
+func (a *buildDefaults) applyResourceAutoSizing(build *buildapi.Build, pod *kapi.Pod) {
+	config := a.defaultsConfig.ResourceAutoSizing
+	configName := buildutil.ConfigNameForBuild(build)
+	if len(configName) == 0 {
+		return
+	}
+	history := config.BuildHistory
+	if history == 0 {
+		history = defaultBuildHistory
+	}
+	peak, err := a.peakResourceUsage(build.Namespace, configName, history)
+	if err != nil {
+		glog.V(2).Infof("Unable to size build %s/%s from the resource usage of recent builds: %v", build.Namespace, build.Name, err)
+		return
+	}
+	if peak == nil {
+		glog.V(5).Infof("No resource usage is recorded for recent builds of %s/%s", build.Namespace, configName)
+		return
+	}
+	limitPercent := config.LimitPercent
+	if limitPercent == 0 {
+		limitPercent = defaultLimitPercent
+	}
+
+	for name, usage := range map[kapi.ResourceName]resource.Quantity{kapi.ResourceCPU: peak.CPU, kapi.ResourceMemory: peak.Memory} {
+		if _, ok := build.Spec.Resources.Requests[name]; ok {
+			continue
+		}
+		if _, ok := build.Spec.Resources.Limits[name]; ok {
+			continue
+		}
+		request, limit, ok := sizeResource(usage, limitPercent, config.Min, config.Max, name)
+		if !ok {
+			continue
+		}
+		glog.V(5).Infof("Setting %s of build %s/%s to a request of %s and a limit of %s", name, build.Namespace, build.Name, request.String(), limit.String())
+		setResource(&build.Spec.Resources, name, request, limit)
+		for i := range pod.Spec.Containers {
+			setResource(&pod.Spec.Containers[i].Resources, name, request, limit)
+		}
+	}
+}
+
+// peakResourceUsage returns the highest CPU and memory used by the most recent
+// completed builds of the named BuildConfig, or nil if none recorded its usage.
+// Failed builds which ran out of memory are considered as well, their memory
+// limit counts as their usage so the limit of the next build grows.
+func (a *buildDefaults) peakResourceUsage(namespace, configName string, history int) (*buildapi.BuildResourceUsage, error) {
+	selector := labels.SelectorFromSet(labels.Set{buildapi.BuildConfigLabel: buildapi.LabelValue(configName)})
+	list, err := a.client.Builds(namespace).List(kapi.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return nil, err
+	}
+	builds := []buildapi.Build{}
+	for _, build := range list.Items {
+		if buildutil.ConfigNameForBuild(&build) != configName || build.Status.ResourceUsage == nil {
+			continue
+		}
+		if build.Status.Phase != buildapi.BuildPhaseComplete && !(build.Status.Phase == buildapi.BuildPhaseFailed && build.Status.ResourceUsage.OutOfMemory) {
+			continue
+		}
+		builds = append(builds, build)
+	}
+	if len(builds) == 0 {
+		return nil, nil
+	}
+	sort.Sort(sort.Reverse(buildapi.BuildSliceByCreationTimestamp(builds)))
+	if len(builds) > history {
+		builds = builds[:history]
+	}
+
+	peak := &buildapi.BuildResourceUsage{}
+	for _, build := range builds {
+		if build.Status.ResourceUsage.CPU.Cmp(peak.CPU) > 0 {
+			peak.CPU = build.Status.ResourceUsage.CPU
+		}
+		if build.Status.ResourceUsage.Memory.Cmp(peak.Memory) > 0 {
+			peak.Memory = build.Status.ResourceUsage.Memory
+		}
+	}
+	return peak, nil
+}
+
+// sizeResource returns the request and limit of the named resource for a peak
+// usage, bounded by min and max. It returns false when the resource should not
+// be set.
+func sizeResource(usage resource.Quantity, limitPercent int, min, max kapi.ResourceList, name kapi.ResourceName) (resource.Quantity, resource.Quantity, bool) {
+	request := usage.Copy()
+	if bound, ok := min[name]; ok && request.Cmp(bound) < 0 {
+		request = bound.Copy()
+	}
+	if bound, ok := max[name]; ok && request.Cmp(bound) > 0 {
+		request = bound.Copy()
+	}
+	if request.MilliValue() == 0 {
+		return resource.Quantity{}, resource.Quantity{}, false
+	}
+	// memory is sized in whole bytes
+	limit := resource.NewQuantity(usage.Value()*int64(limitPercent)/100, usage.Format)
+	if name == kapi.ResourceCPU {
+		limit = resource.NewMilliQuantity(usage.MilliValue()*int64(limitPercent)/100, usage.Format)
+	}
+	if bound, ok := max[name]; ok && limit.Cmp(bound) > 0 {
+		limit = bound.Copy()
+	}
+	if limit.Cmp(*request) < 0 {
+		limit = request.Copy()
+	}
+	return *request, *limit, true
+}
+
+func setResource(resources *kapi.ResourceRequirements, name kapi.ResourceName, request, limit resource.Quantity) {
+	if resources.Requests == nil {
+		resources.Requests = kapi.ResourceList{}
+	}
+	if resources.Limits == nil {
+		resources.Limits = kapi.ResourceList{}
+	}
+	resources.Requests[name] = request
+	resources.Limits[name] = limit
+}
@@ -3,7 +3,8 @@ Package defaults contains the BuildDefaults admission control plugin.
 
 The plugin allows setting default values for build setings like the git HTTP
 and HTTPS proxy URLs and additional environment variables for the build
-strategy
+strategy. It can also size the CPU and memory of builds that do not set them
+from the peak resource usage recorded on recent builds of the same BuildConfig.
 
 Configuration
 
@@ -18,5 +19,17 @@ Configuration is done via a BuildDefaultsConfig object:
    value: VALUE1
  - name: ENV_VAR2
    value: VALUE2
+ resourceAutoSizing:
+   buildHistory: 5
+   limitPercent: 150
+   min:
+     memory: 256Mi
+   max:
+     cpu: "2"
+     memory: 4Gi
+
+With resourceAutoSizing, a build requests the peak CPU and memory of the last
+buildHistory completed builds of its BuildConfig, and is limited to
+limitPercent of that peak. Requests and limits are kept between min and max.
 */
 package defaults
@@ -83,6 +83,14 @@ func (a *buildOverrides) applyOverrides(attributes admission.Attributes) error {
 		glog.V(5).Infof("Setting custom strategy ForcePull to true in build %s/%s", build.Namespace, build.Name)
 		build.Spec.Strategy.CustomStrategy.ForcePull = true
 	}
+	if build.Spec.Strategy.PipelineStrategy != nil {
+		err := applyForcePullToPod(attributes)
+		if err != nil {
+			return err
+		}
+		glog.V(5).Infof("Setting pipeline strategy ForcePull to true in build %s/%s", build.Namespace, build.Name)
+		build.Spec.Strategy.PipelineStrategy.ForcePull = true
+	}
 	return buildadmission.SetBuild(attributes, build, version)
 }
 
@@ -30,6 +30,10 @@ func TestBuildOverrideForcePull(t *testing.T) {
 			name:  "build - source",
 			build: u.Build().WithSourceStrategy().AsBuild(),
 		},
+		{
+			name:  "build - pipeline",
+			build: u.Build().WithPipelineStrategy().AsBuild(),
+		},
 	}
 
 	ops := []admission.Operation{admission.Create, admission.Update}
@@ -51,6 +55,13 @@ func TestBuildOverrideForcePull(t *testing.T) {
 				if pod.Spec.Containers[0].ImagePullPolicy != kapi.PullAlways {
 					t.Errorf("%s (%s): image pull policy is not PullAlways", test.name, op)
 				}
+			case strategy.PipelineStrategy != nil:
+				if strategy.PipelineStrategy.ForcePull == false {
+					t.Errorf("%s (%s): force pull was false", test.name, op)
+				}
+				if pod.Spec.Containers[0].ImagePullPolicy != kapi.PullAlways {
+					t.Errorf("%s (%s): image pull policy is not PullAlways", test.name, op)
+				}
 			case strategy.DockerStrategy != nil:
 				if strategy.DockerStrategy.ForcePull == false {
 					t.Errorf("%s (%s): force pull was false", test.name, op)
@@ -84,6 +84,8 @@ func resourceForStrategyType(strategy buildapi.BuildStrategy) (unversioned.Group
 		return buildapi.Resource(authorizationapi.SourceBuildResource), nil
 	case strategy.JenkinsPipelineStrategy != nil:
 		return buildapi.Resource(authorizationapi.JenkinsPipelineBuildResource), nil
+	case strategy.PipelineStrategy != nil:
+		return buildapi.Resource(authorizationapi.PipelineBuildResource), nil
 	default:
 		return unversioned.GroupResource{}, fmt.Errorf("unrecognized build strategy: %#v", strategy)
 	}
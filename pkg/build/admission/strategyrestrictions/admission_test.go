@@ -159,6 +159,15 @@ func TestBuildAdmission(t *testing.T) {
 			expectedResource: authorizationapi.JenkinsPipelineBuildResource,
 			expectAccept:     true,
 		},
+		{
+			name:             "denied pipeline build",
+			object:           testBuild(buildapi.BuildStrategy{PipelineStrategy: &buildapi.PipelineBuildStrategy{}}),
+			kind:             buildapi.Kind("Build"),
+			resource:         buildsResource,
+			reviewResponse:   reviewResponse(false, ""),
+			expectAccept:     false,
+			expectedResource: authorizationapi.PipelineBuildResource,
+		},
 	}
 
 	ops := []admission.Operation{admission.Create, admission.Update}
@@ -37,6 +37,14 @@ func (b *TestBuild) WithCustomStrategy() *TestBuild {
 	return b
 }
 
+func (b *TestBuild) WithPipelineStrategy() *TestBuild {
+	b.Spec.Strategy.DockerStrategy = nil
+	b.Spec.Strategy.PipelineStrategy = &buildapi.PipelineBuildStrategy{
+		Stages: []buildapi.PipelineStage{{Name: "test", Image: "builder/image"}},
+	}
+	return b
+}
+
 func (b *TestBuild) AsBuild() *buildapi.Build {
 	return (*buildapi.Build)(b)
 }
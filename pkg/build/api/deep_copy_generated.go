@@ -6,6 +6,7 @@ package api
 
 import (
 	api "k8s.io/kubernetes/pkg/api"
+	resource "k8s.io/kubernetes/pkg/api/resource"
 	unversioned "k8s.io/kubernetes/pkg/api/unversioned"
 	conversion "k8s.io/kubernetes/pkg/conversion"
 )
@@ -14,7 +15,10 @@ func init() {
 	if err := api.Scheme.AddGeneratedDeepCopyFuncs(
 		DeepCopy_api_BinaryBuildRequestOptions,
 		DeepCopy_api_BinaryBuildSource,
+		DeepCopy_api_BitbucketWebHookCause,
 		DeepCopy_api_Build,
+		DeepCopy_api_BuildCompletionCause,
+		DeepCopy_api_BuildCompletionTrigger,
 		DeepCopy_api_BuildConfig,
 		DeepCopy_api_BuildConfigList,
 		DeepCopy_api_BuildConfigSpec,
@@ -22,11 +26,17 @@ func init() {
 		DeepCopy_api_BuildList,
 		DeepCopy_api_BuildLog,
 		DeepCopy_api_BuildLogOptions,
+		DeepCopy_api_BuildMatrix,
+		DeepCopy_api_BuildMatrixEntry,
+		DeepCopy_api_BuildMatrixEnv,
+		DeepCopy_api_BuildMatrixImage,
 		DeepCopy_api_BuildOutput,
 		DeepCopy_api_BuildPostCommitSpec,
 		DeepCopy_api_BuildRequest,
+		DeepCopy_api_BuildResourceUsage,
 		DeepCopy_api_BuildSource,
 		DeepCopy_api_BuildSpec,
+		DeepCopy_api_BuildStageStatus,
 		DeepCopy_api_BuildStatus,
 		DeepCopy_api_BuildStrategy,
 		DeepCopy_api_BuildTriggerCause,
@@ -39,13 +49,18 @@ func init() {
 		DeepCopy_api_GitBuildSource,
 		DeepCopy_api_GitHubWebHookCause,
 		DeepCopy_api_GitInfo,
+		DeepCopy_api_GitLabWebHookCause,
 		DeepCopy_api_GitRefInfo,
 		DeepCopy_api_GitSourceRevision,
 		DeepCopy_api_ImageChangeCause,
 		DeepCopy_api_ImageChangeTrigger,
 		DeepCopy_api_ImageSource,
 		DeepCopy_api_ImageSourcePath,
+		DeepCopy_api_IncrementalCacheSource,
 		DeepCopy_api_JenkinsPipelineBuildStrategy,
+		DeepCopy_api_PipelineBuildStrategy,
+		DeepCopy_api_PipelineStage,
+		DeepCopy_api_PullRequestCause,
 		DeepCopy_api_SecretBuildSource,
 		DeepCopy_api_SecretSpec,
 		DeepCopy_api_SourceBuildStrategy,
@@ -80,6 +95,20 @@ func DeepCopy_api_BinaryBuildSource(in BinaryBuildSource, out *BinaryBuildSource
 	return nil
 }
 
+func DeepCopy_api_BitbucketWebHookCause(in BitbucketWebHookCause, out *BitbucketWebHookCause, c *conversion.Cloner) error {
+	if in.Revision != nil {
+		in, out := in.Revision, &out.Revision
+		*out = new(SourceRevision)
+		if err := DeepCopy_api_SourceRevision(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Revision = nil
+	}
+	out.Secret = in.Secret
+	return nil
+}
+
 func DeepCopy_api_Build(in Build, out *Build, c *conversion.Cloner) error {
 	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
 		return err
@@ -96,6 +125,28 @@ func DeepCopy_api_Build(in Build, out *Build, c *conversion.Cloner) error {
 	return nil
 }
 
+func DeepCopy_api_BuildCompletionCause(in BuildCompletionCause, out *BuildCompletionCause, c *conversion.Cloner) error {
+	out.BuildName = in.BuildName
+	if in.FromRef != nil {
+		in, out := in.FromRef, &out.FromRef
+		*out = new(api.ObjectReference)
+		if err := api.DeepCopy_api_ObjectReference(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.FromRef = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_BuildCompletionTrigger(in BuildCompletionTrigger, out *BuildCompletionTrigger, c *conversion.Cloner) error {
+	if err := api.DeepCopy_api_ObjectReference(in.From, &out.From, c); err != nil {
+		return err
+	}
+	out.LastTriggeredBuild = in.LastTriggeredBuild
+	return nil
+}
+
 func DeepCopy_api_BuildConfig(in BuildConfig, out *BuildConfig, c *conversion.Cloner) error {
 	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
 		return err
@@ -146,6 +197,15 @@ func DeepCopy_api_BuildConfigSpec(in BuildConfigSpec, out *BuildConfigSpec, c *c
 		out.Triggers = nil
 	}
 	out.RunPolicy = in.RunPolicy
+	if in.Matrix != nil {
+		in, out := in.Matrix, &out.Matrix
+		*out = new(BuildMatrix)
+		if err := DeepCopy_api_BuildMatrix(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Matrix = nil
+	}
 	if err := DeepCopy_api_CommonSpec(in.CommonSpec, &out.CommonSpec, c); err != nil {
 		return err
 	}
@@ -231,6 +291,64 @@ func DeepCopy_api_BuildLogOptions(in BuildLogOptions, out *BuildLogOptions, c *c
 	} else {
 		out.Version = nil
 	}
+	out.Stage = in.Stage
+	return nil
+}
+
+func DeepCopy_api_BuildMatrix(in BuildMatrix, out *BuildMatrix, c *conversion.Cloner) error {
+	if in.Images != nil {
+		in, out := in.Images, &out.Images
+		*out = make([]BuildMatrixImage, len(in))
+		for i := range in {
+			if err := DeepCopy_api_BuildMatrixImage(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Env != nil {
+		in, out := in.Env, &out.Env
+		*out = make([]BuildMatrixEnv, len(in))
+		for i := range in {
+			if err := DeepCopy_api_BuildMatrixEnv(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_BuildMatrixEntry(in BuildMatrixEntry, out *BuildMatrixEntry, c *conversion.Cloner) error {
+	out.Group = in.Group
+	out.Image = in.Image
+	out.Env = in.Env
+	return nil
+}
+
+func DeepCopy_api_BuildMatrixEnv(in BuildMatrixEnv, out *BuildMatrixEnv, c *conversion.Cloner) error {
+	out.Name = in.Name
+	if in.Env != nil {
+		in, out := in.Env, &out.Env
+		*out = make([]api.EnvVar, len(in))
+		for i := range in {
+			if err := api.DeepCopy_api_EnvVar(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_BuildMatrixImage(in BuildMatrixImage, out *BuildMatrixImage, c *conversion.Cloner) error {
+	out.Name = in.Name
+	if err := api.DeepCopy_api_ObjectReference(in.From, &out.From, c); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -347,6 +465,26 @@ func DeepCopy_api_BuildRequest(in BuildRequest, out *BuildRequest, c *conversion
 	} else {
 		out.TriggeredBy = nil
 	}
+	if in.Matrix != nil {
+		in, out := in.Matrix, &out.Matrix
+		*out = new(BuildMatrixEntry)
+		if err := DeepCopy_api_BuildMatrixEntry(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Matrix = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_BuildResourceUsage(in BuildResourceUsage, out *BuildResourceUsage, c *conversion.Cloner) error {
+	if err := resource.DeepCopy_resource_Quantity(in.CPU, &out.CPU, c); err != nil {
+		return err
+	}
+	if err := resource.DeepCopy_resource_Quantity(in.Memory, &out.Memory, c); err != nil {
+		return err
+	}
+	out.OutOfMemory = in.OutOfMemory
 	return nil
 }
 
@@ -429,6 +567,31 @@ func DeepCopy_api_BuildSpec(in BuildSpec, out *BuildSpec, c *conversion.Cloner)
 	return nil
 }
 
+func DeepCopy_api_BuildStageStatus(in BuildStageStatus, out *BuildStageStatus, c *conversion.Cloner) error {
+	out.Name = in.Name
+	out.Phase = in.Phase
+	out.PodName = in.PodName
+	if in.StartTimestamp != nil {
+		in, out := in.StartTimestamp, &out.StartTimestamp
+		*out = new(unversioned.Time)
+		if err := unversioned.DeepCopy_unversioned_Time(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.StartTimestamp = nil
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = new(unversioned.Time)
+		if err := unversioned.DeepCopy_unversioned_Time(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.CompletionTimestamp = nil
+	}
+	return nil
+}
+
 func DeepCopy_api_BuildStatus(in BuildStatus, out *BuildStatus, c *conversion.Cloner) error {
 	out.Phase = in.Phase
 	out.Cancelled = in.Cancelled
@@ -463,6 +626,26 @@ func DeepCopy_api_BuildStatus(in BuildStatus, out *BuildStatus, c *conversion.Cl
 	} else {
 		out.Config = nil
 	}
+	if in.Stages != nil {
+		in, out := in.Stages, &out.Stages
+		*out = make([]BuildStageStatus, len(in))
+		for i := range in {
+			if err := DeepCopy_api_BuildStageStatus(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Stages = nil
+	}
+	if in.ResourceUsage != nil {
+		in, out := in.ResourceUsage, &out.ResourceUsage
+		*out = new(BuildResourceUsage)
+		if err := DeepCopy_api_BuildResourceUsage(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceUsage = nil
+	}
 	return nil
 }
 
@@ -503,6 +686,15 @@ func DeepCopy_api_BuildStrategy(in BuildStrategy, out *BuildStrategy, c *convers
 	} else {
 		out.JenkinsPipelineStrategy = nil
 	}
+	if in.PipelineStrategy != nil {
+		in, out := in.PipelineStrategy, &out.PipelineStrategy
+		*out = new(PipelineBuildStrategy)
+		if err := DeepCopy_api_PipelineBuildStrategy(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.PipelineStrategy = nil
+	}
 	return nil
 }
 
@@ -526,6 +718,24 @@ func DeepCopy_api_BuildTriggerCause(in BuildTriggerCause, out *BuildTriggerCause
 	} else {
 		out.GitHubWebHook = nil
 	}
+	if in.GitLabWebHook != nil {
+		in, out := in.GitLabWebHook, &out.GitLabWebHook
+		*out = new(GitLabWebHookCause)
+		if err := DeepCopy_api_GitLabWebHookCause(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.GitLabWebHook = nil
+	}
+	if in.BitbucketWebHook != nil {
+		in, out := in.BitbucketWebHook, &out.BitbucketWebHook
+		*out = new(BitbucketWebHookCause)
+		if err := DeepCopy_api_BitbucketWebHookCause(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.BitbucketWebHook = nil
+	}
 	if in.ImageChangeBuild != nil {
 		in, out := in.ImageChangeBuild, &out.ImageChangeBuild
 		*out = new(ImageChangeCause)
@@ -535,6 +745,15 @@ func DeepCopy_api_BuildTriggerCause(in BuildTriggerCause, out *BuildTriggerCause
 	} else {
 		out.ImageChangeBuild = nil
 	}
+	if in.BuildCompletion != nil {
+		in, out := in.BuildCompletion, &out.BuildCompletion
+		*out = new(BuildCompletionCause)
+		if err := DeepCopy_api_BuildCompletionCause(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.BuildCompletion = nil
+	}
 	return nil
 }
 
@@ -558,6 +777,24 @@ func DeepCopy_api_BuildTriggerPolicy(in BuildTriggerPolicy, out *BuildTriggerPol
 	} else {
 		out.GenericWebHook = nil
 	}
+	if in.GitLabWebHook != nil {
+		in, out := in.GitLabWebHook, &out.GitLabWebHook
+		*out = new(WebHookTrigger)
+		if err := DeepCopy_api_WebHookTrigger(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.GitLabWebHook = nil
+	}
+	if in.BitbucketWebHook != nil {
+		in, out := in.BitbucketWebHook, &out.BitbucketWebHook
+		*out = new(WebHookTrigger)
+		if err := DeepCopy_api_WebHookTrigger(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.BitbucketWebHook = nil
+	}
 	if in.ImageChange != nil {
 		in, out := in.ImageChange, &out.ImageChange
 		*out = new(ImageChangeTrigger)
@@ -567,6 +804,15 @@ func DeepCopy_api_BuildTriggerPolicy(in BuildTriggerPolicy, out *BuildTriggerPol
 	} else {
 		out.ImageChange = nil
 	}
+	if in.BuildCompletion != nil {
+		in, out := in.BuildCompletion, &out.BuildCompletion
+		*out = new(BuildCompletionTrigger)
+		if err := DeepCopy_api_BuildCompletionTrigger(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.BuildCompletion = nil
+	}
 	return nil
 }
 
@@ -752,6 +998,15 @@ func DeepCopy_api_GitHubWebHookCause(in GitHubWebHookCause, out *GitHubWebHookCa
 		out.Revision = nil
 	}
 	out.Secret = in.Secret
+	if in.PullRequest != nil {
+		in, out := in.PullRequest, &out.PullRequest
+		*out = new(PullRequestCause)
+		if err := DeepCopy_api_PullRequestCause(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.PullRequest = nil
+	}
 	return nil
 }
 
@@ -773,6 +1028,27 @@ func DeepCopy_api_GitInfo(in GitInfo, out *GitInfo, c *conversion.Cloner) error
 	} else {
 		out.Refs = nil
 	}
+	if in.ChangedPaths != nil {
+		in, out := in.ChangedPaths, &out.ChangedPaths
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.ChangedPaths = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_GitLabWebHookCause(in GitLabWebHookCause, out *GitLabWebHookCause, c *conversion.Cloner) error {
+	if in.Revision != nil {
+		in, out := in.Revision, &out.Revision
+		*out = new(SourceRevision)
+		if err := DeepCopy_api_SourceRevision(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Revision = nil
+	}
+	out.Secret = in.Secret
 	return nil
 }
 
@@ -859,12 +1135,93 @@ func DeepCopy_api_ImageSourcePath(in ImageSourcePath, out *ImageSourcePath, c *c
 	return nil
 }
 
+func DeepCopy_api_IncrementalCacheSource(in IncrementalCacheSource, out *IncrementalCacheSource, c *conversion.Cloner) error {
+	if in.PersistentVolumeClaim != nil {
+		in, out := in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(api.LocalObjectReference)
+		if err := api.DeepCopy_api_LocalObjectReference(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.PersistentVolumeClaim = nil
+	}
+	if in.Image != nil {
+		in, out := in.Image, &out.Image
+		*out = new(api.ObjectReference)
+		if err := api.DeepCopy_api_ObjectReference(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Image = nil
+	}
+	return nil
+}
+
 func DeepCopy_api_JenkinsPipelineBuildStrategy(in JenkinsPipelineBuildStrategy, out *JenkinsPipelineBuildStrategy, c *conversion.Cloner) error {
 	out.JenkinsfilePath = in.JenkinsfilePath
 	out.Jenkinsfile = in.Jenkinsfile
 	return nil
 }
 
+func DeepCopy_api_PipelineBuildStrategy(in PipelineBuildStrategy, out *PipelineBuildStrategy, c *conversion.Cloner) error {
+	if in.Stages != nil {
+		in, out := in.Stages, &out.Stages
+		*out = make([]PipelineStage, len(in))
+		for i := range in {
+			if err := DeepCopy_api_PipelineStage(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Stages = nil
+	}
+	if in.Env != nil {
+		in, out := in.Env, &out.Env
+		*out = make([]api.EnvVar, len(in))
+		for i := range in {
+			if err := api.DeepCopy_api_EnvVar(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	out.ForcePull = in.ForcePull
+	return nil
+}
+
+func DeepCopy_api_PipelineStage(in PipelineStage, out *PipelineStage, c *conversion.Cloner) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	if in.Command != nil {
+		in, out := in.Command, &out.Command
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.Command = nil
+	}
+	if in.Env != nil {
+		in, out := in.Env, &out.Env
+		*out = make([]api.EnvVar, len(in))
+		for i := range in {
+			if err := api.DeepCopy_api_EnvVar(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_PullRequestCause(in PullRequestCause, out *PullRequestCause, c *conversion.Cloner) error {
+	out.Number = in.Number
+	out.Title = in.Title
+	out.HeadRef = in.HeadRef
+	out.Fork = in.Fork
+	return nil
+}
+
 func DeepCopy_api_SecretBuildSource(in SecretBuildSource, out *SecretBuildSource, c *conversion.Cloner) error {
 	if err := api.DeepCopy_api_LocalObjectReference(in.Secret, &out.Secret, c); err != nil {
 		return err
@@ -907,6 +1264,15 @@ func DeepCopy_api_SourceBuildStrategy(in SourceBuildStrategy, out *SourceBuildSt
 	}
 	out.Scripts = in.Scripts
 	out.Incremental = in.Incremental
+	if in.IncrementalCache != nil {
+		in, out := in.IncrementalCache, &out.IncrementalCache
+		*out = new(IncrementalCacheSource)
+		if err := DeepCopy_api_IncrementalCacheSource(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.IncrementalCache = nil
+	}
 	out.ForcePull = in.ForcePull
 	return nil
 }
@@ -933,5 +1299,29 @@ func DeepCopy_api_SourceRevision(in SourceRevision, out *SourceRevision, c *conv
 func DeepCopy_api_WebHookTrigger(in WebHookTrigger, out *WebHookTrigger, c *conversion.Cloner) error {
 	out.Secret = in.Secret
 	out.AllowEnv = in.AllowEnv
+	out.RequireSignature = in.RequireSignature
+	if in.IncludePaths != nil {
+		in, out := in.IncludePaths, &out.IncludePaths
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.IncludePaths = nil
+	}
+	if in.ExcludePaths != nil {
+		in, out := in.ExcludePaths, &out.ExcludePaths
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.ExcludePaths = nil
+	}
+	out.BuildPullRequests = in.BuildPullRequests
+	if in.PullRequestBuildsLimit != nil {
+		in, out := in.PullRequestBuildsLimit, &out.PullRequestBuildsLimit
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.PullRequestBuildsLimit = nil
+	}
+	out.ForkPullRequestSecrets = in.ForkPullRequestSecrets
 	return nil
 }
@@ -4,6 +4,7 @@ import (
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/util/sets"
 )
@@ -25,6 +26,18 @@ const (
 	BuildLabel = "openshift.io/build.name"
 	// BuildRunPolicyLabel represents the start policy used to to start the build.
 	BuildRunPolicyLabel = "openshift.io/build.start-policy"
+	// BuildPullRequestLabel is the key of a Build label whose value is the number of the
+	// pull request the Build was started for.
+	BuildPullRequestLabel = "openshift.io/build.pull-request"
+	// BuildMatrixLabel is the key of a Build label whose value identifies the group of builds
+	// instantiated together from the build matrix of a BuildConfig.
+	BuildMatrixLabel = "openshift.io/build.matrix"
+	// BuildMatrixEntryAnnotation is an annotation whose value is the name of the build matrix
+	// entry the Build was instantiated for.
+	BuildMatrixEntryAnnotation = "openshift.io/build.matrix-entry"
+	// BuildStageAnnotation is an annotation that identifies the stage of a Pipeline build
+	// that a Pod runs.
+	BuildStageAnnotation = "openshift.io/build.stage"
 	// DefaultDockerLabelNamespace is the key of a Build label, whose values are build metadata.
 	DefaultDockerLabelNamespace = "io.openshift."
 	// OriginVersion is an environment variable key that indicates the version of origin that
@@ -123,9 +136,21 @@ type BuildTriggerCause struct {
 	// build.
 	GitHubWebHook *GitHubWebHookCause
 
+	// GitLabWebHook represents data for a GitLab webhook that fired a specific
+	// build.
+	GitLabWebHook *GitLabWebHookCause
+
+	// BitbucketWebHook represents data for a Bitbucket webhook that fired a
+	// specific build.
+	BitbucketWebHook *BitbucketWebHookCause
+
 	// ImageChangeBuild stores information about an imagechange event that
 	// triggered a new build.
 	ImageChangeBuild *ImageChangeCause
+
+	// BuildCompletion stores information about the completed build of another
+	// BuildConfig that triggered a new build.
+	BuildCompletion *BuildCompletionCause
 }
 
 // GenericWebHookCause holds information about a generic WebHook that
@@ -147,6 +172,46 @@ type GitHubWebHookCause struct {
 
 	// Secret is the obfuscated webhook secret that triggered a build.
 	Secret string
+
+	// PullRequest identifies the pull request the build was started for. It is
+	// only set when the build was triggered by a pull request event.
+	PullRequest *PullRequestCause
+}
+
+// PullRequestCause identifies the pull request that triggered a build.
+type PullRequestCause struct {
+	// Number is the number of the pull request.
+	Number int64
+
+	// Title is the title of the pull request.
+	Title string
+
+	// HeadRef is the branch the pull request was opened from.
+	HeadRef string
+
+	// Fork is true if the pull request was opened from another repository than
+	// the one it is merged into.
+	Fork bool
+}
+
+// GitLabWebHookCause has information about a GitLab webhook that triggered a
+// build.
+type GitLabWebHookCause struct {
+	// Revision is the git source revision information of the trigger.
+	Revision *SourceRevision
+
+	// Secret is the obfuscated webhook secret that triggered a build.
+	Secret string
+}
+
+// BitbucketWebHookCause has information about a Bitbucket webhook that
+// triggered a build.
+type BitbucketWebHookCause struct {
+	// Revision is the git source revision information of the trigger.
+	Revision *SourceRevision
+
+	// Secret is the obfuscated webhook secret that triggered a build.
+	Secret string
 }
 
 // ImageChangeCause contains information about the image that triggered a
@@ -160,6 +225,16 @@ type ImageChangeCause struct {
 	FromRef *kapi.ObjectReference
 }
 
+// BuildCompletionCause holds information about the completed build of another
+// BuildConfig that triggered a build.
+type BuildCompletionCause struct {
+	// BuildName is the name of the build whose completion triggered a new build.
+	BuildName string
+
+	// FromRef is the reference to the BuildConfig of the completed build.
+	FromRef *kapi.ObjectReference
+}
+
 // BuildStatus contains the status of a build
 type BuildStatus struct {
 	// Phase is the point in the build lifecycle.
@@ -196,6 +271,47 @@ type BuildStatus struct {
 
 	// Config is an ObjectReference to the BuildConfig this Build is based on.
 	Config *kapi.ObjectReference
+
+	// Stages holds the status of the stages of a Pipeline build that have started,
+	// in the order they ran.
+	Stages []BuildStageStatus
+
+	// ResourceUsage is the peak usage of compute resources by the build and the
+	// containers it ran, recorded when the build completed.
+	ResourceUsage *BuildResourceUsage
+}
+
+// BuildResourceUsage describes the peak usage of compute resources by a build.
+type BuildResourceUsage struct {
+	// CPU is the highest CPU usage of the build, sampled while the build ran.
+	CPU resource.Quantity
+
+	// Memory is the highest memory usage of the build.
+	Memory resource.Quantity
+
+	// OutOfMemory is true if the build ran out of memory, in which case Memory
+	// is the memory limit the build ran into.
+	OutOfMemory bool
+}
+
+// BuildStageStatus describes the status of a stage of a Pipeline build.
+type BuildStageStatus struct {
+	// Name is the name of the stage.
+	Name string
+
+	// Phase is the point in the stage lifecycle.
+	Phase BuildPhase
+
+	// PodName is the name of the pod running the stage.
+	PodName string
+
+	// StartTimestamp is a timestamp representing the server time when the stage
+	// started running.
+	StartTimestamp *unversioned.Time
+
+	// CompletionTimestamp is a timestamp representing the server time when the stage
+	// finished, whether it failed or succeeded.
+	CompletionTimestamp *unversioned.Time
 }
 
 // BuildPhase represents the status of a build at a point in time.
@@ -248,6 +364,10 @@ const (
 	// output is an invalid reference.
 	StatusReasonInvalidOutputReference = "InvalidOutputReference"
 
+	// StatusReasonInvalidIncrementalCacheReference is an error condition when
+	// the incremental cache image of a Source build is an invalid reference.
+	StatusReasonInvalidIncrementalCacheReference = "InvalidIncrementalCacheReference"
+
 	// StatusReasonCancelBuildFailed is an error condition when cancelling a build
 	// fails.
 	StatusReasonCancelBuildFailed = "CancelBuildFailed"
@@ -308,7 +428,8 @@ type BuildSource struct {
 // ImageSource describes an image that is used as source for the build
 type ImageSource struct {
 	// From is a reference to an ImageStreamTag, ImageStreamImage, or DockerImage to
-	// copy source from.
+	// copy source from. It can also be a reference to a BuildConfig in the same
+	// namespace, in which case the output image of its latest successful build is used.
 	From kapi.ObjectReference
 
 	// Paths is a list of source and destination paths to copy from the image.
@@ -419,6 +540,10 @@ type BuildStrategy struct {
 	// JenkinsPipelineStrategy holds the parameters to the Jenkins Pipeline build strategy.
 	// This strategy is experimental.
 	JenkinsPipelineStrategy *JenkinsPipelineBuildStrategy
+
+	// PipelineStrategy holds the parameters to the Pipeline build strategy, which runs
+	// the stages of a pipeline defined in the build without a Jenkins server.
+	PipelineStrategy *PipelineBuildStrategy
 }
 
 // BuildStrategyType describes a particular way of performing a build.
@@ -507,10 +632,30 @@ type SourceBuildStrategy struct {
 	// Incremental flag forces the Source build to do incremental builds if true.
 	Incremental bool
 
+	// IncrementalCache is where the artifacts of incremental builds are kept
+	// between builds. When unset, artifacts are restored from the image pushed
+	// by the previous build. Requires Incremental to be true.
+	IncrementalCache *IncrementalCacheSource
+
 	// ForcePull describes if the builder should pull the images from registry prior to building.
 	ForcePull bool
 }
 
+// IncrementalCacheSource describes a persistent store for the artifacts saved
+// by incremental Source builds. Exactly one of the fields must be set.
+type IncrementalCacheSource struct {
+	// PersistentVolumeClaim is a claim in the namespace of the build that is
+	// mounted into the build pod. The artifacts of the last successful build
+	// are extracted into it and restored from it without pulling any image.
+	PersistentVolumeClaim *kapi.LocalObjectReference
+
+	// Image is a reference to an ImageStreamTag or DockerImage that the built
+	// image is pushed to after every successful build, and that artifacts are
+	// restored from on the next build. It is pushed with the push secret of
+	// the build output.
+	Image *kapi.ObjectReference
+}
+
 // JenkinsPipelineStrategy holds parameters specific to a Jenkins Pipeline build.
 // This strategy is experimental.
 type JenkinsPipelineBuildStrategy struct {
@@ -523,6 +668,37 @@ type JenkinsPipelineBuildStrategy struct {
 	Jenkinsfile string
 }
 
+// PipelineBuildStrategy holds parameters specific to a Pipeline build. The stages
+// of the pipeline run one after the other, each in its own pod, and the build
+// fails as soon as one of them fails.
+type PipelineBuildStrategy struct {
+	// Stages are the steps of the pipeline, in the order they run.
+	Stages []PipelineStage
+
+	// Env contains additional environment variables passed to the container of every stage.
+	Env []kapi.EnvVar
+
+	// ForcePull describes if the stage images should always be pulled before a stage runs.
+	ForcePull bool
+}
+
+// PipelineStage is a step of a Pipeline build, run as a container.
+type PipelineStage struct {
+	// Name identifies the stage within the pipeline.
+	Name string
+
+	// Image is the Docker image the stage runs.
+	Image string
+
+	// Command is the command run in the stage container. When not specified the
+	// entrypoint of the image is run.
+	Command []string
+
+	// Env contains environment variables passed to the stage container in addition to
+	// the ones of the strategy.
+	Env []kapi.EnvVar
+}
+
 // A BuildPostCommitSpec holds a build post commit hook specification. The hook
 // executes a command in a temporary container running the build output image,
 // immediately after the last layer of the image is committed and before the
@@ -651,10 +827,48 @@ type BuildConfigSpec struct {
 	// This is optional, if not specified we default to "Serial".
 	RunPolicy BuildRunPolicy
 
+	// Matrix declares the builder images and environment variable sets the
+	// BuildConfig is built with when it is instantiated as a build matrix.
+	Matrix *BuildMatrix
+
 	// CommonSpec is the desired build specification
 	CommonSpec
 }
 
+// BuildMatrix declares the builder images and environment variable sets a
+// BuildConfig is built with when it is instantiated as a matrix. One build is
+// created for every combination of an image and an environment variable set.
+type BuildMatrix struct {
+	// Images is the list of builder images replacing the image of the strategy.
+	Images []BuildMatrixImage
+
+	// Env is the list of environment variable sets added to the environment
+	// of the strategy.
+	Env []BuildMatrixEnv
+}
+
+// BuildMatrixImage is a builder image of a build matrix.
+type BuildMatrixImage struct {
+	// Name identifies the image in the matrix and is appended to the output
+	// tag of the builds using it. It must be unique among the images and
+	// environment variable sets of the matrix.
+	Name string
+
+	// From is the builder image to build with.
+	From kapi.ObjectReference
+}
+
+// BuildMatrixEnv is an environment variable set of a build matrix.
+type BuildMatrixEnv struct {
+	// Name identifies the set in the matrix and is appended to the output tag
+	// of the builds using it. It must be unique among the images and
+	// environment variable sets of the matrix.
+	Name string
+
+	// Env is the list of environment variables to build with.
+	Env []kapi.EnvVar
+}
+
 // BuildRunPolicy defines the behaviour of how the new builds are executed
 // from the existing build configuration.
 type BuildRunPolicy string
@@ -688,6 +902,42 @@ type WebHookTrigger struct {
 	// AllowEnv determines whether the webhook can set environment variables; can only
 	// be set to true for GenericWebHook
 	AllowEnv bool
+
+	// RequireSignature determines whether the webhook payload must be signed
+	// with an HMAC of the request body keyed by Secret, as sent by GitHub in the
+	// X-Hub-Signature header; can only be set to true for GitHubWebHook
+	RequireSignature bool
+
+	// IncludePaths is a list of glob patterns for the paths of the source
+	// repository a build depends on. When set, the webhook only starts a build
+	// if a path changed by the push matches one of them. A pattern that matches
+	// a directory matches every path below it. Can only be set for
+	// GitHubWebHook and GenericWebHook. Pushes whose changed paths are unknown,
+	// such as GitHub pushes of 20 commits or more, always start a build
+	IncludePaths []string
+
+	// ExcludePaths is a list of glob patterns for paths of the source
+	// repository whose changes never start a build, even when matched by
+	// IncludePaths. Can only be set for GitHubWebHook and GenericWebHook
+	ExcludePaths []string
+
+	// BuildPullRequests determines whether the webhook also starts builds for
+	// pull requests opened or synchronized against the source ref; can only be
+	// set to true for GitHubWebHook. The images built for pull requests are
+	// pushed to the output tag suffixed with -pr-<number>
+	BuildPullRequests bool
+
+	// PullRequestBuildsLimit is the maximum number of pull request builds kept
+	// for the BuildConfig. When exceeded, the oldest completed pull request
+	// builds are deleted. Pull request builds are not pruned if unset.
+	PullRequestBuildsLimit *int64
+
+	// ForkPullRequestSecrets determines whether the builds of pull requests
+	// opened from forks of the repository get the secrets of the BuildConfig and
+	// the pull secrets of its service account, and use its incremental build
+	// cache. Without the push secret, those builds don't push their image. It
+	// can only be set to true when BuildPullRequests is true.
+	ForkPullRequestSecrets bool
 }
 
 // ImageChangeTrigger allows builds to be triggered when an ImageStream changes
@@ -703,6 +953,18 @@ type ImageChangeTrigger struct {
 	From *kapi.ObjectReference
 }
 
+// BuildCompletionTrigger allows builds to be triggered when a build of another
+// BuildConfig completes successfully.
+type BuildCompletionTrigger struct {
+	// From is a reference to the BuildConfig in the same namespace whose successful
+	// builds trigger a build.
+	From kapi.ObjectReference
+
+	// LastTriggeredBuild is used internally by the BuildCompletionController to
+	// save the name of the last build that triggered a build.
+	LastTriggeredBuild string
+}
+
 // BuildTriggerPolicy describes a policy for a single trigger that results in a new Build.
 type BuildTriggerPolicy struct {
 	// Type is the type of build trigger
@@ -714,8 +976,18 @@ type BuildTriggerPolicy struct {
 	// GenericWebHook contains the parameters for a Generic webhook type of trigger
 	GenericWebHook *WebHookTrigger
 
+	// GitLabWebHook contains the parameters for a GitLab webhook type of trigger
+	GitLabWebHook *WebHookTrigger
+
+	// BitbucketWebHook contains the parameters for a Bitbucket webhook type of
+	// trigger
+	BitbucketWebHook *WebHookTrigger
+
 	// ImageChange contains parameters for an ImageChange type of trigger
 	ImageChange *ImageChangeTrigger
+
+	// BuildCompletion contains parameters for a BuildCompletion type of trigger
+	BuildCompletion *BuildCompletionTrigger
 }
 
 // BuildTriggerType refers to a specific BuildTriggerPolicy implementation.
@@ -725,8 +997,11 @@ type BuildTriggerType string
 var KnownTriggerTypes = sets.NewString(
 	string(GitHubWebHookBuildTriggerType),
 	string(GenericWebHookBuildTriggerType),
+	string(GitLabWebHookBuildTriggerType),
+	string(BitbucketWebHookBuildTriggerType),
 	string(ImageChangeBuildTriggerType),
 	string(ConfigChangeBuildTriggerType),
+	string(BuildCompletionBuildTriggerType),
 )
 
 const (
@@ -740,6 +1015,14 @@ const (
 	GenericWebHookBuildTriggerType           BuildTriggerType = "Generic"
 	GenericWebHookBuildTriggerTypeDeprecated BuildTriggerType = "generic"
 
+	// GitLabWebHookBuildTriggerType represents a trigger that launches builds on
+	// GitLab webhook invocations
+	GitLabWebHookBuildTriggerType BuildTriggerType = "GitLab"
+
+	// BitbucketWebHookBuildTriggerType represents a trigger that launches builds on
+	// Bitbucket webhook invocations
+	BitbucketWebHookBuildTriggerType BuildTriggerType = "Bitbucket"
+
 	// ImageChangeBuildTriggerType represents a trigger that launches builds on
 	// availability of a new version of an image
 	ImageChangeBuildTriggerType           BuildTriggerType = "ImageChange"
@@ -748,6 +1031,10 @@ const (
 	// ConfigChangeBuildTriggerType will trigger a build on an initial build config creation
 	// WARNING: In the future the behavior will change to trigger a build on any config change
 	ConfigChangeBuildTriggerType BuildTriggerType = "ConfigChange"
+
+	// BuildCompletionBuildTriggerType represents a trigger that launches builds
+	// when a build of another BuildConfig completes successfully
+	BuildCompletionBuildTriggerType BuildTriggerType = "BuildCompletion"
 )
 
 // BuildList is a collection of Builds.
@@ -786,6 +1073,10 @@ type GitInfo struct {
 	// when used from a post-receive hook. This field is optional and is
 	// used when sending multiple refs
 	Refs []GitRefInfo
+
+	// ChangedPaths is the list of paths changed by the commits being pushed.
+	// It is checked against the path filters of the webhook trigger, if any.
+	ChangedPaths []string
 }
 
 // GitRefInfo is a single ref
@@ -829,6 +1120,24 @@ type BuildRequest struct {
 	// TriggeredBy describes which triggers started the most recent update to the
 	// buildconfig and contains information about those triggers.
 	TriggeredBy []BuildTriggerCause
+
+	// Matrix (optional) selects the entry of the build matrix of the BuildConfig
+	// the build is instantiated for.
+	Matrix *BuildMatrixEntry
+}
+
+// BuildMatrixEntry selects a combination of the images and environment
+// variable sets of a build matrix.
+type BuildMatrixEntry struct {
+	// Group identifies the set of builds instantiated together from the build
+	// matrix.
+	Group string
+
+	// Image is the name of the matrix image to build with.
+	Image string
+
+	// Env is the name of the matrix environment variable set to build with.
+	Env string
 }
 
 type BinaryBuildRequestOptions struct {
@@ -896,6 +1205,10 @@ type BuildLogOptions struct {
 
 	// Version of the build for which to view logs.
 	Version *int64
+
+	// Stage is the name of the stage of a Pipeline build for which to stream logs. Defaults
+	// to the latest stage that started.
+	Stage string
 }
 
 // SecretSpec specifies a secret to be included in a build pod and its corresponding mount point
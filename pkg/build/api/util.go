@@ -10,13 +10,36 @@ import (
 const (
 	// BuildPodSuffix is the suffix used to append to a build pod name given a build name
 	BuildPodSuffix = "build"
+	// BuildStagePodSuffix is the suffix used to append to the name of the pod running a stage
+	// of a Pipeline build, given the build and stage names
+	BuildStagePodSuffix = "stage"
 )
 
-// GetBuildPodName returns name of the build pod.
+// GetBuildPodName returns name of the build pod. For Pipeline builds this is the pod
+// running the latest stage that started.
 func GetBuildPodName(build *Build) string {
+	if stages := build.Status.Stages; len(stages) > 0 {
+		return stages[len(stages)-1].PodName
+	}
 	return namer.GetPodName(build.Name, BuildPodSuffix)
 }
 
+// GetBuildStagePodName returns the name of the pod running the named stage of a Pipeline build.
+func GetBuildStagePodName(build *Build, stage string) string {
+	return namer.GetPodName(build.Name, stage+"-"+BuildStagePodSuffix)
+}
+
+// GetBuildStageStatus returns the status of the named stage of a Pipeline build, or nil
+// if the stage has not started.
+func GetBuildStageStatus(build *Build, stage string) *BuildStageStatus {
+	for i := range build.Status.Stages {
+		if build.Status.Stages[i].Name == stage {
+			return &build.Status.Stages[i]
+		}
+	}
+	return nil
+}
+
 func StrategyType(strategy BuildStrategy) string {
 	switch {
 	case strategy.DockerStrategy != nil:
@@ -27,6 +50,8 @@ func StrategyType(strategy BuildStrategy) string {
 		return "Source"
 	case strategy.JenkinsPipelineStrategy != nil:
 		return "JenkinsPipeline"
+	case strategy.PipelineStrategy != nil:
+		return "Pipeline"
 	}
 	return ""
 }
@@ -144,6 +144,8 @@ func Convert_api_BuildStrategy_To_v1_BuildStrategy(in *newer.BuildStrategy, out
 		out.Type = CustomBuildStrategyType
 	case in.JenkinsPipelineStrategy != nil:
 		out.Type = JenkinsPipelineBuildStrategyType
+	case in.PipelineStrategy != nil:
+		out.Type = PipelineBuildStrategyType
 	default:
 		out.Type = ""
 	}
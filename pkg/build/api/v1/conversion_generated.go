@@ -18,8 +18,14 @@ func init() {
 		Convert_api_BinaryBuildRequestOptions_To_v1_BinaryBuildRequestOptions,
 		Convert_v1_BinaryBuildSource_To_api_BinaryBuildSource,
 		Convert_api_BinaryBuildSource_To_v1_BinaryBuildSource,
+		Convert_v1_BitbucketWebHookCause_To_api_BitbucketWebHookCause,
+		Convert_api_BitbucketWebHookCause_To_v1_BitbucketWebHookCause,
 		Convert_v1_Build_To_api_Build,
 		Convert_api_Build_To_v1_Build,
+		Convert_v1_BuildCompletionCause_To_api_BuildCompletionCause,
+		Convert_api_BuildCompletionCause_To_v1_BuildCompletionCause,
+		Convert_v1_BuildCompletionTrigger_To_api_BuildCompletionTrigger,
+		Convert_api_BuildCompletionTrigger_To_v1_BuildCompletionTrigger,
 		Convert_v1_BuildConfig_To_api_BuildConfig,
 		Convert_api_BuildConfig_To_v1_BuildConfig,
 		Convert_v1_BuildConfigList_To_api_BuildConfigList,
@@ -34,16 +40,28 @@ func init() {
 		Convert_api_BuildLog_To_v1_BuildLog,
 		Convert_v1_BuildLogOptions_To_api_BuildLogOptions,
 		Convert_api_BuildLogOptions_To_v1_BuildLogOptions,
+		Convert_v1_BuildMatrix_To_api_BuildMatrix,
+		Convert_api_BuildMatrix_To_v1_BuildMatrix,
+		Convert_v1_BuildMatrixEntry_To_api_BuildMatrixEntry,
+		Convert_api_BuildMatrixEntry_To_v1_BuildMatrixEntry,
+		Convert_v1_BuildMatrixEnv_To_api_BuildMatrixEnv,
+		Convert_api_BuildMatrixEnv_To_v1_BuildMatrixEnv,
+		Convert_v1_BuildMatrixImage_To_api_BuildMatrixImage,
+		Convert_api_BuildMatrixImage_To_v1_BuildMatrixImage,
 		Convert_v1_BuildOutput_To_api_BuildOutput,
 		Convert_api_BuildOutput_To_v1_BuildOutput,
 		Convert_v1_BuildPostCommitSpec_To_api_BuildPostCommitSpec,
 		Convert_api_BuildPostCommitSpec_To_v1_BuildPostCommitSpec,
 		Convert_v1_BuildRequest_To_api_BuildRequest,
 		Convert_api_BuildRequest_To_v1_BuildRequest,
+		Convert_v1_BuildResourceUsage_To_api_BuildResourceUsage,
+		Convert_api_BuildResourceUsage_To_v1_BuildResourceUsage,
 		Convert_v1_BuildSource_To_api_BuildSource,
 		Convert_api_BuildSource_To_v1_BuildSource,
 		Convert_v1_BuildSpec_To_api_BuildSpec,
 		Convert_api_BuildSpec_To_v1_BuildSpec,
+		Convert_v1_BuildStageStatus_To_api_BuildStageStatus,
+		Convert_api_BuildStageStatus_To_v1_BuildStageStatus,
 		Convert_v1_BuildStatus_To_api_BuildStatus,
 		Convert_api_BuildStatus_To_v1_BuildStatus,
 		Convert_v1_BuildStrategy_To_api_BuildStrategy,
@@ -66,6 +84,8 @@ func init() {
 		Convert_v1_GitHubWebHookCause_To_api_GitHubWebHookCause,
 		Convert_api_GitHubWebHookCause_To_v1_GitHubWebHookCause,
 		Convert_v1_GitInfo_To_api_GitInfo,
+		Convert_v1_GitLabWebHookCause_To_api_GitLabWebHookCause,
+		Convert_api_GitLabWebHookCause_To_v1_GitLabWebHookCause,
 		Convert_v1_GitSourceRevision_To_api_GitSourceRevision,
 		Convert_api_GitSourceRevision_To_v1_GitSourceRevision,
 		Convert_v1_ImageChangeCause_To_api_ImageChangeCause,
@@ -76,8 +96,16 @@ func init() {
 		Convert_api_ImageSource_To_v1_ImageSource,
 		Convert_v1_ImageSourcePath_To_api_ImageSourcePath,
 		Convert_api_ImageSourcePath_To_v1_ImageSourcePath,
+		Convert_v1_IncrementalCacheSource_To_api_IncrementalCacheSource,
+		Convert_api_IncrementalCacheSource_To_v1_IncrementalCacheSource,
 		Convert_v1_JenkinsPipelineBuildStrategy_To_api_JenkinsPipelineBuildStrategy,
 		Convert_api_JenkinsPipelineBuildStrategy_To_v1_JenkinsPipelineBuildStrategy,
+		Convert_v1_PipelineBuildStrategy_To_api_PipelineBuildStrategy,
+		Convert_api_PipelineBuildStrategy_To_v1_PipelineBuildStrategy,
+		Convert_v1_PipelineStage_To_api_PipelineStage,
+		Convert_api_PipelineStage_To_v1_PipelineStage,
+		Convert_v1_PullRequestCause_To_api_PullRequestCause,
+		Convert_api_PullRequestCause_To_v1_PullRequestCause,
 		Convert_v1_SecretBuildSource_To_api_SecretBuildSource,
 		Convert_api_SecretBuildSource_To_v1_SecretBuildSource,
 		Convert_v1_SecretSpec_To_api_SecretSpec,
@@ -158,6 +186,42 @@ func Convert_api_BinaryBuildSource_To_v1_BinaryBuildSource(in *build_api.BinaryB
 	return autoConvert_api_BinaryBuildSource_To_v1_BinaryBuildSource(in, out, s)
 }
 
+func autoConvert_v1_BitbucketWebHookCause_To_api_BitbucketWebHookCause(in *BitbucketWebHookCause, out *build_api.BitbucketWebHookCause, s conversion.Scope) error {
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(build_api.SourceRevision)
+		if err := Convert_v1_SourceRevision_To_api_SourceRevision(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Revision = nil
+	}
+	out.Secret = in.Secret
+	return nil
+}
+
+func Convert_v1_BitbucketWebHookCause_To_api_BitbucketWebHookCause(in *BitbucketWebHookCause, out *build_api.BitbucketWebHookCause, s conversion.Scope) error {
+	return autoConvert_v1_BitbucketWebHookCause_To_api_BitbucketWebHookCause(in, out, s)
+}
+
+func autoConvert_api_BitbucketWebHookCause_To_v1_BitbucketWebHookCause(in *build_api.BitbucketWebHookCause, out *BitbucketWebHookCause, s conversion.Scope) error {
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(SourceRevision)
+		if err := Convert_api_SourceRevision_To_v1_SourceRevision(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Revision = nil
+	}
+	out.Secret = in.Secret
+	return nil
+}
+
+func Convert_api_BitbucketWebHookCause_To_v1_BitbucketWebHookCause(in *build_api.BitbucketWebHookCause, out *BitbucketWebHookCause, s conversion.Scope) error {
+	return autoConvert_api_BitbucketWebHookCause_To_v1_BitbucketWebHookCause(in, out, s)
+}
+
 func autoConvert_v1_Build_To_api_Build(in *Build, out *build_api.Build, s conversion.Scope) error {
 	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
 		return err
@@ -200,6 +264,70 @@ func Convert_api_Build_To_v1_Build(in *build_api.Build, out *Build, s conversion
 	return autoConvert_api_Build_To_v1_Build(in, out, s)
 }
 
+func autoConvert_v1_BuildCompletionCause_To_api_BuildCompletionCause(in *BuildCompletionCause, out *build_api.BuildCompletionCause, s conversion.Scope) error {
+	out.BuildName = in.BuildName
+	if in.FromRef != nil {
+		in, out := &in.FromRef, &out.FromRef
+		*out = new(api.ObjectReference)
+		// TODO: Inefficient conversion - can we improve it?
+		if err := s.Convert(*in, *out, 0); err != nil {
+			return err
+		}
+	} else {
+		out.FromRef = nil
+	}
+	return nil
+}
+
+func Convert_v1_BuildCompletionCause_To_api_BuildCompletionCause(in *BuildCompletionCause, out *build_api.BuildCompletionCause, s conversion.Scope) error {
+	return autoConvert_v1_BuildCompletionCause_To_api_BuildCompletionCause(in, out, s)
+}
+
+func autoConvert_api_BuildCompletionCause_To_v1_BuildCompletionCause(in *build_api.BuildCompletionCause, out *BuildCompletionCause, s conversion.Scope) error {
+	out.BuildName = in.BuildName
+	if in.FromRef != nil {
+		in, out := &in.FromRef, &out.FromRef
+		*out = new(api_v1.ObjectReference)
+		// TODO: Inefficient conversion - can we improve it?
+		if err := s.Convert(*in, *out, 0); err != nil {
+			return err
+		}
+	} else {
+		out.FromRef = nil
+	}
+	return nil
+}
+
+func Convert_api_BuildCompletionCause_To_v1_BuildCompletionCause(in *build_api.BuildCompletionCause, out *BuildCompletionCause, s conversion.Scope) error {
+	return autoConvert_api_BuildCompletionCause_To_v1_BuildCompletionCause(in, out, s)
+}
+
+func autoConvert_v1_BuildCompletionTrigger_To_api_BuildCompletionTrigger(in *BuildCompletionTrigger, out *build_api.BuildCompletionTrigger, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+		return err
+	}
+	out.LastTriggeredBuild = in.LastTriggeredBuild
+	return nil
+}
+
+func Convert_v1_BuildCompletionTrigger_To_api_BuildCompletionTrigger(in *BuildCompletionTrigger, out *build_api.BuildCompletionTrigger, s conversion.Scope) error {
+	return autoConvert_v1_BuildCompletionTrigger_To_api_BuildCompletionTrigger(in, out, s)
+}
+
+func autoConvert_api_BuildCompletionTrigger_To_v1_BuildCompletionTrigger(in *build_api.BuildCompletionTrigger, out *BuildCompletionTrigger, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+		return err
+	}
+	out.LastTriggeredBuild = in.LastTriggeredBuild
+	return nil
+}
+
+func Convert_api_BuildCompletionTrigger_To_v1_BuildCompletionTrigger(in *build_api.BuildCompletionTrigger, out *BuildCompletionTrigger, s conversion.Scope) error {
+	return autoConvert_api_BuildCompletionTrigger_To_v1_BuildCompletionTrigger(in, out, s)
+}
+
 func autoConvert_v1_BuildConfig_To_api_BuildConfig(in *BuildConfig, out *build_api.BuildConfig, s conversion.Scope) error {
 	if err := api.Convert_unversioned_TypeMeta_To_unversioned_TypeMeta(&in.TypeMeta, &out.TypeMeta, s); err != nil {
 		return err
@@ -302,6 +430,15 @@ func autoConvert_v1_BuildConfigSpec_To_api_BuildConfigSpec(in *BuildConfigSpec,
 		out.Triggers = nil
 	}
 	out.RunPolicy = build_api.BuildRunPolicy(in.RunPolicy)
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = new(build_api.BuildMatrix)
+		if err := Convert_v1_BuildMatrix_To_api_BuildMatrix(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Matrix = nil
+	}
 	if err := Convert_v1_CommonSpec_To_api_CommonSpec(&in.CommonSpec, &out.CommonSpec, s); err != nil {
 		return err
 	}
@@ -325,6 +462,15 @@ func autoConvert_api_BuildConfigSpec_To_v1_BuildConfigSpec(in *build_api.BuildCo
 		out.Triggers = nil
 	}
 	out.RunPolicy = BuildRunPolicy(in.RunPolicy)
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = new(BuildMatrix)
+		if err := Convert_api_BuildMatrix_To_v1_BuildMatrix(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Matrix = nil
+	}
 	if err := Convert_api_CommonSpec_To_v1_CommonSpec(&in.CommonSpec, &out.CommonSpec, s); err != nil {
 		return err
 	}
@@ -471,6 +617,7 @@ func autoConvert_v1_BuildLogOptions_To_api_BuildLogOptions(in *BuildLogOptions,
 	} else {
 		out.Version = nil
 	}
+	out.Stage = in.Stage
 	return nil
 }
 
@@ -524,6 +671,7 @@ func autoConvert_api_BuildLogOptions_To_v1_BuildLogOptions(in *build_api.BuildLo
 	} else {
 		out.Version = nil
 	}
+	out.Stage = in.Stage
 	return nil
 }
 
@@ -531,6 +679,156 @@ func Convert_api_BuildLogOptions_To_v1_BuildLogOptions(in *build_api.BuildLogOpt
 	return autoConvert_api_BuildLogOptions_To_v1_BuildLogOptions(in, out, s)
 }
 
+func autoConvert_v1_BuildMatrix_To_api_BuildMatrix(in *BuildMatrix, out *build_api.BuildMatrix, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]build_api.BuildMatrixImage, len(*in))
+		for i := range *in {
+			if err := Convert_v1_BuildMatrixImage_To_api_BuildMatrixImage(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]build_api.BuildMatrixEnv, len(*in))
+		for i := range *in {
+			if err := Convert_v1_BuildMatrixEnv_To_api_BuildMatrixEnv(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func Convert_v1_BuildMatrix_To_api_BuildMatrix(in *BuildMatrix, out *build_api.BuildMatrix, s conversion.Scope) error {
+	return autoConvert_v1_BuildMatrix_To_api_BuildMatrix(in, out, s)
+}
+
+func autoConvert_api_BuildMatrix_To_v1_BuildMatrix(in *build_api.BuildMatrix, out *BuildMatrix, s conversion.Scope) error {
+	if in.Images != nil {
+		in, out := &in.Images, &out.Images
+		*out = make([]BuildMatrixImage, len(*in))
+		for i := range *in {
+			if err := Convert_api_BuildMatrixImage_To_v1_BuildMatrixImage(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]BuildMatrixEnv, len(*in))
+		for i := range *in {
+			if err := Convert_api_BuildMatrixEnv_To_v1_BuildMatrixEnv(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func Convert_api_BuildMatrix_To_v1_BuildMatrix(in *build_api.BuildMatrix, out *BuildMatrix, s conversion.Scope) error {
+	return autoConvert_api_BuildMatrix_To_v1_BuildMatrix(in, out, s)
+}
+
+func autoConvert_v1_BuildMatrixEntry_To_api_BuildMatrixEntry(in *BuildMatrixEntry, out *build_api.BuildMatrixEntry, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Image = in.Image
+	out.Env = in.Env
+	return nil
+}
+
+func Convert_v1_BuildMatrixEntry_To_api_BuildMatrixEntry(in *BuildMatrixEntry, out *build_api.BuildMatrixEntry, s conversion.Scope) error {
+	return autoConvert_v1_BuildMatrixEntry_To_api_BuildMatrixEntry(in, out, s)
+}
+
+func autoConvert_api_BuildMatrixEntry_To_v1_BuildMatrixEntry(in *build_api.BuildMatrixEntry, out *BuildMatrixEntry, s conversion.Scope) error {
+	out.Group = in.Group
+	out.Image = in.Image
+	out.Env = in.Env
+	return nil
+}
+
+func Convert_api_BuildMatrixEntry_To_v1_BuildMatrixEntry(in *build_api.BuildMatrixEntry, out *BuildMatrixEntry, s conversion.Scope) error {
+	return autoConvert_api_BuildMatrixEntry_To_v1_BuildMatrixEntry(in, out, s)
+}
+
+func autoConvert_v1_BuildMatrixEnv_To_api_BuildMatrixEnv(in *BuildMatrixEnv, out *build_api.BuildMatrixEnv, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]api.EnvVar, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func Convert_v1_BuildMatrixEnv_To_api_BuildMatrixEnv(in *BuildMatrixEnv, out *build_api.BuildMatrixEnv, s conversion.Scope) error {
+	return autoConvert_v1_BuildMatrixEnv_To_api_BuildMatrixEnv(in, out, s)
+}
+
+func autoConvert_api_BuildMatrixEnv_To_v1_BuildMatrixEnv(in *build_api.BuildMatrixEnv, out *BuildMatrixEnv, s conversion.Scope) error {
+	out.Name = in.Name
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]api_v1.EnvVar, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func Convert_api_BuildMatrixEnv_To_v1_BuildMatrixEnv(in *build_api.BuildMatrixEnv, out *BuildMatrixEnv, s conversion.Scope) error {
+	return autoConvert_api_BuildMatrixEnv_To_v1_BuildMatrixEnv(in, out, s)
+}
+
+func autoConvert_v1_BuildMatrixImage_To_api_BuildMatrixImage(in *BuildMatrixImage, out *build_api.BuildMatrixImage, s conversion.Scope) error {
+	out.Name = in.Name
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_v1_BuildMatrixImage_To_api_BuildMatrixImage(in *BuildMatrixImage, out *build_api.BuildMatrixImage, s conversion.Scope) error {
+	return autoConvert_v1_BuildMatrixImage_To_api_BuildMatrixImage(in, out, s)
+}
+
+func autoConvert_api_BuildMatrixImage_To_v1_BuildMatrixImage(in *build_api.BuildMatrixImage, out *BuildMatrixImage, s conversion.Scope) error {
+	out.Name = in.Name
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_BuildMatrixImage_To_v1_BuildMatrixImage(in *build_api.BuildMatrixImage, out *BuildMatrixImage, s conversion.Scope) error {
+	return autoConvert_api_BuildMatrixImage_To_v1_BuildMatrixImage(in, out, s)
+}
+
 func autoConvert_v1_BuildOutput_To_api_BuildOutput(in *BuildOutput, out *build_api.BuildOutput, s conversion.Scope) error {
 	if in.To != nil {
 		in, out := &in.To, &out.To
@@ -705,6 +1003,15 @@ func autoConvert_v1_BuildRequest_To_api_BuildRequest(in *BuildRequest, out *buil
 	} else {
 		out.TriggeredBy = nil
 	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = new(build_api.BuildMatrixEntry)
+		if err := Convert_v1_BuildMatrixEntry_To_api_BuildMatrixEntry(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Matrix = nil
+	}
 	return nil
 }
 
@@ -788,6 +1095,15 @@ func autoConvert_api_BuildRequest_To_v1_BuildRequest(in *build_api.BuildRequest,
 	} else {
 		out.TriggeredBy = nil
 	}
+	if in.Matrix != nil {
+		in, out := &in.Matrix, &out.Matrix
+		*out = new(BuildMatrixEntry)
+		if err := Convert_api_BuildMatrixEntry_To_v1_BuildMatrixEntry(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Matrix = nil
+	}
 	return nil
 }
 
@@ -795,6 +1111,36 @@ func Convert_api_BuildRequest_To_v1_BuildRequest(in *build_api.BuildRequest, out
 	return autoConvert_api_BuildRequest_To_v1_BuildRequest(in, out, s)
 }
 
+func autoConvert_v1_BuildResourceUsage_To_api_BuildResourceUsage(in *BuildResourceUsage, out *build_api.BuildResourceUsage, s conversion.Scope) error {
+	if err := api.Convert_resource_Quantity_To_resource_Quantity(&in.CPU, &out.CPU, s); err != nil {
+		return err
+	}
+	if err := api.Convert_resource_Quantity_To_resource_Quantity(&in.Memory, &out.Memory, s); err != nil {
+		return err
+	}
+	out.OutOfMemory = in.OutOfMemory
+	return nil
+}
+
+func Convert_v1_BuildResourceUsage_To_api_BuildResourceUsage(in *BuildResourceUsage, out *build_api.BuildResourceUsage, s conversion.Scope) error {
+	return autoConvert_v1_BuildResourceUsage_To_api_BuildResourceUsage(in, out, s)
+}
+
+func autoConvert_api_BuildResourceUsage_To_v1_BuildResourceUsage(in *build_api.BuildResourceUsage, out *BuildResourceUsage, s conversion.Scope) error {
+	if err := api.Convert_resource_Quantity_To_resource_Quantity(&in.CPU, &out.CPU, s); err != nil {
+		return err
+	}
+	if err := api.Convert_resource_Quantity_To_resource_Quantity(&in.Memory, &out.Memory, s); err != nil {
+		return err
+	}
+	out.OutOfMemory = in.OutOfMemory
+	return nil
+}
+
+func Convert_api_BuildResourceUsage_To_v1_BuildResourceUsage(in *build_api.BuildResourceUsage, out *BuildResourceUsage, s conversion.Scope) error {
+	return autoConvert_api_BuildResourceUsage_To_v1_BuildResourceUsage(in, out, s)
+}
+
 func autoConvert_v1_BuildSource_To_api_BuildSource(in *BuildSource, out *build_api.BuildSource, s conversion.Scope) error {
 	SetDefaults_BuildSource(in)
 	if in.Binary != nil {
@@ -968,6 +1314,64 @@ func Convert_api_BuildSpec_To_v1_BuildSpec(in *build_api.BuildSpec, out *BuildSp
 	return autoConvert_api_BuildSpec_To_v1_BuildSpec(in, out, s)
 }
 
+func autoConvert_v1_BuildStageStatus_To_api_BuildStageStatus(in *BuildStageStatus, out *build_api.BuildStageStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Phase = build_api.BuildPhase(in.Phase)
+	out.PodName = in.PodName
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartTimestamp = nil
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CompletionTimestamp = nil
+	}
+	return nil
+}
+
+func Convert_v1_BuildStageStatus_To_api_BuildStageStatus(in *BuildStageStatus, out *build_api.BuildStageStatus, s conversion.Scope) error {
+	return autoConvert_v1_BuildStageStatus_To_api_BuildStageStatus(in, out, s)
+}
+
+func autoConvert_api_BuildStageStatus_To_v1_BuildStageStatus(in *build_api.BuildStageStatus, out *BuildStageStatus, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Phase = BuildPhase(in.Phase)
+	out.PodName = in.PodName
+	if in.StartTimestamp != nil {
+		in, out := &in.StartTimestamp, &out.StartTimestamp
+		*out = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.StartTimestamp = nil
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := &in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CompletionTimestamp = nil
+	}
+	return nil
+}
+
+func Convert_api_BuildStageStatus_To_v1_BuildStageStatus(in *build_api.BuildStageStatus, out *BuildStageStatus, s conversion.Scope) error {
+	return autoConvert_api_BuildStageStatus_To_v1_BuildStageStatus(in, out, s)
+}
+
 func autoConvert_v1_BuildStatus_To_api_BuildStatus(in *BuildStatus, out *build_api.BuildStatus, s conversion.Scope) error {
 	out.Phase = build_api.BuildPhase(in.Phase)
 	out.Cancelled = in.Cancelled
@@ -1003,6 +1407,26 @@ func autoConvert_v1_BuildStatus_To_api_BuildStatus(in *BuildStatus, out *build_a
 	} else {
 		out.Config = nil
 	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]build_api.BuildStageStatus, len(*in))
+		for i := range *in {
+			if err := Convert_v1_BuildStageStatus_To_api_BuildStageStatus(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Stages = nil
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(build_api.BuildResourceUsage)
+		if err := Convert_v1_BuildResourceUsage_To_api_BuildResourceUsage(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceUsage = nil
+	}
 	return nil
 }
 
@@ -1045,6 +1469,26 @@ func autoConvert_api_BuildStatus_To_v1_BuildStatus(in *build_api.BuildStatus, ou
 	} else {
 		out.Config = nil
 	}
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]BuildStageStatus, len(*in))
+		for i := range *in {
+			if err := Convert_api_BuildStageStatus_To_v1_BuildStageStatus(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Stages = nil
+	}
+	if in.ResourceUsage != nil {
+		in, out := &in.ResourceUsage, &out.ResourceUsage
+		*out = new(BuildResourceUsage)
+		if err := Convert_api_BuildResourceUsage_To_v1_BuildResourceUsage(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceUsage = nil
+	}
 	return nil
 }
 
@@ -1090,6 +1534,15 @@ func autoConvert_v1_BuildStrategy_To_api_BuildStrategy(in *BuildStrategy, out *b
 	} else {
 		out.JenkinsPipelineStrategy = nil
 	}
+	if in.PipelineStrategy != nil {
+		in, out := &in.PipelineStrategy, &out.PipelineStrategy
+		*out = new(build_api.PipelineBuildStrategy)
+		if err := Convert_v1_PipelineBuildStrategy_To_api_PipelineBuildStrategy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PipelineStrategy = nil
+	}
 	return nil
 }
 
@@ -1134,6 +1587,15 @@ func autoConvert_api_BuildStrategy_To_v1_BuildStrategy(in *build_api.BuildStrate
 	} else {
 		out.JenkinsPipelineStrategy = nil
 	}
+	if in.PipelineStrategy != nil {
+		in, out := &in.PipelineStrategy, &out.PipelineStrategy
+		*out = new(PipelineBuildStrategy)
+		if err := Convert_api_PipelineBuildStrategy_To_v1_PipelineBuildStrategy(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PipelineStrategy = nil
+	}
 	return nil
 }
 
@@ -1157,6 +1619,24 @@ func autoConvert_v1_BuildTriggerCause_To_api_BuildTriggerCause(in *BuildTriggerC
 	} else {
 		out.GitHubWebHook = nil
 	}
+	if in.GitLabWebHook != nil {
+		in, out := &in.GitLabWebHook, &out.GitLabWebHook
+		*out = new(build_api.GitLabWebHookCause)
+		if err := Convert_v1_GitLabWebHookCause_To_api_GitLabWebHookCause(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GitLabWebHook = nil
+	}
+	if in.BitbucketWebHook != nil {
+		in, out := &in.BitbucketWebHook, &out.BitbucketWebHook
+		*out = new(build_api.BitbucketWebHookCause)
+		if err := Convert_v1_BitbucketWebHookCause_To_api_BitbucketWebHookCause(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BitbucketWebHook = nil
+	}
 	if in.ImageChangeBuild != nil {
 		in, out := &in.ImageChangeBuild, &out.ImageChangeBuild
 		*out = new(build_api.ImageChangeCause)
@@ -1166,6 +1646,15 @@ func autoConvert_v1_BuildTriggerCause_To_api_BuildTriggerCause(in *BuildTriggerC
 	} else {
 		out.ImageChangeBuild = nil
 	}
+	if in.BuildCompletion != nil {
+		in, out := &in.BuildCompletion, &out.BuildCompletion
+		*out = new(build_api.BuildCompletionCause)
+		if err := Convert_v1_BuildCompletionCause_To_api_BuildCompletionCause(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BuildCompletion = nil
+	}
 	return nil
 }
 
@@ -1193,6 +1682,24 @@ func autoConvert_api_BuildTriggerCause_To_v1_BuildTriggerCause(in *build_api.Bui
 	} else {
 		out.GitHubWebHook = nil
 	}
+	if in.GitLabWebHook != nil {
+		in, out := &in.GitLabWebHook, &out.GitLabWebHook
+		*out = new(GitLabWebHookCause)
+		if err := Convert_api_GitLabWebHookCause_To_v1_GitLabWebHookCause(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GitLabWebHook = nil
+	}
+	if in.BitbucketWebHook != nil {
+		in, out := &in.BitbucketWebHook, &out.BitbucketWebHook
+		*out = new(BitbucketWebHookCause)
+		if err := Convert_api_BitbucketWebHookCause_To_v1_BitbucketWebHookCause(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BitbucketWebHook = nil
+	}
 	if in.ImageChangeBuild != nil {
 		in, out := &in.ImageChangeBuild, &out.ImageChangeBuild
 		*out = new(ImageChangeCause)
@@ -1202,6 +1709,15 @@ func autoConvert_api_BuildTriggerCause_To_v1_BuildTriggerCause(in *build_api.Bui
 	} else {
 		out.ImageChangeBuild = nil
 	}
+	if in.BuildCompletion != nil {
+		in, out := &in.BuildCompletion, &out.BuildCompletion
+		*out = new(BuildCompletionCause)
+		if err := Convert_api_BuildCompletionCause_To_v1_BuildCompletionCause(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BuildCompletion = nil
+	}
 	return nil
 }
 
@@ -1230,6 +1746,24 @@ func autoConvert_v1_BuildTriggerPolicy_To_api_BuildTriggerPolicy(in *BuildTrigge
 	} else {
 		out.GenericWebHook = nil
 	}
+	if in.GitLabWebHook != nil {
+		in, out := &in.GitLabWebHook, &out.GitLabWebHook
+		*out = new(build_api.WebHookTrigger)
+		if err := Convert_v1_WebHookTrigger_To_api_WebHookTrigger(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GitLabWebHook = nil
+	}
+	if in.BitbucketWebHook != nil {
+		in, out := &in.BitbucketWebHook, &out.BitbucketWebHook
+		*out = new(build_api.WebHookTrigger)
+		if err := Convert_v1_WebHookTrigger_To_api_WebHookTrigger(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BitbucketWebHook = nil
+	}
 	if in.ImageChange != nil {
 		in, out := &in.ImageChange, &out.ImageChange
 		*out = new(build_api.ImageChangeTrigger)
@@ -1239,6 +1773,15 @@ func autoConvert_v1_BuildTriggerPolicy_To_api_BuildTriggerPolicy(in *BuildTrigge
 	} else {
 		out.ImageChange = nil
 	}
+	if in.BuildCompletion != nil {
+		in, out := &in.BuildCompletion, &out.BuildCompletion
+		*out = new(build_api.BuildCompletionTrigger)
+		if err := Convert_v1_BuildCompletionTrigger_To_api_BuildCompletionTrigger(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BuildCompletion = nil
+	}
 	return nil
 }
 
@@ -1262,6 +1805,24 @@ func autoConvert_api_BuildTriggerPolicy_To_v1_BuildTriggerPolicy(in *build_api.B
 	} else {
 		out.GenericWebHook = nil
 	}
+	if in.GitLabWebHook != nil {
+		in, out := &in.GitLabWebHook, &out.GitLabWebHook
+		*out = new(WebHookTrigger)
+		if err := Convert_api_WebHookTrigger_To_v1_WebHookTrigger(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.GitLabWebHook = nil
+	}
+	if in.BitbucketWebHook != nil {
+		in, out := &in.BitbucketWebHook, &out.BitbucketWebHook
+		*out = new(WebHookTrigger)
+		if err := Convert_api_WebHookTrigger_To_v1_WebHookTrigger(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BitbucketWebHook = nil
+	}
 	if in.ImageChange != nil {
 		in, out := &in.ImageChange, &out.ImageChange
 		*out = new(ImageChangeTrigger)
@@ -1271,6 +1832,15 @@ func autoConvert_api_BuildTriggerPolicy_To_v1_BuildTriggerPolicy(in *build_api.B
 	} else {
 		out.ImageChange = nil
 	}
+	if in.BuildCompletion != nil {
+		in, out := &in.BuildCompletion, &out.BuildCompletion
+		*out = new(BuildCompletionTrigger)
+		if err := Convert_api_BuildCompletionTrigger_To_v1_BuildCompletionTrigger(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BuildCompletion = nil
+	}
 	return nil
 }
 
@@ -1660,6 +2230,15 @@ func autoConvert_v1_GitHubWebHookCause_To_api_GitHubWebHookCause(in *GitHubWebHo
 		out.Revision = nil
 	}
 	out.Secret = in.Secret
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(build_api.PullRequestCause)
+		if err := Convert_v1_PullRequestCause_To_api_PullRequestCause(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PullRequest = nil
+	}
 	return nil
 }
 
@@ -1678,6 +2257,15 @@ func autoConvert_api_GitHubWebHookCause_To_v1_GitHubWebHookCause(in *build_api.G
 		out.Revision = nil
 	}
 	out.Secret = in.Secret
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestCause)
+		if err := Convert_api_PullRequestCause_To_v1_PullRequestCause(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PullRequest = nil
+	}
 	return nil
 }
 
@@ -1692,6 +2280,13 @@ func autoConvert_v1_GitInfo_To_api_GitInfo(in *GitInfo, out *build_api.GitInfo,
 	if err := Convert_v1_GitSourceRevision_To_api_GitSourceRevision(&in.GitSourceRevision, &out.GitSourceRevision, s); err != nil {
 		return err
 	}
+	if in.ChangedPaths != nil {
+		in, out := &in.ChangedPaths, &out.ChangedPaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.ChangedPaths = nil
+	}
 	return nil
 }
 
@@ -1699,6 +2294,42 @@ func Convert_v1_GitInfo_To_api_GitInfo(in *GitInfo, out *build_api.GitInfo, s co
 	return autoConvert_v1_GitInfo_To_api_GitInfo(in, out, s)
 }
 
+func autoConvert_v1_GitLabWebHookCause_To_api_GitLabWebHookCause(in *GitLabWebHookCause, out *build_api.GitLabWebHookCause, s conversion.Scope) error {
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(build_api.SourceRevision)
+		if err := Convert_v1_SourceRevision_To_api_SourceRevision(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Revision = nil
+	}
+	out.Secret = in.Secret
+	return nil
+}
+
+func Convert_v1_GitLabWebHookCause_To_api_GitLabWebHookCause(in *GitLabWebHookCause, out *build_api.GitLabWebHookCause, s conversion.Scope) error {
+	return autoConvert_v1_GitLabWebHookCause_To_api_GitLabWebHookCause(in, out, s)
+}
+
+func autoConvert_api_GitLabWebHookCause_To_v1_GitLabWebHookCause(in *build_api.GitLabWebHookCause, out *GitLabWebHookCause, s conversion.Scope) error {
+	if in.Revision != nil {
+		in, out := &in.Revision, &out.Revision
+		*out = new(SourceRevision)
+		if err := Convert_api_SourceRevision_To_v1_SourceRevision(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Revision = nil
+	}
+	out.Secret = in.Secret
+	return nil
+}
+
+func Convert_api_GitLabWebHookCause_To_v1_GitLabWebHookCause(in *build_api.GitLabWebHookCause, out *GitLabWebHookCause, s conversion.Scope) error {
+	return autoConvert_api_GitLabWebHookCause_To_v1_GitLabWebHookCause(in, out, s)
+}
+
 func autoConvert_v1_GitSourceRevision_To_api_GitSourceRevision(in *GitSourceRevision, out *build_api.GitSourceRevision, s conversion.Scope) error {
 	out.Commit = in.Commit
 	if err := Convert_v1_SourceControlUser_To_api_SourceControlUser(&in.Author, &out.Author, s); err != nil {
@@ -1893,6 +2524,62 @@ func Convert_api_ImageSourcePath_To_v1_ImageSourcePath(in *build_api.ImageSource
 	return autoConvert_api_ImageSourcePath_To_v1_ImageSourcePath(in, out, s)
 }
 
+func autoConvert_v1_IncrementalCacheSource_To_api_IncrementalCacheSource(in *IncrementalCacheSource, out *build_api.IncrementalCacheSource, s conversion.Scope) error {
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(api.LocalObjectReference)
+		// TODO: Inefficient conversion - can we improve it?
+		if err := s.Convert(*in, *out, 0); err != nil {
+			return err
+		}
+	} else {
+		out.PersistentVolumeClaim = nil
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(api.ObjectReference)
+		// TODO: Inefficient conversion - can we improve it?
+		if err := s.Convert(*in, *out, 0); err != nil {
+			return err
+		}
+	} else {
+		out.Image = nil
+	}
+	return nil
+}
+
+func Convert_v1_IncrementalCacheSource_To_api_IncrementalCacheSource(in *IncrementalCacheSource, out *build_api.IncrementalCacheSource, s conversion.Scope) error {
+	return autoConvert_v1_IncrementalCacheSource_To_api_IncrementalCacheSource(in, out, s)
+}
+
+func autoConvert_api_IncrementalCacheSource_To_v1_IncrementalCacheSource(in *build_api.IncrementalCacheSource, out *IncrementalCacheSource, s conversion.Scope) error {
+	if in.PersistentVolumeClaim != nil {
+		in, out := &in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(api_v1.LocalObjectReference)
+		// TODO: Inefficient conversion - can we improve it?
+		if err := s.Convert(*in, *out, 0); err != nil {
+			return err
+		}
+	} else {
+		out.PersistentVolumeClaim = nil
+	}
+	if in.Image != nil {
+		in, out := &in.Image, &out.Image
+		*out = new(api_v1.ObjectReference)
+		// TODO: Inefficient conversion - can we improve it?
+		if err := s.Convert(*in, *out, 0); err != nil {
+			return err
+		}
+	} else {
+		out.Image = nil
+	}
+	return nil
+}
+
+func Convert_api_IncrementalCacheSource_To_v1_IncrementalCacheSource(in *build_api.IncrementalCacheSource, out *IncrementalCacheSource, s conversion.Scope) error {
+	return autoConvert_api_IncrementalCacheSource_To_v1_IncrementalCacheSource(in, out, s)
+}
+
 func autoConvert_v1_JenkinsPipelineBuildStrategy_To_api_JenkinsPipelineBuildStrategy(in *JenkinsPipelineBuildStrategy, out *build_api.JenkinsPipelineBuildStrategy, s conversion.Scope) error {
 	out.JenkinsfilePath = in.JenkinsfilePath
 	out.Jenkinsfile = in.Jenkinsfile
@@ -1913,6 +2600,152 @@ func Convert_api_JenkinsPipelineBuildStrategy_To_v1_JenkinsPipelineBuildStrategy
 	return autoConvert_api_JenkinsPipelineBuildStrategy_To_v1_JenkinsPipelineBuildStrategy(in, out, s)
 }
 
+func autoConvert_v1_PipelineBuildStrategy_To_api_PipelineBuildStrategy(in *PipelineBuildStrategy, out *build_api.PipelineBuildStrategy, s conversion.Scope) error {
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]build_api.PipelineStage, len(*in))
+		for i := range *in {
+			if err := Convert_v1_PipelineStage_To_api_PipelineStage(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Stages = nil
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]api.EnvVar, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	out.ForcePull = in.ForcePull
+	return nil
+}
+
+func Convert_v1_PipelineBuildStrategy_To_api_PipelineBuildStrategy(in *PipelineBuildStrategy, out *build_api.PipelineBuildStrategy, s conversion.Scope) error {
+	return autoConvert_v1_PipelineBuildStrategy_To_api_PipelineBuildStrategy(in, out, s)
+}
+
+func autoConvert_api_PipelineBuildStrategy_To_v1_PipelineBuildStrategy(in *build_api.PipelineBuildStrategy, out *PipelineBuildStrategy, s conversion.Scope) error {
+	if in.Stages != nil {
+		in, out := &in.Stages, &out.Stages
+		*out = make([]PipelineStage, len(*in))
+		for i := range *in {
+			if err := Convert_api_PipelineStage_To_v1_PipelineStage(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Stages = nil
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]api_v1.EnvVar, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	out.ForcePull = in.ForcePull
+	return nil
+}
+
+func Convert_api_PipelineBuildStrategy_To_v1_PipelineBuildStrategy(in *build_api.PipelineBuildStrategy, out *PipelineBuildStrategy, s conversion.Scope) error {
+	return autoConvert_api_PipelineBuildStrategy_To_v1_PipelineBuildStrategy(in, out, s)
+}
+
+func autoConvert_v1_PipelineStage_To_api_PipelineStage(in *PipelineStage, out *build_api.PipelineStage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.Command = nil
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]api.EnvVar, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func Convert_v1_PipelineStage_To_api_PipelineStage(in *PipelineStage, out *build_api.PipelineStage, s conversion.Scope) error {
+	return autoConvert_v1_PipelineStage_To_api_PipelineStage(in, out, s)
+}
+
+func autoConvert_api_PipelineStage_To_v1_PipelineStage(in *build_api.PipelineStage, out *PipelineStage, s conversion.Scope) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.Command = nil
+	}
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]api_v1.EnvVar, len(*in))
+		for i := range *in {
+			// TODO: Inefficient conversion - can we improve it?
+			if err := s.Convert(&(*in)[i], &(*out)[i], 0); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func Convert_api_PipelineStage_To_v1_PipelineStage(in *build_api.PipelineStage, out *PipelineStage, s conversion.Scope) error {
+	return autoConvert_api_PipelineStage_To_v1_PipelineStage(in, out, s)
+}
+
+func autoConvert_v1_PullRequestCause_To_api_PullRequestCause(in *PullRequestCause, out *build_api.PullRequestCause, s conversion.Scope) error {
+	out.Number = in.Number
+	out.Title = in.Title
+	out.HeadRef = in.HeadRef
+	out.Fork = in.Fork
+	return nil
+}
+
+func Convert_v1_PullRequestCause_To_api_PullRequestCause(in *PullRequestCause, out *build_api.PullRequestCause, s conversion.Scope) error {
+	return autoConvert_v1_PullRequestCause_To_api_PullRequestCause(in, out, s)
+}
+
+func autoConvert_api_PullRequestCause_To_v1_PullRequestCause(in *build_api.PullRequestCause, out *PullRequestCause, s conversion.Scope) error {
+	out.Number = in.Number
+	out.Title = in.Title
+	out.HeadRef = in.HeadRef
+	out.Fork = in.Fork
+	return nil
+}
+
+func Convert_api_PullRequestCause_To_v1_PullRequestCause(in *build_api.PullRequestCause, out *PullRequestCause, s conversion.Scope) error {
+	return autoConvert_api_PullRequestCause_To_v1_PullRequestCause(in, out, s)
+}
+
 func autoConvert_v1_SecretBuildSource_To_api_SecretBuildSource(in *SecretBuildSource, out *build_api.SecretBuildSource, s conversion.Scope) error {
 	// TODO: Inefficient conversion - can we improve it?
 	if err := s.Convert(&in.Secret, &out.Secret, 0); err != nil {
@@ -1995,6 +2828,15 @@ func autoConvert_v1_SourceBuildStrategy_To_api_SourceBuildStrategy(in *SourceBui
 	}
 	out.Scripts = in.Scripts
 	out.Incremental = in.Incremental
+	if in.IncrementalCache != nil {
+		in, out := &in.IncrementalCache, &out.IncrementalCache
+		*out = new(build_api.IncrementalCacheSource)
+		if err := Convert_v1_IncrementalCacheSource_To_api_IncrementalCacheSource(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.IncrementalCache = nil
+	}
 	out.ForcePull = in.ForcePull
 	return nil
 }
@@ -2028,6 +2870,15 @@ func autoConvert_api_SourceBuildStrategy_To_v1_SourceBuildStrategy(in *build_api
 	}
 	out.Scripts = in.Scripts
 	out.Incremental = in.Incremental
+	if in.IncrementalCache != nil {
+		in, out := &in.IncrementalCache, &out.IncrementalCache
+		*out = new(IncrementalCacheSource)
+		if err := Convert_api_IncrementalCacheSource_To_v1_IncrementalCacheSource(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.IncrementalCache = nil
+	}
 	out.ForcePull = in.ForcePull
 	return nil
 }
@@ -2089,6 +2940,30 @@ func autoConvert_api_SourceRevision_To_v1_SourceRevision(in *build_api.SourceRev
 func autoConvert_v1_WebHookTrigger_To_api_WebHookTrigger(in *WebHookTrigger, out *build_api.WebHookTrigger, s conversion.Scope) error {
 	out.Secret = in.Secret
 	out.AllowEnv = in.AllowEnv
+	out.RequireSignature = in.RequireSignature
+	if in.IncludePaths != nil {
+		in, out := &in.IncludePaths, &out.IncludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.IncludePaths = nil
+	}
+	if in.ExcludePaths != nil {
+		in, out := &in.ExcludePaths, &out.ExcludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.ExcludePaths = nil
+	}
+	out.BuildPullRequests = in.BuildPullRequests
+	if in.PullRequestBuildsLimit != nil {
+		in, out := &in.PullRequestBuildsLimit, &out.PullRequestBuildsLimit
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.PullRequestBuildsLimit = nil
+	}
+	out.ForkPullRequestSecrets = in.ForkPullRequestSecrets
 	return nil
 }
 
@@ -2099,6 +2974,30 @@ func Convert_v1_WebHookTrigger_To_api_WebHookTrigger(in *WebHookTrigger, out *bu
 func autoConvert_api_WebHookTrigger_To_v1_WebHookTrigger(in *build_api.WebHookTrigger, out *WebHookTrigger, s conversion.Scope) error {
 	out.Secret = in.Secret
 	out.AllowEnv = in.AllowEnv
+	out.RequireSignature = in.RequireSignature
+	if in.IncludePaths != nil {
+		in, out := &in.IncludePaths, &out.IncludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.IncludePaths = nil
+	}
+	if in.ExcludePaths != nil {
+		in, out := &in.ExcludePaths, &out.ExcludePaths
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.ExcludePaths = nil
+	}
+	out.BuildPullRequests = in.BuildPullRequests
+	if in.PullRequestBuildsLimit != nil {
+		in, out := &in.PullRequestBuildsLimit, &out.PullRequestBuildsLimit
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.PullRequestBuildsLimit = nil
+	}
+	out.ForkPullRequestSecrets = in.ForkPullRequestSecrets
 	return nil
 }
 
@@ -6,6 +6,7 @@ package v1
 
 import (
 	api "k8s.io/kubernetes/pkg/api"
+	resource "k8s.io/kubernetes/pkg/api/resource"
 	unversioned "k8s.io/kubernetes/pkg/api/unversioned"
 	api_v1 "k8s.io/kubernetes/pkg/api/v1"
 	conversion "k8s.io/kubernetes/pkg/conversion"
@@ -15,7 +16,10 @@ func init() {
 	if err := api.Scheme.AddGeneratedDeepCopyFuncs(
 		DeepCopy_v1_BinaryBuildRequestOptions,
 		DeepCopy_v1_BinaryBuildSource,
+		DeepCopy_v1_BitbucketWebHookCause,
 		DeepCopy_v1_Build,
+		DeepCopy_v1_BuildCompletionCause,
+		DeepCopy_v1_BuildCompletionTrigger,
 		DeepCopy_v1_BuildConfig,
 		DeepCopy_v1_BuildConfigList,
 		DeepCopy_v1_BuildConfigSpec,
@@ -23,11 +27,17 @@ func init() {
 		DeepCopy_v1_BuildList,
 		DeepCopy_v1_BuildLog,
 		DeepCopy_v1_BuildLogOptions,
+		DeepCopy_v1_BuildMatrix,
+		DeepCopy_v1_BuildMatrixEntry,
+		DeepCopy_v1_BuildMatrixEnv,
+		DeepCopy_v1_BuildMatrixImage,
 		DeepCopy_v1_BuildOutput,
 		DeepCopy_v1_BuildPostCommitSpec,
 		DeepCopy_v1_BuildRequest,
+		DeepCopy_v1_BuildResourceUsage,
 		DeepCopy_v1_BuildSource,
 		DeepCopy_v1_BuildSpec,
+		DeepCopy_v1_BuildStageStatus,
 		DeepCopy_v1_BuildStatus,
 		DeepCopy_v1_BuildStrategy,
 		DeepCopy_v1_BuildTriggerCause,
@@ -40,12 +50,17 @@ func init() {
 		DeepCopy_v1_GitBuildSource,
 		DeepCopy_v1_GitHubWebHookCause,
 		DeepCopy_v1_GitInfo,
+		DeepCopy_v1_GitLabWebHookCause,
 		DeepCopy_v1_GitSourceRevision,
 		DeepCopy_v1_ImageChangeCause,
 		DeepCopy_v1_ImageChangeTrigger,
 		DeepCopy_v1_ImageSource,
 		DeepCopy_v1_ImageSourcePath,
+		DeepCopy_v1_IncrementalCacheSource,
 		DeepCopy_v1_JenkinsPipelineBuildStrategy,
+		DeepCopy_v1_PipelineBuildStrategy,
+		DeepCopy_v1_PipelineStage,
+		DeepCopy_v1_PullRequestCause,
 		DeepCopy_v1_SecretBuildSource,
 		DeepCopy_v1_SecretSpec,
 		DeepCopy_v1_SourceBuildStrategy,
@@ -80,6 +95,20 @@ func DeepCopy_v1_BinaryBuildSource(in BinaryBuildSource, out *BinaryBuildSource,
 	return nil
 }
 
+func DeepCopy_v1_BitbucketWebHookCause(in BitbucketWebHookCause, out *BitbucketWebHookCause, c *conversion.Cloner) error {
+	if in.Revision != nil {
+		in, out := in.Revision, &out.Revision
+		*out = new(SourceRevision)
+		if err := DeepCopy_v1_SourceRevision(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Revision = nil
+	}
+	out.Secret = in.Secret
+	return nil
+}
+
 func DeepCopy_v1_Build(in Build, out *Build, c *conversion.Cloner) error {
 	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
 		return err
@@ -96,6 +125,28 @@ func DeepCopy_v1_Build(in Build, out *Build, c *conversion.Cloner) error {
 	return nil
 }
 
+func DeepCopy_v1_BuildCompletionCause(in BuildCompletionCause, out *BuildCompletionCause, c *conversion.Cloner) error {
+	out.BuildName = in.BuildName
+	if in.FromRef != nil {
+		in, out := in.FromRef, &out.FromRef
+		*out = new(api_v1.ObjectReference)
+		if err := api_v1.DeepCopy_v1_ObjectReference(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.FromRef = nil
+	}
+	return nil
+}
+
+func DeepCopy_v1_BuildCompletionTrigger(in BuildCompletionTrigger, out *BuildCompletionTrigger, c *conversion.Cloner) error {
+	if err := api_v1.DeepCopy_v1_ObjectReference(in.From, &out.From, c); err != nil {
+		return err
+	}
+	out.LastTriggeredBuild = in.LastTriggeredBuild
+	return nil
+}
+
 func DeepCopy_v1_BuildConfig(in BuildConfig, out *BuildConfig, c *conversion.Cloner) error {
 	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
 		return err
@@ -146,6 +197,15 @@ func DeepCopy_v1_BuildConfigSpec(in BuildConfigSpec, out *BuildConfigSpec, c *co
 		out.Triggers = nil
 	}
 	out.RunPolicy = in.RunPolicy
+	if in.Matrix != nil {
+		in, out := in.Matrix, &out.Matrix
+		*out = new(BuildMatrix)
+		if err := DeepCopy_v1_BuildMatrix(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Matrix = nil
+	}
 	if err := DeepCopy_v1_CommonSpec(in.CommonSpec, &out.CommonSpec, c); err != nil {
 		return err
 	}
@@ -231,6 +291,64 @@ func DeepCopy_v1_BuildLogOptions(in BuildLogOptions, out *BuildLogOptions, c *co
 	} else {
 		out.Version = nil
 	}
+	out.Stage = in.Stage
+	return nil
+}
+
+func DeepCopy_v1_BuildMatrix(in BuildMatrix, out *BuildMatrix, c *conversion.Cloner) error {
+	if in.Images != nil {
+		in, out := in.Images, &out.Images
+		*out = make([]BuildMatrixImage, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_BuildMatrixImage(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Images = nil
+	}
+	if in.Env != nil {
+		in, out := in.Env, &out.Env
+		*out = make([]BuildMatrixEnv, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_BuildMatrixEnv(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func DeepCopy_v1_BuildMatrixEntry(in BuildMatrixEntry, out *BuildMatrixEntry, c *conversion.Cloner) error {
+	out.Group = in.Group
+	out.Image = in.Image
+	out.Env = in.Env
+	return nil
+}
+
+func DeepCopy_v1_BuildMatrixEnv(in BuildMatrixEnv, out *BuildMatrixEnv, c *conversion.Cloner) error {
+	out.Name = in.Name
+	if in.Env != nil {
+		in, out := in.Env, &out.Env
+		*out = make([]api_v1.EnvVar, len(in))
+		for i := range in {
+			if err := api_v1.DeepCopy_v1_EnvVar(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func DeepCopy_v1_BuildMatrixImage(in BuildMatrixImage, out *BuildMatrixImage, c *conversion.Cloner) error {
+	out.Name = in.Name
+	if err := api_v1.DeepCopy_v1_ObjectReference(in.From, &out.From, c); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -347,6 +465,26 @@ func DeepCopy_v1_BuildRequest(in BuildRequest, out *BuildRequest, c *conversion.
 	} else {
 		out.TriggeredBy = nil
 	}
+	if in.Matrix != nil {
+		in, out := in.Matrix, &out.Matrix
+		*out = new(BuildMatrixEntry)
+		if err := DeepCopy_v1_BuildMatrixEntry(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Matrix = nil
+	}
+	return nil
+}
+
+func DeepCopy_v1_BuildResourceUsage(in BuildResourceUsage, out *BuildResourceUsage, c *conversion.Cloner) error {
+	if err := resource.DeepCopy_resource_Quantity(in.CPU, &out.CPU, c); err != nil {
+		return err
+	}
+	if err := resource.DeepCopy_resource_Quantity(in.Memory, &out.Memory, c); err != nil {
+		return err
+	}
+	out.OutOfMemory = in.OutOfMemory
 	return nil
 }
 
@@ -430,6 +568,31 @@ func DeepCopy_v1_BuildSpec(in BuildSpec, out *BuildSpec, c *conversion.Cloner) e
 	return nil
 }
 
+func DeepCopy_v1_BuildStageStatus(in BuildStageStatus, out *BuildStageStatus, c *conversion.Cloner) error {
+	out.Name = in.Name
+	out.Phase = in.Phase
+	out.PodName = in.PodName
+	if in.StartTimestamp != nil {
+		in, out := in.StartTimestamp, &out.StartTimestamp
+		*out = new(unversioned.Time)
+		if err := unversioned.DeepCopy_unversioned_Time(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.StartTimestamp = nil
+	}
+	if in.CompletionTimestamp != nil {
+		in, out := in.CompletionTimestamp, &out.CompletionTimestamp
+		*out = new(unversioned.Time)
+		if err := unversioned.DeepCopy_unversioned_Time(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.CompletionTimestamp = nil
+	}
+	return nil
+}
+
 func DeepCopy_v1_BuildStatus(in BuildStatus, out *BuildStatus, c *conversion.Cloner) error {
 	out.Phase = in.Phase
 	out.Cancelled = in.Cancelled
@@ -464,6 +627,26 @@ func DeepCopy_v1_BuildStatus(in BuildStatus, out *BuildStatus, c *conversion.Clo
 	} else {
 		out.Config = nil
 	}
+	if in.Stages != nil {
+		in, out := in.Stages, &out.Stages
+		*out = make([]BuildStageStatus, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_BuildStageStatus(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Stages = nil
+	}
+	if in.ResourceUsage != nil {
+		in, out := in.ResourceUsage, &out.ResourceUsage
+		*out = new(BuildResourceUsage)
+		if err := DeepCopy_v1_BuildResourceUsage(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceUsage = nil
+	}
 	return nil
 }
 
@@ -505,6 +688,15 @@ func DeepCopy_v1_BuildStrategy(in BuildStrategy, out *BuildStrategy, c *conversi
 	} else {
 		out.JenkinsPipelineStrategy = nil
 	}
+	if in.PipelineStrategy != nil {
+		in, out := in.PipelineStrategy, &out.PipelineStrategy
+		*out = new(PipelineBuildStrategy)
+		if err := DeepCopy_v1_PipelineBuildStrategy(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.PipelineStrategy = nil
+	}
 	return nil
 }
 
@@ -528,6 +720,24 @@ func DeepCopy_v1_BuildTriggerCause(in BuildTriggerCause, out *BuildTriggerCause,
 	} else {
 		out.GitHubWebHook = nil
 	}
+	if in.GitLabWebHook != nil {
+		in, out := in.GitLabWebHook, &out.GitLabWebHook
+		*out = new(GitLabWebHookCause)
+		if err := DeepCopy_v1_GitLabWebHookCause(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.GitLabWebHook = nil
+	}
+	if in.BitbucketWebHook != nil {
+		in, out := in.BitbucketWebHook, &out.BitbucketWebHook
+		*out = new(BitbucketWebHookCause)
+		if err := DeepCopy_v1_BitbucketWebHookCause(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.BitbucketWebHook = nil
+	}
 	if in.ImageChangeBuild != nil {
 		in, out := in.ImageChangeBuild, &out.ImageChangeBuild
 		*out = new(ImageChangeCause)
@@ -537,6 +747,15 @@ func DeepCopy_v1_BuildTriggerCause(in BuildTriggerCause, out *BuildTriggerCause,
 	} else {
 		out.ImageChangeBuild = nil
 	}
+	if in.BuildCompletion != nil {
+		in, out := in.BuildCompletion, &out.BuildCompletion
+		*out = new(BuildCompletionCause)
+		if err := DeepCopy_v1_BuildCompletionCause(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.BuildCompletion = nil
+	}
 	return nil
 }
 
@@ -560,6 +779,24 @@ func DeepCopy_v1_BuildTriggerPolicy(in BuildTriggerPolicy, out *BuildTriggerPoli
 	} else {
 		out.GenericWebHook = nil
 	}
+	if in.GitLabWebHook != nil {
+		in, out := in.GitLabWebHook, &out.GitLabWebHook
+		*out = new(WebHookTrigger)
+		if err := DeepCopy_v1_WebHookTrigger(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.GitLabWebHook = nil
+	}
+	if in.BitbucketWebHook != nil {
+		in, out := in.BitbucketWebHook, &out.BitbucketWebHook
+		*out = new(WebHookTrigger)
+		if err := DeepCopy_v1_WebHookTrigger(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.BitbucketWebHook = nil
+	}
 	if in.ImageChange != nil {
 		in, out := in.ImageChange, &out.ImageChange
 		*out = new(ImageChangeTrigger)
@@ -569,6 +806,15 @@ func DeepCopy_v1_BuildTriggerPolicy(in BuildTriggerPolicy, out *BuildTriggerPoli
 	} else {
 		out.ImageChange = nil
 	}
+	if in.BuildCompletion != nil {
+		in, out := in.BuildCompletion, &out.BuildCompletion
+		*out = new(BuildCompletionTrigger)
+		if err := DeepCopy_v1_BuildCompletionTrigger(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.BuildCompletion = nil
+	}
 	return nil
 }
 
@@ -755,6 +1001,15 @@ func DeepCopy_v1_GitHubWebHookCause(in GitHubWebHookCause, out *GitHubWebHookCau
 		out.Revision = nil
 	}
 	out.Secret = in.Secret
+	if in.PullRequest != nil {
+		in, out := in.PullRequest, &out.PullRequest
+		*out = new(PullRequestCause)
+		if err := DeepCopy_v1_PullRequestCause(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.PullRequest = nil
+	}
 	return nil
 }
 
@@ -765,6 +1020,27 @@ func DeepCopy_v1_GitInfo(in GitInfo, out *GitInfo, c *conversion.Cloner) error {
 	if err := DeepCopy_v1_GitSourceRevision(in.GitSourceRevision, &out.GitSourceRevision, c); err != nil {
 		return err
 	}
+	if in.ChangedPaths != nil {
+		in, out := in.ChangedPaths, &out.ChangedPaths
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.ChangedPaths = nil
+	}
+	return nil
+}
+
+func DeepCopy_v1_GitLabWebHookCause(in GitLabWebHookCause, out *GitLabWebHookCause, c *conversion.Cloner) error {
+	if in.Revision != nil {
+		in, out := in.Revision, &out.Revision
+		*out = new(SourceRevision)
+		if err := DeepCopy_v1_SourceRevision(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Revision = nil
+	}
+	out.Secret = in.Secret
 	return nil
 }
 
@@ -841,12 +1117,93 @@ func DeepCopy_v1_ImageSourcePath(in ImageSourcePath, out *ImageSourcePath, c *co
 	return nil
 }
 
+func DeepCopy_v1_IncrementalCacheSource(in IncrementalCacheSource, out *IncrementalCacheSource, c *conversion.Cloner) error {
+	if in.PersistentVolumeClaim != nil {
+		in, out := in.PersistentVolumeClaim, &out.PersistentVolumeClaim
+		*out = new(api_v1.LocalObjectReference)
+		if err := api_v1.DeepCopy_v1_LocalObjectReference(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.PersistentVolumeClaim = nil
+	}
+	if in.Image != nil {
+		in, out := in.Image, &out.Image
+		*out = new(api_v1.ObjectReference)
+		if err := api_v1.DeepCopy_v1_ObjectReference(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Image = nil
+	}
+	return nil
+}
+
 func DeepCopy_v1_JenkinsPipelineBuildStrategy(in JenkinsPipelineBuildStrategy, out *JenkinsPipelineBuildStrategy, c *conversion.Cloner) error {
 	out.JenkinsfilePath = in.JenkinsfilePath
 	out.Jenkinsfile = in.Jenkinsfile
 	return nil
 }
 
+func DeepCopy_v1_PipelineBuildStrategy(in PipelineBuildStrategy, out *PipelineBuildStrategy, c *conversion.Cloner) error {
+	if in.Stages != nil {
+		in, out := in.Stages, &out.Stages
+		*out = make([]PipelineStage, len(in))
+		for i := range in {
+			if err := DeepCopy_v1_PipelineStage(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Stages = nil
+	}
+	if in.Env != nil {
+		in, out := in.Env, &out.Env
+		*out = make([]api_v1.EnvVar, len(in))
+		for i := range in {
+			if err := api_v1.DeepCopy_v1_EnvVar(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	out.ForcePull = in.ForcePull
+	return nil
+}
+
+func DeepCopy_v1_PipelineStage(in PipelineStage, out *PipelineStage, c *conversion.Cloner) error {
+	out.Name = in.Name
+	out.Image = in.Image
+	if in.Command != nil {
+		in, out := in.Command, &out.Command
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.Command = nil
+	}
+	if in.Env != nil {
+		in, out := in.Env, &out.Env
+		*out = make([]api_v1.EnvVar, len(in))
+		for i := range in {
+			if err := api_v1.DeepCopy_v1_EnvVar(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Env = nil
+	}
+	return nil
+}
+
+func DeepCopy_v1_PullRequestCause(in PullRequestCause, out *PullRequestCause, c *conversion.Cloner) error {
+	out.Number = in.Number
+	out.Title = in.Title
+	out.HeadRef = in.HeadRef
+	out.Fork = in.Fork
+	return nil
+}
+
 func DeepCopy_v1_SecretBuildSource(in SecretBuildSource, out *SecretBuildSource, c *conversion.Cloner) error {
 	if err := api_v1.DeepCopy_v1_LocalObjectReference(in.Secret, &out.Secret, c); err != nil {
 		return err
@@ -889,6 +1246,15 @@ func DeepCopy_v1_SourceBuildStrategy(in SourceBuildStrategy, out *SourceBuildStr
 	}
 	out.Scripts = in.Scripts
 	out.Incremental = in.Incremental
+	if in.IncrementalCache != nil {
+		in, out := in.IncrementalCache, &out.IncrementalCache
+		*out = new(IncrementalCacheSource)
+		if err := DeepCopy_v1_IncrementalCacheSource(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.IncrementalCache = nil
+	}
 	out.ForcePull = in.ForcePull
 	return nil
 }
@@ -916,5 +1282,29 @@ func DeepCopy_v1_SourceRevision(in SourceRevision, out *SourceRevision, c *conve
 func DeepCopy_v1_WebHookTrigger(in WebHookTrigger, out *WebHookTrigger, c *conversion.Cloner) error {
 	out.Secret = in.Secret
 	out.AllowEnv = in.AllowEnv
+	out.RequireSignature = in.RequireSignature
+	if in.IncludePaths != nil {
+		in, out := in.IncludePaths, &out.IncludePaths
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.IncludePaths = nil
+	}
+	if in.ExcludePaths != nil {
+		in, out := in.ExcludePaths, &out.ExcludePaths
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.ExcludePaths = nil
+	}
+	out.BuildPullRequests = in.BuildPullRequests
+	if in.PullRequestBuildsLimit != nil {
+		in, out := in.PullRequestBuildsLimit, &out.PullRequestBuildsLimit
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.PullRequestBuildsLimit = nil
+	}
+	out.ForkPullRequestSecrets = in.ForkPullRequestSecrets
 	return nil
 }
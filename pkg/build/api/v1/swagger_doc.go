@@ -30,6 +30,16 @@ func (BinaryBuildSource) SwaggerDoc() map[string]string {
 	return map_BinaryBuildSource
 }
 
+var map_BitbucketWebHookCause = map[string]string{
+	"":         "BitbucketWebHookCause has information about a Bitbucket webhook that triggered a build.",
+	"revision": "revision is the git source revision information of the trigger.",
+	"secret":   "secret is the obfuscated webhook secret that triggered a build.",
+}
+
+func (BitbucketWebHookCause) SwaggerDoc() map[string]string {
+	return map_BitbucketWebHookCause
+}
+
 var map_Build = map[string]string{
 	"":         "Build encapsulates the inputs needed to produce a new deployable image, as well as the status of the execution and a reference to the Pod which executed the build.",
 	"metadata": "Standard object's metadata.",
@@ -41,6 +51,26 @@ func (Build) SwaggerDoc() map[string]string {
 	return map_Build
 }
 
+var map_BuildCompletionCause = map[string]string{
+	"":          "BuildCompletionCause holds information about the completed build of another BuildConfig that triggered a build.",
+	"buildName": "buildName is the name of the build whose completion triggered a new build.",
+	"fromRef":   "fromRef is the reference to the BuildConfig of the completed build.",
+}
+
+func (BuildCompletionCause) SwaggerDoc() map[string]string {
+	return map_BuildCompletionCause
+}
+
+var map_BuildCompletionTrigger = map[string]string{
+	"":                   "BuildCompletionTrigger allows builds to be triggered when a build of another BuildConfig completes successfully.",
+	"from":               "from is a reference to the BuildConfig in the same namespace whose successful builds trigger a build.",
+	"lastTriggeredBuild": "lastTriggeredBuild is used internally by the BuildCompletionController to save the name of the last build that triggered a build.",
+}
+
+func (BuildCompletionTrigger) SwaggerDoc() map[string]string {
+	return map_BuildCompletionTrigger
+}
+
 var map_BuildConfig = map[string]string{
 	"":         "BuildConfig is a template which can be used to create new builds.",
 	"metadata": "metadata for BuildConfig.",
@@ -66,6 +96,7 @@ var map_BuildConfigSpec = map[string]string{
 	"":          "BuildConfigSpec describes when and how builds are created",
 	"triggers":  "triggers determine how new Builds can be launched from a BuildConfig. If no triggers are defined, a new build can only occur as a result of an explicit client build creation.",
 	"runPolicy": "RunPolicy describes how the new build created from this build configuration will be scheduled for execution. This is optional, if not specified we default to \"Serial\".",
+	"matrix":    "matrix declares the builder images and environment variable sets the BuildConfig is built with when it is instantiated as a build matrix.",
 }
 
 func (BuildConfigSpec) SwaggerDoc() map[string]string {
@@ -111,12 +142,54 @@ var map_BuildLogOptions = map[string]string{
 	"limitBytes":   "limitBytes, If set, is the number of bytes to read from the server before terminating the log output. This may not display a complete final line of logging, and may return slightly more or slightly less than the specified limit.",
 	"nowait":       "noWait if true causes the call to return immediately even if the build is not available yet. Otherwise the server will wait until the build has started.",
 	"version":      "version of the build for which to view logs.",
+	"stage":        "stage is the name of the stage of a Pipeline build for which to stream logs. Defaults to the latest stage that started.",
 }
 
 func (BuildLogOptions) SwaggerDoc() map[string]string {
 	return map_BuildLogOptions
 }
 
+var map_BuildMatrix = map[string]string{
+	"":       "BuildMatrix declares the builder images and environment variable sets a BuildConfig is built with when it is instantiated as a matrix. One build is created for every combination of an image and an environment variable set.",
+	"images": "images is the list of builder images replacing the image of the strategy.",
+	"env":    "env is the list of environment variable sets added to the environment of the strategy.",
+}
+
+func (BuildMatrix) SwaggerDoc() map[string]string {
+	return map_BuildMatrix
+}
+
+var map_BuildMatrixEntry = map[string]string{
+	"":      "BuildMatrixEntry selects a combination of the images and environment variable sets of a build matrix.",
+	"group": "group identifies the set of builds instantiated together from the build matrix.",
+	"image": "image is the name of the matrix image to build with.",
+	"env":   "env is the name of the matrix environment variable set to build with.",
+}
+
+func (BuildMatrixEntry) SwaggerDoc() map[string]string {
+	return map_BuildMatrixEntry
+}
+
+var map_BuildMatrixEnv = map[string]string{
+	"":     "BuildMatrixEnv is an environment variable set of a build matrix.",
+	"name": "name identifies the set in the matrix and is appended to the output tag of the builds using it. It must be unique among the images and environment variable sets of the matrix.",
+	"env":  "env is the list of environment variables to build with.",
+}
+
+func (BuildMatrixEnv) SwaggerDoc() map[string]string {
+	return map_BuildMatrixEnv
+}
+
+var map_BuildMatrixImage = map[string]string{
+	"":     "BuildMatrixImage is a builder image of a build matrix.",
+	"name": "name identifies the image in the matrix and is appended to the output tag of the builds using it. It must be unique among the images and environment variable sets of the matrix.",
+	"from": "from is the builder image to build with.",
+}
+
+func (BuildMatrixImage) SwaggerDoc() map[string]string {
+	return map_BuildMatrixImage
+}
+
 var map_BuildOutput = map[string]string{
 	"":           "BuildOutput is input to a build strategy and describes the Docker image that the strategy should produce.",
 	"to":         "to defines an optional location to push the output of this build to. Kind must be one of 'ImageStreamTag' or 'DockerImage'. This value will be used to look up a Docker image repository to push to. In the case of an ImageStreamTag, the ImageStreamTag will be looked for in the namespace of the build unless Namespace is specified.",
@@ -148,12 +221,24 @@ var map_BuildRequest = map[string]string{
 	"lastVersion":      "lastVersion (optional) is the LastVersion of the BuildConfig that was used to generate the build. If the BuildConfig in the generator doesn't match, a build will not be generated.",
 	"env":              "env contains additional environment variables you want to pass into a builder container",
 	"triggeredBy":      "triggeredBy describes which triggers started the most recent update to the build configuration and contains information about those triggers.",
+	"matrix":           "matrix (optional) selects the entry of the build matrix of the BuildConfig the build is instantiated for.",
 }
 
 func (BuildRequest) SwaggerDoc() map[string]string {
 	return map_BuildRequest
 }
 
+var map_BuildResourceUsage = map[string]string{
+	"":            "BuildResourceUsage describes the peak usage of compute resources by a build.",
+	"cpu":         "cpu is the highest CPU usage of the build, sampled while the build ran.",
+	"memory":      "memory is the highest memory usage of the build.",
+	"outOfMemory": "outOfMemory is true if the build ran out of memory, in which case memory is the memory limit the build ran into.",
+}
+
+func (BuildResourceUsage) SwaggerDoc() map[string]string {
+	return map_BuildResourceUsage
+}
+
 var map_BuildSource = map[string]string{
 	"":             "BuildSource is the SCM used for the build.",
 	"type":         "type of build input to accept",
@@ -179,6 +264,19 @@ func (BuildSpec) SwaggerDoc() map[string]string {
 	return map_BuildSpec
 }
 
+var map_BuildStageStatus = map[string]string{
+	"":                    "BuildStageStatus describes the status of a stage of a Pipeline build.",
+	"name":                "name is the name of the stage.",
+	"phase":               "phase is the point in the stage lifecycle.",
+	"podName":             "podName is the name of the pod running the stage.",
+	"startTimestamp":      "startTimestamp is a timestamp representing the server time when the stage started running.",
+	"completionTimestamp": "completionTimestamp is a timestamp representing the server time when the stage finished, whether it failed or succeeded.",
+}
+
+func (BuildStageStatus) SwaggerDoc() map[string]string {
+	return map_BuildStageStatus
+}
+
 var map_BuildStatus = map[string]string{
 	"":                           "BuildStatus contains the status of a build",
 	"phase":                      "phase is the point in the build lifecycle.",
@@ -190,6 +288,8 @@ var map_BuildStatus = map[string]string{
 	"duration":                   "duration contains time.Duration object describing build time.",
 	"outputDockerImageReference": "outputDockerImageReference contains a reference to the Docker image that will be built by this build. Its value is computed from Build.Spec.Output.To, and should include the registry address, so that it can be used to push and pull the image.",
 	"config":                     "config is an ObjectReference to the BuildConfig this Build is based on.",
+	"stages":                     "stages holds the status of the stages of a Pipeline build that have started, in the order they ran.",
+	"resourceUsage":              "resourceUsage is the peak usage of compute resources by the build and the containers it ran, recorded when the build completed.",
 }
 
 func (BuildStatus) SwaggerDoc() map[string]string {
@@ -203,6 +303,7 @@ var map_BuildStrategy = map[string]string{
 	"sourceStrategy":          "sourceStrategy holds the parameters to the Source build strategy.",
 	"customStrategy":          "customStrategy holds the parameters to the Custom build strategy",
 	"jenkinsPipelineStrategy": "JenkinsPipelineStrategy holds the parameters to the Jenkins Pipeline build strategy. This strategy is experimental.",
+	"pipelineStrategy":        "pipelineStrategy holds the parameters to the Pipeline build strategy, which runs the stages of a pipeline defined in the build without a Jenkins server.",
 }
 
 func (BuildStrategy) SwaggerDoc() map[string]string {
@@ -214,7 +315,10 @@ var map_BuildTriggerCause = map[string]string{
 	"message":          "message is used to store a human readable message for why the build was triggered. E.g.: \"Manually triggered by user\", \"Configuration change\",etc.",
 	"genericWebHook":   "genericWebHook holds data about a builds generic webhook trigger.",
 	"githubWebHook":    "gitHubWebHook represents data for a GitHub webhook that fired a specific build.",
+	"gitlabWebHook":    "gitlabWebHook represents data for a GitLab webhook that fired a specific build.",
+	"bitbucketWebHook": "bitbucketWebHook represents data for a Bitbucket webhook that fired a specific build.",
 	"imageChangeBuild": "imageChangeBuild stores information about an imagechange event that triggered a new build.",
+	"buildCompletion":  "buildCompletion stores information about the completed build of another BuildConfig that triggered a new build.",
 }
 
 func (BuildTriggerCause) SwaggerDoc() map[string]string {
@@ -222,11 +326,14 @@ func (BuildTriggerCause) SwaggerDoc() map[string]string {
 }
 
 var map_BuildTriggerPolicy = map[string]string{
-	"":            "BuildTriggerPolicy describes a policy for a single trigger that results in a new Build.",
-	"type":        "type is the type of build trigger",
-	"github":      "github contains the parameters for a GitHub webhook type of trigger",
-	"generic":     "generic contains the parameters for a Generic webhook type of trigger",
-	"imageChange": "imageChange contains parameters for an ImageChange type of trigger",
+	"":                "BuildTriggerPolicy describes a policy for a single trigger that results in a new Build.",
+	"type":            "type is the type of build trigger",
+	"github":          "github contains the parameters for a GitHub webhook type of trigger",
+	"generic":         "generic contains the parameters for a Generic webhook type of trigger",
+	"gitlab":          "gitlab contains the parameters for a GitLab webhook type of trigger",
+	"bitbucket":       "bitbucket contains the parameters for a Bitbucket webhook type of trigger",
+	"imageChange":     "imageChange contains parameters for an ImageChange type of trigger",
+	"buildCompletion": "buildCompletion contains parameters for a BuildCompletion type of trigger",
 }
 
 func (BuildTriggerPolicy) SwaggerDoc() map[string]string {
@@ -312,9 +419,10 @@ func (GitBuildSource) SwaggerDoc() map[string]string {
 }
 
 var map_GitHubWebHookCause = map[string]string{
-	"":         "GitHubWebHookCause has information about a GitHub webhook that triggered a build.",
-	"revision": "revision is the git revision information of the trigger.",
-	"secret":   "secret is the obfuscated webhook secret that triggered a build.",
+	"":            "GitHubWebHookCause has information about a GitHub webhook that triggered a build.",
+	"revision":    "revision is the git revision information of the trigger.",
+	"secret":      "secret is the obfuscated webhook secret that triggered a build.",
+	"pullRequest": "pullRequest identifies the pull request the build was started for. It is only set when the build was triggered by a pull request event.",
 }
 
 func (GitHubWebHookCause) SwaggerDoc() map[string]string {
@@ -322,13 +430,24 @@ func (GitHubWebHookCause) SwaggerDoc() map[string]string {
 }
 
 var map_GitInfo = map[string]string{
-	"": "GitInfo is the aggregated git information for a generic webhook post",
+	"":             "GitInfo is the aggregated git information for a generic webhook post",
+	"changedPaths": "changedPaths is the list of paths changed by the commits being pushed. It is checked against the path filters of the webhook trigger, if any.",
 }
 
 func (GitInfo) SwaggerDoc() map[string]string {
 	return map_GitInfo
 }
 
+var map_GitLabWebHookCause = map[string]string{
+	"":         "GitLabWebHookCause has information about a GitLab webhook that triggered a build.",
+	"revision": "revision is the git source revision information of the trigger.",
+	"secret":   "secret is the obfuscated webhook secret that triggered a build.",
+}
+
+func (GitLabWebHookCause) SwaggerDoc() map[string]string {
+	return map_GitLabWebHookCause
+}
+
 var map_GitSourceRevision = map[string]string{
 	"":          "GitSourceRevision is the commit information from a git source for a build",
 	"commit":    "commit is the commit hash identifying a specific commit",
@@ -363,7 +482,7 @@ func (ImageChangeTrigger) SwaggerDoc() map[string]string {
 
 var map_ImageSource = map[string]string{
 	"":           "ImageSource describes an image that is used as source for the build",
-	"from":       "from is a reference to an ImageStreamTag, ImageStreamImage, or DockerImage to copy source from.",
+	"from":       "from is a reference to an ImageStreamTag, ImageStreamImage, or DockerImage to copy source from. It can also be a reference to a BuildConfig in the same namespace, in which case the output image of its latest successful build is used.",
 	"paths":      "paths is a list of source and destination paths to copy from the image.",
 	"pullSecret": "pullSecret is a reference to a secret to be used to pull the image from a registry If the image is pulled from the OpenShift registry, this field does not need to be set.",
 }
@@ -382,6 +501,16 @@ func (ImageSourcePath) SwaggerDoc() map[string]string {
 	return map_ImageSourcePath
 }
 
+var map_IncrementalCacheSource = map[string]string{
+	"":                      "IncrementalCacheSource describes a persistent store for the artifacts saved by incremental Source builds. Exactly one of the fields must be set.",
+	"persistentVolumeClaim": "persistentVolumeClaim is a claim in the namespace of the build that is mounted into the build pod. The artifacts of the last successful build are extracted into it and restored from it without pulling any image.",
+	"image":                 "image is a reference to an ImageStreamTag or DockerImage that the built image is pushed to after every successful build, and that artifacts are restored from on the next build. It is pushed with the push secret of the build output.",
+}
+
+func (IncrementalCacheSource) SwaggerDoc() map[string]string {
+	return map_IncrementalCacheSource
+}
+
 var map_JenkinsPipelineBuildStrategy = map[string]string{
 	"":                "JenkinsPipelineBuildStrategy holds parameters specific to a Jenkins Pipeline build. This strategy is experimental.",
 	"jenkinsfilePath": "JenkinsfilePath is the optional path of the Jenkinsfile that will be used to configure the pipeline relative to the root of the context (contextDir). If both JenkinsfilePath & Jenkinsfile are both not specified, this defaults to Jenkinsfile in the root of the specified contextDir.",
@@ -392,6 +521,41 @@ func (JenkinsPipelineBuildStrategy) SwaggerDoc() map[string]string {
 	return map_JenkinsPipelineBuildStrategy
 }
 
+var map_PipelineBuildStrategy = map[string]string{
+	"":          "PipelineBuildStrategy holds parameters specific to a Pipeline build. The stages of the pipeline run one after the other, each in its own pod, and the build fails as soon as one of them fails.",
+	"stages":    "stages are the steps of the pipeline, in the order they run.",
+	"env":       "env contains additional environment variables passed to the container of every stage.",
+	"forcePull": "forcePull describes if the stage images should always be pulled before a stage runs.",
+}
+
+func (PipelineBuildStrategy) SwaggerDoc() map[string]string {
+	return map_PipelineBuildStrategy
+}
+
+var map_PipelineStage = map[string]string{
+	"":        "PipelineStage is a step of a Pipeline build, run as a container.",
+	"name":    "name identifies the stage within the pipeline.",
+	"image":   "image is the Docker image the stage runs.",
+	"command": "command is the command run in the stage container. When not specified the entrypoint of the image is run.",
+	"env":     "env contains environment variables passed to the stage container in addition to the ones of the strategy.",
+}
+
+func (PipelineStage) SwaggerDoc() map[string]string {
+	return map_PipelineStage
+}
+
+var map_PullRequestCause = map[string]string{
+	"":        "PullRequestCause identifies the pull request that triggered a build.",
+	"number":  "number is the number of the pull request.",
+	"title":   "title is the title of the pull request.",
+	"headRef": "headRef is the branch the pull request was opened from.",
+	"fork":    "fork is true if the pull request was opened from another repository than the one it is merged into.",
+}
+
+func (PullRequestCause) SwaggerDoc() map[string]string {
+	return map_PullRequestCause
+}
+
 var map_SecretBuildSource = map[string]string{
 	"":               "SecretBuildSource describes a secret and its destination directory that will be used only at the build time. The content of the secret referenced here will be copied into the destination directory instead of mounting.",
 	"secret":         "secret is a reference to an existing secret that you want to use in your build.",
@@ -413,13 +577,14 @@ func (SecretSpec) SwaggerDoc() map[string]string {
 }
 
 var map_SourceBuildStrategy = map[string]string{
-	"":            "SourceBuildStrategy defines input parameters specific to an Source build.",
-	"from":        "from is reference to an DockerImage, ImageStreamTag, or ImageStreamImage from which the docker image should be pulled",
-	"pullSecret":  "pullSecret is the name of a Secret that would be used for setting up the authentication for pulling the Docker images from the private Docker registries",
-	"env":         "env contains additional environment variables you want to pass into a builder container",
-	"scripts":     "scripts is the location of Source scripts",
-	"incremental": "incremental flag forces the Source build to do incremental builds if true.",
-	"forcePull":   "forcePull describes if the builder should pull the images from registry prior to building.",
+	"":                 "SourceBuildStrategy defines input parameters specific to an Source build.",
+	"from":             "from is reference to an DockerImage, ImageStreamTag, or ImageStreamImage from which the docker image should be pulled",
+	"pullSecret":       "pullSecret is the name of a Secret that would be used for setting up the authentication for pulling the Docker images from the private Docker registries",
+	"env":              "env contains additional environment variables you want to pass into a builder container",
+	"scripts":          "scripts is the location of Source scripts",
+	"incremental":      "incremental flag forces the Source build to do incremental builds if true.",
+	"incrementalCache": "incrementalCache is where the artifacts of incremental builds are kept between builds. When unset, artifacts are restored from the image pushed by the previous build. Requires incremental to be true.",
+	"forcePull":        "forcePull describes if the builder should pull the images from registry prior to building.",
 }
 
 func (SourceBuildStrategy) SwaggerDoc() map[string]string {
@@ -447,9 +612,15 @@ func (SourceRevision) SwaggerDoc() map[string]string {
 }
 
 var map_WebHookTrigger = map[string]string{
-	"":         "WebHookTrigger is a trigger that gets invoked using a webhook type of post",
-	"secret":   "secret used to validate requests.",
-	"allowEnv": "allowEnv determines whether the webhook can set environment variables; can only be set to true for GenericWebHook.",
+	"":                       "WebHookTrigger is a trigger that gets invoked using a webhook type of post",
+	"secret":                 "secret used to validate requests.",
+	"allowEnv":               "allowEnv determines whether the webhook can set environment variables; can only be set to true for GenericWebHook.",
+	"requireSignature":       "requireSignature determines whether the webhook payload must be signed with an HMAC of the request body keyed by secret, as sent by GitHub in the X-Hub-Signature header; can only be set to true for GitHubWebHook.",
+	"includePaths":           "includePaths is a list of glob patterns for the paths of the source repository a build depends on. When set, the webhook only starts a build if a path changed by the push matches one of them. A pattern that matches a directory matches every path below it. Can only be set for GitHubWebHook and GenericWebHook. Pushes whose changed paths are unknown, such as GitHub pushes of 20 commits or more, always start a build.",
+	"excludePaths":           "excludePaths is a list of glob patterns for paths of the source repository whose changes never start a build, even when matched by includePaths. Can only be set for GitHubWebHook and GenericWebHook.",
+	"buildPullRequests":      "buildPullRequests determines whether the webhook also starts builds for pull requests opened or synchronized against the source ref; can only be set to true for GitHubWebHook. The images built for pull requests are pushed to the output tag suffixed with -pr-<number>.",
+	"pullRequestBuildsLimit": "pullRequestBuildsLimit is the maximum number of pull request builds kept for the BuildConfig. When exceeded, the oldest completed pull request builds are deleted. Pull request builds are not pruned if unset.",
+	"forkPullRequestSecrets": "forkPullRequestSecrets determines whether the builds of pull requests opened from forks of the repository get the secrets of the BuildConfig and the pull secrets of its service account, and use its incremental build cache. Without the push secret, those builds don't push their image. It can only be set to true when buildPullRequests is true.",
 }
 
 func (WebHookTrigger) SwaggerDoc() map[string]string {
@@ -3,6 +3,7 @@ package v1
 import (
 	"time"
 
+	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	kapi "k8s.io/kubernetes/pkg/api/v1"
 )
@@ -82,9 +83,21 @@ type BuildTriggerCause struct {
 	//specific build.
 	GitHubWebHook *GitHubWebHookCause `json:"githubWebHook,omitempty"`
 
+	// gitlabWebHook represents data for a GitLab webhook that fired a specific
+	// build.
+	GitLabWebHook *GitLabWebHookCause `json:"gitlabWebHook,omitempty"`
+
+	// bitbucketWebHook represents data for a Bitbucket webhook that fired a
+	// specific build.
+	BitbucketWebHook *BitbucketWebHookCause `json:"bitbucketWebHook,omitempty"`
+
 	// imageChangeBuild stores information about an imagechange event
 	// that triggered a new build.
 	ImageChangeBuild *ImageChangeCause `json:"imageChangeBuild,omitempty"`
+
+	// buildCompletion stores information about the completed build of another
+	// BuildConfig that triggered a new build.
+	BuildCompletion *BuildCompletionCause `json:"buildCompletion,omitempty"`
 }
 
 // GenericWebHookCause holds information about a generic WebHook that
@@ -106,6 +119,46 @@ type GitHubWebHookCause struct {
 
 	// secret is the obfuscated webhook secret that triggered a build.
 	Secret string `json:"secret,omitempty"`
+
+	// pullRequest identifies the pull request the build was started for. It is
+	// only set when the build was triggered by a pull request event.
+	PullRequest *PullRequestCause `json:"pullRequest,omitempty"`
+}
+
+// PullRequestCause identifies the pull request that triggered a build.
+type PullRequestCause struct {
+	// number is the number of the pull request.
+	Number int64 `json:"number"`
+
+	// title is the title of the pull request.
+	Title string `json:"title,omitempty"`
+
+	// headRef is the branch the pull request was opened from.
+	HeadRef string `json:"headRef,omitempty"`
+
+	// fork is true if the pull request was opened from another repository than
+	// the one it is merged into.
+	Fork bool `json:"fork,omitempty"`
+}
+
+// GitLabWebHookCause has information about a GitLab webhook that triggered a
+// build.
+type GitLabWebHookCause struct {
+	// revision is the git source revision information of the trigger.
+	Revision *SourceRevision `json:"revision,omitempty"`
+
+	// secret is the obfuscated webhook secret that triggered a build.
+	Secret string `json:"secret,omitempty"`
+}
+
+// BitbucketWebHookCause has information about a Bitbucket webhook that
+// triggered a build.
+type BitbucketWebHookCause struct {
+	// revision is the git source revision information of the trigger.
+	Revision *SourceRevision `json:"revision,omitempty"`
+
+	// secret is the obfuscated webhook secret that triggered a build.
+	Secret string `json:"secret,omitempty"`
 }
 
 // ImageChangeCause contains information about the image that triggered a
@@ -119,6 +172,16 @@ type ImageChangeCause struct {
 	FromRef *kapi.ObjectReference `json:"fromRef,omitempty"`
 }
 
+// BuildCompletionCause holds information about the completed build of another
+// BuildConfig that triggered a build.
+type BuildCompletionCause struct {
+	// buildName is the name of the build whose completion triggered a new build.
+	BuildName string `json:"buildName,omitempty"`
+
+	// fromRef is the reference to the BuildConfig of the completed build.
+	FromRef *kapi.ObjectReference `json:"fromRef,omitempty"`
+}
+
 // BuildStatus contains the status of a build
 type BuildStatus struct {
 	// phase is the point in the build lifecycle.
@@ -155,6 +218,47 @@ type BuildStatus struct {
 
 	// config is an ObjectReference to the BuildConfig this Build is based on.
 	Config *kapi.ObjectReference `json:"config,omitempty"`
+
+	// stages holds the status of the stages of a Pipeline build that have started,
+	// in the order they ran.
+	Stages []BuildStageStatus `json:"stages,omitempty"`
+
+	// resourceUsage is the peak usage of compute resources by the build and the
+	// containers it ran, recorded when the build completed.
+	ResourceUsage *BuildResourceUsage `json:"resourceUsage,omitempty"`
+}
+
+// BuildResourceUsage describes the peak usage of compute resources by a build.
+type BuildResourceUsage struct {
+	// cpu is the highest CPU usage of the build, sampled while the build ran.
+	CPU resource.Quantity `json:"cpu"`
+
+	// memory is the highest memory usage of the build.
+	Memory resource.Quantity `json:"memory"`
+
+	// outOfMemory is true if the build ran out of memory, in which case memory
+	// is the memory limit the build ran into.
+	OutOfMemory bool `json:"outOfMemory,omitempty"`
+}
+
+// BuildStageStatus describes the status of a stage of a Pipeline build.
+type BuildStageStatus struct {
+	// name is the name of the stage.
+	Name string `json:"name"`
+
+	// phase is the point in the stage lifecycle.
+	Phase BuildPhase `json:"phase"`
+
+	// podName is the name of the pod running the stage.
+	PodName string `json:"podName,omitempty"`
+
+	// startTimestamp is a timestamp representing the server time when the stage
+	// started running.
+	StartTimestamp *unversioned.Time `json:"startTimestamp,omitempty"`
+
+	// completionTimestamp is a timestamp representing the server time when the stage
+	// finished, whether it failed or succeeded.
+	CompletionTimestamp *unversioned.Time `json:"completionTimestamp,omitempty"`
 }
 
 // BuildPhase represents the status of a build at a point in time.
@@ -256,7 +360,8 @@ type BuildSource struct {
 // ImageSource describes an image that is used as source for the build
 type ImageSource struct {
 	// from is a reference to an ImageStreamTag, ImageStreamImage, or DockerImage to
-	// copy source from.
+	// copy source from. It can also be a reference to a BuildConfig in the same
+	// namespace, in which case the output image of its latest successful build is used.
 	From kapi.ObjectReference `json:"from"`
 
 	// paths is a list of source and destination paths to copy from the image.
@@ -377,6 +482,10 @@ type BuildStrategy struct {
 	// JenkinsPipelineStrategy holds the parameters to the Jenkins Pipeline build strategy.
 	// This strategy is experimental.
 	JenkinsPipelineStrategy *JenkinsPipelineBuildStrategy `json:"jenkinsPipelineStrategy,omitempty"`
+
+	// pipelineStrategy holds the parameters to the Pipeline build strategy, which runs
+	// the stages of a pipeline defined in the build without a Jenkins server.
+	PipelineStrategy *PipelineBuildStrategy `json:"pipelineStrategy,omitempty"`
 }
 
 // BuildStrategyType describes a particular way of performing a build.
@@ -396,6 +505,9 @@ const (
 
 	// JenkinsPipelineBuildStrategyType indicates the build will run via Jenkine Pipeline.
 	JenkinsPipelineBuildStrategyType BuildStrategyType = "JenkinsPipeline"
+
+	// PipelineBuildStrategyType runs the stages of a pipeline defined in the build.
+	PipelineBuildStrategyType BuildStrategyType = "Pipeline"
 )
 
 // CustomBuildStrategy defines input parameters specific to Custom build.
@@ -475,10 +587,30 @@ type SourceBuildStrategy struct {
 	// incremental flag forces the Source build to do incremental builds if true.
 	Incremental bool `json:"incremental,omitempty"`
 
+	// incrementalCache is where the artifacts of incremental builds are kept
+	// between builds. When unset, artifacts are restored from the image pushed
+	// by the previous build. Requires incremental to be true.
+	IncrementalCache *IncrementalCacheSource `json:"incrementalCache,omitempty"`
+
 	// forcePull describes if the builder should pull the images from registry prior to building.
 	ForcePull bool `json:"forcePull,omitempty"`
 }
 
+// IncrementalCacheSource describes a persistent store for the artifacts saved
+// by incremental Source builds. Exactly one of the fields must be set.
+type IncrementalCacheSource struct {
+	// persistentVolumeClaim is a claim in the namespace of the build that is
+	// mounted into the build pod. The artifacts of the last successful build
+	// are extracted into it and restored from it without pulling any image.
+	PersistentVolumeClaim *kapi.LocalObjectReference `json:"persistentVolumeClaim,omitempty"`
+
+	// image is a reference to an ImageStreamTag or DockerImage that the built
+	// image is pushed to after every successful build, and that artifacts are
+	// restored from on the next build. It is pushed with the push secret of
+	// the build output.
+	Image *kapi.ObjectReference `json:"image,omitempty"`
+}
+
 // JenkinsPipelineBuildStrategy holds parameters specific to a Jenkins Pipeline build.
 // This strategy is experimental.
 type JenkinsPipelineBuildStrategy struct {
@@ -491,6 +623,37 @@ type JenkinsPipelineBuildStrategy struct {
 	Jenkinsfile string `json:"jenkinsfile,omitempty"`
 }
 
+// PipelineBuildStrategy holds parameters specific to a Pipeline build. The stages
+// of the pipeline run one after the other, each in its own pod, and the build
+// fails as soon as one of them fails.
+type PipelineBuildStrategy struct {
+	// stages are the steps of the pipeline, in the order they run.
+	Stages []PipelineStage `json:"stages"`
+
+	// env contains additional environment variables passed to the container of every stage.
+	Env []kapi.EnvVar `json:"env,omitempty"`
+
+	// forcePull describes if the stage images should always be pulled before a stage runs.
+	ForcePull bool `json:"forcePull,omitempty"`
+}
+
+// PipelineStage is a step of a Pipeline build, run as a container.
+type PipelineStage struct {
+	// name identifies the stage within the pipeline.
+	Name string `json:"name"`
+
+	// image is the Docker image the stage runs.
+	Image string `json:"image"`
+
+	// command is the command run in the stage container. When not specified the
+	// entrypoint of the image is run.
+	Command []string `json:"command,omitempty"`
+
+	// env contains environment variables passed to the stage container in addition to
+	// the ones of the strategy.
+	Env []kapi.EnvVar `json:"env,omitempty"`
+}
+
 // A BuildPostCommitSpec holds a build post commit hook specification. The hook
 // executes a command in a temporary container running the build output image,
 // immediately after the last layer of the image is committed and before the
@@ -621,10 +784,48 @@ type BuildConfigSpec struct {
 	// This is optional, if not specified we default to "Serial".
 	RunPolicy BuildRunPolicy `json:"runPolicy,omitempty"`
 
+	// matrix declares the builder images and environment variable sets the
+	// BuildConfig is built with when it is instantiated as a build matrix.
+	Matrix *BuildMatrix `json:"matrix,omitempty"`
+
 	// CommonSpec is the desired build specification
 	CommonSpec `json:",inline"`
 }
 
+// BuildMatrix declares the builder images and environment variable sets a
+// BuildConfig is built with when it is instantiated as a matrix. One build is
+// created for every combination of an image and an environment variable set.
+type BuildMatrix struct {
+	// images is the list of builder images replacing the image of the strategy.
+	Images []BuildMatrixImage `json:"images,omitempty"`
+
+	// env is the list of environment variable sets added to the environment
+	// of the strategy.
+	Env []BuildMatrixEnv `json:"env,omitempty"`
+}
+
+// BuildMatrixImage is a builder image of a build matrix.
+type BuildMatrixImage struct {
+	// name identifies the image in the matrix and is appended to the output
+	// tag of the builds using it. It must be unique among the images and
+	// environment variable sets of the matrix.
+	Name string `json:"name"`
+
+	// from is the builder image to build with.
+	From kapi.ObjectReference `json:"from"`
+}
+
+// BuildMatrixEnv is an environment variable set of a build matrix.
+type BuildMatrixEnv struct {
+	// name identifies the set in the matrix and is appended to the output tag
+	// of the builds using it. It must be unique among the images and
+	// environment variable sets of the matrix.
+	Name string `json:"name"`
+
+	// env is the list of environment variables to build with.
+	Env []kapi.EnvVar `json:"env"`
+}
+
 // BuildRunPolicy defines the behaviour of how the new builds are executed
 // from the existing build configuration.
 type BuildRunPolicy string
@@ -658,6 +859,42 @@ type WebHookTrigger struct {
 	// allowEnv determines whether the webhook can set environment variables; can only
 	// be set to true for GenericWebHook.
 	AllowEnv bool `json:"allowEnv,omitempty"`
+
+	// requireSignature determines whether the webhook payload must be signed
+	// with an HMAC of the request body keyed by secret, as sent by GitHub in the
+	// X-Hub-Signature header; can only be set to true for GitHubWebHook.
+	RequireSignature bool `json:"requireSignature,omitempty"`
+
+	// includePaths is a list of glob patterns for the paths of the source
+	// repository a build depends on. When set, the webhook only starts a build
+	// if a path changed by the push matches one of them. A pattern that matches
+	// a directory matches every path below it. Can only be set for
+	// GitHubWebHook and GenericWebHook. Pushes whose changed paths are unknown,
+	// such as GitHub pushes of 20 commits or more, always start a build.
+	IncludePaths []string `json:"includePaths,omitempty"`
+
+	// excludePaths is a list of glob patterns for paths of the source
+	// repository whose changes never start a build, even when matched by
+	// includePaths. Can only be set for GitHubWebHook and GenericWebHook.
+	ExcludePaths []string `json:"excludePaths,omitempty"`
+
+	// buildPullRequests determines whether the webhook also starts builds for
+	// pull requests opened or synchronized against the source ref; can only be
+	// set to true for GitHubWebHook. The images built for pull requests are
+	// pushed to the output tag suffixed with -pr-<number>.
+	BuildPullRequests bool `json:"buildPullRequests,omitempty"`
+
+	// pullRequestBuildsLimit is the maximum number of pull request builds kept
+	// for the BuildConfig. When exceeded, the oldest completed pull request
+	// builds are deleted. Pull request builds are not pruned if unset.
+	PullRequestBuildsLimit *int64 `json:"pullRequestBuildsLimit,omitempty"`
+
+	// forkPullRequestSecrets determines whether the builds of pull requests
+	// opened from forks of the repository get the secrets of the BuildConfig and
+	// the pull secrets of its service account, and use its incremental build
+	// cache. Without the push secret, those builds don't push their image. It
+	// can only be set to true when buildPullRequests is true.
+	ForkPullRequestSecrets bool `json:"forkPullRequestSecrets,omitempty"`
 }
 
 // ImageChangeTrigger allows builds to be triggered when an ImageStream changes
@@ -673,6 +910,18 @@ type ImageChangeTrigger struct {
 	From *kapi.ObjectReference `json:"from,omitempty"`
 }
 
+// BuildCompletionTrigger allows builds to be triggered when a build of another
+// BuildConfig completes successfully.
+type BuildCompletionTrigger struct {
+	// from is a reference to the BuildConfig in the same namespace whose successful
+	// builds trigger a build.
+	From kapi.ObjectReference `json:"from"`
+
+	// lastTriggeredBuild is used internally by the BuildCompletionController to
+	// save the name of the last build that triggered a build.
+	LastTriggeredBuild string `json:"lastTriggeredBuild,omitempty"`
+}
+
 // BuildTriggerPolicy describes a policy for a single trigger that results in a new Build.
 type BuildTriggerPolicy struct {
 	// type is the type of build trigger
@@ -684,8 +933,17 @@ type BuildTriggerPolicy struct {
 	// generic contains the parameters for a Generic webhook type of trigger
 	GenericWebHook *WebHookTrigger `json:"generic,omitempty"`
 
+	// gitlab contains the parameters for a GitLab webhook type of trigger
+	GitLabWebHook *WebHookTrigger `json:"gitlab,omitempty"`
+
+	// bitbucket contains the parameters for a Bitbucket webhook type of trigger
+	BitbucketWebHook *WebHookTrigger `json:"bitbucket,omitempty"`
+
 	// imageChange contains parameters for an ImageChange type of trigger
 	ImageChange *ImageChangeTrigger `json:"imageChange,omitempty"`
+
+	// buildCompletion contains parameters for a BuildCompletion type of trigger
+	BuildCompletion *BuildCompletionTrigger `json:"buildCompletion,omitempty"`
 }
 
 // BuildTriggerType refers to a specific BuildTriggerPolicy implementation.
@@ -702,6 +960,14 @@ const (
 	GenericWebHookBuildTriggerType           BuildTriggerType = "Generic"
 	GenericWebHookBuildTriggerTypeDeprecated BuildTriggerType = "generic"
 
+	// GitLabWebHookBuildTriggerType represents a trigger that launches builds on
+	// GitLab webhook invocations
+	GitLabWebHookBuildTriggerType BuildTriggerType = "GitLab"
+
+	// BitbucketWebHookBuildTriggerType represents a trigger that launches builds on
+	// Bitbucket webhook invocations
+	BitbucketWebHookBuildTriggerType BuildTriggerType = "Bitbucket"
+
 	// ImageChangeBuildTriggerType represents a trigger that launches builds on
 	// availability of a new version of an image
 	ImageChangeBuildTriggerType           BuildTriggerType = "ImageChange"
@@ -710,6 +976,10 @@ const (
 	// ConfigChangeBuildTriggerType will trigger a build on an initial build config creation
 	// WARNING: In the future the behavior will change to trigger a build on any config change
 	ConfigChangeBuildTriggerType BuildTriggerType = "ConfigChange"
+
+	// BuildCompletionBuildTriggerType represents a trigger that launches builds
+	// when a build of another BuildConfig completes successfully
+	BuildCompletionBuildTriggerType BuildTriggerType = "BuildCompletion"
 )
 
 // BuildList is a collection of Builds.
@@ -749,6 +1019,10 @@ type GenericWebHookEvent struct {
 type GitInfo struct {
 	GitBuildSource    `json:",inline"`
 	GitSourceRevision `json:",inline"`
+
+	// changedPaths is the list of paths changed by the commits being pushed.
+	// It is checked against the path filters of the webhook trigger, if any.
+	ChangedPaths []string `json:"changedPaths,omitempty"`
 }
 
 // BuildLog is the (unused) resource associated with the build log redirector
@@ -785,6 +1059,24 @@ type BuildRequest struct {
 	// triggeredBy describes which triggers started the most recent update to the
 	// build configuration and contains information about those triggers.
 	TriggeredBy []BuildTriggerCause `json:"triggeredBy"`
+
+	// matrix (optional) selects the entry of the build matrix of the BuildConfig
+	// the build is instantiated for.
+	Matrix *BuildMatrixEntry `json:"matrix,omitempty"`
+}
+
+// BuildMatrixEntry selects a combination of the images and environment
+// variable sets of a build matrix.
+type BuildMatrixEntry struct {
+	// group identifies the set of builds instantiated together from the build
+	// matrix.
+	Group string `json:"group"`
+
+	// image is the name of the matrix image to build with.
+	Image string `json:"image,omitempty"`
+
+	// env is the name of the matrix environment variable set to build with.
+	Env string `json:"env,omitempty"`
 }
 
 // BinaryBuildRequestOptions are the options required to fully speficy a binary build request
@@ -856,6 +1148,10 @@ type BuildLogOptions struct {
 
 	// version of the build for which to view logs.
 	Version *int64 `json:"version,omitempty"`
+
+	// stage is the name of the stage of a Pipeline build for which to stream logs. Defaults
+	// to the latest stage that started.
+	Stage string `json:"stage,omitempty"`
 }
 
 // SecretSpec specifies a secret to be included in a build pod and its corresponding mount point
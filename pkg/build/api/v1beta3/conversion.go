@@ -136,6 +136,10 @@ func Convert_v1beta3_BuildTriggerPolicy_To_api_BuildTriggerPolicy(in *BuildTrigg
 		out.Type = newer.GenericWebHookBuildTriggerType
 	case GitHubWebHookBuildTriggerType:
 		out.Type = newer.GitHubWebHookBuildTriggerType
+	case GitLabWebHookBuildTriggerType:
+		out.Type = newer.GitLabWebHookBuildTriggerType
+	case BitbucketWebHookBuildTriggerType:
+		out.Type = newer.BitbucketWebHookBuildTriggerType
 	}
 	return nil
 }
@@ -151,6 +155,10 @@ func Convert_api_BuildTriggerPolicy_To_v1beta3_BuildTriggerPolicy(in *newer.Buil
 		out.Type = GenericWebHookBuildTriggerType
 	case newer.GitHubWebHookBuildTriggerType:
 		out.Type = GitHubWebHookBuildTriggerType
+	case newer.GitLabWebHookBuildTriggerType:
+		out.Type = GitLabWebHookBuildTriggerType
+	case newer.BitbucketWebHookBuildTriggerType:
+		out.Type = BitbucketWebHookBuildTriggerType
 	}
 	return nil
 }
@@ -196,6 +204,20 @@ func Convert_api_BuildSource_To_v1beta3_BuildSource(in *newer.BuildSource, out *
 	return nil
 }
 
+func Convert_v1beta3_BuildStatus_To_api_BuildStatus(in *BuildStatus, out *newer.BuildStatus, s conversion.Scope) error {
+	if err := s.DefaultConvert(in, out, conversion.IgnoreMissingFields); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_BuildStatus_To_v1beta3_BuildStatus(in *newer.BuildStatus, out *BuildStatus, s conversion.Scope) error {
+	if err := s.DefaultConvert(in, out, conversion.IgnoreMissingFields); err != nil {
+		return err
+	}
+	return nil
+}
+
 func Convert_v1beta3_BuildStrategy_To_api_BuildStrategy(in *BuildStrategy, out *newer.BuildStrategy, s conversion.Scope) error {
 	if err := s.DefaultConvert(in, out, conversion.IgnoreMissingFields); err != nil {
 		return err
@@ -277,6 +299,8 @@ func addConversionFuncs(scheme *runtime.Scheme) {
 		Convert_api_BuildSource_To_v1beta3_BuildSource,
 		Convert_v1beta3_BuildStrategy_To_api_BuildStrategy,
 		Convert_api_BuildStrategy_To_v1beta3_BuildStrategy,
+		Convert_v1beta3_BuildStatus_To_api_BuildStatus,
+		Convert_api_BuildStatus_To_v1beta3_BuildStatus,
 	)
 
 	// Add field conversion funcs.
@@ -82,6 +82,14 @@ type BuildTriggerCause struct {
 	//specific build.
 	GitHubWebHook *GitHubWebHookCause `json:"githubWebHook,omitempty"`
 
+	// gitlabWebHook represents data for a GitLab webhook that fired a specific
+	// build.
+	GitLabWebHook *GitLabWebHookCause `json:"gitlabWebHook,omitempty"`
+
+	// bitbucketWebHook represents data for a Bitbucket webhook that fired a
+	// specific build.
+	BitbucketWebHook *BitbucketWebHookCause `json:"bitbucketWebHook,omitempty"`
+
 	// imageChangeBuild stores information about an imagechange event
 	// that triggered a new build.
 	ImageChangeBuild *ImageChangeCause `json:"imageChangeBuild,omitempty"`
@@ -108,6 +116,26 @@ type GitHubWebHookCause struct {
 	Secret string `json:"secret,omitempty"`
 }
 
+// GitLabWebHookCause has information about a GitLab webhook that triggered a
+// build.
+type GitLabWebHookCause struct {
+	// revision is the git source revision information of the trigger.
+	Revision *SourceRevision `json:"revision,omitempty"`
+
+	// secret is the obfuscated webhook secret that triggered a build.
+	Secret string `json:"secret,omitempty"`
+}
+
+// BitbucketWebHookCause has information about a Bitbucket webhook that
+// triggered a build.
+type BitbucketWebHookCause struct {
+	// revision is the git source revision information of the trigger.
+	Revision *SourceRevision `json:"revision,omitempty"`
+
+	// secret is the obfuscated webhook secret that triggered a build.
+	Secret string `json:"secret,omitempty"`
+}
+
 // ImageChangeCause contains information about the image that triggered a
 // build.
 type ImageChangeCause struct {
@@ -641,6 +669,13 @@ type BuildTriggerPolicy struct {
 	// GenericWebHook contains the parameters for a Generic webhook type of trigger
 	GenericWebHook *WebHookTrigger `json:"generic,omitempty"`
 
+	// GitLabWebHook contains the parameters for a GitLab webhook type of trigger
+	GitLabWebHook *WebHookTrigger `json:"gitlab,omitempty"`
+
+	// BitbucketWebHook contains the parameters for a Bitbucket webhook type of
+	// trigger
+	BitbucketWebHook *WebHookTrigger `json:"bitbucket,omitempty"`
+
 	// ImageChange contains parameters for an ImageChange type of trigger
 	ImageChange *ImageChangeTrigger `json:"imageChange,omitempty"`
 }
@@ -657,6 +692,14 @@ const (
 	// generic webhook invocations
 	GenericWebHookBuildTriggerType BuildTriggerType = "generic"
 
+	// GitLabWebHookBuildTriggerType represents a trigger that launches builds on
+	// GitLab webhook invocations
+	GitLabWebHookBuildTriggerType BuildTriggerType = "gitlab"
+
+	// BitbucketWebHookBuildTriggerType represents a trigger that launches builds on
+	// Bitbucket webhook invocations
+	BitbucketWebHookBuildTriggerType BuildTriggerType = "bitbucket"
+
 	// ImageChangeBuildTriggerType represents a trigger that launches builds on
 	// availability of a new version of an image
 	ImageChangeBuildTriggerType BuildTriggerType = "imageChange"
@@ -12,6 +12,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/validation"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/util/strategicpatch"
 	kvalidation "k8s.io/kubernetes/pkg/util/validation"
 	"k8s.io/kubernetes/pkg/util/validation/field"
@@ -28,6 +29,7 @@ func ValidateBuild(build *buildapi.Build) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validation.ValidateObjectMeta(&build.ObjectMeta, true, validation.NameIsDNSSubdomain, field.NewPath("metadata"))...)
 	allErrs = append(allErrs, validateCommonSpec(&build.Spec.CommonSpec, field.NewPath("spec"))...)
+	allErrs = append(allErrs, validateBuildConfigNamespaces(build.Namespace, &build.Spec.CommonSpec, nil, field.NewPath("spec"))...)
 	return allErrs
 }
 
@@ -98,6 +100,11 @@ func ValidateBuildConfig(config *buildapi.BuildConfig) field.ErrorList {
 	buildFrom := buildutil.GetInputReference(config.Spec.Strategy)
 	for i, trg := range config.Spec.Triggers {
 		allErrs = append(allErrs, validateTrigger(&trg, buildFrom, triggersPath.Index(i))...)
+		if trg.Type == buildapi.BuildCompletionBuildTriggerType && trg.BuildCompletion != nil &&
+			trg.BuildCompletion.From.Name == config.Name &&
+			(len(trg.BuildCompletion.From.Namespace) == 0 || trg.BuildCompletion.From.Namespace == config.Namespace) {
+			allErrs = append(allErrs, field.Invalid(triggersPath.Index(i).Child("buildCompletion", "from"), trg.BuildCompletion.From.Name, "a BuildConfig cannot be triggered by its own builds"))
+		}
 		if trg.Type != buildapi.ImageChangeBuildTriggerType || trg.ImageChange == nil {
 			continue
 		}
@@ -120,11 +127,39 @@ func ValidateBuildConfig(config *buildapi.BuildConfig) field.ErrorList {
 			"run policy must Parallel, Serial, or SerialLatestOnly"))
 	}
 
+	if config.Spec.Matrix != nil {
+		allErrs = append(allErrs, validateBuildMatrix(config.Spec.Matrix, buildFrom, specPath.Child("matrix"))...)
+	}
+
 	allErrs = append(allErrs, validateCommonSpec(&config.Spec.CommonSpec, specPath)...)
+	allErrs = append(allErrs, validateBuildConfigNamespaces(config.Namespace, &config.Spec.CommonSpec, config.Spec.Triggers, specPath)...)
 
 	return allErrs
 }
 
+// validateBuildConfigNamespaces makes sure the BuildConfigs used as image sources or triggers are
+// in namespace. Their builds and outputs are looked up by the build controllers, which could
+// otherwise be used to read them from any namespace.
+func validateBuildConfigNamespaces(namespace string, spec *buildapi.CommonSpec, triggers []buildapi.BuildTriggerPolicy, specPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	validate := func(ref *kapi.ObjectReference, fldPath *field.Path) {
+		if len(ref.Namespace) != 0 && ref.Namespace != namespace {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), ref.Namespace, "only a BuildConfig in the same namespace can be referenced"))
+		}
+	}
+	for i, image := range spec.Source.Images {
+		if image.From.Kind == "BuildConfig" {
+			validate(&image.From, specPath.Child("source", "images").Index(i).Child("from"))
+		}
+	}
+	for i, trigger := range triggers {
+		if trigger.Type == buildapi.BuildCompletionBuildTriggerType && trigger.BuildCompletion != nil {
+			validate(&trigger.BuildCompletion.From, specPath.Child("triggers").Index(i).Child("buildCompletion", "from"))
+		}
+	}
+	return allErrs
+}
+
 func ValidateBuildConfigUpdate(config *buildapi.BuildConfig, older *buildapi.BuildConfig) field.ErrorList {
 	allErrs := field.ErrorList{}
 	allErrs = append(allErrs, validation.ValidateObjectMetaUpdate(&config.ObjectMeta, &older.ObjectMeta, field.NewPath("metadata"))...)
@@ -134,7 +169,65 @@ func ValidateBuildConfigUpdate(config *buildapi.BuildConfig, older *buildapi.Bui
 
 // ValidateBuildRequest validates a BuildRequest object
 func ValidateBuildRequest(request *buildapi.BuildRequest) field.ErrorList {
-	return validation.ValidateObjectMeta(&request.ObjectMeta, true, oapi.MinimalNameRequirements, field.NewPath("metadata"))
+	allErrs := validation.ValidateObjectMeta(&request.ObjectMeta, true, oapi.MinimalNameRequirements, field.NewPath("metadata"))
+	if request.Matrix != nil {
+		allErrs = append(allErrs, validateBuildMatrixEntry(request.Matrix, field.NewPath("matrix"))...)
+	}
+	return allErrs
+}
+
+func validateBuildMatrix(matrix *buildapi.BuildMatrix, buildFrom *kapi.ObjectReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(matrix.Images) == 0 && len(matrix.Env) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, matrix, "a build matrix must declare at least one image or environment variable set"))
+	}
+
+	imagesPath := fldPath.Child("images")
+	if len(matrix.Images) > 0 && buildFrom == nil {
+		allErrs = append(allErrs, field.Invalid(imagesPath, matrix.Images, "the build strategy has no builder image to replace"))
+	}
+	names := sets.NewString()
+	for i, image := range matrix.Images {
+		allErrs = append(allErrs, validateUniqueName(image.Name, names, imagesPath.Index(i).Child("name"))...)
+		allErrs = append(allErrs, validateFromImageReference(&image.From, imagesPath.Index(i).Child("from"))...)
+	}
+
+	envPath := fldPath.Child("env")
+	for i, env := range matrix.Env {
+		allErrs = append(allErrs, validateUniqueName(env.Name, names, envPath.Index(i).Child("name"))...)
+		allErrs = append(allErrs, ValidateStrategyEnv(env.Env, envPath.Index(i).Child("env"))...)
+	}
+	return allErrs
+}
+
+// validateUniqueName validates a name that must be unique among names and
+// usable in object names and tags, such as the name of a build matrix image or
+// of a pipeline stage.
+func validateUniqueName(name string, names sets.String, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case len(name) == 0:
+		allErrs = append(allErrs, field.Required(fldPath, ""))
+	case !kvalidation.IsDNS1123Label(name):
+		allErrs = append(allErrs, field.Invalid(fldPath, name, "name must be a valid DNS label"))
+	case names.Has(name):
+		allErrs = append(allErrs, field.Duplicate(fldPath, name))
+	}
+	names.Insert(name)
+	return allErrs
+}
+
+func validateBuildMatrixEntry(entry *buildapi.BuildMatrixEntry, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(entry.Group) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("group"), ""))
+	} else if !kvalidation.IsValidLabelValue(entry.Group) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("group"), entry.Group, "group must be a valid label value"))
+	}
+	if len(entry.Image) == 0 && len(entry.Env) == 0 {
+		allErrs = append(allErrs, field.Invalid(fldPath, entry, "an image or an environment variable set of the build matrix must be selected"))
+	}
+	return allErrs
 }
 
 func validateCommonSpec(spec *buildapi.CommonSpec, fldPath *field.Path) field.ErrorList {
@@ -154,6 +247,10 @@ func validateCommonSpec(spec *buildapi.CommonSpec, fldPath *field.Path) field.Er
 			fldPath.Child("source"))...,
 	)
 
+	if s.PipelineStrategy != nil && spec.Source.Binary != nil {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("source", "binary"), "", "may not be used with the Pipeline strategy"))
+	}
+
 	if spec.CompletionDeadlineSeconds != nil {
 		if *spec.CompletionDeadlineSeconds <= 0 {
 			allErrs = append(allErrs, field.Invalid(fldPath.Child("completionDeadlineSeconds"), spec.CompletionDeadlineSeconds, "completionDeadlineSeconds must be a positive integer greater than 0"))
@@ -292,7 +389,12 @@ func validateSecrets(secrets []buildapi.SecretBuildSource, isDockerStrategy bool
 }
 
 func validateImageSource(imageSource buildapi.ImageSource, fldPath *field.Path) field.ErrorList {
-	allErrs := validateFromImageReference(&imageSource.From, fldPath.Child("from"))
+	var allErrs field.ErrorList
+	if imageSource.From.Kind == "BuildConfig" {
+		allErrs = validateBuildConfigReference(&imageSource.From, fldPath.Child("from"))
+	} else {
+		allErrs = validateFromImageReference(&imageSource.From, fldPath.Child("from"))
+	}
 	if imageSource.PullSecret != nil {
 		allErrs = append(allErrs, validateSecretRef(imageSource.PullSecret, fldPath.Child("pullSecret"))...)
 	}
@@ -409,6 +511,19 @@ func validateFromImageReference(reference *kapi.ObjectReference, fldPath *field.
 	return allErrs
 }
 
+func validateBuildConfigReference(reference *kapi.ObjectReference, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	if len(reference.Name) == 0 {
+		allErrs = append(allErrs, field.Required(fldPath.Child("name"), ""))
+	} else if !kvalidation.IsDNS1123Subdomain(reference.Name) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("name"), reference.Name, "name must be a valid subdomain"))
+	}
+	if len(reference.Namespace) != 0 && !kvalidation.IsDNS1123Subdomain(reference.Namespace) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("namespace"), reference.Namespace, "namespace must be a valid subdomain"))
+	}
+	return allErrs
+}
+
 func validateOutput(output *buildapi.BuildOutput, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -438,8 +553,11 @@ func validateStrategy(strategy *buildapi.BuildStrategy, fldPath *field.Path) fie
 	if strategy.JenkinsPipelineStrategy != nil {
 		strategyCount++
 	}
+	if strategy.PipelineStrategy != nil {
+		strategyCount++
+	}
 	if strategyCount != 1 {
-		return append(allErrs, field.Invalid(fldPath, strategy, "must provide a value for exactly one of sourceStrategy, customStrategy, dockerStrategy, or jenkinsPipelineStrategy"))
+		return append(allErrs, field.Invalid(fldPath, strategy, "must provide a value for exactly one of sourceStrategy, customStrategy, dockerStrategy, jenkinsPipelineStrategy, or pipelineStrategy"))
 	}
 
 	if strategy.SourceStrategy != nil {
@@ -454,6 +572,9 @@ func validateStrategy(strategy *buildapi.BuildStrategy, fldPath *field.Path) fie
 	if strategy.JenkinsPipelineStrategy != nil {
 		allErrs = append(allErrs, validateJenkinsPipelineStrategy(strategy.JenkinsPipelineStrategy, fldPath.Child("jenkinsPipelineStrategy"))...)
 	}
+	if strategy.PipelineStrategy != nil {
+		allErrs = append(allErrs, validatePipelineStrategy(strategy.PipelineStrategy, fldPath.Child("pipelineStrategy"))...)
+	}
 
 	return allErrs
 }
@@ -499,6 +620,30 @@ func validateSourceStrategy(strategy *buildapi.SourceBuildStrategy, fldPath *fie
 	allErrs = append(allErrs, validateFromImageReference(&strategy.From, fldPath.Child("from"))...)
 	allErrs = append(allErrs, validateSecretRef(strategy.PullSecret, fldPath.Child("pullSecret"))...)
 	allErrs = append(allErrs, ValidateStrategyEnv(strategy.Env, fldPath.Child("env"))...)
+	if strategy.IncrementalCache != nil {
+		allErrs = append(allErrs, validateIncrementalCache(strategy, fldPath.Child("incrementalCache"))...)
+	}
+	return allErrs
+}
+
+func validateIncrementalCache(strategy *buildapi.SourceBuildStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	cache := strategy.IncrementalCache
+	if !strategy.Incremental {
+		allErrs = append(allErrs, field.Invalid(fldPath, cache, "an incremental cache can only be used by incremental builds"))
+	}
+	switch {
+	case cache.PersistentVolumeClaim != nil && cache.Image != nil:
+		allErrs = append(allErrs, field.Invalid(fldPath, cache, "must provide a value for exactly one of persistentVolumeClaim or image"))
+	case cache.PersistentVolumeClaim != nil:
+		if len(cache.PersistentVolumeClaim.Name) == 0 {
+			allErrs = append(allErrs, field.Required(fldPath.Child("persistentVolumeClaim", "name"), ""))
+		}
+	case cache.Image != nil:
+		allErrs = append(allErrs, validateToImageReference(cache.Image, fldPath.Child("image"))...)
+	default:
+		allErrs = append(allErrs, field.Required(fldPath, "must provide a value for exactly one of persistentVolumeClaim or image"))
+	}
 	return allErrs
 }
 
@@ -532,6 +677,27 @@ func validateJenkinsPipelineStrategy(strategy *buildapi.JenkinsPipelineBuildStra
 	return allErrs
 }
 
+func validatePipelineStrategy(strategy *buildapi.PipelineBuildStrategy, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	stagesPath := fldPath.Child("stages")
+	if len(strategy.Stages) == 0 {
+		allErrs = append(allErrs, field.Required(stagesPath, "must provide at least one stage"))
+	}
+	names := sets.NewString()
+	for i, stage := range strategy.Stages {
+		stagePath := stagesPath.Index(i)
+		allErrs = append(allErrs, validateUniqueName(stage.Name, names, stagePath.Child("name"))...)
+		if len(stage.Image) == 0 {
+			allErrs = append(allErrs, field.Required(stagePath.Child("image"), ""))
+		}
+		allErrs = append(allErrs, ValidateStrategyEnv(stage.Env, stagePath.Child("env"))...)
+	}
+	allErrs = append(allErrs, ValidateStrategyEnv(strategy.Env, fldPath.Child("env"))...)
+
+	return allErrs
+}
+
 func validateTrigger(trigger *buildapi.BuildTriggerPolicy, buildFrom *kapi.ObjectReference, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if len(trigger.Type) == 0 {
@@ -545,13 +711,25 @@ func validateTrigger(trigger *buildapi.BuildTriggerPolicy, buildFrom *kapi.Objec
 		if trigger.GitHubWebHook == nil {
 			allErrs = append(allErrs, field.Required(fldPath.Child("github"), ""))
 		} else {
-			allErrs = append(allErrs, validateWebHook(trigger.GitHubWebHook, fldPath.Child("github"), false)...)
+			allErrs = append(allErrs, validateWebHook(trigger.GitHubWebHook, fldPath.Child("github"), false, true)...)
 		}
 	case buildapi.GenericWebHookBuildTriggerType:
 		if trigger.GenericWebHook == nil {
 			allErrs = append(allErrs, field.Required(fldPath.Child("generic"), ""))
 		} else {
-			allErrs = append(allErrs, validateWebHook(trigger.GenericWebHook, fldPath.Child("generic"), true)...)
+			allErrs = append(allErrs, validateWebHook(trigger.GenericWebHook, fldPath.Child("generic"), true, false)...)
+		}
+	case buildapi.GitLabWebHookBuildTriggerType:
+		if trigger.GitLabWebHook == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("gitlab"), ""))
+		} else {
+			allErrs = append(allErrs, validateWebHook(trigger.GitLabWebHook, fldPath.Child("gitlab"), false, false)...)
+		}
+	case buildapi.BitbucketWebHookBuildTriggerType:
+		if trigger.BitbucketWebHook == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("bitbucket"), ""))
+		} else {
+			allErrs = append(allErrs, validateWebHook(trigger.BitbucketWebHook, fldPath.Child("bitbucket"), false, false)...)
 		}
 	case buildapi.ImageChangeBuildTriggerType:
 		if trigger.ImageChange == nil {
@@ -579,6 +757,17 @@ func validateTrigger(trigger *buildapi.BuildTriggerPolicy, buildFrom *kapi.Objec
 			break
 		}
 		allErrs = append(allErrs, validateFromImageReference(trigger.ImageChange.From, fldPath.Child("from"))...)
+	case buildapi.BuildCompletionBuildTriggerType:
+		if trigger.BuildCompletion == nil {
+			allErrs = append(allErrs, field.Required(fldPath.Child("buildCompletion"), ""))
+			break
+		}
+		fromPath := fldPath.Child("buildCompletion", "from")
+		if kind := trigger.BuildCompletion.From.Kind; kind != "BuildConfig" {
+			allErrs = append(allErrs, field.Invalid(fromPath.Child("kind"), kind, "only a BuildConfig type of reference is allowed in a BuildCompletion trigger."))
+			break
+		}
+		allErrs = append(allErrs, validateBuildConfigReference(&trigger.BuildCompletion.From, fromPath)...)
 	case buildapi.ConfigChangeBuildTriggerType:
 		// doesn't require additional validation
 	default:
@@ -587,7 +776,7 @@ func validateTrigger(trigger *buildapi.BuildTriggerPolicy, buildFrom *kapi.Objec
 	return allErrs
 }
 
-func validateWebHook(webHook *buildapi.WebHookTrigger, fldPath *field.Path, isGeneric bool) field.ErrorList {
+func validateWebHook(webHook *buildapi.WebHookTrigger, fldPath *field.Path, isGeneric, isGitHub bool) field.ErrorList {
 	allErrs := field.ErrorList{}
 	if len(webHook.Secret) == 0 {
 		allErrs = append(allErrs, field.Required(fldPath.Child("secret"), ""))
@@ -595,6 +784,41 @@ func validateWebHook(webHook *buildapi.WebHookTrigger, fldPath *field.Path, isGe
 	if !isGeneric && webHook.AllowEnv {
 		allErrs = append(allErrs, field.Invalid(fldPath.Child("allowEnv"), webHook, "git webhooks cannot allow env vars"))
 	}
+	if !isGitHub && webHook.RequireSignature {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("requireSignature"), webHook, "only GitHub webhooks can require a payload signature"))
+	}
+	if !isGeneric && !isGitHub && (len(webHook.IncludePaths) > 0 || len(webHook.ExcludePaths) > 0) {
+		allErrs = append(allErrs, field.Invalid(fldPath, webHook, "only GitHub and generic webhooks can filter on changed paths"))
+	}
+	allErrs = append(allErrs, validatePathFilters(webHook.IncludePaths, fldPath.Child("includePaths"))...)
+	allErrs = append(allErrs, validatePathFilters(webHook.ExcludePaths, fldPath.Child("excludePaths"))...)
+	if !isGitHub && webHook.BuildPullRequests {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("buildPullRequests"), webHook, "only GitHub webhooks can build pull requests"))
+	}
+	if webHook.PullRequestBuildsLimit != nil {
+		if !webHook.BuildPullRequests {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pullRequestBuildsLimit"), *webHook.PullRequestBuildsLimit, "may only be set when buildPullRequests is true"))
+		} else if *webHook.PullRequestBuildsLimit < 1 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Child("pullRequestBuildsLimit"), *webHook.PullRequestBuildsLimit, "must be greater than zero"))
+		}
+	}
+	if webHook.ForkPullRequestSecrets && !webHook.BuildPullRequests {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("forkPullRequestSecrets"), webHook.ForkPullRequestSecrets, "may only be set when buildPullRequests is true"))
+	}
+	return allErrs
+}
+
+func validatePathFilters(patterns []string, fldPath *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	for i, pattern := range patterns {
+		if len(strings.Trim(pattern, "/")) == 0 {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), pattern, "must not be empty"))
+			continue
+		}
+		if _, err := path.Match(pattern, ""); err != nil {
+			allErrs = append(allErrs, field.Invalid(fldPath.Index(i), pattern, err.Error()))
+		}
+	}
 	return allErrs
 }
 
@@ -833,6 +833,14 @@ func TestValidateBuildRequest(t *testing.T) {
 	testCases := map[string]*buildapi.BuildRequest{
 		string(field.ErrorTypeRequired) + "metadata.namespace": {ObjectMeta: kapi.ObjectMeta{Name: "requestName"}},
 		string(field.ErrorTypeRequired) + "metadata.name":      {ObjectMeta: kapi.ObjectMeta{Namespace: kapi.NamespaceDefault}},
+		string(field.ErrorTypeRequired) + "matrix.group": {
+			ObjectMeta: kapi.ObjectMeta{Name: "requestName", Namespace: kapi.NamespaceDefault},
+			Matrix:     &buildapi.BuildMatrixEntry{Image: "py35"},
+		},
+		string(field.ErrorTypeInvalid) + "matrix": {
+			ObjectMeta: kapi.ObjectMeta{Name: "requestName", Namespace: kapi.NamespaceDefault},
+			Matrix:     &buildapi.BuildMatrixEntry{Group: "matrix-abcde"},
+		},
 	}
 
 	for desc, tc := range testCases {
@@ -2035,6 +2043,7 @@ func TestValidateJenkinsfilePath(t *testing.T) {
 }
 
 func TestValidateTrigger(t *testing.T) {
+	zero, five := int64(0), int64(5)
 	tests := map[string]struct {
 		trigger  buildapi.BuildTriggerPolicy
 		expected []*field.Error
@@ -2079,6 +2088,116 @@ func TestValidateTrigger(t *testing.T) {
 			},
 			expected: []*field.Error{field.Invalid(field.NewPath("github", "allowEnv"), "", "")},
 		},
+		"GitHub trigger with require signature": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:           "secret101",
+					RequireSignature: true,
+				},
+			},
+		},
+		"Generic trigger with require signature": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GenericWebHookBuildTriggerType,
+				GenericWebHook: &buildapi.WebHookTrigger{
+					Secret:           "secret101",
+					RequireSignature: true,
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("generic", "requireSignature"), "", "")},
+		},
+		"GitHub trigger with pull request builds": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:                 "secret101",
+					BuildPullRequests:      true,
+					PullRequestBuildsLimit: &five,
+				},
+			},
+		},
+		"GitHub trigger with pull request builds limit of zero": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:                 "secret101",
+					BuildPullRequests:      true,
+					PullRequestBuildsLimit: &zero,
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("github", "pullRequestBuildsLimit"), "", "")},
+		},
+		"GitHub trigger with pull request builds limit but no pull request builds": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:                 "secret101",
+					PullRequestBuildsLimit: &five,
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("github", "pullRequestBuildsLimit"), "", "")},
+		},
+		"GitHub trigger with fork pull request secrets but no pull request builds": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:                 "secret101",
+					ForkPullRequestSecrets: true,
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("github", "forkPullRequestSecrets"), "", "")},
+		},
+		"GitHub trigger with path filters": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:       "secret101",
+					IncludePaths: []string{"services/api", "libs/*.go"},
+					ExcludePaths: []string{"docs"},
+				},
+			},
+		},
+		"GitHub trigger with invalid path filter": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitHubWebHookBuildTriggerType,
+				GitHubWebHook: &buildapi.WebHookTrigger{
+					Secret:       "secret101",
+					IncludePaths: []string{"services/api", "libs/[.go"},
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("github", "includePaths").Index(1), "", "")},
+		},
+		"Generic trigger with empty path filter": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GenericWebHookBuildTriggerType,
+				GenericWebHook: &buildapi.WebHookTrigger{
+					Secret:       "secret101",
+					ExcludePaths: []string{"/"},
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("generic", "excludePaths").Index(0), "", "")},
+		},
+		"GitLab trigger with path filters": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitLabWebHookBuildTriggerType,
+				GitLabWebHook: &buildapi.WebHookTrigger{
+					Secret:       "secret101",
+					IncludePaths: []string{"services/api"},
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("gitlab"), "", "")},
+		},
+		"Generic trigger with pull request builds": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GenericWebHookBuildTriggerType,
+				GenericWebHook: &buildapi.WebHookTrigger{
+					Secret:            "secret101",
+					BuildPullRequests: true,
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("generic", "buildPullRequests"), "", "")},
+		},
 		"Generic trigger with no generic webhook": {
 			trigger:  buildapi.BuildTriggerPolicy{Type: buildapi.GenericWebHookBuildTriggerType},
 			expected: []*field.Error{field.Required(field.NewPath("generic"), "")},
@@ -2099,6 +2218,38 @@ func TestValidateTrigger(t *testing.T) {
 			},
 			expected: []*field.Error{field.Required(field.NewPath("generic"), "")},
 		},
+		"GitLab type with no gitlab webhook": {
+			trigger:  buildapi.BuildTriggerPolicy{Type: buildapi.GitLabWebHookBuildTriggerType},
+			expected: []*field.Error{field.Required(field.NewPath("gitlab"), "")},
+		},
+		"GitLab trigger with no secret": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type:          buildapi.GitLabWebHookBuildTriggerType,
+				GitLabWebHook: &buildapi.WebHookTrigger{},
+			},
+			expected: []*field.Error{field.Required(field.NewPath("gitlab", "secret"), "")},
+		},
+		"GitLab trigger with allow env": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitLabWebHookBuildTriggerType,
+				GitLabWebHook: &buildapi.WebHookTrigger{
+					Secret:   "secret101",
+					AllowEnv: true,
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("gitlab", "allowEnv"), "", "")},
+		},
+		"Bitbucket type with no bitbucket webhook": {
+			trigger:  buildapi.BuildTriggerPolicy{Type: buildapi.BitbucketWebHookBuildTriggerType},
+			expected: []*field.Error{field.Required(field.NewPath("bitbucket"), "")},
+		},
+		"Bitbucket trigger with no secret": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type:             buildapi.BitbucketWebHookBuildTriggerType,
+				BitbucketWebHook: &buildapi.WebHookTrigger{},
+			},
+			expected: []*field.Error{field.Required(field.NewPath("bitbucket", "secret"), "")},
+		},
 		"ImageChange trigger without params": {
 			trigger: buildapi.BuildTriggerPolicy{
 				Type: buildapi.ImageChangeBuildTriggerType,
@@ -2113,6 +2264,22 @@ func TestValidateTrigger(t *testing.T) {
 				},
 			},
 		},
+		"valid GitLab trigger": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.GitLabWebHookBuildTriggerType,
+				GitLabWebHook: &buildapi.WebHookTrigger{
+					Secret: "secret101",
+				},
+			},
+		},
+		"valid Bitbucket trigger": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.BitbucketWebHookBuildTriggerType,
+				BitbucketWebHook: &buildapi.WebHookTrigger{
+					Secret: "secret101",
+				},
+			},
+		},
 		"valid Generic trigger": {
 			trigger: buildapi.BuildTriggerPolicy{
 				Type: buildapi.GenericWebHookBuildTriggerType,
@@ -2135,6 +2302,36 @@ func TestValidateTrigger(t *testing.T) {
 				ImageChange: &buildapi.ImageChangeTrigger{},
 			},
 		},
+		"BuildCompletion type with no buildCompletion": {
+			trigger:  buildapi.BuildTriggerPolicy{Type: buildapi.BuildCompletionBuildTriggerType},
+			expected: []*field.Error{field.Required(field.NewPath("buildCompletion"), "")},
+		},
+		"BuildCompletion trigger from an image stream tag": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.BuildCompletionBuildTriggerType,
+				BuildCompletion: &buildapi.BuildCompletionTrigger{
+					From: kapi.ObjectReference{Kind: "ImageStreamTag", Name: "app:latest"},
+				},
+			},
+			expected: []*field.Error{field.Invalid(field.NewPath("buildCompletion", "from", "kind"), "", "")},
+		},
+		"BuildCompletion trigger without a name": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.BuildCompletionBuildTriggerType,
+				BuildCompletion: &buildapi.BuildCompletionTrigger{
+					From: kapi.ObjectReference{Kind: "BuildConfig"},
+				},
+			},
+			expected: []*field.Error{field.Required(field.NewPath("buildCompletion", "from", "name"), "")},
+		},
+		"valid BuildCompletion trigger": {
+			trigger: buildapi.BuildTriggerPolicy{
+				Type: buildapi.BuildCompletionBuildTriggerType,
+				BuildCompletion: &buildapi.BuildCompletionTrigger{
+					From: kapi.ObjectReference{Kind: "BuildConfig", Name: "base", Namespace: "other"},
+				},
+			},
+		},
 	}
 	for desc, test := range tests {
 		errors := validateTrigger(&test.trigger, &kapi.ObjectReference{Kind: "ImageStreamTag"}, nil)
@@ -2163,6 +2360,73 @@ func TestValidateTrigger(t *testing.T) {
 	}
 }
 
+func TestBuildConfigTriggeredByItself(t *testing.T) {
+	config := &buildapi.BuildConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "config-id", Namespace: "namespace"},
+		Spec: buildapi.BuildConfigSpec{
+			RunPolicy: buildapi.BuildRunPolicySerial,
+			CommonSpec: buildapi.CommonSpec{
+				Source: buildapi.BuildSource{
+					Git: &buildapi.GitBuildSource{
+						URI: "http://github.com/my/repository",
+					},
+				},
+				Strategy: buildapi.BuildStrategy{
+					DockerStrategy: &buildapi.DockerBuildStrategy{},
+				},
+			},
+			Triggers: []buildapi.BuildTriggerPolicy{
+				{
+					Type: buildapi.BuildCompletionBuildTriggerType,
+					BuildCompletion: &buildapi.BuildCompletionTrigger{
+						From: kapi.ObjectReference{Kind: "BuildConfig", Name: "config-id"},
+					},
+				},
+			},
+		},
+	}
+	errors := ValidateBuildConfig(config)
+	if len(errors) != 1 {
+		t.Fatalf("Expected one error, got %v", errors)
+	}
+	if errors[0].Field != "spec.triggers[0].buildCompletion.from" {
+		t.Errorf("Unexpected error field: %s", errors[0].Field)
+	}
+
+	config.Spec.Triggers[0].BuildCompletion.From.Namespace = "other"
+	errors = ValidateBuildConfig(config)
+	if len(errors) != 1 || errors[0].Field != "spec.triggers[0].buildCompletion.from.namespace" {
+		t.Errorf("Expected the BuildConfig in another namespace to be rejected, got %v", errors)
+	}
+
+	config.Spec.Triggers[0].BuildCompletion.From = kapi.ObjectReference{Kind: "BuildConfig", Name: "base", Namespace: config.Namespace}
+	config.Spec.Source.Images = []buildapi.ImageSource{
+		{
+			From:  kapi.ObjectReference{Kind: "BuildConfig", Name: "base", Namespace: "other"},
+			Paths: []buildapi.ImageSourcePath{{SourcePath: "/opt/app/lib", DestinationDir: "lib"}},
+		},
+	}
+	errors = ValidateBuildConfig(config)
+	if len(errors) != 1 || errors[0].Field != "spec.source.images[0].from.namespace" {
+		t.Errorf("Expected the image source from another namespace to be rejected, got %v", errors)
+	}
+}
+
+func TestValidateImageSourceFromBuildConfig(t *testing.T) {
+	imageSource := buildapi.ImageSource{
+		From:  kapi.ObjectReference{Kind: "BuildConfig", Name: "base"},
+		Paths: []buildapi.ImageSourcePath{{SourcePath: "/opt/app/lib", DestinationDir: "lib"}},
+	}
+	if errs := validateImageSource(imageSource, nil); len(errs) != 0 {
+		t.Errorf("Unexpected errors: %v", errs)
+	}
+	imageSource.From.Name = ""
+	errs := validateImageSource(imageSource, nil)
+	if len(errs) != 1 || errs[0].Field != "from.name" || errs[0].Type != field.ErrorTypeRequired {
+		t.Errorf("Expected from.name to be required, got %v", errs)
+	}
+}
+
 func TestValidateToImageReference(t *testing.T) {
 	o := &kapi.ObjectReference{
 		Name:      "somename",
@@ -2182,6 +2446,101 @@ func TestValidateToImageReference(t *testing.T) {
 	}
 }
 
+func TestValidateIncrementalCache(t *testing.T) {
+	from := kapi.ObjectReference{Kind: "DockerImage", Name: "builder"}
+	tests := []struct {
+		name     string
+		strategy buildapi.SourceBuildStrategy
+		errs     []string
+	}{
+		{
+			name: "persistent volume claim",
+			strategy: buildapi.SourceBuildStrategy{
+				From:        from,
+				Incremental: true,
+				IncrementalCache: &buildapi.IncrementalCacheSource{
+					PersistentVolumeClaim: &kapi.LocalObjectReference{Name: "cache"},
+				},
+			},
+		},
+		{
+			name: "image stream tag",
+			strategy: buildapi.SourceBuildStrategy{
+				From:        from,
+				Incremental: true,
+				IncrementalCache: &buildapi.IncrementalCacheSource{
+					Image: &kapi.ObjectReference{Kind: "ImageStreamTag", Name: "app:cache"},
+				},
+			},
+		},
+		{
+			name: "not incremental",
+			strategy: buildapi.SourceBuildStrategy{
+				From: from,
+				IncrementalCache: &buildapi.IncrementalCacheSource{
+					PersistentVolumeClaim: &kapi.LocalObjectReference{Name: "cache"},
+				},
+			},
+			errs: []string{"incrementalCache"},
+		},
+		{
+			name: "empty",
+			strategy: buildapi.SourceBuildStrategy{
+				From:             from,
+				Incremental:      true,
+				IncrementalCache: &buildapi.IncrementalCacheSource{},
+			},
+			errs: []string{"incrementalCache"},
+		},
+		{
+			name: "both",
+			strategy: buildapi.SourceBuildStrategy{
+				From:        from,
+				Incremental: true,
+				IncrementalCache: &buildapi.IncrementalCacheSource{
+					PersistentVolumeClaim: &kapi.LocalObjectReference{Name: "cache"},
+					Image:                 &kapi.ObjectReference{Kind: "ImageStreamTag", Name: "app:cache"},
+				},
+			},
+			errs: []string{"incrementalCache"},
+		},
+		{
+			name: "claim without name",
+			strategy: buildapi.SourceBuildStrategy{
+				From:        from,
+				Incremental: true,
+				IncrementalCache: &buildapi.IncrementalCacheSource{
+					PersistentVolumeClaim: &kapi.LocalObjectReference{},
+				},
+			},
+			errs: []string{"incrementalCache.persistentVolumeClaim.name"},
+		},
+		{
+			name: "invalid image stream tag",
+			strategy: buildapi.SourceBuildStrategy{
+				From:        from,
+				Incremental: true,
+				IncrementalCache: &buildapi.IncrementalCacheSource{
+					Image: &kapi.ObjectReference{Kind: "ImageStreamTag", Name: "app"},
+				},
+			},
+			errs: []string{"incrementalCache.image.name"},
+		},
+	}
+	for _, test := range tests {
+		errs := validateSourceStrategy(&test.strategy, nil)
+		if len(errs) != len(test.errs) {
+			t.Errorf("%s: expected errors on %v, got %v", test.name, test.errs, errs)
+			continue
+		}
+		for i, err := range errs {
+			if err.Field != test.errs[i] {
+				t.Errorf("%s: expected error on %s, got %v", test.name, test.errs[i], err)
+			}
+		}
+	}
+}
+
 func TestValidateStrategyEnvVars(t *testing.T) {
 	tests := []struct {
 		env         []kapi.EnvVar
@@ -2401,3 +2760,132 @@ func TestDiffBuildSpec(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateBuildMatrix(t *testing.T) {
+	sourceFrom := &kapi.ObjectReference{Kind: "ImageStreamTag", Name: "python:latest"}
+	validImage := buildapi.BuildMatrixImage{Name: "py35", From: kapi.ObjectReference{Kind: "DockerImage", Name: "registry/python:3.5"}}
+	validEnv := buildapi.BuildMatrixEnv{Name: "debug", Env: []kapi.EnvVar{{Name: "DEBUG", Value: "true"}}}
+	tests := []struct {
+		name      string
+		matrix    buildapi.BuildMatrix
+		buildFrom *kapi.ObjectReference
+		errors    []string
+	}{
+		{
+			name:      "valid",
+			matrix:    buildapi.BuildMatrix{Images: []buildapi.BuildMatrixImage{validImage}, Env: []buildapi.BuildMatrixEnv{validEnv}},
+			buildFrom: sourceFrom,
+		},
+		{
+			name:      "environment only without builder image",
+			matrix:    buildapi.BuildMatrix{Env: []buildapi.BuildMatrixEnv{validEnv}},
+			buildFrom: nil,
+		},
+		{
+			name:      "empty",
+			buildFrom: sourceFrom,
+			errors:    []string{"matrix"},
+		},
+		{
+			name:   "images without builder image",
+			matrix: buildapi.BuildMatrix{Images: []buildapi.BuildMatrixImage{validImage}},
+			errors: []string{"matrix.images"},
+		},
+		{
+			name:      "duplicate names",
+			matrix:    buildapi.BuildMatrix{Images: []buildapi.BuildMatrixImage{validImage, validImage}},
+			buildFrom: sourceFrom,
+			errors:    []string{"matrix.images[1].name"},
+		},
+		{
+			name: "image and environment sharing a name",
+			matrix: buildapi.BuildMatrix{
+				Images: []buildapi.BuildMatrixImage{validImage},
+				Env:    []buildapi.BuildMatrixEnv{{Name: "py35", Env: validEnv.Env}},
+			},
+			buildFrom: sourceFrom,
+			errors:    []string{"matrix.env[0].name"},
+		},
+		{
+			name: "invalid names",
+			matrix: buildapi.BuildMatrix{
+				Images: []buildapi.BuildMatrixImage{{Name: "Python 3", From: validImage.From}},
+				Env:    []buildapi.BuildMatrixEnv{{Env: validEnv.Env}},
+			},
+			buildFrom: sourceFrom,
+			errors:    []string{"matrix.images[0].name", "matrix.env[0].name"},
+		},
+		{
+			name: "invalid image and environment",
+			matrix: buildapi.BuildMatrix{
+				Images: []buildapi.BuildMatrixImage{{Name: "py35", From: kapi.ObjectReference{Kind: "ImageStream", Name: "python"}}},
+				Env:    []buildapi.BuildMatrixEnv{{Name: "debug", Env: []kapi.EnvVar{{Value: "true"}}}},
+			},
+			buildFrom: sourceFrom,
+			errors:    []string{"matrix.images[0].from.kind", "matrix.env[0].env[0].name"},
+		},
+	}
+	for _, test := range tests {
+		errs := validateBuildMatrix(&test.matrix, test.buildFrom, field.NewPath("matrix"))
+		if len(errs) != len(test.errors) {
+			t.Errorf("%s: expected errors on %v, got %v", test.name, test.errors, errs)
+			continue
+		}
+		for i, err := range errs {
+			if err.Field != test.errors[i] {
+				t.Errorf("%s: expected error on %s, got %v", test.name, test.errors[i], err)
+			}
+		}
+	}
+}
+
+func TestValidatePipelineStrategy(t *testing.T) {
+	validStage := buildapi.PipelineStage{Name: "test", Image: "golang:1.6", Command: []string{"make", "test"}}
+	tests := []struct {
+		name     string
+		strategy buildapi.PipelineBuildStrategy
+		errors   []string
+	}{
+		{
+			name: "valid",
+			strategy: buildapi.PipelineBuildStrategy{
+				Stages: []buildapi.PipelineStage{validStage, {Name: "deploy", Image: "deployer", Env: []kapi.EnvVar{{Name: "TARGET", Value: "staging"}}}},
+				Env:    []kapi.EnvVar{{Name: "CI", Value: "true"}},
+			},
+		},
+		{
+			name:   "no stages",
+			errors: []string{"pipelineStrategy.stages"},
+		},
+		{
+			name:     "duplicate stage names",
+			strategy: buildapi.PipelineBuildStrategy{Stages: []buildapi.PipelineStage{validStage, validStage}},
+			errors:   []string{"pipelineStrategy.stages[1].name"},
+		},
+		{
+			name:     "invalid stage",
+			strategy: buildapi.PipelineBuildStrategy{Stages: []buildapi.PipelineStage{{Name: "Unit Tests", Env: []kapi.EnvVar{{Value: "true"}}}}},
+			errors:   []string{"pipelineStrategy.stages[0].name", "pipelineStrategy.stages[0].image", "pipelineStrategy.stages[0].env[0].name"},
+		},
+		{
+			name: "invalid strategy environment",
+			strategy: buildapi.PipelineBuildStrategy{
+				Stages: []buildapi.PipelineStage{validStage},
+				Env:    []kapi.EnvVar{{Name: "NOT-VALID", Value: "5"}},
+			},
+			errors: []string{"pipelineStrategy.env[0].name"},
+		},
+	}
+	for _, test := range tests {
+		errs := validatePipelineStrategy(&test.strategy, field.NewPath("pipelineStrategy"))
+		if len(errs) != len(test.errors) {
+			t.Errorf("%s: expected errors on %v, got %v", test.name, test.errors, errs)
+			continue
+		}
+		for i, err := range errs {
+			if err.Field != test.errors[i] {
+				t.Errorf("%s: expected error on %s, got %v", test.name, test.errors[i], err)
+			}
+		}
+	}
+}
@@ -152,7 +152,13 @@ func (c *builderConfig) execute(b builder) error {
 	}
 	glog.V(4).Infof("Running build with cgroup limits: %#v", *cgLimits)
 
-	if err := b.Build(c.dockerClient, c.dockerEndpoint, c.buildsClient, c.build, gitClient, cgLimits); err != nil {
+	monitor := bld.NewResourceUsageMonitor(c.dockerClient)
+	monitor.Start()
+	err = b.Build(monitor.DockerClient(c.dockerClient), c.dockerEndpoint, c.buildsClient, c.build, gitClient, cgLimits)
+	if err := bld.WriteResourceUsage(kapi.TerminationMessagePathDefault, monitor.Stop()); err != nil {
+		glog.V(4).Infof("Unable to report the resource usage of the build: %v", err)
+	}
+	if err != nil {
 		return fmt.Errorf("build error: %v", err)
 	}
 
@@ -32,6 +32,7 @@ type KeyValue struct {
 type GitClient interface {
 	CloneWithOptions(dir string, url string, opts git.CloneOptions) error
 	Checkout(dir string, ref string) error
+	FetchRef(dir, ref string) error
 	SubmoduleUpdate(dir string, init, recursive bool) error
 	TimedListRemote(timeout time.Duration, url string, args ...string) (string, string, error)
 	GetInfo(location string) (*git.SourceInfo, []error)
@@ -0,0 +1,115 @@
+package builder
+
+import (
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+
+	"github.com/fsouza/go-dockerclient"
+
+	s2iapi "github.com/openshift/source-to-image/pkg/api"
+	s2idocker "github.com/openshift/source-to-image/pkg/docker"
+	"github.com/openshift/source-to-image/pkg/tar"
+)
+
+// artifactsArchive is the name of the archive holding the artifacts of the
+// last successful build in the incremental cache volume.
+const artifactsArchive = "artifacts.tar"
+
+// restoreArtifacts extracts the artifacts archived in cacheDir into the
+// directory S2I uploads to the builder container as /tmp/artifacts. It is a
+// no-op if no artifacts were saved yet.
+func restoreArtifacts(cacheDir, buildDir string) error {
+	archive, err := os.Open(filepath.Join(cacheDir, artifactsArchive))
+	if err != nil {
+		if os.IsNotExist(err) {
+			glog.V(0).Infof("No artifacts found in the incremental cache, a clean build will be performed")
+			return nil
+		}
+		return err
+	}
+	defer archive.Close()
+
+	artifactsDir := filepath.Join(buildDir, "upload", "artifacts")
+	if err := os.MkdirAll(artifactsDir, 0700); err != nil {
+		return err
+	}
+	glog.V(0).Infof("Restoring artifacts from the incremental cache ...")
+	return tar.New().ExtractTarStream(artifactsDir, archive)
+}
+
+// saveArtifacts runs the save-artifacts script of the built image and stores
+// its output in cacheDir, replacing the artifacts of the previous build only
+// once the script succeeded.
+func saveArtifacts(client DockerClient, image, scriptsURL, cacheDir, containerName string) error {
+	script, err := saveArtifactsScript(client, image, scriptsURL)
+	if err != nil {
+		return err
+	}
+
+	tmp := filepath.Join(cacheDir, artifactsArchive+".tmp")
+	archive, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp)
+
+	glog.V(0).Infof("Saving artifacts of image %s into the incremental cache ...", image)
+	err = dockerRun(client, docker.CreateContainerOptions{
+		Name: containerName,
+		Config: &docker.Config{
+			Image:      image,
+			Entrypoint: []string{script},
+		},
+	}, docker.LogsOptions{
+		OutputStream: archive,
+		ErrorStream:  os.Stderr,
+		Follow:       true,
+		Stdout:       true,
+		Stderr:       true,
+	})
+	if closeErr := archive.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return err
+	}
+	return os.Rename(tmp, filepath.Join(cacheDir, artifactsArchive))
+}
+
+// saveArtifactsScript returns the path of the save-artifacts script inside
+// image. Scripts are only looked up inside the image, either at the location
+// given by scriptsURL or at the one the image labels as its S2I scripts URL.
+func saveArtifactsScript(client DockerClient, image, scriptsURL string) (string, error) {
+	if !strings.HasPrefix(scriptsURL, "image://") {
+		metadata, err := client.InspectImage(image)
+		if err != nil {
+			return "", err
+		}
+		scriptsURL = imageScriptsURL(metadata)
+	}
+	if !strings.HasPrefix(scriptsURL, "image://") {
+		return "", fmt.Errorf("image %s does not provide a %s script", image, s2iapi.SaveArtifacts)
+	}
+	return path.Join(strings.TrimPrefix(scriptsURL, "image://"), s2iapi.SaveArtifacts), nil
+}
+
+// imageScriptsURL returns the S2I scripts URL of the image from its label, or
+// from the deprecated environment variable.
+func imageScriptsURL(image *docker.Image) string {
+	if image.Config == nil {
+		return ""
+	}
+	if url := image.Config.Labels[s2idocker.ScriptsURLLabel]; len(url) > 0 {
+		return url
+	}
+	prefix := s2idocker.ScriptsURLEnvironment + "="
+	for _, env := range image.Config.Env {
+		if strings.HasPrefix(env, prefix) {
+			return strings.TrimPrefix(env, prefix)
+		}
+	}
+	return ""
+}
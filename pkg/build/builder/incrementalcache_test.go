@@ -0,0 +1,128 @@
+package builder
+
+import (
+	"archive/tar"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/fsouza/go-dockerclient"
+)
+
+func TestRestoreArtifacts(t *testing.T) {
+	cacheDir, err := ioutil.TempDir("", "incremental-cache")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(cacheDir)
+	buildDir, err := ioutil.TempDir("", "s2i-build")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(buildDir)
+
+	if err := restoreArtifacts(cacheDir, buildDir); err != nil {
+		t.Fatalf("unexpected error restoring from an empty cache: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(buildDir, "upload", "artifacts")); !os.IsNotExist(err) {
+		t.Errorf("expected no artifacts to be restored from an empty cache, got %v", err)
+	}
+
+	archive, err := os.Create(filepath.Join(cacheDir, artifactsArchive))
+	if err != nil {
+		t.Fatal(err)
+	}
+	content := []byte("dependency")
+	w := tar.NewWriter(archive)
+	if err := w.WriteHeader(&tar.Header{Name: "lib/dep.jar", Mode: 0600, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := w.Write(content); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	archive.Close()
+
+	if err := restoreArtifacts(cacheDir, buildDir); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	restored, err := ioutil.ReadFile(filepath.Join(buildDir, "upload", "artifacts", "lib", "dep.jar"))
+	if err != nil {
+		t.Fatalf("expected the artifact to be restored: %v", err)
+	}
+	if string(restored) != string(content) {
+		t.Errorf("expected %q, got %q", content, restored)
+	}
+}
+
+type fakeImageInspector struct {
+	FakeDocker
+	image *docker.Image
+}
+
+func (d *fakeImageInspector) InspectImage(name string) (*docker.Image, error) {
+	return d.image, nil
+}
+
+func TestSaveArtifactsScript(t *testing.T) {
+	tests := []struct {
+		name       string
+		scriptsURL string
+		image      *docker.Image
+		expected   string
+		err        bool
+	}{
+		{
+			name:       "scripts url in image",
+			scriptsURL: "image:///opt/scripts",
+			image:      &docker.Image{},
+			expected:   "/opt/scripts/save-artifacts",
+		},
+		{
+			name:       "image label",
+			scriptsURL: "http://example.com/scripts",
+			image: &docker.Image{Config: &docker.Config{
+				Labels: map[string]string{"io.openshift.s2i.scripts-url": "image:///usr/libexec/s2i"},
+			}},
+			expected: "/usr/libexec/s2i/save-artifacts",
+		},
+		{
+			name: "image environment",
+			image: &docker.Image{Config: &docker.Config{
+				Env: []string{"STI_SCRIPTS_URL=image:///usr/local/sti"},
+			}},
+			expected: "/usr/local/sti/save-artifacts",
+		},
+		{
+			name: "scripts outside of the image",
+			image: &docker.Image{Config: &docker.Config{
+				Labels: map[string]string{"io.openshift.s2i.scripts-url": "http://example.com/scripts"},
+			}},
+			err: true,
+		},
+		{
+			name:  "no scripts url",
+			image: &docker.Image{},
+			err:   true,
+		},
+	}
+	for _, test := range tests {
+		script, err := saveArtifactsScript(&fakeImageInspector{image: test.image}, "image", test.scriptsURL)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: expected an error, got %q", test.name, script)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if script != test.expected {
+			t.Errorf("%s: expected %q, got %q", test.name, test.expected, script)
+		}
+	}
+}
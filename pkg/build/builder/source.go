@@ -222,6 +222,13 @@ func extractGitSource(gitClient GitClient, gitSource *api.GitBuildSource, revisi
 	if usingRef {
 		commit := gitSource.Ref
 
+		if requiresFetch(gitSource.Ref) {
+			if err := gitClient.FetchRef(dir, gitSource.Ref); err != nil {
+				return true, err
+			}
+			commit = "FETCH_HEAD"
+		}
+
 		if revision != nil && revision.Git != nil && revision.Git.Commit != "" {
 			commit = revision.Git.Commit
 		}
@@ -239,6 +246,12 @@ func extractGitSource(gitClient GitClient, gitSource *api.GitBuildSource, revisi
 	return true, nil
 }
 
+// requiresFetch returns true for refs that are not fetched when cloning a
+// repository, such as the refs GitHub keeps for the head of pull requests.
+func requiresFetch(ref string) bool {
+	return strings.HasPrefix(ref, "refs/") && !strings.HasPrefix(ref, "refs/heads/") && !strings.HasPrefix(ref, "refs/tags/")
+}
+
 func copyImageSource(dockerClient DockerClient, containerID, sourceDir, destDir string, tarHelper tar.Tar) error {
 	// Setup destination directory
 	fi, err := os.Stat(destDir)
@@ -299,3 +299,19 @@ func TestCloneFromBranch(t *testing.T) {
 		}
 	}
 }
+
+func TestRequiresFetch(t *testing.T) {
+	tests := map[string]bool{
+		"":                  false,
+		"master":            false,
+		"v1.0":              false,
+		"refs/heads/master": false,
+		"refs/tags/v1.0":    false,
+		"refs/pull/42/head": true,
+	}
+	for ref, expected := range tests {
+		if actual := requiresFetch(ref); actual != expected {
+			t.Errorf("%q: expected %t, got %t", ref, expected, actual)
+		}
+	}
+}
@@ -199,6 +199,23 @@ func (s *S2IBuilder) Build() error {
 	}
 	config.PreviousImagePullPolicy = s2iapi.PullAlways
 
+	var cacheDir, cacheTag string
+	if cache := s.build.Spec.Strategy.SourceStrategy.IncrementalCache; config.Incremental && cache != nil {
+		switch {
+		case cache.PersistentVolumeClaim != nil:
+			// The artifacts are restored from the cache volume, so S2I must
+			// not extract them from the previously pushed image.
+			cacheDir = strategy.IncrementalCacheMountPath
+			config.Incremental = false
+			if err := restoreArtifacts(cacheDir, buildDir); err != nil {
+				return fmt.Errorf("unable to restore artifacts from the incremental cache: %v", err)
+			}
+		case cache.Image != nil:
+			cacheTag = cache.Image.Name
+			config.IncrementalFromTag = cacheTag
+		}
+	}
+
 	allowedUIDs := os.Getenv(api.AllowedUIDs)
 	glog.V(0).Infof("The value of %s is [%s]", api.AllowedUIDs, allowedUIDs)
 	if len(allowedUIDs) > 0 {
@@ -225,7 +242,7 @@ func (s *S2IBuilder) Build() error {
 	// If DockerCfgPath is provided in api.Config, then attempt to read the the
 	// dockercfg file and get the authentication for pulling the builder image.
 	config.PullAuthentication, _ = dockercfg.NewHelper().GetDockerAuth(config.BuilderImage, dockercfg.PullAuthType)
-	config.IncrementalAuthentication, _ = dockercfg.NewHelper().GetDockerAuth(pushTag, dockercfg.PushAuthType)
+	config.IncrementalAuthentication, _ = dockercfg.NewHelper().GetDockerAuth(config.IncrementalFromTag, dockercfg.PushAuthType)
 
 	glog.V(0).Infof("Creating a new S2I builder with build config: %#v\n", describe.DescribeConfig(config))
 	builder, err := s.builder.Builder(config, s2ibuild.Overrides{Downloader: download})
@@ -244,11 +261,23 @@ func (s *S2IBuilder) Build() error {
 		return err
 	}
 
+	if len(cacheDir) > 0 {
+		cname := containerName("s2i", s.build.Name, s.build.Namespace, "save-artifacts")
+		if err := saveArtifacts(s.dockerClient, buildTag, config.ScriptsURL, cacheDir, cname); err != nil {
+			glog.V(0).Infof("warning: Failed to save artifacts into the incremental cache: %v", err)
+		}
+	}
+
 	if push {
 		if err := tagImage(s.dockerClient, buildTag, pushTag); err != nil {
 			return err
 		}
 	}
+	if len(cacheTag) > 0 {
+		if err := tagImage(s.dockerClient, buildTag, cacheTag); err != nil {
+			return err
+		}
+	}
 
 	if err := removeImage(s.dockerClient, buildTag); err != nil {
 		glog.V(0).Infof("warning: Failed to remove temporary build tag %v: %v", buildTag, err)
@@ -283,6 +312,17 @@ func (s *S2IBuilder) Build() error {
 		}
 		glog.V(0).Infof("Push successful")
 	}
+
+	if len(cacheTag) > 0 {
+		cacheAuthConfig, _ := dockercfg.NewHelper().GetDockerAuth(cacheTag, dockercfg.PushAuthType)
+		glog.V(0).Infof("Pushing image %s to the incremental cache ...", cacheTag)
+		if err := pushImage(s.dockerClient, cacheTag, cacheAuthConfig); err != nil {
+			glog.V(0).Infof("warning: Failed to push the incremental cache image %s: %v", cacheTag, err)
+		}
+		if err := removeImage(s.dockerClient, cacheTag); err != nil {
+			glog.V(0).Infof("warning: Failed to remove temporary incremental cache tag %v: %v", cacheTag, err)
+		}
+	}
 	return nil
 }
 
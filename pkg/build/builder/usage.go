@@ -0,0 +1,332 @@
+package builder
+
+import (
+	"bufio"
+	"io"
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"sync"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+
+	"k8s.io/kubernetes/pkg/api/resource"
+
+	"github.com/openshift/origin/pkg/build/api"
+	buildutil "github.com/openshift/origin/pkg/build/util"
+)
+
+const (
+	memoryMaxUsagePath = "/sys/fs/cgroup/memory/memory.max_usage_in_bytes"
+
+	// resourceUsageInterval is how often the CPU usage of the build is sampled.
+	resourceUsageInterval = 5 * time.Second
+	// containerStatsTimeout bounds the time spent reading the stats of a build
+	// container.
+	containerStatsTimeout = 10 * time.Second
+	// shortContainerIDLength is the length of the container IDs Docker shows.
+	shortContainerIDLength = 12
+)
+
+// dockerBuildContainerPattern matches the lines of the output of a Docker build
+// reporting the containers running the instructions of the Dockerfile.
+var dockerBuildContainerPattern = regexp.MustCompile(`^\s*---> Running in ([0-9a-f]+)\s*$`)
+
+// containerStatsClient is the part of the Docker client used to find and measure
+// the containers running a build.
+type containerStatsClient interface {
+	ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error)
+	InspectContainer(id string) (*docker.Container, error)
+	Stats(opts docker.StatsOptions) error
+}
+
+// containerUsage is the last sampled usage of a build container.
+type containerUsage struct {
+	cpu     uint64
+	sampled bool
+}
+
+// ResourceUsageMonitor records the peak usage of compute resources by a build
+// while it runs. The builder process is measured from its own cgroup, and the
+// containers the Docker daemon runs for the build next to the builder container
+// from their Docker stats. Those are the containers created through the client
+// returned by DockerClient, the containers of Docker builds started through it,
+// and the containers sharing the network of the builder container, which S2I
+// runs its builds in.
+type ResourceUsageMonitor struct {
+	cpuUsagePath    string
+	memoryUsagePath string
+	interval        time.Duration
+
+	client    containerStatsClient
+	builderID string
+
+	stop chan struct{}
+	done chan struct{}
+
+	lock         sync.Mutex
+	lastCPU      int64
+	lastSample   time.Time
+	peakMilliCPU int64
+	sampled      bool
+	// containers holds the build containers by ID, and nil for the other
+	// containers found running next to the builder.
+	containers      map[string]*containerUsage
+	containerMemory int64
+	outOfMemory     bool
+}
+
+// NewResourceUsageMonitor returns a monitor of the build run by the builder
+// container through the Docker client.
+func NewResourceUsageMonitor(client containerStatsClient) *ResourceUsageMonitor {
+	cpuUsagePath := filepath.Join(getCPUCGroupDir(), "cpuacct.usage")
+	return newResourceUsageMonitor(cpuUsagePath, memoryMaxUsagePath, resourceUsageInterval, client, getContainerID())
+}
+
+func newResourceUsageMonitor(cpuUsagePath, memoryUsagePath string, interval time.Duration, client containerStatsClient, builderID string) *ResourceUsageMonitor {
+	return &ResourceUsageMonitor{
+		cpuUsagePath:    cpuUsagePath,
+		memoryUsagePath: memoryUsagePath,
+		interval:        interval,
+		client:          client,
+		builderID:       builderID,
+		stop:            make(chan struct{}),
+		done:            make(chan struct{}),
+		containers:      make(map[string]*containerUsage),
+	}
+}
+
+// DockerClient returns a Docker client recording the containers created through
+// client as containers of the build.
+func (m *ResourceUsageMonitor) DockerClient(client DockerClient) DockerClient {
+	return &monitoredDockerClient{DockerClient: client, monitor: m}
+}
+
+// AddContainer records the container with the given ID as a container of the build.
+func (m *ResourceUsageMonitor) AddContainer(id string) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if m.containers[id] == nil {
+		m.containers[id] = &containerUsage{}
+	}
+}
+
+// Start begins sampling the CPU usage of the build.
+func (m *ResourceUsageMonitor) Start() {
+	usage, err := readInt64(m.cpuUsagePath)
+	if err != nil {
+		glog.V(4).Infof("Not recording the resource usage of the build: %v", err)
+		close(m.done)
+		return
+	}
+	m.lastCPU, m.lastSample = usage, time.Now()
+	go func() {
+		defer close(m.done)
+		ticker := time.NewTicker(m.interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-m.stop:
+				m.sample(time.Now())
+				return
+			case now := <-ticker.C:
+				m.sample(now)
+			}
+		}
+	}()
+}
+
+// sample records the CPU used by the builder and the build containers since the
+// previous sample, and the peak memory usage of the build containers.
+func (m *ResourceUsageMonitor) sample(now time.Time) {
+	usage, err := readInt64(m.cpuUsagePath)
+	if err != nil {
+		return
+	}
+	containerCPU := m.sampleContainers()
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if elapsed := now.Sub(m.lastSample).Nanoseconds(); elapsed > 0 {
+		if milliCPU := (usage - m.lastCPU + containerCPU) * 1000 / elapsed; milliCPU > m.peakMilliCPU {
+			m.peakMilliCPU = milliCPU
+		}
+		m.sampled = true
+	}
+	m.lastCPU, m.lastSample = usage, now
+}
+
+// sampleContainers reads the stats of the running build containers and returns
+// the CPU time they used since they were last sampled.
+func (m *ResourceUsageMonitor) sampleContainers() int64 {
+	if m.client == nil {
+		return 0
+	}
+	running, err := m.client.ListContainers(docker.ListContainersOptions{})
+	if err != nil {
+		glog.V(4).Infof("Unable to list the containers of the build: %v", err)
+		return 0
+	}
+	var cpu int64
+	for _, container := range running {
+		usage := m.buildContainer(container.ID)
+		if usage == nil {
+			continue
+		}
+		stats, err := m.containerStats(container.ID)
+		if err != nil || stats == nil {
+			glog.V(4).Infof("Unable to read the resource usage of build container %s: %v", container.ID, err)
+			continue
+		}
+		m.lock.Lock()
+		if total := stats.CPUStats.CPUUsage.TotalUsage; usage.sampled && total > usage.cpu {
+			cpu += int64(total - usage.cpu)
+		}
+		usage.cpu, usage.sampled = stats.CPUStats.CPUUsage.TotalUsage, true
+		if memory := int64(stats.MemoryStats.MaxUsage); memory > m.containerMemory {
+			m.containerMemory = memory
+		}
+		// the container ran into its memory limit
+		if stats.MemoryStats.Failcnt > 0 {
+			m.outOfMemory = true
+		}
+		m.lock.Unlock()
+	}
+	return cpu
+}
+
+// buildContainer returns the usage of the container with the given ID if it is a
+// container of the build, or nil. Containers not created through the monitored
+// client are inspected once to find whether they share the network of the
+// builder container.
+func (m *ResourceUsageMonitor) buildContainer(id string) *containerUsage {
+	m.lock.Lock()
+	usage, known := m.containers[id]
+	if !known && len(id) > shortContainerIDLength {
+		// Docker builds report the short IDs of their containers
+		usage, known = m.containers[id[:shortContainerIDLength]]
+	}
+	m.lock.Unlock()
+	if known || len(m.builderID) == 0 || id == m.builderID {
+		return usage
+	}
+	container, err := m.client.InspectContainer(id)
+	if err != nil {
+		return nil
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if container.HostConfig != nil && container.HostConfig.NetworkMode == "container:"+m.builderID {
+		m.containers[id] = &containerUsage{}
+	} else {
+		m.containers[id] = nil
+	}
+	return m.containers[id]
+}
+
+// containerStats returns the current stats of the container with the given ID.
+func (m *ResourceUsageMonitor) containerStats(id string) (*docker.Stats, error) {
+	statsCh := make(chan *docker.Stats, 1)
+	errCh := make(chan error, 1)
+	go func() {
+		errCh <- m.client.Stats(docker.StatsOptions{ID: id, Stats: statsCh, Stream: false, Timeout: containerStatsTimeout})
+	}()
+	var stats *docker.Stats
+	for s := range statsCh {
+		stats = s
+	}
+	return stats, <-errCh
+}
+
+// Stop stops sampling and returns the peak resource usage of the build, or nil
+// if it could not be read from the cgroup of the builder container. The memory
+// usage of the build is the peak memory usage of the builder and of its largest
+// build container.
+func (m *ResourceUsageMonitor) Stop() *api.BuildResourceUsage {
+	close(m.stop)
+	<-m.done
+	memory, err := readInt64(m.memoryUsagePath)
+	if err != nil {
+		glog.V(4).Infof("Unable to read the memory usage of the build: %v", err)
+		return nil
+	}
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	if !m.sampled {
+		return nil
+	}
+	return &api.BuildResourceUsage{
+		CPU:         *resource.NewMilliQuantity(m.peakMilliCPU, resource.DecimalSI),
+		Memory:      *resource.NewQuantity(memory+m.containerMemory, resource.BinarySI),
+		OutOfMemory: m.outOfMemory,
+	}
+}
+
+// monitoredDockerClient records the containers it creates, and the containers
+// of the Docker builds it runs, as containers of the build.
+type monitoredDockerClient struct {
+	DockerClient
+	monitor *ResourceUsageMonitor
+}
+
+func (c *monitoredDockerClient) CreateContainer(opts docker.CreateContainerOptions) (*docker.Container, error) {
+	container, err := c.DockerClient.CreateContainer(opts)
+	if err == nil && container != nil {
+		c.monitor.AddContainer(container.ID)
+	}
+	return container, err
+}
+
+func (c *monitoredDockerClient) BuildImage(opts docker.BuildImageOptions) error {
+	if opts.OutputStream == nil || opts.RawJSONStream {
+		return c.DockerClient.BuildImage(opts)
+	}
+	r, w := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		c.monitor.scanDockerBuild(r, opts.OutputStream)
+	}()
+	opts.OutputStream = w
+	err := c.DockerClient.BuildImage(opts)
+	// Wait for all of the output to be copied before returning.
+	w.Close()
+	<-done
+	return err
+}
+
+// scanDockerBuild copies the output of a Docker build to out, recording the
+// containers running its instructions. The output is copied as it is read, so
+// lines too long to be scanned are still copied in full.
+func (m *ResourceUsageMonitor) scanDockerBuild(r io.Reader, out io.Writer) {
+	tee := io.TeeReader(r, out)
+	scanner := bufio.NewScanner(tee)
+	for scanner.Scan() {
+		if match := dockerBuildContainerPattern.FindStringSubmatch(scanner.Text()); match != nil {
+			m.AddContainer(match[1])
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		glog.V(4).Infof("Unable to scan the output of the Docker build for build containers: %v", err)
+	}
+	// Copy the remaining output even if it could not be scanned, and drain it
+	// if it could not be written, so that the build is never blocked writing it.
+	if _, err := io.Copy(ioutil.Discard, tee); err != nil {
+		io.Copy(ioutil.Discard, r)
+	}
+}
+
+// WriteResourceUsage reports the resource usage of the build in the termination
+// message of the build container, where the build controller records it on the
+// build once the build completes.
+func WriteResourceUsage(path string, usage *api.BuildResourceUsage) error {
+	if usage == nil {
+		return nil
+	}
+	message, err := buildutil.FormatResourceUsage(usage)
+	if err != nil {
+		return err
+	}
+	return ioutil.WriteFile(path, []byte(message), 0644)
+}
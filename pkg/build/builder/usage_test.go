@@ -0,0 +1,229 @@
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	docker "github.com/fsouza/go-dockerclient"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+
+	"github.com/openshift/origin/pkg/build/api"
+	buildutil "github.com/openshift/origin/pkg/build/util"
+)
+
+func TestResourceUsageMonitor(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cpuPath := filepath.Join(dir, "cpuacct.usage")
+	memoryPath := filepath.Join(dir, "memory.max_usage_in_bytes")
+	if err := ioutil.WriteFile(cpuPath, []byte("0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(memoryPath, []byte("268435456\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	monitor := newResourceUsageMonitor(cpuPath, memoryPath, time.Hour, nil, "")
+	monitor.Start()
+	time.Sleep(50 * time.Millisecond)
+	// one second of CPU time in well under a second is more than one core
+	if err := ioutil.WriteFile(cpuPath, []byte("1000000000\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	usage := monitor.Stop()
+	if usage == nil {
+		t.Fatalf("expected the resource usage to be recorded")
+	}
+	if usage.CPU.MilliValue() <= 1000 {
+		t.Errorf("expected more than one core, got %s", usage.CPU.String())
+	}
+	if usage.Memory.String() != "256Mi" {
+		t.Errorf("expected 256Mi of memory, got %s", usage.Memory.String())
+	}
+}
+
+type fakeContainerStatsClient struct {
+	containers map[string]*docker.Container
+	stats      map[string]*docker.Stats
+	inspected  []string
+}
+
+func (c *fakeContainerStatsClient) ListContainers(opts docker.ListContainersOptions) ([]docker.APIContainers, error) {
+	containers := []docker.APIContainers{}
+	for id := range c.containers {
+		containers = append(containers, docker.APIContainers{ID: id})
+	}
+	return containers, nil
+}
+
+func (c *fakeContainerStatsClient) InspectContainer(id string) (*docker.Container, error) {
+	c.inspected = append(c.inspected, id)
+	return c.containers[id], nil
+}
+
+func (c *fakeContainerStatsClient) Stats(opts docker.StatsOptions) error {
+	defer close(opts.Stats)
+	if stats, ok := c.stats[opts.ID]; ok {
+		opts.Stats <- stats
+	}
+	return nil
+}
+
+func containerStats(cpu, memory, failcnt uint64) *docker.Stats {
+	stats := &docker.Stats{}
+	stats.CPUStats.CPUUsage.TotalUsage = cpu
+	stats.MemoryStats.MaxUsage = memory
+	stats.MemoryStats.Failcnt = failcnt
+	return stats
+}
+
+func TestResourceUsageMonitorBuildContainers(t *testing.T) {
+	dir, err := ioutil.TempDir("", "cgroup")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	cpuPath := filepath.Join(dir, "cpuacct.usage")
+	memoryPath := filepath.Join(dir, "memory.max_usage_in_bytes")
+	if err := ioutil.WriteFile(cpuPath, []byte("0\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(memoryPath, []byte("67108864\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	s2iContainer := strings.Repeat("a", 64)
+	dockerBuildContainer := strings.Repeat("b", 64)
+	otherContainer := strings.Repeat("c", 64)
+	client := &fakeContainerStatsClient{
+		containers: map[string]*docker.Container{
+			s2iContainer:         {HostConfig: &docker.HostConfig{NetworkMode: "container:builder"}},
+			dockerBuildContainer: {HostConfig: &docker.HostConfig{}},
+			otherContainer:       {HostConfig: &docker.HostConfig{}},
+		},
+		stats: map[string]*docker.Stats{
+			s2iContainer:         containerStats(0, 256*1024*1024, 0),
+			dockerBuildContainer: containerStats(0, 128*1024*1024, 0),
+			otherContainer:       containerStats(0, 1024*1024*1024, 0),
+		},
+	}
+	monitor := newResourceUsageMonitor(cpuPath, memoryPath, time.Hour, client, "builder")
+
+	// the containers of Docker builds are found from the build output
+	out := &bytes.Buffer{}
+	r, w := io.Pipe()
+	done := make(chan struct{})
+	go func() {
+		monitor.scanDockerBuild(r, out)
+		close(done)
+	}()
+	fmt.Fprintf(w, "Step 2 : RUN make\n ---> Running in %s\n", dockerBuildContainer[:12])
+	w.Close()
+	<-done
+	if !strings.Contains(out.String(), "Running in") {
+		t.Errorf("expected the build output to be copied, got %q", out.String())
+	}
+
+	start := time.Now()
+	monitor.lastSample = start
+	monitor.sample(start.Add(time.Second))
+	client.stats[s2iContainer] = containerStats(1500000000, 512*1024*1024, 1)
+	client.stats[dockerBuildContainer] = containerStats(500000000, 128*1024*1024, 0)
+	client.stats[otherContainer] = containerStats(10000000000, 1024*1024*1024, 0)
+	monitor.sample(start.Add(2 * time.Second))
+	close(monitor.done)
+
+	usage := monitor.Stop()
+	if usage == nil {
+		t.Fatalf("expected the resource usage to be recorded")
+	}
+	if usage.CPU.String() != "2" {
+		t.Errorf("expected two cores used by the build containers, got %s", usage.CPU.String())
+	}
+	if usage.Memory.String() != "576Mi" {
+		t.Errorf("expected the memory of the builder and of the largest build container, got %s", usage.Memory.String())
+	}
+	if !usage.OutOfMemory {
+		t.Errorf("expected the build to have run out of memory")
+	}
+	for _, id := range client.inspected {
+		if id == dockerBuildContainer {
+			t.Errorf("expected the Docker build container not to be inspected")
+		}
+	}
+}
+
+func TestMonitoredDockerClientBuildImage(t *testing.T) {
+	container := strings.Repeat("d", 64)
+	longLine := strings.Repeat("x", 128*1024)
+	output := fmt.Sprintf("Step 1 : RUN make\n ---> Running in %s\n%s\nSuccessfully built\n", container[:12], longLine)
+	fake := &FakeDocker{
+		buildImageFunc: func(opts docker.BuildImageOptions) error {
+			_, err := io.WriteString(opts.OutputStream, output)
+			return err
+		},
+	}
+	monitor := newResourceUsageMonitor("", "", time.Hour, nil, "")
+
+	// all of the output, including lines too long to be scanned, is copied
+	// by the time the build returns
+	out := &bytes.Buffer{}
+	if err := monitor.DockerClient(fake).BuildImage(docker.BuildImageOptions{OutputStream: out}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != output {
+		t.Errorf("expected the build output to be copied, got %d of %d bytes", out.Len(), len(output))
+	}
+	if _, ok := monitor.containers[container[:12]]; !ok {
+		t.Errorf("expected the build container to be recorded, got %v", monitor.containers)
+	}
+}
+
+func TestResourceUsageMonitorWithoutCGroups(t *testing.T) {
+	monitor := newResourceUsageMonitor("/non/existent/cpuacct.usage", "/non/existent/memory.max_usage_in_bytes", time.Hour, nil, "")
+	monitor.Start()
+	if usage := monitor.Stop(); usage != nil {
+		t.Errorf("expected no resource usage, got %#v", usage)
+	}
+}
+
+func TestWriteResourceUsage(t *testing.T) {
+	dir, err := ioutil.TempDir("", "termination")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "termination-log")
+
+	usage := monitorUsage("1500m", "512Mi")
+	if err := WriteResourceUsage(path, usage); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	message, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pod := &kapi.Pod{Status: kapi.PodStatus{ContainerStatuses: []kapi.ContainerStatus{{
+		State: kapi.ContainerState{Terminated: &kapi.ContainerStateTerminated{Message: string(message)}},
+	}}}}
+	reported := buildutil.ResourceUsageForPod(pod)
+	if reported == nil || reported.CPU.Cmp(usage.CPU) != 0 || reported.Memory.Cmp(usage.Memory) != 0 {
+		t.Errorf("expected %#v to be reported, got %#v", usage, reported)
+	}
+}
+
+func monitorUsage(cpu, memory string) *api.BuildResourceUsage {
+	return &api.BuildResourceUsage{CPU: resource.MustParse(cpu), Memory: resource.MustParse(memory)}
+}
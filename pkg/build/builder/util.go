@@ -50,40 +50,30 @@ func readNetClsCGroup(reader io.Reader) string {
 	return ""
 }
 
-// getDockerNetworkMode determines whether the builder is running as a container
-// by examining /proc/self/cgroup. This context is then passed to source-to-image.
-func getDockerNetworkMode() s2iapi.DockerNetworkMode {
+// getContainerID returns the ID of the container the builder is running in, by
+// examining /proc/self/cgroup, or an empty string if it is not running in one.
+func getContainerID() string {
 	file, err := os.Open("/proc/self/cgroup")
 	if err != nil {
 		return ""
 	}
 	defer file.Close()
 
-	if id := readNetClsCGroup(file); id != "" {
+	return readNetClsCGroup(file)
+}
+
+// getDockerNetworkMode determines whether the builder is running as a container
+// by examining /proc/self/cgroup. This context is then passed to source-to-image.
+func getDockerNetworkMode() s2iapi.DockerNetworkMode {
+	if id := getContainerID(); id != "" {
 		return s2iapi.NewDockerNetworkModeContainer(id)
 	}
 	return ""
 }
 
-// GetCGroupLimits returns a struct populated with cgroup limit values gathered
-// from the local /sys/fs/cgroup filesystem.  Overflow values are set to
-// math.MaxInt64.
-func GetCGroupLimits() (*s2iapi.CGroupLimits, error) {
-	byteLimit, err := readInt64("/sys/fs/cgroup/memory/memory.limit_in_bytes")
-	if err != nil {
-		// for systems without cgroups builds should succeed
-		if _, err := os.Stat("/sys/fs/cgroup"); os.IsNotExist(err) {
-			return &s2iapi.CGroupLimits{}, nil
-		}
-		return nil, fmt.Errorf("cannot determine cgroup limits: %v", err)
-	}
-	// math.MaxInt64 seems to give cgroups trouble, this value is
-	// still 92 terabytes, so it ought to be sufficiently large for
-	// our purposes.
-	if byteLimit > 92233720368547 {
-		byteLimit = 92233720368547
-	}
-
+// getCPUCGroupDir returns the directory of the cpu cgroup of the builder, which
+// also holds its cpuacct accounting.
+func getCPUCGroupDir() string {
 	// different docker versions seem to use different cgroup directories,
 	// check for all of them.
 
@@ -103,6 +93,29 @@ func GetCGroupLimits() (*s2iapi.CGroupLimits, error) {
 	if _, err := os.Stat("/sys/fs/cgroup/cpu"); err == nil {
 		cpuDir = "/sys/fs/cgroup/cpu"
 	}
+	return cpuDir
+}
+
+// GetCGroupLimits returns a struct populated with cgroup limit values gathered
+// from the local /sys/fs/cgroup filesystem.  Overflow values are set to
+// math.MaxInt64.
+func GetCGroupLimits() (*s2iapi.CGroupLimits, error) {
+	byteLimit, err := readInt64("/sys/fs/cgroup/memory/memory.limit_in_bytes")
+	if err != nil {
+		// for systems without cgroups builds should succeed
+		if _, err := os.Stat("/sys/fs/cgroup"); os.IsNotExist(err) {
+			return &s2iapi.CGroupLimits{}, nil
+		}
+		return nil, fmt.Errorf("cannot determine cgroup limits: %v", err)
+	}
+	// math.MaxInt64 seems to give cgroups trouble, this value is
+	// still 92 terabytes, so it ought to be sufficiently large for
+	// our purposes.
+	if byteLimit > 92233720368547 {
+		byteLimit = 92233720368547
+	}
+
+	cpuDir := getCPUCGroupDir()
 
 	cpuQuota, err := readInt64(filepath.Join(cpuDir, "cpu.cfs_quota_us"))
 	if err != nil {
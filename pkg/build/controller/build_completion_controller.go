@@ -0,0 +1,166 @@
+package controller
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/golang/glog"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildclient "github.com/openshift/origin/pkg/build/client"
+	buildutil "github.com/openshift/origin/pkg/build/util"
+)
+
+// BuildCompletionController watches for builds that complete successfully and
+// triggers builds of the BuildConfigs that have a BuildCompletion trigger for
+// the BuildConfig of the completed build.
+type BuildCompletionController struct {
+	BuildConfigStore cache.Store
+	// BuildStore is used to follow the chain of builds that caused the
+	// completed build, so that triggers forming a cycle are not followed.
+	BuildStore              cache.Store
+	BuildConfigInstantiator buildclient.BuildConfigInstantiator
+}
+
+// HandleBuild processes the next Build event.
+func (c *BuildCompletionController) HandleBuild(build *buildapi.Build) error {
+	if build.Status.Phase != buildapi.BuildPhaseComplete {
+		return nil
+	}
+	// builds of pull requests and build matrix entries don't build the
+	// BuildConfig's own source, so they are not inputs of downstream builds
+	if _, ok := build.Labels[buildapi.BuildPullRequestLabel]; ok {
+		return nil
+	}
+	if _, ok := build.Labels[buildapi.BuildMatrixLabel]; ok {
+		return nil
+	}
+	configName := buildutil.ConfigNameForBuild(build)
+	if len(configName) == 0 {
+		return nil
+	}
+	glog.V(4).Infof("Build completion controller detected completed build %s/%s", build.Namespace, build.Name)
+
+	// Loop through all build configurations and record if there was an error
+	// instead of breaking the loop, as the ImageChangeController does.
+	hasError := false
+	for _, obj := range c.BuildConfigStore.List() {
+		config := obj.(*buildapi.BuildConfig)
+		trigger := buildCompletionTriggerFor(config, build.Namespace, configName)
+		if trigger == nil || !isNewerBuild(build, configName, trigger.LastTriggeredBuild) {
+			continue
+		}
+		if c.isCausedBy(build, config.Namespace, config.Name) {
+			glog.V(4).Infof("Not running build for BuildConfig %s/%s, build %s/%s was caused by one of its builds", config.Namespace, config.Name, build.Namespace, build.Name)
+			continue
+		}
+
+		glog.V(4).Infof("Running build for BuildConfig %s/%s triggered by build %s/%s", config.Namespace, config.Name, build.Namespace, build.Name)
+		request := &buildapi.BuildRequest{
+			ObjectMeta: kapi.ObjectMeta{
+				Name:      config.Name,
+				Namespace: config.Namespace,
+			},
+			TriggeredBy: []buildapi.BuildTriggerCause{
+				{
+					Message: "Build completion",
+					BuildCompletion: &buildapi.BuildCompletionCause{
+						BuildName: build.Name,
+						FromRef:   &trigger.From,
+					},
+				},
+			},
+		}
+		if _, err := c.BuildConfigInstantiator.Instantiate(config.Namespace, request); err != nil {
+			if kerrors.IsConflict(err) {
+				utilruntime.HandleError(fmt.Errorf("unable to instantiate Build for BuildConfig %s/%s due to a conflicting update: %v", config.Namespace, config.Name, err))
+			} else {
+				utilruntime.HandleError(fmt.Errorf("error instantiating Build from BuildConfig %s/%s: %v", config.Namespace, config.Name, err))
+			}
+			hasError = true
+		}
+	}
+	if hasError {
+		return fmt.Errorf("an error occurred processing 1 or more build configurations; the build completion trigger for build %s/%s will be retried", build.Namespace, build.Name)
+	}
+	return nil
+}
+
+// buildCompletionTriggerFor returns the BuildCompletion trigger of config that
+// refers to the named BuildConfig, if any. Only BuildConfigs in the namespace of
+// config can trigger its builds.
+func buildCompletionTriggerFor(config *buildapi.BuildConfig, namespace, name string) *buildapi.BuildCompletionTrigger {
+	if config.Namespace != namespace {
+		return nil
+	}
+	for _, trigger := range config.Spec.Triggers {
+		if trigger.Type != buildapi.BuildCompletionBuildTriggerType || trigger.BuildCompletion == nil {
+			continue
+		}
+		from := trigger.BuildCompletion.From
+		fromNamespace := from.Namespace
+		if len(fromNamespace) == 0 {
+			fromNamespace = config.Namespace
+		}
+		if from.Name == name && fromNamespace == namespace {
+			return trigger.BuildCompletion
+		}
+	}
+	return nil
+}
+
+// isCausedBy returns true if build was caused by the completion of a build of
+// the named BuildConfig, directly or through builds of other BuildConfigs.
+func (c *BuildCompletionController) isCausedBy(build *buildapi.Build, namespace, name string) bool {
+	visited := sets.NewString()
+	for pending := []*buildapi.Build{build}; len(pending) > 0; pending = pending[1:] {
+		current := pending[0]
+		for _, cause := range current.Spec.TriggeredBy {
+			if cause.BuildCompletion == nil || cause.BuildCompletion.FromRef == nil {
+				continue
+			}
+			fromNamespace := cause.BuildCompletion.FromRef.Namespace
+			if len(fromNamespace) == 0 {
+				fromNamespace = current.Namespace
+			}
+			if fromNamespace == namespace && cause.BuildCompletion.FromRef.Name == name {
+				return true
+			}
+			key := fromNamespace + "/" + cause.BuildCompletion.BuildName
+			if visited.Has(key) {
+				continue
+			}
+			visited.Insert(key)
+			// builds which were pruned end the chain
+			obj, exists, err := c.BuildStore.GetByKey(key)
+			if err != nil || !exists {
+				continue
+			}
+			pending = append(pending, obj.(*buildapi.Build))
+		}
+	}
+	return false
+}
+
+// isNewerBuild returns true if build is more recent than the last build of the
+// same BuildConfig that triggered a build, so that builds completing out of
+// order do not trigger a build with older inputs.
+func isNewerBuild(build *buildapi.Build, configName, lastTriggeredBuild string) bool {
+	if len(lastTriggeredBuild) == 0 {
+		return true
+	}
+	if lastTriggeredBuild == build.Name {
+		return false
+	}
+	lastVersion, err := strconv.Atoi(strings.TrimPrefix(lastTriggeredBuild, configName+"-"))
+	if err != nil {
+		return true
+	}
+	return buildutil.VersionForBuild(build) > lastVersion
+}
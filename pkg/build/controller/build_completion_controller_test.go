@@ -0,0 +1,219 @@
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+)
+
+type recordingInstantiator struct {
+	requests []*buildapi.BuildRequest
+	err      error
+}
+
+func (i *recordingInstantiator) Instantiate(namespace string, request *buildapi.BuildRequest) (*buildapi.Build, error) {
+	i.requests = append(i.requests, request)
+	return nil, i.err
+}
+
+func mockCompletedBuild(namespace, config string, version int, phase buildapi.BuildPhase) *buildapi.Build {
+	return &buildapi.Build{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:        fmt.Sprintf("%s-%d", config, version),
+			Namespace:   namespace,
+			Labels:      map[string]string{buildapi.BuildConfigLabel: config},
+			Annotations: map[string]string{buildapi.BuildNumberAnnotation: fmt.Sprintf("%d", version)},
+		},
+		Status: buildapi.BuildStatus{Phase: phase},
+	}
+}
+
+func mockDownstreamBuildConfig(namespace, name string, from kapi.ObjectReference, lastTriggeredBuild string) *buildapi.BuildConfig {
+	return &buildapi.BuildConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: namespace},
+		Spec: buildapi.BuildConfigSpec{
+			Triggers: []buildapi.BuildTriggerPolicy{
+				{
+					Type: buildapi.BuildCompletionBuildTriggerType,
+					BuildCompletion: &buildapi.BuildCompletionTrigger{
+						From:               from,
+						LastTriggeredBuild: lastTriggeredBuild,
+					},
+				},
+			},
+		},
+	}
+}
+
+func withLabel(build *buildapi.Build, key, value string) *buildapi.Build {
+	build.Labels[key] = value
+	return build
+}
+
+func TestBuildCompletionControllerHandleBuild(t *testing.T) {
+	base := kapi.ObjectReference{Kind: "BuildConfig", Name: "base"}
+	tests := []struct {
+		name      string
+		build     *buildapi.Build
+		configs   []*buildapi.BuildConfig
+		triggered []string
+	}{
+		{
+			name:      "completed build triggers downstream config",
+			build:     mockCompletedBuild("test", "base", 2, buildapi.BuildPhaseComplete),
+			configs:   []*buildapi.BuildConfig{mockDownstreamBuildConfig("test", "app", base, "base-1")},
+			triggered: []string{"app"},
+		},
+		{
+			name:    "running build is ignored",
+			build:   mockCompletedBuild("test", "base", 2, buildapi.BuildPhaseRunning),
+			configs: []*buildapi.BuildConfig{mockDownstreamBuildConfig("test", "app", base, "")},
+		},
+		{
+			name:    "failed build is ignored",
+			build:   mockCompletedBuild("test", "base", 2, buildapi.BuildPhaseFailed),
+			configs: []*buildapi.BuildConfig{mockDownstreamBuildConfig("test", "app", base, "")},
+		},
+		{
+			name:    "build already triggered a build",
+			build:   mockCompletedBuild("test", "base", 2, buildapi.BuildPhaseComplete),
+			configs: []*buildapi.BuildConfig{mockDownstreamBuildConfig("test", "app", base, "base-2")},
+		},
+		{
+			name:    "older build completing late",
+			build:   mockCompletedBuild("test", "base", 2, buildapi.BuildPhaseComplete),
+			configs: []*buildapi.BuildConfig{mockDownstreamBuildConfig("test", "app", base, "base-3")},
+		},
+		{
+			name:  "cross namespace reference",
+			build: mockCompletedBuild("shared", "base", 1, buildapi.BuildPhaseComplete),
+			configs: []*buildapi.BuildConfig{
+				mockDownstreamBuildConfig("test", "app", kapi.ObjectReference{Kind: "BuildConfig", Name: "base", Namespace: "shared"}, ""),
+				mockDownstreamBuildConfig("test", "other", base, ""),
+			},
+		},
+		{
+			name:  "explicit namespace reference",
+			build: mockCompletedBuild("test", "base", 1, buildapi.BuildPhaseComplete),
+			configs: []*buildapi.BuildConfig{
+				mockDownstreamBuildConfig("test", "app", kapi.ObjectReference{Kind: "BuildConfig", Name: "base", Namespace: "test"}, ""),
+			},
+			triggered: []string{"app"},
+		},
+		{
+			name:    "pull request build is ignored",
+			build:   withLabel(mockCompletedBuild("test", "base", 2, buildapi.BuildPhaseComplete), buildapi.BuildPullRequestLabel, "12"),
+			configs: []*buildapi.BuildConfig{mockDownstreamBuildConfig("test", "app", base, "base-1")},
+		},
+		{
+			name:    "build matrix entry is ignored",
+			build:   withLabel(mockCompletedBuild("test", "base", 2, buildapi.BuildPhaseComplete), buildapi.BuildMatrixLabel, "base-x1z2p"),
+			configs: []*buildapi.BuildConfig{mockDownstreamBuildConfig("test", "app", base, "base-1")},
+		},
+		{
+			name:    "unrelated build config",
+			build:   mockCompletedBuild("test", "unrelated", 1, buildapi.BuildPhaseComplete),
+			configs: []*buildapi.BuildConfig{mockDownstreamBuildConfig("test", "app", base, "")},
+		},
+	}
+
+	for _, test := range tests {
+		store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+		for _, config := range test.configs {
+			store.Add(config)
+		}
+		instantiator := &recordingInstantiator{}
+		controller := &BuildCompletionController{
+			BuildConfigStore:        store,
+			BuildStore:              cache.NewStore(cache.MetaNamespaceKeyFunc),
+			BuildConfigInstantiator: instantiator,
+		}
+		if err := controller.HandleBuild(test.build); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if len(instantiator.requests) != len(test.triggered) {
+			t.Errorf("%s: expected %d builds to be triggered, got %d", test.name, len(test.triggered), len(instantiator.requests))
+			continue
+		}
+		for i, request := range instantiator.requests {
+			if request.Name != test.triggered[i] {
+				t.Errorf("%s: expected build of %s to be triggered, got %s", test.name, test.triggered[i], request.Name)
+			}
+			cause := request.TriggeredBy[0].BuildCompletion
+			if cause == nil || cause.BuildName != test.build.Name {
+				t.Errorf("%s: expected build completion cause for %s, got %#v", test.name, test.build.Name, cause)
+			}
+		}
+	}
+}
+
+func TestBuildCompletionControllerInstantiateError(t *testing.T) {
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	store.Add(mockDownstreamBuildConfig("test", "app", kapi.ObjectReference{Kind: "BuildConfig", Name: "base"}, ""))
+	controller := &BuildCompletionController{
+		BuildConfigStore:        store,
+		BuildStore:              cache.NewStore(cache.MetaNamespaceKeyFunc),
+		BuildConfigInstantiator: &recordingInstantiator{err: fmt.Errorf("instantiating error")},
+	}
+	if err := controller.HandleBuild(mockCompletedBuild("test", "base", 1, buildapi.BuildPhaseComplete)); err == nil {
+		t.Error("expected an error to be returned so the build is retried")
+	}
+}
+
+func TestBuildCompletionControllerCycle(t *testing.T) {
+	ref := func(name string) kapi.ObjectReference {
+		return kapi.ObjectReference{Kind: "BuildConfig", Name: name}
+	}
+	causedBy := func(build *buildapi.Build, from *buildapi.Build) *buildapi.Build {
+		build.Spec.TriggeredBy = []buildapi.BuildTriggerCause{
+			{BuildCompletion: &buildapi.BuildCompletionCause{BuildName: from.Name, FromRef: &kapi.ObjectReference{Kind: "BuildConfig", Name: from.Labels[buildapi.BuildConfigLabel]}}},
+		}
+		return build
+	}
+	// a triggers b, b triggers c and c triggers a
+	configs := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	configs.Add(mockDownstreamBuildConfig("test", "a", ref("c"), ""))
+	configs.Add(mockDownstreamBuildConfig("test", "b", ref("a"), ""))
+	configs.Add(mockDownstreamBuildConfig("test", "c", ref("b"), ""))
+
+	a := mockCompletedBuild("test", "a", 1, buildapi.BuildPhaseComplete)
+	b := causedBy(mockCompletedBuild("test", "b", 1, buildapi.BuildPhaseComplete), a)
+	c := causedBy(mockCompletedBuild("test", "c", 1, buildapi.BuildPhaseComplete), b)
+	builds := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	for _, build := range []*buildapi.Build{a, b, c} {
+		builds.Add(build)
+	}
+
+	for _, test := range []struct {
+		build     *buildapi.Build
+		triggered []string
+	}{
+		{build: a, triggered: []string{"b"}},
+		{build: b, triggered: []string{"c"}},
+		{build: c},
+	} {
+		instantiator := &recordingInstantiator{}
+		controller := &BuildCompletionController{
+			BuildConfigStore:        configs,
+			BuildStore:              builds,
+			BuildConfigInstantiator: instantiator,
+		}
+		if err := controller.HandleBuild(test.build); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.build.Name, err)
+			continue
+		}
+		var triggered []string
+		for _, request := range instantiator.requests {
+			triggered = append(triggered, request.Name)
+		}
+		if !reflect.DeepEqual(triggered, test.triggered) {
+			t.Errorf("%s: expected builds of %v to be triggered, got %v", test.build.Name, test.triggered, triggered)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/golang/glog"
 
@@ -10,11 +11,13 @@ import (
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/client/cache"
 	"k8s.io/kubernetes/pkg/client/record"
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	buildclient "github.com/openshift/origin/pkg/build/client"
 	"github.com/openshift/origin/pkg/build/controller/policy"
 	strategy "github.com/openshift/origin/pkg/build/controller/strategy"
+	"github.com/openshift/origin/pkg/build/logarchive"
 	buildutil "github.com/openshift/origin/pkg/build/util"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 )
@@ -41,6 +44,14 @@ type podManager interface {
 	GetPod(namespace, name string) (*kapi.Pod, error)
 }
 
+type podLogStreamer interface {
+	StreamPodLogs(namespace, name string) (io.ReadCloser, error)
+}
+
+type logArchiver interface {
+	ArchiveLog(namespace, podName, name string)
+}
+
 type imageStreamClient interface {
 	GetImageStream(namespace, name string) (*imageapi.ImageStream, error)
 }
@@ -182,6 +193,20 @@ func (bc *BuildController) nextBuildPhase(build *buildapi.Build) error {
 		}
 	}
 
+	// The S2I builder likewise expects the incremental cache image to be a
+	// resolved reference to a Docker image.
+	if source := buildCopy.Spec.Strategy.SourceStrategy; source != nil && source.IncrementalCache != nil && source.IncrementalCache.Image != nil {
+		cacheRef, err := bc.resolveDockerImageReference(build, source.IncrementalCache.Image, "incremental cache")
+		if err != nil {
+			build.Status.Reason = buildapi.StatusReasonInvalidIncrementalCacheReference
+			return err
+		}
+		source.IncrementalCache.Image = &kapi.ObjectReference{
+			Kind: "DockerImage",
+			Name: cacheRef,
+		}
+	}
+
 	// Invoke the strategy to get a build pod.
 	podSpec, err := bc.BuildStrategy.CreateBuildPod(buildCopy)
 	if err != nil {
@@ -208,6 +233,7 @@ func (bc *BuildController) nextBuildPhase(build *buildapi.Build) error {
 		build.Annotations = make(map[string]string)
 	}
 	build.Annotations[buildapi.BuildPodNameAnnotation] = podSpec.Name
+	recordStageStarted(build, podSpec)
 	glog.V(4).Infof("Created pod for build: %#v", podSpec)
 
 	// Set the build phase, which will be persisted.
@@ -220,7 +246,13 @@ func (bc *BuildController) nextBuildPhase(build *buildapi.Build) error {
 // resolveOutputDockerImageReference returns a reference to a Docker image
 // computed from the buid.Spec.Output.To reference.
 func (bc *BuildController) resolveOutputDockerImageReference(build *buildapi.Build) (string, error) {
-	outputTo := build.Spec.Output.To
+	return bc.resolveDockerImageReference(build, build.Spec.Output.To, "output")
+}
+
+// resolveDockerImageReference returns a reference to a Docker image computed
+// from an ImageStream, ImageStreamTag or DockerImage reference that the build
+// pushes to. use describes what the image is used for in error messages.
+func (bc *BuildController) resolveDockerImageReference(build *buildapi.Build, outputTo *kapi.ObjectReference, use string) (string, error) {
 	if outputTo == nil || outputTo.Name == "" {
 		return "", nil
 	}
@@ -248,12 +280,12 @@ func (bc *BuildController) resolveOutputDockerImageReference(build *buildapi.Bui
 		stream, err := bc.ImageStreamClient.GetImageStream(namespace, streamName)
 		if err != nil {
 			if errors.IsNotFound(err) {
-				return "", fmt.Errorf("the referenced output image stream %s/%s does not exist", namespace, streamName)
+				return "", fmt.Errorf("the referenced %s image stream %s/%s does not exist", use, namespace, streamName)
 			}
-			return "", fmt.Errorf("the referenced output image stream %s/%s could not be found by build %s/%s: %v", namespace, streamName, build.Namespace, build.Name, err)
+			return "", fmt.Errorf("the referenced %s image stream %s/%s could not be found by build %s/%s: %v", use, namespace, streamName, build.Namespace, build.Name, err)
 		}
 		if len(stream.Status.DockerImageRepository) == 0 {
-			e := fmt.Errorf("the image stream %s/%s cannot be used as the output for build %s/%s because the integrated Docker registry is not configured and no external registry was defined", namespace, outputTo.Name, build.Namespace, build.Name)
+			e := fmt.Errorf("the image stream %s/%s cannot be used as the %s for build %s/%s because the integrated Docker registry is not configured and no external registry was defined", namespace, outputTo.Name, use, build.Namespace, build.Name)
 			bc.Recorder.Eventf(build, kapi.EventTypeWarning, "invalidOutput", "Error starting build: %v", e)
 			return "", e
 		}
@@ -267,6 +299,11 @@ type BuildPodController struct {
 	BuildStore   cache.Store
 	BuildUpdater buildclient.BuildUpdater
 	PodManager   podManager
+	// PipelineStrategy creates the pods running the stages of Pipeline builds after
+	// the first one.
+	PipelineStrategy BuildStrategy
+	// LogArchiver, if set, archives the log of each build whose pod completes.
+	LogArchiver logArchiver
 }
 
 // HandlePod updates the state of the build based on the pod state
@@ -283,31 +320,11 @@ func (bc *BuildPodController) HandlePod(pod *kapi.Pod) error {
 
 	build := obj.(*buildapi.Build)
 
-	nextStatus := build.Status.Phase
-	switch pod.Status.Phase {
-	case kapi.PodRunning:
-		// The pod's still running
-		nextStatus = buildapi.BuildPhaseRunning
-	case kapi.PodSucceeded:
-		// Check the exit codes of all the containers in the pod
-		nextStatus = buildapi.BuildPhaseComplete
-		if len(pod.Status.ContainerStatuses) == 0 {
-			// no containers in the pod means something went badly wrong, so the build
-			// should be failed.
-			glog.V(2).Infof("Failing build %s/%s because the pod has no containers", build.Namespace, build.Name)
-			nextStatus = buildapi.BuildPhaseFailed
-		} else {
-			for _, info := range pod.Status.ContainerStatuses {
-				if info.State.Terminated != nil && info.State.Terminated.ExitCode != 0 {
-					nextStatus = buildapi.BuildPhaseFailed
-					break
-				}
-			}
-		}
-	case kapi.PodFailed:
-		nextStatus = buildapi.BuildPhaseFailed
+	if stage, ok := pod.Annotations[buildapi.BuildStageAnnotation]; ok {
+		return bc.handleStagePod(build, pod, stage)
 	}
 
+	nextStatus := podBuildPhase(build, pod, build.Status.Phase)
 	if build.Status.Phase != nextStatus && !buildutil.IsBuildComplete(build) {
 		glog.V(4).Infof("Updating build %s/%s status %s -> %s", build.Namespace, build.Name, build.Status.Phase, nextStatus)
 		build.Status.Phase = nextStatus
@@ -316,6 +333,8 @@ func (bc *BuildPodController) HandlePod(pod *kapi.Pod) error {
 		if buildutil.IsBuildComplete(build) {
 			now := unversioned.Now()
 			build.Status.CompletionTimestamp = &now
+			build.Status.ResourceUsage = buildutil.ResourceUsageForPod(pod)
+			bc.archiveLog(build, pod, build.Name)
 		}
 		if build.Status.Phase == buildapi.BuildPhaseRunning {
 			now := unversioned.Now()
@@ -329,6 +348,128 @@ func (bc *BuildPodController) HandlePod(pod *kapi.Pod) error {
 	return nil
 }
 
+// podBuildPhase returns the phase of a build, or of a stage of a Pipeline build,
+// run by pod, given its current phase.
+func podBuildPhase(build *buildapi.Build, pod *kapi.Pod, current buildapi.BuildPhase) buildapi.BuildPhase {
+	switch pod.Status.Phase {
+	case kapi.PodRunning:
+		// The pod's still running
+		return buildapi.BuildPhaseRunning
+	case kapi.PodSucceeded:
+		// Check the exit codes of all the containers in the pod
+		if len(pod.Status.ContainerStatuses) == 0 {
+			// no containers in the pod means something went badly wrong, so the build
+			// should be failed.
+			glog.V(2).Infof("Failing build %s/%s because the pod has no containers", build.Namespace, build.Name)
+			return buildapi.BuildPhaseFailed
+		}
+		for _, info := range pod.Status.ContainerStatuses {
+			if info.State.Terminated != nil && info.State.Terminated.ExitCode != 0 {
+				return buildapi.BuildPhaseFailed
+			}
+		}
+		return buildapi.BuildPhaseComplete
+	case kapi.PodFailed:
+		return buildapi.BuildPhaseFailed
+	}
+	return current
+}
+
+// handleStagePod updates a Pipeline build from the state of the pod running one
+// of its stages, and starts the next stage once the stage completes.
+func (bc *BuildPodController) handleStagePod(build *buildapi.Build, pod *kapi.Pod, stageName string) error {
+	stage := buildapi.GetBuildStageStatus(build, stageName)
+	if stage == nil || build.Spec.Strategy.PipelineStrategy == nil || buildutil.IsBuildComplete(build) {
+		return nil
+	}
+	nextStatus := podBuildPhase(build, pod, stage.Phase)
+	if stage.Phase == nextStatus {
+		return nil
+	}
+	glog.V(4).Infof("Updating stage %s of build %s/%s status %s -> %s", stageName, build.Namespace, build.Name, stage.Phase, nextStatus)
+	now := unversioned.Now()
+	stage.Phase = nextStatus
+	if stage.StartTimestamp == nil {
+		stage.StartTimestamp = &now
+	}
+	if build.Status.Phase != buildapi.BuildPhaseRunning {
+		build.Status.Phase = buildapi.BuildPhaseRunning
+		build.Status.Reason = ""
+		build.Status.Message = ""
+		if build.Status.StartTimestamp == nil {
+			build.Status.StartTimestamp = &now
+		}
+	}
+
+	switch nextStatus {
+	case buildapi.BuildPhaseComplete:
+		stage.CompletionTimestamp = &now
+		bc.archiveLog(build, pod, logarchive.StageLogName(build.Name, stageName))
+		if len(build.Status.Stages) == len(build.Spec.Strategy.PipelineStrategy.Stages) {
+			build.Status.Phase = buildapi.BuildPhaseComplete
+			build.Status.CompletionTimestamp = &now
+		} else if err := bc.startNextStage(build); err != nil {
+			glog.V(2).Infof("Failing build %s/%s: %v", build.Namespace, build.Name, err)
+			build.Status.Phase = buildapi.BuildPhaseFailed
+			build.Status.Reason = buildapi.StatusReasonCannotCreateBuildPod
+			build.Status.Message = err.Error()
+			build.Status.CompletionTimestamp = &now
+		}
+	case buildapi.BuildPhaseFailed:
+		stage.CompletionTimestamp = &now
+		bc.archiveLog(build, pod, logarchive.StageLogName(build.Name, stageName))
+		build.Status.Phase = buildapi.BuildPhaseFailed
+		build.Status.CompletionTimestamp = &now
+	}
+
+	if err := bc.BuildUpdater.Update(build.Namespace, build); err != nil {
+		return fmt.Errorf("failed to update build %s/%s: %v", build.Namespace, build.Name, err)
+	}
+	return nil
+}
+
+// startNextStage creates the pod running the next stage of a Pipeline build.
+func (bc *BuildPodController) startNextStage(build *buildapi.Build) error {
+	if bc.PipelineStrategy == nil {
+		return fmt.Errorf("unable to start the next stage of the pipeline")
+	}
+	pod, err := bc.PipelineStrategy.CreateBuildPod(build)
+	if err != nil {
+		return fmt.Errorf("failed to create the pod spec for the next stage of the pipeline: %v", err)
+	}
+	if _, err := bc.PodManager.CreatePod(build.Namespace, pod); err != nil && !errors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create the pod for the next stage of the pipeline: %v", err)
+	}
+	recordStageStarted(build, pod)
+	glog.V(4).Infof("Created pod %s for stage %s of build %s/%s", pod.Name, pod.Annotations[buildapi.BuildStageAnnotation], build.Namespace, build.Name)
+	return nil
+}
+
+// recordStageStarted adds the stage run by pod, if any, to the status of the build.
+func recordStageStarted(build *buildapi.Build, pod *kapi.Pod) {
+	stage, ok := pod.Annotations[buildapi.BuildStageAnnotation]
+	if !ok {
+		return
+	}
+	build.Status.Stages = append(build.Status.Stages, buildapi.BuildStageStatus{
+		Name:    stage,
+		Phase:   buildapi.BuildPhasePending,
+		PodName: pod.Name,
+	})
+}
+
+// archiveLog queues the log of the completed build pod to be stored in the log
+// archive under name so it can still be retrieved after the pod is deleted. The
+// log is archived asynchronously, failing to archive it does not prevent the
+// build from being updated.
+func (bc *BuildPodController) archiveLog(build *buildapi.Build, pod *kapi.Pod, name string) {
+	if bc.LogArchiver == nil {
+		return
+	}
+	glog.V(4).Infof("Queueing the log of build %s/%s from pod %s for archival", build.Namespace, build.Name, pod.Name)
+	bc.LogArchiver.ArchiveLog(build.Namespace, pod.Name, name)
+}
+
 // isBuildCancellable checks for build status and returns true if the condition is checked.
 func isBuildCancellable(build *buildapi.Build) bool {
 	return build.Status.Phase == buildapi.BuildPhaseNew || build.Status.Phase == buildapi.BuildPhasePending || build.Status.Phase == buildapi.BuildPhaseRunning
@@ -365,6 +506,13 @@ func (bc *BuildPodDeleteController) HandleBuildPodDeletion(pod *kapi.Pod) error
 		return nil
 	}
 
+	// The pods of the completed stages of a Pipeline build may be deleted while
+	// later stages run.
+	if podName := buildapi.GetBuildPodName(build); pod.Name != podName {
+		glog.V(4).Infof("Pod %s/%s does not run the current stage of build %s, ignoring", pod.Namespace, pod.Name, build.Name)
+		return nil
+	}
+
 	nextStatus := buildapi.BuildPhaseError
 	if build.Status.Phase != nextStatus {
 		glog.V(4).Infof("Updating build %s/%s status %s -> %s", build.Namespace, build.Name, build.Status.Phase, nextStatus)
@@ -383,12 +531,37 @@ func (bc *BuildPodDeleteController) HandleBuildPodDeletion(pod *kapi.Pod) error
 // BuildDeleteController watches for builds being deleted and cleans up associated pods
 type BuildDeleteController struct {
 	PodManager podManager
+	// LogArchive, if set, has the archived log of deleted builds removed.
+	LogArchive logarchive.Archive
 }
 
 // HandleBuildDeletion deletes a build pod if the corresponding build has been deleted
 func (bc *BuildDeleteController) HandleBuildDeletion(build *buildapi.Build) error {
 	glog.V(4).Infof("Handling deletion of build %s", build.Name)
-	podName := buildapi.GetBuildPodName(build)
+	if bc.LogArchive != nil {
+		names := []string{build.Name}
+		for _, stage := range build.Status.Stages {
+			names = append(names, logarchive.StageLogName(build.Name, stage.Name))
+		}
+		for _, name := range names {
+			if err := bc.LogArchive.Delete(build.Namespace, name); err != nil {
+				utilruntime.HandleError(fmt.Errorf("unable to delete the archived log %s of build %s/%s: %v", name, build.Namespace, build.Name, err))
+			}
+		}
+	}
+	if len(build.Status.Stages) == 0 {
+		return bc.deletePod(build, buildapi.GetBuildPodName(build))
+	}
+	for _, stage := range build.Status.Stages {
+		if err := bc.deletePod(build, stage.PodName); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// deletePod deletes the named pod of build, if it still exists.
+func (bc *BuildDeleteController) deletePod(build *buildapi.Build, podName string) error {
 	pod, err := bc.PodManager.GetPod(build.Namespace, podName)
 	if err != nil && !errors.IsNotFound(err) {
 		glog.V(2).Infof("Failed to find pod with name %s for build %s in namespace %s due to error: %v", podName, build.Name, build.Namespace, err)
@@ -2,7 +2,10 @@ package controller
 
 import (
 	"errors"
+	"io"
+	"io/ioutil"
 	"reflect"
+	"strings"
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
@@ -14,6 +17,7 @@ import (
 	buildclient "github.com/openshift/origin/pkg/build/client"
 	"github.com/openshift/origin/pkg/build/controller/policy"
 	buildtest "github.com/openshift/origin/pkg/build/controller/test"
+	buildutil "github.com/openshift/origin/pkg/build/util"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 )
 
@@ -437,6 +441,47 @@ func TestHandleBuild(t *testing.T) {
 	}
 }
 
+func TestHandleBuildIncrementalCacheImage(t *testing.T) {
+	build := mockBuild(buildapi.BuildPhaseNew, buildapi.BuildOutput{})
+	build.Spec.Strategy = buildapi.BuildStrategy{
+		SourceStrategy: &buildapi.SourceBuildStrategy{
+			Incremental: true,
+			IncrementalCache: &buildapi.IncrementalCacheSource{
+				Image: &kapi.ObjectReference{Kind: "ImageStreamTag", Name: "app:cache"},
+			},
+		},
+	}
+	ctrl := mockBuildController()
+	if err := ctrl.HandleBuild(build); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if build.Spec.Strategy.SourceStrategy.IncrementalCache.Image.Kind != "ImageStreamTag" {
+		t.Errorf("build.Spec mutated: %#v", build.Spec.Strategy.SourceStrategy.IncrementalCache.Image)
+	}
+	cache := ctrl.BuildStrategy.(*okStrategy).build.Spec.Strategy.SourceStrategy.IncrementalCache.Image
+	if cache.Kind != "DockerImage" || cache.Name != "image/repo:cache" {
+		t.Errorf("expected the cache image to be resolved to image/repo:cache, got %#v", cache)
+	}
+
+	build = mockBuild(buildapi.BuildPhaseNew, buildapi.BuildOutput{})
+	build.Spec.Strategy = buildapi.BuildStrategy{
+		SourceStrategy: &buildapi.SourceBuildStrategy{
+			Incremental: true,
+			IncrementalCache: &buildapi.IncrementalCacheSource{
+				Image: &kapi.ObjectReference{Kind: "ImageStreamTag", Name: "app:cache"},
+			},
+		},
+	}
+	ctrl = mockBuildController()
+	ctrl.ImageStreamClient = &errNotFoundImageStreamClient{}
+	if err := ctrl.HandleBuild(build); err == nil {
+		t.Errorf("expected an error for a missing cache image stream")
+	}
+	if build.Status.Reason != buildapi.StatusReasonInvalidIncrementalCacheReference {
+		t.Errorf("expected reason %s, got %s", buildapi.StatusReasonInvalidIncrementalCacheReference, build.Status.Reason)
+	}
+}
+
 func TestHandlePod(t *testing.T) {
 	type handlePodTest struct {
 		matchID             bool
@@ -565,6 +610,218 @@ func TestHandlePod(t *testing.T) {
 	}
 }
 
+type fakeLogStreamer struct {
+	log string
+	err error
+}
+
+func (s *fakeLogStreamer) StreamPodLogs(namespace, name string) (io.ReadCloser, error) {
+	if s.err != nil {
+		return nil, s.err
+	}
+	return ioutil.NopCloser(strings.NewReader(s.log)), nil
+}
+
+type fakeLogArchive struct {
+	logs    map[string]string
+	deleted []string
+}
+
+func (a *fakeLogArchive) Put(namespace, name string, r io.Reader) error {
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return err
+	}
+	a.logs[namespace+"/"+name] = string(data)
+	return nil
+}
+
+func (a *fakeLogArchive) Get(namespace, name string) ([]byte, error) {
+	return []byte(a.logs[namespace+"/"+name]), nil
+}
+
+func (a *fakeLogArchive) Delete(namespace, name string) error {
+	a.deleted = append(a.deleted, namespace+"/"+name)
+	return nil
+}
+
+type fakeLogArchiver struct {
+	logs map[string]string
+}
+
+func (a *fakeLogArchiver) ArchiveLog(namespace, podName, name string) {
+	a.logs[namespace+"/"+name] = podName
+}
+
+func TestHandlePodArchivesLog(t *testing.T) {
+	tests := []struct {
+		name      string
+		inStatus  buildapi.BuildPhase
+		podStatus kapi.PodPhase
+		exitCode  int
+		archived  bool
+	}{
+		{
+			name:      "completed build",
+			inStatus:  buildapi.BuildPhaseRunning,
+			podStatus: kapi.PodSucceeded,
+			archived:  true,
+		},
+		{
+			name:      "failed build",
+			inStatus:  buildapi.BuildPhaseRunning,
+			podStatus: kapi.PodFailed,
+			exitCode:  1,
+			archived:  true,
+		},
+		{
+			name:      "running build",
+			inStatus:  buildapi.BuildPhasePending,
+			podStatus: kapi.PodRunning,
+		},
+		{
+			name:      "build already complete",
+			inStatus:  buildapi.BuildPhaseComplete,
+			podStatus: kapi.PodSucceeded,
+		},
+	}
+
+	for _, tc := range tests {
+		build := mockBuild(tc.inStatus, buildapi.BuildOutput{})
+		archiver := &fakeLogArchiver{logs: map[string]string{}}
+		ctrl := mockBuildPodController(build)
+		ctrl.LogArchiver = archiver
+
+		pod := mockPod(tc.podStatus, tc.exitCode)
+		if err := ctrl.HandlePod(pod); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		podName, archived := archiver.logs["namespace/data-build"]
+		if archived != tc.archived {
+			t.Errorf("%s: expected archived to be %t, got %t", tc.name, tc.archived, archived)
+		}
+		if archived && podName != pod.Name {
+			t.Errorf("%s: expected the log of pod %s to be archived, got %s", tc.name, pod.Name, podName)
+		}
+	}
+}
+
+type stageStrategy struct{}
+
+func (*stageStrategy) CreateBuildPod(build *buildapi.Build) (*kapi.Pod, error) {
+	stage := build.Spec.Strategy.PipelineStrategy.Stages[len(build.Status.Stages)].Name
+	return mockStagePod(stage, kapi.PodPending, 0), nil
+}
+
+func mockPipelineBuild(stages ...buildapi.BuildStageStatus) *buildapi.Build {
+	build := mockBuild(buildapi.BuildPhaseRunning, buildapi.BuildOutput{})
+	build.Spec.Strategy = buildapi.BuildStrategy{
+		PipelineStrategy: &buildapi.PipelineBuildStrategy{
+			Stages: []buildapi.PipelineStage{{Name: "test"}, {Name: "publish"}},
+		},
+	}
+	build.Status.Stages = stages
+	return build
+}
+
+func mockStagePod(stage string, status kapi.PodPhase, exitCode int) *kapi.Pod {
+	pod := mockPod(status, exitCode)
+	pod.Name = "data-build-" + stage + "-stage"
+	pod.Annotations[buildapi.BuildStageAnnotation] = stage
+	return pod
+}
+
+func TestHandleStagePod(t *testing.T) {
+	tests := []struct {
+		name        string
+		stages      []buildapi.BuildStageStatus
+		pod         *kapi.Pod
+		outStatus   buildapi.BuildPhase
+		outStages   []buildapi.BuildPhase
+		archivedLog string
+	}{
+		{
+			name:      "stage running",
+			stages:    []buildapi.BuildStageStatus{{Name: "test", Phase: buildapi.BuildPhasePending}},
+			pod:       mockStagePod("test", kapi.PodRunning, 0),
+			outStatus: buildapi.BuildPhaseRunning,
+			outStages: []buildapi.BuildPhase{buildapi.BuildPhaseRunning},
+		},
+		{
+			name:        "stage complete starts the next stage",
+			stages:      []buildapi.BuildStageStatus{{Name: "test", Phase: buildapi.BuildPhaseRunning}},
+			pod:         mockStagePod("test", kapi.PodSucceeded, 0),
+			outStatus:   buildapi.BuildPhaseRunning,
+			outStages:   []buildapi.BuildPhase{buildapi.BuildPhaseComplete, buildapi.BuildPhasePending},
+			archivedLog: "namespace/data-build@test",
+		},
+		{
+			name:        "stage failed fails the build",
+			stages:      []buildapi.BuildStageStatus{{Name: "test", Phase: buildapi.BuildPhaseRunning}},
+			pod:         mockStagePod("test", kapi.PodFailed, 1),
+			outStatus:   buildapi.BuildPhaseFailed,
+			outStages:   []buildapi.BuildPhase{buildapi.BuildPhaseFailed},
+			archivedLog: "namespace/data-build@test",
+		},
+		{
+			name: "last stage complete completes the build",
+			stages: []buildapi.BuildStageStatus{
+				{Name: "test", Phase: buildapi.BuildPhaseComplete},
+				{Name: "publish", Phase: buildapi.BuildPhaseRunning},
+			},
+			pod:         mockStagePod("publish", kapi.PodSucceeded, 0),
+			outStatus:   buildapi.BuildPhaseComplete,
+			outStages:   []buildapi.BuildPhase{buildapi.BuildPhaseComplete, buildapi.BuildPhaseComplete},
+			archivedLog: "namespace/data-build@publish",
+		},
+	}
+
+	for _, tc := range tests {
+		build := mockPipelineBuild(tc.stages...)
+		archiver := &fakeLogArchiver{logs: map[string]string{}}
+		ctrl := mockBuildPodController(build)
+		ctrl.PipelineStrategy = &stageStrategy{}
+		ctrl.LogArchiver = archiver
+
+		if err := ctrl.HandlePod(tc.pod); err != nil {
+			t.Errorf("%s: unexpected error: %v", tc.name, err)
+			continue
+		}
+		if build.Status.Phase != tc.outStatus {
+			t.Errorf("%s: expected build phase %s, got %s", tc.name, tc.outStatus, build.Status.Phase)
+		}
+		var phases []buildapi.BuildPhase
+		for _, stage := range build.Status.Stages {
+			phases = append(phases, stage.Phase)
+		}
+		if !reflect.DeepEqual(phases, tc.outStages) {
+			t.Errorf("%s: expected stage phases %v, got %v", tc.name, tc.outStages, phases)
+		}
+		if len(tc.archivedLog) > 0 && archiver.logs[tc.archivedLog] != tc.pod.Name {
+			t.Errorf("%s: expected the stage log to be archived as %s, got %v", tc.name, tc.archivedLog, archiver.logs)
+		}
+		if buildutil.IsBuildComplete(build) && build.Status.CompletionTimestamp == nil {
+			t.Errorf("%s: expected the completion timestamp to be set", tc.name)
+		}
+	}
+}
+
+func TestHandlePodRecordsResourceUsage(t *testing.T) {
+	build := mockBuild(buildapi.BuildPhaseRunning, buildapi.BuildOutput{})
+	ctrl := mockBuildPodController(build)
+	pod := mockPod(kapi.PodSucceeded, 0)
+	pod.Status.ContainerStatuses[0].State.Terminated.Message = `{"cpu":"750m","memory":"512Mi"}`
+
+	if err := ctrl.HandlePod(pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	usage := build.Status.ResourceUsage
+	if usage == nil || usage.CPU.String() != "750m" || usage.Memory.String() != "512Mi" {
+		t.Errorf("expected the resource usage reported by the build container to be recorded, got %#v", usage)
+	}
+}
+
 func TestCancelBuild(t *testing.T) {
 	type handleCancelBuildTest struct {
 		inStatus            buildapi.BuildPhase
@@ -719,7 +976,7 @@ func (c *customPodManager) GetPod(namespace, name string) (*kapi.Pod, error) {
 func TestHandleHandleBuildDeletionOK(t *testing.T) {
 	deleteWasCalled := false
 	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	ctrl := BuildDeleteController{PodManager: &customPodManager{
 		GetPodFunc: func(namespace, names string) (*kapi.Pod, error) {
 			return &kapi.Pod{ObjectMeta: kapi.ObjectMeta{
 				Labels:      map[string]string{buildapi.BuildLabel: buildapi.LabelValue(build.Name)},
@@ -741,10 +998,30 @@ func TestHandleHandleBuildDeletionOK(t *testing.T) {
 	}
 }
 
+func TestHandleBuildDeletionDeletesArchivedLog(t *testing.T) {
+	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
+	archive := &fakeLogArchive{logs: map[string]string{}}
+	ctrl := BuildDeleteController{
+		PodManager: &customPodManager{
+			GetPodFunc: func(namespace, names string) (*kapi.Pod, error) {
+				return nil, kerrors.NewNotFound(kapi.Resource("Pod"), "name")
+			},
+		},
+		LogArchive: archive,
+	}
+
+	if err := ctrl.HandleBuildDeletion(build); err != nil {
+		t.Errorf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(archive.deleted, []string{"namespace/data-build"}) {
+		t.Errorf("Expected the archived log to be deleted, got %v", archive.deleted)
+	}
+}
+
 func TestHandleHandleBuildDeletionOKDeprecatedLabel(t *testing.T) {
 	deleteWasCalled := false
 	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	ctrl := BuildDeleteController{PodManager: &customPodManager{
 		GetPodFunc: func(namespace, names string) (*kapi.Pod, error) {
 			return &kapi.Pod{ObjectMeta: kapi.ObjectMeta{
 				Labels:      map[string]string{buildapi.BuildLabel: buildapi.LabelValue(build.Name)},
@@ -768,7 +1045,7 @@ func TestHandleHandleBuildDeletionOKDeprecatedLabel(t *testing.T) {
 
 func TestHandleHandleBuildDeletionFailGetPod(t *testing.T) {
 	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	ctrl := BuildDeleteController{PodManager: &customPodManager{
 		GetPodFunc: func(namespace, name string) (*kapi.Pod, error) {
 			return nil, errors.New("random")
 		},
@@ -783,7 +1060,7 @@ func TestHandleHandleBuildDeletionFailGetPod(t *testing.T) {
 func TestHandleHandleBuildDeletionGetPodNotFound(t *testing.T) {
 	deleteWasCalled := false
 	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	ctrl := BuildDeleteController{PodManager: &customPodManager{
 		GetPodFunc: func(namespace, name string) (*kapi.Pod, error) {
 			return nil, kerrors.NewNotFound(kapi.Resource("Pod"), name)
 		},
@@ -805,7 +1082,7 @@ func TestHandleHandleBuildDeletionGetPodNotFound(t *testing.T) {
 func TestHandleHandleBuildDeletionMismatchedLabels(t *testing.T) {
 	deleteWasCalled := false
 	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	ctrl := BuildDeleteController{PodManager: &customPodManager{
 		GetPodFunc: func(namespace, names string) (*kapi.Pod, error) {
 			return &kapi.Pod{}, nil
 		},
@@ -826,7 +1103,7 @@ func TestHandleHandleBuildDeletionMismatchedLabels(t *testing.T) {
 
 func TestHandleHandleBuildDeletionDeletePodError(t *testing.T) {
 	build := mockBuild(buildapi.BuildPhaseComplete, buildapi.BuildOutput{})
-	ctrl := BuildDeleteController{&customPodManager{
+	ctrl := BuildDeleteController{PodManager: &customPodManager{
 		GetPodFunc: func(namespace, names string) (*kapi.Pod, error) {
 			return &kapi.Pod{ObjectMeta: kapi.ObjectMeta{
 				Labels:      map[string]string{buildapi.BuildLabel: buildapi.LabelValue(build.Name)},
@@ -2,6 +2,7 @@ package factory
 
 import (
 	"fmt"
+	"io"
 	"time"
 
 	"github.com/golang/glog"
@@ -23,6 +24,7 @@ import (
 	buildcontroller "github.com/openshift/origin/pkg/build/controller"
 	"github.com/openshift/origin/pkg/build/controller/policy"
 	strategy "github.com/openshift/origin/pkg/build/controller/strategy"
+	"github.com/openshift/origin/pkg/build/logarchive"
 	buildutil "github.com/openshift/origin/pkg/build/util"
 	osclient "github.com/openshift/origin/pkg/client"
 	controller "github.com/openshift/origin/pkg/controller"
@@ -30,7 +32,12 @@ import (
 	errors "github.com/openshift/origin/pkg/util/errors"
 )
 
-const maxRetries = 60
+const (
+	maxRetries = 60
+
+	// logArchiveWorkers is the number of build logs archived concurrently.
+	logArchiveWorkers = 5
+)
 
 // limitedLogAndRetry stops retrying after maxTimeout, failing the build.
 func limitedLogAndRetry(buildupdater buildclient.BuildUpdater, maxTimeout time.Duration) controller.RetryFunc {
@@ -67,6 +74,10 @@ type BuildControllerFactory struct {
 	DockerBuildStrategy *strategy.DockerBuildStrategy
 	SourceBuildStrategy *strategy.SourceBuildStrategy
 	CustomBuildStrategy *strategy.CustomBuildStrategy
+	// PipelineBuildStrategy creates the pod running the first stage of Pipeline builds.
+	PipelineBuildStrategy *strategy.PipelineBuildStrategy
+	// LogArchive, if set, stores the logs of completed builds.
+	LogArchive logarchive.Archive
 	// Stop may be set to allow controllers created by this factory to be terminated.
 	Stop <-chan struct{}
 }
@@ -87,9 +98,10 @@ func (factory *BuildControllerFactory) Create() controller.RunnableController {
 		PodManager:        client,
 		RunPolicies:       policy.GetAllRunPolicies(factory.BuildLister, factory.BuildUpdater),
 		BuildStrategy: &typeBasedFactoryStrategy{
-			DockerBuildStrategy: factory.DockerBuildStrategy,
-			SourceBuildStrategy: factory.SourceBuildStrategy,
-			CustomBuildStrategy: factory.CustomBuildStrategy,
+			DockerBuildStrategy:   factory.DockerBuildStrategy,
+			SourceBuildStrategy:   factory.SourceBuildStrategy,
+			CustomBuildStrategy:   factory.CustomBuildStrategy,
+			PipelineBuildStrategy: factory.PipelineBuildStrategy,
 		},
 		Recorder: eventBroadcaster.NewRecorder(kapi.EventSource{Component: "build-controller"}),
 	}
@@ -131,6 +143,7 @@ func (factory *BuildControllerFactory) CreateDeleteController() controller.Runna
 
 	buildDeleteController := &buildcontroller.BuildDeleteController{
 		PodManager: client,
+		LogArchive: factory.LogArchive,
 	}
 
 	return &controller.RetryController{
@@ -157,6 +170,11 @@ type BuildPodControllerFactory struct {
 	OSClient     osclient.Interface
 	KubeClient   kclient.Interface
 	BuildUpdater buildclient.BuildUpdater
+	// PipelineBuildStrategy, if set, creates the pods running the stages of Pipeline
+	// builds after the first one.
+	PipelineBuildStrategy *strategy.PipelineBuildStrategy
+	// LogArchive, if set, stores the logs of completed builds.
+	LogArchive logarchive.Archive
 	// Stop may be set to allow controllers created by this factory to be terminated.
 	Stop <-chan struct{}
 
@@ -199,6 +217,14 @@ func (factory *BuildPodControllerFactory) Create() controller.RunnableController
 		BuildUpdater: factory.BuildUpdater,
 		PodManager:   client,
 	}
+	if factory.PipelineBuildStrategy != nil {
+		buildPodController.PipelineStrategy = factory.PipelineBuildStrategy
+	}
+	if factory.LogArchive != nil {
+		archiver := buildcontroller.NewBuildLogArchiveController(factory.LogArchive, client)
+		go archiver.Run(logArchiveWorkers, factory.Stop)
+		buildPodController.LogArchiver = archiver
+	}
 
 	return &controller.RetryController{
 		Queue: queue,
@@ -309,6 +335,48 @@ func (factory *ImageChangeControllerFactory) Create() controller.RunnableControl
 	}
 }
 
+// BuildCompletionControllerFactory can create a BuildCompletionController which obtains Builds
+// from a queue populated from a watch of all Builds.
+type BuildCompletionControllerFactory struct {
+	Client                  osclient.Interface
+	BuildConfigInstantiator buildclient.BuildConfigInstantiator
+	// Stop may be set to allow controllers created by this factory to be terminated.
+	Stop <-chan struct{}
+}
+
+// Create creates a new BuildCompletionController which is used to trigger builds when a
+// build of another BuildConfig completes
+func (factory *BuildCompletionControllerFactory) Create() controller.RunnableController {
+	queue := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(&buildLW{client: factory.Client}, &buildapi.Build{}, queue, 2*time.Minute).RunUntil(factory.Stop)
+
+	store := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(&buildConfigLW{client: factory.Client}, &buildapi.BuildConfig{}, store, 2*time.Minute).RunUntil(factory.Stop)
+
+	buildStore := cache.NewStore(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(&buildLW{client: factory.Client}, &buildapi.Build{}, buildStore, 2*time.Minute).RunUntil(factory.Stop)
+
+	buildCompletionController := &buildcontroller.BuildCompletionController{
+		BuildConfigStore:        store,
+		BuildStore:              buildStore,
+		BuildConfigInstantiator: factory.BuildConfigInstantiator,
+	}
+
+	return &controller.RetryController{
+		Queue: queue,
+		RetryManager: controller.NewQueueRetryManager(
+			queue,
+			cache.MetaNamespaceKeyFunc,
+			retryFunc("Build completion", nil),
+			flowcontrol.NewTokenBucketRateLimiter(1, 10),
+		),
+		Handle: func(obj interface{}) error {
+			build := obj.(*buildapi.Build)
+			return buildCompletionController.HandleBuild(build)
+		},
+	}
+}
+
 type BuildConfigControllerFactory struct {
 	Client                  osclient.Interface
 	KubeClient              kclient.Interface
@@ -363,9 +431,10 @@ func (pe *podEnumerator) Get(index int) interface{} {
 }
 
 type typeBasedFactoryStrategy struct {
-	DockerBuildStrategy *strategy.DockerBuildStrategy
-	SourceBuildStrategy *strategy.SourceBuildStrategy
-	CustomBuildStrategy *strategy.CustomBuildStrategy
+	DockerBuildStrategy   *strategy.DockerBuildStrategy
+	SourceBuildStrategy   *strategy.SourceBuildStrategy
+	CustomBuildStrategy   *strategy.CustomBuildStrategy
+	PipelineBuildStrategy *strategy.PipelineBuildStrategy
 }
 
 func (f *typeBasedFactoryStrategy) CreateBuildPod(build *buildapi.Build) (*kapi.Pod, error) {
@@ -378,6 +447,8 @@ func (f *typeBasedFactoryStrategy) CreateBuildPod(build *buildapi.Build) (*kapi.
 		pod, err = f.SourceBuildStrategy.CreateBuildPod(build)
 	case build.Spec.Strategy.CustomStrategy != nil:
 		pod, err = f.CustomBuildStrategy.CreateBuildPod(build)
+	case build.Spec.Strategy.PipelineStrategy != nil:
+		pod, err = f.PipelineBuildStrategy.CreateBuildPod(build)
 	case build.Spec.Strategy.JenkinsPipelineStrategy != nil:
 		return nil, nil
 	default:
@@ -640,6 +711,11 @@ func (c ControllerClient) GetPod(namespace, name string) (*kapi.Pod, error) {
 	return c.KubeClient.Pods(namespace).Get(name)
 }
 
+// StreamPodLogs streams the logs of a pod using the Kubernetes client.
+func (c ControllerClient) StreamPodLogs(namespace, name string) (io.ReadCloser, error) {
+	return c.KubeClient.Pods(namespace).GetLogs(name, &kapi.PodLogOptions{}).Stream()
+}
+
 // GetImageStream retrieves an image repository by namespace and name
 func (c ControllerClient) GetImageStream(namespace, name string) (*imageapi.ImageStream, error) {
 	return c.Client.ImageStreams(namespace).Get(name)
@@ -0,0 +1,104 @@
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang/glog"
+
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+	"k8s.io/kubernetes/pkg/util/wait"
+	"k8s.io/kubernetes/pkg/util/workqueue"
+
+	"github.com/openshift/origin/pkg/build/logarchive"
+)
+
+// logArchiveRequest identifies the log of a completed build pod to store in the
+// log archive under name.
+type logArchiveRequest struct {
+	namespace string
+	pod       string
+	name      string
+}
+
+// BuildLogArchiveController stores the logs of completed build pods in a log
+// archive. The logs are streamed by its own workers so the pod controller is not
+// held up by slow or large logs.
+type BuildLogArchiveController struct {
+	archive  logarchive.Archive
+	streamer podLogStreamer
+
+	queue      workqueue.RateLimitingInterface
+	maxRetries int
+}
+
+// NewBuildLogArchiveController creates a BuildLogArchiveController storing the
+// logs read from streamer in archive.
+func NewBuildLogArchiveController(archive logarchive.Archive, streamer podLogStreamer) *BuildLogArchiveController {
+	return &BuildLogArchiveController{
+		archive:    archive,
+		streamer:   streamer,
+		queue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		maxRetries: 5,
+	}
+}
+
+// ArchiveLog queues the log of the pod podName in namespace to be stored under name.
+func (c *BuildLogArchiveController) ArchiveLog(namespace, podName, name string) {
+	c.queue.Add(logArchiveRequest{namespace: namespace, pod: podName, name: name})
+}
+
+// Run starts workers archiving the queued logs until stopCh is closed.
+func (c *BuildLogArchiveController) Run(workers int, stopCh <-chan struct{}) {
+	defer utilruntime.HandleCrash()
+	for i := 0; i < workers; i++ {
+		go wait.Until(c.worker, time.Second, stopCh)
+	}
+
+	<-stopCh
+	glog.Infof("Shutting down build log archive controller")
+	c.queue.ShutDown()
+}
+
+func (c *BuildLogArchiveController) worker() {
+	for c.processNextWorkItem() {
+	}
+}
+
+// processNextWorkItem archives the next queued log and returns false once the
+// queue is shut down. Logs which cannot be archived are retried a limited number
+// of times, since the pod may not be able to serve its log right away.
+func (c *BuildLogArchiveController) processNextWorkItem() bool {
+	key, quit := c.queue.Get()
+	if quit {
+		return false
+	}
+	defer c.queue.Done(key)
+
+	request := key.(logArchiveRequest)
+	err := c.archiveLog(request)
+	switch {
+	case err == nil:
+		c.queue.Forget(key)
+	case c.queue.NumRequeues(key) < c.maxRetries:
+		glog.V(4).Infof("Retrying to archive the log of pod %s/%s: %v", request.namespace, request.pod, err)
+		c.queue.AddRateLimited(key)
+	default:
+		utilruntime.HandleError(err)
+		c.queue.Forget(key)
+	}
+	return true
+}
+
+func (c *BuildLogArchiveController) archiveLog(request logArchiveRequest) error {
+	logs, err := c.streamer.StreamPodLogs(request.namespace, request.pod)
+	if err != nil {
+		return fmt.Errorf("unable to read the log of pod %s/%s: %v", request.namespace, request.pod, err)
+	}
+	defer logs.Close()
+	if err := c.archive.Put(request.namespace, request.name, logs); err != nil {
+		return fmt.Errorf("unable to archive the log of pod %s/%s as %s: %v", request.namespace, request.pod, request.name, err)
+	}
+	glog.V(4).Infof("Archived the log of pod %s/%s as %s", request.namespace, request.pod, request.name)
+	return nil
+}
@@ -0,0 +1,47 @@
+package controller
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBuildLogArchiveController(t *testing.T) {
+	tests := []struct {
+		name      string
+		streamErr error
+		archived  bool
+		requeued  bool
+	}{
+		{
+			name:     "log archived",
+			archived: true,
+		},
+		{
+			name:      "log cannot be read",
+			streamErr: errors.New("pod log unavailable"),
+			requeued:  true,
+		},
+	}
+
+	for _, tc := range tests {
+		archive := &fakeLogArchive{logs: map[string]string{}}
+		c := NewBuildLogArchiveController(archive, &fakeLogStreamer{log: "build output\n", err: tc.streamErr})
+		c.ArchiveLog("namespace", "data-build", "data-build")
+
+		if !c.processNextWorkItem() {
+			t.Fatalf("%s: expected the queue to be running", tc.name)
+		}
+		log, archived := archive.logs["namespace/data-build"]
+		if archived != tc.archived {
+			t.Errorf("%s: expected archived to be %t, got %t", tc.name, tc.archived, archived)
+		}
+		if archived && log != "build output\n" {
+			t.Errorf("%s: unexpected archived log %q", tc.name, log)
+		}
+		request := logArchiveRequest{namespace: "namespace", pod: "data-build", name: "data-build"}
+		if requeued := c.queue.NumRequeues(request) > 0; requeued != tc.requeued {
+			t.Errorf("%s: expected requeued to be %t, got %t", tc.name, tc.requeued, requeued)
+		}
+		c.queue.ShutDown()
+	}
+}
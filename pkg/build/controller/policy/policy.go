@@ -111,10 +111,45 @@ func GetNextConfigBuild(lister buildclient.BuildLister, namespace, buildConfigNa
 	return nextBuild, hasRunningBuilds, nil
 }
 
+// isNextSerialBuild returns true if the given build is the next build of its
+// build configuration to run when builds run serially. The builds instantiated
+// together from a build matrix run in parallel with each other, so they are all
+// next once the first of them is, as long as only builds of the same build
+// matrix group are running.
+func isNextSerialBuild(lister buildclient.BuildLister, build *buildapi.Build) (bool, error) {
+	bcName := buildutil.ConfigNameForBuild(build)
+	if len(bcName) == 0 {
+		return false, NewNoBuildConfigLabelError(build)
+	}
+	nextBuild, runningBuilds, err := GetNextConfigBuild(lister, build.Namespace, bcName)
+	if err != nil || nextBuild == nil {
+		return false, err
+	}
+	group := build.Labels[buildapi.BuildMatrixLabel]
+	if len(group) == 0 || nextBuild.Labels[buildapi.BuildMatrixLabel] != group {
+		return !runningBuilds && nextBuild.Name == build.Name, nil
+	}
+	if !runningBuilds {
+		return true, nil
+	}
+	var hasOtherRunningBuilds bool
+	_, err = buildutil.BuildConfigBuilds(lister, build.Namespace, bcName, func(b buildapi.Build) bool {
+		switch b.Status.Phase {
+		case buildapi.BuildPhasePending, buildapi.BuildPhaseRunning:
+			if b.Labels[buildapi.BuildMatrixLabel] != group {
+				hasOtherRunningBuilds = true
+			}
+		}
+		return false
+	})
+	return !hasOtherRunningBuilds, err
+}
+
 // handleComplete represents the default OnComplete handler. This Handler will
 // check which build should be run next and update the StartTimestamp field for
 // that build. That will trigger HandleBuild() to process that build immediately
-// and as a result the build is immediately executed.
+// and as a result the build is immediately executed. When the next build belongs
+// to a build matrix group, all the queued builds of the group are updated.
 func handleComplete(lister buildclient.BuildLister, updater buildclient.BuildUpdater, build *buildapi.Build) error {
 	bcName := buildutil.ConfigNameForBuild(build)
 	if len(bcName) == 0 {
@@ -127,14 +162,31 @@ func handleComplete(lister buildclient.BuildLister, updater buildclient.BuildUpd
 	if hasRunningBuilds || nextBuild == nil {
 		return nil
 	}
+	nextBuilds := []buildapi.Build{*nextBuild}
+	if group := nextBuild.Labels[buildapi.BuildMatrixLabel]; len(group) > 0 {
+		builds, err := buildutil.BuildConfigBuilds(lister, build.Namespace, bcName, func(b buildapi.Build) bool {
+			return b.Status.Phase == buildapi.BuildPhaseNew && b.Labels[buildapi.BuildMatrixLabel] == group
+		})
+		if err != nil {
+			return fmt.Errorf("unable to get the builds of build matrix %s for %s/%s: %v", group, build.Namespace, build.Name, err)
+		}
+		nextBuilds = builds.Items
+	}
 	now := unversioned.Now()
-	nextBuild.Status.StartTimestamp = &now
-	return wait.Poll(500*time.Millisecond, 5*time.Second, func() (bool, error) {
-		err := updater.Update(nextBuild.Namespace, nextBuild)
-		if err != nil && errors.IsConflict(err) {
-			glog.V(5).Infof("Error updating build %s/%s: %v (will retry)", nextBuild.Namespace, nextBuild.Name, err)
-			return false, nil
+	for i := range nextBuilds {
+		nextBuild := &nextBuilds[i]
+		nextBuild.Status.StartTimestamp = &now
+		err := wait.Poll(500*time.Millisecond, 5*time.Second, func() (bool, error) {
+			err := updater.Update(nextBuild.Namespace, nextBuild)
+			if err != nil && errors.IsConflict(err) {
+				glog.V(5).Infof("Error updating build %s/%s: %v (will retry)", nextBuild.Namespace, nextBuild.Name, err)
+				return false, nil
+			}
+			return true, err
+		})
+		if err != nil {
+			return err
 		}
-		return true, err
-	})
+	}
+	return nil
 }
@@ -120,3 +120,37 @@ func TestHandleComplete(t *testing.T) {
 		t.Errorf("build-3 should not have Status.StartTimestamp set")
 	}
 }
+
+func addMatrixBuild(name, bcName, group string, phase buildapi.BuildPhase, policy buildapi.BuildRunPolicy) buildapi.Build {
+	build := addBuild(name, bcName, phase, policy)
+	build.Labels[buildapi.BuildMatrixLabel] = group
+	return build
+}
+
+func TestHandleCompleteBuildMatrix(t *testing.T) {
+	builds := []buildapi.Build{
+		addBuild("build-1", "sample-bc", buildapi.BuildPhaseComplete, buildapi.BuildRunPolicySerial),
+		addMatrixBuild("build-2", "sample-bc", "matrix-a", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+		addMatrixBuild("build-3", "sample-bc", "matrix-a", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+		addMatrixBuild("build-4", "sample-bc", "matrix-b", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+	}
+
+	client := newTestClient(builds)
+
+	if err := handleComplete(client, client, &builds[0]); err != nil {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	resultBuilds, err := client.List("test", kapi.ListOptions{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	for _, build := range resultBuilds.Items[1:3] {
+		if build.Status.StartTimestamp == nil {
+			t.Errorf("%s should have Status.StartTimestamp set to trigger it", build.Name)
+		}
+	}
+	if resultBuilds.Items[3].Status.StartTimestamp != nil {
+		t.Errorf("build-4 should not have Status.StartTimestamp set")
+	}
+}
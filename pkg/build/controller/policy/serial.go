@@ -3,14 +3,14 @@ package policy
 import (
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	buildclient "github.com/openshift/origin/pkg/build/client"
-	buildutil "github.com/openshift/origin/pkg/build/util"
 )
 
 // SerialPolicy implements the RunPolicy interface. Using this run policy, every
 // created build is put into a queue. The serial run policy guarantees that
 // all builds are executed synchroniously in the same order as they were
 // created. This will produce consistent results, but block the build execution until the
-// previous builds are complete.
+// previous builds are complete. The builds instantiated together from a build
+// matrix are queued as a single unit and run in parallel with each other.
 type SerialPolicy struct {
 	BuildLister  buildclient.BuildLister
 	BuildUpdater buildclient.BuildUpdater
@@ -18,12 +18,7 @@ type SerialPolicy struct {
 
 // IsRunnable implements the RunPolicy interface.
 func (s *SerialPolicy) IsRunnable(build *buildapi.Build) (bool, error) {
-	bcName := buildutil.ConfigNameForBuild(build)
-	if len(bcName) == 0 {
-		return false, NewNoBuildConfigLabelError(build)
-	}
-	nextBuild, runningBuilds, err := GetNextConfigBuild(s.BuildLister, build.Namespace, bcName)
-	return !runningBuilds && (nextBuild != nil && nextBuild.Name == build.Name), err
+	return isNextSerialBuild(s.BuildLister, build)
 }
 
 // OnComplete implements the RunPolicy interface.
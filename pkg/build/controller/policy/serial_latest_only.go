@@ -19,7 +19,8 @@ import (
 // they were created, but when a new build is created, the previous, queued
 // build is cancelled, always making the latest created build run as next. This
 // will produce consistent results, but might not suit the CI/CD flow where user
-// expect that every commit is built.
+// expect that every commit is built. The builds instantiated together from a
+// build matrix are queued as a single unit, so they never cancel each other.
 type SerialLatestOnlyPolicy struct {
 	BuildUpdater buildclient.BuildUpdater
 	BuildLister  buildclient.BuildLister
@@ -37,11 +38,7 @@ func (s *SerialLatestOnlyPolicy) IsRunnable(build *buildapi.Build) (bool, error)
 	if err := kerrors.NewAggregate(s.cancelPreviousBuilds(build)); err != nil {
 		return false, err
 	}
-	nextBuild, runningBuilds, err := GetNextConfigBuild(s.BuildLister, build.Namespace, bcName)
-	if err != nil || runningBuilds {
-		return false, err
-	}
-	return nextBuild != nil && nextBuild.Name == build.Name, err
+	return isNextSerialBuild(s.BuildLister, build)
 }
 
 // IsRunnable implements the Scheduler interface.
@@ -60,6 +57,7 @@ func (s *SerialLatestOnlyPolicy) cancelPreviousBuilds(build *buildapi.Build) []e
 	if err != nil {
 		return []error{NewNoBuildNumberAnnotationError(build)}
 	}
+	group := build.Labels[buildapi.BuildMatrixLabel]
 	builds, err := buildutil.BuildConfigBuilds(s.BuildLister, build.Namespace, bcName, func(b buildapi.Build) bool {
 		// Do not cancel the complete builds, builds that were already cancelled, or
 		// running builds.
@@ -67,6 +65,11 @@ func (s *SerialLatestOnlyPolicy) cancelPreviousBuilds(build *buildapi.Build) []e
 			return false
 		}
 
+		// Do not cancel the builds of the same build matrix group.
+		if len(group) > 0 && b.Labels[buildapi.BuildMatrixLabel] == group {
+			return false
+		}
+
 		// Prevent race-condition when there is a newer build than this and we don't
 		// want to cancel it. The HandleBuild() function that runs for that build
 		// will cancel this build.
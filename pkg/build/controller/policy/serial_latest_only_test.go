@@ -142,3 +142,34 @@ func TestSerialLatestOnlyIsRunnableBuildsWithErrors(t *testing.T) {
 		t.Errorf("expected error for build-3")
 	}
 }
+
+func TestSerialLatestOnlyIsRunnableBuildMatrix(t *testing.T) {
+	allNewBuilds := []buildapi.Build{
+		addBuild("build-1", "sample-bc", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerialLatestOnly),
+		addMatrixBuild("build-2", "sample-bc", "matrix-a", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerialLatestOnly),
+		addMatrixBuild("build-3", "sample-bc", "matrix-a", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerialLatestOnly),
+	}
+	client := newTestClient(allNewBuilds)
+	policy := SerialLatestOnlyPolicy{BuildLister: client, BuildUpdater: client}
+
+	build := allNewBuilds[2]
+	runnable, err := policy.IsRunnable(&build)
+	if err != nil {
+		t.Errorf("expected no error, got %v", err)
+	}
+	builds, err := client.List("test", kapi.ListOptions{})
+	if err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if !builds.Items[0].Status.Cancelled {
+		t.Errorf("expected build-1 to be cancelled")
+	}
+	if builds.Items[1].Status.Cancelled {
+		t.Errorf("expected build-2 of the same build matrix not to be cancelled")
+	}
+	// build-1 is only marked as cancelled, the build controller still has to
+	// process the cancellation before build-3 can run.
+	if runnable {
+		t.Errorf("expected build-3 not to be runnable before build-1 is cancelled")
+	}
+}
@@ -41,3 +41,61 @@ func TestSerialIsRunnableNewBuilds(t *testing.T) {
 		}
 	}
 }
+
+func TestSerialIsRunnableBuildMatrix(t *testing.T) {
+	tests := []struct {
+		name     string
+		builds   []buildapi.Build
+		runnable []string
+	}{
+		{
+			name: "queued build matrix",
+			builds: []buildapi.Build{
+				addMatrixBuild("build-1", "sample-bc", "matrix-a", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+				addMatrixBuild("build-2", "sample-bc", "matrix-a", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+				addMatrixBuild("build-3", "sample-bc", "matrix-b", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+				addBuild("build-4", "sample-bc", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+			},
+			runnable: []string{"build-1", "build-2"},
+		},
+		{
+			name: "running build matrix",
+			builds: []buildapi.Build{
+				addMatrixBuild("build-1", "sample-bc", "matrix-a", buildapi.BuildPhaseRunning, buildapi.BuildRunPolicySerial),
+				addMatrixBuild("build-2", "sample-bc", "matrix-a", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+				addMatrixBuild("build-3", "sample-bc", "matrix-b", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+			},
+			runnable: []string{"build-2"},
+		},
+		{
+			name: "build matrix queued behind a running build",
+			builds: []buildapi.Build{
+				addBuild("build-1", "sample-bc", buildapi.BuildPhaseRunning, buildapi.BuildRunPolicySerial),
+				addMatrixBuild("build-2", "sample-bc", "matrix-a", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+				addMatrixBuild("build-3", "sample-bc", "matrix-a", buildapi.BuildPhaseNew, buildapi.BuildRunPolicySerial),
+			},
+		},
+	}
+	for _, test := range tests {
+		client := newTestClient(test.builds)
+		policy := SerialPolicy{BuildLister: client, BuildUpdater: client}
+		for _, build := range test.builds {
+			if build.Status.Phase != buildapi.BuildPhaseNew {
+				continue
+			}
+			runnable, err := policy.IsRunnable(&build)
+			if err != nil {
+				t.Errorf("%s: expected no error, got %v", test.name, err)
+			}
+			shouldRun := false
+			for _, name := range test.runnable {
+				if name == build.Name {
+					shouldRun = true
+				}
+			}
+			if runnable != shouldRun {
+				t.Errorf("%s: expected %s runnable to be %t, got %t", test.name, build.Name, shouldRun, runnable)
+			}
+		}
+	}
+}
@@ -0,0 +1,109 @@
+package strategy
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/glog"
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+)
+
+// PipelineBuildStrategy creates the pods running the stages of a Pipeline build.
+type PipelineBuildStrategy struct {
+	// Codec is the codec to use for encoding the build in the stage pods, so
+	// that they are recognized as build pods by the build admission plugins.
+	Codec runtime.Codec
+}
+
+// CreateBuildPod creates the pod running the next stage of the Pipeline build,
+// which is the first stage when none has started yet.
+func (bs *PipelineBuildStrategy) CreateBuildPod(build *buildapi.Build) (*kapi.Pod, error) {
+	strategy := build.Spec.Strategy.PipelineStrategy
+	if strategy == nil || len(strategy.Stages) == 0 {
+		return nil, errors.New("PipelineBuildStrategy cannot be executed without stages")
+	}
+	next := len(build.Status.Stages)
+	if next >= len(strategy.Stages) {
+		return nil, FatalError(fmt.Sprintf("all the stages of build %s/%s have already started", build.Namespace, build.Name))
+	}
+	stage := strategy.Stages[next]
+
+	data, err := runtime.Encode(bs.Codec, build)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode the build: %v", err)
+	}
+
+	containerEnv := []kapi.EnvVar{
+		{Name: "BUILD", Value: string(data)},
+		{Name: "BUILD_STAGE", Value: stage.Name},
+	}
+	if build.Spec.Source.Git != nil {
+		addSourceEnvVars(build.Spec.Source, &containerEnv)
+	}
+	addOriginVersionVar(&containerEnv)
+	if ref := build.Status.OutputDockerImageReference; len(ref) > 0 {
+		if err := addOutputEnvVars(&kapi.ObjectReference{Kind: "DockerImage", Name: ref}, &containerEnv); err != nil {
+			return nil, fmt.Errorf("failed to parse the output docker image reference %q: %v", ref, err)
+		}
+	}
+	containerEnv = append(containerEnv, strategy.Env...)
+	containerEnv = append(containerEnv, stage.Env...)
+
+	pod := &kapi.Pod{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:      buildapi.GetBuildStagePodName(build, stage.Name),
+			Namespace: build.Namespace,
+			Labels:    getPodLabels(build),
+			Annotations: map[string]string{
+				buildapi.BuildAnnotation:      build.Name,
+				buildapi.BuildStageAnnotation: stage.Name,
+			},
+		},
+		Spec: kapi.PodSpec{
+			ServiceAccountName: build.Spec.ServiceAccount,
+			Containers: []kapi.Container{
+				{
+					Name:      "stage",
+					Image:     stage.Image,
+					Command:   stage.Command,
+					Env:       containerEnv,
+					Resources: build.Spec.Resources,
+				},
+			},
+			RestartPolicy: kapi.RestartPolicyNever,
+		},
+	}
+	if build.Spec.CompletionDeadlineSeconds != nil {
+		pod.Spec.ActiveDeadlineSeconds = remainingDeadlineSeconds(build)
+	}
+
+	if !strategy.ForcePull {
+		pod.Spec.Containers[0].ImagePullPolicy = kapi.PullIfNotPresent
+	} else {
+		glog.V(2).Infof("ForcePull is enabled for %s build", build.Name)
+		pod.Spec.Containers[0].ImagePullPolicy = kapi.PullAlways
+	}
+
+	setupSourceSecrets(pod, build.Spec.Source.SourceSecret)
+	setupSecrets(pod, build.Spec.Source.Secrets)
+	return pod, nil
+}
+
+// remainingDeadlineSeconds returns the time left for the stages of a Pipeline
+// build that have not started yet, since CompletionDeadlineSeconds applies to
+// the build as a whole.
+func remainingDeadlineSeconds(build *buildapi.Build) *int64 {
+	deadline := *build.Spec.CompletionDeadlineSeconds
+	if build.Status.StartTimestamp != nil {
+		elapsed := int64(unversioned.Now().Sub(build.Status.StartTimestamp.Time).Seconds())
+		deadline -= elapsed
+	}
+	if deadline < 1 {
+		deadline = 1
+	}
+	return &deadline
+}
@@ -0,0 +1,123 @@
+package strategy
+
+import (
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+)
+
+func mockPipelineBuild() *buildapi.Build {
+	timeout := int64(600)
+	return &buildapi.Build{
+		ObjectMeta: kapi.ObjectMeta{
+			Name:      "pipeline-1",
+			Namespace: "test",
+		},
+		Spec: buildapi.BuildSpec{
+			CommonSpec: buildapi.CommonSpec{
+				Source: buildapi.BuildSource{
+					Git: &buildapi.GitBuildSource{URI: "http://my.build.com/the/repo", Ref: "master"},
+				},
+				Strategy: buildapi.BuildStrategy{
+					PipelineStrategy: &buildapi.PipelineBuildStrategy{
+						Stages: []buildapi.PipelineStage{
+							{Name: "test", Image: "golang:1.6", Command: []string{"make", "test"}},
+							{Name: "publish", Image: "publisher", Env: []kapi.EnvVar{{Name: "TARGET", Value: "staging"}}},
+						},
+						Env: []kapi.EnvVar{{Name: "CI", Value: "true"}},
+					},
+				},
+				CompletionDeadlineSeconds: &timeout,
+			},
+		},
+		Status: buildapi.BuildStatus{
+			OutputDockerImageReference: "docker-registry/repository/pipeline:latest",
+		},
+	}
+}
+
+func envValue(env []kapi.EnvVar, name string) (string, bool) {
+	for _, v := range env {
+		if v.Name == name {
+			return v.Value, true
+		}
+	}
+	return "", false
+}
+
+func TestPipelineCreateBuildPod(t *testing.T) {
+	strategy := &PipelineBuildStrategy{
+		Codec: kapi.Codecs.LegacyCodec(buildapi.SchemeGroupVersion),
+	}
+	build := mockPipelineBuild()
+
+	pod, err := strategy.CreateBuildPod(build)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := buildapi.GetBuildStagePodName(build, "test"); pod.Name != expected {
+		t.Errorf("expected pod name %s, got %s", expected, pod.Name)
+	}
+	if pod.Annotations[buildapi.BuildStageAnnotation] != "test" || pod.Annotations[buildapi.BuildAnnotation] != build.Name {
+		t.Errorf("unexpected pod annotations: %v", pod.Annotations)
+	}
+	if pod.Labels[buildapi.BuildLabel] != buildapi.LabelValue(build.Name) {
+		t.Errorf("unexpected pod labels: %v", pod.Labels)
+	}
+	container := pod.Spec.Containers[0]
+	if container.Image != "golang:1.6" || len(container.Command) != 2 {
+		t.Errorf("unexpected stage container: %#v", container)
+	}
+	for name, expected := range map[string]string{
+		"BUILD_STAGE":     "test",
+		"SOURCE_URI":      "http://my.build.com/the/repo",
+		"SOURCE_REF":      "master",
+		"OUTPUT_REGISTRY": "docker-registry",
+		"OUTPUT_IMAGE":    "repository/pipeline:latest",
+		"CI":              "true",
+	} {
+		if value, _ := envValue(container.Env, name); value != expected {
+			t.Errorf("expected %s=%s, got %q", name, expected, value)
+		}
+	}
+	if value, _ := envValue(container.Env, "BUILD"); len(value) == 0 {
+		t.Errorf("expected the build to be set in the BUILD environment variable")
+	}
+	if _, ok := envValue(container.Env, "TARGET"); ok {
+		t.Errorf("expected the environment of the next stage not to be set")
+	}
+	if pod.Spec.RestartPolicy != kapi.RestartPolicyNever {
+		t.Errorf("expected never, got %#v", pod.Spec.RestartPolicy)
+	}
+	if *pod.Spec.ActiveDeadlineSeconds != 600 {
+		t.Errorf("expected ActiveDeadlineSeconds 600, got %d", *pod.Spec.ActiveDeadlineSeconds)
+	}
+
+	// the second stage gets the time left for the build
+	started := unversioned.NewTime(time.Now().Add(-100 * time.Second))
+	build.Status.StartTimestamp = &started
+	build.Status.Stages = []buildapi.BuildStageStatus{{Name: "test", Phase: buildapi.BuildPhaseComplete}}
+	pod, err = strategy.CreateBuildPod(build)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.Annotations[buildapi.BuildStageAnnotation] != "publish" {
+		t.Errorf("expected a pod for the publish stage, got %v", pod.Annotations)
+	}
+	if value, _ := envValue(pod.Spec.Containers[0].Env, "TARGET"); value != "staging" {
+		t.Errorf("expected the stage environment to be set, got %v", pod.Spec.Containers[0].Env)
+	}
+	if deadline := *pod.Spec.ActiveDeadlineSeconds; deadline > 500 || deadline < 490 {
+		t.Errorf("expected ActiveDeadlineSeconds of about 500, got %d", deadline)
+	}
+
+	// every stage has started
+	build.Status.Stages = append(build.Status.Stages, buildapi.BuildStageStatus{Name: "publish", Phase: buildapi.BuildPhaseComplete})
+	if _, err := strategy.CreateBuildPod(build); !IsFatal(err) {
+		t.Errorf("expected a fatal error, got %v", err)
+	}
+}
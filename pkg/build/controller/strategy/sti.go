@@ -103,6 +103,7 @@ func (bs *SourceBuildStrategy) CreateBuildPod(build *buildapi.Build) (*kapi.Pod,
 	setupDockerSecrets(pod, build.Spec.Output.PushSecret, strategy.PullSecret, build.Spec.Source.Images)
 	setupSourceSecrets(pod, build.Spec.Source.SourceSecret)
 	setupSecrets(pod, build.Spec.Source.Secrets)
+	setupIncrementalCache(pod, strategy.IncrementalCache)
 	return pod, nil
 }
 
@@ -165,6 +165,31 @@ func TestS2IBuildLongName(t *testing.T) {
 	}
 }
 
+func TestS2IBuildIncrementalCache(t *testing.T) {
+	strategy := &SourceBuildStrategy{
+		Image:            "sti-test-image",
+		Codec:            kapi.Codecs.LegacyCodec(buildapi.SchemeGroupVersion),
+		AdmissionControl: &FakeAdmissionControl{admit: true},
+	}
+	build := mockSTIBuild()
+	build.Spec.Strategy.SourceStrategy.Incremental = true
+	build.Spec.Strategy.SourceStrategy.IncrementalCache = &buildapi.IncrementalCacheSource{
+		PersistentVolumeClaim: &kapi.LocalObjectReference{Name: "cache"},
+	}
+	pod, err := strategy.CreateBuildPod(build)
+	if err != nil {
+		t.Fatalf("unexpected: %v", err)
+	}
+	volume := pod.Spec.Volumes[len(pod.Spec.Volumes)-1]
+	if volume.PersistentVolumeClaim == nil || volume.PersistentVolumeClaim.ClaimName != "cache" {
+		t.Errorf("Expected the cache claim to be the last volume, got %#v", volume)
+	}
+	mounts := pod.Spec.Containers[0].VolumeMounts
+	if mount := mounts[len(mounts)-1]; mount.Name != volume.Name || mount.MountPath != IncrementalCacheMountPath {
+		t.Errorf("Expected the cache claim mounted at %s, got %#v", IncrementalCacheMountPath, mount)
+	}
+}
+
 func mockSTIBuild() *buildapi.Build {
 	timeout := int64(60)
 	return &buildapi.Build{
@@ -23,6 +23,7 @@ const (
 	SecretBuildSourceBaseMountPath = "/var/run/secrets/openshift.io/build"
 	SourceImagePullSecretMountPath = "/var/run/secrets/openshift.io/source-image"
 	sourceSecretMountPath          = "/var/run/secrets/openshift.io/source"
+	IncrementalCacheMountPath      = "/var/run/openshift.io/incremental-cache"
 )
 
 var whitelistEnvVarNames = []string{"BUILD_LOGLEVEL"}
@@ -142,6 +143,29 @@ func setupSecrets(pod *kapi.Pod, secrets []buildapi.SecretBuildSource) {
 	}
 }
 
+// setupIncrementalCache mounts the persistent volume claim that keeps the
+// artifacts of incremental builds into the builder container.
+func setupIncrementalCache(pod *kapi.Pod, cache *buildapi.IncrementalCacheSource) {
+	if cache == nil || cache.PersistentVolumeClaim == nil {
+		return
+	}
+	volume := kapi.Volume{
+		Name: "incremental-cache",
+		VolumeSource: kapi.VolumeSource{
+			PersistentVolumeClaim: &kapi.PersistentVolumeClaimVolumeSource{
+				ClaimName: cache.PersistentVolumeClaim.Name,
+			},
+		},
+	}
+	volumeMount := kapi.VolumeMount{
+		Name:      "incremental-cache",
+		MountPath: IncrementalCacheMountPath,
+	}
+	pod.Spec.Volumes = append(pod.Spec.Volumes, volume)
+	pod.Spec.Containers[0].VolumeMounts = append(pod.Spec.Containers[0].VolumeMounts, volumeMount)
+	glog.V(3).Infof("%s will be used as the incremental build cache in %s", cache.PersistentVolumeClaim.Name, pod.Name)
+}
+
 // addSourceEnvVars adds environment variables related to the source code
 // repository to builder container
 func addSourceEnvVars(source buildapi.BuildSource, output *[]kapi.EnvVar) {
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"reflect"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
 
@@ -14,6 +15,7 @@ import (
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/credentialprovider"
+	"k8s.io/kubernetes/pkg/labels"
 	kvalidation "k8s.io/kubernetes/pkg/util/validation"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
@@ -57,6 +59,8 @@ type GeneratorClient interface {
 	UpdateBuildConfig(ctx kapi.Context, buildConfig *buildapi.BuildConfig) error
 	GetBuild(ctx kapi.Context, name string) (*buildapi.Build, error)
 	CreateBuild(ctx kapi.Context, build *buildapi.Build) error
+	ListBuilds(ctx kapi.Context, options *kapi.ListOptions) (*buildapi.BuildList, error)
+	DeleteBuild(ctx kapi.Context, name string) error
 	GetImageStream(ctx kapi.Context, name string) (*imageapi.ImageStream, error)
 	GetImageStreamImage(ctx kapi.Context, name string) (*imageapi.ImageStreamImage, error)
 	GetImageStreamTag(ctx kapi.Context, name string) (*imageapi.ImageStreamTag, error)
@@ -68,6 +72,8 @@ type Client struct {
 	UpdateBuildConfigFunc   func(ctx kapi.Context, buildConfig *buildapi.BuildConfig) error
 	GetBuildFunc            func(ctx kapi.Context, name string) (*buildapi.Build, error)
 	CreateBuildFunc         func(ctx kapi.Context, build *buildapi.Build) error
+	ListBuildsFunc          func(ctx kapi.Context, options *kapi.ListOptions) (*buildapi.BuildList, error)
+	DeleteBuildFunc         func(ctx kapi.Context, name string) error
 	GetImageStreamFunc      func(ctx kapi.Context, name string) (*imageapi.ImageStream, error)
 	GetImageStreamImageFunc func(ctx kapi.Context, name string) (*imageapi.ImageStreamImage, error)
 	GetImageStreamTagFunc   func(ctx kapi.Context, name string) (*imageapi.ImageStreamTag, error)
@@ -93,6 +99,16 @@ func (c Client) CreateBuild(ctx kapi.Context, build *buildapi.Build) error {
 	return c.CreateBuildFunc(ctx, build)
 }
 
+// ListBuilds lists builds
+func (c Client) ListBuilds(ctx kapi.Context, options *kapi.ListOptions) (*buildapi.BuildList, error) {
+	return c.ListBuildsFunc(ctx, options)
+}
+
+// DeleteBuild deletes a named build
+func (c Client) DeleteBuild(ctx kapi.Context, name string) error {
+	return c.DeleteBuildFunc(ctx, name)
+}
+
 // GetImageStream retrieves a named image stream
 func (c Client) GetImageStream(ctx kapi.Context, name string) (*imageapi.ImageStream, error) {
 	return c.GetImageStreamFunc(ctx, name)
@@ -231,6 +247,10 @@ func (g *BuildGenerator) Instantiate(ctx kapi.Context, request *buildapi.BuildRe
 		return nil, errors.NewInternalError(err)
 	}
 
+	if err := updateBuildCompletionTriggers(bc, request.TriggeredBy); err != nil {
+		return nil, errors.NewInternalError(err)
+	}
+
 	newBuild, err := g.generateBuildFromConfig(ctx, bc, request.Revision, request.Binary)
 	if err != nil {
 		return nil, errors.NewInternalError(err)
@@ -244,6 +264,25 @@ func (g *BuildGenerator) Instantiate(ctx kapi.Context, request *buildapi.BuildRe
 	// Copy build trigger information to the build object.
 	newBuild.Spec.TriggeredBy = request.TriggeredBy
 
+	pullRequest := pullRequestForBuildRequest(request)
+	if pullRequest != nil {
+		if err := setPullRequestSource(bc, newBuild, pullRequest); err != nil {
+			return nil, errors.NewInternalError(err)
+		}
+	}
+
+	if request.Matrix != nil {
+		if err := g.setBuildMatrixEntry(ctx, bc, newBuild, request.Matrix); err != nil {
+			return nil, err
+		}
+	}
+
+	// Secrets are withheld last, once every secret of the build, including the
+	// pull secret of a build matrix image, has been resolved.
+	if pullRequest != nil && pullRequest.Fork && !forkPullRequestSecrets(bc) {
+		withholdSecrets(newBuild)
+	}
+
 	if len(request.Env) > 0 {
 		updateBuildEnv(&newBuild.Spec.Strategy, request.Env)
 	}
@@ -261,7 +300,247 @@ func (g *BuildGenerator) Instantiate(ctx kapi.Context, request *buildapi.BuildRe
 	// create the corresponding build, however doing things in that order
 	// allows for a race condition in which two builds get kicked off.  Doing
 	// it in this order ensures that we catch the race while updating the BC.
-	return g.createBuild(ctx, newBuild)
+	build, err := g.createBuild(ctx, newBuild)
+	if err != nil {
+		return nil, err
+	}
+	if pullRequest != nil {
+		g.prunePullRequestBuilds(ctx, bc)
+	}
+	return build, nil
+}
+
+// pullRequestForBuildRequest returns the pull request the build request was
+// made for, or nil if it was not triggered by a pull request.
+func pullRequestForBuildRequest(request *buildapi.BuildRequest) *buildapi.PullRequestCause {
+	for _, cause := range request.TriggeredBy {
+		if cause.GitHubWebHook != nil && cause.GitHubWebHook.PullRequest != nil {
+			return cause.GitHubWebHook.PullRequest
+		}
+	}
+	return nil
+}
+
+// setPullRequestSource labels the build with the pull request number and
+// points its git source at the pull request head, which is not fetched when
+// cloning the repository. The output tag is suffixed with the pull request
+// number, so that code from pull requests never replaces the images built from
+// the repository or triggers what depends on them.
+func setPullRequestSource(bc *buildapi.BuildConfig, build *buildapi.Build, pullRequest *buildapi.PullRequestCause) error {
+	build.Labels[buildapi.BuildPullRequestLabel] = strconv.FormatInt(pullRequest.Number, 10)
+	if build.Spec.Source.Git != nil {
+		build.Spec.Source.Git.Ref = fmt.Sprintf("refs/pull/%d/head", pullRequest.Number)
+	}
+	return suffixOutputTag(build, fmt.Sprintf("pr-%d", pullRequest.Number))
+}
+
+// withholdSecrets removes every secret from a build of a pull request from a
+// fork, whose code can't be trusted with them. The pull secrets resolved from
+// the builder service account can push to the integrated registry, so they
+// are removed as well. The build doesn't push its image, since it can't
+// without the push secret, and doesn't use the incremental build cache, which
+// the builds of the repository restore their artifacts from.
+func withholdSecrets(build *buildapi.Build) {
+	build.Spec.Source.SourceSecret = nil
+	build.Spec.Source.Secrets = nil
+	for i := range build.Spec.Source.Images {
+		build.Spec.Source.Images[i].PullSecret = nil
+	}
+	build.Spec.Output.PushSecret = nil
+	build.Spec.Output.To = nil
+
+	strategy := &build.Spec.Strategy
+	switch {
+	case strategy.SourceStrategy != nil:
+		strategy.SourceStrategy.PullSecret = nil
+		strategy.SourceStrategy.Incremental = false
+		strategy.SourceStrategy.IncrementalCache = nil
+	case strategy.DockerStrategy != nil:
+		strategy.DockerStrategy.PullSecret = nil
+	case strategy.CustomStrategy != nil:
+		strategy.CustomStrategy.PullSecret = nil
+		strategy.CustomStrategy.Secrets = nil
+	}
+}
+
+// forkPullRequestSecrets returns true if a GitHub webhook trigger of the
+// BuildConfig gives its secrets to the builds of pull requests from forks.
+func forkPullRequestSecrets(bc *buildapi.BuildConfig) bool {
+	for _, trigger := range bc.Spec.Triggers {
+		if trigger.Type == buildapi.GitHubWebHookBuildTriggerType && trigger.GitHubWebHook != nil && trigger.GitHubWebHook.BuildPullRequests && trigger.GitHubWebHook.ForkPullRequestSecrets {
+			return true
+		}
+	}
+	return false
+}
+
+// suffixOutputTag appends "-<suffix>" to the tag the build pushes its image to.
+func suffixOutputTag(build *buildapi.Build, suffix string) error {
+	to := build.Spec.Output.To
+	if to == nil {
+		return nil
+	}
+	switch to.Kind {
+	case "ImageStreamTag":
+		stream, tag, _ := imageapi.SplitImageStreamTag(to.Name)
+		to.Name = imageapi.JoinImageStreamTag(stream, tag+"-"+suffix)
+	case "DockerImage":
+		ref, err := imageapi.ParseDockerImageReference(to.Name)
+		if err != nil {
+			return err
+		}
+		if len(ref.Tag) == 0 {
+			ref.Tag = imageapi.DefaultImageTag
+		}
+		ref.Tag += "-" + suffix
+		to.Name = ref.String()
+	}
+	return nil
+}
+
+// setBuildMatrixEntry builds the build with the image and environment variable
+// set of the selected entry of the BuildConfig's build matrix, suffixes its
+// output tag with the name of the entry and labels it with the group of builds
+// it was instantiated with.
+func (g *BuildGenerator) setBuildMatrixEntry(ctx kapi.Context, bc *buildapi.BuildConfig, build *buildapi.Build, entry *buildapi.BuildMatrixEntry) error {
+	matrix := bc.Spec.Matrix
+	if matrix == nil {
+		return errors.NewBadRequest(fmt.Sprintf("BuildConfig %s/%s does not declare a build matrix", bc.Namespace, bc.Name))
+	}
+
+	if len(entry.Image) > 0 {
+		var image *buildapi.BuildMatrixImage
+		for i := range matrix.Images {
+			if matrix.Images[i].Name == entry.Image {
+				image = &matrix.Images[i]
+				break
+			}
+		}
+		if image == nil {
+			return errors.NewBadRequest(fmt.Sprintf("the build matrix of BuildConfig %s/%s has no image %q", bc.Namespace, bc.Name, entry.Image))
+		}
+		if err := g.setBuildMatrixImage(ctx, bc, build, image); err != nil {
+			return errors.NewInternalError(err)
+		}
+	}
+
+	if len(entry.Env) > 0 {
+		var env *buildapi.BuildMatrixEnv
+		for i := range matrix.Env {
+			if matrix.Env[i].Name == entry.Env {
+				env = &matrix.Env[i]
+				break
+			}
+		}
+		if env == nil {
+			return errors.NewBadRequest(fmt.Sprintf("the build matrix of BuildConfig %s/%s has no environment variable set %q", bc.Namespace, bc.Name, entry.Env))
+		}
+		updateBuildEnv(&build.Spec.Strategy, env.Env)
+	}
+
+	name := buildutil.BuildMatrixEntryName(entry)
+	if err := suffixOutputTag(build, name); err != nil {
+		return errors.NewInternalError(err)
+	}
+	build.Labels[buildapi.BuildMatrixLabel] = buildapi.LabelValue(entry.Group)
+	build.Annotations[buildapi.BuildMatrixEntryAnnotation] = name
+	return nil
+}
+
+// setBuildMatrixImage replaces the builder image of the build strategy with
+// the resolved image of the build matrix. The pull secret is resolved again
+// unless the BuildConfig sets it explicitly.
+func (g *BuildGenerator) setBuildMatrixImage(ctx kapi.Context, bc *buildapi.BuildConfig, build *buildapi.Build, image *buildapi.BuildMatrixImage) error {
+	if buildutil.GetInputReference(build.Spec.Strategy) == nil {
+		return fmt.Errorf("the build strategy of BuildConfig %s/%s has no builder image to replace", bc.Namespace, bc.Name)
+	}
+	ref, err := g.resolveImageStreamReference(ctx, image.From, bc.Namespace)
+	if err != nil {
+		return err
+	}
+	secrets, err := g.FetchServiceAccountSecrets(bc.Namespace, build.Spec.ServiceAccount)
+	if err != nil {
+		return err
+	}
+	from := kapi.ObjectReference{Kind: "DockerImage", Name: ref}
+	strategy := build.Spec.Strategy
+	switch {
+	case strategy.SourceStrategy != nil:
+		strategy.SourceStrategy.From = from
+		if bc.Spec.Strategy.SourceStrategy.PullSecret == nil {
+			strategy.SourceStrategy.PullSecret = g.resolveImageSecret(ctx, secrets, &from, bc.Namespace)
+		}
+	case strategy.DockerStrategy != nil:
+		strategy.DockerStrategy.From = &from
+		if bc.Spec.Strategy.DockerStrategy.PullSecret == nil {
+			strategy.DockerStrategy.PullSecret = g.resolveImageSecret(ctx, secrets, &from, bc.Namespace)
+		}
+	case strategy.CustomStrategy != nil:
+		strategy.CustomStrategy.From = from
+		if bc.Spec.Strategy.CustomStrategy.PullSecret == nil {
+			strategy.CustomStrategy.PullSecret = g.resolveImageSecret(ctx, secrets, &from, bc.Namespace)
+		}
+		updateCustomImageEnv(strategy.CustomStrategy, ref)
+	}
+	return nil
+}
+
+// pullRequestBuildsLimit returns the lowest number of pull request builds to
+// keep set by the GitHub webhook triggers of the BuildConfig, or nil if pull
+// request builds are not limited.
+func pullRequestBuildsLimit(bc *buildapi.BuildConfig) *int64 {
+	var limit *int64
+	for _, trigger := range bc.Spec.Triggers {
+		if trigger.Type != buildapi.GitHubWebHookBuildTriggerType || trigger.GitHubWebHook == nil {
+			continue
+		}
+		hook := trigger.GitHubWebHook
+		if !hook.BuildPullRequests || hook.PullRequestBuildsLimit == nil {
+			continue
+		}
+		if limit == nil || *hook.PullRequestBuildsLimit < *limit {
+			limit = hook.PullRequestBuildsLimit
+		}
+	}
+	return limit
+}
+
+// prunePullRequestBuilds deletes the oldest completed pull request builds of
+// the BuildConfig until no more than the configured limit are kept. Failing to
+// prune is not fatal, the builds are pruned again on the next pull request.
+func (g *BuildGenerator) prunePullRequestBuilds(ctx kapi.Context, bc *buildapi.BuildConfig) {
+	limit := pullRequestBuildsLimit(bc)
+	if limit == nil {
+		return
+	}
+	selector := labels.SelectorFromSet(labels.Set{buildapi.BuildConfigLabel: buildapi.LabelValue(bc.Name)})
+	builds, err := g.Client.ListBuilds(ctx, &kapi.ListOptions{LabelSelector: selector})
+	if err != nil {
+		glog.V(2).Infof("Unable to list pull request builds for BuildConfig %s/%s: %v", bc.Namespace, bc.Name, err)
+		return
+	}
+	var pullRequestBuilds []*buildapi.Build
+	for i := range builds.Items {
+		if _, ok := builds.Items[i].Labels[buildapi.BuildPullRequestLabel]; ok {
+			pullRequestBuilds = append(pullRequestBuilds, &builds.Items[i])
+		}
+	}
+	sort.Sort(buildapi.BuildPtrSliceByCreationTimestamp(pullRequestBuilds))
+	excess := int64(len(pullRequestBuilds)) - *limit
+	for _, build := range pullRequestBuilds {
+		if excess <= 0 {
+			break
+		}
+		if !buildutil.IsBuildComplete(build) {
+			continue
+		}
+		glog.V(4).Infof("Pruning pull request build %s/%s", build.Namespace, build.Name)
+		if err := g.Client.DeleteBuild(ctx, build.Name); err != nil && !errors.IsNotFound(err) {
+			glog.V(2).Infof("Unable to prune pull request build %s/%s: %v", build.Namespace, build.Name, err)
+			continue
+		}
+		excess--
+	}
 }
 
 // checkBuildConfigLastVersion will return an error if the BuildConfig's LastVersion doesn't match the passed in lastVersion
@@ -319,6 +598,105 @@ func (g *BuildGenerator) updateImageTriggers(ctx kapi.Context, bc *buildapi.Buil
 	return nil
 }
 
+// updateBuildCompletionTriggers sets the LastTriggeredBuild on the
+// BuildCompletionTrigger matching the build completion that caused the build,
+// if any.
+func updateBuildCompletionTriggers(bc *buildapi.BuildConfig, causes []buildapi.BuildTriggerCause) error {
+	for _, cause := range causes {
+		if cause.BuildCompletion == nil || cause.BuildCompletion.FromRef == nil {
+			continue
+		}
+		trigger := findBuildCompletionTrigger(bc, cause.BuildCompletion.FromRef)
+		if trigger == nil {
+			continue
+		}
+		if trigger.LastTriggeredBuild == cause.BuildCompletion.BuildName {
+			glog.V(2).Infof("Aborting build completion triggered build for BuildConfig %s/%s because the BuildConfig was already triggered by build %s", bc.Namespace, bc.Name, cause.BuildCompletion.BuildName)
+			return fmt.Errorf("build config %s/%s has already instantiated a build for build %s", bc.Namespace, bc.Name, cause.BuildCompletion.BuildName)
+		}
+		trigger.LastTriggeredBuild = cause.BuildCompletion.BuildName
+	}
+	return nil
+}
+
+// findBuildCompletionTrigger finds the BuildCompletionTrigger of the
+// BuildConfig that refers to the given BuildConfig reference.
+func findBuildCompletionTrigger(bc *buildapi.BuildConfig, ref *kapi.ObjectReference) *buildapi.BuildCompletionTrigger {
+	refNamespace := ref.Namespace
+	if len(refNamespace) == 0 {
+		refNamespace = bc.Namespace
+	}
+	for _, trigger := range bc.Spec.Triggers {
+		if trigger.Type != buildapi.BuildCompletionBuildTriggerType || trigger.BuildCompletion == nil {
+			continue
+		}
+		from := trigger.BuildCompletion.From
+		fromNamespace := from.Namespace
+		if len(fromNamespace) == 0 {
+			fromNamespace = bc.Namespace
+		}
+		if from.Name == ref.Name && fromNamespace == refNamespace {
+			return trigger.BuildCompletion
+		}
+	}
+	return nil
+}
+
+// resolveBuildConfigOutput returns the output image of the build of the
+// referenced BuildConfig that the BuildConfig uses as an input. That is the
+// build that last triggered it if it has a BuildCompletionTrigger for the
+// referenced BuildConfig, or else its latest successful build. Builds of pull
+// requests and build matrix entries are never used. The referenced
+// BuildConfig must be in the namespace of bc.
+func (g *BuildGenerator) resolveBuildConfigOutput(ctx kapi.Context, bc *buildapi.BuildConfig, from kapi.ObjectReference) (string, error) {
+	namespace := from.Namespace
+	if len(namespace) == 0 {
+		namespace = bc.Namespace
+	}
+	if namespace != bc.Namespace {
+		return "", fmt.Errorf("BuildConfig %s/%s can't use the output of BuildConfig %s/%s from another namespace", bc.Namespace, bc.Name, namespace, from.Name)
+	}
+	ctx = kapi.WithNamespace(ctx, namespace)
+
+	if trigger := findBuildCompletionTrigger(bc, &from); trigger != nil && len(trigger.LastTriggeredBuild) > 0 {
+		build, err := g.Client.GetBuild(ctx, trigger.LastTriggeredBuild)
+		if err != nil {
+			return "", err
+		}
+		if len(build.Status.OutputDockerImageReference) == 0 {
+			return "", fmt.Errorf("build %s/%s has no output image", namespace, build.Name)
+		}
+		return build.Status.OutputDockerImageReference, nil
+	}
+
+	selector := labels.SelectorFromSet(labels.Set{buildapi.BuildConfigLabel: buildapi.LabelValue(from.Name)})
+	builds, err := g.Client.ListBuilds(ctx, &kapi.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return "", err
+	}
+	var latest *buildapi.Build
+	for i := range builds.Items {
+		build := &builds.Items[i]
+		if build.Status.Phase != buildapi.BuildPhaseComplete || len(build.Status.OutputDockerImageReference) == 0 {
+			continue
+		}
+		if _, ok := build.Labels[buildapi.BuildPullRequestLabel]; ok {
+			continue
+		}
+		if _, ok := build.Labels[buildapi.BuildMatrixLabel]; ok {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(build.CreationTimestamp) {
+			latest = build
+		}
+	}
+	if latest == nil {
+		return "", fmt.Errorf("BuildConfig %s/%s has no successful build with an output image", namespace, from.Name)
+	}
+	glog.V(4).Infof("Resolved BuildConfig %s/%s to the output %s of build %s", namespace, from.Name, latest.Status.OutputDockerImageReference, latest.Name)
+	return latest.Status.OutputDockerImageReference, nil
+}
+
 // Clone returns clone of a Build
 func (g *BuildGenerator) Clone(ctx kapi.Context, request *buildapi.BuildRequest) (*buildapi.Build, error) {
 	glog.V(4).Infof("Generating build from build %s/%s", request.Namespace, request.Name)
@@ -449,6 +827,13 @@ func (g *BuildGenerator) generateBuildFromConfig(ctx kapi.Context, bc *buildapi.
 
 	// Resolve image source if present
 	for i, sourceImage := range build.Spec.Source.Images {
+		if sourceImage.From.Kind == "BuildConfig" {
+			ref, err := g.resolveBuildConfigOutput(ctx, bc, sourceImage.From)
+			if err != nil {
+				return nil, err
+			}
+			sourceImage.From = kapi.ObjectReference{Kind: "DockerImage", Name: ref}
+		}
 		if sourceImage.PullSecret == nil {
 			sourceImage.PullSecret = g.resolveImageSecret(ctx, builderSecrets, &sourceImage.From, bc.Namespace)
 		}
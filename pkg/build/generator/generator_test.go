@@ -6,9 +6,12 @@ import (
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 
 	"k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	"k8s.io/kubernetes/pkg/runtime"
@@ -1680,3 +1683,338 @@ func TestInstantiateBuildTriggerCauseGitHubWebHook(t *testing.T) {
 		}
 	}
 }
+
+func TestInstantiatePullRequest(t *testing.T) {
+	limit := int64(2)
+	bc := mocks.MockBuildConfig(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+	bc.Spec.Triggers = append(bc.Spec.Triggers, buildapi.BuildTriggerPolicy{
+		Type: buildapi.GitHubWebHookBuildTriggerType,
+		GitHubWebHook: &buildapi.WebHookTrigger{
+			Secret:                 "testsecret",
+			BuildPullRequests:      true,
+			PullRequestBuildsLimit: &limit,
+		},
+	})
+	pullRequestBuild := func(name string, phase buildapi.BuildPhase, age int) buildapi.Build {
+		return buildapi.Build{
+			ObjectMeta: kapi.ObjectMeta{
+				Name:              name,
+				Labels:            map[string]string{buildapi.BuildPullRequestLabel: "1"},
+				CreationTimestamp: unversioned.NewTime(time.Now().Add(-time.Duration(age) * time.Hour)),
+			},
+			Status: buildapi.BuildStatus{Phase: phase},
+		}
+	}
+	existing := &buildapi.BuildList{
+		Items: []buildapi.Build{
+			pullRequestBuild("pr-running", buildapi.BuildPhaseRunning, 3),
+			pullRequestBuild("pr-newest", buildapi.BuildPhaseComplete, 1),
+			pullRequestBuild("pr-oldest", buildapi.BuildPhaseFailed, 4),
+			pullRequestBuild("pr-older", buildapi.BuildPhaseComplete, 2),
+			{ObjectMeta: kapi.ObjectMeta{Name: "push"}, Status: buildapi.BuildStatus{Phase: buildapi.BuildPhaseComplete}},
+		},
+	}
+	deleted := []string{}
+
+	generator := mockBuildGenerator()
+	c := generator.Client.(Client)
+	c.GetBuildConfigFunc = func(ctx kapi.Context, name string) (*buildapi.BuildConfig, error) {
+		return bc, nil
+	}
+	c.ListBuildsFunc = func(ctx kapi.Context, options *kapi.ListOptions) (*buildapi.BuildList, error) {
+		return existing, nil
+	}
+	c.DeleteBuildFunc = func(ctx kapi.Context, name string) error {
+		deleted = append(deleted, name)
+		return nil
+	}
+	generator.Client = c
+
+	buildRequest := &buildapi.BuildRequest{
+		TriggeredBy: []buildapi.BuildTriggerCause{
+			{
+				Message: "GitHub WebHook",
+				GitHubWebHook: &buildapi.GitHubWebHookCause{
+					Secret:      "testsecret",
+					PullRequest: &buildapi.PullRequestCause{Number: 42, HeadRef: "feature"},
+				},
+			},
+		},
+	}
+	build, err := generator.Instantiate(kapi.NewDefaultContext(), buildRequest)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if build.Labels[buildapi.BuildPullRequestLabel] != "42" {
+		t.Errorf("Expected the build to be labeled with the pull request number, got %v", build.Labels)
+	}
+	if build.Spec.Source.Git.Ref != "refs/pull/42/head" {
+		t.Errorf("Expected the build to use the pull request head, got %q", build.Spec.Source.Git.Ref)
+	}
+	if build.Spec.Output.To.Name != "localhost:5000/test/image-tag:latest-pr-42" {
+		t.Errorf("Expected the build to push to a tag of the pull request, got %q", build.Spec.Output.To.Name)
+	}
+	if bc.Spec.Output.To.Name != "localhost:5000/test/image-tag" {
+		t.Errorf("Expected the output of the BuildConfig to be left alone, got %q", bc.Spec.Output.To.Name)
+	}
+	if !reflect.DeepEqual(deleted, []string{"pr-oldest", "pr-older"}) {
+		t.Errorf("Expected the oldest completed pull request builds to be pruned, got %v", deleted)
+	}
+}
+
+func TestInstantiateForkPullRequest(t *testing.T) {
+	for _, allowSecrets := range []bool{false, true} {
+		bc := mocks.MockBuildConfig(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+		bc.Spec.Source.SourceSecret = &kapi.LocalObjectReference{Name: "source"}
+		bc.Spec.Source.Secrets = []buildapi.SecretBuildSource{{Secret: kapi.LocalObjectReference{Name: "settings"}}}
+		bc.Spec.Source.Images = []buildapi.ImageSource{{
+			From:       kapi.ObjectReference{Kind: "DockerImage", Name: "registry/base"},
+			Paths:      []buildapi.ImageSourcePath{{SourcePath: "/opt/app/lib", DestinationDir: "lib"}},
+			PullSecret: &kapi.LocalObjectReference{Name: "image-pull"},
+		}}
+		bc.Spec.Output.PushSecret = &kapi.LocalObjectReference{Name: "push"}
+		bc.Spec.Strategy.SourceStrategy.PullSecret = &kapi.LocalObjectReference{Name: "pull"}
+		bc.Spec.Strategy.SourceStrategy.Incremental = true
+		bc.Spec.Strategy.SourceStrategy.IncrementalCache = &buildapi.IncrementalCacheSource{
+			PersistentVolumeClaim: &kapi.LocalObjectReference{Name: "cache"},
+		}
+		bc.Spec.Triggers = append(bc.Spec.Triggers, buildapi.BuildTriggerPolicy{
+			Type: buildapi.GitHubWebHookBuildTriggerType,
+			GitHubWebHook: &buildapi.WebHookTrigger{
+				Secret:                 "testsecret",
+				BuildPullRequests:      true,
+				ForkPullRequestSecrets: allowSecrets,
+			},
+		})
+
+		generator := mockBuildGenerator()
+		c := generator.Client.(Client)
+		c.GetBuildConfigFunc = func(ctx kapi.Context, name string) (*buildapi.BuildConfig, error) {
+			return bc, nil
+		}
+		generator.Client = c
+
+		buildRequest := &buildapi.BuildRequest{
+			TriggeredBy: []buildapi.BuildTriggerCause{
+				{
+					Message: "GitHub WebHook",
+					GitHubWebHook: &buildapi.GitHubWebHookCause{
+						Secret:      "testsecret",
+						PullRequest: &buildapi.PullRequestCause{Number: 42, HeadRef: "feature", Fork: true},
+					},
+				},
+			},
+		}
+		build, err := generator.Instantiate(kapi.NewDefaultContext(), buildRequest)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		hasSecrets := build.Spec.Source.SourceSecret != nil || len(build.Spec.Source.Secrets) > 0 || build.Spec.Output.PushSecret != nil
+		if hasSecrets != allowSecrets {
+			t.Errorf("allowSecrets=%t: unexpected secrets %#v %#v", allowSecrets, build.Spec.Source, build.Spec.Output)
+		}
+		if pushed := build.Spec.Output.To != nil; pushed != allowSecrets {
+			t.Errorf("allowSecrets=%t: unexpected output %#v", allowSecrets, build.Spec.Output.To)
+		}
+		strategy := build.Spec.Strategy.SourceStrategy
+		if hasPullSecrets := strategy.PullSecret != nil || build.Spec.Source.Images[0].PullSecret != nil; hasPullSecrets != allowSecrets {
+			t.Errorf("allowSecrets=%t: unexpected pull secrets %#v %#v", allowSecrets, strategy.PullSecret, build.Spec.Source.Images[0].PullSecret)
+		}
+		if cached := strategy.Incremental || strategy.IncrementalCache != nil; cached != allowSecrets {
+			t.Errorf("allowSecrets=%t: unexpected incremental cache %t %#v", allowSecrets, strategy.Incremental, strategy.IncrementalCache)
+		}
+	}
+}
+
+func TestInstantiateBuildCompletion(t *testing.T) {
+	bc := mocks.MockBuildConfig(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+	bc.Spec.Source.Images = []buildapi.ImageSource{
+		{
+			From:  kapi.ObjectReference{Kind: "BuildConfig", Name: "base"},
+			Paths: []buildapi.ImageSourcePath{{SourcePath: "/opt/app/lib", DestinationDir: "lib"}},
+		},
+	}
+	bc.Spec.Triggers = append(bc.Spec.Triggers, buildapi.BuildTriggerPolicy{
+		Type: buildapi.BuildCompletionBuildTriggerType,
+		BuildCompletion: &buildapi.BuildCompletionTrigger{
+			From: kapi.ObjectReference{Kind: "BuildConfig", Name: "base"},
+		},
+	})
+
+	generator := mockBuildGenerator()
+	c := generator.Client.(Client)
+	c.GetBuildConfigFunc = func(ctx kapi.Context, name string) (*buildapi.BuildConfig, error) {
+		return bc, nil
+	}
+	c.GetBuildFunc = func(ctx kapi.Context, name string) (*buildapi.Build, error) {
+		return &buildapi.Build{
+			ObjectMeta: kapi.ObjectMeta{Name: name},
+			Status: buildapi.BuildStatus{
+				Phase:                      buildapi.BuildPhaseComplete,
+				OutputDockerImageReference: "registry/base:" + name,
+			},
+		}, nil
+	}
+	var created *buildapi.Build
+	c.CreateBuildFunc = func(ctx kapi.Context, build *buildapi.Build) error {
+		created = build
+		return nil
+	}
+	generator.Client = c
+
+	buildRequest := &buildapi.BuildRequest{
+		TriggeredBy: []buildapi.BuildTriggerCause{
+			{
+				Message: "Build completion",
+				BuildCompletion: &buildapi.BuildCompletionCause{
+					BuildName: "base-2",
+					FromRef:   &kapi.ObjectReference{Kind: "BuildConfig", Name: "base"},
+				},
+			},
+		},
+	}
+	if _, err := generator.Instantiate(kapi.NewDefaultContext(), buildRequest); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if trigger := bc.Spec.Triggers[len(bc.Spec.Triggers)-1].BuildCompletion; trigger.LastTriggeredBuild != "base-2" {
+		t.Errorf("Expected the trigger to record the triggering build, got %q", trigger.LastTriggeredBuild)
+	}
+	if from := created.Spec.Source.Images[0].From; from.Kind != "DockerImage" || from.Name != "registry/base:base-2" {
+		t.Errorf("Expected the image source to be the output of the triggering build, got %#v", from)
+	}
+
+	if _, err := generator.Instantiate(kapi.NewDefaultContext(), buildRequest); err == nil {
+		t.Errorf("Expected an error instantiating twice for the same build")
+	}
+}
+
+func TestResolveBuildConfigOutputLatestBuild(t *testing.T) {
+	bc := mocks.MockBuildConfig(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+	build := func(name string, phase buildapi.BuildPhase, age int) buildapi.Build {
+		return buildapi.Build{
+			ObjectMeta: kapi.ObjectMeta{
+				Name:              name,
+				CreationTimestamp: unversioned.NewTime(time.Now().Add(-time.Duration(age) * time.Hour)),
+			},
+			Status: buildapi.BuildStatus{
+				Phase:                      phase,
+				OutputDockerImageReference: "registry/base:" + name,
+			},
+		}
+	}
+	generator := mockBuildGenerator()
+	c := generator.Client.(Client)
+	c.ListBuildsFunc = func(ctx kapi.Context, options *kapi.ListOptions) (*buildapi.BuildList, error) {
+		return &buildapi.BuildList{Items: []buildapi.Build{
+			build("base-1", buildapi.BuildPhaseComplete, 3),
+			build("base-3", buildapi.BuildPhaseFailed, 1),
+			build("base-2", buildapi.BuildPhaseComplete, 2),
+		}}, nil
+	}
+	generator.Client = c
+
+	ref, err := generator.resolveBuildConfigOutput(kapi.NewDefaultContext(), bc, kapi.ObjectReference{Kind: "BuildConfig", Name: "base"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ref != "registry/base:base-2" {
+		t.Errorf("Expected the output of the latest successful build, got %q", ref)
+	}
+}
+
+func TestResolveBuildConfigOutputSkipsPullRequestAndMatrixBuilds(t *testing.T) {
+	bc := mocks.MockBuildConfig(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+	build := func(name string, age int, labels map[string]string) buildapi.Build {
+		return buildapi.Build{
+			ObjectMeta: kapi.ObjectMeta{
+				Name:              name,
+				Labels:            labels,
+				CreationTimestamp: unversioned.NewTime(time.Now().Add(-time.Duration(age) * time.Hour)),
+			},
+			Status: buildapi.BuildStatus{
+				Phase:                      buildapi.BuildPhaseComplete,
+				OutputDockerImageReference: "registry/base:" + name,
+			},
+		}
+	}
+	generator := mockBuildGenerator()
+	c := generator.Client.(Client)
+	c.ListBuildsFunc = func(ctx kapi.Context, options *kapi.ListOptions) (*buildapi.BuildList, error) {
+		return &buildapi.BuildList{Items: []buildapi.Build{
+			build("base-1", 3, nil),
+			build("base-2", 2, map[string]string{buildapi.BuildPullRequestLabel: "12"}),
+			build("base-3", 1, map[string]string{buildapi.BuildMatrixLabel: "base-x1z2p"}),
+		}}, nil
+	}
+	generator.Client = c
+
+	ref, err := generator.resolveBuildConfigOutput(kapi.NewDefaultContext(), bc, kapi.ObjectReference{Kind: "BuildConfig", Name: "base"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if ref != "registry/base:base-1" {
+		t.Errorf("Expected the output of the latest build of the BuildConfig's own source, got %q", ref)
+	}
+}
+
+func TestInstantiateBuildMatrix(t *testing.T) {
+	bc := mocks.MockBuildConfig(mocks.MockSource(), mocks.MockSourceStrategyForImageRepository(), mocks.MockOutput())
+	bc.Spec.Strategy.SourceStrategy.Env = []kapi.EnvVar{{Name: "DEBUG", Value: "false"}, {Name: "MODE", Value: "app"}}
+	bc.Spec.Matrix = &buildapi.BuildMatrix{
+		Images: []buildapi.BuildMatrixImage{
+			{Name: "py27", From: kapi.ObjectReference{Kind: "DockerImage", Name: "registry/python:2.7"}},
+			{Name: "py35", From: kapi.ObjectReference{Kind: "DockerImage", Name: "registry/python:3.5"}},
+		},
+		Env: []buildapi.BuildMatrixEnv{
+			{Name: "debug", Env: []kapi.EnvVar{{Name: "DEBUG", Value: "true"}}},
+		},
+	}
+
+	generator := mockBuildGenerator()
+	c := generator.Client.(Client)
+	c.GetBuildConfigFunc = func(ctx kapi.Context, name string) (*buildapi.BuildConfig, error) {
+		return bc, nil
+	}
+	var created *buildapi.Build
+	c.CreateBuildFunc = func(ctx kapi.Context, build *buildapi.Build) error {
+		created = build
+		return nil
+	}
+	c.GetBuildFunc = func(ctx kapi.Context, name string) (*buildapi.Build, error) {
+		return created, nil
+	}
+	generator.Client = c
+
+	request := &buildapi.BuildRequest{
+		ObjectMeta: kapi.ObjectMeta{Name: bc.Name},
+		Matrix:     &buildapi.BuildMatrixEntry{Group: "test-build-config-abcde", Image: "py35", Env: "debug"},
+	}
+	build, err := generator.Instantiate(kapi.NewDefaultContext(), request)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if from := build.Spec.Strategy.SourceStrategy.From; from.Kind != "DockerImage" || from.Name != "registry/python:3.5" {
+		t.Errorf("Expected the builder image of the matrix entry, got %#v", from)
+	}
+	expectedEnv := []kapi.EnvVar{{Name: "MODE", Value: "app"}, {Name: "DEBUG", Value: "true"}}
+	if env := build.Spec.Strategy.SourceStrategy.Env; !reflect.DeepEqual(env, expectedEnv) {
+		t.Errorf("Expected environment %#v, got %#v", expectedEnv, env)
+	}
+	if to := build.Spec.Output.To.Name; to != "localhost:5000/test/image-tag:latest-py35.debug" {
+		t.Errorf("Expected the output tag of the matrix entry, got %s", to)
+	}
+	if group := build.Labels[buildapi.BuildMatrixLabel]; group != "test-build-config-abcde" {
+		t.Errorf("Expected the build to be labeled with its build matrix group, got %q", group)
+	}
+	if entry := build.Annotations[buildapi.BuildMatrixEntryAnnotation]; entry != "py35.debug" {
+		t.Errorf("Expected the build to be annotated with its build matrix entry, got %q", entry)
+	}
+	if bc.Spec.Strategy.SourceStrategy.From.Kind != "ImageStreamTag" {
+		t.Errorf("Expected the BuildConfig strategy not to be modified, got %#v", bc.Spec.Strategy.SourceStrategy.From)
+	}
+
+	request.Matrix.Image = "py36"
+	if _, err := generator.Instantiate(kapi.NewDefaultContext(), request); err == nil || !errors.IsBadRequest(err) {
+		t.Errorf("Expected a bad request for an unknown matrix image, got %v", err)
+	}
+}
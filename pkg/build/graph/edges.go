@@ -32,6 +32,15 @@ const (
 	// input source for the build.
 	BuildInputEdgeKind = "BuildInput"
 
+	// BuildTriggerBuildConfigEdgeKind is an edge from a BuildConfig to another BuildConfig that
+	// represents a trigger connection. The completion of a build of the first BuildConfig will
+	// trigger a new build from the second BuildConfig.
+	BuildTriggerBuildConfigEdgeKind = "BuildTriggerBuildConfig"
+
+	// BuildInputBuildConfigEdgeKind is an edge from a BuildConfig to another BuildConfig, where the
+	// output of the latest build of the first BuildConfig is an image source of the second.
+	BuildInputBuildConfigEdgeKind = "BuildInputBuildConfig"
+
 	// BuildEdgeKind goes from a BuildConfigNode to a BuildNode and indicates that the buildConfig owns the build
 	BuildEdgeKind = "Build"
 )
@@ -115,10 +124,39 @@ func AddTriggerEdges(g osgraph.MutableUniqueGraph, node *buildgraph.BuildConfigN
 	}
 }
 
+// buildConfigRefNode returns the node of the BuildConfig ref points to, adding it to the graph
+// if it was not loaded.
+func buildConfigRefNode(g osgraph.MutableUniqueGraph, ref kapi.ObjectReference, bc *buildapi.BuildConfig) *buildgraph.BuildConfigNode {
+	return buildgraph.EnsureBuildConfigNode(g, &buildapi.BuildConfig{
+		ObjectMeta: kapi.ObjectMeta{
+			Namespace: defaultNamespace(ref.Namespace, bc.Namespace),
+			Name:      ref.Name,
+		},
+	})
+}
+
+// AddBuildConfigEdges links the build config to the build configs it is triggered by or takes
+// image sources from.
+func AddBuildConfigEdges(g osgraph.MutableUniqueGraph, node *buildgraph.BuildConfigNode) {
+	for _, trigger := range node.BuildConfig.Spec.Triggers {
+		if trigger.Type != buildapi.BuildCompletionBuildTriggerType || trigger.BuildCompletion == nil {
+			continue
+		}
+		g.AddEdge(buildConfigRefNode(g, trigger.BuildCompletion.From, node.BuildConfig), node, BuildTriggerBuildConfigEdgeKind)
+	}
+	for _, image := range node.BuildConfig.Spec.Source.Images {
+		if image.From.Kind != "BuildConfig" {
+			continue
+		}
+		g.AddEdge(buildConfigRefNode(g, image.From, node.BuildConfig), node, BuildInputBuildConfigEdgeKind)
+	}
+}
+
 // AddInputOutputEdges links the build config to other nodes for the images and source repositories it depends on.
 func AddInputOutputEdges(g osgraph.MutableUniqueGraph, node *buildgraph.BuildConfigNode) *buildgraph.BuildConfigNode {
 	AddInputEdges(g, node)
 	AddTriggerEdges(g, node)
+	AddBuildConfigEdges(g, node)
 	AddOutputEdges(g, node)
 	return node
 }
@@ -70,3 +70,39 @@ func namespaceFor(node graph.Node) (string, error) {
 		return "", fmt.Errorf("unknown object: %#v", obj)
 	}
 }
+
+func TestBuildConfigEdges(t *testing.T) {
+	g := osgraph.New()
+
+	base := &api.BuildConfig{}
+	base.Namespace = "ns"
+	base.Name = "base"
+	baseNode := nodes.EnsureBuildConfigNode(g, base)
+
+	app := &api.BuildConfig{}
+	app.Namespace = "ns"
+	app.Name = "app"
+	app.Spec.Triggers = []api.BuildTriggerPolicy{
+		{
+			Type:            api.BuildCompletionBuildTriggerType,
+			BuildCompletion: &api.BuildCompletionTrigger{From: kapi.ObjectReference{Kind: "BuildConfig", Name: "base"}},
+		},
+	}
+	app.Spec.Source.Images = []api.ImageSource{
+		{From: kapi.ObjectReference{Kind: "BuildConfig", Name: "tools", Namespace: "shared"}},
+	}
+	appNode := nodes.EnsureBuildConfigNode(g, app)
+
+	AddAllInputOutputEdges(g)
+
+	if edges := g.InboundEdges(appNode, BuildTriggerBuildConfigEdgeKind); len(edges) != 1 || edges[0].From() != baseNode {
+		t.Errorf("expected a trigger edge from the base build config, got %v", edges)
+	}
+	edges := g.InboundEdges(appNode, BuildInputBuildConfigEdgeKind)
+	if len(edges) != 1 {
+		t.Fatalf("expected an input edge from the tools build config, got %v", edges)
+	}
+	if ns, err := namespaceFor(edges[0].From()); err != nil || ns != "shared" {
+		t.Errorf("expected the tools build config in namespace shared, got %q: %v", ns, err)
+	}
+}
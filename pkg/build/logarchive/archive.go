@@ -0,0 +1,71 @@
+// Package logarchive stores the logs of completed builds so that they remain
+// available once the build pods have been deleted.
+package logarchive
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// DefaultMaxBytes is the maximum size of an archived build log when no limit
+// is configured.
+const DefaultMaxBytes int64 = 1024 * 1024
+
+// ErrNotFound is returned when no log is archived for a build.
+var ErrNotFound = errors.New("build log is not archived")
+
+// Archive stores the logs of completed builds.
+type Archive interface {
+	// Put archives the log of the named build read from r, replacing any
+	// previously archived log.
+	Put(namespace, name string, r io.Reader) error
+	// Get returns the archived log of the named build, or ErrNotFound.
+	Get(namespace, name string) ([]byte, error)
+	// Delete removes the archived log of the named build, if any.
+	Delete(namespace, name string) error
+}
+
+// readLog reads the log from r, keeping only its last maxBytes bytes. The end
+// of a build log usually explains why the build failed, so it is preferred
+// over the beginning when the log has to be truncated.
+func readLog(r io.Reader, maxBytes int64) ([]byte, error) {
+	if maxBytes <= 0 {
+		maxBytes = DefaultMaxBytes
+	}
+	buf := &bytes.Buffer{}
+	chunk := make([]byte, 32*1024)
+	truncated := false
+	for {
+		n, err := r.Read(chunk)
+		buf.Write(chunk[:n])
+		if extra := int64(buf.Len()) - maxBytes; extra > 0 {
+			buf.Next(int(extra))
+			truncated = true
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if !truncated {
+		return buf.Bytes(), nil
+	}
+	// drop the partial first line
+	data := buf.Bytes()
+	if i := bytes.IndexByte(data, '\n'); i >= 0 {
+		data = data[i+1:]
+	}
+	header := fmt.Sprintf("[log truncated to the last %d bytes]\n", len(data))
+	return append([]byte(header), data...), nil
+}
+
+// StageLogName returns the name the log of a stage of a Pipeline build is
+// archived under. Build names cannot contain "@", so the log of a stage never
+// replaces the log of another build.
+func StageLogName(build, stage string) string {
+	return build + "@" + stage
+}
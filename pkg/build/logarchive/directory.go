@@ -0,0 +1,78 @@
+package logarchive
+
+import (
+	"bytes"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// DirectoryArchive archives build logs as files in a local directory, one
+// subdirectory per namespace. The logs are only readable from the master that
+// archived them, so it is limited to configurations with a single master.
+type DirectoryArchive struct {
+	// Dir is the directory the logs are written to.
+	Dir string
+	// MaxBytes is the maximum size of an archived log.
+	MaxBytes int64
+}
+
+var _ Archive = &DirectoryArchive{}
+
+// NewDirectoryArchive returns an Archive storing build logs in dir.
+func NewDirectoryArchive(dir string, maxBytes int64) *DirectoryArchive {
+	return &DirectoryArchive{Dir: dir, MaxBytes: maxBytes}
+}
+
+func (a *DirectoryArchive) path(namespace, name string) string {
+	return filepath.Join(a.Dir, namespace, name+".log")
+}
+
+// Put writes the log to a temporary file and renames it, so a partially
+// written log is never returned by Get.
+func (a *DirectoryArchive) Put(namespace, name string, r io.Reader) error {
+	data, err := readLog(r, a.MaxBytes)
+	if err != nil {
+		return err
+	}
+	dir := filepath.Join(a.Dir, namespace)
+	if err := os.MkdirAll(dir, 0700); err != nil {
+		return err
+	}
+	f, err := ioutil.TempFile(dir, "."+name)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(f, bytes.NewReader(data)); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	if err := os.Rename(f.Name(), a.path(namespace, name)); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return nil
+}
+
+// Get reads the archived log of the named build.
+func (a *DirectoryArchive) Get(namespace, name string) ([]byte, error) {
+	data, err := ioutil.ReadFile(a.path(namespace, name))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+	return data, err
+}
+
+// Delete removes the archived log of the named build.
+func (a *DirectoryArchive) Delete(namespace, name string) error {
+	if err := os.Remove(a.path(namespace, name)); err != nil && !os.IsNotExist(err) {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package logarchive
+
+import (
+	"io/ioutil"
+	"os"
+	"strings"
+	"testing"
+)
+
+func TestDirectoryArchive(t *testing.T) {
+	dir, err := ioutil.TempDir("", "buildlogs")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	archive := NewDirectoryArchive(dir, 0)
+	if _, err := archive.Get("test", "build-1"); err != ErrNotFound {
+		t.Fatalf("expected ErrNotFound, got %v", err)
+	}
+	if err := archive.Put("test", "build-1", strings.NewReader("step 1\nstep 2\n")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	data, err := archive.Get("test", "build-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != "step 1\nstep 2\n" {
+		t.Errorf("unexpected log: %q", string(data))
+	}
+	if _, err := archive.Get("other", "build-1"); err != ErrNotFound {
+		t.Errorf("expected logs to be stored per namespace, got %v", err)
+	}
+	if err := archive.Delete("test", "build-1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := archive.Get("test", "build-1"); err != ErrNotFound {
+		t.Errorf("expected ErrNotFound after delete, got %v", err)
+	}
+	if err := archive.Delete("test", "build-1"); err != nil {
+		t.Errorf("expected deleting a missing log to succeed, got %v", err)
+	}
+}
+
+func TestReadLogTruncates(t *testing.T) {
+	log := strings.Repeat("first\n", 10) + "last line\n"
+	data, err := readLog(strings.NewReader(log), 12)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := "[log truncated to the last 10 bytes]\nlast line\n"
+	if string(data) != expected {
+		t.Errorf("expected %q, got %q", expected, string(data))
+	}
+
+	data, err = readLog(strings.NewReader(log), int64(len(log)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if string(data) != log {
+		t.Errorf("expected log to be kept as is, got %q", string(data))
+	}
+}
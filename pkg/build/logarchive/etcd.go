@@ -0,0 +1,66 @@
+package logarchive
+
+import (
+	"io"
+	"path"
+
+	etcdclient "github.com/coreos/etcd/client"
+	"golang.org/x/net/context"
+)
+
+// EtcdArchive archives build logs as keys in etcd. Logs are stored below
+// Prefix, keyed by namespace and build name, and are limited to MaxBytes so
+// they do not put pressure on the cluster store.
+type EtcdArchive struct {
+	// Keys is the etcd client used to store the logs.
+	Keys etcdclient.KeysAPI
+	// Prefix is the key all archived logs are stored under.
+	Prefix string
+	// MaxBytes is the maximum size of an archived log.
+	MaxBytes int64
+}
+
+var _ Archive = &EtcdArchive{}
+
+// NewEtcdArchive returns an Archive storing build logs in etcd below prefix.
+func NewEtcdArchive(client etcdclient.Client, prefix string, maxBytes int64) *EtcdArchive {
+	return &EtcdArchive{
+		Keys:     etcdclient.NewKeysAPI(client),
+		Prefix:   prefix,
+		MaxBytes: maxBytes,
+	}
+}
+
+func (a *EtcdArchive) key(namespace, name string) string {
+	return path.Join(a.Prefix, namespace, name)
+}
+
+// Put stores the log of the named build in etcd.
+func (a *EtcdArchive) Put(namespace, name string, r io.Reader) error {
+	data, err := readLog(r, a.MaxBytes)
+	if err != nil {
+		return err
+	}
+	_, err = a.Keys.Set(context.TODO(), a.key(namespace, name), string(data), nil)
+	return err
+}
+
+// Get returns the log of the named build stored in etcd.
+func (a *EtcdArchive) Get(namespace, name string) ([]byte, error) {
+	resp, err := a.Keys.Get(context.TODO(), a.key(namespace, name), nil)
+	if err != nil {
+		if etcdclient.IsKeyNotFound(err) {
+			return nil, ErrNotFound
+		}
+		return nil, err
+	}
+	return []byte(resp.Node.Value), nil
+}
+
+// Delete removes the log of the named build from etcd.
+func (a *EtcdArchive) Delete(namespace, name string) error {
+	if _, err := a.Keys.Delete(context.TODO(), a.key(namespace, name), nil); err != nil && !etcdclient.IsKeyNotFound(err) {
+		return err
+	}
+	return nil
+}
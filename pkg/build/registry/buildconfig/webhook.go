@@ -7,6 +7,7 @@ import (
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/record"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/build/client"
@@ -15,10 +16,11 @@ import (
 )
 
 // NewWebHookREST returns the webhook handler wrapped in a rest.WebHook object.
-func NewWebHookREST(registry Registry, instantiator client.BuildConfigInstantiator, plugins map[string]webhook.Plugin) *rest.WebHook {
+func NewWebHookREST(registry Registry, instantiator client.BuildConfigInstantiator, recorder record.EventRecorder, plugins map[string]webhook.Plugin) *rest.WebHook {
 	hook := &WebHook{
 		registry:     registry,
 		instantiator: instantiator,
+		recorder:     recorder,
 		plugins:      plugins,
 	}
 	return rest.NewWebHook(hook, false)
@@ -27,6 +29,7 @@ func NewWebHookREST(registry Registry, instantiator client.BuildConfigInstantiat
 type WebHook struct {
 	registry     Registry
 	instantiator client.BuildConfigInstantiator
+	recorder     record.EventRecorder
 	plugins      map[string]webhook.Plugin
 }
 
@@ -50,10 +53,19 @@ func (w *WebHook) ServeHTTP(writer http.ResponseWriter, req *http.Request, ctx k
 		return errors.NewUnauthorized(fmt.Sprintf("the webhook %q for %q did not accept your secret", hookType, name))
 	}
 
-	revision, envvars, proceed, err := plugin.Extract(config, secret, "", req)
+	revision, envvars, pullRequest, proceed, err := plugin.Extract(config, secret, "", req)
+	if skipped, ok := err.(*webhook.SkippedError); ok {
+		writer.Header().Set("Content-Type", "text/plain; charset=utf-8")
+		writer.WriteHeader(http.StatusOK)
+		fmt.Fprintf(writer, "No build was started: %s\n", skipped.Reason)
+		return nil
+	}
 	switch err {
 	case webhook.ErrSecretMismatch, webhook.ErrHookNotEnabled:
 		return errors.NewUnauthorized(fmt.Sprintf("the webhook %q for %q did not accept your secret", hookType, name))
+	case webhook.ErrSignatureMismatch:
+		w.recorder.Eventf(config, kapi.EventTypeWarning, "WebHookSignatureMismatch", "Rejected %s webhook request: %v", hookType, err)
+		return errors.NewUnauthorized(fmt.Sprintf("the webhook %q for %q did not accept your payload signature", hookType, name))
 	case nil:
 	default:
 		return errors.NewInternalError(fmt.Errorf("hook failed: %v", err))
@@ -63,7 +75,7 @@ func (w *WebHook) ServeHTTP(writer http.ResponseWriter, req *http.Request, ctx k
 		return nil
 	}
 
-	buildTriggerCauses := generateBuildTriggerInfo(revision, hookType, secret)
+	buildTriggerCauses := generateBuildTriggerInfo(revision, pullRequest, hookType, secret)
 	request := &buildapi.BuildRequest{
 		TriggeredBy: buildTriggerCauses,
 		ObjectMeta:  kapi.ObjectMeta{Name: name},
@@ -76,7 +88,7 @@ func (w *WebHook) ServeHTTP(writer http.ResponseWriter, req *http.Request, ctx k
 	return nil
 }
 
-func generateBuildTriggerInfo(revision *buildapi.SourceRevision, hookType, secret string) (buildTriggerCauses []buildapi.BuildTriggerCause) {
+func generateBuildTriggerInfo(revision *buildapi.SourceRevision, pullRequest *buildapi.PullRequestCause, hookType, secret string) (buildTriggerCauses []buildapi.BuildTriggerCause) {
 	hiddenSecret := fmt.Sprintf("%s***", secret[:(len(secret)/2)])
 	switch {
 	case hookType == "generic":
@@ -93,6 +105,25 @@ func generateBuildTriggerInfo(revision *buildapi.SourceRevision, hookType, secre
 			buildapi.BuildTriggerCause{
 				Message: "GitHub WebHook",
 				GitHubWebHook: &buildapi.GitHubWebHookCause{
+					Revision:    revision,
+					Secret:      hiddenSecret,
+					PullRequest: pullRequest,
+				},
+			})
+	case hookType == "gitlab":
+		buildTriggerCauses = append(buildTriggerCauses,
+			buildapi.BuildTriggerCause{
+				Message: "GitLab WebHook",
+				GitLabWebHook: &buildapi.GitLabWebHookCause{
+					Revision: revision,
+					Secret:   hiddenSecret,
+				},
+			})
+	case hookType == "bitbucket":
+		buildTriggerCauses = append(buildTriggerCauses,
+			buildapi.BuildTriggerCause{
+				Message: "Bitbucket WebHook",
+				BitbucketWebHook: &buildapi.BitbucketWebHookCause{
 					Revision: revision,
 					Secret:   hiddenSecret,
 				},
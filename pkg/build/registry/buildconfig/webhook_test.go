@@ -12,6 +12,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/client/record"
 	"k8s.io/kubernetes/pkg/runtime"
 
 	"github.com/openshift/origin/pkg/build/api"
@@ -38,15 +39,15 @@ type plugin struct {
 	Env          []kapi.EnvVar
 }
 
-func (p *plugin) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (*api.SourceRevision, []kapi.EnvVar, bool, error) {
+func (p *plugin) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (*api.SourceRevision, []kapi.EnvVar, *api.PullRequestCause, bool, error) {
 	p.Secret, p.Path = secret, path
-	return nil, p.Env, true, p.Err
+	return nil, p.Env, nil, true, p.Err
 }
 
 func newStorage() (*rest.WebHook, *buildConfigInstantiator, *test.BuildConfigRegistry) {
 	mockRegistry := &test.BuildConfigRegistry{}
 	bci := &buildConfigInstantiator{}
-	hook := NewWebHookREST(mockRegistry, bci, map[string]webhook.Plugin{
+	hook := NewWebHookREST(mockRegistry, bci, &record.FakeRecorder{}, map[string]webhook.Plugin{
 		"ok": &plugin{},
 		"okenv": &plugin{
 			Env: []kapi.EnvVar{
@@ -58,6 +59,8 @@ func newStorage() (*rest.WebHook, *buildConfigInstantiator, *test.BuildConfigReg
 		},
 		"errsecret": &plugin{Err: webhook.ErrSecretMismatch},
 		"errhook":   &plugin{Err: webhook.ErrHookNotEnabled},
+		"errsig":    &plugin{Err: webhook.ErrSignatureMismatch},
+		"skipped":   &plugin{Err: webhook.NewSkippedError("no matching paths")},
 		"err":       &plugin{Err: fmt.Errorf("test error")},
 	})
 	return hook, bci, mockRegistry
@@ -128,6 +131,15 @@ func TestConnectWebHook(t *testing.T) {
 			ErrFn:       kerrors.IsUnauthorized,
 			Instantiate: false,
 		},
+		"hook returns unauthorized for bad signature": {
+			Name: "test",
+			Path: "secret/errsig",
+			Obj:  &api.BuildConfig{ObjectMeta: kapi.ObjectMeta{Name: "test", Namespace: "default"}},
+			ErrFn: func(err error) bool {
+				return kerrors.IsUnauthorized(err) && strings.Contains(err.Error(), "did not accept your payload signature")
+			},
+			Instantiate: false,
+		},
 		"hook returns unauthorized for missing build config": {
 			Name:        "test",
 			Path:        "secret/errhook",
@@ -146,6 +158,16 @@ func TestConnectWebHook(t *testing.T) {
 			},
 			Instantiate: true,
 		},
+		"hook returns 200 with the reason for skipped hook": {
+			Name:  "test",
+			Path:  "secret/skipped",
+			Obj:   &api.BuildConfig{ObjectMeta: kapi.ObjectMeta{Name: "test", Namespace: "default"}},
+			ErrFn: func(err error) bool { return err == nil },
+			WFn: func(w *httptest.ResponseRecorder) bool {
+				return w.Code == http.StatusOK && strings.Contains(w.Body.String(), "no matching paths")
+			},
+			Instantiate: false,
+		},
 		"hook returns 200 for okenv hook": {
 			Name:  "test",
 			Path:  "secret/okenv",
@@ -231,15 +253,15 @@ type pathPlugin struct {
 	Path string
 }
 
-func (p *pathPlugin) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (*api.SourceRevision, []kapi.EnvVar, bool, error) {
+func (p *pathPlugin) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (*api.SourceRevision, []kapi.EnvVar, *api.PullRequestCause, bool, error) {
 	p.Path = path
-	return nil, []kapi.EnvVar{}, true, nil
+	return nil, []kapi.EnvVar{}, nil, true, nil
 }
 
 type errPlugin struct{}
 
-func (*errPlugin) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (*api.SourceRevision, []kapi.EnvVar, bool, error) {
-	return nil, []kapi.EnvVar{}, true, errors.New("Plugin error!")
+func (*errPlugin) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (*api.SourceRevision, []kapi.EnvVar, *api.PullRequestCause, bool, error) {
+	return nil, []kapi.EnvVar{}, nil, true, errors.New("Plugin error!")
 }
 
 var testBuildConfig = &api.BuildConfig{
@@ -275,7 +297,7 @@ var mockBuildStrategy = api.BuildStrategy{
 func TestParseUrlError(t *testing.T) {
 	bcRegistry := &test.BuildConfigRegistry{BuildConfig: testBuildConfig}
 	responder := &fakeResponder{}
-	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, map[string]webhook.Plugin{"github": github.New()}).
+	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, &record.FakeRecorder{}, map[string]webhook.Plugin{"github": github.New()}).
 		Connect(kapi.NewDefaultContext(), "build100", &kapi.PodProxyOptions{Path: ""}, responder)
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -293,7 +315,7 @@ func TestParseUrlError(t *testing.T) {
 func TestParseUrlOK(t *testing.T) {
 	bcRegistry := &test.BuildConfigRegistry{BuildConfig: testBuildConfig}
 	responder := &fakeResponder{}
-	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, map[string]webhook.Plugin{"pathplugin": &pathPlugin{}}).
+	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, &record.FakeRecorder{}, map[string]webhook.Plugin{"pathplugin": &pathPlugin{}}).
 		Connect(kapi.NewDefaultContext(), "build100", &kapi.PodProxyOptions{Path: "secret101/pathplugin"}, responder)
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -311,7 +333,7 @@ func TestParseUrlLong(t *testing.T) {
 	plugin := &pathPlugin{}
 	bcRegistry := &test.BuildConfigRegistry{BuildConfig: testBuildConfig}
 	responder := &fakeResponder{}
-	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, map[string]webhook.Plugin{"pathplugin": plugin}).
+	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, &record.FakeRecorder{}, map[string]webhook.Plugin{"pathplugin": plugin}).
 		Connect(kapi.NewDefaultContext(), "build100", &kapi.PodProxyOptions{Path: "secret101/pathplugin/some/more/args"}, responder)
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -329,7 +351,7 @@ func TestParseUrlLong(t *testing.T) {
 func TestInvokeWebhookMissingPlugin(t *testing.T) {
 	bcRegistry := &test.BuildConfigRegistry{BuildConfig: testBuildConfig}
 	responder := &fakeResponder{}
-	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, map[string]webhook.Plugin{"pathplugin": &pathPlugin{}}).
+	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, &record.FakeRecorder{}, map[string]webhook.Plugin{"pathplugin": &pathPlugin{}}).
 		Connect(kapi.NewDefaultContext(), "build100", &kapi.PodProxyOptions{Path: "secret101/missingplugin"}, responder)
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -347,7 +369,7 @@ func TestInvokeWebhookMissingPlugin(t *testing.T) {
 func TestInvokeWebhookErrorBuildConfigInstantiate(t *testing.T) {
 	bcRegistry := &test.BuildConfigRegistry{BuildConfig: testBuildConfig}
 	responder := &fakeResponder{}
-	handler, _ := NewWebHookREST(bcRegistry, &errorBuildConfigInstantiator{}, map[string]webhook.Plugin{"pathplugin": &pathPlugin{}}).
+	handler, _ := NewWebHookREST(bcRegistry, &errorBuildConfigInstantiator{}, &record.FakeRecorder{}, map[string]webhook.Plugin{"pathplugin": &pathPlugin{}}).
 		Connect(kapi.NewDefaultContext(), "build100", &kapi.PodProxyOptions{Path: "secret101/pathplugin"}, responder)
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -365,7 +387,7 @@ func TestInvokeWebhookErrorBuildConfigInstantiate(t *testing.T) {
 func TestInvokeWebhookErrorGetConfig(t *testing.T) {
 	bcRegistry := &test.BuildConfigRegistry{BuildConfig: testBuildConfig}
 	responder := &fakeResponder{}
-	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, map[string]webhook.Plugin{"pathplugin": &pathPlugin{}}).
+	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, &record.FakeRecorder{}, map[string]webhook.Plugin{"pathplugin": &pathPlugin{}}).
 		Connect(kapi.NewDefaultContext(), "badbuild100", &kapi.PodProxyOptions{Path: "secret101/pathplugin"}, responder)
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -385,7 +407,7 @@ func TestInvokeWebhookErrorGetConfig(t *testing.T) {
 func TestInvokeWebhookErrorCreateBuild(t *testing.T) {
 	bcRegistry := &test.BuildConfigRegistry{BuildConfig: testBuildConfig}
 	responder := &fakeResponder{}
-	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, map[string]webhook.Plugin{"errPlugin": &errPlugin{}}).
+	handler, _ := NewWebHookREST(bcRegistry, &okBuildConfigInstantiator{}, &record.FakeRecorder{}, map[string]webhook.Plugin{"errPlugin": &errPlugin{}}).
 		Connect(kapi.NewDefaultContext(), "build100", &kapi.PodProxyOptions{Path: "secret101/errPlugin"}, responder)
 	server := httptest.NewServer(handler)
 	defer server.Close()
@@ -415,7 +437,7 @@ func TestGeneratedBuildTriggerInfoGenericWebHook(t *testing.T) {
 		},
 	}
 
-	buildtriggerCause := generateBuildTriggerInfo(revision, "generic", "mysecret")
+	buildtriggerCause := generateBuildTriggerInfo(revision, nil, "generic", "mysecret")
 	hiddenSecret := fmt.Sprintf("%s***", "mysecret"[:(len("mysecret")/2)])
 	for _, cause := range buildtriggerCause {
 		if !reflect.DeepEqual(revision, cause.GenericWebHook.Revision) {
@@ -445,7 +467,7 @@ func TestGeneratedBuildTriggerInfoGitHubWebHook(t *testing.T) {
 		},
 	}
 
-	buildtriggerCause := generateBuildTriggerInfo(revision, "github", "mysecret")
+	buildtriggerCause := generateBuildTriggerInfo(revision, nil, "github", "mysecret")
 	hiddenSecret := fmt.Sprintf("%s***", "mysecret"[:(len("mysecret")/2)])
 	for _, cause := range buildtriggerCause {
 		if !reflect.DeepEqual(revision, cause.GitHubWebHook.Revision) {
@@ -459,3 +481,89 @@ func TestGeneratedBuildTriggerInfoGitHubWebHook(t *testing.T) {
 		}
 	}
 }
+
+func TestGeneratedBuildTriggerInfoGitHubPullRequest(t *testing.T) {
+	revision := &api.SourceRevision{
+		Git: &api.GitSourceRevision{
+			Commit:  "9bdc3a26ff933b32f3e558636b58aea86a69f051",
+			Message: "Add a new feature",
+		},
+	}
+	pullRequest := &api.PullRequestCause{
+		Number:  42,
+		Title:   "Add a new feature",
+		HeadRef: "feature",
+	}
+
+	buildtriggerCause := generateBuildTriggerInfo(revision, pullRequest, "github", "mysecret")
+	if len(buildtriggerCause) != 1 {
+		t.Fatalf("Expected a single build trigger cause, got %d", len(buildtriggerCause))
+	}
+	cause := buildtriggerCause[0].GitHubWebHook
+	if !reflect.DeepEqual(revision, cause.Revision) {
+		t.Errorf("Expected returned revision to equal: %v", revision)
+	}
+	if !reflect.DeepEqual(pullRequest, cause.PullRequest) {
+		t.Errorf("Expected returned pull request to equal: %v, got %v", pullRequest, cause.PullRequest)
+	}
+}
+
+func TestGeneratedBuildTriggerInfoGitLabWebHook(t *testing.T) {
+	revision := &api.SourceRevision{
+		Git: &api.GitSourceRevision{
+			Author: api.SourceControlUser{
+				Name:  "John Doe",
+				Email: "john.doe@test.com",
+			},
+			Committer: api.SourceControlUser{
+				Name:  "John Doe",
+				Email: "john.doe@test.com",
+			},
+			Message: "A random act of kindness",
+		},
+	}
+
+	buildtriggerCause := generateBuildTriggerInfo(revision, nil, "gitlab", "mysecret")
+	hiddenSecret := fmt.Sprintf("%s***", "mysecret"[:(len("mysecret")/2)])
+	for _, cause := range buildtriggerCause {
+		if !reflect.DeepEqual(revision, cause.GitLabWebHook.Revision) {
+			t.Errorf("Expected returned revision to equal: %v", revision)
+		}
+		if cause.GitLabWebHook.Secret != hiddenSecret {
+			t.Errorf("Expected obfuscated secret to be: %s", hiddenSecret)
+		}
+		if cause.Message != "GitLab WebHook" {
+			t.Errorf("Expected build reason to be 'GitLab WebHook, go %s'", cause.Message)
+		}
+	}
+}
+
+func TestGeneratedBuildTriggerInfoBitbucketWebHook(t *testing.T) {
+	revision := &api.SourceRevision{
+		Git: &api.GitSourceRevision{
+			Author: api.SourceControlUser{
+				Name:  "John Doe",
+				Email: "john.doe@test.com",
+			},
+			Committer: api.SourceControlUser{
+				Name:  "John Doe",
+				Email: "john.doe@test.com",
+			},
+			Message: "A random act of kindness",
+		},
+	}
+
+	buildtriggerCause := generateBuildTriggerInfo(revision, nil, "bitbucket", "mysecret")
+	hiddenSecret := fmt.Sprintf("%s***", "mysecret"[:(len("mysecret")/2)])
+	for _, cause := range buildtriggerCause {
+		if !reflect.DeepEqual(revision, cause.BitbucketWebHook.Revision) {
+			t.Errorf("Expected returned revision to equal: %v", revision)
+		}
+		if cause.BitbucketWebHook.Secret != hiddenSecret {
+			t.Errorf("Expected obfuscated secret to be: %s", hiddenSecret)
+		}
+		if cause.Message != "Bitbucket WebHook" {
+			t.Errorf("Expected build reason to be 'Bitbucket WebHook, go %s'", cause.Message)
+		}
+	}
+}
@@ -1,7 +1,10 @@
 package buildlog
 
 import (
+	"bytes"
 	"fmt"
+	"io"
+	"io/ioutil"
 	"time"
 
 	"github.com/golang/glog"
@@ -9,6 +12,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/rest"
+	kunversioned "k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/client/unversioned"
 	kubeletclient "k8s.io/kubernetes/pkg/kubelet/client"
 	genericrest "k8s.io/kubernetes/pkg/registry/generic/rest"
@@ -17,6 +21,7 @@ import (
 
 	"github.com/openshift/origin/pkg/build/api"
 	"github.com/openshift/origin/pkg/build/api/validation"
+	"github.com/openshift/origin/pkg/build/logarchive"
 	"github.com/openshift/origin/pkg/build/registry"
 	buildutil "github.com/openshift/origin/pkg/build/util"
 )
@@ -28,6 +33,8 @@ type REST struct {
 	PodGetter      pod.ResourceGetter
 	ConnectionInfo kubeletclient.ConnectionInfoGetter
 	Timeout        time.Duration
+	// LogArchive, if set, serves the logs of completed builds whose pod was deleted.
+	LogArchive logarchive.Archive
 }
 
 type podGetter struct {
@@ -47,13 +54,15 @@ const defaultTimeout time.Duration = 10 * time.Second
 // NewREST creates a new REST for BuildLog
 // Takes build registry and pod client to get necessary attributes to assemble
 // URL to which the request shall be redirected in order to get build logs.
-func NewREST(getter rest.Getter, watcher rest.Watcher, pn unversioned.PodsNamespacer, connectionInfo kubeletclient.ConnectionInfoGetter) *REST {
+// The archive may be nil if the logs of completed builds are not archived.
+func NewREST(getter rest.Getter, watcher rest.Watcher, pn unversioned.PodsNamespacer, connectionInfo kubeletclient.ConnectionInfoGetter, archive logarchive.Archive) *REST {
 	return &REST{
 		Getter:         getter,
 		Watcher:        watcher,
 		PodGetter:      &podGetter{pn},
 		ConnectionInfo: connectionInfo,
 		Timeout:        defaultTimeout,
+		LogArchive:     archive,
 	}
 }
 
@@ -117,10 +126,29 @@ func (r *REST) Get(ctx kapi.Context, name string, opts runtime.Object) (runtime.
 	}
 	// The container should be the default build container, so setting it to blank
 	buildPodName := api.GetBuildPodName(build)
+	archiveName, complete := build.Name, buildutil.IsBuildComplete(build)
+	if len(build.Status.Stages) > 0 {
+		// Pipeline builds show the log of the requested stage, or of the latest one
+		stage := &build.Status.Stages[len(build.Status.Stages)-1]
+		if len(buildLogOpts.Stage) > 0 {
+			if stage = api.GetBuildStageStatus(build, buildLogOpts.Stage); stage == nil {
+				return nil, errors.NewBadRequest(fmt.Sprintf("stage %s of build %s has not started", buildLogOpts.Stage, build.Name))
+			}
+		}
+		buildPodName = stage.PodName
+		archiveName = logarchive.StageLogName(build.Name, stage.Name)
+		complete = stage.Phase == api.BuildPhaseComplete || stage.Phase == api.BuildPhaseFailed
+	} else if len(buildLogOpts.Stage) > 0 {
+		return nil, errors.NewBadRequest(fmt.Sprintf("build %s has no stages", build.Name))
+	}
 	logOpts := api.BuildToPodLogOptions(buildLogOpts)
 	location, transport, err := pod.LogLocation(r.PodGetter, r.ConnectionInfo, ctx, buildPodName, logOpts)
 	if err != nil {
 		if errors.IsNotFound(err) {
+			// The build pod was deleted, serve the log from the archive if it has one
+			if archived, ok := r.archivedLog(build, archiveName, complete, buildLogOpts); ok {
+				return archived, nil
+			}
 			return nil, errors.NewNotFound(kapi.Resource("pod"), buildPodName)
 		}
 		return nil, errors.NewBadRequest(err.Error())
@@ -134,6 +162,65 @@ func (r *REST) Get(ctx kapi.Context, name string, opts runtime.Object) (runtime.
 	}, nil
 }
 
+// archivedLog returns a streamer with the log of build archived under name, if
+// the build, or the stage the log belongs to, is complete.
+func (r *REST) archivedLog(build *api.Build, name string, complete bool, opts *api.BuildLogOptions) (runtime.Object, bool) {
+	if r.LogArchive == nil || !complete {
+		return nil, false
+	}
+	log, err := r.LogArchive.Get(build.Namespace, name)
+	if err != nil {
+		if err != logarchive.ErrNotFound {
+			glog.V(2).Infof("Unable to read the archived log %s of build %s/%s: %v", name, build.Namespace, build.Name, err)
+		}
+		return nil, false
+	}
+	if opts.TailLines != nil {
+		log = tailLines(log, *opts.TailLines)
+	}
+	if opts.LimitBytes != nil && int64(len(log)) > *opts.LimitBytes {
+		log = log[:*opts.LimitBytes]
+	}
+	return &ArchivedLogStreamer{Log: log}, true
+}
+
+// tailLines returns the last n lines of log.
+func tailLines(log []byte, n int64) []byte {
+	if n <= 0 {
+		return []byte{}
+	}
+	end := len(log)
+	if end > 0 && log[end-1] == '\n' {
+		end--
+	}
+	for i := end - 1; i >= 0; i-- {
+		if log[i] != '\n' {
+			continue
+		}
+		if n--; n <= 0 {
+			return log[i+1:]
+		}
+	}
+	return log
+}
+
+// ArchivedLogStreamer is a resource that streams a build log read from the
+// log archive.
+type ArchivedLogStreamer struct {
+	Log []byte
+}
+
+var _ rest.ResourceStreamer = &ArchivedLogStreamer{}
+
+func (obj *ArchivedLogStreamer) GetObjectKind() kunversioned.ObjectKind {
+	return kunversioned.EmptyObjectKind
+}
+
+// InputStream returns a stream with the archived log.
+func (obj *ArchivedLogStreamer) InputStream(apiVersion, acceptHeader string) (io.ReadCloser, bool, string, error) {
+	return ioutil.NopCloser(bytes.NewReader(obj.Log)), false, "text/plain", nil
+}
+
 // NewGetOptions returns a new options object for build logs
 func (r *REST) NewGetOptions() (runtime.Object, bool, string) {
 	return &api.BuildLogOptions{}, false, ""
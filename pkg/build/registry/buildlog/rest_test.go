@@ -2,6 +2,7 @@ package buildlog
 
 import (
 	"fmt"
+	"io"
 	"net/http"
 	"net/url"
 	"strconv"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/rest"
 	kubeletclient "k8s.io/kubernetes/pkg/kubelet/client"
 	genericrest "k8s.io/kubernetes/pkg/registry/generic/rest"
@@ -17,6 +19,7 @@ import (
 	"k8s.io/kubernetes/pkg/watch"
 
 	"github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/build/logarchive"
 	"github.com/openshift/origin/pkg/build/registry/test"
 )
 
@@ -315,3 +318,175 @@ func TestPreviousBuildLogs(t *testing.T) {
 		t.Fatalf("expected location:\n\t%s\ngot location:\n\t%s\n", exp, got)
 	}
 }
+
+type deletedPodGetter struct{}
+
+func (p *deletedPodGetter) Get(ctx kapi.Context, name string) (runtime.Object, error) {
+	return nil, errors.NewNotFound(kapi.Resource("pod"), name)
+}
+
+type testLogArchive struct {
+	logs map[string]string
+}
+
+func (a *testLogArchive) Put(namespace, name string, r io.Reader) error {
+	return nil
+}
+
+func (a *testLogArchive) Get(namespace, name string) ([]byte, error) {
+	log, ok := a.logs[namespace+"/"+name]
+	if !ok {
+		return nil, logarchive.ErrNotFound
+	}
+	return []byte(log), nil
+}
+
+func (a *testLogArchive) Delete(namespace, name string) error {
+	return nil
+}
+
+func TestArchivedBuildLogs(t *testing.T) {
+	ctx := kapi.NewDefaultContext()
+	archive := &testLogArchive{logs: map[string]string{
+		"default/archived":      "step 1\nstep 2\nstep 3\n",
+		"default/pipeline@test": "ok\n",
+	}}
+	pipelineStages := []api.BuildStageStatus{
+		{Name: "test", Phase: api.BuildPhaseComplete, PodName: "pipeline-test-stage"},
+		{Name: "publish", Phase: api.BuildPhaseRunning, PodName: "pipeline-publish-stage"},
+	}
+	tailLines, noLines := int64(2), int64(0)
+	limitBytes := int64(4)
+	tests := []struct {
+		name        string
+		build       string
+		phase       api.BuildPhase
+		stages      []api.BuildStageStatus
+		options     api.BuildLogOptions
+		archive     logarchive.Archive
+		expected    string
+		expectError bool
+	}{
+		{
+			name:     "completed build",
+			build:    "archived",
+			phase:    api.BuildPhaseComplete,
+			archive:  archive,
+			expected: "step 1\nstep 2\nstep 3\n",
+		},
+		{
+			name:     "tail lines",
+			build:    "archived",
+			phase:    api.BuildPhaseFailed,
+			options:  api.BuildLogOptions{TailLines: &tailLines},
+			archive:  archive,
+			expected: "step 2\nstep 3\n",
+		},
+		{
+			name:     "no tail lines",
+			build:    "archived",
+			phase:    api.BuildPhaseComplete,
+			options:  api.BuildLogOptions{TailLines: &noLines},
+			archive:  archive,
+			expected: "",
+		},
+		{
+			name:     "limit bytes",
+			build:    "archived",
+			phase:    api.BuildPhaseComplete,
+			options:  api.BuildLogOptions{LimitBytes: &limitBytes},
+			archive:  archive,
+			expected: "step",
+		},
+		{
+			name:        "running build",
+			build:       "archived",
+			phase:       api.BuildPhaseRunning,
+			archive:     archive,
+			expectError: true,
+		},
+		{
+			name:        "log not archived",
+			build:       "other",
+			phase:       api.BuildPhaseComplete,
+			archive:     archive,
+			expectError: true,
+		},
+		{
+			name:        "no archive",
+			build:       "archived",
+			phase:       api.BuildPhaseComplete,
+			expectError: true,
+		},
+		{
+			name:     "completed stage",
+			build:    "pipeline",
+			phase:    api.BuildPhaseRunning,
+			stages:   pipelineStages,
+			options:  api.BuildLogOptions{Stage: "test"},
+			archive:  archive,
+			expected: "ok\n",
+		},
+		{
+			name:        "running stage",
+			build:       "pipeline",
+			phase:       api.BuildPhaseRunning,
+			stages:      pipelineStages,
+			archive:     archive,
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		build := mockBuild(tt.phase, tt.build, 1)
+		build.Namespace = kapi.NamespaceDefault
+		build.Status.Stages = tt.stages
+		storage := &REST{
+			Getter:         &test.BuildStorage{Build: build},
+			PodGetter:      &deletedPodGetter{},
+			ConnectionInfo: &kubeletclient.HTTPKubeletClient{Config: &kubeletclient.KubeletClientConfig{EnableHttps: true, Port: 12345}, Client: &http.Client{}},
+			Timeout:        defaultTimeout,
+			LogArchive:     tt.archive,
+		}
+		obj, err := storage.Get(ctx, tt.build, &tt.options)
+		if tt.expectError {
+			if !errors.IsNotFound(err) {
+				t.Errorf("%s: expected a not found error, got %v", tt.name, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", tt.name, err)
+			continue
+		}
+		streamer, ok := obj.(*ArchivedLogStreamer)
+		if !ok {
+			t.Errorf("%s: unexpected object: %#v", tt.name, obj)
+			continue
+		}
+		if string(streamer.Log) != tt.expected {
+			t.Errorf("%s: expected log %q, got %q", tt.name, tt.expected, string(streamer.Log))
+		}
+	}
+}
+
+func TestTailLines(t *testing.T) {
+	tests := []struct {
+		log      string
+		n        int64
+		expected string
+	}{
+		{log: "a\nb\nc\n", n: 0, expected: ""},
+		{log: "a\nb\nc\n", n: -1, expected: ""},
+		{log: "a\nb\nc\n", n: 1, expected: "c\n"},
+		{log: "a\nb\nc", n: 2, expected: "b\nc"},
+		{log: "a\nb\nc\n", n: 3, expected: "a\nb\nc\n"},
+		{log: "a\nb\nc\n", n: 5, expected: "a\nb\nc\n"},
+		{log: "", n: 1, expected: ""},
+	}
+	for _, test := range tests {
+		if tail := string(tailLines([]byte(test.log), test.n)); tail != test.expected {
+			t.Errorf("last %d lines of %q: expected %q, got %q", test.n, test.log, test.expected, tail)
+		}
+	}
+}
@@ -1,12 +1,16 @@
 package util
 
 import (
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
 	"strconv"
 	"strings"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/labels"
+	kvalidation "k8s.io/kubernetes/pkg/util/validation"
 
 	"github.com/golang/glog"
 	buildapi "github.com/openshift/origin/pkg/build/api"
@@ -167,3 +171,136 @@ func VersionForBuild(build *buildapi.Build) int {
 	}
 	return version
 }
+
+// BuildMatrixEntries returns the entries of the given build matrix, one for
+// every combination of its images and environment variable sets, in the given
+// group.
+func BuildMatrixEntries(matrix *buildapi.BuildMatrix, group string) []buildapi.BuildMatrixEntry {
+	if matrix == nil {
+		return nil
+	}
+	images := []string{""}
+	if len(matrix.Images) > 0 {
+		images = []string{}
+		for _, image := range matrix.Images {
+			images = append(images, image.Name)
+		}
+	}
+	envs := []string{""}
+	if len(matrix.Env) > 0 {
+		envs = []string{}
+		for _, env := range matrix.Env {
+			envs = append(envs, env.Name)
+		}
+	}
+	entries := []buildapi.BuildMatrixEntry{}
+	for _, image := range images {
+		for _, env := range envs {
+			if len(image) == 0 && len(env) == 0 {
+				continue
+			}
+			entries = append(entries, buildapi.BuildMatrixEntry{Group: group, Image: image, Env: env})
+		}
+	}
+	return entries
+}
+
+// BuildMatrixEntryName returns the name of the build matrix entry, made of the
+// names of its image and environment variable set separated by a dot. The names
+// of a build matrix are unique DNS labels, so the dot keeps the entry names
+// unambiguous. Names longer than a label value are truncated and suffixed with
+// a hash of the full name.
+func BuildMatrixEntryName(entry *buildapi.BuildMatrixEntry) string {
+	names := []string{}
+	for _, name := range []string{entry.Image, entry.Env} {
+		if len(name) > 0 {
+			names = append(names, name)
+		}
+	}
+	name := strings.Join(names, ".")
+	if len(name) <= kvalidation.LabelValueMaxLength {
+		return name
+	}
+	hash := fnv.New32a()
+	hash.Write([]byte(name))
+	prefix := strings.TrimRight(name[:kvalidation.LabelValueMaxLength-9], "-.")
+	return fmt.Sprintf("%s-%08x", prefix, hash.Sum32())
+}
+
+// BuildMatrixPhase returns the aggregate phase of the builds of a build matrix
+// group. The group is running as long as one of its builds did not finish, and
+// it is only complete once all of its builds completed successfully.
+func BuildMatrixPhase(builds []buildapi.Build) buildapi.BuildPhase {
+	queued, failed, cancelled := 0, false, false
+	for _, build := range builds {
+		switch build.Status.Phase {
+		case buildapi.BuildPhaseNew:
+			queued++
+		case buildapi.BuildPhasePending, buildapi.BuildPhaseRunning:
+			return buildapi.BuildPhaseRunning
+		case buildapi.BuildPhaseFailed, buildapi.BuildPhaseError:
+			failed = true
+		case buildapi.BuildPhaseCancelled:
+			cancelled = true
+		}
+	}
+	switch {
+	case queued == len(builds):
+		return buildapi.BuildPhaseNew
+	case queued > 0:
+		return buildapi.BuildPhaseRunning
+	case failed:
+		return buildapi.BuildPhaseFailed
+	case cancelled:
+		return buildapi.BuildPhaseCancelled
+	}
+	return buildapi.BuildPhaseComplete
+}
+
+// resourceUsageMessage is the termination message of a build container
+// reporting the resources the build used.
+type resourceUsageMessage struct {
+	CPU         resource.Quantity `json:"cpu"`
+	Memory      resource.Quantity `json:"memory"`
+	OutOfMemory bool              `json:"outOfMemory,omitempty"`
+}
+
+// FormatResourceUsage returns the termination message a build container writes
+// to report the resources the build used.
+func FormatResourceUsage(usage *buildapi.BuildResourceUsage) (string, error) {
+	data, err := json.Marshal(resourceUsageMessage{CPU: usage.CPU, Memory: usage.Memory, OutOfMemory: usage.OutOfMemory})
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// ResourceUsageForPod returns the resource usage reported in the termination
+// message of the container of a completed build pod, or nil if the container
+// did not report it. A container killed for running out of memory reports the
+// memory limit of the container.
+func ResourceUsageForPod(pod *kapi.Pod) *buildapi.BuildResourceUsage {
+	for _, status := range pod.Status.ContainerStatuses {
+		if status.State.Terminated == nil {
+			continue
+		}
+		if status.State.Terminated.Reason == "OOMKilled" {
+			for _, container := range pod.Spec.Containers {
+				if limit, ok := container.Resources.Limits[kapi.ResourceMemory]; ok && container.Name == status.Name {
+					return &buildapi.BuildResourceUsage{Memory: limit, OutOfMemory: true}
+				}
+			}
+			continue
+		}
+		if len(status.State.Terminated.Message) == 0 {
+			continue
+		}
+		message := &resourceUsageMessage{}
+		if err := json.Unmarshal([]byte(status.State.Terminated.Message), message); err != nil {
+			glog.V(4).Infof("Ignoring termination message of container %s of pod %s/%s: %v", status.Name, pod.Namespace, pod.Name, err)
+			continue
+		}
+		return &buildapi.BuildResourceUsage{CPU: message.CPU, Memory: message.Memory, OutOfMemory: message.OutOfMemory}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package util
+
+import (
+	"strings"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	kvalidation "k8s.io/kubernetes/pkg/util/validation"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+)
+
+func TestBuildMatrixEntryName(t *testing.T) {
+	long := strings.Repeat("a", 40)
+	tests := []struct {
+		entry    buildapi.BuildMatrixEntry
+		expected string
+	}{
+		{entry: buildapi.BuildMatrixEntry{Image: "py35", Env: "debug"}, expected: "py35.debug"},
+		{entry: buildapi.BuildMatrixEntry{Image: "py35"}, expected: "py35"},
+		{entry: buildapi.BuildMatrixEntry{Env: "debug"}, expected: "debug"},
+		{entry: buildapi.BuildMatrixEntry{Image: "a-b", Env: "c"}, expected: "a-b.c"},
+		{entry: buildapi.BuildMatrixEntry{Image: "a", Env: "b-c"}, expected: "a.b-c"},
+	}
+	for _, test := range tests {
+		if name := BuildMatrixEntryName(&test.entry); name != test.expected {
+			t.Errorf("%#v: expected %q, got %q", test.entry, test.expected, name)
+		}
+	}
+
+	first := BuildMatrixEntryName(&buildapi.BuildMatrixEntry{Image: long, Env: long + "-x"})
+	second := BuildMatrixEntryName(&buildapi.BuildMatrixEntry{Image: long, Env: long + "-y"})
+	for _, name := range []string{first, second} {
+		if !kvalidation.IsValidLabelValue(name) {
+			t.Errorf("expected %q to be a valid label value", name)
+		}
+	}
+	if first == second {
+		t.Errorf("expected distinct names for distinct entries, got %q", first)
+	}
+}
+
+func TestResourceUsageForOutOfMemoryPod(t *testing.T) {
+	pod := &kapi.Pod{
+		Spec: kapi.PodSpec{Containers: []kapi.Container{{
+			Name:      "sti-build",
+			Resources: kapi.ResourceRequirements{Limits: kapi.ResourceList{kapi.ResourceMemory: resource.MustParse("1Gi")}},
+		}}},
+		Status: kapi.PodStatus{ContainerStatuses: []kapi.ContainerStatus{{
+			Name:  "sti-build",
+			State: kapi.ContainerState{Terminated: &kapi.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+		}}},
+	}
+	usage := ResourceUsageForPod(pod)
+	if usage == nil || !usage.OutOfMemory || usage.Memory.String() != "1Gi" {
+		t.Errorf("expected the memory limit to be reported as out of memory, got %#v", usage)
+	}
+}
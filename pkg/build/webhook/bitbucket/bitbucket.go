@@ -0,0 +1,146 @@
+package bitbucket
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+	"net/mail"
+	"strings"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	"github.com/golang/glog"
+	"github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/build/webhook"
+)
+
+var (
+	ErrNoBitbucketEvent = errors.New("missing X-Event-Key")
+)
+
+const pushEventKey = "repo:push"
+
+// WebHook used for processing bitbucket webhook requests.
+type WebHook struct{}
+
+// New returns bitbucket webhook plugin.
+func New() *WebHook {
+	return &WebHook{}
+}
+
+type author struct {
+	// Raw is the author as recorded in the commit, e.g. "Name <email>".
+	Raw  string `json:"raw,omitempty"`
+	User struct {
+		DisplayName string `json:"display_name,omitempty"`
+	} `json:"user,omitempty"`
+}
+
+type commit struct {
+	Hash    string `json:"hash,omitempty"`
+	Message string `json:"message,omitempty"`
+	Author  author `json:"author,omitempty"`
+}
+
+type reference struct {
+	Type   string `json:"type,omitempty"`
+	Name   string `json:"name,omitempty"`
+	Target commit `json:"target,omitempty"`
+}
+
+type change struct {
+	// New is nil when the push deleted the reference.
+	New *reference `json:"new,omitempty"`
+}
+
+type pushEvent struct {
+	Push struct {
+		Changes []change `json:"changes,omitempty"`
+	} `json:"push,omitempty"`
+}
+
+// Extract services webhooks from bitbucket.org
+func (p *WebHook) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (revision *api.SourceRevision, envvars []kapi.EnvVar, pullRequest *api.PullRequestCause, proceed bool, err error) {
+	triggers, err := webhook.FindTriggerPolicy(api.BitbucketWebHookBuildTriggerType, buildCfg)
+	if err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+	glog.V(4).Infof("Checking if the provided secret for BuildConfig %s/%s matches", buildCfg.Namespace, buildCfg.Name)
+
+	if _, err = webhook.ValidateWebHookSecret(triggers, secret); err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+
+	glog.V(4).Infof("Verifying build request for BuildConfig %s/%s", buildCfg.Namespace, buildCfg.Name)
+	if err = verifyRequest(req); err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+	method := req.Header.Get("X-Event-Key")
+	if method != pushEventKey {
+		return revision, envvars, pullRequest, proceed, fmt.Errorf("Unknown X-Event-Key %s", method)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+	var event pushEvent
+	if err = json.Unmarshal(body, &event); err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+
+	// a single push may update several branches, build from the first one
+	// that matches the configuration
+	for _, c := range event.Push.Changes {
+		if c.New == nil || c.New.Type != "branch" {
+			continue
+		}
+		if !webhook.GitRefMatches(c.New.Name, webhook.DefaultConfigRef, &buildCfg.Spec.Source) {
+			continue
+		}
+		user := sourceControlUser(c.New.Target.Author)
+		revision = &api.SourceRevision{
+			Git: &api.GitSourceRevision{
+				Commit:    c.New.Target.Hash,
+				Author:    user,
+				Committer: user,
+				Message:   strings.TrimSpace(c.New.Target.Message),
+			},
+		}
+		return revision, envvars, pullRequest, true, nil
+	}
+	glog.V(2).Infof("Skipping build for BuildConfig %s/%s.  None of the pushed branches match configuration", buildCfg.Namespace, buildCfg.Name)
+	return revision, envvars, pullRequest, proceed, nil
+}
+
+// sourceControlUser converts a Bitbucket commit author into a
+// SourceControlUser, preferring the name and email recorded in the commit.
+func sourceControlUser(a author) api.SourceControlUser {
+	if addr, err := mail.ParseAddress(a.Raw); err == nil {
+		return api.SourceControlUser{Name: addr.Name, Email: addr.Address}
+	}
+	if len(a.User.DisplayName) != 0 {
+		return api.SourceControlUser{Name: a.User.DisplayName}
+	}
+	return api.SourceControlUser{Name: a.Raw}
+}
+
+func verifyRequest(req *http.Request) error {
+	if method := req.Method; method != "POST" {
+		return fmt.Errorf("unsupported HTTP method %s", method)
+	}
+	contentType := req.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("non-parseable Content-Type %s (%s)", contentType, err)
+	}
+	if mediaType != "application/json" {
+		return fmt.Errorf("unsupported Content-Type %s", contentType)
+	}
+	if len(req.Header.Get("X-Event-Key")) == 0 {
+		return ErrNoBitbucketEvent
+	}
+	return nil
+}
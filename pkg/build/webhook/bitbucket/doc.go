@@ -0,0 +1,3 @@
+// Package bitbucket contains webhook.Plugin implementation of bitbucket webhooks
+// according to https://confluence.atlassian.com/bitbucket/event-payloads-740262817.html
+package bitbucket
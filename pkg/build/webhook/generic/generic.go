@@ -24,80 +24,96 @@ func New() *WebHookPlugin {
 }
 
 // Extract services generic webhooks.
-func (p *WebHookPlugin) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (revision *api.SourceRevision, envvars []kapi.EnvVar, proceed bool, err error) {
+func (p *WebHookPlugin) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (revision *api.SourceRevision, envvars []kapi.EnvVar, pullRequest *api.PullRequestCause, proceed bool, err error) {
 	triggers, err := webhook.FindTriggerPolicy(api.GenericWebHookBuildTriggerType, buildCfg)
 	if err != nil {
-		return revision, envvars, false, err
+		return revision, envvars, nil, false, err
 	}
 	glog.V(4).Infof("Checking if the provided secret for BuildConfig %s/%s matches", buildCfg.Namespace, buildCfg.Name)
 
 	trigger, err := webhook.ValidateWebHookSecret(triggers, secret)
 	if err != nil {
-		return revision, envvars, false, err
+		return revision, envvars, nil, false, err
 	}
 
 	glog.V(4).Infof("Verifying build request for BuildConfig %s/%s", buildCfg.Namespace, buildCfg.Name)
 	if err = verifyRequest(req); err != nil {
-		return revision, envvars, false, err
+		return revision, envvars, nil, false, err
 	}
 
 	if buildCfg.Spec.Source.Git == nil {
 		glog.V(4).Infof("No git source defined for BuildConfig %s/%s, but triggering anyway", buildCfg.Namespace, buildCfg.Name)
-		return revision, envvars, true, err
+		return revision, envvars, nil, true, err
 	}
 
 	contentType := req.Header.Get("Content-Type")
 	if len(contentType) != 0 {
 		contentType, _, err = mime.ParseMediaType(contentType)
 		if err != nil {
-			return nil, envvars, false, fmt.Errorf("non-parseable Content-Type %s (%s)", contentType, err)
+			return nil, envvars, nil, false, fmt.Errorf("non-parseable Content-Type %s (%s)", contentType, err)
 		}
 	}
 
 	if req.Body != nil && contentType == "application/json" {
 		body, err := ioutil.ReadAll(req.Body)
 		if err != nil {
-			return nil, envvars, false, err
+			return nil, envvars, nil, false, err
 		}
 
 		if len(body) == 0 {
-			return nil, envvars, true, nil
+			return nil, envvars, nil, true, nil
 		}
 
 		var data api.GenericWebHookEvent
 		if err = json.Unmarshal(body, &data); err != nil {
 			glog.V(4).Infof("Error unmarshaling json %v, but continuing", err)
-			return nil, envvars, true, nil
+			return nil, envvars, nil, true, nil
 		}
 		if len(data.Env) > 0 && trigger.AllowEnv {
 			envvars = data.Env
 		}
 		if data.Git == nil {
 			glog.V(4).Infof("No git information for the generic webhook found in %s/%s", buildCfg.Namespace, buildCfg.Name)
-			return nil, envvars, true, nil
+			return nil, envvars, nil, true, nil
 		}
 
 		if data.Git.Refs != nil {
 			for _, ref := range data.Git.Refs {
 				if webhook.GitRefMatches(ref.Ref, webhook.DefaultConfigRef, &buildCfg.Spec.Source) {
+					if err := checkChangedPaths(buildCfg, trigger, data.Git.ChangedPaths); err != nil {
+						return nil, envvars, nil, false, err
+					}
 					revision = &api.SourceRevision{
 						Git: &ref.GitSourceRevision,
 					}
-					return revision, envvars, true, nil
+					return revision, envvars, nil, true, nil
 				}
 			}
-			glog.V(2).Infof("Skipping build for BuildConfig %s/%s. None of the supplied refs matched %q", buildCfg.Namespace, buildCfg, buildCfg.Spec.Source.Git.Ref)
-			return nil, envvars, false, nil
+			glog.V(2).Infof("Skipping build for BuildConfig %s/%s. None of the supplied refs matched %q", buildCfg.Namespace, buildCfg.Name, buildCfg.Spec.Source.Git.Ref)
+			return nil, envvars, nil, false, nil
 		}
 		if !webhook.GitRefMatches(data.Git.Ref, webhook.DefaultConfigRef, &buildCfg.Spec.Source) {
 			glog.V(2).Infof("Skipping build for BuildConfig %s/%s. Branch reference from %q does not match configuration", buildCfg.Namespace, buildCfg.Name, data.Git.Ref)
-			return nil, envvars, false, nil
+			return nil, envvars, nil, false, nil
+		}
+		if err := checkChangedPaths(buildCfg, trigger, data.Git.ChangedPaths); err != nil {
+			return nil, envvars, nil, false, err
 		}
 		revision = &api.SourceRevision{
 			Git: &data.Git.GitSourceRevision,
 		}
 	}
-	return revision, envvars, true, nil
+	return revision, envvars, nil, true, nil
+}
+
+// checkChangedPaths returns a SkippedError if none of the changed paths sent
+// with the event match the path filters of the trigger.
+func checkChangedPaths(buildCfg *api.BuildConfig, trigger *api.WebHookTrigger, changedPaths []string) error {
+	if webhook.ChangedPathsMatch(trigger, changedPaths) {
+		return nil
+	}
+	glog.V(2).Infof("Skipping build for BuildConfig %s/%s. None of the changed paths match the path filters", buildCfg.Namespace, buildCfg.Name)
+	return webhook.NewSkippedError("none of the paths changed by the push match the path filters of the webhook")
 }
 
 func verifyRequest(req *http.Request) error {
@@ -67,7 +67,7 @@ func TestVerifyRequestForMethod(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err == nil || !strings.Contains(err.Error(), "Unsupported HTTP method") {
 		t.Errorf("Expected unsupported HTTP method, got %v!", err)
@@ -108,7 +108,7 @@ func TestWrongSecretMultipleGenericWebHooks(t *testing.T) {
 	}
 
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "wrongsecret", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "wrongsecret", "", req)
 
 	if err != webhook.ErrSecretMismatch {
 		t.Errorf("Expected %s, got %s", webhook.ErrSecretMismatch, err)
@@ -159,7 +159,7 @@ func TestMatchSecretMultipleGenericWebHooks(t *testing.T) {
 	}
 
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret102", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret102", "", req)
 
 	if err != nil {
 		t.Errorf("Expected no error, got %s", err)
@@ -211,7 +211,7 @@ func TestEnvVarsMultipleGenericWebHooks(t *testing.T) {
 	}
 
 	plugin := New()
-	revision, envvars, proceed, err := plugin.Extract(buildConfig, "secret101", "", req)
+	revision, envvars, _, proceed, err := plugin.Extract(buildConfig, "secret101", "", req)
 
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
@@ -246,7 +246,7 @@ func TestWrongSecret(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "wrongsecret", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "wrongsecret", "", req)
 
 	if err != webhook.ErrSecretMismatch {
 		t.Errorf("Expected %v, got %v!", webhook.ErrSecretMismatch, err)
@@ -290,7 +290,7 @@ func TestExtractWithEmptyPayload(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
 	}
@@ -325,7 +325,7 @@ func TestExtractWithUnmatchedRefGitPayload(t *testing.T) {
 		},
 	}
 	plugin := New()
-	build, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	build, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Unexpected error when triggering build: %v", err)
@@ -361,7 +361,7 @@ func TestExtractWithGitPayload(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
@@ -397,7 +397,7 @@ func TestExtractWithGitPayloadAndUTF8Charset(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
@@ -433,7 +433,7 @@ func TestExtractWithGitRefsPayload(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
@@ -469,7 +469,7 @@ func TestExtractWithUnmatchedGitRefsPayload(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
@@ -506,7 +506,7 @@ func TestExtractWithKeyValuePairs(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, envvars, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, envvars, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
@@ -546,7 +546,7 @@ func TestExtractWithKeyValuePairsDisabled(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, envvars, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, envvars, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
@@ -584,7 +584,7 @@ func TestGitlabPush(t *testing.T) {
 		},
 	}
 	plugin := New()
-	_, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	_, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
@@ -616,7 +616,7 @@ func TestNonJsonPush(t *testing.T) {
 		},
 	}
 	plugin := New()
-	_, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	_, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
@@ -657,7 +657,7 @@ func TestExtractWithUnmarshalError(t *testing.T) {
 		},
 	}
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 	if err != nil {
 		t.Errorf("Expected to be able to trigger a build without a payload error: %v", err)
 	}
@@ -668,3 +668,56 @@ func TestExtractWithUnmarshalError(t *testing.T) {
 		t.Error("Expected the 'revision' return value to be nil")
 	}
 }
+
+func TestExtractWithPathFilters(t *testing.T) {
+	tests := map[string]struct {
+		include, exclude []string
+		proceed          bool
+	}{
+		"changed path included": {
+			include: []string{"services/web"},
+			proceed: true,
+		},
+		"changed path not included": {
+			include: []string{"services/api"},
+		},
+		"all changed paths excluded": {
+			exclude: []string{"services/*/*.html", "docs"},
+		},
+	}
+	for name, test := range tests {
+		req := GivenRequestWithPayload(t, "push-generic-paths.json")
+		buildConfig := &api.BuildConfig{
+			Spec: api.BuildConfigSpec{
+				Triggers: []api.BuildTriggerPolicy{
+					{
+						Type: api.GenericWebHookBuildTriggerType,
+						GenericWebHook: &api.WebHookTrigger{
+							Secret:       "secret100",
+							IncludePaths: test.include,
+							ExcludePaths: test.exclude,
+						},
+					},
+				},
+				CommonSpec: api.CommonSpec{
+					Source: api.BuildSource{
+						Git: &api.GitBuildSource{
+							Ref: "master",
+						},
+					},
+					Strategy: mockBuildStrategy,
+				},
+			},
+		}
+		revision, _, _, proceed, err := New().Extract(buildConfig, "secret100", "", req)
+		if proceed != test.proceed {
+			t.Errorf("%s: expected proceed to be %t", name, test.proceed)
+		}
+		if test.proceed && (err != nil || revision == nil) {
+			t.Errorf("%s: expected a revision, got error %v", name, err)
+		}
+		if _, skipped := err.(*webhook.SkippedError); !test.proceed && !skipped {
+			t.Errorf("%s: expected a SkippedError, got %v", name, err)
+		}
+	}
+}
@@ -1,12 +1,18 @@
 package github
 
 import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"hash"
 	"io/ioutil"
 	"mime"
 	"net/http"
+	"strings"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 
@@ -32,48 +38,118 @@ type commit struct {
 	Author    api.SourceControlUser `json:"author,omitempty"`
 	Committer api.SourceControlUser `json:"committer,omitempty"`
 	Message   string                `json:"message,omitempty"`
+	Added     []string              `json:"added,omitempty"`
+	Removed   []string              `json:"removed,omitempty"`
+	Modified  []string              `json:"modified,omitempty"`
 }
 
 type pushEvent struct {
-	Ref        string `json:"ref,omitempty"`
-	After      string `json:"after,omitempty"`
-	HeadCommit commit `json:"head_commit,omitempty"`
+	Ref        string   `json:"ref,omitempty"`
+	After      string   `json:"after,omitempty"`
+	Commits    []commit `json:"commits,omitempty"`
+	HeadCommit commit   `json:"head_commit,omitempty"`
+}
+
+// maxPushEventCommits is the number of commits push events list at most, the
+// others being left out of the payload.
+const maxPushEventCommits = 20
+
+// changedPaths returns the paths added, removed or modified by the commits of
+// the push, or nil if the push may have more commits than the event lists.
+func (e *pushEvent) changedPaths() []string {
+	if len(e.Commits) >= maxPushEventCommits {
+		return nil
+	}
+	var paths []string
+	for _, c := range e.Commits {
+		paths = append(paths, c.Added...)
+		paths = append(paths, c.Removed...)
+		paths = append(paths, c.Modified...)
+	}
+	return paths
+}
+
+type user struct {
+	Login string `json:"login,omitempty"`
+}
+
+type repository struct {
+	FullName string `json:"full_name,omitempty"`
+}
+
+type pullRequestBranch struct {
+	Ref  string      `json:"ref,omitempty"`
+	SHA  string      `json:"sha,omitempty"`
+	Repo *repository `json:"repo,omitempty"`
+}
+
+type pullRequest struct {
+	Title string            `json:"title,omitempty"`
+	User  user              `json:"user,omitempty"`
+	Head  pullRequestBranch `json:"head,omitempty"`
+	Base  pullRequestBranch `json:"base,omitempty"`
+}
+
+type pullRequestEvent struct {
+	Action      string      `json:"action,omitempty"`
+	Number      int64       `json:"number,omitempty"`
+	PullRequest pullRequest `json:"pull_request,omitempty"`
 }
 
 // Extract services webhooks from github.com
-func (p *WebHook) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (revision *api.SourceRevision, envvars []kapi.EnvVar, proceed bool, err error) {
+func (p *WebHook) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (revision *api.SourceRevision, envvars []kapi.EnvVar, pullRequest *api.PullRequestCause, proceed bool, err error) {
 	triggers, err := webhook.FindTriggerPolicy(api.GitHubWebHookBuildTriggerType, buildCfg)
 	if err != nil {
-		return revision, envvars, proceed, err
+		return revision, envvars, pullRequest, proceed, err
 	}
 	glog.V(4).Infof("Checking if the provided secret for BuildConfig %s/%s matches", buildCfg.Namespace, buildCfg.Name)
 
-	if _, err = webhook.ValidateWebHookSecret(triggers, secret); err != nil {
-		return revision, envvars, proceed, err
+	trigger, err := webhook.ValidateWebHookSecret(triggers, secret)
+	if err != nil {
+		return revision, envvars, pullRequest, proceed, err
 	}
 
 	glog.V(4).Infof("Verifying build request for BuildConfig %s/%s", buildCfg.Namespace, buildCfg.Name)
 	if err = verifyRequest(req); err != nil {
-		return revision, envvars, proceed, err
+		return revision, envvars, pullRequest, proceed, err
 	}
 	method := getEvent(req.Header)
-	if method != "ping" && method != "push" {
-		return revision, envvars, proceed, fmt.Errorf("Unknown X-GitHub-Event or X-Gogs-Event %s", method)
-	}
-	if method == "ping" {
-		return revision, envvars, proceed, err
+	if method != "ping" && method != "push" && method != "pull_request" {
+		return revision, envvars, pullRequest, proceed, fmt.Errorf("Unknown X-GitHub-Event or X-Gogs-Event %s", method)
 	}
 	body, err := ioutil.ReadAll(req.Body)
 	if err != nil {
-		return revision, envvars, proceed, err
+		return revision, envvars, pullRequest, proceed, err
+	}
+	if trigger.RequireSignature {
+		glog.V(4).Infof("Verifying payload signature for BuildConfig %s/%s", buildCfg.Namespace, buildCfg.Name)
+		if err = verifySignature(req.Header, body, trigger.Secret); err != nil {
+			return revision, envvars, pullRequest, proceed, err
+		}
+	}
+	if method == "ping" {
+		return revision, envvars, pullRequest, proceed, err
+	}
+	if method == "pull_request" {
+		return extractPullRequest(buildCfg, trigger, body)
 	}
 	var event pushEvent
 	if err = json.Unmarshal(body, &event); err != nil {
-		return revision, envvars, proceed, err
+		return revision, envvars, pullRequest, proceed, err
 	}
 	if !webhook.GitRefMatches(event.Ref, webhook.DefaultConfigRef, &buildCfg.Spec.Source) {
-		glog.V(2).Infof("Skipping build for BuildConfig %s/%s.  Branch reference from '%s' does not match configuration", buildCfg.Namespace, buildCfg, event)
-		return revision, envvars, proceed, err
+		glog.V(2).Infof("Skipping build for BuildConfig %s/%s.  Branch reference from '%s' does not match configuration", buildCfg.Namespace, buildCfg.Name, event.Ref)
+		return revision, envvars, pullRequest, proceed, err
+	}
+	// the paths changed by the commits left out of the event are unknown, so
+	// the build is started as for pushes which don't report their changes
+	changedPaths := event.changedPaths()
+	if changedPaths == nil && len(event.Commits) > 0 {
+		glog.V(4).Infof("Not filtering the paths changed by the push for BuildConfig %s/%s.  The event lists %d commits and may leave some out", buildCfg.Namespace, buildCfg.Name, len(event.Commits))
+	}
+	if !webhook.ChangedPathsMatch(trigger, changedPaths) {
+		glog.V(2).Infof("Skipping build for BuildConfig %s/%s.  None of the changed paths match the path filters", buildCfg.Namespace, buildCfg.Name)
+		return revision, envvars, pullRequest, proceed, webhook.NewSkippedError("none of the paths changed by the push match the path filters of the webhook")
 	}
 
 	revision = &api.SourceRevision{
@@ -84,7 +160,46 @@ func (p *WebHook) Extract(buildCfg *api.BuildConfig, secret, path string, req *h
 			Message:   event.HeadCommit.Message,
 		},
 	}
-	return revision, envvars, true, err
+	return revision, envvars, pullRequest, true, err
+}
+
+// extractPullRequest builds the head of a pull request that was opened or
+// synchronized against the ref of the build configuration, provided the
+// trigger allows pull request builds.
+func extractPullRequest(buildCfg *api.BuildConfig, trigger *api.WebHookTrigger, body []byte) (revision *api.SourceRevision, envvars []kapi.EnvVar, pullRequest *api.PullRequestCause, proceed bool, err error) {
+	if !trigger.BuildPullRequests {
+		glog.V(2).Infof("Skipping build for BuildConfig %s/%s.  Pull request builds are not enabled", buildCfg.Namespace, buildCfg.Name)
+		return revision, envvars, pullRequest, proceed, err
+	}
+	var event pullRequestEvent
+	if err = json.Unmarshal(body, &event); err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+	if event.Action != "opened" && event.Action != "synchronize" {
+		glog.V(4).Infof("Skipping build for BuildConfig %s/%s.  Pull request action %q does not require a build", buildCfg.Namespace, buildCfg.Name, event.Action)
+		return revision, envvars, pullRequest, proceed, err
+	}
+	if !webhook.GitRefMatches(event.PullRequest.Base.Ref, webhook.DefaultConfigRef, &buildCfg.Spec.Source) {
+		glog.V(2).Infof("Skipping build for BuildConfig %s/%s.  Pull request base '%s' does not match configuration", buildCfg.Namespace, buildCfg.Name, event.PullRequest.Base.Ref)
+		return revision, envvars, pullRequest, proceed, err
+	}
+
+	revision = &api.SourceRevision{
+		Git: &api.GitSourceRevision{
+			Commit:  event.PullRequest.Head.SHA,
+			Author:  api.SourceControlUser{Name: event.PullRequest.User.Login},
+			Message: event.PullRequest.Title,
+		},
+	}
+	// the repository of the head is missing when the fork was deleted
+	head, base := event.PullRequest.Head.Repo, event.PullRequest.Base.Repo
+	pullRequest = &api.PullRequestCause{
+		Number:  event.Number,
+		Title:   event.PullRequest.Title,
+		HeadRef: event.PullRequest.Head.Ref,
+		Fork:    head == nil || base == nil || head.FullName != base.FullName,
+	}
+	return revision, envvars, pullRequest, true, err
 }
 
 func verifyRequest(req *http.Request) error {
@@ -105,6 +220,36 @@ func verifyRequest(req *http.Request) error {
 	return nil
 }
 
+// verifySignature checks that the request carries an HMAC of the body keyed
+// by secret, as sent by GitHub in X-Hub-Signature-256 or X-Hub-Signature and
+// by Gogs in X-Gogs-Signature.
+func verifySignature(header http.Header, body []byte, secret string) error {
+	var (
+		algorithm func() hash.Hash
+		signature string
+	)
+	switch {
+	case len(header.Get("X-Hub-Signature-256")) != 0:
+		algorithm, signature = sha256.New, strings.TrimPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	case len(header.Get("X-Hub-Signature")) != 0:
+		algorithm, signature = sha1.New, strings.TrimPrefix(header.Get("X-Hub-Signature"), "sha1=")
+	case len(header.Get("X-Gogs-Signature")) != 0:
+		algorithm, signature = sha256.New, header.Get("X-Gogs-Signature")
+	default:
+		return webhook.ErrSignatureMismatch
+	}
+	expected, err := hex.DecodeString(signature)
+	if err != nil {
+		return webhook.ErrSignatureMismatch
+	}
+	mac := hmac.New(algorithm, []byte(secret))
+	mac.Write(body)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return webhook.ErrSignatureMismatch
+	}
+	return nil
+}
+
 func getEvent(header http.Header) string {
 	event := header.Get("X-GitHub-Event")
 	if len(event) == 0 {
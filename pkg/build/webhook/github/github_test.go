@@ -2,8 +2,15 @@ package github
 
 import (
 	"bytes"
+	"crypto/hmac"
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"hash"
 	"io/ioutil"
 	"net/http"
+	"reflect"
 	"strings"
 	"testing"
 
@@ -112,7 +119,7 @@ func GivenRequest(method string) *http.Request {
 func TestVerifyRequestForMethod(t *testing.T) {
 	req := GivenRequest("GET")
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err == nil || !strings.Contains(err.Error(), "unsupported HTTP method") {
 		t.Errorf("Expected unsupported HTTP method, got %v", err)
@@ -128,7 +135,7 @@ func TestVerifyRequestForMethod(t *testing.T) {
 func TestWrongSecret(t *testing.T) {
 	req := GivenRequest("POST")
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "wrongsecret", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "wrongsecret", "", req)
 
 	if err != webhook.ErrSecretMismatch {
 		t.Errorf("Expected %v, got %v", webhook.ErrSecretMismatch, err)
@@ -145,7 +152,7 @@ func TestMissingEvent(t *testing.T) {
 	req := GivenRequest("POST")
 	req.Header.Add("Content-Type", "application/json")
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err == nil || !strings.Contains(err.Error(), "missing X-GitHub-Event or X-Gogs-Event") {
 		t.Errorf("Expected missing X-GitHub-Event or X-Gogs-Event, got %v", err)
@@ -163,7 +170,7 @@ func TestWrongGitHubEvent(t *testing.T) {
 	req.Header.Add("Content-Type", "application/json")
 	req.Header.Add("X-GitHub-Event", "wrong")
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err == nil || !strings.Contains(err.Error(), "Unknown X-GitHub-Event or X-Gogs-Event") {
 		t.Errorf("Expected missing Unknown X-GitHub-Event or X-Gogs-Event, got %v", err)
@@ -179,7 +186,7 @@ func TestWrongGitHubEvent(t *testing.T) {
 func TestJsonPingEvent(t *testing.T) {
 	req := postFile("X-GitHub-Event", "ping", "pingevent.json", "http://some.url", http.StatusOK, t)
 	plugin := New()
-	_, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	_, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
@@ -192,7 +199,7 @@ func TestJsonPingEvent(t *testing.T) {
 func TestJsonPushEventError(t *testing.T) {
 	req := post("X-GitHub-Event", "push", []byte{}, "http://some.url", http.StatusBadRequest, t)
 	plugin := New()
-	revision, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	revision, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err == nil || !strings.Contains(err.Error(), "unexpected end of JSON input") {
 		t.Errorf("Expected unexpected end of JSON input, got %v", err)
@@ -208,7 +215,7 @@ func TestJsonPushEventError(t *testing.T) {
 func TestJsonGitHubPushEvent(t *testing.T) {
 	req := postFile("X-GitHub-Event", "push", "pushevent.json", "http://some.url", http.StatusOK, t)
 	plugin := New()
-	_, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	_, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
@@ -221,7 +228,7 @@ func TestJsonGitHubPushEvent(t *testing.T) {
 func TestJsonGitHubPushEventWithCharset(t *testing.T) {
 	req := postFileWithCharset("X-GitHub-Event", "push", "pushevent.json", "http://some.url", "application/json; charset=utf-8", http.StatusOK, t)
 	plugin := New()
-	_, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	_, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
@@ -234,7 +241,7 @@ func TestJsonGitHubPushEventWithCharset(t *testing.T) {
 func TestJsonGogsPushEvent(t *testing.T) {
 	req := postFile("X-Gogs-Event", "push", "pushevent.json", "http://some.url", http.StatusOK, t)
 	plugin := New()
-	_, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
+	_, _, _, proceed, err := plugin.Extract(buildConfig, "secret100", "", req)
 
 	if err != nil {
 		t.Errorf("Unexpected error: %v", err)
@@ -338,7 +345,7 @@ func TestExtractForAPingEvent(t *testing.T) {
 	context := setup(t, "pingevent.json", "ping", "")
 
 	//execute
-	_, _, proceed, err := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
+	_, _, _, proceed, err := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
 
 	//validation
 	if err != nil {
@@ -354,7 +361,7 @@ func TestExtractProvidesValidBuildForAPushEvent(t *testing.T) {
 	context := setup(t, "pushevent.json", "push", "")
 
 	//execute
-	revision, _, proceed, err := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
+	revision, _, _, proceed, err := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
 
 	//validation
 	if err != nil {
@@ -376,7 +383,7 @@ func TestExtractProvidesValidBuildForAPushEventOtherThanMaster(t *testing.T) {
 	//setup
 	context := setup(t, "pushevent-not-master-branch.json", "push", "my_other_branch")
 	//execute
-	revision, _, proceed, err := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
+	revision, _, _, proceed, err := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
 
 	//validation
 	if err != nil {
@@ -398,8 +405,286 @@ func TestExtractSkipsBuildForUnmatchedBranches(t *testing.T) {
 	context := setup(t, "pushevent.json", "push", "wrongref")
 
 	//execute
-	_, _, proceed, _ := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
+	_, _, _, proceed, _ := context.plugin.Extract(context.buildCfg, "secret101", context.path, context.req)
 	if proceed {
 		t.Errorf("Expecting to not continue from this event because the branch is not for this buildConfig '%s'", context.buildCfg.Spec.Source.Git.Ref)
 	}
 }
+
+func signedBuildConfig() *api.BuildConfig {
+	return &api.BuildConfig{
+		Spec: api.BuildConfigSpec{
+			Triggers: []api.BuildTriggerPolicy{
+				{
+					Type: api.GitHubWebHookBuildTriggerType,
+					GitHubWebHook: &api.WebHookTrigger{
+						Secret:           "secret100",
+						RequireSignature: true,
+					},
+				},
+			},
+			CommonSpec: api.CommonSpec{
+				Source: api.BuildSource{
+					Git: &api.GitBuildSource{},
+				},
+			},
+		},
+	}
+}
+
+func sign(algorithm func() hash.Hash, key string, data []byte) string {
+	mac := hmac.New(algorithm, []byte(key))
+	mac.Write(data)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func TestExtractVerifiesSignature(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/pushevent.json")
+	if err != nil {
+		t.Fatalf("Failed to open pushevent.json: %v", err)
+	}
+	tests := map[string]struct {
+		header    string
+		signature string
+		expected  error
+	}{
+		"missing signature": {
+			expected: webhook.ErrSignatureMismatch,
+		},
+		"valid sha1 signature": {
+			header:    "X-Hub-Signature",
+			signature: "sha1=" + sign(sha1.New, "secret100", data),
+		},
+		"valid sha256 signature": {
+			header:    "X-Hub-Signature-256",
+			signature: "sha256=" + sign(sha256.New, "secret100", data),
+		},
+		"valid gogs signature": {
+			header:    "X-Gogs-Signature",
+			signature: sign(sha256.New, "secret100", data),
+		},
+		"signature with wrong key": {
+			header:    "X-Hub-Signature",
+			signature: "sha1=" + sign(sha1.New, "secret101", data),
+			expected:  webhook.ErrSignatureMismatch,
+		},
+		"malformed signature": {
+			header:    "X-Hub-Signature",
+			signature: "sha1=nothex",
+			expected:  webhook.ErrSignatureMismatch,
+		},
+	}
+	for name, test := range tests {
+		req := post("X-GitHub-Event", "push", data, "http://some.url", http.StatusOK, t)
+		if len(test.header) != 0 {
+			req.Header.Add(test.header, test.signature)
+		}
+		revision, _, _, proceed, err := New().Extract(signedBuildConfig(), "secret100", "", req)
+		if err != test.expected {
+			t.Errorf("%s: expected error %v, got %v", name, test.expected, err)
+		}
+		if test.expected != nil && (proceed || revision != nil) {
+			t.Errorf("%s: expected the build to be skipped", name)
+		}
+		if test.expected == nil && !proceed {
+			t.Errorf("%s: expected the build to proceed", name)
+		}
+	}
+}
+
+func TestExtractIgnoresSignatureWhenNotRequired(t *testing.T) {
+	req := postFile("X-GitHub-Event", "push", "pushevent.json", "http://some.url", http.StatusOK, t)
+	req.Header.Add("X-Hub-Signature", "sha1=0000")
+	_, _, _, proceed, err := New().Extract(buildConfig, "secret100", "", req)
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if !proceed {
+		t.Error("Expected 'proceed' return value to be 'true'")
+	}
+}
+
+func pullRequestBuildConfig() *api.BuildConfig {
+	return &api.BuildConfig{
+		Spec: api.BuildConfigSpec{
+			Triggers: []api.BuildTriggerPolicy{
+				{
+					Type: api.GitHubWebHookBuildTriggerType,
+					GitHubWebHook: &api.WebHookTrigger{
+						Secret:            "secret100",
+						BuildPullRequests: true,
+					},
+				},
+			},
+			CommonSpec: api.CommonSpec{
+				Source: api.BuildSource{
+					Git: &api.GitBuildSource{},
+				},
+			},
+		},
+	}
+}
+
+func TestExtractPullRequest(t *testing.T) {
+	req := postFile("X-GitHub-Event", "pull_request", "pullrequestevent.json", "http://some.url", http.StatusOK, t)
+	revision, _, pullRequest, proceed, err := New().Extract(pullRequestBuildConfig(), "secret100", "", req)
+
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !proceed {
+		t.Error("Expected 'proceed' return value to be 'true'")
+	}
+	if revision == nil || revision.Git == nil {
+		t.Fatal("Expected a git revision")
+	}
+	if revision.Git.Commit != "b4c8ab3c5c0d8d6e1cb4f5bb7b3b7a2d9f0e1a2b" {
+		t.Errorf("Expected the pull request head to be built, got %s", revision.Git.Commit)
+	}
+	expected := &api.PullRequestCause{Number: 42, Title: "Add a readme", HeadRef: "readme", Fork: true}
+	if !reflect.DeepEqual(pullRequest, expected) {
+		t.Errorf("Expected pull request %#v, got %#v", expected, pullRequest)
+	}
+}
+
+func TestExtractPullRequestFromSameRepository(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/pullrequestevent.json")
+	if err != nil {
+		t.Fatalf("Failed to open pullrequestevent.json: %v", err)
+	}
+	data = bytes.Replace(data, []byte("anonFork/anonRepo"), []byte("anonUser/anonRepo"), 1)
+	req := post("X-GitHub-Event", "pull_request", data, "http://some.url", http.StatusOK, t)
+	_, _, pullRequest, proceed, err := New().Extract(pullRequestBuildConfig(), "secret100", "", req)
+	if err != nil || !proceed {
+		t.Fatalf("Expected the pull request to be built, got %v", err)
+	}
+	if pullRequest == nil || pullRequest.Fork {
+		t.Errorf("Expected a pull request which is not from a fork, got %#v", pullRequest)
+	}
+}
+
+func TestExtractPullRequestSkipped(t *testing.T) {
+	data, err := ioutil.ReadFile("testdata/pullrequestevent.json")
+	if err != nil {
+		t.Fatalf("Failed to open pullrequestevent.json: %v", err)
+	}
+	tests := map[string]struct {
+		data   []byte
+		config func() *api.BuildConfig
+	}{
+		"pull request builds disabled": {
+			data:   data,
+			config: func() *api.BuildConfig { return buildConfig },
+		},
+		"closed pull request": {
+			data:   bytes.Replace(data, []byte(`"action":"opened"`), []byte(`"action":"closed"`), 1),
+			config: pullRequestBuildConfig,
+		},
+		"different base branch": {
+			data: data,
+			config: func() *api.BuildConfig {
+				cfg := pullRequestBuildConfig()
+				cfg.Spec.Source.Git.Ref = "release"
+				return cfg
+			},
+		},
+	}
+	for name, test := range tests {
+		req := post("X-GitHub-Event", "pull_request", test.data, "http://some.url", http.StatusOK, t)
+		revision, _, pullRequest, proceed, err := New().Extract(test.config(), "secret100", "", req)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if proceed || revision != nil || pullRequest != nil {
+			t.Errorf("%s: expected the build to be skipped", name)
+		}
+	}
+}
+
+func TestExtractWithPathFilters(t *testing.T) {
+	tests := map[string]struct {
+		include, exclude []string
+		proceed          bool
+	}{
+		"changed path included": {
+			include: []string{"LICENSE"},
+			proceed: true,
+		},
+		"changed path not included": {
+			include: []string{"src"},
+		},
+		"changed path excluded": {
+			exclude: []string{"LICENSE"},
+		},
+	}
+	for name, test := range tests {
+		cfg := &api.BuildConfig{
+			Spec: api.BuildConfigSpec{
+				Triggers: []api.BuildTriggerPolicy{
+					{
+						Type: api.GitHubWebHookBuildTriggerType,
+						GitHubWebHook: &api.WebHookTrigger{
+							Secret:       "secret100",
+							IncludePaths: test.include,
+							ExcludePaths: test.exclude,
+						},
+					},
+				},
+				CommonSpec: api.CommonSpec{
+					Source: api.BuildSource{
+						Git: &api.GitBuildSource{},
+					},
+				},
+			},
+		}
+		req := postFile("X-GitHub-Event", "push", "pushevent.json", "http://some.url", http.StatusOK, t)
+		_, _, _, proceed, err := New().Extract(cfg, "secret100", "", req)
+		if proceed != test.proceed {
+			t.Errorf("%s: expected proceed to be %t", name, test.proceed)
+		}
+		if test.proceed && err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+		}
+		if _, skipped := err.(*webhook.SkippedError); !test.proceed && !skipped {
+			t.Errorf("%s: expected a SkippedError, got %v", name, err)
+		}
+	}
+}
+
+func TestExtractWithPathFiltersTruncatedCommits(t *testing.T) {
+	cfg := &api.BuildConfig{
+		Spec: api.BuildConfigSpec{
+			Triggers: []api.BuildTriggerPolicy{
+				{
+					Type: api.GitHubWebHookBuildTriggerType,
+					GitHubWebHook: &api.WebHookTrigger{
+						Secret:       "secret100",
+						IncludePaths: []string{"src"},
+					},
+				},
+			},
+			CommonSpec: api.CommonSpec{
+				Source: api.BuildSource{
+					Git: &api.GitBuildSource{},
+				},
+			},
+		},
+	}
+	for _, count := range []int{maxPushEventCommits - 1, maxPushEventCommits} {
+		event := pushEvent{Ref: "refs/heads/master", HeadCommit: commit{ID: "abc"}}
+		for i := 0; i < count; i++ {
+			event.Commits = append(event.Commits, commit{ID: "abc", Modified: []string{"docs/README.md"}})
+		}
+		data, err := json.Marshal(&event)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req := post("X-GitHub-Event", "push", data, "http://some.url", http.StatusOK, t)
+		_, _, _, proceed, err := New().Extract(cfg, "secret100", "", req)
+		// the commits left out of full events may have changed included paths
+		if expected := count == maxPushEventCommits; proceed != expected {
+			t.Errorf("%d commits: expected proceed to be %t, got %t (%v)", count, expected, proceed, err)
+		}
+	}
+}
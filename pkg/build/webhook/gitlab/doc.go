@@ -0,0 +1,3 @@
+// Package gitlab contains webhook.Plugin implementation of gitlab webhooks
+// according to https://docs.gitlab.com/ce/web_hooks/web_hooks.html
+package gitlab
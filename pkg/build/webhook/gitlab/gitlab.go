@@ -0,0 +1,127 @@
+package gitlab
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"net/http"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	"github.com/golang/glog"
+	"github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/build/webhook"
+)
+
+var (
+	ErrNoGitLabEvent = errors.New("missing X-Gitlab-Event")
+)
+
+const pushHookEvent = "Push Hook"
+
+// WebHook used for processing gitlab webhook requests.
+type WebHook struct{}
+
+// New returns gitlab webhook plugin.
+func New() *WebHook {
+	return &WebHook{}
+}
+
+type commit struct {
+	ID      string                `json:"id,omitempty"`
+	Message string                `json:"message,omitempty"`
+	Author  api.SourceControlUser `json:"author,omitempty"`
+}
+
+type pushEvent struct {
+	ObjectKind  string   `json:"object_kind,omitempty"`
+	Ref         string   `json:"ref,omitempty"`
+	After       string   `json:"after,omitempty"`
+	CheckoutSHA string   `json:"checkout_sha,omitempty"`
+	Commits     []commit `json:"commits,omitempty"`
+}
+
+// Extract services webhooks from gitlab.com
+func (p *WebHook) Extract(buildCfg *api.BuildConfig, secret, path string, req *http.Request) (revision *api.SourceRevision, envvars []kapi.EnvVar, pullRequest *api.PullRequestCause, proceed bool, err error) {
+	triggers, err := webhook.FindTriggerPolicy(api.GitLabWebHookBuildTriggerType, buildCfg)
+	if err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+	glog.V(4).Infof("Checking if the provided secret for BuildConfig %s/%s matches", buildCfg.Namespace, buildCfg.Name)
+
+	if _, err = webhook.ValidateWebHookSecret(triggers, secret); err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+
+	glog.V(4).Infof("Verifying build request for BuildConfig %s/%s", buildCfg.Namespace, buildCfg.Name)
+	if err = verifyRequest(req); err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+	method := req.Header.Get("X-Gitlab-Event")
+	if method != pushHookEvent {
+		return revision, envvars, pullRequest, proceed, fmt.Errorf("Unknown X-Gitlab-Event %s", method)
+	}
+	body, err := ioutil.ReadAll(req.Body)
+	if err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+	var event pushEvent
+	if err = json.Unmarshal(body, &event); err != nil {
+		return revision, envvars, pullRequest, proceed, err
+	}
+	if !webhook.GitRefMatches(event.Ref, webhook.DefaultConfigRef, &buildCfg.Spec.Source) {
+		glog.V(2).Infof("Skipping build for BuildConfig %s/%s.  Branch reference from '%s' does not match configuration", buildCfg.Namespace, buildCfg.Name, event.Ref)
+		return revision, envvars, pullRequest, proceed, err
+	}
+
+	revision = &api.SourceRevision{
+		Git: headCommit(&event),
+	}
+	return revision, envvars, pullRequest, true, err
+}
+
+// headCommit returns the revision of the commit a push event moved the
+// branch to. GitLab lists the pushed commits oldest first and does not report
+// a separate committer, so the author is used for both.
+func headCommit(event *pushEvent) *api.GitSourceRevision {
+	sha := event.CheckoutSHA
+	if len(sha) == 0 {
+		sha = event.After
+	}
+	if len(event.Commits) == 0 {
+		return &api.GitSourceRevision{Commit: sha}
+	}
+	head := event.Commits[len(event.Commits)-1]
+	for _, c := range event.Commits {
+		if c.ID == sha {
+			head = c
+			break
+		}
+	}
+	return &api.GitSourceRevision{
+		Commit:    head.ID,
+		Author:    head.Author,
+		Committer: head.Author,
+		Message:   head.Message,
+	}
+}
+
+func verifyRequest(req *http.Request) error {
+	if method := req.Method; method != "POST" {
+		return fmt.Errorf("unsupported HTTP method %s", method)
+	}
+	contentType := req.Header.Get("Content-Type")
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return fmt.Errorf("non-parseable Content-Type %s (%s)", contentType, err)
+	}
+	if mediaType != "application/json" {
+		return fmt.Errorf("unsupported Content-Type %s", contentType)
+	}
+	if len(req.Header.Get("X-Gitlab-Event")) == 0 {
+		return ErrNoGitLabEvent
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package gitlab
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/build/webhook"
+)
+
+var buildConfig = &api.BuildConfig{
+	Spec: api.BuildConfigSpec{
+		Triggers: []api.BuildTriggerPolicy{
+			{
+				Type: api.GitLabWebHookBuildTriggerType,
+				GitLabWebHook: &api.WebHookTrigger{
+					Secret: "secret100",
+				},
+			},
+		},
+		CommonSpec: api.CommonSpec{
+			Source: api.BuildSource{
+				Git: &api.GitBuildSource{},
+			},
+		},
+	},
+}
+
+func GivenRequest(method string) *http.Request {
+	req, _ := http.NewRequest(method, "http://someurl.com", nil)
+	return req
+}
+
+func postFile(event, filename string, t *testing.T) *http.Request {
+	data, err := ioutil.ReadFile("testdata/" + filename)
+	if err != nil {
+		t.Fatalf("Failed to open %s: %v", filename, err)
+	}
+	return post(event, data, t)
+}
+
+func post(event string, data []byte, t *testing.T) *http.Request {
+	req, err := http.NewRequest("POST", "http://some.url", bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Error creating POST request: %v", err)
+	}
+	req.Header.Add("Content-Type", "application/json")
+	req.Header.Add("X-Gitlab-Event", event)
+	return req
+}
+
+func TestVerifyRequestForMethod(t *testing.T) {
+	req := GivenRequest("GET")
+	revision, _, _, proceed, err := New().Extract(buildConfig, "secret100", "", req)
+
+	if err == nil || !strings.Contains(err.Error(), "unsupported HTTP method") {
+		t.Errorf("Expected unsupported HTTP method, got %v", err)
+	}
+	if proceed {
+		t.Error("Expected 'proceed' return value to be 'false'")
+	}
+	if revision != nil {
+		t.Error("Expected the 'revision' return value to be nil")
+	}
+}
+
+func TestWrongSecret(t *testing.T) {
+	req := GivenRequest("POST")
+	revision, _, _, proceed, err := New().Extract(buildConfig, "wrongsecret", "", req)
+
+	if err != webhook.ErrSecretMismatch {
+		t.Errorf("Expected %v, got %v", webhook.ErrSecretMismatch, err)
+	}
+	if proceed {
+		t.Error("Expected 'proceed' return value to be 'false'")
+	}
+	if revision != nil {
+		t.Error("Expected the 'revision' return value to be nil")
+	}
+}
+
+func TestHookNotEnabled(t *testing.T) {
+	cfg := &api.BuildConfig{
+		Spec: api.BuildConfigSpec{
+			Triggers: []api.BuildTriggerPolicy{
+				{
+					Type: api.GitHubWebHookBuildTriggerType,
+					GitHubWebHook: &api.WebHookTrigger{
+						Secret: "secret100",
+					},
+				},
+			},
+		},
+	}
+	_, _, _, proceed, err := New().Extract(cfg, "secret100", "", postFile(pushHookEvent, "pushevent.json", t))
+
+	if err != webhook.ErrHookNotEnabled {
+		t.Errorf("Expected %v, got %v", webhook.ErrHookNotEnabled, err)
+	}
+	if proceed {
+		t.Error("Expected 'proceed' return value to be 'false'")
+	}
+}
+
+func TestMissingEvent(t *testing.T) {
+	req := GivenRequest("POST")
+	req.Header.Add("Content-Type", "application/json")
+	_, _, _, proceed, err := New().Extract(buildConfig, "secret100", "", req)
+
+	if err != ErrNoGitLabEvent {
+		t.Errorf("Expected %v, got %v", ErrNoGitLabEvent, err)
+	}
+	if proceed {
+		t.Error("Expected 'proceed' return value to be 'false'")
+	}
+}
+
+func TestWrongGitLabEvent(t *testing.T) {
+	_, _, _, proceed, err := New().Extract(buildConfig, "secret100", "", postFile("Tag Push Hook", "pushevent.json", t))
+
+	if err == nil || !strings.Contains(err.Error(), "Unknown X-Gitlab-Event") {
+		t.Errorf("Expected Unknown X-Gitlab-Event, got %v", err)
+	}
+	if proceed {
+		t.Error("Expected 'proceed' return value to be 'false'")
+	}
+}
+
+func TestJsonPushEventError(t *testing.T) {
+	revision, _, _, proceed, err := New().Extract(buildConfig, "secret100", "", post(pushHookEvent, []byte{}, t))
+
+	if err == nil || !strings.Contains(err.Error(), "unexpected end of JSON input") {
+		t.Errorf("Expected unexpected end of JSON input, got %v", err)
+	}
+	if proceed {
+		t.Error("Expected 'proceed' return value to be 'false'")
+	}
+	if revision != nil {
+		t.Error("Expected the 'revision' return value to be nil")
+	}
+}
+
+func TestExtractProvidesValidBuildForAPushEvent(t *testing.T) {
+	revision, _, _, proceed, err := New().Extract(buildConfig, "secret100", "", postFile(pushHookEvent, "pushevent.json", t))
+
+	if err != nil {
+		t.Errorf("Error while extracting build info: %s", err)
+	}
+	if !proceed {
+		t.Errorf("The 'proceed' return value should equal 'true' %t", proceed)
+	}
+	if revision == nil {
+		t.Fatal("Expecting the revision to not be nil")
+	}
+	if revision.Git.Commit != "da1560886d4f094c3e6c9ef40349f7d38b5d27d7" {
+		t.Errorf("Expecting the revision to contain the checkout commit id, got %s", revision.Git.Commit)
+	}
+	if revision.Git.Author.Name != "Anonymous User" || revision.Git.Committer.Email != "anonUser@example.com" {
+		t.Errorf("Expecting the revision to contain the commit author, got %#v", revision.Git)
+	}
+	if revision.Git.Message != "fixed readme" {
+		t.Errorf("Expecting the revision to contain the commit message, got %s", revision.Git.Message)
+	}
+}
+
+func TestExtractProvidesValidBuildForAPushEventOtherThanMaster(t *testing.T) {
+	cfg := &api.BuildConfig{
+		Spec: api.BuildConfigSpec{
+			Triggers: buildConfig.Spec.Triggers,
+			CommonSpec: api.CommonSpec{
+				Source: api.BuildSource{
+					Git: &api.GitBuildSource{Ref: "my_other_branch"},
+				},
+			},
+		},
+	}
+	revision, _, _, proceed, err := New().Extract(cfg, "secret100", "", postFile(pushHookEvent, "pushevent-not-master-branch.json", t))
+
+	if err != nil {
+		t.Errorf("Error while extracting build info: %s", err)
+	}
+	if !proceed {
+		t.Errorf("The 'proceed' return value should equal 'true' %t", proceed)
+	}
+	if revision == nil {
+		t.Fatal("Expecting the revision to not be nil")
+	}
+}
+
+func TestExtractSkipsBuildForUnmatchedBranches(t *testing.T) {
+	revision, _, _, proceed, err := New().Extract(buildConfig, "secret100", "", postFile(pushHookEvent, "pushevent-not-master-branch.json", t))
+
+	if err != nil {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if proceed {
+		t.Error("Expected 'proceed' return value to be 'false' for unmatched branch")
+	}
+	if revision != nil {
+		t.Error("Expected the 'revision' return value to be nil")
+	}
+}
@@ -3,7 +3,9 @@ package webhook
 import (
 	"crypto/hmac"
 	"errors"
+	"fmt"
 	"net/http"
+	"path"
 	"strings"
 
 	kapi "k8s.io/kubernetes/pkg/api"
@@ -17,19 +19,37 @@ const (
 )
 
 var (
-	ErrSecretMismatch = errors.New("the provided secret does not match")
-	ErrHookNotEnabled = errors.New("the specified hook is not enabled")
+	ErrSecretMismatch    = errors.New("the provided secret does not match")
+	ErrHookNotEnabled    = errors.New("the specified hook is not enabled")
+	ErrSignatureMismatch = errors.New("the payload signature does not match")
 )
 
+// SkippedError is returned by a Plugin when the webhook request was accepted
+// but does not require a build. Reason is reported back to the caller.
+type SkippedError struct {
+	Reason string
+}
+
+// NewSkippedError returns a SkippedError with a formatted reason.
+func NewSkippedError(format string, args ...interface{}) *SkippedError {
+	return &SkippedError{Reason: fmt.Sprintf(format, args...)}
+}
+
+// Error returns the reason the build was skipped.
+func (e *SkippedError) Error() string {
+	return e.Reason
+}
+
 // Plugin for Webhook verification is dependent on the sending side, it can be
 // eg. github, bitbucket or else, so there must be a separate Plugin
 // instance for each webhook provider.
 type Plugin interface {
 	// Method extracts build information and returns:
 	// - newly created build object or nil if default is to be created
+	// - the pull request being built, or nil if the event is not for a pull request
 	// - information whether to trigger the build itself
 	// - eventual error.
-	Extract(buildCfg *buildapi.BuildConfig, secret, path string, req *http.Request) (*buildapi.SourceRevision, []kapi.EnvVar, bool, error)
+	Extract(buildCfg *buildapi.BuildConfig, secret, path string, req *http.Request) (*buildapi.SourceRevision, []kapi.EnvVar, *buildapi.PullRequestCause, bool, error)
 }
 
 // GitRefMatches determines if the ref from a webhook event matches a build
@@ -73,6 +93,55 @@ func ValidateWebHookSecret(webHookTriggers []buildapi.BuildTriggerPolicy, secret
 			}
 			return trigger.GitHubWebHook, nil
 		}
+		if trigger.Type == buildapi.GitLabWebHookBuildTriggerType {
+			if !hmac.Equal([]byte(trigger.GitLabWebHook.Secret), []byte(secret)) {
+				continue
+			}
+			return trigger.GitLabWebHook, nil
+		}
+		if trigger.Type == buildapi.BitbucketWebHookBuildTriggerType {
+			if !hmac.Equal([]byte(trigger.BitbucketWebHook.Secret), []byte(secret)) {
+				continue
+			}
+			return trigger.BitbucketWebHook, nil
+		}
 	}
 	return nil, ErrSecretMismatch
 }
+
+// ChangedPathsMatch determines whether a push that changed the given paths
+// should start a build according to the path filters of the trigger. A path
+// is ignored if it matches one of the ExcludePaths, and otherwise matches if
+// there are no IncludePaths or it matches one of them. Pushes that do not
+// report their changed paths always start a build.
+func ChangedPathsMatch(trigger *buildapi.WebHookTrigger, changedPaths []string) bool {
+	if len(trigger.IncludePaths) == 0 && len(trigger.ExcludePaths) == 0 {
+		return true
+	}
+	if len(changedPaths) == 0 {
+		return true
+	}
+	for _, changed := range changedPaths {
+		if matchesAnyPath(trigger.ExcludePaths, changed) {
+			continue
+		}
+		if len(trigger.IncludePaths) == 0 || matchesAnyPath(trigger.IncludePaths, changed) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesAnyPath returns true if the file or one of its parent directories
+// matches one of the glob patterns.
+func matchesAnyPath(patterns []string, file string) bool {
+	for _, pattern := range patterns {
+		pattern = strings.Trim(pattern, "/")
+		for dir := strings.Trim(file, "/"); len(dir) != 0 && dir != "."; dir = path.Dir(dir) {
+			if ok, _ := path.Match(pattern, dir); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
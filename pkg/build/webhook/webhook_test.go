@@ -183,3 +183,57 @@ func TestValidateEnvVarsGenericWebHook(t *testing.T) {
 		t.Errorf("Expected AllowEnv to be true for %s", secret)
 	}
 }
+
+func TestChangedPathsMatch(t *testing.T) {
+	tests := map[string]struct {
+		include, exclude []string
+		changed          []string
+		expected         bool
+	}{
+		"no filters": {
+			changed:  []string{"docs/README.md"},
+			expected: true,
+		},
+		"no changed paths reported": {
+			include:  []string{"services/api"},
+			expected: true,
+		},
+		"included directory": {
+			include:  []string{"services/api"},
+			changed:  []string{"docs/README.md", "services/api/main.go"},
+			expected: true,
+		},
+		"included glob": {
+			include:  []string{"services/*/Dockerfile"},
+			changed:  []string{"services/web/Dockerfile"},
+			expected: true,
+		},
+		"nothing included": {
+			include:  []string{"services/api"},
+			changed:  []string{"services/web/main.go", "README.md"},
+			expected: false,
+		},
+		"everything excluded": {
+			exclude:  []string{"docs", "*.md"},
+			changed:  []string{"docs/index.html", "README.md"},
+			expected: false,
+		},
+		"excluded within included": {
+			include:  []string{"services/api"},
+			exclude:  []string{"services/api/docs"},
+			changed:  []string{"services/api/docs/usage.md"},
+			expected: false,
+		},
+		"partly excluded": {
+			exclude:  []string{"docs"},
+			changed:  []string{"docs/index.html", "main.go"},
+			expected: true,
+		},
+	}
+	for name, test := range tests {
+		trigger := &api.WebHookTrigger{IncludePaths: test.include, ExcludePaths: test.exclude}
+		if actual := ChangedPathsMatch(trigger, test.changed); actual != test.expected {
+			t.Errorf("%s: expected %t, got %t", name, test.expected, actual)
+		}
+	}
+}
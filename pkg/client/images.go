@@ -2,6 +2,7 @@ package client
 
 import (
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/watch"
 
 	imageapi "github.com/openshift/origin/pkg/image/api"
 )
@@ -16,7 +17,9 @@ type ImageInterface interface {
 	List(opts kapi.ListOptions) (*imageapi.ImageList, error)
 	Get(name string) (*imageapi.Image, error)
 	Create(image *imageapi.Image) (*imageapi.Image, error)
+	Update(image *imageapi.Image) (*imageapi.Image, error)
 	Delete(name string) error
+	Watch(opts kapi.ListOptions) (watch.Interface, error)
 }
 
 // images implements ImagesInterface.
@@ -56,8 +59,24 @@ func (c *images) Create(image *imageapi.Image) (result *imageapi.Image, err erro
 	return
 }
 
+// Update updates an existing image. Returns the server's representation of the image and error if one occurs.
+func (c *images) Update(image *imageapi.Image) (result *imageapi.Image, err error) {
+	result = &imageapi.Image{}
+	err = c.r.Put().Resource("images").Name(image.Name).Body(image).Do().Into(result)
+	return
+}
+
 // Delete deletes an image, returns error if one occurs.
 func (c *images) Delete(name string) (err error) {
 	err = c.r.Delete().Resource("images").Name(name).Do().Error()
 	return
 }
+
+// Watch returns a watch.Interface that watches the requested images.
+func (c *images) Watch(opts kapi.ListOptions) (watch.Interface, error) {
+	return c.r.Get().
+		Prefix("watch").
+		Resource("images").
+		VersionedParams(&opts, kapi.ParameterCodec).
+		Watch()
+}
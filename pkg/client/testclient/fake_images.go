@@ -3,6 +3,7 @@ package testclient
 import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/watch"
 
 	"github.com/openshift/origin/pkg/client"
 	imageapi "github.com/openshift/origin/pkg/image/api"
@@ -44,7 +45,20 @@ func (c *FakeImages) Create(inObj *imageapi.Image) (*imageapi.Image, error) {
 	return obj.(*imageapi.Image), err
 }
 
+func (c *FakeImages) Update(inObj *imageapi.Image) (*imageapi.Image, error) {
+	obj, err := c.Fake.Invokes(ktestclient.NewRootUpdateAction("images", inObj), inObj)
+	if obj == nil {
+		return nil, err
+	}
+
+	return obj.(*imageapi.Image), err
+}
+
 func (c *FakeImages) Delete(name string) error {
 	_, err := c.Fake.Invokes(ktestclient.NewRootDeleteAction("images", name), &imageapi.Image{})
 	return err
 }
+
+func (c *FakeImages) Watch(opts kapi.ListOptions) (watch.Interface, error) {
+	return c.Fake.InvokesWatch(ktestclient.NewRootWatchAction("images", opts))
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/openshift/origin/pkg/client"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	registryapi "github.com/openshift/origin/pkg/dockerregistry/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	"github.com/openshift/origin/pkg/image/prune"
 	oserrors "github.com/openshift/origin/pkg/util/errors"
@@ -44,7 +45,10 @@ images.`
   %[1]s %[2]s --keep-tag-revisions=3 --keep-younger-than=60m
 
   # To actually perform the prune operation, the confirm flag must be appended
-  %[1]s %[2]s --keep-tag-revisions=3 --keep-younger-than=60m --confirm`
+  %[1]s %[2]s --keep-tag-revisions=3 --keep-younger-than=60m --confirm
+
+  # Also make the registry delete the blobs no image references any more
+  %[1]s %[2]s --keep-tag-revisions=3 --keep-younger-than=60m --registry-gc --confirm`
 )
 
 // PruneImagesOptions holds all the required options for prune images
@@ -56,6 +60,7 @@ type PruneImagesOptions struct {
 	Confirm          bool
 	KeepYoungerThan  time.Duration
 	KeepTagRevisions int
+	RegistryGC       bool
 
 	CABundle            string
 	RegistryUrlOverride string
@@ -94,6 +99,7 @@ func NewCmdPruneImages(f *clientcmd.Factory, parentName, name string, out io.Wri
 	cmd.Flags().BoolVar(&opts.Confirm, "confirm", opts.Confirm, "Specify that image pruning should proceed. Defaults to false, displaying what would be deleted but not actually deleting anything.")
 	cmd.Flags().DurationVar(&opts.KeepYoungerThan, "keep-younger-than", opts.KeepYoungerThan, "Specify the minimum age of an image for it to be considered a candidate for pruning.")
 	cmd.Flags().IntVar(&opts.KeepTagRevisions, "keep-tag-revisions", opts.KeepTagRevisions, "Specify the number of image revisions for a tag in an image stream that will be preserved.")
+	cmd.Flags().BoolVar(&opts.RegistryGC, "registry-gc", opts.RegistryGC, "Make the registry delete the blobs and uploads older than --keep-younger-than no image references once images are pruned. Without --confirm, the registry reports the blobs no image references yet.")
 	cmd.Flags().StringVar(&opts.CABundle, "certificate-authority", opts.CABundle, "The path to a certificate authority bundle to use when communicating with the managed Docker registries. Defaults to the certificate authority data from the current user's config file.")
 	cmd.Flags().StringVar(&opts.RegistryUrlOverride, "registry-url", opts.RegistryUrlOverride, "The address to use when contacting the registry, instead of using the default value. This is useful if you can't resolve or reach the registry (e.g.; the default is a cluster-internal URL) but you do have an alternative route that works.")
 
@@ -199,6 +205,11 @@ func (o *PruneImagesOptions) RunPruneImages() error {
 	blobPruner := &describingBlobPruner{w: w}
 	manifestPruner := &describingManifestPruner{w: w}
 
+	var garbageCollector prune.RegistryGarbageCollector
+	if o.RegistryGC {
+		garbageCollector = &describingRegistryGarbageCollector{w: w, delegate: prune.NewRegistryGarbageCollector(!o.Confirm, o.KeepYoungerThan)}
+	}
+
 	if o.Confirm {
 		imagePruner.delegate = prune.NewDeletingImagePruner(o.Client.Images())
 		imageStreamPruner.delegate = prune.NewDeletingImageStreamPruner(o.Client)
@@ -209,7 +220,7 @@ func (o *PruneImagesOptions) RunPruneImages() error {
 		fmt.Fprintln(os.Stderr, "Dry run enabled - no modifications will be made. Add --confirm to remove images")
 	}
 
-	return o.Pruner.Prune(imagePruner, imageStreamPruner, layerPruner, blobPruner, manifestPruner)
+	return o.Pruner.Prune(imagePruner, imageStreamPruner, layerPruner, blobPruner, manifestPruner, garbageCollector)
 }
 
 // describingImageStreamPruner prints information about each image stream update.
@@ -369,6 +380,44 @@ func (p *describingManifestPruner) PruneManifest(registryClient *http.Client, re
 	return err
 }
 
+// describingRegistryGarbageCollector prints information about each blob and
+// upload the registry deletes, or would delete in a dry run, when its delegate
+// collects the garbage of the registry.
+type describingRegistryGarbageCollector struct {
+	w        io.Writer
+	delegate prune.RegistryGarbageCollector
+}
+
+var _ prune.RegistryGarbageCollector = &describingRegistryGarbageCollector{}
+
+func (c *describingRegistryGarbageCollector) CollectGarbage(registryClient *http.Client, registryURL string) (*registryapi.GarbageCollectionReport, error) {
+	report, err := c.delegate.CollectGarbage(registryClient, registryURL)
+	if report == nil {
+		fmt.Fprintf(os.Stderr, "error collecting the garbage of the registry: %v\n", err)
+		return report, err
+	}
+
+	if len(report.Blobs) > 0 {
+		fmt.Fprintln(c.w, "\nDeleting unreferenced registry blobs ...")
+		fmt.Fprintln(c.w, "BLOB\tSIZE")
+		for _, blob := range report.Blobs {
+			fmt.Fprintf(c.w, "%s\t%d\n", blob.Digest, blob.Size)
+		}
+	}
+	if len(report.Uploads) > 0 {
+		fmt.Fprintln(c.w, "\nDeleting registry upload leftovers ...")
+		fmt.Fprintln(c.w, "UPLOAD")
+		for _, upload := range report.Uploads {
+			fmt.Fprintf(c.w, "%s\n", upload)
+		}
+	}
+	for _, message := range report.Errors {
+		fmt.Fprintf(os.Stderr, "%s\n", message)
+	}
+
+	return report, err
+}
+
 // getClients returns a Kube client, OpenShift client, and registry client.
 func getClients(f *clientcmd.Factory, caBundle string) (*client.Client, *kclient.Client, *http.Client, error) {
 	clientConfig, err := f.OpenShiftClientConfig.ClientConfig()
@@ -40,6 +40,8 @@ type DeployOptions struct {
 	retryDeploy          bool
 	cancelDeploy         bool
 	enableTriggers       bool
+	diffDeployments      bool
+	diffRevisions        []int64
 }
 
 const (
@@ -71,6 +73,10 @@ If you want to cancel a running deployment, use '--cancel' but keep in mind that
 operation and may take some time to complete. It’s possible the deployment will partially or totally
 complete before the cancellation is effective. In such a case an appropriate event will be emitted.
 
+To review what changed between two deployments, use '--diff' with the two versions to compare. If
+only one version is given, it is compared with the latest deployment. The changes to containers,
+images, environment variables, resources and volumes of the pod template are shown.
+
 If no options are given, shows information about the latest deployment.`
 
 	deployExample = `  # Display the latest deployment for the 'database' deployment config
@@ -84,7 +90,10 @@ If no options are given, shows information about the latest deployment.`
   %[1]s deploy frontend --retry
 
   # Cancel the in-progress deployment based on 'frontend'
-  %[1]s deploy frontend --cancel`
+  %[1]s deploy frontend --cancel
+
+  # Show the changes to the pod template between deployments 2 and 3 of 'frontend'
+  %[1]s deploy frontend --diff 2 3`
 )
 
 // NewCmdDeploy creates a new `deploy` command.
@@ -94,7 +103,7 @@ func NewCmdDeploy(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.C
 	}
 
 	cmd := &cobra.Command{
-		Use:        "deploy DEPLOYMENTCONFIG [--latest|--retry|--cancel|--enable-triggers]",
+		Use:        "deploy DEPLOYMENTCONFIG [--latest|--retry|--cancel|--enable-triggers|--diff VERSION [VERSION]]",
 		Short:      "View, start, cancel, or retry a deployment",
 		Long:       fmt.Sprintf(deployLong, fullName),
 		Example:    fmt.Sprintf(deployExample, fullName),
@@ -118,13 +127,14 @@ func NewCmdDeploy(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.C
 	cmd.Flags().BoolVar(&options.retryDeploy, "retry", false, "Retry the latest failed deployment.")
 	cmd.Flags().BoolVar(&options.cancelDeploy, "cancel", false, "Cancel the in-progress deployment.")
 	cmd.Flags().BoolVar(&options.enableTriggers, "enable-triggers", false, "Enables all image triggers for the deployment config.")
+	cmd.Flags().BoolVar(&options.diffDeployments, "diff", false, "Show the changes to the pod template between two deployments.")
 
 	return cmd
 }
 
 func (o *DeployOptions) Complete(f *clientcmd.Factory, args []string, out io.Writer) error {
-	if len(args) > 1 {
-		return errors.New("only one deployment config name is supported as argument.")
+	if err := o.completeArgs(args); err != nil {
+		return err
 	}
 	var err error
 
@@ -142,10 +152,27 @@ func (o *DeployOptions) Complete(f *clientcmd.Factory, args []string, out io.Wri
 
 	o.out = out
 
+	return nil
+}
+
+// completeArgs sets the deployment config name, and the deployment versions to
+// diff, from the arguments. A missing name is reported by Validate.
+func (o *DeployOptions) completeArgs(args []string) error {
+	if len(args) > 1 && !o.diffDeployments {
+		return errors.New("only one deployment config name is supported as argument.")
+	}
 	if len(args) > 0 {
 		o.deploymentConfigName = args[0]
 	}
-
+	if len(args) > 1 {
+		for _, arg := range args[1:] {
+			revision, err := strconv.ParseInt(arg, 10, 64)
+			if err != nil || revision < 1 {
+				return fmt.Errorf("%q is not a valid deployment version.", arg)
+			}
+			o.diffRevisions = append(o.diffRevisions, revision)
+		}
+	}
 	return nil
 }
 
@@ -166,8 +193,14 @@ func (o DeployOptions) Validate() error {
 	if o.enableTriggers {
 		numOptions++
 	}
+	if o.diffDeployments {
+		numOptions++
+		if len(o.diffRevisions) == 0 || len(o.diffRevisions) > 2 {
+			return errors.New("--diff requires one or two deployment versions.")
+		}
+	}
 	if numOptions > 1 {
-		return errors.New("only one of --latest, --retry, --cancel, or --enable-triggers is allowed.")
+		return errors.New("only one of --latest, --retry, --cancel, --enable-triggers, or --diff is allowed.")
 	}
 	return nil
 }
@@ -196,6 +229,8 @@ func (o DeployOptions) RunDeploy() error {
 		err = o.cancel(config, o.out)
 	case o.enableTriggers:
 		err = o.reenableTriggers(config, o.out)
+	case o.diffDeployments:
+		err = o.diff(config, o.out)
 	default:
 		describer := describe.NewLatestDeploymentsDescriber(o.osClient, o.kubeClient, -1)
 		desc, err := describer.Describe(config.Namespace, config.Name)
@@ -379,3 +414,30 @@ func (o DeployOptions) reenableTriggers(config *deployapi.DeploymentConfig, out
 	fmt.Fprintf(out, "Enabled image triggers: %s\n", strings.Join(enabled, ","))
 	return nil
 }
+
+// diff shows the changes to the pod template between two deployments of
+// config. If only one version was given, it is compared with the latest
+// deployment.
+func (o DeployOptions) diff(config *deployapi.DeploymentConfig, out io.Writer) error {
+	versions := o.diffRevisions
+	if len(versions) == 1 {
+		versions = append(versions, config.Status.LatestVersion)
+	}
+	deployments := []*kapi.ReplicationController{}
+	for _, version := range versions {
+		deployment, err := o.kubeClient.ReplicationControllers(config.Namespace).Get(deployutil.DeploymentNameForConfigVersion(config.Name, version))
+		if err != nil {
+			if kerrors.IsNotFound(err) {
+				return fmt.Errorf("deployment #%d of %s/%s not found", version, config.Namespace, config.Name)
+			}
+			return err
+		}
+		deployments = append(deployments, deployment)
+	}
+	desc, err := describe.DescribeDeploymentDiff(deployments[0], deployments[1])
+	if err != nil {
+		return err
+	}
+	fmt.Fprint(out, desc)
+	return nil
+}
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"bytes"
 	"fmt"
 	"io/ioutil"
 	"reflect"
 	"sort"
+	"strings"
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
@@ -328,3 +330,111 @@ func TestDeploy_reenableTriggers(t *testing.T) {
 		}
 	}
 }
+
+func TestDeploy_completeArgs(t *testing.T) {
+	tests := []struct {
+		name      string
+		args      []string
+		diff      bool
+		config    string
+		revisions []int64
+		err       bool
+	}{
+		{
+			name: "no arguments",
+		},
+		{
+			name:   "deployment config",
+			args:   []string{"config"},
+			config: "config",
+		},
+		{
+			name: "deployment config and version without --diff",
+			args: []string{"config", "1"},
+			err:  true,
+		},
+		{
+			name:      "deployment config and versions to diff",
+			args:      []string{"config", "1", "3"},
+			diff:      true,
+			config:    "config",
+			revisions: []int64{1, 3},
+		},
+		{
+			name: "invalid version to diff",
+			args: []string{"config", "0"},
+			diff: true,
+			err:  true,
+		},
+	}
+	for _, test := range tests {
+		o := &DeployOptions{diffDeployments: test.diff}
+		err := o.completeArgs(test.args)
+		if test.err {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if o.deploymentConfigName != test.config || !reflect.DeepEqual(o.diffRevisions, test.revisions) {
+			t.Errorf("%s: unexpected name %q and versions %v", test.name, o.deploymentConfigName, o.diffRevisions)
+		}
+	}
+
+	// a missing deployment config name is reported by Validate
+	if err := (DeployOptions{}).Validate(); err == nil {
+		t.Errorf("expected an error for a missing deployment config name")
+	}
+}
+
+func TestCmdDeploy_diff(t *testing.T) {
+	config := deploytest.OkDeploymentConfig(3)
+	deployments := map[string]*kapi.ReplicationController{}
+	for version := int64(1); version <= 3; version++ {
+		versionConfig := deploytest.OkDeploymentConfig(version)
+		versionConfig.Spec.Template.Spec.Containers[0].Image = fmt.Sprintf("registry:8080/repo1:v%d", version)
+		deployment := deploymentFor(versionConfig, deployapi.DeploymentStatusComplete)
+		deployments[deployment.Name] = deployment
+	}
+
+	kubeClient := &ktc.Fake{}
+	kubeClient.AddReactor("get", "replicationcontrollers", func(action ktc.Action) (handled bool, ret runtime.Object, err error) {
+		name := action.(ktc.GetAction).GetName()
+		if deployment, ok := deployments[name]; ok {
+			return true, deployment, nil
+		}
+		return true, nil, kerrors.NewNotFound(kapi.Resource("replicationcontrollers"), name)
+	})
+
+	tests := []struct {
+		revisions []int64
+		expected  string
+		err       bool
+	}{
+		{revisions: []int64{1, 2}, expected: "registry:8080/repo1:v1 -> registry:8080/repo1:v2"},
+		{revisions: []int64{1}, expected: "registry:8080/repo1:v1 -> registry:8080/repo1:v3"},
+		{revisions: []int64{4}, err: true},
+	}
+	for _, test := range tests {
+		out := &bytes.Buffer{}
+		o := &DeployOptions{kubeClient: kubeClient, diffDeployments: true, diffRevisions: test.revisions}
+		err := o.diff(config, out)
+		if test.err {
+			if err == nil {
+				t.Errorf("%v: expected an error", test.revisions)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("%v: unexpected error: %v", test.revisions, err)
+			continue
+		}
+		if !strings.Contains(out.String(), test.expected) {
+			t.Errorf("%v: expected %q in the output:\n%s", test.revisions, test.expected, out.String())
+		}
+	}
+}
@@ -26,9 +26,16 @@ const (
 Import tag and image information from an external Docker image repository
 
 Only image streams that have a value set for spec.dockerImageRepository and/or
-spec.Tags may have tag and image information imported.`
+spec.Tags may have tag and image information imported.
 
-	importImageExample = `  %[1]s import-image mystream`
+Pass --scheduled to have the server regularly check the imported tags for updates,
+and --scheduled-interval to check them less often than the interval configured for
+the cluster.`
+
+	importImageExample = `  %[1]s import-image mystream
+
+  # Import the latest image and check it for updates every hour.
+  %[1]s import-image mystream:latest --scheduled --scheduled-interval=1h`
 )
 
 // NewCmdImportImage implements the OpenShift cli import-image command.
@@ -50,6 +57,8 @@ func NewCmdImportImage(fullName string, f *clientcmd.Factory, out io.Writer) *co
 	cmd.Flags().BoolVar(&opts.Confirm, "confirm", false, "If true, allow the image stream import location to be set or changed")
 	cmd.Flags().BoolVar(&opts.All, "all", false, "If true, import all tags from the provided source on creation or if --from is specified")
 	opts.Insecure = cmd.Flags().Bool("insecure", false, "If true, allow importing from registries that have invalid HTTPS certificates or are hosted via HTTP. This flag will take precedence over the insecure annotation.")
+	opts.Scheduled = cmd.Flags().Bool("scheduled", false, "If true, the imported tags are periodically imported from the remote repository. Defaults to the current setting of existing tags.")
+	cmd.Flags().DurationVar(&opts.ScheduledInterval, "scheduled-interval", 0, "The minimum interval between two periodic imports of the scheduled tags. Defaults to the interval configured for the cluster.")
 
 	return cmd
 }
@@ -57,10 +66,12 @@ func NewCmdImportImage(fullName string, f *clientcmd.Factory, out io.Writer) *co
 // ImageImportOptions contains all the necessary information to perform an import.
 type ImportImageOptions struct {
 	// user set values
-	From     string
-	Confirm  bool
-	All      bool
-	Insecure *bool
+	From              string
+	Confirm           bool
+	All               bool
+	Insecure          *bool
+	Scheduled         *bool
+	ScheduledInterval time.Duration
 
 	// internal values
 	Namespace string
@@ -84,6 +95,9 @@ func (o *ImportImageOptions) Complete(f *clientcmd.Factory, cmd *cobra.Command,
 	if !cmd.Flags().Lookup("insecure").Changed {
 		o.Insecure = nil
 	}
+	if !cmd.Flags().Lookup("scheduled").Changed {
+		o.Scheduled = nil
+	}
 
 	namespace, _, err := f.DefaultNamespace()
 	if err != nil {
@@ -125,6 +139,15 @@ func (o *ImportImageOptions) Validate(cmd *cobra.Command) error {
 	o.Name = targetRef.Name
 	o.Tag = targetRef.Tag
 
+	if o.ScheduledInterval != 0 {
+		if o.Scheduled == nil || !*o.Scheduled {
+			return kcmdutil.UsageError(cmd, "--scheduled-interval may only be set with --scheduled")
+		}
+		if o.ScheduledInterval < time.Second {
+			return kcmdutil.UsageError(cmd, "--scheduled-interval must be at least one second")
+		}
+	}
+
 	return nil
 }
 
@@ -445,7 +468,7 @@ func (o *ImportImageOptions) newImageStreamImportAll(stream *imageapi.ImageStrea
 			Kind: "DockerImage",
 			Name: from,
 		},
-		ImportPolicy: imageapi.TagImportPolicy{Insecure: insecure},
+		ImportPolicy: o.importPolicy(stream, "", insecure),
 	}
 
 	return isi
@@ -460,8 +483,27 @@ func (o *ImportImageOptions) newImageStreamImportTags(stream *imageapi.ImageStre
 				Name: from,
 			},
 			To:           &kapi.LocalObjectReference{Name: tag},
-			ImportPolicy: imageapi.TagImportPolicy{Insecure: insecure},
+			ImportPolicy: o.importPolicy(stream, tag, insecure),
 		})
 	}
 	return isi
 }
+
+// importPolicy returns the import policy of the given tag. The tag keeps being scheduled for import as it currently
+// is, unless the --scheduled flag is set.
+func (o *ImportImageOptions) importPolicy(stream *imageapi.ImageStream, tag string, insecure bool) imageapi.TagImportPolicy {
+	policy := imageapi.TagImportPolicy{Insecure: insecure}
+	if existing, ok := stream.Spec.Tags[tag]; ok {
+		policy.Scheduled = existing.ImportPolicy.Scheduled
+		policy.IntervalSeconds = existing.ImportPolicy.IntervalSeconds
+	}
+	if o.Scheduled != nil {
+		policy.Scheduled = *o.Scheduled
+		policy.IntervalSeconds = nil
+		if policy.Scheduled && o.ScheduledInterval > 0 {
+			seconds := int64(o.ScheduledInterval / time.Second)
+			policy.IntervalSeconds = &seconds
+		}
+	}
+	return policy
+}
@@ -3,6 +3,7 @@ package cmd
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -20,6 +21,8 @@ func TestCreateImageImport(t *testing.T) {
 		all                bool
 		confirm            bool
 		insecure           *bool
+		scheduled          *bool
+		interval           time.Duration
 		err                string
 		expectedImages     []imageapi.ImageImportSpec
 		expectedRepository *imageapi.RepositoryImportSpec
@@ -326,6 +329,47 @@ func TestCreateImageImport(t *testing.T) {
 				ImportPolicy: imageapi.TagImportPolicy{Insecure: false},
 			}},
 		},
+		"scheduled flag": {
+			name:      "testis",
+			scheduled: newBool(true),
+			interval:  time.Hour,
+			stream: &imageapi.ImageStream{
+				ObjectMeta: kapi.ObjectMeta{Name: "testis", Namespace: "other"},
+				Spec: imageapi.ImageStreamSpec{
+					DockerImageRepository: "repo.com/somens/someimage",
+					Tags: make(map[string]imageapi.TagReference),
+				},
+			},
+			expectedImages: []imageapi.ImageImportSpec{{
+				From:         kapi.ObjectReference{Kind: "DockerImage", Name: "repo.com/somens/someimage"},
+				To:           &kapi.LocalObjectReference{Name: "latest"},
+				ImportPolicy: imageapi.TagImportPolicy{Scheduled: true, IntervalSeconds: newInt64(3600)},
+			}},
+		},
+		"scheduled tags stay scheduled": {
+			name: "testis",
+			stream: &imageapi.ImageStream{
+				ObjectMeta: kapi.ObjectMeta{Name: "testis", Namespace: "other"},
+				Spec: imageapi.ImageStreamSpec{
+					Tags: map[string]imageapi.TagReference{
+						"latest": {
+							From:         &kapi.ObjectReference{Kind: "DockerImage", Name: "repo.com/somens/someimage:latest"},
+							ImportPolicy: imageapi.TagImportPolicy{Scheduled: true, IntervalSeconds: newInt64(600)},
+						},
+					},
+				},
+			},
+			expectedImages: []imageapi.ImageImportSpec{{
+				From:         kapi.ObjectReference{Kind: "DockerImage", Name: "repo.com/somens/someimage:latest"},
+				To:           &kapi.LocalObjectReference{Name: "latest"},
+				ImportPolicy: imageapi.TagImportPolicy{Scheduled: true, IntervalSeconds: newInt64(600)},
+			}},
+		},
+		"interval without scheduled flag": {
+			name:     "testis",
+			interval: time.Hour,
+			err:      "--scheduled-interval may only be set with --scheduled",
+		},
 	}
 
 	for name, test := range testCases {
@@ -342,9 +386,15 @@ func TestCreateImageImport(t *testing.T) {
 			Insecure: test.insecure,
 			Confirm:  test.confirm,
 			isClient: fake.ImageStreams(""),
+
+			Scheduled:         test.scheduled,
+			ScheduledInterval: test.interval,
 		}
 		// we need to run Validate, because it sets appropriate Name and Tag
 		if err := o.Validate(&cobra.Command{}); err != nil {
+			if len(test.err) > 0 && strings.Contains(err.Error(), test.err) {
+				continue
+			}
 			t.Errorf("%s: unexpected error: %v", name, err)
 		}
 
@@ -393,6 +443,10 @@ func listEqual(actual, expected []imageapi.ImageImportSpec) bool {
 	return true
 }
 
+func newInt64(a int64) *int64 {
+	return &a
+}
+
 func newBool(a bool) *bool {
 	r := new(bool)
 	*r = a
@@ -30,7 +30,8 @@ Print the logs for a resource.
 Supported resources are builds, build configs (bc), deployment configs (dc), and pods.
 When a pod is specified and has more than one container, the container name should be
 specified via -c. When a build config or deployment config is specified, you can view
-the logs for a particular version of it via --version.
+the logs for a particular version of it via --version. The logs of a Pipeline build are
+shown one stage at a time: the latest stage by default, or the stage named by --stage.
 
 If your pod is failing to start, you may need to use the --previous option to see the
 logs of the last attempt.`
@@ -46,6 +47,9 @@ logs of the last attempt.`
   # or due to deployment pruning or manual deletion of the deployment.
   %[1]s --version=1 dc/mysql
 
+  # Get the logs of the test stage of the most recent build of the app pipeline build config.
+  %[1]s --stage=test bc/app
+
   # Return a snapshot of ruby-container logs from pod backend.
   %[1]s backend -c ruby-container
 
@@ -80,6 +84,7 @@ func NewCmdLogs(name, parent string, f *clientcmd.Factory, out io.Writer) *cobra
 		kcmdutil.CheckErr(o.RunLog())
 	}
 	cmd.Flags().Int64("version", 0, "View the logs of a particular build or deployment by version if greater than zero")
+	cmd.Flags().String("stage", "", "View the logs of a particular stage of a Pipeline build")
 
 	return cmd
 }
@@ -112,6 +117,7 @@ func (o *OpenShiftLogsOptions) Complete(f *clientcmd.Factory, out io.Writer, cmd
 	}
 
 	version := kcmdutil.GetFlagInt64(cmd, "version")
+	stage := kcmdutil.GetFlagString(cmd, "stage")
 	_, resource := meta.KindToResource(infos[0].Mapping.GroupVersionKind)
 
 	// TODO: podLogOptions should be included in our own logOptions objects.
@@ -125,6 +131,7 @@ func (o *OpenShiftLogsOptions) Complete(f *clientcmd.Factory, out io.Writer, cmd
 			Timestamps:   podLogOptions.Timestamps,
 			TailLines:    podLogOptions.TailLines,
 			LimitBytes:   podLogOptions.LimitBytes,
+			Stage:        stage,
 		}
 		if version != 0 {
 			bopts.Version = &version
@@ -147,6 +154,9 @@ func (o *OpenShiftLogsOptions) Complete(f *clientcmd.Factory, out io.Writer, cmd
 	default:
 		o.Options = nil
 	}
+	if _, ok := o.Options.(*buildapi.BuildLogOptions); !ok && len(stage) > 0 {
+		return errors.New("--stage can only be used with builds and build configs")
+	}
 
 	return nil
 }
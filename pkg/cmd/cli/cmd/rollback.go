@@ -20,6 +20,7 @@ import (
 	describe "github.com/openshift/origin/pkg/cmd/cli/describe"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/deploy/strategy/bluegreen"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 )
 
@@ -42,7 +43,11 @@ triggers, use the 'deploy' command.
 If you would like to review the outcome of the rollback, pass '--dry-run' to print
 a human-readable representation of the updated deployment configuration instead of
 executing the rollback. This is useful if you're not quite sure what the outcome
-will be.`
+will be.
+
+When the deployment configuration uses the BlueGreen strategy and the previous
+deployment is still running, its service is switched back to that deployment
+right away, before the rolled back configuration is deployed.`
 
 	rollbackExample = `  # Perform a rollback to the last successfully completed deployment for a deploymentconfig
   %[1]s rollback frontend
@@ -185,6 +190,7 @@ func (o *RollbackOptions) Run() error {
 
 	// Interpret the resource to resolve a target for rollback.
 	var target *kapi.ReplicationController
+	var config *deployapi.DeploymentConfig
 	switch r := obj.(type) {
 	case *kapi.ReplicationController:
 		dcName := deployutil.DeploymentConfigNameFor(r)
@@ -198,6 +204,7 @@ func (o *RollbackOptions) Run() error {
 
 		// A specific deployment was used.
 		target = r
+		config = dc
 	case *deployapi.DeploymentConfig:
 		if r.Spec.Paused {
 			return fmt.Errorf("cannot rollback a paused deployment config")
@@ -210,6 +217,7 @@ func (o *RollbackOptions) Run() error {
 			return err
 		}
 		target = deployment
+		config = r
 	}
 	if target == nil {
 		return fmt.Errorf("%s is not a valid deployment or deployment config", o.TargetName)
@@ -262,6 +270,21 @@ func (o *RollbackOptions) Run() error {
 
 	// Print warnings about any image triggers disabled during the rollback.
 	fmt.Fprintf(o.out, "#%d rolled back to %s\n", rolledback.Status.LatestVersion, rollback.Spec.From.Name)
+
+	// A blue-green deployment keeps the previous deployment running for a
+	// while, so its service can be switched back without waiting.
+	if params := config.Spec.Strategy.BlueGreenParams; config.Spec.Strategy.Type == deployapi.DeploymentStrategyTypeBlueGreen && params != nil && target.Spec.Replicas > 0 {
+		if _, err := bluegreen.SwitchService(o.kc, target.Namespace, params.ServiceName, target); err != nil {
+			return err
+		}
+		fmt.Fprintf(o.out, "Switched service %s back to %s\n", params.ServiceName, target.Name)
+		if len(params.RouteName) > 0 {
+			if _, err := bluegreen.PointRoute(o.kc, o.oc, target.Namespace, params.RouteName, params.ServiceName, target); err != nil {
+				return err
+			}
+			fmt.Fprintf(o.out, "Pointed route %s back at %s\n", params.RouteName, target.Name)
+		}
+	}
 	for _, trigger := range rolledback.Spec.Triggers {
 		disabled := []string{}
 		if trigger.Type == deployapi.DeploymentTriggerOnImageChange && !trigger.ImageChangeParams.Automatic {
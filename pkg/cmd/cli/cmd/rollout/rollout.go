@@ -0,0 +1,44 @@
+package rollout
+
+import (
+	"fmt"
+	"io"
+
+	"github.com/spf13/cobra"
+
+	"github.com/openshift/origin/pkg/cmd/templates"
+	cmdutil "github.com/openshift/origin/pkg/cmd/util"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+)
+
+const (
+	rolloutLong = `
+Manage the rollout of a deployment config
+
+These commands help you follow and control the rollout of the latest deployment of a
+deployment config.`
+)
+
+// NewCmdRollout exposes commands for managing the rollout of deployment configs.
+func NewCmdRollout(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	rollout := &cobra.Command{
+		Use:   "rollout COMMAND",
+		Short: "Manage the rollout of a deployment config",
+		Long:  rolloutLong,
+		Run:   cmdutil.DefaultSubCommandRun(out),
+	}
+
+	name := fmt.Sprintf("%s rollout", fullName)
+
+	groups := templates.CommandGroups{
+		{
+			Message: "Available Commands:",
+			Commands: []*cobra.Command{
+				NewCmdRolloutStatus(name, f, out),
+			},
+		},
+	}
+	groups.Add(rollout)
+	templates.ActsAsRootCommand(rollout, []string{"options"}, groups...)
+	return rollout
+}
@@ -0,0 +1,180 @@
+package rollout
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/fields"
+	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/kubectl/resource"
+	"k8s.io/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+)
+
+const (
+	rolloutStatusLong = `
+Watch the status of the latest rollout
+
+Shows the status of the latest rollout of a deployment config from its Progressing condition
+and, unless --watch=false is given, waits until the rollout finishes. The command exits with a
+non-zero status if the rollout fails or is cancelled.`
+
+	rolloutStatusExample = `  # Watch the status of the latest rollout of the 'frontend' deployment config
+  %[1]s dc/frontend
+
+  # Show the status of the latest rollout without waiting for it to finish
+  %[1]s dc/frontend --watch=false`
+)
+
+// RolloutStatusOptions holds all the options for the `rollout status` command.
+type RolloutStatusOptions struct {
+	Out       io.Writer
+	Namespace string
+	Name      string
+	Watch     bool
+
+	Builder *resource.Builder
+	Client  client.DeploymentConfigsNamespacer
+}
+
+// NewCmdRolloutStatus creates a new `rollout status` command.
+func NewCmdRolloutStatus(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Command {
+	options := &RolloutStatusOptions{Out: out, Watch: true}
+
+	cmd := &cobra.Command{
+		Use:     "status DEPLOYMENTCONFIG",
+		Short:   "Watch the status of the latest rollout",
+		Long:    rolloutStatusLong,
+		Example: fmt.Sprintf(rolloutStatusExample, fullName+" status"),
+		Run: func(cmd *cobra.Command, args []string) {
+			if err := options.Complete(f, args); err != nil {
+				kcmdutil.CheckErr(kcmdutil.UsageError(cmd, err.Error()))
+			}
+			kcmdutil.CheckErr(options.Run())
+		},
+	}
+
+	cmd.Flags().BoolVarP(&options.Watch, "watch", "w", options.Watch, "Watch the status of the rollout until it finishes.")
+
+	return cmd
+}
+
+// Complete fills in the options from the factory and the arguments.
+func (o *RolloutStatusOptions) Complete(f *clientcmd.Factory, args []string) error {
+	if len(args) != 1 {
+		return errors.New("a deployment config name is required")
+	}
+	o.Name = args[0]
+	if strings.Index(o.Name, "/") == -1 {
+		o.Name = "dc/" + o.Name
+	}
+
+	var err error
+	if o.Namespace, _, err = f.DefaultNamespace(); err != nil {
+		return err
+	}
+	if o.Client, _, err = f.Clients(); err != nil {
+		return err
+	}
+	mapper, typer := f.Object(false)
+	o.Builder = resource.NewBuilder(mapper, typer, resource.ClientMapperFunc(f.ClientForMapping), kapi.Codecs.UniversalDecoder())
+	return nil
+}
+
+// Run prints the status of the latest rollout of the deployment config and,
+// when watching, waits for the rollout to finish.
+func (o *RolloutStatusOptions) Run() error {
+	obj, err := o.Builder.
+		NamespaceParam(o.Namespace).
+		ResourceTypeOrNameArgs(false, o.Name).
+		SingleResourceType().
+		Do().
+		Object()
+	if err != nil {
+		return err
+	}
+	config, ok := obj.(*deployapi.DeploymentConfig)
+	if !ok {
+		return fmt.Errorf("%s is not a deployment config", o.Name)
+	}
+
+	message, done, err := RolloutStatus(config)
+	if err != nil || done || !o.Watch {
+		if len(message) > 0 {
+			fmt.Fprintln(o.Out, message)
+		}
+		return err
+	}
+	fmt.Fprintln(o.Out, message)
+
+	w, err := o.Client.DeploymentConfigs(config.Namespace).Watch(kapi.ListOptions{
+		FieldSelector:   fields.OneTermEqualSelector("metadata.name", config.Name),
+		ResourceVersion: config.ResourceVersion,
+	})
+	if err != nil {
+		return err
+	}
+	defer w.Stop()
+	for event := range w.ResultChan() {
+		switch event.Type {
+		case watch.Deleted:
+			return fmt.Errorf("deployment config %q was deleted", config.Name)
+		case watch.Error:
+			return fmt.Errorf("error watching deployment config %q: %v", config.Name, event.Object)
+		}
+		config, ok := event.Object.(*deployapi.DeploymentConfig)
+		if !ok {
+			continue
+		}
+		next, done, err := RolloutStatus(config)
+		if err != nil {
+			return err
+		}
+		if next != message {
+			fmt.Fprintln(o.Out, next)
+			message = next
+		}
+		if done {
+			return nil
+		}
+	}
+	return fmt.Errorf("watch of deployment config %q closed before the rollout finished", config.Name)
+}
+
+// RolloutStatus describes the latest rollout of config from its Progressing
+// condition. It returns true once the rollout has finished, and an error if the
+// rollout failed, was cancelled or cannot proceed.
+func RolloutStatus(config *deployapi.DeploymentConfig) (string, bool, error) {
+	// Bumping the latest version increments the generation, so once it is
+	// observed the conditions describe the rollout of the latest deployment.
+	if config.Status.ObservedGeneration < config.Generation {
+		return fmt.Sprintf("Waiting for the latest version of deployment config %q to be observed...", config.Name), false, nil
+	}
+	if config.Status.LatestVersion == 0 {
+		return fmt.Sprintf("Waiting for the first rollout of deployment config %q...", config.Name), false, nil
+	}
+	name := deployutil.LatestDeploymentNameForConfig(config)
+	progressing := deployutil.GetDeploymentCondition(config.Status, deployapi.DeploymentProgressing)
+	if progressing == nil {
+		return fmt.Sprintf("Waiting for rollout of %q to start...", name), false, nil
+	}
+
+	switch {
+	case progressing.Status == kapi.ConditionFalse:
+		return "", true, fmt.Errorf("rollout of %q failed: %s", name, progressing.Message)
+	case progressing.Reason == deployapi.PausedConfigReason:
+		return "", true, fmt.Errorf("deployment config %q is paused, resume it to continue the rollout", config.Name)
+	case progressing.Reason == deployapi.NewReplicationControllerAvailableReason:
+		return fmt.Sprintf("Replication controller %q successfully rolled out", name), true, nil
+	}
+	return fmt.Sprintf("Waiting for rollout of %q to finish: %d out of %d new replicas have been updated...", name, config.Status.UpdatedReplicas, config.Spec.Replicas), false, nil
+}
@@ -0,0 +1,93 @@
+package rollout
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deploytest "github.com/openshift/origin/pkg/deploy/api/test"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+)
+
+func TestRolloutStatus(t *testing.T) {
+	tests := []struct {
+		name        string
+		generation  int64
+		progressing *deployapi.DeploymentCondition
+
+		expectedDone bool
+		expectedErr  bool
+	}{
+		{
+			name:        "not observed yet",
+			generation:  2,
+			progressing: deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionTrue, deployapi.NewReplicationControllerAvailableReason, `Replication controller "config-1" successfully rolled out`),
+		},
+		{
+			name: "not started yet",
+		},
+		{
+			name:        "previous version complete",
+			generation:  2,
+			progressing: deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionTrue, deployapi.NewReplicationControllerAvailableReason, `Replication controller "config-0" successfully rolled out`),
+		},
+		{
+			name:        "latest version not created yet",
+			progressing: deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionUnknown, deployapi.ReplicationControllerPendingReason, `Waiting for replication controller "config-1" to be created for version 1`),
+		},
+		{
+			name:         "latest version can't be created",
+			progressing:  deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionFalse, deployapi.FailedReplicationControllerCreateReason, "exceeded quota"),
+			expectedDone: true,
+			expectedErr:  true,
+		},
+		{
+			name:        "in progress",
+			progressing: deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionTrue, deployapi.ReplicationControllerUpdatedReason, `Replication controller "config-1" is being rolled out`),
+		},
+		{
+			name:         "complete",
+			progressing:  deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionTrue, deployapi.NewReplicationControllerAvailableReason, `Replication controller "config-1" successfully rolled out`),
+			expectedDone: true,
+		},
+		{
+			name:         "failed",
+			progressing:  deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionFalse, deployapi.RolloutFailedReason, `Replication controller "config-1" has failed progressing: deployer pod failed`),
+			expectedDone: true,
+			expectedErr:  true,
+		},
+		{
+			name:         "cancelled",
+			progressing:  deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionFalse, deployapi.RolloutCancelledReason, `Rollout of replication controller "config-1" was cancelled`),
+			expectedDone: true,
+			expectedErr:  true,
+		},
+		{
+			name:         "paused",
+			progressing:  deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionUnknown, deployapi.PausedConfigReason, ""),
+			expectedDone: true,
+			expectedErr:  true,
+		},
+	}
+
+	for _, test := range tests {
+		config := deploytest.OkDeploymentConfig(1)
+		config.Generation = test.generation
+		config.Status.ObservedGeneration = 1
+		if test.progressing != nil {
+			config.Status.Conditions = []deployapi.DeploymentCondition{*test.progressing}
+		}
+
+		message, done, err := RolloutStatus(config)
+		if done != test.expectedDone {
+			t.Errorf("%s: expected done to be %t, got %t (%s)", test.name, test.expectedDone, done, message)
+		}
+		if test.expectedErr && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+		if !test.expectedErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+	}
+}
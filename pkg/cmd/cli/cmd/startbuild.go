@@ -24,8 +24,11 @@ import (
 	kclientcmd "k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
 	"k8s.io/kubernetes/pkg/fields"
 	kcmdutil "k8s.io/kubernetes/pkg/kubectl/cmd/util"
+	"k8s.io/kubernetes/pkg/labels"
+	kvalidation "k8s.io/kubernetes/pkg/util/validation"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildutil "github.com/openshift/origin/pkg/build/util"
 	osclient "github.com/openshift/origin/pkg/client"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
@@ -48,6 +51,10 @@ used to control which branch, tag, or commit is sent to the server. If you pass
 file is placed in the root of an empty directory with the same filename. Note that builds
 triggered from binary input will not preserve the source on the server, so rebuilds triggered by
 base image changes will use the source specified on the build config.
+
+If the build config declares a build matrix, pass the --matrix flag to start one build for every
+combination of its builder images and environment variable sets. The builds are labeled with the
+name of the group they were started in, and --wait waits for all of them to complete.
 `
 
 	startBuildExample = `  # Starts build from build config "hello-world"
@@ -68,7 +75,11 @@ base image changes will use the source specified on the build config.
 
   # Start a new build for build config "hello-world" and wait until the build completes. It
   # exits with a non-zero return code if the build fails.
-  %[1]s start-build hello-world --wait`
+  %[1]s start-build hello-world --wait
+
+  # Start the builds of the build matrix of build config "hello-world" and wait until all of
+  # them complete.
+  %[1]s start-build hello-world --matrix --wait`
 )
 
 // NewCmdStartBuild implements the OpenShift cli start-build command
@@ -92,13 +103,14 @@ func NewCmdStartBuild(fullName string, f *clientcmd.Factory, in io.Reader, out i
 
 	cmd.Flags().BoolVar(&o.Follow, "follow", o.Follow, "Start a build and watch its logs until it completes or fails")
 	cmd.Flags().BoolVar(&o.WaitForComplete, "wait", o.WaitForComplete, "Wait for a build to complete and exit with a non-zero return code if the build fails")
+	cmd.Flags().BoolVar(&o.Matrix, "matrix", o.Matrix, "Start one build for every entry of the build matrix of the build config")
 
 	cmd.Flags().StringVar(&o.FromFile, "from-file", o.FromFile, "A file to use as the binary input for the build; example a pom.xml or Dockerfile. Will be the only file in the build source.")
 	cmd.Flags().StringVar(&o.FromDir, "from-dir", o.FromDir, "A directory to archive and use as the binary input for a build.")
 	cmd.Flags().StringVar(&o.FromRepo, "from-repo", o.FromRepo, "The path to a local source code repository to use as the binary input for a build.")
 	cmd.Flags().StringVar(&o.Commit, "commit", o.Commit, "Specify the source code commit identifier the build should use; requires a build based on a Git repository")
 
-	cmd.Flags().StringVar(&o.ListWebhooks, "list-webhooks", o.ListWebhooks, "List the webhooks for the specified build config or build; accepts 'all', 'generic', or 'github'")
+	cmd.Flags().StringVar(&o.ListWebhooks, "list-webhooks", o.ListWebhooks, "List the webhooks for the specified build config or build; accepts 'all', 'generic', 'github', 'gitlab', or 'bitbucket'")
 	cmd.Flags().StringVar(&o.FromWebhook, "from-webhook", o.FromWebhook, "Specify a webhook URL for an existing build config to trigger")
 
 	cmd.Flags().StringVar(&o.GitPostReceive, "git-post-receive", o.GitPostReceive, "The contents of the post-receive hook to trigger a build")
@@ -126,6 +138,7 @@ type StartBuildOptions struct {
 
 	Follow          bool
 	WaitForComplete bool
+	Matrix          bool
 	LogLevel        string
 
 	GitRepository  string
@@ -167,6 +180,10 @@ func (o *StartBuildOptions) Complete(f *clientcmd.Factory, in io.Reader, out io.
 
 	o.AsBinary = len(fromFile) > 0 || len(fromDir) > 0 || len(fromRepo) > 0
 
+	if o.Matrix && (len(buildName) > 0 || o.AsBinary || o.Follow) {
+		return kcmdutil.UsageError(cmd, "The '--matrix' flag is incompatible with '--follow', '--from-build' and the binary input flags")
+	}
+
 	namespace, _, err := f.DefaultNamespace()
 	if err != nil {
 		return err
@@ -263,6 +280,10 @@ func (o *StartBuildOptions) Run() error {
 		}
 	}
 
+	if o.Matrix {
+		return o.RunStartBuildMatrix(request)
+	}
+
 	var err error
 	var newBuild *buildapi.Build
 	switch {
@@ -347,20 +368,84 @@ func (o *StartBuildOptions) Run() error {
 	return exitErr
 }
 
+// buildMatrixGroupSuffixLength is the length of the dash and the random
+// characters the name generator appends to the name of a build matrix group.
+const buildMatrixGroupSuffixLength = 6
+
+// buildMatrixGroup returns a new group for a run of the build matrix of the
+// named build config. The group is the value of the build matrix label of the
+// builds, so the name is truncated to leave room for the random suffix within
+// the length of a label value, and every run still gets its own group.
+func buildMatrixGroup(name string) string {
+	if max := kvalidation.DNS1123LabelMaxLength - buildMatrixGroupSuffixLength; len(name) > max {
+		name = name[:max]
+	}
+	return kapi.SimpleNameGenerator.GenerateName(name + "-")
+}
+
+// RunStartBuildMatrix starts one build for every entry of the build matrix of
+// the build config, all in the same group, and waits for the group to complete
+// if requested.
+func (o *StartBuildOptions) RunStartBuildMatrix(request *buildapi.BuildRequest) error {
+	config, err := o.Client.BuildConfigs(o.Namespace).Get(o.Name)
+	if err != nil {
+		return err
+	}
+	if config.Spec.Matrix == nil {
+		return fmt.Errorf("the build config %s/%s does not declare a build matrix", o.Namespace, o.Name)
+	}
+
+	group := buildMatrixGroup(config.Name)
+	names := []string{}
+	for _, entry := range buildutil.BuildMatrixEntries(config.Spec.Matrix, group) {
+		entry := entry
+		request.Matrix = &entry
+		newBuild, err := o.Client.BuildConfigs(o.Namespace).Instantiate(request)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintln(o.Out, newBuild.Name)
+		names = append(names, newBuild.Name)
+	}
+	fmt.Fprintf(o.ErrOut, "Started build matrix %s, list its builds with the label %s=%s\n", group, buildapi.BuildMatrixLabel, group)
+
+	if !o.WaitForComplete {
+		return nil
+	}
+	for _, name := range names {
+		if err := WaitForBuildComplete(o.Client.Builds(o.Namespace), name); err != nil {
+			fmt.Fprintf(o.ErrOut, "%v\n", err)
+		}
+	}
+	selector := labels.SelectorFromSet(labels.Set{buildapi.BuildMatrixLabel: buildapi.LabelValue(group)})
+	builds, err := o.Client.Builds(o.Namespace).List(kapi.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return err
+	}
+	if phase := buildutil.BuildMatrixPhase(builds.Items); phase != buildapi.BuildPhaseComplete {
+		return fmt.Errorf("the build matrix %s/%s status is %q", o.Namespace, group, phase)
+	}
+	return nil
+}
+
 // RunListBuildWebHooks prints the webhooks for the provided build config.
 func (o *StartBuildOptions) RunListBuildWebHooks() error {
-	generic, github := false, false
+	generic, github, gitlab, bitbucket := false, false, false, false
 	prefix := false
 	switch o.ListWebhooks {
 	case "all":
-		generic, github = true, true
+		generic, github, gitlab, bitbucket = true, true, true, true
 		prefix = true
 	case "generic":
 		generic = true
 	case "github":
 		github = true
+	case "gitlab":
+		gitlab = true
+	case "bitbucket":
+		bitbucket = true
 	default:
-		return fmt.Errorf("--list-webhooks must be 'all', 'generic', or 'github'")
+		return fmt.Errorf("--list-webhooks must be 'all', 'generic', 'github', 'gitlab', or 'bitbucket'")
 	}
 	client := o.Client
 
@@ -380,6 +465,14 @@ func (o *StartBuildOptions) RunListBuildWebHooks() error {
 			if prefix {
 				hookType = "github "
 			}
+		case t.GitLabWebHook != nil && gitlab:
+			if prefix {
+				hookType = "gitlab "
+			}
+		case t.BitbucketWebHook != nil && bitbucket:
+			if prefix {
+				hookType = "bitbucket "
+			}
 		default:
 			continue
 		}
@@ -12,11 +12,16 @@ import (
 	"strings"
 	"testing"
 
+	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/client/restclient"
 	kclientcmd "k8s.io/kubernetes/pkg/client/unversioned/clientcmd"
 	clientcmdapi "k8s.io/kubernetes/pkg/client/unversioned/clientcmd/api"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+	kvalidation "k8s.io/kubernetes/pkg/util/validation"
 
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client/testclient"
 )
 
 type FakeClientConfig struct {
@@ -141,3 +146,98 @@ func TestStartBuildHookPostReceive(t *testing.T) {
 		t.Fatalf("unexpected ref: %#v", event.Git.Refs[0])
 	}
 }
+
+func TestStartBuildMatrix(t *testing.T) {
+	config := &buildapi.BuildConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "app", Namespace: "test"},
+		Spec: buildapi.BuildConfigSpec{
+			Matrix: &buildapi.BuildMatrix{
+				Images: []buildapi.BuildMatrixImage{{Name: "py27"}, {Name: "py35"}},
+				Env:    []buildapi.BuildMatrixEnv{{Name: "debug"}},
+			},
+		},
+	}
+	client := testclient.NewSimpleFake(config)
+	requests := []*buildapi.BuildRequest{}
+	client.PrependReactor("create", "buildconfigs", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		request := action.(ktestclient.CreateAction).GetObject().(*buildapi.BuildRequest)
+		entry := *request.Matrix
+		requests = append(requests, &buildapi.BuildRequest{Matrix: &entry})
+		return true, &buildapi.Build{ObjectMeta: kapi.ObjectMeta{Name: fmt.Sprintf("app-%d", len(requests))}}, nil
+	})
+
+	out, errOut := &bytes.Buffer{}, &bytes.Buffer{}
+	o := &StartBuildOptions{
+		Out:       out,
+		ErrOut:    errOut,
+		Client:    client,
+		Matrix:    true,
+		Name:      "app",
+		Namespace: "test",
+	}
+	if err := o.Run(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if out.String() != "app-1\napp-2\n" {
+		t.Errorf("unexpected output: %q", out.String())
+	}
+	if len(requests) != 2 {
+		t.Fatalf("expected 2 builds to be started, got %d", len(requests))
+	}
+	group := requests[0].Matrix.Group
+	if !strings.HasPrefix(group, "app-") || requests[1].Matrix.Group != group {
+		t.Errorf("expected the builds to be started in the same group, got %#v and %#v", requests[0].Matrix, requests[1].Matrix)
+	}
+	if entry := requests[1].Matrix; entry.Image != "py35" || entry.Env != "debug" {
+		t.Errorf("unexpected matrix entry %#v", entry)
+	}
+
+	config.Spec.Matrix = nil
+	if err := o.Run(); err == nil {
+		t.Errorf("expected an error for a build config without a build matrix")
+	}
+}
+
+func TestStartBuildMatrixLongName(t *testing.T) {
+	for _, length := range []int{57, 58, 62, 63} {
+		name := strings.Repeat("a", length)
+		config := &buildapi.BuildConfig{
+			ObjectMeta: kapi.ObjectMeta{Name: name, Namespace: "test"},
+			Spec: buildapi.BuildConfigSpec{
+				Matrix: &buildapi.BuildMatrix{Images: []buildapi.BuildMatrixImage{{Name: "py27"}}},
+			},
+		}
+		client := testclient.NewSimpleFake(config)
+		groups := []string{}
+		client.PrependReactor("create", "buildconfigs", func(action ktestclient.Action) (bool, runtime.Object, error) {
+			groups = append(groups, action.(ktestclient.CreateAction).GetObject().(*buildapi.BuildRequest).Matrix.Group)
+			return true, &buildapi.Build{ObjectMeta: kapi.ObjectMeta{Name: name + "-1"}}, nil
+		})
+
+		// every run of the matrix gets its own group
+		for i := 0; i < 2; i++ {
+			o := &StartBuildOptions{
+				Out:       &bytes.Buffer{},
+				ErrOut:    &bytes.Buffer{},
+				Client:    client,
+				Matrix:    true,
+				Name:      name,
+				Namespace: "test",
+			}
+			if err := o.Run(); err != nil {
+				t.Fatalf("%d: unexpected error: %v", length, err)
+			}
+		}
+		if len(groups) != 2 || groups[0] == groups[1] {
+			t.Errorf("%d: expected two runs to get different groups, got %v", length, groups)
+		}
+		for _, group := range groups {
+			if !kvalidation.IsValidLabelValue(group) || strings.HasSuffix(group, "-") {
+				t.Errorf("%d: expected the group to be a valid label value, got %q", length, group)
+			}
+			if !strings.HasPrefix(group, name[:57]+"-") {
+				t.Errorf("%d: expected the group to be prefixed with the build config name, got %q", length, group)
+			}
+		}
+	}
+}
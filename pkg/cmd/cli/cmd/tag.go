@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"strings"
+	"time"
 
 	"github.com/golang/glog"
 	"github.com/spf13/cobra"
@@ -33,6 +34,8 @@ type TagOptions struct {
 	referenceTag bool
 	namespace    string
 
+	scheduleInterval time.Duration
+
 	ref            imageapi.DockerImageReference
 	sourceKind     string
 	destNamespace  []string
@@ -52,7 +55,9 @@ Pass the --insecure flag if your external registry does not have a valid HTTPS
 certificate, or is only served over HTTP. Pass --scheduled to have the server
 regularly check the tag for updates and import the latest version (which can
 then trigger builds and deployments). Note that --scheduled is only allowed for
-Docker images.
+Docker images. The server checks scheduled tags at an interval configured for
+the cluster; pass --scheduled-interval to check a tag less often. Imports that
+keep failing are retried less and less often.
 `
 
 	tagExample = `  # Tag the current image for the image stream 'openshift/ruby' and tag '2.0' into the image stream 'yourproject/ruby with tag 'tip'.
@@ -64,6 +69,9 @@ Docker images.
   # Tag an external Docker image.
   %[1]s tag --source=docker openshift/origin:latest yourproject/ruby:tip
 
+  # Tag an external Docker image and check it for updates every 6 hours.
+  %[1]s tag --scheduled --scheduled-interval=6h docker.io/openshift/origin:latest yourproject/origin:latest
+
   # Remove the specified spec tag from an image stream.
   %[1]s tag openshift/origin:latest -d`
 )
@@ -89,6 +97,7 @@ func NewCmdTag(fullName string, f *clientcmd.Factory, out io.Writer) *cobra.Comm
 	cmd.Flags().BoolVar(&opts.aliasTag, "alias", false, "Should the destination tag be updated whenever the source tag changes. Defaults to false.")
 	cmd.Flags().BoolVar(&opts.referenceTag, "reference", false, "Should the destination tag continue to pull from the source namespace. Defaults to false.")
 	cmd.Flags().BoolVar(&opts.scheduleTag, "scheduled", false, "Set a Docker image to be periodically imported from a remote repository.")
+	cmd.Flags().DurationVar(&opts.scheduleInterval, "scheduled-interval", 0, "The minimum interval between two periodic imports of a scheduled Docker image. Defaults to the interval configured for the cluster.")
 	cmd.Flags().BoolVar(&opts.insecureTag, "insecure", false, "Set to true if importing the specified Docker image requires HTTP or has a self-signed certificate.")
 
 	return cmd
@@ -280,7 +289,7 @@ func (o TagOptions) Validate() error {
 		if len(o.ref.String()) > 0 {
 			return errors.New("cannot specify a source when deleting")
 		}
-		if o.scheduleTag || o.insecureTag {
+		if o.scheduleTag || o.insecureTag || o.scheduleInterval != 0 {
 			return errors.New("cannot set flags for importing images when deleting a tag")
 		}
 	} else {
@@ -305,6 +314,12 @@ func (o TagOptions) Validate() error {
 	if o.aliasTag && (o.scheduleTag || o.insecureTag) {
 		return errors.New("cannot set a Docker image tag as an alias and also set import flags")
 	}
+	if o.scheduleInterval != 0 && o.scheduleInterval < time.Second {
+		return errors.New("--scheduled-interval must be at least one second")
+	}
+	if o.scheduleInterval != 0 && !o.scheduleTag {
+		return errors.New("--scheduled-interval may only be set with --scheduled")
+	}
 
 	return nil
 }
@@ -362,6 +377,11 @@ func (o TagOptions) RunTag() error {
 				targetRef.Reference = o.referenceTag
 				targetRef.ImportPolicy.Insecure = o.insecureTag
 				targetRef.ImportPolicy.Scheduled = o.scheduleTag
+				targetRef.ImportPolicy.IntervalSeconds = nil
+				if o.scheduleInterval > 0 {
+					seconds := int64(o.scheduleInterval / time.Second)
+					targetRef.ImportPolicy.IntervalSeconds = &seconds
+				}
 				targetRef.From = &kapi.ObjectReference{
 					Kind: o.sourceKind,
 				}
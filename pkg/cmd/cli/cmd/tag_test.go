@@ -3,6 +3,7 @@ package cmd
 import (
 	"os"
 	"testing"
+	"time"
 
 	"k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
@@ -132,3 +133,45 @@ func TestRunTag_Delete(t *testing.T) {
 		}
 	}
 }
+
+func TestRunTag_AddScheduled(t *testing.T) {
+	streams := testData()
+	client := testclient.NewSimpleFake(streams[0])
+
+	opts := &TagOptions{
+		out:      os.Stdout,
+		osClient: client,
+		ref: imageapi.DockerImageReference{
+			Registry:  "docker.io",
+			Namespace: "openshift",
+			Name:      "origin",
+			Tag:       "latest",
+		},
+		sourceKind:       "DockerImage",
+		scheduleTag:      true,
+		scheduleInterval: 6 * time.Hour,
+		destNamespace:    []string{"yourproject"},
+		destNameAndTag:   []string{"rails:tip"},
+	}
+	if err := opts.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := opts.RunTag(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := client.Actions()
+	if len(got) != 2 || !got[1].Matches("update", "imagestreams") {
+		t.Fatalf("unexpected actions: %#v", got)
+	}
+	stream := got[1].(ktc.UpdateAction).GetObject().(*imageapi.ImageStream)
+	policy := stream.Spec.Tags["tip"].ImportPolicy
+	if !policy.Scheduled || policy.IntervalSeconds == nil || *policy.IntervalSeconds != 6*60*60 {
+		t.Errorf("unexpected import policy: %#v", policy)
+	}
+
+	opts.scheduleTag = false
+	if err := opts.Validate(); err == nil {
+		t.Errorf("expected an error for an interval without --scheduled")
+	}
+}
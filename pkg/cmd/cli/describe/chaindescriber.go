@@ -102,9 +102,9 @@ func (d *ChainDescriber) Describe(ist *imageapi.ImageStreamTag, includeInputImag
 		}
 	}
 
-	buildInputEdgeKinds := []string{buildedges.BuildTriggerImageEdgeKind}
+	buildInputEdgeKinds := []string{buildedges.BuildTriggerImageEdgeKind, buildedges.BuildTriggerBuildConfigEdgeKind}
 	if includeInputImages {
-		buildInputEdgeKinds = append(buildInputEdgeKinds, buildedges.BuildInputImageEdgeKind)
+		buildInputEdgeKinds = append(buildInputEdgeKinds, buildedges.BuildInputImageEdgeKind, buildedges.BuildInputBuildConfigEdgeKind)
 	}
 
 	// Partition down to the subgraph containing the imagestreamtag of interest
@@ -133,7 +133,7 @@ func (d *ChainDescriber) Describe(ist *imageapi.ImageStreamTag, includeInputImag
 func partition(g osgraph.Graph, root graph.Node, buildInputEdgeKinds []string) osgraph.Graph {
 	// Filter out all but BuildConfig and ImageStreamTag nodes
 	nodeFn := osgraph.NodesOfKind(buildgraph.BuildConfigNodeKind, imagegraph.ImageStreamTagNodeKind)
-	// Filter out all but BuildInputImage and BuildOutput edges
+	// Filter out all but build input, build trigger and BuildOutput edges
 	edgeKinds := []string{}
 	edgeKinds = append(edgeKinds, buildInputEdgeKinds...)
 	edgeKinds = append(edgeKinds, buildedges.BuildOutputEdgeKind)
@@ -164,7 +164,7 @@ func partition(g osgraph.Graph, root graph.Node, buildInputEdgeKinds []string) o
 func partitionReverse(g osgraph.Graph, root graph.Node, buildInputEdgeKinds []string) osgraph.Graph {
 	// Filter out all but BuildConfig and ImageStreamTag nodes
 	nodeFn := osgraph.NodesOfKind(buildgraph.BuildConfigNodeKind, imagegraph.ImageStreamTagNodeKind)
-	// Filter out all but BuildInputImage and BuildOutput edges
+	// Filter out all but build input, build trigger and BuildOutput edges
 	edgeKinds := []string{}
 	edgeKinds = append(edgeKinds, buildInputEdgeKinds...)
 	edgeKinds = append(edgeKinds, buildedges.BuildOutputEdgeKind)
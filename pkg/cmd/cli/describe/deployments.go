@@ -7,6 +7,7 @@ import (
 	"strconv"
 	"strings"
 	"text/tabwriter"
+	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
@@ -16,6 +17,7 @@ import (
 	rcutils "k8s.io/kubernetes/pkg/controller/replication"
 	kctl "k8s.io/kubernetes/pkg/kubectl"
 	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/util/sets"
 
 	"github.com/openshift/origin/pkg/api/graph"
 	kubegraph "github.com/openshift/origin/pkg/api/kubegraph/nodes"
@@ -75,6 +77,19 @@ func (d *DeploymentConfigDescriber) Describe(namespace, name string, settings kc
 			fmt.Fprintf(out, "Warning:\t%s\n", deploymentConfig.Status.Details.Message)
 		}
 
+		if pending := deploymentConfig.Status.PendingDeployment; pending != nil {
+			printPendingDeployment(pending, out)
+			fmt.Fprintln(out)
+		}
+
+		if len(deploymentConfig.Status.Conditions) > 0 {
+			fmt.Fprint(out, "Conditions:\n  Type\tStatus\tReason\n  ----\t------\t------\n")
+			for _, c := range deploymentConfig.Status.Conditions {
+				fmt.Fprintf(out, "  %v \t%v \t%s\n", c.Type, c.Status, c.Reason)
+			}
+			fmt.Fprintln(out)
+		}
+
 		deploymentName := deployutil.LatestDeploymentNameForConfig(deploymentConfig)
 		deployment, err := d.kubeClient.ReplicationControllers(namespace).Get(deploymentName)
 		if err != nil {
@@ -167,6 +182,81 @@ func printStrategy(strategy deployapi.DeploymentStrategy, indent string, w *tabw
 			printHook("Post-deployment", post, indent, w)
 		}
 	}
+
+	if strategy.CanaryParams != nil {
+		params := strategy.CanaryParams
+		if params.Percent != nil {
+			fmt.Fprintf(w, "%sCanary:\t%d%% of replicas\n", indent, *params.Percent)
+		}
+		if params.BakeSeconds != nil {
+			fmt.Fprintf(w, "%sBake Period:\t%s\n", indent, time.Duration(*params.BakeSeconds)*time.Second)
+		}
+		if gate := params.HealthGate; gate != nil {
+			switch {
+			case gate.ExecNewPod != nil:
+				fmt.Fprintf(w, "%sHealth Gate (pod type):\n", indent)
+				fmt.Fprintf(w, "%s  Container:\t%s\n", indent, gate.ExecNewPod.ContainerName)
+				fmt.Fprintf(w, "%s  Command:\t%v\n", indent, multilineStringArray(" ", "\t  ", gate.ExecNewPod.Command...))
+			case gate.HTTPGet != nil:
+				fmt.Fprintf(w, "%sHealth Gate (http):\t%s\n", indent, formatHTTPGetAction(gate.HTTPGet))
+			}
+		}
+		if params.Pre != nil {
+			printHook("Pre-deployment", params.Pre, indent, w)
+		}
+		if params.Post != nil {
+			printHook("Post-deployment", params.Post, indent, w)
+		}
+	}
+
+	if strategy.BlueGreenParams != nil {
+		params := strategy.BlueGreenParams
+		fmt.Fprintf(w, "%sService:\t%s\n", indent, params.ServiceName)
+		if len(params.RouteName) > 0 {
+			fmt.Fprintf(w, "%sRoute:\t%s\n", indent, params.RouteName)
+		}
+		if params.KeepPreviousSeconds != nil {
+			fmt.Fprintf(w, "%sKeep Previous:\t%s\n", indent, time.Duration(*params.KeepPreviousSeconds)*time.Second)
+		}
+		if params.Pre != nil {
+			printHook("Pre-deployment", params.Pre, indent, w)
+		}
+		if params.Mid != nil {
+			printHook("Mid-deployment", params.Mid, indent, w)
+		}
+		if params.Post != nil {
+			printHook("Post-deployment", params.Post, indent, w)
+		}
+	}
+
+	if strategy.OrderedParams != nil {
+		params := strategy.OrderedParams
+		if check := params.ReadinessCheck; check != nil {
+			fmt.Fprintf(w, "%sReadiness Check (pod type):\n", indent)
+			fmt.Fprintf(w, "%s  Container:\t%s\n", indent, check.ContainerName)
+			fmt.Fprintf(w, "%s  Command:\t%v\n", indent, multilineStringArray(" ", "\t  ", check.Command...))
+		}
+		if params.Pre != nil {
+			printHook("Pre-deployment", params.Pre, indent, w)
+		}
+		if params.Post != nil {
+			printHook("Post-deployment", params.Post, indent, w)
+		}
+	}
+}
+
+// formatHTTPGetAction describes the URL an HTTP health check requests from
+// each pod.
+func formatHTTPGetAction(action *kapi.HTTPGetAction) string {
+	scheme := strings.ToLower(string(action.Scheme))
+	if len(scheme) == 0 {
+		scheme = "http"
+	}
+	host := action.Host
+	if len(host) == 0 {
+		host = "<pod>"
+	}
+	return fmt.Sprintf("%s://%s:%s%s", scheme, host, action.Port.String(), action.Path)
 }
 
 func printHook(prefix string, hook *deployapi.LifecycleHook, indent string, w io.Writer) {
@@ -178,6 +268,16 @@ func printHook(prefix string, hook *deployapi.LifecycleHook, indent string, w io
 			fmt.Fprintf(w, "%s  Env:\t%s\n", indent, formatLabels(convertEnv(hook.ExecNewPod.Env)))
 		}
 	}
+	if hook.ExecInPod != nil {
+		fmt.Fprintf(w, "%s%s hook (exec in pod, failure policy: %s):\n", indent, prefix, hook.FailurePolicy)
+		fmt.Fprintf(w, "%s  Container:\t%s\n", indent, hook.ExecInPod.ContainerName)
+		fmt.Fprintf(w, "%s  Command:\t%v\n", indent, multilineStringArray(" ", "\t  ", hook.ExecInPod.Command...))
+		pods := "one pod"
+		if hook.ExecInPod.AllPods {
+			pods = "all pods"
+		}
+		fmt.Fprintf(w, "%s  Target:\t%s deployment (%s)\n", indent, hook.ExecInPod.Target, pods)
+	}
 	if len(hook.TagImages) > 0 {
 		fmt.Fprintf(w, "%s%s hook (tag images, failure policy: %s):\n", indent, prefix, hook.FailurePolicy)
 		for _, image := range hook.TagImages {
@@ -203,6 +303,10 @@ func printTriggers(triggers []deployapi.DeploymentTriggerPolicy, w *tabwriter.Wr
 				name, tag, _ := imageapi.SplitImageStreamTag(t.ImageChangeParams.From.Name)
 				labels = append(labels, fmt.Sprintf("Image(%s@%s, auto=%v)", name, tag, t.ImageChangeParams.Automatic))
 			}
+		case deployapi.DeploymentTriggerOnResourceChange:
+			if p := t.ResourceChangeParams; p != nil {
+				labels = append(labels, fmt.Sprintf("%s(%s)", p.From.Kind, p.From.Name))
+			}
 		}
 	}
 
@@ -210,6 +314,23 @@ func printTriggers(triggers []deployapi.DeploymentTriggerPolicy, w *tabwriter.Wr
 	formatString(w, "Triggers", desc)
 }
 
+// printPendingDeployment describes a deployment which is held back until the
+// deployment window of its config opens.
+func printPendingDeployment(pending *deployapi.PendingDeployment, w *tabwriter.Writer) {
+	scheduled := pending.ScheduledTime.Time
+	formatString(w, "Pending Deployment", fmt.Sprintf("scheduled for %s (in %s)", scheduled.Format(time.RFC1123Z), formatToHumanDuration(scheduled.Sub(timeNowFn()))))
+	for _, cause := range pending.Causes {
+		switch {
+		case cause.ImageTrigger != nil:
+			fmt.Fprintf(w, "  Cause:\t%s (%s %s)\n", cause.Type, cause.ImageTrigger.From.Kind, cause.ImageTrigger.From.Name)
+		case cause.ResourceTrigger != nil:
+			fmt.Fprintf(w, "  Cause:\t%s (%s %s)\n", cause.Type, cause.ResourceTrigger.From.Kind, cause.ResourceTrigger.From.Name)
+		default:
+			fmt.Fprintf(w, "  Cause:\t%s\n", cause.Type)
+		}
+	}
+}
+
 func printDeploymentConfigSpec(kc kclient.Interface, dc deployapi.DeploymentConfig, w *tabwriter.Writer) error {
 	spec := dc.Spec
 	// Selector
@@ -222,6 +343,20 @@ func printDeploymentConfigSpec(kc kclient.Interface, dc deployapi.DeploymentConf
 	}
 	formatString(w, "Replicas", fmt.Sprintf("%d%s", spec.Replicas, test))
 
+	if spec.AutoRollback {
+		formatString(w, "Auto Rollback", "failed deployments are rolled back to the last complete deployment")
+	}
+	if spec.RevisionHistoryLimit != nil {
+		formatString(w, "Revision History Limit", fmt.Sprintf("%d old deployments are kept", *spec.RevisionHistoryLimit))
+	}
+	if window := spec.DeploymentWindow; window != nil {
+		timeZone := window.TimeZone
+		if len(timeZone) == 0 {
+			timeZone = "UTC"
+		}
+		formatString(w, "Deployment Window", fmt.Sprintf("%s (%s)", window.Schedule, timeZone))
+	}
+
 	// Autoscaling info
 	printAutoscalingInfo(deployapi.Resource("DeploymentConfig"), dc.Namespace, dc.Name, kc, w)
 
@@ -371,3 +506,222 @@ func (d *LatestDeploymentsDescriber) Describe(namespace, name string) (string, e
 		return nil
 	})
 }
+
+// DescribeDeploymentDiff returns a description of the changes to the pod
+// template between the deployment configs encoded in two deployments.
+func DescribeDeploymentDiff(from, to *kapi.ReplicationController) (string, error) {
+	fromConfig, err := deployutil.DecodeDeploymentConfig(from, kapi.Codecs.UniversalDecoder())
+	if err != nil {
+		return "", fmt.Errorf("couldn't decode the deployment config of %s: %v", from.Name, err)
+	}
+	toConfig, err := deployutil.DecodeDeploymentConfig(to, kapi.Codecs.UniversalDecoder())
+	if err != nil {
+		return "", fmt.Errorf("couldn't decode the deployment config of %s: %v", to.Name, err)
+	}
+
+	return tabbedString(func(out *tabwriter.Writer) error {
+		fmt.Fprintf(out, "Comparing deployment #%d with deployment #%d:\n", deployutil.DeploymentVersionFor(from), deployutil.DeploymentVersionFor(to))
+		changes := diffPodTemplates(fromConfig.Spec.Template, toConfig.Spec.Template)
+		if len(changes) == 0 {
+			fmt.Fprintln(out, "  The pod templates are identical.")
+			return nil
+		}
+		for _, change := range changes {
+			fmt.Fprintf(out, "  %s:\t%s -> %s\n", change.field, change.from, change.to)
+		}
+		return nil
+	})
+}
+
+// templateChange is a change to a field of a pod template.
+type templateChange struct {
+	field    string
+	from, to string
+}
+
+const noValue = "<none>"
+
+// diffPodTemplates returns the changes to the containers and volumes between
+// two pod templates.
+func diffPodTemplates(from, to *kapi.PodTemplateSpec) []templateChange {
+	if from == nil {
+		from = &kapi.PodTemplateSpec{}
+	}
+	if to == nil {
+		to = &kapi.PodTemplateSpec{}
+	}
+	changes := []templateChange{}
+	add := func(field, from, to string) {
+		if from != to {
+			changes = append(changes, templateChange{field: field, from: from, to: to})
+		}
+	}
+
+	fromContainers := map[string]kapi.Container{}
+	for _, container := range from.Spec.Containers {
+		fromContainers[container.Name] = container
+	}
+	toContainers := map[string]kapi.Container{}
+	for _, container := range to.Spec.Containers {
+		toContainers[container.Name] = container
+	}
+	for _, container := range from.Spec.Containers {
+		if _, ok := toContainers[container.Name]; !ok {
+			add(fmt.Sprintf("Container %q", container.Name), container.Image, noValue)
+		}
+	}
+	for _, container := range to.Spec.Containers {
+		old, ok := fromContainers[container.Name]
+		if !ok {
+			add(fmt.Sprintf("Container %q", container.Name), noValue, container.Image)
+			continue
+		}
+		prefix := fmt.Sprintf("Container %q", container.Name)
+		add(prefix+" image", old.Image, container.Image)
+		add(prefix+" command", formatValue(strings.Join(old.Command, " ")), formatValue(strings.Join(container.Command, " ")))
+		add(prefix+" args", formatValue(strings.Join(old.Args, " ")), formatValue(strings.Join(container.Args, " ")))
+
+		fromEnv, toEnv := map[string]string{}, map[string]string{}
+		for _, env := range old.Env {
+			fromEnv[env.Name] = formatEnvValue(env)
+		}
+		for _, env := range container.Env {
+			toEnv[env.Name] = formatEnvValue(env)
+		}
+		for _, name := range unionKeys(fromEnv, toEnv) {
+			add(fmt.Sprintf("%s env %s", prefix, name), formatMapValue(fromEnv, name), formatMapValue(toEnv, name))
+		}
+
+		for _, resources := range []struct {
+			name     string
+			from, to kapi.ResourceList
+		}{
+			{"requests", old.Resources.Requests, container.Resources.Requests},
+			{"limits", old.Resources.Limits, container.Resources.Limits},
+		} {
+			fromQuantities, toQuantities := map[string]string{}, map[string]string{}
+			for name, quantity := range resources.from {
+				fromQuantities[string(name)] = quantity.String()
+			}
+			for name, quantity := range resources.to {
+				toQuantities[string(name)] = quantity.String()
+			}
+			for _, name := range unionKeys(fromQuantities, toQuantities) {
+				add(fmt.Sprintf("%s %s %s", prefix, resources.name, name), formatMapValue(fromQuantities, name), formatMapValue(toQuantities, name))
+			}
+		}
+
+		fromMounts, toMounts := map[string]string{}, map[string]string{}
+		for _, mount := range old.VolumeMounts {
+			fromMounts[mount.Name] = formatVolumeMount(mount)
+		}
+		for _, mount := range container.VolumeMounts {
+			toMounts[mount.Name] = formatVolumeMount(mount)
+		}
+		for _, name := range unionKeys(fromMounts, toMounts) {
+			add(fmt.Sprintf("%s mount %s", prefix, name), formatMapValue(fromMounts, name), formatMapValue(toMounts, name))
+		}
+	}
+
+	fromVolumes, toVolumes := map[string]kapi.VolumeSource{}, map[string]kapi.VolumeSource{}
+	for _, volume := range from.Spec.Volumes {
+		fromVolumes[volume.Name] = volume.VolumeSource
+	}
+	for _, volume := range to.Spec.Volumes {
+		toVolumes[volume.Name] = volume.VolumeSource
+	}
+	volumeNames := sets.NewString()
+	for name := range fromVolumes {
+		volumeNames.Insert(name)
+	}
+	for name := range toVolumes {
+		volumeNames.Insert(name)
+	}
+	for _, name := range volumeNames.List() {
+		old, inFrom := fromVolumes[name]
+		source, inTo := toVolumes[name]
+		field := fmt.Sprintf("Volume %q", name)
+		switch {
+		case !inFrom:
+			add(field, noValue, formatVolumeSource(source))
+		case !inTo:
+			add(field, formatVolumeSource(old), noValue)
+		case !kapi.Semantic.DeepEqual(old, source):
+			fromSource, toSource := formatVolumeSource(old), formatVolumeSource(source)
+			if fromSource == toSource {
+				toSource += " (modified)"
+			}
+			add(field, fromSource, toSource)
+		}
+	}
+	return changes
+}
+
+func unionKeys(a, b map[string]string) []string {
+	keys := sets.NewString()
+	for key := range a {
+		keys.Insert(key)
+	}
+	for key := range b {
+		keys.Insert(key)
+	}
+	return keys.List()
+}
+
+func formatValue(value string) string {
+	if len(value) == 0 {
+		return noValue
+	}
+	return value
+}
+
+func formatMapValue(values map[string]string, key string) string {
+	if value, ok := values[key]; ok {
+		return value
+	}
+	return noValue
+}
+
+func formatEnvValue(env kapi.EnvVar) string {
+	if from := env.ValueFrom; from != nil {
+		switch {
+		case from.FieldRef != nil:
+			return fmt.Sprintf("<%s>", from.FieldRef.FieldPath)
+		case from.ConfigMapKeyRef != nil:
+			return fmt.Sprintf("<configmap/%s %s>", from.ConfigMapKeyRef.Name, from.ConfigMapKeyRef.Key)
+		case from.SecretKeyRef != nil:
+			return fmt.Sprintf("<secret/%s %s>", from.SecretKeyRef.Name, from.SecretKeyRef.Key)
+		}
+	}
+	return fmt.Sprintf("%q", env.Value)
+}
+
+func formatVolumeMount(mount kapi.VolumeMount) string {
+	if mount.ReadOnly {
+		return mount.MountPath + " (ro)"
+	}
+	return mount.MountPath
+}
+
+func formatVolumeSource(source kapi.VolumeSource) string {
+	switch {
+	case source.EmptyDir != nil:
+		return "empty directory"
+	case source.HostPath != nil:
+		return fmt.Sprintf("host path %s", source.HostPath.Path)
+	case source.Secret != nil:
+		return fmt.Sprintf("secret/%s", source.Secret.SecretName)
+	case source.ConfigMap != nil:
+		return fmt.Sprintf("configmap/%s", source.ConfigMap.Name)
+	case source.PersistentVolumeClaim != nil:
+		return fmt.Sprintf("pvc/%s", source.PersistentVolumeClaim.ClaimName)
+	case source.GitRepo != nil:
+		return fmt.Sprintf("Git repository %s", source.GitRepo.Repository)
+	case source.DownwardAPI != nil:
+		return "downward API"
+	case source.NFS != nil:
+		return fmt.Sprintf("NFS %s:%s", source.NFS.Server, source.NFS.Path)
+	default:
+		return "other"
+	}
+}
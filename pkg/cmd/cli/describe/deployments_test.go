@@ -5,6 +5,8 @@ import (
 	"testing"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/resource"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/apis/extensions"
 	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	"k8s.io/kubernetes/pkg/kubectl"
@@ -106,4 +108,74 @@ func TestDeploymentConfigDescriber(t *testing.T) {
 		},
 	}
 	describe()
+
+	config.Spec.DeploymentWindow = &deployapi.DeploymentWindow{Schedule: "* 2-4 * * *", TimeZone: "Europe/Prague"}
+	config.Status.PendingDeployment = &deployapi.PendingDeployment{
+		Causes: []deployapi.DeploymentCause{
+			{Type: deployapi.DeploymentTriggerOnConfigChange},
+			{
+				Type: deployapi.DeploymentTriggerOnResourceChange,
+				ResourceTrigger: &deployapi.DeploymentCauseResourceTrigger{
+					From: kapi.ObjectReference{Kind: "Secret", Name: "creds"},
+				},
+			},
+		},
+		ScheduledTime: unversioned.Now(),
+	}
+	out = describe()
+	for _, substr := range []string{
+		"Deployment Window:\t* 2-4 * * * (Europe/Prague)",
+		"Pending Deployment:\tscheduled for",
+		"ConfigChange\n",
+		"ResourceChange (Secret creds)",
+	} {
+		if !strings.Contains(out, substr) {
+			t.Errorf("expected %q in output:\n%s", substr, out)
+		}
+	}
+}
+
+func TestDescribeDeploymentDiff(t *testing.T) {
+	fromConfig := deployapitest.OkDeploymentConfig(1)
+	from, _ := deployutil.MakeDeployment(fromConfig, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+
+	toConfig := deployapitest.OkDeploymentConfig(2)
+	container := &toConfig.Spec.Template.Spec.Containers[0]
+	container.Image = "registry:8080/repo1:ref3"
+	container.Env = append(container.Env, kapi.EnvVar{Name: "ENV2", Value: "VAL2"})
+	container.Resources.Limits = kapi.ResourceList{kapi.ResourceMemory: resource.MustParse("512Mi")}
+	toConfig.Spec.Template.Spec.Containers = toConfig.Spec.Template.Spec.Containers[:1]
+	toConfig.Spec.Template.Spec.Volumes = append(toConfig.Spec.Template.Spec.Volumes, kapi.Volume{
+		Name:         "data",
+		VolumeSource: kapi.VolumeSource{EmptyDir: &kapi.EmptyDirVolumeSource{}},
+	})
+	to, _ := deployutil.MakeDeployment(toConfig, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+
+	out, err := DescribeDeploymentDiff(from, to)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, expected := range []string{
+		"Comparing deployment #1 with deployment #2",
+		`Container "container1" image:`, "registry:8080/repo1:ref1 -> registry:8080/repo1:ref3",
+		`Container "container1" env ENV2:`, `<none> -> "VAL2"`,
+		`Container "container1" limits memory:`, "<none> -> 512Mi",
+		`Container "container2":`, "registry:8080/repo1:ref2 -> <none>",
+		`Volume "data":`, "<none> -> empty directory",
+	} {
+		if !strings.Contains(out, expected) {
+			t.Errorf("expected %q in the diff:\n%s", expected, out)
+		}
+	}
+	if strings.Contains(out, "ENV1") {
+		t.Errorf("unexpected change of an unchanged variable:\n%s", out)
+	}
+
+	out, err = DescribeDeploymentDiff(from, from)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "The pod templates are identical.") {
+		t.Errorf("expected no changes, got:\n%s", out)
+	}
 }
@@ -24,6 +24,7 @@ import (
 
 	authorizationapi "github.com/openshift/origin/pkg/authorization/api"
 	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildutil "github.com/openshift/origin/pkg/build/util"
 	"github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
@@ -127,11 +128,15 @@ func (d *BuildDescriber) Describe(namespace, name string, settings kctl.Describe
 		// Create the time object with second-level precision so we don't get
 		// output like "duration: 1.2724395728934s"
 		formatString(out, "Duration", describeBuildDuration(build))
+		if usage := build.Status.ResourceUsage; usage != nil {
+			formatString(out, "Peak Usage", fmt.Sprintf("cpu %s, memory %s", usage.CPU.String(), usage.Memory.String()))
+		}
 
 		if build.Status.Config != nil {
 			formatString(out, "Build Config", build.Status.Config.Name)
 		}
 		formatString(out, "Build Pod", buildapi.GetBuildPodName(build))
+		describeBuildStages(build.Status.Stages, out)
 
 		describeCommonSpec(build.Spec.CommonSpec, out)
 		describeBuildTriggerCauses(build.Spec.TriggeredBy, out)
@@ -142,6 +147,20 @@ func (d *BuildDescriber) Describe(namespace, name string, settings kctl.Describe
 	})
 }
 
+func describeBuildStages(stages []buildapi.BuildStageStatus, out *tabwriter.Writer) {
+	if len(stages) == 0 {
+		return
+	}
+	fmt.Fprintf(out, "\nStage\tStatus\tPod\tStarted\n")
+	for _, stage := range stages {
+		started := "<none>"
+		if stage.StartTimestamp != nil {
+			started = stage.StartTimestamp.Time.Format(time.RFC1123)
+		}
+		fmt.Fprintf(out, "%s\t%s\t%s\t%s\n", stage.Name, stage.Phase, stage.PodName, started)
+	}
+}
+
 func describeBuildDuration(build *buildapi.Build) string {
 	t := unversioned.Now().Rfc3339Copy()
 	if build.Status.StartTimestamp == nil &&
@@ -210,6 +229,8 @@ func describeCommonSpec(p buildapi.CommonSpec, out *tabwriter.Writer) {
 		describeCustomStrategy(p.Strategy.CustomStrategy, out)
 	case p.Strategy.JenkinsPipelineStrategy != nil:
 		describeJenkinsPipelineStrategy(p.Strategy.JenkinsPipelineStrategy, out)
+	case p.Strategy.PipelineStrategy != nil:
+		describePipelineStrategy(p.Strategy.PipelineStrategy, out)
 	}
 
 	if p.Output.To != nil {
@@ -301,6 +322,14 @@ func describeSourceStrategy(s *buildapi.SourceBuildStrategy, out *tabwriter.Writ
 	if s.Incremental {
 		formatString(out, "Incremental Build", "yes")
 	}
+	if cache := s.IncrementalCache; cache != nil {
+		switch {
+		case cache.PersistentVolumeClaim != nil:
+			formatString(out, "Incremental Cache", fmt.Sprintf("PersistentVolumeClaim %s", cache.PersistentVolumeClaim.Name))
+		case cache.Image != nil:
+			formatString(out, "Incremental Cache", fmt.Sprintf("%s %s", cache.Image.Kind, nameAndNamespace(cache.Image.Namespace, cache.Image.Name)))
+		}
+	}
 	if s.ForcePull {
 		formatString(out, "Force Pull", "yes")
 	}
@@ -361,6 +390,30 @@ func describeJenkinsPipelineStrategy(s *buildapi.JenkinsPipelineBuildStrategy, o
 	}
 }
 
+func describePipelineStrategy(s *buildapi.PipelineBuildStrategy, out *tabwriter.Writer) {
+	for i, stage := range s.Stages {
+		description := fmt.Sprintf("%s (%s)", stage.Name, stage.Image)
+		if len(stage.Command) > 0 {
+			description += " " + strings.Join(stage.Command, " ")
+		}
+		if i == 0 {
+			formatString(out, "Stages", description)
+		} else {
+			formatString(out, "", description)
+		}
+	}
+	if s.ForcePull {
+		formatString(out, "Force Pull", "yes")
+	}
+	for i, env := range s.Env {
+		if i == 0 {
+			formatString(out, "Environment", formatEnv(env))
+		} else {
+			formatString(out, "", formatEnv(env))
+		}
+	}
+}
+
 // DescribeTriggers generates information about the triggers associated with a
 // buildconfig
 func (d *BuildConfigDescriber) DescribeTriggers(bc *buildapi.BuildConfig, out *tabwriter.Writer) {
@@ -377,7 +430,7 @@ func describeBuildTriggers(triggers []buildapi.BuildTriggerPolicy, name, namespa
 
 	for _, t := range triggers {
 		switch t.Type {
-		case buildapi.GitHubWebHookBuildTriggerType, buildapi.GenericWebHookBuildTriggerType:
+		case buildapi.GitHubWebHookBuildTriggerType, buildapi.GenericWebHookBuildTriggerType, buildapi.GitLabWebHookBuildTriggerType, buildapi.BitbucketWebHookBuildTriggerType:
 			continue
 		case buildapi.ConfigChangeBuildTriggerType:
 			labels = append(labels, "Config")
@@ -387,6 +440,12 @@ func describeBuildTriggers(triggers []buildapi.BuildTriggerPolicy, name, namespa
 			} else {
 				labels = append(labels, string(t.Type))
 			}
+		case buildapi.BuildCompletionBuildTriggerType:
+			if t.BuildCompletion != nil && len(t.BuildCompletion.From.Name) > 0 {
+				labels = append(labels, fmt.Sprintf("Build(%s %s)", t.BuildCompletion.From.Kind, t.BuildCompletion.From.Name))
+			} else {
+				labels = append(labels, string(t.Type))
+			}
 		case "":
 			labels = append(labels, "<unknown>")
 		default:
@@ -431,6 +490,7 @@ func (d *BuildConfigDescriber) Describe(namespace, name string, settings kctl.De
 		}
 		describeCommonSpec(buildConfig.Spec.CommonSpec, out)
 		formatString(out, "\nBuild Run Policy", string(buildConfig.Spec.RunPolicy))
+		describeBuildMatrix(buildConfig.Spec.Matrix, buildList.Items, out)
 		d.DescribeTriggers(buildConfig, out)
 		if len(buildList.Items) == 0 {
 			return nil
@@ -455,6 +515,51 @@ func (d *BuildConfigDescriber) Describe(namespace, name string, settings kctl.De
 	})
 }
 
+// describeBuildMatrix prints the images and environment variable sets of the
+// build matrix and the aggregate status of the latest group of builds started
+// from it.
+func describeBuildMatrix(matrix *buildapi.BuildMatrix, builds []buildapi.Build, out *tabwriter.Writer) {
+	if matrix == nil {
+		return
+	}
+	if len(matrix.Images) > 0 {
+		images := []string{}
+		for _, image := range matrix.Images {
+			images = append(images, fmt.Sprintf("%s(%s %s)", image.Name, image.From.Kind, nameAndNamespace(image.From.Namespace, image.From.Name)))
+		}
+		formatString(out, "Build Matrix Images", strings.Join(images, ", "))
+	}
+	if len(matrix.Env) > 0 {
+		envs := []string{}
+		for _, env := range matrix.Env {
+			envs = append(envs, env.Name)
+		}
+		formatString(out, "Build Matrix Env", strings.Join(envs, ", "))
+	}
+
+	var latest *buildapi.Build
+	for i := range builds {
+		if _, ok := builds[i].Labels[buildapi.BuildMatrixLabel]; !ok {
+			continue
+		}
+		if latest == nil || latest.CreationTimestamp.Before(builds[i].CreationTimestamp) {
+			latest = &builds[i]
+		}
+	}
+	if latest == nil {
+		return
+	}
+	group := latest.Labels[buildapi.BuildMatrixLabel]
+	groupBuilds := []buildapi.Build{}
+	for _, build := range builds {
+		if build.Labels[buildapi.BuildMatrixLabel] == group {
+			groupBuilds = append(groupBuilds, build)
+		}
+	}
+	phase := buildutil.BuildMatrixPhase(groupBuilds)
+	formatString(out, "Latest Build Matrix", fmt.Sprintf("%s (%s, %d builds)", group, strings.ToLower(string(phase)), len(groupBuilds)))
+}
+
 // OAuthAccessTokenDescriber generates information about an OAuth Acess Token (OAuth)
 type OAuthAccessTokenDescriber struct {
 	client.Interface
@@ -636,11 +741,29 @@ func (d *ImageStreamDescriber) Describe(namespace, name string, settings kctl.De
 	return tabbedString(func(out *tabwriter.Writer) error {
 		formatMeta(out, imageStream.ObjectMeta)
 		formatString(out, "Docker Pull Spec", imageStream.Status.DockerImageRepository)
+		if retention := imageStream.Spec.Retention; retention != nil {
+			formatString(out, "Retention", describeTagRetentionPolicy(retention))
+		}
 		formatImageStreamTags(out, imageStream)
 		return nil
 	})
 }
 
+// describeTagRetentionPolicy returns a one line summary of a tag retention policy
+func describeTagRetentionPolicy(policy *imageapi.TagRetentionPolicy) string {
+	rules := []string{}
+	if policy.KeepLast != nil {
+		rules = append(rules, fmt.Sprintf("keep last %d", *policy.KeepLast))
+	}
+	if policy.KeepYoungerThanSeconds != nil {
+		rules = append(rules, fmt.Sprintf("keep younger than %s", time.Duration(*policy.KeepYoungerThanSeconds)*time.Second))
+	}
+	if len(policy.PreserveTags) > 0 {
+		rules = append(rules, fmt.Sprintf("preserve %s", strings.Join(policy.PreserveTags, ", ")))
+	}
+	return strings.Join(rules, "; ")
+}
+
 // RouteDescriber generates information about a Route
 type RouteDescriber struct {
 	client.Interface
@@ -1367,14 +1490,28 @@ func describeBuildTriggerCauses(causes []buildapi.BuildTriggerCause, out *tabwri
 		case cause.GitHubWebHook != nil:
 			squashGitInfo(cause.GitHubWebHook.Revision, out)
 			formatString(out, "Secret", cause.GitHubWebHook.Secret)
+			if pr := cause.GitHubWebHook.PullRequest; pr != nil {
+				formatString(out, "Pull Request", fmt.Sprintf("#%d %s (%s)", pr.Number, pr.Title, pr.HeadRef))
+			}
 
 		case cause.GenericWebHook != nil:
 			squashGitInfo(cause.GenericWebHook.Revision, out)
 			formatString(out, "Secret", cause.GenericWebHook.Secret)
 
+		case cause.GitLabWebHook != nil:
+			squashGitInfo(cause.GitLabWebHook.Revision, out)
+			formatString(out, "Secret", cause.GitLabWebHook.Secret)
+
+		case cause.BitbucketWebHook != nil:
+			squashGitInfo(cause.BitbucketWebHook.Revision, out)
+			formatString(out, "Secret", cause.BitbucketWebHook.Secret)
+
 		case cause.ImageChangeBuild != nil:
 			formatString(out, "Image ID", cause.ImageChangeBuild.ImageID)
 			formatString(out, "Image Name/Kind", fmt.Sprintf("%s / %s", cause.ImageChangeBuild.FromRef.Name, cause.ImageChangeBuild.FromRef.Kind))
+
+		case cause.BuildCompletion != nil:
+			formatString(out, "Build", cause.BuildCompletion.BuildName)
 		}
 	}
 	fmt.Fprintf(out, "\n")
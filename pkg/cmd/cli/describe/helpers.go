@@ -167,6 +167,12 @@ func webHooksDescribe(triggers []buildapi.BuildTriggerPolicy, name, namespace st
 			webHookTrigger = trigger.GenericWebHook.Secret
 			allowEnv = &trigger.GenericWebHook.AllowEnv
 
+		case buildapi.GitLabWebHookBuildTriggerType:
+			webHookTrigger = trigger.GitLabWebHook.Secret
+
+		case buildapi.BitbucketWebHookBuildTriggerType:
+			webHookTrigger = trigger.BitbucketWebHook.Secret
+
 		default:
 			continue
 		}
@@ -264,7 +270,11 @@ func formatImageStreamTags(out *tabwriter.Writer, stream *imageapi.ImageStream)
 					switch condition.Type {
 					case imageapi.ImportSuccess:
 						if condition.Status == api.ConditionFalse {
-							summary = append(summary, fmt.Sprintf("import failed: %s", condition.Message))
+							if next := condition.NextScheduledImport; next != nil {
+								summary = append(summary, fmt.Sprintf("import failed (retrying in %s): %s", units.HumanDuration(next.Sub(timeNowFn())), condition.Message))
+							} else {
+								summary = append(summary, fmt.Sprintf("import failed: %s", condition.Message))
+							}
 						}
 					default:
 						summary = append(summary, string(condition.Type))
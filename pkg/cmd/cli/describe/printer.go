@@ -574,6 +574,10 @@ func printDeploymentConfig(dc *deployapi.DeploymentConfig, w io.Writer, opts kct
 					triggers.Insert(fmt.Sprintf("%s(%s%s)", p.From.Kind, prefix, p.From.Name))
 				}
 			}
+		case deployapi.DeploymentTriggerOnResourceChange:
+			if p := trigger.ResourceChangeParams; p != nil {
+				triggers.Insert(fmt.Sprintf("%s(%s)", strings.ToLower(p.From.Kind), p.From.Name))
+			}
 		default:
 			triggers.Insert(string(t))
 		}
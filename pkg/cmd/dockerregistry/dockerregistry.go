@@ -84,6 +84,17 @@ func Execute(configFile io.Reader) {
 		pruneAccessRecords,
 	)
 
+	app.RegisterRoute(
+		// POST /admin/gc
+		adminRouter.Path("/gc").Methods("POST"),
+		// handler
+		server.GarbageCollectionDispatcher,
+		// repo name not required in url
+		handlers.NameNotRequired,
+		// custom access records
+		pruneAccessRecords,
+	)
+
 	app.RegisterHealthChecks()
 	handler := alive("/", app)
 	// TODO: temporarily keep for backwards compatibility; remove in the future
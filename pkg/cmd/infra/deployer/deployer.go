@@ -4,12 +4,14 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"reflect"
 	"sort"
 	"time"
 
 	"github.com/spf13/cobra"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/client/restclient"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/kubectl"
@@ -19,8 +21,12 @@ import (
 	"github.com/openshift/origin/pkg/cmd/util"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	"github.com/openshift/origin/pkg/deploy/strategy"
+	"github.com/openshift/origin/pkg/deploy/strategy/bluegreen"
+	"github.com/openshift/origin/pkg/deploy/strategy/canary"
+	"github.com/openshift/origin/pkg/deploy/strategy/ordered"
 	"github.com/openshift/origin/pkg/deploy/strategy/recreate"
 	"github.com/openshift/origin/pkg/deploy/strategy/rolling"
+	stratsupport "github.com/openshift/origin/pkg/deploy/strategy/support"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 	"github.com/openshift/origin/pkg/version"
 )
@@ -110,12 +116,13 @@ func (cfg *config) RunDeployer() error {
 		return err
 	}
 
-	deployer := NewDeployer(kc, oc, cfg.Out, cfg.ErrOut, cfg.Until)
+	deployer := NewDeployer(kc, oc, stratsupport.NewPodExecutor(kc, kcfg), cfg.Out, cfg.ErrOut, cfg.Until)
 	return deployer.Deploy(cfg.Namespace, cfg.rcName)
 }
 
-// NewDeployer makes a new Deployer from a kube client.
-func NewDeployer(client kclient.Interface, oclient client.Interface, out, errOut io.Writer, until string) *Deployer {
+// NewDeployer makes a new Deployer from a kube client. ExecInPod lifecycle hooks
+// are run through executor.
+func NewDeployer(client kclient.Interface, oclient client.Interface, executor stratsupport.PodExecutor, out, errOut io.Writer, until string) *Deployer {
 	scaler, _ := kubectl.ScalerFor(kapi.Kind("ReplicationController"), client)
 	return &Deployer{
 		out:    out,
@@ -127,14 +134,23 @@ func NewDeployer(client kclient.Interface, oclient client.Interface, out, errOut
 		getDeployments: func(namespace, configName string) (*kapi.ReplicationControllerList, error) {
 			return client.ReplicationControllers(namespace).List(kapi.ListOptions{LabelSelector: deployutil.ConfigSelector(configName)})
 		},
+		getService: func(namespace, name string) (*kapi.Service, error) {
+			return client.Services(namespace).Get(name)
+		},
 		scaler: scaler,
 		strategyFor: func(config *deployapi.DeploymentConfig) (strategy.DeploymentStrategy, error) {
 			switch config.Spec.Strategy.Type {
 			case deployapi.DeploymentStrategyTypeRecreate:
-				return recreate.NewRecreateDeploymentStrategy(client, oclient, kapi.Codecs.UniversalDecoder(), out, errOut, until), nil
+				return recreate.NewRecreateDeploymentStrategy(client, oclient, executor, kapi.Codecs.UniversalDecoder(), out, errOut, until), nil
 			case deployapi.DeploymentStrategyTypeRolling:
-				recreate := recreate.NewRecreateDeploymentStrategy(client, oclient, kapi.Codecs.UniversalDecoder(), out, errOut, until)
-				return rolling.NewRollingDeploymentStrategy(config.Namespace, client, oclient, kapi.Codecs.UniversalDecoder(), recreate, out, errOut, until), nil
+				recreate := recreate.NewRecreateDeploymentStrategy(client, oclient, executor, kapi.Codecs.UniversalDecoder(), out, errOut, until)
+				return rolling.NewRollingDeploymentStrategy(config.Namespace, client, oclient, executor, kapi.Codecs.UniversalDecoder(), recreate, out, errOut, until), nil
+			case deployapi.DeploymentStrategyTypeCanary:
+				return canary.NewCanaryDeploymentStrategy(client, oclient, executor, kapi.Codecs.UniversalDecoder(), out, errOut, until), nil
+			case deployapi.DeploymentStrategyTypeBlueGreen:
+				return bluegreen.NewBlueGreenDeploymentStrategy(client, oclient, executor, kapi.Codecs.UniversalDecoder(), out, errOut, until), nil
+			case deployapi.DeploymentStrategyTypeOrdered:
+				return ordered.NewOrderedDeploymentStrategy(client, oclient, executor, kapi.Codecs.UniversalDecoder(), out, errOut, until), nil
 			default:
 				return nil, fmt.Errorf("unsupported strategy type: %s", config.Spec.Strategy.Type)
 			}
@@ -145,7 +161,8 @@ func NewDeployer(client kclient.Interface, oclient client.Interface, out, errOut
 // Deployer prepares and executes the deployment process. It will:
 //
 // 1. Validate the deployment has a desired replica count and strategy.
-// 2. Find the last completed deployment.
+// 2. Find the last completed deployment, or for a BlueGreen config the
+// deployment its service sends traffic to.
 // 3. Scale down to 0 any old deployments which aren't the new deployment or
 // the last complete deployment.
 // 4. Pass the last completed deployment and the new deployment to a strategy
@@ -161,6 +178,8 @@ type Deployer struct {
 	getDeployment func(namespace, name string) (*kapi.ReplicationController, error)
 	// getDeployments finds all deployments associated with a config.
 	getDeployments func(namespace, configName string) (*kapi.ReplicationControllerList, error)
+	// getService finds the named service.
+	getService func(namespace, name string) (*kapi.Service, error)
 	// scaler is used to scale replication controllers.
 	scaler kubectl.Scaler
 }
@@ -213,6 +232,13 @@ func (d *Deployer) Deploy(namespace, rcName string) error {
 		}
 	}
 
+	// A rollback of a BlueGreen config switches its service back to an older
+	// deployment before the rolled back config is deployed, so the deployment
+	// the service sends traffic to is the one to keep running.
+	if serving := d.servingDeployment(config, to, deployments); serving != nil {
+		from = serving
+	}
+
 	// Scale down any deployments which aren't the new or last deployment.
 	for _, candidate := range deployments {
 		// Skip the from/to deployments.
@@ -246,3 +272,29 @@ func (d *Deployer) Deploy(namespace, rcName string) error {
 	fmt.Fprintf(d.out, "--> Success\n")
 	return nil
 }
+
+// servingDeployment returns the complete deployment the service of a BlueGreen
+// config sends traffic to, if it is not to.
+func (d *Deployer) servingDeployment(config *deployapi.DeploymentConfig, to *kapi.ReplicationController, deployments []kapi.ReplicationController) *kapi.ReplicationController {
+	params := config.Spec.Strategy.BlueGreenParams
+	if config.Spec.Strategy.Type != deployapi.DeploymentStrategyTypeBlueGreen || params == nil {
+		return nil
+	}
+	service, err := d.getService(to.Namespace, params.ServiceName)
+	if err != nil {
+		if !kerrors.IsNotFound(err) {
+			fmt.Fprintf(d.errOut, "error: Couldn't get service %s: %v\n", params.ServiceName, err)
+		}
+		return nil
+	}
+	for i := range deployments {
+		candidate := &deployments[i]
+		if candidate.Name == to.Name || deployutil.DeploymentStatusFor(candidate) != deployapi.DeploymentStatusComplete {
+			continue
+		}
+		if reflect.DeepEqual(service.Spec.Selector, candidate.Spec.Selector) {
+			return candidate
+		}
+	}
+	return nil
+}
@@ -251,6 +251,8 @@ func GetMasterFileReferences(config *MasterConfig) []*string {
 
 	refs = append(refs, &config.PolicyConfig.BootstrapPolicyFile)
 
+	refs = append(refs, &config.BuildLogArchiveConfig.Directory)
+
 	if config.ControllerConfig.ServiceServingCert.Signer != nil {
 		refs = append(refs, &config.ControllerConfig.ServiceServingCert.Signer.CertFile)
 		refs = append(refs, &config.ControllerConfig.ServiceServingCert.Signer.KeyFile)
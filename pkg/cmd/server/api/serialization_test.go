@@ -19,6 +19,7 @@ import (
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
 	configapiv1 "github.com/openshift/origin/pkg/cmd/server/api/v1"
 	"github.com/openshift/origin/pkg/cmd/server/bootstrappolicy"
+	imagesignatureapi "github.com/openshift/origin/pkg/image/admission/imagesignature/api"
 	podnodeapi "github.com/openshift/origin/pkg/scheduler/admission/podnodeconstraints/api"
 
 	// install all APIs
@@ -203,6 +204,12 @@ func fuzzInternalObject(t *testing.T, forVersion unversioned.GroupVersion, item
 				obj.NodeSelectorLabelBlacklist = []string{"kubernetes.io/hostname"}
 			}
 		},
+		func(obj *imagesignatureapi.SignatureVerificationScope, c fuzz.Continue) {
+			c.FuzzNoCustom(obj)
+			if len(obj.Policy) == 0 {
+				obj.Policy = imagesignatureapi.SignatureVerificationPolicyReject
+			}
+		},
 		func(obj *configapi.GrantConfig, c fuzz.Continue) {
 			c.FuzzNoCustom(obj)
 			if len(obj.ServiceAccountMethod) == 0 {
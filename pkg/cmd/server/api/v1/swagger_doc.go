@@ -105,6 +105,17 @@ func (BasicAuthPasswordIdentityProvider) SwaggerDoc() map[string]string {
 	return map_BasicAuthPasswordIdentityProvider
 }
 
+var map_BuildLogArchiveConfig = map[string]string{
+	"":          "BuildLogArchiveConfig holds configuration for archiving the logs of completed builds",
+	"backend":   "Backend is the storage build logs are archived to, either Directory or Etcd. When not specified build logs are not archived. Directory may only be used with a single master, since the logs are not shared with the other masters.",
+	"directory": "Directory is the directory build logs are written to when Backend is Directory.",
+	"maxBytes":  "MaxBytes is the maximum size of an archived build log. Only the end of longer logs is kept. When not specified this option defaults to 1MiB.",
+}
+
+func (BuildLogArchiveConfig) SwaggerDoc() map[string]string {
+	return map_BuildLogArchiveConfig
+}
+
 var map_CertInfo = map[string]string{
 	"":         "CertInfo relates a certificate with a private key",
 	"certFile": "CertFile is a file containing a PEM-encoded certificate",
@@ -454,6 +465,7 @@ var map_MasterConfig = map[string]string{
 	"volumeConfig":           "MasterVolumeConfig contains options for configuring volume plugins in the master node.",
 	"jenkinsPipelineConfig":  "JenkinsPipelineConfig holds information about the default Jenkins template used for JenkinsPipeline build strategy.",
 	"auditConfig":            "AuditConfig holds information related to auditing capabilities.",
+	"buildLogArchiveConfig":  "BuildLogArchiveConfig controls where the logs of completed builds are archived so they remain available after the build pods are deleted.",
 }
 
 func (MasterConfig) SwaggerDoc() map[string]string {
@@ -239,6 +239,10 @@ type MasterConfig struct {
 
 	// AuditConfig holds information related to auditing capabilities.
 	AuditConfig AuditConfig `json:"auditConfig"`
+
+	// BuildLogArchiveConfig controls where the logs of completed builds are archived
+	// so they remain available after the build pods are deleted.
+	BuildLogArchiveConfig BuildLogArchiveConfig `json:"buildLogArchiveConfig"`
 }
 
 // AuditConfig holds configuration for the audit capabilities
@@ -248,6 +252,30 @@ type AuditConfig struct {
 	Enabled bool `json:"enabled"`
 }
 
+// BuildLogArchiveBackend is the kind of storage the logs of completed builds are archived to
+type BuildLogArchiveBackend string
+
+const (
+	// BuildLogArchiveDirectory archives build logs as files in a directory local to the master.
+	// It may only be used with a single master.
+	BuildLogArchiveDirectory BuildLogArchiveBackend = "Directory"
+	// BuildLogArchiveEtcd archives build logs in etcd, below the OpenShift storage prefix.
+	BuildLogArchiveEtcd BuildLogArchiveBackend = "Etcd"
+)
+
+// BuildLogArchiveConfig holds configuration for archiving the logs of completed builds
+type BuildLogArchiveConfig struct {
+	// Backend is the storage build logs are archived to, either Directory or Etcd.
+	// When not specified build logs are not archived. Directory may only be used with a
+	// single master, since the logs are not shared with the other masters.
+	Backend BuildLogArchiveBackend `json:"backend"`
+	// Directory is the directory build logs are written to when Backend is Directory.
+	Directory string `json:"directory"`
+	// MaxBytes is the maximum size of an archived build log. Only the end of longer logs is
+	// kept. When not specified this option defaults to 1MiB.
+	MaxBytes int64 `json:"maxBytes"`
+}
+
 // JenkinsPipelineConfig holds configuration for the Jenkins pipeline strategy
 type JenkinsPipelineConfig struct {
 	// If the enabled flag is set, a Jenkins server will be spawned from the provided
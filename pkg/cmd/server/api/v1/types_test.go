@@ -104,6 +104,10 @@ assetConfig:
     requestTimeoutSeconds: 0
 auditConfig:
   enabled: false
+buildLogArchiveConfig:
+  backend: ""
+  directory: ""
+  maxBytes: 0
 controllerConfig:
   serviceServingCert:
     signer: null
@@ -127,6 +127,11 @@ func ValidateMasterConfig(config *api.MasterConfig, fldPath *field.Path) Validat
 
 	validationResults.AddErrors(ValidateImagePolicyConfig(config.ImagePolicyConfig, fldPath.Child("imagePolicyConfig"))...)
 
+	// Masters sharing the controllers through a lease, or running as a cluster,
+	// cannot share build logs archived to a local directory.
+	multipleMasters := config.ControllerLeaseTTL > 0 || (config.KubernetesMasterConfig != nil && config.KubernetesMasterConfig.MasterCount != 1)
+	validationResults.AddErrors(ValidateBuildLogArchiveConfig(config.BuildLogArchiveConfig, multipleMasters, fldPath.Child("buildLogArchiveConfig"))...)
+
 	validationResults.AddErrors(ValidateKubeletConnectionInfo(config.KubeletClientInfo, fldPath.Child("kubeletClientInfo"))...)
 
 	builtInKubernetes := config.KubernetesMasterConfig != nil
@@ -422,6 +427,30 @@ func ValidateImagePolicyConfig(config api.ImagePolicyConfig, fldPath *field.Path
 	return errs
 }
 
+func ValidateBuildLogArchiveConfig(config api.BuildLogArchiveConfig, multipleMasters bool, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	switch config.Backend {
+	case api.BuildLogArchiveDirectory:
+		if len(config.Directory) == 0 {
+			errs = append(errs, field.Required(fldPath.Child("directory"), ""))
+		}
+		if multipleMasters {
+			errs = append(errs, field.Invalid(fldPath.Child("backend"), config.Backend, "may only be used with a single master, use Etcd when running multiple masters"))
+		}
+	case "", api.BuildLogArchiveEtcd:
+		if len(config.Directory) > 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("directory"), config.Directory, "may only be specified when backend is Directory"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("backend"), config.Backend, []string{string(api.BuildLogArchiveDirectory), string(api.BuildLogArchiveEtcd)}))
+	}
+	if config.MaxBytes < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("maxBytes"), config.MaxBytes, "must be a positive integer"))
+	}
+	return errs
+}
+
 func ValidateKubeletConnectionInfo(config api.KubeletConnectionInfo, fldPath *field.Path) field.ErrorList {
 	allErrs := field.ErrorList{}
 
@@ -248,3 +248,56 @@ func TestValidateAdmissionPluginConfig(t *testing.T) {
 		}
 	}
 }
+
+func TestValidateBuildLogArchiveConfig(t *testing.T) {
+	tests := []struct {
+		config          configapi.BuildLogArchiveConfig
+		multipleMasters bool
+		expectError     bool
+	}{
+		{
+			config: configapi.BuildLogArchiveConfig{},
+		},
+		{
+			config: configapi.BuildLogArchiveConfig{Backend: configapi.BuildLogArchiveDirectory, Directory: "/var/lib/origin/buildlogs"},
+		},
+		{
+			config: configapi.BuildLogArchiveConfig{Backend: configapi.BuildLogArchiveEtcd, MaxBytes: 4096},
+		},
+		{
+			config:          configapi.BuildLogArchiveConfig{Backend: configapi.BuildLogArchiveEtcd},
+			multipleMasters: true,
+		},
+		{
+			config:          configapi.BuildLogArchiveConfig{Backend: configapi.BuildLogArchiveDirectory, Directory: "/var/lib/origin/buildlogs"},
+			multipleMasters: true,
+			expectError:     true,
+		},
+		{
+			config:      configapi.BuildLogArchiveConfig{Backend: configapi.BuildLogArchiveDirectory},
+			expectError: true,
+		},
+		{
+			config:      configapi.BuildLogArchiveConfig{Backend: configapi.BuildLogArchiveEtcd, Directory: "/var/lib/origin/buildlogs"},
+			expectError: true,
+		},
+		{
+			config:      configapi.BuildLogArchiveConfig{Backend: "S3"},
+			expectError: true,
+		},
+		{
+			config:      configapi.BuildLogArchiveConfig{Backend: configapi.BuildLogArchiveEtcd, MaxBytes: -1},
+			expectError: true,
+		},
+	}
+
+	for _, tc := range tests {
+		errs := ValidateBuildLogArchiveConfig(tc.config, tc.multipleMasters, field.NewPath("buildLogArchiveConfig"))
+		if len(errs) > 0 && !tc.expectError {
+			t.Errorf("Unexpected error for %#v: %v", tc.config, errs)
+		}
+		if len(errs) == 0 && tc.expectError {
+			t.Errorf("Did not get expected error for: %#v", tc.config)
+		}
+	}
+}
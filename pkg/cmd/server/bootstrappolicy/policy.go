@@ -200,6 +200,14 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 				authorizationapi.NewRule("create").Groups(buildGroup).Resources(authorizationapi.JenkinsPipelineBuildResource).RuleOrDie(),
 			},
 		},
+		{
+			ObjectMeta: kapi.ObjectMeta{
+				Name: BuildStrategyPipelineRoleName,
+			},
+			Rules: []authorizationapi.PolicyRule{
+				authorizationapi.NewRule("create").Groups(buildGroup).Resources(authorizationapi.PipelineBuildResource).RuleOrDie(),
+			},
+		},
 
 		{
 			ObjectMeta: kapi.ObjectMeta{
@@ -437,9 +445,18 @@ func GetBootstrapClusterRoles() []authorizationapi.ClusterRole {
 			Rules: []authorizationapi.PolicyRule{
 				authorizationapi.NewRule("get", "list", "update").Groups(kapiGroup).Resources("replicationcontrollers").RuleOrDie(),
 				authorizationapi.NewRule("get", "list", "watch", "create").Groups(kapiGroup).Resources("pods").RuleOrDie(),
+				// Ordered deployments remove the pods of the previous deployment in order.
+				authorizationapi.NewRule("delete").Groups(kapiGroup).Resources("pods").RuleOrDie(),
 				authorizationapi.NewRule("get").Groups(kapiGroup).Resources("pods/log").RuleOrDie(),
+				// ExecInPod lifecycle hooks run commands in the pods of a deployment.
+				authorizationapi.NewRule("create").Groups(kapiGroup).Resources("pods/exec").RuleOrDie(),
+				// BlueGreen deployments switch services and routes over to the new deployment,
+				// and create and delete the services of deployments their routes point at.
+				authorizationapi.NewRule("get", "list", "create", "update", "delete").Groups(kapiGroup).Resources("services").RuleOrDie(),
 
 				authorizationapi.NewRule("update").Groups(imageGroup).Resources("imagestreamtags").RuleOrDie(),
+
+				authorizationapi.NewRule("get", "update").Groups(routeGroup).Resources("routes").RuleOrDie(),
 			},
 		},
 		{
@@ -881,5 +898,10 @@ func GetBootstrapClusterRoleBindings() []authorizationapi.ClusterRoleBinding {
 			RoleRef:    kapi.ObjectReference{Name: BuildStrategyJenkinsPipelineRoleName},
 			Subjects:   []kapi.ObjectReference{{Kind: authorizationapi.SystemGroupKind, Name: AuthenticatedGroup}},
 		},
+		{
+			ObjectMeta: kapi.ObjectMeta{Name: BuildStrategyPipelineRoleBindingName},
+			RoleRef:    kapi.ObjectReference{Name: BuildStrategyPipelineRoleName},
+			Subjects:   []kapi.ObjectReference{{Kind: authorizationapi.SystemGroupKind, Name: AuthenticatedGroup}},
+		},
 	}
 }
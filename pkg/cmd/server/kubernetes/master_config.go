@@ -45,6 +45,7 @@ import (
 	cmdflags "github.com/openshift/origin/pkg/cmd/util/flags"
 	"github.com/openshift/origin/pkg/cmd/util/pluginconfig"
 	"github.com/openshift/origin/pkg/controller"
+	"github.com/openshift/origin/pkg/image/admission/imagesignature"
 	overrideapi "github.com/openshift/origin/pkg/quota/admission/clusterresourceoverride/api"
 	serviceadmit "github.com/openshift/origin/pkg/service/admission"
 )
@@ -63,6 +64,7 @@ var AdmissionPlugins = []string{
 	"BuildDefaults",
 	"BuildOverrides",
 	"AlwaysPullImages",
+	imagesignature.PluginName,
 	"LimitPodHardAntiAffinityTopology",
 	"ResourceQuota",
 	"SCCExecRestrictions",
@@ -52,6 +52,7 @@ import (
 	policybindingregistry "github.com/openshift/origin/pkg/authorization/registry/policybinding"
 	policybindingetcd "github.com/openshift/origin/pkg/authorization/registry/policybinding/etcd"
 	"github.com/openshift/origin/pkg/authorization/rulevalidation"
+	"github.com/openshift/origin/pkg/build/logarchive"
 	osclient "github.com/openshift/origin/pkg/client"
 	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
 	configapi "github.com/openshift/origin/pkg/cmd/server/api"
@@ -62,6 +63,7 @@ import (
 	"github.com/openshift/origin/pkg/cmd/util/variable"
 	"github.com/openshift/origin/pkg/controller"
 	imageadmission "github.com/openshift/origin/pkg/image/admission"
+	"github.com/openshift/origin/pkg/image/admission/imagesignature"
 	accesstokenregistry "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken"
 	accesstokenetcd "github.com/openshift/origin/pkg/oauth/registry/oauthaccesstoken/etcd"
 	projectauth "github.com/openshift/origin/pkg/project/auth"
@@ -111,6 +113,9 @@ type MasterConfig struct {
 	// TODO: remove direct access to EtcdHelper, require selecting by providing target resource
 	EtcdHelper storage.Interface
 
+	// BuildLogArchive stores the logs of completed builds, or is nil if build logs are not archived
+	BuildLogArchive logarchive.Archive
+
 	KubeletClientConfig *kubeletclient.KubeletClientConfig
 
 	// ClientCAs will be used to request client certificates in connections to the API.
@@ -207,6 +212,7 @@ func BuildMasterConfig(options configapi.MasterConfig) (*MasterConfig, error) {
 		"PodNodeConstraints",
 		"JenkinsBootstrapper",
 		"BuildByStrategy",
+		imagesignature.PluginName,
 		imageadmission.PluginName,
 		quotaadmission.PluginName,
 	}
@@ -289,6 +295,7 @@ func BuildMasterConfig(options configapi.MasterConfig) (*MasterConfig, error) {
 
 		ImageFor:            imageTemplate.ExpandOrDie,
 		EtcdHelper:          etcdHelper,
+		BuildLogArchive:     newBuildLogArchive(options, etcdClient),
 		KubeletClientConfig: kubeletClientConfig,
 
 		ClientCAs:    clientCAs,
@@ -326,6 +333,20 @@ func newControllerPlug(options configapi.MasterConfig, client *etcdclient.Client
 	}
 }
 
+// newBuildLogArchive returns the archive the logs of completed builds are stored in, or nil if
+// build logs should not be archived.
+func newBuildLogArchive(options configapi.MasterConfig, client newetcdclient.Client) logarchive.Archive {
+	config := options.BuildLogArchiveConfig
+	switch config.Backend {
+	case configapi.BuildLogArchiveDirectory:
+		return logarchive.NewDirectoryArchive(config.Directory, config.MaxBytes)
+	case configapi.BuildLogArchiveEtcd:
+		return logarchive.NewEtcdArchive(client, path.Join(options.EtcdStorageConfig.OpenShiftStoragePrefix, "buildlogs"), config.MaxBytes)
+	default:
+		return nil
+	}
+}
+
 func newServiceAccountTokenGetter(options configapi.MasterConfig, client newetcdclient.Client) (serviceaccount.ServiceAccountTokenGetter, error) {
 	var tokenGetter serviceaccount.ServiceAccountTokenGetter
 	if options.KubernetesMasterConfig == nil {
@@ -621,6 +642,11 @@ func (c *MasterConfig) BuildImageChangeTriggerControllerClients() (*osclient.Cli
 	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
 }
 
+// BuildCompletionTriggerControllerClients returns the build completion trigger controller client objects
+func (c *MasterConfig) BuildCompletionTriggerControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+}
+
 // BuildConfigChangeControllerClients returns the build config change controller client objects
 func (c *MasterConfig) BuildConfigChangeControllerClients() (*osclient.Client, *kclient.Client) {
 	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
@@ -636,6 +662,18 @@ func (c *MasterConfig) ImageImportControllerClient() *osclient.Client {
 	return c.PrivilegedLoopbackOpenShiftClient
 }
 
+// ImageStreamRetentionControllerClients returns the clients used by the image stream retention
+// controller
+func (c *MasterConfig) ImageStreamRetentionControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+}
+
+// ImageSignatureVerificationControllerClient returns the client used by the image signature
+// verification controller
+func (c *MasterConfig) ImageSignatureVerificationControllerClient() *osclient.Client {
+	return c.PrivilegedLoopbackOpenShiftClient
+}
+
 // DeploymentConfigScaleClient returns the client used by the Scale subresource registry
 func (c *MasterConfig) DeploymentConfigScaleClient() *kclient.Client {
 	return c.PrivilegedLoopbackKubernetesClient
@@ -675,6 +713,11 @@ func (c *MasterConfig) DeploymentImageChangeTriggerControllerClient() *osclient.
 	return c.PrivilegedLoopbackOpenShiftClient
 }
 
+// DeploymentResourceChangeTriggerControllerClients returns the deploymentConfig resource change controller client objects
+func (c *MasterConfig) DeploymentResourceChangeTriggerControllerClients() (*osclient.Client, *kclient.Client) {
+	return c.PrivilegedLoopbackOpenShiftClient, c.PrivilegedLoopbackKubernetesClient
+}
+
 // DeploymentLogClient returns the deployment log client object
 func (c *MasterConfig) DeploymentLogClient() *kclient.Client {
 	return c.PrivilegedLoopbackKubernetesClient
@@ -3,6 +3,7 @@ package origin
 import (
 	"io/ioutil"
 	"net"
+	"os"
 	"path"
 	"time"
 
@@ -32,12 +33,15 @@ import (
 	"github.com/openshift/origin/pkg/cmd/server/crypto"
 	cmdutil "github.com/openshift/origin/pkg/cmd/util"
 	"github.com/openshift/origin/pkg/cmd/util/clientcmd"
+	"github.com/openshift/origin/pkg/cmd/util/pluginconfig"
 	deployerpodcontroller "github.com/openshift/origin/pkg/deploy/controller/deployerpod"
 	deploycontroller "github.com/openshift/origin/pkg/deploy/controller/deployment"
 	deployconfigcontroller "github.com/openshift/origin/pkg/deploy/controller/deploymentconfig"
 	triggercontroller "github.com/openshift/origin/pkg/deploy/controller/generictrigger"
 	imagechangecontroller "github.com/openshift/origin/pkg/deploy/controller/imagechange"
+	resourcechangecontroller "github.com/openshift/origin/pkg/deploy/controller/resourcechange"
 	"github.com/openshift/origin/pkg/dns"
+	"github.com/openshift/origin/pkg/image/admission/imagesignature"
 	imagecontroller "github.com/openshift/origin/pkg/image/controller"
 	projectcontroller "github.com/openshift/origin/pkg/project/controller"
 	securitycontroller "github.com/openshift/origin/pkg/security/controller"
@@ -253,6 +257,10 @@ func (c *MasterConfig) RunBuildController() {
 			// TODO: this will be set to --storage-version (the internal schema we use)
 			Codec: codec,
 		},
+		PipelineBuildStrategy: &buildstrategy.PipelineBuildStrategy{
+			Codec: codec,
+		},
+		LogArchive: c.BuildLogArchive,
 	}
 
 	controller := factory.Create()
@@ -263,11 +271,19 @@ func (c *MasterConfig) RunBuildController() {
 
 // RunBuildPodController starts the build/pod status sync loop for build status
 func (c *MasterConfig) RunBuildPodController() {
+	storageVersion := c.Options.EtcdStorageConfig.OpenShiftStorageVersion
+	groupVersion := unversioned.GroupVersion{Group: "", Version: storageVersion}
+	codec := kapi.Codecs.LegacyCodec(groupVersion)
+
 	osclient, kclient := c.BuildPodControllerClients()
 	factory := buildcontrollerfactory.BuildPodControllerFactory{
 		OSClient:     osclient,
 		KubeClient:   kclient,
 		BuildUpdater: buildclient.NewOSClientBuildClient(osclient),
+		PipelineBuildStrategy: &buildstrategy.PipelineBuildStrategy{
+			Codec: codec,
+		},
+		LogArchive: c.BuildLogArchive,
 	}
 	controller := factory.Create()
 	controller.Run()
@@ -283,6 +299,14 @@ func (c *MasterConfig) RunBuildImageChangeTriggerController() {
 	factory.Create().Run()
 }
 
+// RunBuildCompletionTriggerController starts the build completion trigger controller process.
+func (c *MasterConfig) RunBuildCompletionTriggerController() {
+	bcClient, _ := c.BuildCompletionTriggerControllerClients()
+	bcInstantiator := buildclient.NewOSClientBuildConfigInstantiatorClient(bcClient)
+	factory := buildcontrollerfactory.BuildCompletionControllerFactory{Client: bcClient, BuildConfigInstantiator: bcInstantiator}
+	factory.Create().Run()
+}
+
 // RunBuildConfigChangeController starts the build config change trigger controller process.
 func (c *MasterConfig) RunBuildConfigChangeController() {
 	bcClient, kClient := c.BuildConfigChangeControllerClients()
@@ -369,6 +393,17 @@ func (c *MasterConfig) RunDeploymentImageChangeTriggerController() {
 	controller.Run()
 }
 
+// RunDeploymentResourceChangeTriggerController starts the resource change trigger controller process.
+func (c *MasterConfig) RunDeploymentResourceChangeTriggerController() {
+	osclient, kclient := c.DeploymentResourceChangeTriggerControllerClients()
+	factory := resourcechangecontroller.ResourceChangeControllerFactory{
+		Client:     osclient,
+		KubeClient: kclient,
+	}
+	controller := factory.Create()
+	controller.Run()
+}
+
 // RunSDNController runs openshift-sdn if the said network plugin is provided
 func (c *MasterConfig) RunSDNController() {
 	oClient, kClient := c.SDNControllerClients()
@@ -419,6 +454,54 @@ func (c *MasterConfig) RunImageImportController() {
 	}
 }
 
+// RunImageStreamRetentionController starts the controller enforcing the tag retention policies of
+// image streams.
+func (c *MasterConfig) RunImageStreamRetentionController() {
+	osclient, kclient := c.ImageStreamRetentionControllerClients()
+	factory := imagecontroller.RetentionControllerFactory{
+		Client:         osclient,
+		KubeClient:     kclient,
+		ResyncInterval: 10 * time.Minute,
+	}
+	factory.Create().Run()
+}
+
+// RunImageSignatureVerificationController starts the controller recording the verification
+// results of image signatures if the image signature verification admission plugin is
+// configured.
+func (c *MasterConfig) RunImageSignatureVerificationController() {
+	configFile, err := pluginconfig.GetPluginConfig(c.Options.AdmissionConfig.PluginConfig[imagesignature.PluginName])
+	if err != nil {
+		glog.Fatalf("Unable to read the configuration of %s: %v", imagesignature.PluginName, err)
+	}
+	if len(configFile) == 0 {
+		return
+	}
+	file, err := os.Open(configFile)
+	if err != nil {
+		glog.Fatalf("Unable to read the configuration of %s: %v", imagesignature.PluginName, err)
+	}
+	defer file.Close()
+	config, err := imagesignature.ReadConfig(file)
+	if err != nil {
+		glog.Fatalf("Unable to read the configuration of %s: %v", imagesignature.PluginName, err)
+	}
+	if config == nil {
+		return
+	}
+
+	factory := imagesignature.VerificationControllerFactory{
+		Client:         c.ImageSignatureVerificationControllerClient(),
+		Config:         config,
+		ResyncInterval: 10 * time.Minute,
+	}
+	controller, err := factory.Create()
+	if err != nil {
+		glog.Fatalf("Unable to start the image signature verification controller: %v", err)
+	}
+	controller.Run()
+}
+
 // RunSecurityAllocationController starts the security allocation controller process.
 func (c *MasterConfig) RunSecurityAllocationController() {
 	alloc := c.Options.ProjectConfig.SecurityAllocator
@@ -646,13 +646,17 @@ func startControllers(oc *origin.MasterConfig, kc *kubernetes.MasterConfig) erro
 		oc.RunBuildPodController()
 		oc.RunBuildConfigChangeController()
 		oc.RunBuildImageChangeTriggerController()
+		oc.RunBuildCompletionTriggerController()
 	}
 	oc.RunDeploymentController()
 	oc.RunDeployerPodController()
 	oc.RunDeploymentConfigController()
 	oc.RunDeploymentTriggerController()
 	oc.RunDeploymentImageChangeTriggerController()
+	oc.RunDeploymentResourceChangeTriggerController()
 	oc.RunImageImportController()
+	oc.RunImageStreamRetentionController()
+	oc.RunImageSignatureVerificationController()
 	oc.RunOriginNamespaceController()
 	oc.RunSDNController()
 
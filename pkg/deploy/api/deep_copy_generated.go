@@ -14,9 +14,15 @@ import (
 
 func init() {
 	if err := api.Scheme.AddGeneratedDeepCopyFuncs(
+		DeepCopy_api_BlueGreenDeploymentStrategyParams,
+		DeepCopy_api_CanaryDeploymentStrategyParams,
+		DeepCopy_api_CanaryHealthGate,
 		DeepCopy_api_CustomDeploymentStrategyParams,
 		DeepCopy_api_DeploymentCause,
+		DeepCopy_api_DeploymentCauseAutoRollback,
 		DeepCopy_api_DeploymentCauseImageTrigger,
+		DeepCopy_api_DeploymentCauseResourceTrigger,
+		DeepCopy_api_DeploymentCondition,
 		DeepCopy_api_DeploymentConfig,
 		DeepCopy_api_DeploymentConfigList,
 		DeepCopy_api_DeploymentConfigRollback,
@@ -29,8 +35,13 @@ func init() {
 		DeepCopy_api_DeploymentStrategy,
 		DeepCopy_api_DeploymentTriggerImageChangeParams,
 		DeepCopy_api_DeploymentTriggerPolicy,
+		DeepCopy_api_DeploymentTriggerResourceChangeParams,
+		DeepCopy_api_DeploymentWindow,
+		DeepCopy_api_ExecInPodHook,
 		DeepCopy_api_ExecNewPodHook,
 		DeepCopy_api_LifecycleHook,
+		DeepCopy_api_OrderedDeploymentStrategyParams,
+		DeepCopy_api_PendingDeployment,
 		DeepCopy_api_RecreateDeploymentStrategyParams,
 		DeepCopy_api_RollingDeploymentStrategyParams,
 		DeepCopy_api_TagImageHook,
@@ -41,6 +52,127 @@ func init() {
 	}
 }
 
+func DeepCopy_api_BlueGreenDeploymentStrategyParams(in BlueGreenDeploymentStrategyParams, out *BlueGreenDeploymentStrategyParams, c *conversion.Cloner) error {
+	out.ServiceName = in.ServiceName
+	out.RouteName = in.RouteName
+	if in.TimeoutSeconds != nil {
+		in, out := in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	if in.KeepPreviousSeconds != nil {
+		in, out := in.KeepPreviousSeconds, &out.KeepPreviousSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.KeepPreviousSeconds = nil
+	}
+	if in.Pre != nil {
+		in, out := in.Pre, &out.Pre
+		*out = new(LifecycleHook)
+		if err := DeepCopy_api_LifecycleHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Pre = nil
+	}
+	if in.Mid != nil {
+		in, out := in.Mid, &out.Mid
+		*out = new(LifecycleHook)
+		if err := DeepCopy_api_LifecycleHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
+	if in.Post != nil {
+		in, out := in.Post, &out.Post
+		*out = new(LifecycleHook)
+		if err := DeepCopy_api_LifecycleHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Post = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_CanaryDeploymentStrategyParams(in CanaryDeploymentStrategyParams, out *CanaryDeploymentStrategyParams, c *conversion.Cloner) error {
+	if in.Percent != nil {
+		in, out := in.Percent, &out.Percent
+		*out = new(int32)
+		**out = *in
+	} else {
+		out.Percent = nil
+	}
+	if in.BakeSeconds != nil {
+		in, out := in.BakeSeconds, &out.BakeSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.BakeSeconds = nil
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	if in.HealthGate != nil {
+		in, out := in.HealthGate, &out.HealthGate
+		*out = new(CanaryHealthGate)
+		if err := DeepCopy_api_CanaryHealthGate(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.HealthGate = nil
+	}
+	if in.Pre != nil {
+		in, out := in.Pre, &out.Pre
+		*out = new(LifecycleHook)
+		if err := DeepCopy_api_LifecycleHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Pre = nil
+	}
+	if in.Post != nil {
+		in, out := in.Post, &out.Post
+		*out = new(LifecycleHook)
+		if err := DeepCopy_api_LifecycleHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Post = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_CanaryHealthGate(in CanaryHealthGate, out *CanaryHealthGate, c *conversion.Cloner) error {
+	if in.ExecNewPod != nil {
+		in, out := in.ExecNewPod, &out.ExecNewPod
+		*out = new(ExecNewPodHook)
+		if err := DeepCopy_api_ExecNewPodHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.ExecNewPod = nil
+	}
+	if in.HTTPGet != nil {
+		in, out := in.HTTPGet, &out.HTTPGet
+		*out = new(api.HTTPGetAction)
+		if err := api.DeepCopy_api_HTTPGetAction(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.HTTPGet = nil
+	}
+	return nil
+}
+
 func DeepCopy_api_CustomDeploymentStrategyParams(in CustomDeploymentStrategyParams, out *CustomDeploymentStrategyParams, c *conversion.Cloner) error {
 	out.Image = in.Image
 	if in.Environment != nil {
@@ -75,6 +207,31 @@ func DeepCopy_api_DeploymentCause(in DeploymentCause, out *DeploymentCause, c *c
 	} else {
 		out.ImageTrigger = nil
 	}
+	if in.AutoRollback != nil {
+		in, out := in.AutoRollback, &out.AutoRollback
+		*out = new(DeploymentCauseAutoRollback)
+		if err := DeepCopy_api_DeploymentCauseAutoRollback(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.AutoRollback = nil
+	}
+	if in.ResourceTrigger != nil {
+		in, out := in.ResourceTrigger, &out.ResourceTrigger
+		*out = new(DeploymentCauseResourceTrigger)
+		if err := DeepCopy_api_DeploymentCauseResourceTrigger(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceTrigger = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_DeploymentCauseAutoRollback(in DeploymentCauseAutoRollback, out *DeploymentCauseAutoRollback, c *conversion.Cloner) error {
+	if err := api.DeepCopy_api_ObjectReference(in.FailedDeployment, &out.FailedDeployment, c); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -85,6 +242,24 @@ func DeepCopy_api_DeploymentCauseImageTrigger(in DeploymentCauseImageTrigger, ou
 	return nil
 }
 
+func DeepCopy_api_DeploymentCauseResourceTrigger(in DeploymentCauseResourceTrigger, out *DeploymentCauseResourceTrigger, c *conversion.Cloner) error {
+	if err := api.DeepCopy_api_ObjectReference(in.From, &out.From, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeepCopy_api_DeploymentCondition(in DeploymentCondition, out *DeploymentCondition, c *conversion.Cloner) error {
+	out.Type = in.Type
+	out.Status = in.Status
+	if err := unversioned.DeepCopy_unversioned_Time(in.LastTransitionTime, &out.LastTransitionTime, c); err != nil {
+		return err
+	}
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
 func DeepCopy_api_DeploymentConfig(in DeploymentConfig, out *DeploymentConfig, c *conversion.Cloner) error {
 	if err := unversioned.DeepCopy_unversioned_TypeMeta(in.TypeMeta, &out.TypeMeta, c); err != nil {
 		return err
@@ -161,6 +336,23 @@ func DeepCopy_api_DeploymentConfigSpec(in DeploymentConfigSpec, out *DeploymentC
 	out.Replicas = in.Replicas
 	out.Test = in.Test
 	out.Paused = in.Paused
+	out.AutoRollback = in.AutoRollback
+	if in.RevisionHistoryLimit != nil {
+		in, out := in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = *in
+	} else {
+		out.RevisionHistoryLimit = nil
+	}
+	if in.DeploymentWindow != nil {
+		in, out := in.DeploymentWindow, &out.DeploymentWindow
+		*out = new(DeploymentWindow)
+		if err := DeepCopy_api_DeploymentWindow(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.DeploymentWindow = nil
+	}
 	if in.Selector != nil {
 		in, out := in.Selector, &out.Selector
 		*out = make(map[string]string)
@@ -198,6 +390,26 @@ func DeepCopy_api_DeploymentConfigStatus(in DeploymentConfigStatus, out *Deploym
 	} else {
 		out.Details = nil
 	}
+	if in.Conditions != nil {
+		in, out := in.Conditions, &out.Conditions
+		*out = make([]DeploymentCondition, len(in))
+		for i := range in {
+			if err := DeepCopy_api_DeploymentCondition(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.PendingDeployment != nil {
+		in, out := in.PendingDeployment, &out.PendingDeployment
+		*out = new(PendingDeployment)
+		if err := DeepCopy_api_PendingDeployment(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.PendingDeployment = nil
+	}
 	return nil
 }
 
@@ -293,6 +505,33 @@ func DeepCopy_api_DeploymentStrategy(in DeploymentStrategy, out *DeploymentStrat
 	} else {
 		out.RollingParams = nil
 	}
+	if in.CanaryParams != nil {
+		in, out := in.CanaryParams, &out.CanaryParams
+		*out = new(CanaryDeploymentStrategyParams)
+		if err := DeepCopy_api_CanaryDeploymentStrategyParams(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.CanaryParams = nil
+	}
+	if in.BlueGreenParams != nil {
+		in, out := in.BlueGreenParams, &out.BlueGreenParams
+		*out = new(BlueGreenDeploymentStrategyParams)
+		if err := DeepCopy_api_BlueGreenDeploymentStrategyParams(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.BlueGreenParams = nil
+	}
+	if in.OrderedParams != nil {
+		in, out := in.OrderedParams, &out.OrderedParams
+		*out = new(OrderedDeploymentStrategyParams)
+		if err := DeepCopy_api_OrderedDeploymentStrategyParams(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.OrderedParams = nil
+	}
 	if in.CustomParams != nil {
 		in, out := in.CustomParams, &out.CustomParams
 		*out = new(CustomDeploymentStrategyParams)
@@ -353,6 +592,50 @@ func DeepCopy_api_DeploymentTriggerPolicy(in DeploymentTriggerPolicy, out *Deplo
 	} else {
 		out.ImageChangeParams = nil
 	}
+	if in.ResourceChangeParams != nil {
+		in, out := in.ResourceChangeParams, &out.ResourceChangeParams
+		*out = new(DeploymentTriggerResourceChangeParams)
+		if err := DeepCopy_api_DeploymentTriggerResourceChangeParams(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceChangeParams = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_DeploymentTriggerResourceChangeParams(in DeploymentTriggerResourceChangeParams, out *DeploymentTriggerResourceChangeParams, c *conversion.Cloner) error {
+	if err := api.DeepCopy_api_ObjectReference(in.From, &out.From, c); err != nil {
+		return err
+	}
+	out.LastTriggeredResourceVersion = in.LastTriggeredResourceVersion
+	return nil
+}
+
+func DeepCopy_api_DeploymentWindow(in DeploymentWindow, out *DeploymentWindow, c *conversion.Cloner) error {
+	out.Schedule = in.Schedule
+	out.TimeZone = in.TimeZone
+	return nil
+}
+
+func DeepCopy_api_ExecInPodHook(in ExecInPodHook, out *ExecInPodHook, c *conversion.Cloner) error {
+	if in.Command != nil {
+		in, out := in.Command, &out.Command
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.Target = in.Target
+	out.AllPods = in.AllPods
+	if in.TimeoutSeconds != nil {
+		in, out := in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.TimeoutSeconds = nil
+	}
 	return nil
 }
 
@@ -408,6 +691,71 @@ func DeepCopy_api_LifecycleHook(in LifecycleHook, out *LifecycleHook, c *convers
 	} else {
 		out.TagImages = nil
 	}
+	if in.ExecInPod != nil {
+		in, out := in.ExecInPod, &out.ExecInPod
+		*out = new(ExecInPodHook)
+		if err := DeepCopy_api_ExecInPodHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.ExecInPod = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_OrderedDeploymentStrategyParams(in OrderedDeploymentStrategyParams, out *OrderedDeploymentStrategyParams, c *conversion.Cloner) error {
+	if in.TimeoutSeconds != nil {
+		in, out := in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	if in.ReadinessCheck != nil {
+		in, out := in.ReadinessCheck, &out.ReadinessCheck
+		*out = new(ExecNewPodHook)
+		if err := DeepCopy_api_ExecNewPodHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessCheck = nil
+	}
+	if in.Pre != nil {
+		in, out := in.Pre, &out.Pre
+		*out = new(LifecycleHook)
+		if err := DeepCopy_api_LifecycleHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Pre = nil
+	}
+	if in.Post != nil {
+		in, out := in.Post, &out.Post
+		*out = new(LifecycleHook)
+		if err := DeepCopy_api_LifecycleHook(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Post = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_PendingDeployment(in PendingDeployment, out *PendingDeployment, c *conversion.Cloner) error {
+	if in.Causes != nil {
+		in, out := in.Causes, &out.Causes
+		*out = make([]DeploymentCause, len(in))
+		for i := range in {
+			if err := DeepCopy_api_DeploymentCause(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Causes = nil
+	}
+	if err := unversioned.DeepCopy_unversioned_Time(in.ScheduledTime, &out.ScheduledTime, c); err != nil {
+		return err
+	}
 	return nil
 }
 
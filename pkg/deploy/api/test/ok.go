@@ -4,6 +4,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/apis/extensions"
+	"k8s.io/kubernetes/pkg/util/intstr"
 	"k8s.io/kubernetes/pkg/util/sets"
 
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
@@ -126,6 +127,49 @@ func OkRollingStrategy() deployapi.DeploymentStrategy {
 	}
 }
 
+func OkCanaryStrategy() deployapi.DeploymentStrategy {
+	percent := int32(20)
+	return deployapi.DeploymentStrategy{
+		Type: deployapi.DeploymentStrategyTypeCanary,
+		CanaryParams: &deployapi.CanaryDeploymentStrategyParams{
+			Percent:        &percent,
+			BakeSeconds:    mkintp(60),
+			TimeoutSeconds: mkintp(20),
+			HealthGate: &deployapi.CanaryHealthGate{
+				HTTPGet: &kapi.HTTPGetAction{
+					Path:   "/healthz",
+					Port:   intstr.FromInt(8080),
+					Scheme: kapi.URISchemeHTTP,
+				},
+			},
+		},
+	}
+}
+
+func OkBlueGreenStrategy() deployapi.DeploymentStrategy {
+	return deployapi.DeploymentStrategy{
+		Type: deployapi.DeploymentStrategyTypeBlueGreen,
+		BlueGreenParams: &deployapi.BlueGreenDeploymentStrategyParams{
+			ServiceName:         "frontend",
+			TimeoutSeconds:      mkintp(20),
+			KeepPreviousSeconds: mkintp(60),
+		},
+	}
+}
+
+func OkOrderedStrategy() deployapi.DeploymentStrategy {
+	return deployapi.DeploymentStrategy{
+		Type: deployapi.DeploymentStrategyTypeOrdered,
+		OrderedParams: &deployapi.OrderedDeploymentStrategyParams{
+			TimeoutSeconds: mkintp(20),
+			ReadinessCheck: &deployapi.ExecNewPodHook{
+				ContainerName: "container1",
+				Command:       []string{"/bin/check-cluster-health"},
+			},
+		},
+	}
+}
+
 func OkSelector() map[string]string {
 	return map[string]string{"a": "b"}
 }
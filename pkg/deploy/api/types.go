@@ -33,6 +33,12 @@ type DeploymentStrategy struct {
 	RecreateParams *RecreateDeploymentStrategyParams
 	// RollingParams are the input to the Rolling deployment strategy.
 	RollingParams *RollingDeploymentStrategyParams
+	// CanaryParams are the input to the Canary deployment strategy.
+	CanaryParams *CanaryDeploymentStrategyParams
+	// BlueGreenParams are the input to the BlueGreen deployment strategy.
+	BlueGreenParams *BlueGreenDeploymentStrategyParams
+	// OrderedParams are the input to the Ordered deployment strategy.
+	OrderedParams *OrderedDeploymentStrategyParams
 
 	// CustomParams are the input to the Custom deployment strategy, and may also
 	// be specified for the Recreate and Rolling strategies to customize the execution
@@ -57,6 +63,16 @@ const (
 	DeploymentStrategyTypeCustom DeploymentStrategyType = "Custom"
 	// DeploymentStrategyTypeRolling uses the Kubernetes RollingUpdater.
 	DeploymentStrategyTypeRolling DeploymentStrategyType = "Rolling"
+	// DeploymentStrategyTypeCanary rolls out a fraction of the new deployment
+	// and completes the rollout only if the canary pods are healthy.
+	DeploymentStrategyTypeCanary DeploymentStrategyType = "Canary"
+	// DeploymentStrategyTypeBlueGreen brings up the new deployment next to the
+	// previous one and then switches a service over to it.
+	DeploymentStrategyTypeBlueGreen DeploymentStrategyType = "BlueGreen"
+	// DeploymentStrategyTypeOrdered replaces the pods of the previous deployment
+	// one at a time in a stable order, checking the readiness of the
+	// application after each replacement.
+	DeploymentStrategyTypeOrdered DeploymentStrategyType = "Ordered"
 )
 
 // CustomDeploymentStrategyParams are the input to the Custom deployment strategy.
@@ -96,6 +112,10 @@ type LifecycleHook struct {
 
 	// TagImages instructs the deployer to tag the current image referenced under a container onto an image stream tag if the deployment succeeds.
 	TagImages []TagImageHook
+
+	// ExecInPod specifies the options for a lifecycle hook which runs a command in running pods
+	// of the previous or the new deployment.
+	ExecInPod *ExecInPodHook
 }
 
 // LifecycleHookFailurePolicy describes possibles actions to take if a hook fails.
@@ -126,6 +146,34 @@ type ExecNewPodHook struct {
 	Volumes []string
 }
 
+// ExecInPodHook is a hook implementation which runs a command through the exec API in running
+// pods of the previous or the new deployment.
+type ExecInPodHook struct {
+	// Command is the action command and its arguments.
+	Command []string
+	// ContainerName is the name of the container in the pods the command is run in.
+	ContainerName string
+	// Target is the deployment whose pods the command is run in.
+	Target ExecInPodTarget
+	// AllPods runs the command in every running pod of the target deployment, one pod at a time,
+	// rather than in a single one.
+	AllPods bool
+	// TimeoutSeconds is how long the command may run in each pod before the hook fails.
+	TimeoutSeconds *int64
+}
+
+// ExecInPodTarget is the deployment whose pods an ExecInPod hook runs in.
+type ExecInPodTarget string
+
+const (
+	// ExecInPodTargetPrevious runs the command in the pods of the previous deployment. It is not
+	// allowed in the mid hook of the Recreate strategy, which runs after those pods are removed.
+	ExecInPodTargetPrevious ExecInPodTarget = "Previous"
+	// ExecInPodTargetNew runs the command in the pods of the new deployment. It is not allowed in
+	// pre hooks, which run before the new deployment has any pods.
+	ExecInPodTargetNew ExecInPodTarget = "New"
+)
+
 // TagImageHook is a request to tag the image in a particular container onto an ImageStreamTag.
 type TagImageHook struct {
 	// ContainerName is the name of a container in the deployment config whose image value will be used as the source of the tag
@@ -181,6 +229,91 @@ type RollingDeploymentStrategyParams struct {
 	Post *LifecycleHook
 }
 
+// CanaryDeploymentStrategyParams are the input to the Canary deployment
+// strategy.
+type CanaryDeploymentStrategyParams struct {
+	// Percent is the percentage of the desired replicas the new deployment is
+	// scaled to while it is being verified. The number of canary replicas is
+	// rounded up and is at least one. If the value is nil, a default will be
+	// used.
+	Percent *int32
+	// BakeSeconds is the time the canary pods run before the health gate is
+	// checked. If the value is nil, a default will be used.
+	BakeSeconds *int64
+	// TimeoutSeconds is the time to wait for updates before giving up. If the
+	// value is nil, a default will be used.
+	TimeoutSeconds *int64
+	// HealthGate decides whether the canary is promoted once the bake period
+	// is over. If nil, the canary is promoted if its pods are still ready.
+	HealthGate *CanaryHealthGate
+	// Pre is a lifecycle hook which is executed before the deployment process
+	// begins. All LifecycleHookFailurePolicy values are supported.
+	Pre *LifecycleHook
+	// Post is a lifecycle hook which is executed after the strategy has
+	// finished all deployment logic.
+	Post *LifecycleHook
+}
+
+// CanaryHealthGate checks the health of the canary pods of a deployment. Only
+// one type of check may be specified at any time.
+type CanaryHealthGate struct {
+	// ExecNewPod runs a command in a new pod. The canary is healthy if the
+	// command exits successfully.
+	ExecNewPod *ExecNewPodHook
+	// HTTPGet probes every canary pod at its IP, so its host must be empty.
+	// The canary is healthy if every pod responds with a status code of at
+	// least 200 and below 400.
+	HTTPGet *kapi.HTTPGetAction
+}
+
+// BlueGreenDeploymentStrategyParams are the input to the BlueGreen deployment
+// strategy.
+type BlueGreenDeploymentStrategyParams struct {
+	// ServiceName is the name of the Service whose selector is switched over to
+	// the new deployment once it is running at full size.
+	ServiceName string
+	// RouteName is the name of a Route which is pointed at a Service of the new
+	// deployment when the switch-over happens. That Service is named after the
+	// deployment and has the ports of the Service named by ServiceName. Optional.
+	RouteName string
+	// TimeoutSeconds is the time to wait for updates before giving up. If the
+	// value is nil, a default will be used.
+	TimeoutSeconds *int64
+	// KeepPreviousSeconds is the time the deployment config controller keeps
+	// the previous deployment at its size after the switch-over, during which a
+	// rollback only has to switch the Service back. If the value is nil, a default will be used.
+	KeepPreviousSeconds *int64
+	// Pre is a lifecycle hook which is executed before the deployment process
+	// begins. All LifecycleHookFailurePolicy values are supported.
+	Pre *LifecycleHook
+	// Mid is a lifecycle hook which is executed once the new deployment is
+	// running at full size, before the Service is switched over to it. All
+	// LifecycleHookFailurePolicy values are supported.
+	Mid *LifecycleHook
+	// Post is a lifecycle hook which is executed after the strategy has
+	// finished all deployment logic.
+	Post *LifecycleHook
+}
+
+// OrderedDeploymentStrategyParams are the input to the Ordered deployment
+// strategy.
+type OrderedDeploymentStrategyParams struct {
+	// TimeoutSeconds is the time to wait for each replacement pod to become
+	// ready before giving up. If the value is nil, a default will be used.
+	TimeoutSeconds *int64
+	// ReadinessCheck is run after each replacement pod has become ready. The
+	// rollout moves on to the next pod only if the check succeeds, otherwise
+	// the rollout is paused: the deployment fails, but the pods replaced so far
+	// are left running until the deployment is retried. Optional.
+	ReadinessCheck *ExecNewPodHook
+	// Pre is a lifecycle hook which is executed before the deployment process
+	// begins. All LifecycleHookFailurePolicy values are supported.
+	Pre *LifecycleHook
+	// Post is a lifecycle hook which is executed after the strategy has
+	// finished all deployment logic.
+	Post *LifecycleHook
+}
+
 const (
 	// DefaultRollingTimeoutSeconds is the default TimeoutSeconds for RollingDeploymentStrategyParams.
 	DefaultRollingTimeoutSeconds int64 = 10 * 60
@@ -188,6 +321,14 @@ const (
 	DefaultRollingIntervalSeconds int64 = 1
 	// DefaultRollingUpdatePeriodSeconds is the default PeriodSeconds for RollingDeploymentStrategyParams.
 	DefaultRollingUpdatePeriodSeconds int64 = 1
+	// DefaultCanaryPercent is the default Percent for CanaryDeploymentStrategyParams.
+	DefaultCanaryPercent int32 = 10
+	// DefaultCanaryBakeSeconds is the default BakeSeconds for CanaryDeploymentStrategyParams.
+	DefaultCanaryBakeSeconds int64 = 5 * 60
+	// DefaultBlueGreenKeepPreviousSeconds is the default KeepPreviousSeconds for BlueGreenDeploymentStrategyParams.
+	DefaultBlueGreenKeepPreviousSeconds int64 = 10 * 60
+	// DefaultExecInPodTimeoutSeconds is the default TimeoutSeconds for ExecInPodHook.
+	DefaultExecInPodTimeoutSeconds int64 = 10 * 60
 )
 
 // These constants represent keys used for correlating objects related to deployments.
@@ -239,6 +380,9 @@ const (
 	// DeploymentStatusReasonAnnotation represents the reason for deployment being in a given state
 	// Used for specifying the reason for cancellation or failure of a deployment
 	DeploymentStatusReasonAnnotation = "openshift.io/deployment.status-reason"
+	// DeploymentReplicaFailureAnnotation records why the pods of a deployment could not be
+	// created, for example because the deployer pod was rejected. It is removed once they are.
+	DeploymentReplicaFailureAnnotation = "openshift.io/deployment.replica-failure"
 	// DeploymentCancelledAnnotation indicates that the deployment has been cancelled
 	// The annotation value does not matter and its mere presence indicates cancellation
 	DeploymentCancelledAnnotation = "openshift.io/deployment.cancelled"
@@ -248,12 +392,21 @@ const (
 	// DeploymentInstantiatedAnnotation indicates that the deployment has been instantiated.
 	// The annotation value does not matter and its mere presence indicates instantiation.
 	DeploymentInstantiatedAnnotation = "openshift.io/deployment.instantiated"
+	// DeploymentKeepUntilAnnotation records until when the previous deployment of a
+	// BlueGreen deployment config is kept at its size after the switch-over, in
+	// RFC3339 format.
+	DeploymentKeepUntilAnnotation = "openshift.io/deployment.keep-until"
 	// PostHookPodSuffix is the suffix added to all pre hook pods
 	PreHookPodSuffix = "hook-pre"
 	// PostHookPodSuffix is the suffix added to all mid hook pods
 	MidHookPodSuffix = "hook-mid"
 	// PostHookPodSuffix is the suffix added to all post hook pods
 	PostHookPodSuffix = "hook-post"
+	// CanaryHookPodSuffix is the suffix added to all canary health gate pods
+	CanaryHookPodSuffix = "hook-canary"
+	// ReadinessCheckPodSuffix is the suffix added to all ordered readiness check
+	// pods, followed by the generated part of the name of the checked pod.
+	ReadinessCheckPodSuffix = "hook-ready"
 )
 
 // These constants represent the various reasons for cancelling a deployment
@@ -263,6 +416,47 @@ const (
 	DeploymentCancelledNewerDeploymentExists  = "cancelled as a newer deployment was found running"
 	DeploymentFailedUnrelatedDeploymentExists = "unrelated pod with the same name as this deployment is already running"
 	DeploymentFailedDeployerPodNoLongerExists = "deployer pod no longer exists"
+	DeploymentFailedRolloutPaused             = "rollout paused as a new pod failed to become ready"
+)
+
+// These constants represent the reasons of the conditions of a deployment config.
+const (
+	// ReplicationControllerPendingReason is added to the Progressing condition while the
+	// latest deployment has not been created yet.
+	ReplicationControllerPendingReason = "ReplicationControllerPending"
+	// NewReplicationControllerReason is added to the Progressing condition when a new
+	// deployment is created.
+	NewReplicationControllerReason = "NewReplicationControllerCreated"
+	// ReplicationControllerUpdatedReason is added to the Progressing condition while the
+	// deployer rolls out a deployment.
+	ReplicationControllerUpdatedReason = "ReplicationControllerUpdated"
+	// NewReplicationControllerAvailableReason is added to the Progressing condition when the
+	// latest deployment is complete.
+	NewReplicationControllerAvailableReason = "NewReplicationControllerAvailable"
+	// FailedReplicationControllerCreateReason is added to the Progressing condition when the
+	// latest deployment can't be created.
+	FailedReplicationControllerCreateReason = "ReplicationControllerCreateError"
+	// RolloutFailedReason is added to the Progressing condition when the latest deployment
+	// failed.
+	RolloutFailedReason = "RolloutFailed"
+	// RolloutCancelledReason is added to the Progressing condition when the latest deployment
+	// was cancelled.
+	RolloutCancelledReason = "RolloutCancelled"
+	// RolloutPausedReason is added to the Progressing condition when the latest deployment
+	// failed but its rollout is paused, waiting to be retried.
+	RolloutPausedReason = "RolloutPaused"
+	// PausedConfigReason is added to the Progressing condition while the deployment config is
+	// paused.
+	PausedConfigReason = "DeploymentConfigPaused"
+	// MinimumReplicasAvailableReason is added to the Available condition when the minimum
+	// required replicas are available.
+	MinimumReplicasAvailableReason = "MinimumReplicasAvailable"
+	// MinimumReplicasUnavailableReason is added to the Available condition when fewer than
+	// the minimum required replicas are available.
+	MinimumReplicasUnavailableReason = "MinimumReplicasUnavailable"
+	// FailedCreateReason is added to the ReplicaFailure condition when the pods of the latest
+	// deployment can't be created.
+	FailedCreateReason = "FailedCreate"
 )
 
 // MaxDeploymentDurationSeconds represents the maximum duration that a deployment is allowed to run
@@ -315,6 +509,20 @@ type DeploymentConfigSpec struct {
 	// changes or changes in the template caused by other triggers.
 	Paused bool
 
+	// AutoRollback indicates that a failed deployment of this config is rolled back to the last
+	// complete deployment automatically.
+	AutoRollback bool
+
+	// RevisionHistoryLimit is the number of old deployments of this config to keep. Older
+	// deployments which are scaled down are deleted by the deployment config controller. The last
+	// complete deployment is always kept. When unset, no deployments are deleted.
+	RevisionHistoryLimit *int32
+
+	// DeploymentWindow restricts when new deployments triggered by image change, resource change
+	// or config change triggers may start. Triggered deployments outside of the window are kept
+	// pending until it opens. When unset, deployments start as soon as they are triggered.
+	DeploymentWindow *DeploymentWindow
+
 	// Selector is a label query over pods that should match the Replicas count.
 	Selector map[string]string
 
@@ -342,6 +550,63 @@ type DeploymentConfigStatus struct {
 	// Details are the reasons for the update to this deployment config.
 	// This could be based on a change made by the user or caused by an automatic trigger
 	Details *DeploymentDetails
+	// Conditions represent the latest available observations of the current state of the
+	// deployment config.
+	Conditions []DeploymentCondition
+	// PendingDeployment describes a triggered deployment which waits for the deployment window
+	// to open.
+	PendingDeployment *PendingDeployment
+}
+
+// DeploymentWindow describes the times at which new deployments of a deployment config may start.
+type DeploymentWindow struct {
+	// Schedule is a cron expression (minute, hour, day of month, month and day of week) matching
+	// the minutes in which new deployments may start, e.g. "* 2-4 * * 6" for Saturdays between
+	// 2:00 and 4:59.
+	Schedule string
+	// TimeZone is the name of the time zone the schedule is evaluated in, e.g. "Europe/Prague".
+	// Defaults to UTC.
+	TimeZone string
+}
+
+// PendingDeployment describes a triggered deployment which waits for the deployment window to open.
+type PendingDeployment struct {
+	// Causes are the causes of the pending deployment.
+	Causes []DeploymentCause
+	// ScheduledTime is the time the deployment window opens next.
+	ScheduledTime unversioned.Time
+}
+
+// DeploymentConditionType describes the state of a deployment config at a certain point.
+type DeploymentConditionType string
+
+// These are valid conditions of a deployment config.
+const (
+	// DeploymentAvailable means the deployment config is available, ie. at least the minimum
+	// available replicas required are up and running.
+	DeploymentAvailable DeploymentConditionType = "Available"
+	// DeploymentProgressing means the deployment config is progressing. Progress for a
+	// deployment config is considered when a new deployment is created and its pods are
+	// rolled out. A complete deployment leaves the condition true, a failed deployment
+	// turns it to false.
+	DeploymentProgressing DeploymentConditionType = "Progressing"
+	// DeploymentReplicaFailure is added to a deployment config when the pods of its latest
+	// deployment fail to be created.
+	DeploymentReplicaFailure DeploymentConditionType = "ReplicaFailure"
+)
+
+// DeploymentCondition describes the state of a deployment config at a certain point.
+type DeploymentCondition struct {
+	// Type of deployment condition.
+	Type DeploymentConditionType
+	// Status of the condition, one of True, False, Unknown.
+	Status kapi.ConditionStatus
+	// The last time the condition transitioned from one status to another.
+	LastTransitionTime unversioned.Time
+	// The reason for the condition's last transition.
+	Reason string
+	// A human readable message indicating details about the transition.
+	Message string
 }
 
 // DeploymentTriggerPolicy describes a policy for a single trigger that results in a new deployment.
@@ -350,6 +615,8 @@ type DeploymentTriggerPolicy struct {
 	Type DeploymentTriggerType
 	// ImageChangeParams represents the parameters for the ImageChange trigger.
 	ImageChangeParams *DeploymentTriggerImageChangeParams
+	// ResourceChangeParams represents the parameters for the ResourceChange trigger.
+	ResourceChangeParams *DeploymentTriggerResourceChangeParams
 }
 
 // DeploymentTriggerType refers to a specific DeploymentTriggerPolicy implementation.
@@ -364,6 +631,12 @@ const (
 	// DeploymentTriggerOnConfigChange will create new deployments in response to changes to
 	// the ControllerTemplate of a DeploymentConfig.
 	DeploymentTriggerOnConfigChange DeploymentTriggerType = "ConfigChange"
+	// DeploymentTriggerOnResourceChange will create new deployments in response to changes to
+	// a Secret or a ConfigMap in the namespace of a DeploymentConfig.
+	DeploymentTriggerOnResourceChange DeploymentTriggerType = "ResourceChange"
+	// DeploymentTriggerOnAutoRollback is only used as the cause of a deployment which rolls back a
+	// failed deployment of a config with AutoRollback set; it can't be used as a trigger.
+	DeploymentTriggerOnAutoRollback DeploymentTriggerType = "AutoRollback"
 )
 
 // DeploymentTriggerImageChangeParams represents the parameters to the ImageChange trigger.
@@ -383,6 +656,16 @@ type DeploymentTriggerImageChangeParams struct {
 	LastTriggeredImage string
 }
 
+// DeploymentTriggerResourceChangeParams represents the parameters to the ResourceChange trigger.
+type DeploymentTriggerResourceChangeParams struct {
+	// From is a reference to the Secret or ConfigMap to watch for changes. From.Kind and From.Name
+	// are required and the object must be in the namespace of the deployment config.
+	From kapi.ObjectReference
+	// LastTriggeredResourceVersion is the resource version of the object which was last observed
+	// by the trigger.
+	LastTriggeredResourceVersion string
+}
+
 // DeploymentDetails captures information about the causes of a deployment.
 type DeploymentDetails struct {
 	// Message is the user specified change message, if this deployment was triggered manually by the user
@@ -397,6 +680,12 @@ type DeploymentCause struct {
 	Type DeploymentTriggerType
 	// ImageTrigger contains the image trigger details, if this trigger was fired based on an image change
 	ImageTrigger *DeploymentCauseImageTrigger
+	// AutoRollback contains the details of an automatic rollback, if this deployment rolled back a
+	// failed deployment
+	AutoRollback *DeploymentCauseAutoRollback
+	// ResourceTrigger contains the details of the changed Secret or ConfigMap, if this trigger was
+	// fired based on a resource change
+	ResourceTrigger *DeploymentCauseResourceTrigger
 }
 
 // DeploymentCauseImageTrigger contains information about a deployment caused by an image trigger
@@ -406,6 +695,22 @@ type DeploymentCauseImageTrigger struct {
 	From kapi.ObjectReference
 }
 
+// DeploymentCauseAutoRollback represents details about the cause of a deployment which rolled
+// back a failed deployment
+type DeploymentCauseAutoRollback struct {
+	// FailedDeployment is a reference to the failed deployment (a ReplicationController) which
+	// was rolled back.
+	FailedDeployment kapi.ObjectReference
+}
+
+// DeploymentCauseResourceTrigger represents details about the cause of a deployment originating
+// from a resource change trigger
+type DeploymentCauseResourceTrigger struct {
+	// From is a reference to the changed Secret or ConfigMap which triggered a deployment,
+	// including the resource version of the change.
+	From kapi.ObjectReference
+}
+
 // DeploymentConfigList is a collection of deployment configs.
 type DeploymentConfigList struct {
 	unversioned.TypeMeta
@@ -14,12 +14,24 @@ import (
 
 func init() {
 	if err := api.Scheme.AddGeneratedConversionFuncs(
+		Convert_v1_BlueGreenDeploymentStrategyParams_To_api_BlueGreenDeploymentStrategyParams,
+		Convert_api_BlueGreenDeploymentStrategyParams_To_v1_BlueGreenDeploymentStrategyParams,
+		Convert_v1_CanaryDeploymentStrategyParams_To_api_CanaryDeploymentStrategyParams,
+		Convert_api_CanaryDeploymentStrategyParams_To_v1_CanaryDeploymentStrategyParams,
+		Convert_v1_CanaryHealthGate_To_api_CanaryHealthGate,
+		Convert_api_CanaryHealthGate_To_v1_CanaryHealthGate,
 		Convert_v1_CustomDeploymentStrategyParams_To_api_CustomDeploymentStrategyParams,
 		Convert_api_CustomDeploymentStrategyParams_To_v1_CustomDeploymentStrategyParams,
 		Convert_v1_DeploymentCause_To_api_DeploymentCause,
 		Convert_api_DeploymentCause_To_v1_DeploymentCause,
+		Convert_v1_DeploymentCauseAutoRollback_To_api_DeploymentCauseAutoRollback,
+		Convert_api_DeploymentCauseAutoRollback_To_v1_DeploymentCauseAutoRollback,
 		Convert_v1_DeploymentCauseImageTrigger_To_api_DeploymentCauseImageTrigger,
 		Convert_api_DeploymentCauseImageTrigger_To_v1_DeploymentCauseImageTrigger,
+		Convert_v1_DeploymentCauseResourceTrigger_To_api_DeploymentCauseResourceTrigger,
+		Convert_api_DeploymentCauseResourceTrigger_To_v1_DeploymentCauseResourceTrigger,
+		Convert_v1_DeploymentCondition_To_api_DeploymentCondition,
+		Convert_api_DeploymentCondition_To_v1_DeploymentCondition,
 		Convert_v1_DeploymentConfig_To_api_DeploymentConfig,
 		Convert_api_DeploymentConfig_To_v1_DeploymentConfig,
 		Convert_v1_DeploymentConfigList_To_api_DeploymentConfigList,
@@ -44,10 +56,20 @@ func init() {
 		Convert_api_DeploymentTriggerImageChangeParams_To_v1_DeploymentTriggerImageChangeParams,
 		Convert_v1_DeploymentTriggerPolicy_To_api_DeploymentTriggerPolicy,
 		Convert_api_DeploymentTriggerPolicy_To_v1_DeploymentTriggerPolicy,
+		Convert_v1_DeploymentTriggerResourceChangeParams_To_api_DeploymentTriggerResourceChangeParams,
+		Convert_api_DeploymentTriggerResourceChangeParams_To_v1_DeploymentTriggerResourceChangeParams,
+		Convert_v1_DeploymentWindow_To_api_DeploymentWindow,
+		Convert_api_DeploymentWindow_To_v1_DeploymentWindow,
+		Convert_v1_ExecInPodHook_To_api_ExecInPodHook,
+		Convert_api_ExecInPodHook_To_v1_ExecInPodHook,
 		Convert_v1_ExecNewPodHook_To_api_ExecNewPodHook,
 		Convert_api_ExecNewPodHook_To_v1_ExecNewPodHook,
 		Convert_v1_LifecycleHook_To_api_LifecycleHook,
 		Convert_api_LifecycleHook_To_v1_LifecycleHook,
+		Convert_v1_OrderedDeploymentStrategyParams_To_api_OrderedDeploymentStrategyParams,
+		Convert_api_OrderedDeploymentStrategyParams_To_v1_OrderedDeploymentStrategyParams,
+		Convert_v1_PendingDeployment_To_api_PendingDeployment,
+		Convert_api_PendingDeployment_To_v1_PendingDeployment,
 		Convert_v1_RecreateDeploymentStrategyParams_To_api_RecreateDeploymentStrategyParams,
 		Convert_api_RecreateDeploymentStrategyParams_To_v1_RecreateDeploymentStrategyParams,
 		Convert_v1_RollingDeploymentStrategyParams_To_api_RollingDeploymentStrategyParams,
@@ -60,6 +82,276 @@ func init() {
 	}
 }
 
+func autoConvert_v1_BlueGreenDeploymentStrategyParams_To_api_BlueGreenDeploymentStrategyParams(in *BlueGreenDeploymentStrategyParams, out *deploy_api.BlueGreenDeploymentStrategyParams, s conversion.Scope) error {
+	SetDefaults_BlueGreenDeploymentStrategyParams(in)
+	out.ServiceName = in.ServiceName
+	out.RouteName = in.RouteName
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	if in.KeepPreviousSeconds != nil {
+		in, out := &in.KeepPreviousSeconds, &out.KeepPreviousSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.KeepPreviousSeconds = nil
+	}
+	if in.Pre != nil {
+		in, out := &in.Pre, &out.Pre
+		*out = new(deploy_api.LifecycleHook)
+		if err := Convert_v1_LifecycleHook_To_api_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pre = nil
+	}
+	if in.Mid != nil {
+		in, out := &in.Mid, &out.Mid
+		*out = new(deploy_api.LifecycleHook)
+		if err := Convert_v1_LifecycleHook_To_api_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = new(deploy_api.LifecycleHook)
+		if err := Convert_v1_LifecycleHook_To_api_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Post = nil
+	}
+	return nil
+}
+
+func Convert_v1_BlueGreenDeploymentStrategyParams_To_api_BlueGreenDeploymentStrategyParams(in *BlueGreenDeploymentStrategyParams, out *deploy_api.BlueGreenDeploymentStrategyParams, s conversion.Scope) error {
+	return autoConvert_v1_BlueGreenDeploymentStrategyParams_To_api_BlueGreenDeploymentStrategyParams(in, out, s)
+}
+
+func autoConvert_api_BlueGreenDeploymentStrategyParams_To_v1_BlueGreenDeploymentStrategyParams(in *deploy_api.BlueGreenDeploymentStrategyParams, out *BlueGreenDeploymentStrategyParams, s conversion.Scope) error {
+	out.ServiceName = in.ServiceName
+	out.RouteName = in.RouteName
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	if in.KeepPreviousSeconds != nil {
+		in, out := &in.KeepPreviousSeconds, &out.KeepPreviousSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.KeepPreviousSeconds = nil
+	}
+	if in.Pre != nil {
+		in, out := &in.Pre, &out.Pre
+		*out = new(LifecycleHook)
+		if err := Convert_api_LifecycleHook_To_v1_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pre = nil
+	}
+	if in.Mid != nil {
+		in, out := &in.Mid, &out.Mid
+		*out = new(LifecycleHook)
+		if err := Convert_api_LifecycleHook_To_v1_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Mid = nil
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = new(LifecycleHook)
+		if err := Convert_api_LifecycleHook_To_v1_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Post = nil
+	}
+	return nil
+}
+
+func Convert_api_BlueGreenDeploymentStrategyParams_To_v1_BlueGreenDeploymentStrategyParams(in *deploy_api.BlueGreenDeploymentStrategyParams, out *BlueGreenDeploymentStrategyParams, s conversion.Scope) error {
+	return autoConvert_api_BlueGreenDeploymentStrategyParams_To_v1_BlueGreenDeploymentStrategyParams(in, out, s)
+}
+
+func autoConvert_v1_CanaryDeploymentStrategyParams_To_api_CanaryDeploymentStrategyParams(in *CanaryDeploymentStrategyParams, out *deploy_api.CanaryDeploymentStrategyParams, s conversion.Scope) error {
+	SetDefaults_CanaryDeploymentStrategyParams(in)
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	} else {
+		out.Percent = nil
+	}
+	if in.BakeSeconds != nil {
+		in, out := &in.BakeSeconds, &out.BakeSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.BakeSeconds = nil
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(deploy_api.CanaryHealthGate)
+		if err := Convert_v1_CanaryHealthGate_To_api_CanaryHealthGate(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthGate = nil
+	}
+	if in.Pre != nil {
+		in, out := &in.Pre, &out.Pre
+		*out = new(deploy_api.LifecycleHook)
+		if err := Convert_v1_LifecycleHook_To_api_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pre = nil
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = new(deploy_api.LifecycleHook)
+		if err := Convert_v1_LifecycleHook_To_api_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Post = nil
+	}
+	return nil
+}
+
+func Convert_v1_CanaryDeploymentStrategyParams_To_api_CanaryDeploymentStrategyParams(in *CanaryDeploymentStrategyParams, out *deploy_api.CanaryDeploymentStrategyParams, s conversion.Scope) error {
+	return autoConvert_v1_CanaryDeploymentStrategyParams_To_api_CanaryDeploymentStrategyParams(in, out, s)
+}
+
+func autoConvert_api_CanaryDeploymentStrategyParams_To_v1_CanaryDeploymentStrategyParams(in *deploy_api.CanaryDeploymentStrategyParams, out *CanaryDeploymentStrategyParams, s conversion.Scope) error {
+	if in.Percent != nil {
+		in, out := &in.Percent, &out.Percent
+		*out = new(int32)
+		**out = **in
+	} else {
+		out.Percent = nil
+	}
+	if in.BakeSeconds != nil {
+		in, out := &in.BakeSeconds, &out.BakeSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.BakeSeconds = nil
+	}
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	if in.HealthGate != nil {
+		in, out := &in.HealthGate, &out.HealthGate
+		*out = new(CanaryHealthGate)
+		if err := Convert_api_CanaryHealthGate_To_v1_CanaryHealthGate(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.HealthGate = nil
+	}
+	if in.Pre != nil {
+		in, out := &in.Pre, &out.Pre
+		*out = new(LifecycleHook)
+		if err := Convert_api_LifecycleHook_To_v1_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pre = nil
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = new(LifecycleHook)
+		if err := Convert_api_LifecycleHook_To_v1_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Post = nil
+	}
+	return nil
+}
+
+func Convert_api_CanaryDeploymentStrategyParams_To_v1_CanaryDeploymentStrategyParams(in *deploy_api.CanaryDeploymentStrategyParams, out *CanaryDeploymentStrategyParams, s conversion.Scope) error {
+	return autoConvert_api_CanaryDeploymentStrategyParams_To_v1_CanaryDeploymentStrategyParams(in, out, s)
+}
+
+func autoConvert_v1_CanaryHealthGate_To_api_CanaryHealthGate(in *CanaryHealthGate, out *deploy_api.CanaryHealthGate, s conversion.Scope) error {
+	if in.ExecNewPod != nil {
+		in, out := &in.ExecNewPod, &out.ExecNewPod
+		*out = new(deploy_api.ExecNewPodHook)
+		if err := Convert_v1_ExecNewPodHook_To_api_ExecNewPodHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExecNewPod = nil
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(api.HTTPGetAction)
+		// TODO: Inefficient conversion - can we improve it?
+		if err := s.Convert(*in, *out, 0); err != nil {
+			return err
+		}
+	} else {
+		out.HTTPGet = nil
+	}
+	return nil
+}
+
+func Convert_v1_CanaryHealthGate_To_api_CanaryHealthGate(in *CanaryHealthGate, out *deploy_api.CanaryHealthGate, s conversion.Scope) error {
+	return autoConvert_v1_CanaryHealthGate_To_api_CanaryHealthGate(in, out, s)
+}
+
+func autoConvert_api_CanaryHealthGate_To_v1_CanaryHealthGate(in *deploy_api.CanaryHealthGate, out *CanaryHealthGate, s conversion.Scope) error {
+	if in.ExecNewPod != nil {
+		in, out := &in.ExecNewPod, &out.ExecNewPod
+		*out = new(ExecNewPodHook)
+		if err := Convert_api_ExecNewPodHook_To_v1_ExecNewPodHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExecNewPod = nil
+	}
+	if in.HTTPGet != nil {
+		in, out := &in.HTTPGet, &out.HTTPGet
+		*out = new(api_v1.HTTPGetAction)
+		// TODO: Inefficient conversion - can we improve it?
+		if err := s.Convert(*in, *out, 0); err != nil {
+			return err
+		}
+	} else {
+		out.HTTPGet = nil
+	}
+	return nil
+}
+
+func Convert_api_CanaryHealthGate_To_v1_CanaryHealthGate(in *deploy_api.CanaryHealthGate, out *CanaryHealthGate, s conversion.Scope) error {
+	return autoConvert_api_CanaryHealthGate_To_v1_CanaryHealthGate(in, out, s)
+}
+
 func autoConvert_v1_CustomDeploymentStrategyParams_To_api_CustomDeploymentStrategyParams(in *CustomDeploymentStrategyParams, out *deploy_api.CustomDeploymentStrategyParams, s conversion.Scope) error {
 	out.Image = in.Image
 	if in.Environment != nil {
@@ -127,6 +419,24 @@ func autoConvert_v1_DeploymentCause_To_api_DeploymentCause(in *DeploymentCause,
 	} else {
 		out.ImageTrigger = nil
 	}
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(deploy_api.DeploymentCauseAutoRollback)
+		if err := Convert_v1_DeploymentCauseAutoRollback_To_api_DeploymentCauseAutoRollback(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AutoRollback = nil
+	}
+	if in.ResourceTrigger != nil {
+		in, out := &in.ResourceTrigger, &out.ResourceTrigger
+		*out = new(deploy_api.DeploymentCauseResourceTrigger)
+		if err := Convert_v1_DeploymentCauseResourceTrigger_To_api_DeploymentCauseResourceTrigger(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceTrigger = nil
+	}
 	return nil
 }
 
@@ -145,6 +455,24 @@ func autoConvert_api_DeploymentCause_To_v1_DeploymentCause(in *deploy_api.Deploy
 	} else {
 		out.ImageTrigger = nil
 	}
+	if in.AutoRollback != nil {
+		in, out := &in.AutoRollback, &out.AutoRollback
+		*out = new(DeploymentCauseAutoRollback)
+		if err := Convert_api_DeploymentCauseAutoRollback_To_v1_DeploymentCauseAutoRollback(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.AutoRollback = nil
+	}
+	if in.ResourceTrigger != nil {
+		in, out := &in.ResourceTrigger, &out.ResourceTrigger
+		*out = new(DeploymentCauseResourceTrigger)
+		if err := Convert_api_DeploymentCauseResourceTrigger_To_v1_DeploymentCauseResourceTrigger(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceTrigger = nil
+	}
 	return nil
 }
 
@@ -152,6 +480,30 @@ func Convert_api_DeploymentCause_To_v1_DeploymentCause(in *deploy_api.Deployment
 	return autoConvert_api_DeploymentCause_To_v1_DeploymentCause(in, out, s)
 }
 
+func autoConvert_v1_DeploymentCauseAutoRollback_To_api_DeploymentCauseAutoRollback(in *DeploymentCauseAutoRollback, out *deploy_api.DeploymentCauseAutoRollback, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.FailedDeployment, &out.FailedDeployment, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_v1_DeploymentCauseAutoRollback_To_api_DeploymentCauseAutoRollback(in *DeploymentCauseAutoRollback, out *deploy_api.DeploymentCauseAutoRollback, s conversion.Scope) error {
+	return autoConvert_v1_DeploymentCauseAutoRollback_To_api_DeploymentCauseAutoRollback(in, out, s)
+}
+
+func autoConvert_api_DeploymentCauseAutoRollback_To_v1_DeploymentCauseAutoRollback(in *deploy_api.DeploymentCauseAutoRollback, out *DeploymentCauseAutoRollback, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.FailedDeployment, &out.FailedDeployment, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_DeploymentCauseAutoRollback_To_v1_DeploymentCauseAutoRollback(in *deploy_api.DeploymentCauseAutoRollback, out *DeploymentCauseAutoRollback, s conversion.Scope) error {
+	return autoConvert_api_DeploymentCauseAutoRollback_To_v1_DeploymentCauseAutoRollback(in, out, s)
+}
+
 func autoConvert_v1_DeploymentCauseImageTrigger_To_api_DeploymentCauseImageTrigger(in *DeploymentCauseImageTrigger, out *deploy_api.DeploymentCauseImageTrigger, s conversion.Scope) error {
 	// TODO: Inefficient conversion - can we improve it?
 	if err := s.Convert(&in.From, &out.From, 0); err != nil {
@@ -164,16 +516,70 @@ func Convert_v1_DeploymentCauseImageTrigger_To_api_DeploymentCauseImageTrigger(i
 	return autoConvert_v1_DeploymentCauseImageTrigger_To_api_DeploymentCauseImageTrigger(in, out, s)
 }
 
-func autoConvert_api_DeploymentCauseImageTrigger_To_v1_DeploymentCauseImageTrigger(in *deploy_api.DeploymentCauseImageTrigger, out *DeploymentCauseImageTrigger, s conversion.Scope) error {
-	// TODO: Inefficient conversion - can we improve it?
-	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+func autoConvert_api_DeploymentCauseImageTrigger_To_v1_DeploymentCauseImageTrigger(in *deploy_api.DeploymentCauseImageTrigger, out *DeploymentCauseImageTrigger, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_DeploymentCauseImageTrigger_To_v1_DeploymentCauseImageTrigger(in *deploy_api.DeploymentCauseImageTrigger, out *DeploymentCauseImageTrigger, s conversion.Scope) error {
+	return autoConvert_api_DeploymentCauseImageTrigger_To_v1_DeploymentCauseImageTrigger(in, out, s)
+}
+
+func autoConvert_v1_DeploymentCauseResourceTrigger_To_api_DeploymentCauseResourceTrigger(in *DeploymentCauseResourceTrigger, out *deploy_api.DeploymentCauseResourceTrigger, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_v1_DeploymentCauseResourceTrigger_To_api_DeploymentCauseResourceTrigger(in *DeploymentCauseResourceTrigger, out *deploy_api.DeploymentCauseResourceTrigger, s conversion.Scope) error {
+	return autoConvert_v1_DeploymentCauseResourceTrigger_To_api_DeploymentCauseResourceTrigger(in, out, s)
+}
+
+func autoConvert_api_DeploymentCauseResourceTrigger_To_v1_DeploymentCauseResourceTrigger(in *deploy_api.DeploymentCauseResourceTrigger, out *DeploymentCauseResourceTrigger, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_DeploymentCauseResourceTrigger_To_v1_DeploymentCauseResourceTrigger(in *deploy_api.DeploymentCauseResourceTrigger, out *DeploymentCauseResourceTrigger, s conversion.Scope) error {
+	return autoConvert_api_DeploymentCauseResourceTrigger_To_v1_DeploymentCauseResourceTrigger(in, out, s)
+}
+
+func autoConvert_v1_DeploymentCondition_To_api_DeploymentCondition(in *DeploymentCondition, out *deploy_api.DeploymentCondition, s conversion.Scope) error {
+	out.Type = deploy_api.DeploymentConditionType(in.Type)
+	out.Status = api.ConditionStatus(in.Status)
+	if err := api.Convert_unversioned_Time_To_unversioned_Time(&in.LastTransitionTime, &out.LastTransitionTime, s); err != nil {
+		return err
+	}
+	out.Reason = in.Reason
+	out.Message = in.Message
+	return nil
+}
+
+func Convert_v1_DeploymentCondition_To_api_DeploymentCondition(in *DeploymentCondition, out *deploy_api.DeploymentCondition, s conversion.Scope) error {
+	return autoConvert_v1_DeploymentCondition_To_api_DeploymentCondition(in, out, s)
+}
+
+func autoConvert_api_DeploymentCondition_To_v1_DeploymentCondition(in *deploy_api.DeploymentCondition, out *DeploymentCondition, s conversion.Scope) error {
+	out.Type = DeploymentConditionType(in.Type)
+	out.Status = api_v1.ConditionStatus(in.Status)
+	if err := api.Convert_unversioned_Time_To_unversioned_Time(&in.LastTransitionTime, &out.LastTransitionTime, s); err != nil {
 		return err
 	}
+	out.Reason = in.Reason
+	out.Message = in.Message
 	return nil
 }
 
-func Convert_api_DeploymentCauseImageTrigger_To_v1_DeploymentCauseImageTrigger(in *deploy_api.DeploymentCauseImageTrigger, out *DeploymentCauseImageTrigger, s conversion.Scope) error {
-	return autoConvert_api_DeploymentCauseImageTrigger_To_v1_DeploymentCauseImageTrigger(in, out, s)
+func Convert_api_DeploymentCondition_To_v1_DeploymentCondition(in *deploy_api.DeploymentCondition, out *DeploymentCondition, s conversion.Scope) error {
+	return autoConvert_api_DeploymentCondition_To_v1_DeploymentCondition(in, out, s)
 }
 
 func autoConvert_v1_DeploymentConfig_To_api_DeploymentConfig(in *DeploymentConfig, out *deploy_api.DeploymentConfig, s conversion.Scope) error {
@@ -348,6 +754,23 @@ func autoConvert_v1_DeploymentConfigSpec_To_api_DeploymentConfigSpec(in *Deploym
 	out.Replicas = in.Replicas
 	out.Test = in.Test
 	out.Paused = in.Paused
+	out.AutoRollback = in.AutoRollback
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	} else {
+		out.RevisionHistoryLimit = nil
+	}
+	if in.DeploymentWindow != nil {
+		in, out := &in.DeploymentWindow, &out.DeploymentWindow
+		*out = new(deploy_api.DeploymentWindow)
+		if err := Convert_v1_DeploymentWindow_To_api_DeploymentWindow(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DeploymentWindow = nil
+	}
 	if in.Selector != nil {
 		in, out := &in.Selector, &out.Selector
 		*out = make(map[string]string, len(*in))
@@ -392,6 +815,23 @@ func autoConvert_api_DeploymentConfigSpec_To_v1_DeploymentConfigSpec(in *deploy_
 	out.Replicas = in.Replicas
 	out.Test = in.Test
 	out.Paused = in.Paused
+	out.AutoRollback = in.AutoRollback
+	if in.RevisionHistoryLimit != nil {
+		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
+		*out = new(int32)
+		**out = **in
+	} else {
+		out.RevisionHistoryLimit = nil
+	}
+	if in.DeploymentWindow != nil {
+		in, out := &in.DeploymentWindow, &out.DeploymentWindow
+		*out = new(DeploymentWindow)
+		if err := Convert_api_DeploymentWindow_To_v1_DeploymentWindow(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.DeploymentWindow = nil
+	}
 	if in.Selector != nil {
 		in, out := &in.Selector, &out.Selector
 		*out = make(map[string]string, len(*in))
@@ -434,6 +874,26 @@ func autoConvert_v1_DeploymentConfigStatus_To_api_DeploymentConfigStatus(in *Dep
 	} else {
 		out.Details = nil
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]deploy_api.DeploymentCondition, len(*in))
+		for i := range *in {
+			if err := Convert_v1_DeploymentCondition_To_api_DeploymentCondition(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.PendingDeployment != nil {
+		in, out := &in.PendingDeployment, &out.PendingDeployment
+		*out = new(deploy_api.PendingDeployment)
+		if err := Convert_v1_PendingDeployment_To_api_PendingDeployment(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PendingDeployment = nil
+	}
 	return nil
 }
 
@@ -457,6 +917,26 @@ func autoConvert_api_DeploymentConfigStatus_To_v1_DeploymentConfigStatus(in *dep
 	} else {
 		out.Details = nil
 	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]DeploymentCondition, len(*in))
+		for i := range *in {
+			if err := Convert_api_DeploymentCondition_To_v1_DeploymentCondition(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Conditions = nil
+	}
+	if in.PendingDeployment != nil {
+		in, out := &in.PendingDeployment, &out.PendingDeployment
+		*out = new(PendingDeployment)
+		if err := Convert_api_PendingDeployment_To_v1_PendingDeployment(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.PendingDeployment = nil
+	}
 	return nil
 }
 
@@ -662,6 +1142,33 @@ func autoConvert_v1_DeploymentStrategy_To_api_DeploymentStrategy(in *DeploymentS
 	} else {
 		out.RollingParams = nil
 	}
+	if in.CanaryParams != nil {
+		in, out := &in.CanaryParams, &out.CanaryParams
+		*out = new(deploy_api.CanaryDeploymentStrategyParams)
+		if err := Convert_v1_CanaryDeploymentStrategyParams_To_api_CanaryDeploymentStrategyParams(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CanaryParams = nil
+	}
+	if in.BlueGreenParams != nil {
+		in, out := &in.BlueGreenParams, &out.BlueGreenParams
+		*out = new(deploy_api.BlueGreenDeploymentStrategyParams)
+		if err := Convert_v1_BlueGreenDeploymentStrategyParams_To_api_BlueGreenDeploymentStrategyParams(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BlueGreenParams = nil
+	}
+	if in.OrderedParams != nil {
+		in, out := &in.OrderedParams, &out.OrderedParams
+		*out = new(deploy_api.OrderedDeploymentStrategyParams)
+		if err := Convert_v1_OrderedDeploymentStrategyParams_To_api_OrderedDeploymentStrategyParams(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.OrderedParams = nil
+	}
 	// TODO: Inefficient conversion - can we improve it?
 	if err := s.Convert(&in.Resources, &out.Resources, 0); err != nil {
 		return err
@@ -711,6 +1218,33 @@ func autoConvert_api_DeploymentStrategy_To_v1_DeploymentStrategy(in *deploy_api.
 	} else {
 		out.RollingParams = nil
 	}
+	if in.CanaryParams != nil {
+		in, out := &in.CanaryParams, &out.CanaryParams
+		*out = new(CanaryDeploymentStrategyParams)
+		if err := Convert_api_CanaryDeploymentStrategyParams_To_v1_CanaryDeploymentStrategyParams(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.CanaryParams = nil
+	}
+	if in.BlueGreenParams != nil {
+		in, out := &in.BlueGreenParams, &out.BlueGreenParams
+		*out = new(BlueGreenDeploymentStrategyParams)
+		if err := Convert_api_BlueGreenDeploymentStrategyParams_To_v1_BlueGreenDeploymentStrategyParams(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.BlueGreenParams = nil
+	}
+	if in.OrderedParams != nil {
+		in, out := &in.OrderedParams, &out.OrderedParams
+		*out = new(OrderedDeploymentStrategyParams)
+		if err := Convert_api_OrderedDeploymentStrategyParams_To_v1_OrderedDeploymentStrategyParams(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.OrderedParams = nil
+	}
 	if in.CustomParams != nil {
 		in, out := &in.CustomParams, &out.CustomParams
 		*out = new(CustomDeploymentStrategyParams)
@@ -794,6 +1328,15 @@ func autoConvert_v1_DeploymentTriggerPolicy_To_api_DeploymentTriggerPolicy(in *D
 	} else {
 		out.ImageChangeParams = nil
 	}
+	if in.ResourceChangeParams != nil {
+		in, out := &in.ResourceChangeParams, &out.ResourceChangeParams
+		*out = new(deploy_api.DeploymentTriggerResourceChangeParams)
+		if err := Convert_v1_DeploymentTriggerResourceChangeParams_To_api_DeploymentTriggerResourceChangeParams(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceChangeParams = nil
+	}
 	return nil
 }
 
@@ -812,6 +1355,15 @@ func autoConvert_api_DeploymentTriggerPolicy_To_v1_DeploymentTriggerPolicy(in *d
 	} else {
 		out.ImageChangeParams = nil
 	}
+	if in.ResourceChangeParams != nil {
+		in, out := &in.ResourceChangeParams, &out.ResourceChangeParams
+		*out = new(DeploymentTriggerResourceChangeParams)
+		if err := Convert_api_DeploymentTriggerResourceChangeParams_To_v1_DeploymentTriggerResourceChangeParams(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ResourceChangeParams = nil
+	}
 	return nil
 }
 
@@ -819,6 +1371,103 @@ func Convert_api_DeploymentTriggerPolicy_To_v1_DeploymentTriggerPolicy(in *deplo
 	return autoConvert_api_DeploymentTriggerPolicy_To_v1_DeploymentTriggerPolicy(in, out, s)
 }
 
+func autoConvert_v1_DeploymentTriggerResourceChangeParams_To_api_DeploymentTriggerResourceChangeParams(in *DeploymentTriggerResourceChangeParams, out *deploy_api.DeploymentTriggerResourceChangeParams, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+		return err
+	}
+	out.LastTriggeredResourceVersion = in.LastTriggeredResourceVersion
+	return nil
+}
+
+func Convert_v1_DeploymentTriggerResourceChangeParams_To_api_DeploymentTriggerResourceChangeParams(in *DeploymentTriggerResourceChangeParams, out *deploy_api.DeploymentTriggerResourceChangeParams, s conversion.Scope) error {
+	return autoConvert_v1_DeploymentTriggerResourceChangeParams_To_api_DeploymentTriggerResourceChangeParams(in, out, s)
+}
+
+func autoConvert_api_DeploymentTriggerResourceChangeParams_To_v1_DeploymentTriggerResourceChangeParams(in *deploy_api.DeploymentTriggerResourceChangeParams, out *DeploymentTriggerResourceChangeParams, s conversion.Scope) error {
+	// TODO: Inefficient conversion - can we improve it?
+	if err := s.Convert(&in.From, &out.From, 0); err != nil {
+		return err
+	}
+	out.LastTriggeredResourceVersion = in.LastTriggeredResourceVersion
+	return nil
+}
+
+func Convert_api_DeploymentTriggerResourceChangeParams_To_v1_DeploymentTriggerResourceChangeParams(in *deploy_api.DeploymentTriggerResourceChangeParams, out *DeploymentTriggerResourceChangeParams, s conversion.Scope) error {
+	return autoConvert_api_DeploymentTriggerResourceChangeParams_To_v1_DeploymentTriggerResourceChangeParams(in, out, s)
+}
+
+func autoConvert_v1_DeploymentWindow_To_api_DeploymentWindow(in *DeploymentWindow, out *deploy_api.DeploymentWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.TimeZone = in.TimeZone
+	return nil
+}
+
+func Convert_v1_DeploymentWindow_To_api_DeploymentWindow(in *DeploymentWindow, out *deploy_api.DeploymentWindow, s conversion.Scope) error {
+	return autoConvert_v1_DeploymentWindow_To_api_DeploymentWindow(in, out, s)
+}
+
+func autoConvert_api_DeploymentWindow_To_v1_DeploymentWindow(in *deploy_api.DeploymentWindow, out *DeploymentWindow, s conversion.Scope) error {
+	out.Schedule = in.Schedule
+	out.TimeZone = in.TimeZone
+	return nil
+}
+
+func Convert_api_DeploymentWindow_To_v1_DeploymentWindow(in *deploy_api.DeploymentWindow, out *DeploymentWindow, s conversion.Scope) error {
+	return autoConvert_api_DeploymentWindow_To_v1_DeploymentWindow(in, out, s)
+}
+
+func autoConvert_v1_ExecInPodHook_To_api_ExecInPodHook(in *ExecInPodHook, out *deploy_api.ExecInPodHook, s conversion.Scope) error {
+	SetDefaults_ExecInPodHook(in)
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.Target = deploy_api.ExecInPodTarget(in.Target)
+	out.AllPods = in.AllPods
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	return nil
+}
+
+func Convert_v1_ExecInPodHook_To_api_ExecInPodHook(in *ExecInPodHook, out *deploy_api.ExecInPodHook, s conversion.Scope) error {
+	return autoConvert_v1_ExecInPodHook_To_api_ExecInPodHook(in, out, s)
+}
+
+func autoConvert_api_ExecInPodHook_To_v1_ExecInPodHook(in *deploy_api.ExecInPodHook, out *ExecInPodHook, s conversion.Scope) error {
+	if in.Command != nil {
+		in, out := &in.Command, &out.Command
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.Command = nil
+	}
+	out.ContainerName = in.ContainerName
+	out.Target = ExecInPodTarget(in.Target)
+	out.AllPods = in.AllPods
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	return nil
+}
+
+func Convert_api_ExecInPodHook_To_v1_ExecInPodHook(in *deploy_api.ExecInPodHook, out *ExecInPodHook, s conversion.Scope) error {
+	return autoConvert_api_ExecInPodHook_To_v1_ExecInPodHook(in, out, s)
+}
+
 func autoConvert_v1_ExecNewPodHook_To_api_ExecNewPodHook(in *ExecNewPodHook, out *deploy_api.ExecNewPodHook, s conversion.Scope) error {
 	if in.Command != nil {
 		in, out := &in.Command, &out.Command
@@ -911,6 +1560,15 @@ func autoConvert_v1_LifecycleHook_To_api_LifecycleHook(in *LifecycleHook, out *d
 	} else {
 		out.TagImages = nil
 	}
+	if in.ExecInPod != nil {
+		in, out := &in.ExecInPod, &out.ExecInPod
+		*out = new(deploy_api.ExecInPodHook)
+		if err := Convert_v1_ExecInPodHook_To_api_ExecInPodHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExecInPod = nil
+	}
 	return nil
 }
 
@@ -940,6 +1598,15 @@ func autoConvert_api_LifecycleHook_To_v1_LifecycleHook(in *deploy_api.LifecycleH
 	} else {
 		out.TagImages = nil
 	}
+	if in.ExecInPod != nil {
+		in, out := &in.ExecInPod, &out.ExecInPod
+		*out = new(ExecInPodHook)
+		if err := Convert_api_ExecInPodHook_To_v1_ExecInPodHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ExecInPod = nil
+	}
 	return nil
 }
 
@@ -947,6 +1614,135 @@ func Convert_api_LifecycleHook_To_v1_LifecycleHook(in *deploy_api.LifecycleHook,
 	return autoConvert_api_LifecycleHook_To_v1_LifecycleHook(in, out, s)
 }
 
+func autoConvert_v1_OrderedDeploymentStrategyParams_To_api_OrderedDeploymentStrategyParams(in *OrderedDeploymentStrategyParams, out *deploy_api.OrderedDeploymentStrategyParams, s conversion.Scope) error {
+	SetDefaults_OrderedDeploymentStrategyParams(in)
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	if in.ReadinessCheck != nil {
+		in, out := &in.ReadinessCheck, &out.ReadinessCheck
+		*out = new(deploy_api.ExecNewPodHook)
+		if err := Convert_v1_ExecNewPodHook_To_api_ExecNewPodHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessCheck = nil
+	}
+	if in.Pre != nil {
+		in, out := &in.Pre, &out.Pre
+		*out = new(deploy_api.LifecycleHook)
+		if err := Convert_v1_LifecycleHook_To_api_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pre = nil
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = new(deploy_api.LifecycleHook)
+		if err := Convert_v1_LifecycleHook_To_api_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Post = nil
+	}
+	return nil
+}
+
+func Convert_v1_OrderedDeploymentStrategyParams_To_api_OrderedDeploymentStrategyParams(in *OrderedDeploymentStrategyParams, out *deploy_api.OrderedDeploymentStrategyParams, s conversion.Scope) error {
+	return autoConvert_v1_OrderedDeploymentStrategyParams_To_api_OrderedDeploymentStrategyParams(in, out, s)
+}
+
+func autoConvert_api_OrderedDeploymentStrategyParams_To_v1_OrderedDeploymentStrategyParams(in *deploy_api.OrderedDeploymentStrategyParams, out *OrderedDeploymentStrategyParams, s conversion.Scope) error {
+	if in.TimeoutSeconds != nil {
+		in, out := &in.TimeoutSeconds, &out.TimeoutSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.TimeoutSeconds = nil
+	}
+	if in.ReadinessCheck != nil {
+		in, out := &in.ReadinessCheck, &out.ReadinessCheck
+		*out = new(ExecNewPodHook)
+		if err := Convert_api_ExecNewPodHook_To_v1_ExecNewPodHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.ReadinessCheck = nil
+	}
+	if in.Pre != nil {
+		in, out := &in.Pre, &out.Pre
+		*out = new(LifecycleHook)
+		if err := Convert_api_LifecycleHook_To_v1_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Pre = nil
+	}
+	if in.Post != nil {
+		in, out := &in.Post, &out.Post
+		*out = new(LifecycleHook)
+		if err := Convert_api_LifecycleHook_To_v1_LifecycleHook(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.Post = nil
+	}
+	return nil
+}
+
+func Convert_api_OrderedDeploymentStrategyParams_To_v1_OrderedDeploymentStrategyParams(in *deploy_api.OrderedDeploymentStrategyParams, out *OrderedDeploymentStrategyParams, s conversion.Scope) error {
+	return autoConvert_api_OrderedDeploymentStrategyParams_To_v1_OrderedDeploymentStrategyParams(in, out, s)
+}
+
+func autoConvert_v1_PendingDeployment_To_api_PendingDeployment(in *PendingDeployment, out *deploy_api.PendingDeployment, s conversion.Scope) error {
+	if in.Causes != nil {
+		in, out := &in.Causes, &out.Causes
+		*out = make([]deploy_api.DeploymentCause, len(*in))
+		for i := range *in {
+			if err := Convert_v1_DeploymentCause_To_api_DeploymentCause(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Causes = nil
+	}
+	if err := api.Convert_unversioned_Time_To_unversioned_Time(&in.ScheduledTime, &out.ScheduledTime, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_v1_PendingDeployment_To_api_PendingDeployment(in *PendingDeployment, out *deploy_api.PendingDeployment, s conversion.Scope) error {
+	return autoConvert_v1_PendingDeployment_To_api_PendingDeployment(in, out, s)
+}
+
+func autoConvert_api_PendingDeployment_To_v1_PendingDeployment(in *deploy_api.PendingDeployment, out *PendingDeployment, s conversion.Scope) error {
+	if in.Causes != nil {
+		in, out := &in.Causes, &out.Causes
+		*out = make([]DeploymentCause, len(*in))
+		for i := range *in {
+			if err := Convert_api_DeploymentCause_To_v1_DeploymentCause(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Causes = nil
+	}
+	if err := api.Convert_unversioned_Time_To_unversioned_Time(&in.ScheduledTime, &out.ScheduledTime, s); err != nil {
+		return err
+	}
+	return nil
+}
+
+func Convert_api_PendingDeployment_To_v1_PendingDeployment(in *deploy_api.PendingDeployment, out *PendingDeployment, s conversion.Scope) error {
+	return autoConvert_api_PendingDeployment_To_v1_PendingDeployment(in, out, s)
+}
+
 func autoConvert_v1_RecreateDeploymentStrategyParams_To_api_RecreateDeploymentStrategyParams(in *RecreateDeploymentStrategyParams, out *deploy_api.RecreateDeploymentStrategyParams, s conversion.Scope) error {
 	SetDefaults_RecreateDeploymentStrategyParams(in)
 	if in.TimeoutSeconds != nil {
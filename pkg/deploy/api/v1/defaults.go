@@ -22,6 +22,11 @@ func defaultHookContainerName(hook *LifecycleHook, containerName string) {
 			hook.ExecNewPod.ContainerName = containerName
 		}
 	}
+	if hook.ExecInPod != nil {
+		if len(hook.ExecInPod.ContainerName) == 0 {
+			hook.ExecInPod.ContainerName = containerName
+		}
+	}
 }
 
 func SetDefaults_DeploymentConfigSpec(obj *DeploymentConfigSpec) {
@@ -46,6 +51,25 @@ func SetDefaults_DeploymentConfigSpec(obj *DeploymentConfigSpec) {
 			defaultHookContainerName(p.Pre, containerName)
 			defaultHookContainerName(p.Post, containerName)
 		}
+		if p := obj.Strategy.CanaryParams; p != nil {
+			defaultHookContainerName(p.Pre, containerName)
+			defaultHookContainerName(p.Post, containerName)
+			if p.HealthGate != nil && p.HealthGate.ExecNewPod != nil && len(p.HealthGate.ExecNewPod.ContainerName) == 0 {
+				p.HealthGate.ExecNewPod.ContainerName = containerName
+			}
+		}
+		if p := obj.Strategy.BlueGreenParams; p != nil {
+			defaultHookContainerName(p.Pre, containerName)
+			defaultHookContainerName(p.Mid, containerName)
+			defaultHookContainerName(p.Post, containerName)
+		}
+		if p := obj.Strategy.OrderedParams; p != nil {
+			defaultHookContainerName(p.Pre, containerName)
+			defaultHookContainerName(p.Post, containerName)
+			if p.ReadinessCheck != nil && len(p.ReadinessCheck.ContainerName) == 0 {
+				p.ReadinessCheck.ContainerName = containerName
+			}
+		}
 	}
 }
 
@@ -64,6 +88,12 @@ func SetDefaults_DeploymentStrategy(obj *DeploymentStrategy) {
 	if obj.Type == DeploymentStrategyTypeRecreate && obj.RecreateParams == nil {
 		obj.RecreateParams = &RecreateDeploymentStrategyParams{}
 	}
+	if obj.Type == DeploymentStrategyTypeCanary && obj.CanaryParams == nil {
+		obj.CanaryParams = &CanaryDeploymentStrategyParams{}
+	}
+	if obj.Type == DeploymentStrategyTypeOrdered && obj.OrderedParams == nil {
+		obj.OrderedParams = &OrderedDeploymentStrategyParams{}
+	}
 }
 
 func SetDefaults_RecreateDeploymentStrategyParams(obj *RecreateDeploymentStrategyParams) {
@@ -97,6 +127,43 @@ func SetDefaults_RollingDeploymentStrategyParams(obj *RollingDeploymentStrategyP
 	}
 }
 
+func SetDefaults_CanaryDeploymentStrategyParams(obj *CanaryDeploymentStrategyParams) {
+	if obj.Percent == nil {
+		percent := deployapi.DefaultCanaryPercent
+		obj.Percent = &percent
+	}
+	if obj.BakeSeconds == nil {
+		obj.BakeSeconds = mkintp(deployapi.DefaultCanaryBakeSeconds)
+	}
+	if obj.TimeoutSeconds == nil {
+		obj.TimeoutSeconds = mkintp(deployapi.DefaultRollingTimeoutSeconds)
+	}
+}
+
+func SetDefaults_BlueGreenDeploymentStrategyParams(obj *BlueGreenDeploymentStrategyParams) {
+	if obj.TimeoutSeconds == nil {
+		obj.TimeoutSeconds = mkintp(deployapi.DefaultRollingTimeoutSeconds)
+	}
+	if obj.KeepPreviousSeconds == nil {
+		obj.KeepPreviousSeconds = mkintp(deployapi.DefaultBlueGreenKeepPreviousSeconds)
+	}
+}
+
+func SetDefaults_OrderedDeploymentStrategyParams(obj *OrderedDeploymentStrategyParams) {
+	if obj.TimeoutSeconds == nil {
+		obj.TimeoutSeconds = mkintp(deployapi.DefaultRollingTimeoutSeconds)
+	}
+}
+
+func SetDefaults_ExecInPodHook(obj *ExecInPodHook) {
+	if len(obj.Target) == 0 {
+		obj.Target = ExecInPodTargetNew
+	}
+	if obj.TimeoutSeconds == nil {
+		obj.TimeoutSeconds = mkintp(deployapi.DefaultExecInPodTimeoutSeconds)
+	}
+}
+
 func SetDefaults_DeploymentConfig(obj *DeploymentConfig) {
 	for _, t := range obj.Spec.Triggers {
 		if t.ImageChangeParams != nil {
@@ -118,6 +185,10 @@ func addDefaultingFuncs(scheme *runtime.Scheme) {
 		SetDefaults_DeploymentStrategy,
 		SetDefaults_RecreateDeploymentStrategyParams,
 		SetDefaults_RollingDeploymentStrategyParams,
+		SetDefaults_CanaryDeploymentStrategyParams,
+		SetDefaults_BlueGreenDeploymentStrategyParams,
+		SetDefaults_OrderedDeploymentStrategyParams,
+		SetDefaults_ExecInPodHook,
 		SetDefaults_DeploymentConfig,
 	)
 	if err != nil {
@@ -5,6 +5,45 @@ package v1
 // by hack/update-generated-swagger-descriptions.sh and should be run after a full build of OpenShift.
 // ==== DO NOT EDIT THIS FILE MANUALLY ====
 
+var map_BlueGreenDeploymentStrategyParams = map[string]string{
+	"":                    "BlueGreenDeploymentStrategyParams are the input to the BlueGreen deployment strategy.",
+	"serviceName":         "ServiceName is the name of the Service whose selector is switched over to the new deployment once it is running at full size.",
+	"routeName":           "RouteName is the name of a Route which is pointed at a Service of the new deployment when the switch-over happens. That Service is named after the deployment and has the ports of the Service named by ServiceName. Optional.",
+	"timeoutSeconds":      "TimeoutSeconds is the time to wait for updates before giving up. If the value is nil, a default will be used.",
+	"keepPreviousSeconds": "KeepPreviousSeconds is the time the deployment config controller keeps the previous deployment at its size after the switch-over, during which a rollback only has to switch the Service back. Defaults to 600 seconds.",
+	"pre":                 "Pre is a lifecycle hook which is executed before the deployment process begins. All LifecycleHookFailurePolicy values are supported.",
+	"mid":                 "Mid is a lifecycle hook which is executed once the new deployment is running at full size, before the Service is switched over to it. All LifecycleHookFailurePolicy values are supported.",
+	"post":                "Post is a lifecycle hook which is executed after the strategy has finished all deployment logic. All LifecycleHookFailurePolicy values are supported.",
+}
+
+func (BlueGreenDeploymentStrategyParams) SwaggerDoc() map[string]string {
+	return map_BlueGreenDeploymentStrategyParams
+}
+
+var map_CanaryDeploymentStrategyParams = map[string]string{
+	"":               "CanaryDeploymentStrategyParams are the input to the Canary deployment strategy.",
+	"percent":        "Percent is the percentage of the desired replicas the new deployment is scaled to while it is being verified. The number of canary replicas is rounded up and is at least one. Defaults to 10.",
+	"bakeSeconds":    "BakeSeconds is the time the canary pods run before the health gate is checked. Defaults to 300 seconds.",
+	"timeoutSeconds": "TimeoutSeconds is the time to wait for updates before giving up. If the value is nil, a default will be used.",
+	"healthGate":     "HealthGate decides whether the canary is promoted once the bake period is over. If not set, the canary is promoted if its pods are still ready. A canary which is not promoted is scaled back to zero and the deployment fails.",
+	"pre":            "Pre is a lifecycle hook which is executed before the deployment process begins. All LifecycleHookFailurePolicy values are supported.",
+	"post":           "Post is a lifecycle hook which is executed after the strategy has finished all deployment logic. All LifecycleHookFailurePolicy values are supported.",
+}
+
+func (CanaryDeploymentStrategyParams) SwaggerDoc() map[string]string {
+	return map_CanaryDeploymentStrategyParams
+}
+
+var map_CanaryHealthGate = map[string]string{
+	"":           "CanaryHealthGate checks the health of the canary pods of a deployment. Only one type of check may be specified at any time.",
+	"execNewPod": "ExecNewPod runs a command in a new pod. The canary is healthy if the command exits successfully.",
+	"httpGet":    "HTTPGet probes every canary pod at its IP, so its host must be empty. The canary is healthy if every pod responds with a status code of at least 200 and below 400.",
+}
+
+func (CanaryHealthGate) SwaggerDoc() map[string]string {
+	return map_CanaryHealthGate
+}
+
 var map_CustomDeploymentStrategyParams = map[string]string{
 	"":            "CustomDeploymentStrategyParams are the input to the Custom deployment strategy.",
 	"image":       "Image specifies a Docker image which can carry out a deployment.",
@@ -17,15 +56,26 @@ func (CustomDeploymentStrategyParams) SwaggerDoc() map[string]string {
 }
 
 var map_DeploymentCause = map[string]string{
-	"":             "DeploymentCause captures information about a particular cause of a deployment.",
-	"type":         "Type of the trigger that resulted in the creation of a new deployment",
-	"imageTrigger": "ImageTrigger contains the image trigger details, if this trigger was fired based on an image change",
+	"":                "DeploymentCause captures information about a particular cause of a deployment.",
+	"type":            "Type of the trigger that resulted in the creation of a new deployment",
+	"imageTrigger":    "ImageTrigger contains the image trigger details, if this trigger was fired based on an image change",
+	"autoRollback":    "AutoRollback contains the details of an automatic rollback, if this deployment rolled back a failed deployment",
+	"resourceTrigger": "ResourceTrigger contains the details of the changed Secret or ConfigMap, if this trigger was fired based on a resource change",
 }
 
 func (DeploymentCause) SwaggerDoc() map[string]string {
 	return map_DeploymentCause
 }
 
+var map_DeploymentCauseAutoRollback = map[string]string{
+	"":                 "DeploymentCauseAutoRollback represents details about the cause of a deployment which rolled back a failed deployment",
+	"failedDeployment": "FailedDeployment is a reference to the failed deployment (a ReplicationController) which was rolled back.",
+}
+
+func (DeploymentCauseAutoRollback) SwaggerDoc() map[string]string {
+	return map_DeploymentCauseAutoRollback
+}
+
 var map_DeploymentCauseImageTrigger = map[string]string{
 	"":     "DeploymentCauseImageTrigger represents details about the cause of a deployment originating from an image change trigger",
 	"from": "From is a reference to the changed object which triggered a deployment. The field may have the kinds DockerImage, ImageStreamTag, or ImageStreamImage.",
@@ -35,6 +85,28 @@ func (DeploymentCauseImageTrigger) SwaggerDoc() map[string]string {
 	return map_DeploymentCauseImageTrigger
 }
 
+var map_DeploymentCauseResourceTrigger = map[string]string{
+	"":     "DeploymentCauseResourceTrigger represents details about the cause of a deployment originating from a resource change trigger",
+	"from": "From is a reference to the changed Secret or ConfigMap which triggered a deployment, including the resource version of the change.",
+}
+
+func (DeploymentCauseResourceTrigger) SwaggerDoc() map[string]string {
+	return map_DeploymentCauseResourceTrigger
+}
+
+var map_DeploymentCondition = map[string]string{
+	"":                   "DeploymentCondition describes the state of a deployment config at a certain point.",
+	"type":               "Type of deployment condition.",
+	"status":             "Status of the condition, one of True, False, Unknown.",
+	"lastTransitionTime": "The last time the condition transitioned from one status to another.",
+	"reason":             "The reason for the condition's last transition.",
+	"message":            "A human readable message indicating details about the transition.",
+}
+
+func (DeploymentCondition) SwaggerDoc() map[string]string {
+	return map_DeploymentCondition
+}
+
 var map_DeploymentConfig = map[string]string{
 	"":         "DeploymentConfig represents a configuration for a single deployment (represented as a ReplicationController). It also contains details about changes which resulted in the current state of the DeploymentConfig. Each change to the DeploymentConfig which should result in a new deployment results in an increment of LatestVersion.",
 	"metadata": "Standard object's metadata.",
@@ -79,14 +151,17 @@ func (DeploymentConfigRollbackSpec) SwaggerDoc() map[string]string {
 }
 
 var map_DeploymentConfigSpec = map[string]string{
-	"":         "DeploymentConfigSpec represents the desired state of the deployment.",
-	"strategy": "Strategy describes how a deployment is executed.",
-	"triggers": "Triggers determine how updates to a DeploymentConfig result in new deployments. If no triggers are defined, a new deployment can only occur as a result of an explicit client update to the DeploymentConfig with a new LatestVersion.",
-	"replicas": "Replicas is the number of desired replicas.",
-	"test":     "Test ensures that this deployment config will have zero replicas except while a deployment is running. This allows the deployment config to be used as a continuous deployment test - triggering on images, running the deployment, and then succeeding or failing. Post strategy hooks and After actions can be used to integrate successful deployment with an action.",
-	"paused":   "Paused indicates that the deployment config is paused resulting in no new deployments on template changes or changes in the template caused by other triggers.",
-	"selector": "Selector is a label query over pods that should match the Replicas count.",
-	"template": "Template is the object that describes the pod that will be created if insufficient replicas are detected.",
+	"":                     "DeploymentConfigSpec represents the desired state of the deployment.",
+	"strategy":             "Strategy describes how a deployment is executed.",
+	"triggers":             "Triggers determine how updates to a DeploymentConfig result in new deployments. If no triggers are defined, a new deployment can only occur as a result of an explicit client update to the DeploymentConfig with a new LatestVersion.",
+	"replicas":             "Replicas is the number of desired replicas.",
+	"test":                 "Test ensures that this deployment config will have zero replicas except while a deployment is running. This allows the deployment config to be used as a continuous deployment test - triggering on images, running the deployment, and then succeeding or failing. Post strategy hooks and After actions can be used to integrate successful deployment with an action.",
+	"paused":               "Paused indicates that the deployment config is paused resulting in no new deployments on template changes or changes in the template caused by other triggers.",
+	"autoRollback":         "AutoRollback indicates that a failed deployment of this config is rolled back to the last complete deployment automatically.",
+	"revisionHistoryLimit": "RevisionHistoryLimit is the number of old deployments of this config to keep. Older deployments which are scaled down are deleted by the deployment config controller. The last complete deployment is always kept. When unset, no deployments are deleted.",
+	"deploymentWindow":     "DeploymentWindow restricts when new deployments triggered by image change, resource change or config change triggers may start. Triggered deployments outside of the window are kept pending until it opens. When unset, deployments start as soon as they are triggered.",
+	"selector":             "Selector is a label query over pods that should match the Replicas count.",
+	"template":             "Template is the object that describes the pod that will be created if insufficient replicas are detected.",
 }
 
 func (DeploymentConfigSpec) SwaggerDoc() map[string]string {
@@ -102,6 +177,8 @@ var map_DeploymentConfigStatus = map[string]string{
 	"availableReplicas":   "AvailableReplicas is the total number of available pods targeted by this deployment config.",
 	"unavailableReplicas": "UnavailableReplicas is the total number of unavailable pods targeted by this deployment config.",
 	"details":             "Details are the reasons for the update to this deployment config. This could be based on a change made by the user or caused by an automatic trigger",
+	"conditions":          "Conditions represent the latest available observations of the current state of the deployment config.",
+	"pendingDeployment":   "PendingDeployment describes a triggered deployment which waits for the deployment window to open.",
 }
 
 func (DeploymentConfigStatus) SwaggerDoc() map[string]string {
@@ -145,14 +222,17 @@ func (DeploymentLogOptions) SwaggerDoc() map[string]string {
 }
 
 var map_DeploymentStrategy = map[string]string{
-	"":               "DeploymentStrategy describes how to perform a deployment.",
-	"type":           "Type is the name of a deployment strategy.",
-	"customParams":   "CustomParams are the input to the Custom deployment strategy.",
-	"recreateParams": "RecreateParams are the input to the Recreate deployment strategy.",
-	"rollingParams":  "RollingParams are the input to the Rolling deployment strategy.",
-	"resources":      "Resources contains resource requirements to execute the deployment and any hooks",
-	"labels":         "Labels is a set of key, value pairs added to custom deployer and lifecycle pre/post hook pods.",
-	"annotations":    "Annotations is a set of key, value pairs added to custom deployer and lifecycle pre/post hook pods.",
+	"":                "DeploymentStrategy describes how to perform a deployment.",
+	"type":            "Type is the name of a deployment strategy.",
+	"customParams":    "CustomParams are the input to the Custom deployment strategy.",
+	"recreateParams":  "RecreateParams are the input to the Recreate deployment strategy.",
+	"rollingParams":   "RollingParams are the input to the Rolling deployment strategy.",
+	"canaryParams":    "CanaryParams are the input to the Canary deployment strategy.",
+	"blueGreenParams": "BlueGreenParams are the input to the BlueGreen deployment strategy.",
+	"orderedParams":   "OrderedParams are the input to the Ordered deployment strategy.",
+	"resources":       "Resources contains resource requirements to execute the deployment and any hooks",
+	"labels":          "Labels is a set of key, value pairs added to custom deployer and lifecycle pre/post hook pods.",
+	"annotations":     "Annotations is a set of key, value pairs added to custom deployer and lifecycle pre/post hook pods.",
 }
 
 func (DeploymentStrategy) SwaggerDoc() map[string]string {
@@ -172,15 +252,49 @@ func (DeploymentTriggerImageChangeParams) SwaggerDoc() map[string]string {
 }
 
 var map_DeploymentTriggerPolicy = map[string]string{
-	"":                  "DeploymentTriggerPolicy describes a policy for a single trigger that results in a new deployment.",
-	"type":              "Type of the trigger",
-	"imageChangeParams": "ImageChangeParams represents the parameters for the ImageChange trigger.",
+	"":                     "DeploymentTriggerPolicy describes a policy for a single trigger that results in a new deployment.",
+	"type":                 "Type of the trigger",
+	"imageChangeParams":    "ImageChangeParams represents the parameters for the ImageChange trigger.",
+	"resourceChangeParams": "ResourceChangeParams represents the parameters for the ResourceChange trigger.",
 }
 
 func (DeploymentTriggerPolicy) SwaggerDoc() map[string]string {
 	return map_DeploymentTriggerPolicy
 }
 
+var map_DeploymentTriggerResourceChangeParams = map[string]string{
+	"":                             "DeploymentTriggerResourceChangeParams represents the parameters to the ResourceChange trigger.",
+	"from":                         "From is a reference to the Secret or ConfigMap to watch for changes. From.Kind and From.Name are required and the object must be in the namespace of the deployment config.",
+	"lastTriggeredResourceVersion": "LastTriggeredResourceVersion is the resource version of the object which was last observed by the trigger.",
+}
+
+func (DeploymentTriggerResourceChangeParams) SwaggerDoc() map[string]string {
+	return map_DeploymentTriggerResourceChangeParams
+}
+
+var map_DeploymentWindow = map[string]string{
+	"":         "DeploymentWindow describes the times at which new deployments of a deployment config may start.",
+	"schedule": "Schedule is a cron expression (minute, hour, day of month, month and day of week) matching the minutes in which new deployments may start, e.g. \"* 2-4 * * 6\" for Saturdays between 2:00 and 4:59.",
+	"timeZone": "TimeZone is the name of the time zone the schedule is evaluated in, e.g. \"Europe/Prague\". Defaults to UTC.",
+}
+
+func (DeploymentWindow) SwaggerDoc() map[string]string {
+	return map_DeploymentWindow
+}
+
+var map_ExecInPodHook = map[string]string{
+	"":               "ExecInPodHook is a hook implementation which runs a command through the exec API in running pods of the previous or the new deployment.",
+	"command":        "Command is the action command and its arguments.",
+	"containerName":  "ContainerName is the name of the container in the pods the command is run in. If there is only a single container in the pod template it may be omitted.",
+	"target":         "Target is the deployment whose pods the command is run in: Previous or New. Defaults to New.",
+	"allPods":        "AllPods runs the command in every running pod of the target deployment, one pod at a time, rather than in a single one.",
+	"timeoutSeconds": "TimeoutSeconds is how long the command may run in each pod before the hook fails. Defaults to 600 seconds.",
+}
+
+func (ExecInPodHook) SwaggerDoc() map[string]string {
+	return map_ExecInPodHook
+}
+
 var map_ExecNewPodHook = map[string]string{
 	"":              "ExecNewPodHook is a hook implementation which runs a command in a new pod based on the specified container which is assumed to be part of the deployment template.",
 	"command":       "Command is the action command and its arguments.",
@@ -198,12 +312,35 @@ var map_LifecycleHook = map[string]string{
 	"failurePolicy": "FailurePolicy specifies what action to take if the hook fails.",
 	"execNewPod":    "ExecNewPod specifies the options for a lifecycle hook backed by a pod.",
 	"tagImages":     "TagImages instructs the deployer to tag the current image referenced under a container onto an image stream tag.",
+	"execInPod":     "ExecInPod specifies the options for a lifecycle hook which runs a command in running pods of the previous or the new deployment.",
 }
 
 func (LifecycleHook) SwaggerDoc() map[string]string {
 	return map_LifecycleHook
 }
 
+var map_OrderedDeploymentStrategyParams = map[string]string{
+	"":               "OrderedDeploymentStrategyParams are the input to the Ordered deployment strategy. The pods of the previous deployment are removed one at a time in the order of their names, each followed by a new pod.",
+	"timeoutSeconds": "TimeoutSeconds is the time to wait for each replacement pod to become ready before giving up. If the value is nil, a default will be used.",
+	"readinessCheck": "ReadinessCheck is a command run in a new pod after each replacement pod has become ready. The name of the replacement pod is available to the command in OPENSHIFT_DEPLOYMENT_POD_NAME. The rollout moves on to the next pod only if the command succeeds, otherwise the rollout is paused: the deployment fails, but the pods replaced so far are left running until the deployment is retried, which continues with the next pod, or replaced by a newer deployment.",
+	"pre":            "Pre is a lifecycle hook which is executed before the deployment process begins. All LifecycleHookFailurePolicy values are supported.",
+	"post":           "Post is a lifecycle hook which is executed after the strategy has finished all deployment logic. All LifecycleHookFailurePolicy values are supported.",
+}
+
+func (OrderedDeploymentStrategyParams) SwaggerDoc() map[string]string {
+	return map_OrderedDeploymentStrategyParams
+}
+
+var map_PendingDeployment = map[string]string{
+	"":              "PendingDeployment describes a triggered deployment which waits for the deployment window to open.",
+	"causes":        "Causes are the causes of the pending deployment.",
+	"scheduledTime": "ScheduledTime is the time the deployment window opens next.",
+}
+
+func (PendingDeployment) SwaggerDoc() map[string]string {
+	return map_PendingDeployment
+}
+
 var map_RecreateDeploymentStrategyParams = map[string]string{
 	"":               "RecreateDeploymentStrategyParams are the input to the Recreate deployment strategy.",
 	"timeoutSeconds": "TimeoutSeconds is the time to wait for updates before giving up. If the value is nil, a default will be used.",
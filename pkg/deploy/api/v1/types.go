@@ -35,6 +35,12 @@ type DeploymentStrategy struct {
 	RecreateParams *RecreateDeploymentStrategyParams `json:"recreateParams,omitempty"`
 	// RollingParams are the input to the Rolling deployment strategy.
 	RollingParams *RollingDeploymentStrategyParams `json:"rollingParams,omitempty"`
+	// CanaryParams are the input to the Canary deployment strategy.
+	CanaryParams *CanaryDeploymentStrategyParams `json:"canaryParams,omitempty"`
+	// BlueGreenParams are the input to the BlueGreen deployment strategy.
+	BlueGreenParams *BlueGreenDeploymentStrategyParams `json:"blueGreenParams,omitempty"`
+	// OrderedParams are the input to the Ordered deployment strategy.
+	OrderedParams *OrderedDeploymentStrategyParams `json:"orderedParams,omitempty"`
 
 	// Resources contains resource requirements to execute the deployment and any hooks
 	Resources kapi.ResourceRequirements `json:"resources,omitempty"`
@@ -54,6 +60,16 @@ const (
 	DeploymentStrategyTypeCustom DeploymentStrategyType = "Custom"
 	// DeploymentStrategyTypeRolling uses the Kubernetes RollingUpdater.
 	DeploymentStrategyTypeRolling DeploymentStrategyType = "Rolling"
+	// DeploymentStrategyTypeCanary rolls out a fraction of the new deployment
+	// and completes the rollout only if the canary pods are healthy.
+	DeploymentStrategyTypeCanary DeploymentStrategyType = "Canary"
+	// DeploymentStrategyTypeBlueGreen brings up the new deployment next to the
+	// previous one and then switches a service over to it.
+	DeploymentStrategyTypeBlueGreen DeploymentStrategyType = "BlueGreen"
+	// DeploymentStrategyTypeOrdered replaces the pods of the previous deployment
+	// one at a time in a stable order, checking the readiness of the
+	// application after each replacement.
+	DeploymentStrategyTypeOrdered DeploymentStrategyType = "Ordered"
 )
 
 // CustomDeploymentStrategyParams are the input to the Custom deployment strategy.
@@ -93,6 +109,10 @@ type LifecycleHook struct {
 
 	// TagImages instructs the deployer to tag the current image referenced under a container onto an image stream tag.
 	TagImages []TagImageHook `json:"tagImages,omitempty"`
+
+	// ExecInPod specifies the options for a lifecycle hook which runs a command in running pods
+	// of the previous or the new deployment.
+	ExecInPod *ExecInPodHook `json:"execInPod,omitempty"`
 }
 
 // LifecycleHookFailurePolicy describes possibles actions to take if a hook fails.
@@ -124,6 +144,36 @@ type ExecNewPodHook struct {
 	Volumes []string `json:"volumes,omitempty"`
 }
 
+// ExecInPodHook is a hook implementation which runs a command through the exec API in running
+// pods of the previous or the new deployment.
+type ExecInPodHook struct {
+	// Command is the action command and its arguments.
+	Command []string `json:"command"`
+	// ContainerName is the name of the container in the pods the command is run in. If there is
+	// only a single container in the pod template it may be omitted.
+	ContainerName string `json:"containerName"`
+	// Target is the deployment whose pods the command is run in: Previous or New. Defaults to New.
+	Target ExecInPodTarget `json:"target,omitempty"`
+	// AllPods runs the command in every running pod of the target deployment, one pod at a time,
+	// rather than in a single one.
+	AllPods bool `json:"allPods,omitempty"`
+	// TimeoutSeconds is how long the command may run in each pod before the hook fails. Defaults
+	// to 600 seconds.
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+}
+
+// ExecInPodTarget is the deployment whose pods an ExecInPod hook runs in.
+type ExecInPodTarget string
+
+const (
+	// ExecInPodTargetPrevious runs the command in the pods of the previous deployment. It is not
+	// allowed in the mid hook of the Recreate strategy, which runs after those pods are removed.
+	ExecInPodTargetPrevious ExecInPodTarget = "Previous"
+	// ExecInPodTargetNew runs the command in the pods of the new deployment. It is not allowed in
+	// pre hooks, which run before the new deployment has any pods.
+	ExecInPodTargetNew ExecInPodTarget = "New"
+)
+
 // TagImageHook is a request to tag the image in a particular container onto an ImageStreamTag.
 type TagImageHook struct {
 	// ContainerName is the name of a container in the deployment config whose image value will be used as the source of the tag. If there is only a single
@@ -185,6 +235,96 @@ type RollingDeploymentStrategyParams struct {
 	Post *LifecycleHook `json:"post,omitempty"`
 }
 
+// CanaryDeploymentStrategyParams are the input to the Canary deployment
+// strategy.
+type CanaryDeploymentStrategyParams struct {
+	// Percent is the percentage of the desired replicas the new deployment is
+	// scaled to while it is being verified. The number of canary replicas is
+	// rounded up and is at least one. Defaults to 10.
+	Percent *int32 `json:"percent,omitempty"`
+	// BakeSeconds is the time the canary pods run before the health gate is
+	// checked. Defaults to 300 seconds.
+	BakeSeconds *int64 `json:"bakeSeconds,omitempty"`
+	// TimeoutSeconds is the time to wait for updates before giving up. If the
+	// value is nil, a default will be used.
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+	// HealthGate decides whether the canary is promoted once the bake period
+	// is over. If not set, the canary is promoted if its pods are still ready.
+	// A canary which is not promoted is scaled back to zero and the deployment
+	// fails.
+	HealthGate *CanaryHealthGate `json:"healthGate,omitempty"`
+	// Pre is a lifecycle hook which is executed before the deployment process
+	// begins. All LifecycleHookFailurePolicy values are supported.
+	Pre *LifecycleHook `json:"pre,omitempty"`
+	// Post is a lifecycle hook which is executed after the strategy has
+	// finished all deployment logic. All LifecycleHookFailurePolicy values are supported.
+	Post *LifecycleHook `json:"post,omitempty"`
+}
+
+// CanaryHealthGate checks the health of the canary pods of a deployment. Only
+// one type of check may be specified at any time.
+type CanaryHealthGate struct {
+	// ExecNewPod runs a command in a new pod. The canary is healthy if the
+	// command exits successfully.
+	ExecNewPod *ExecNewPodHook `json:"execNewPod,omitempty"`
+	// HTTPGet probes every canary pod at its IP, so its host must be empty.
+	// The canary is healthy if every pod responds with a status code of at
+	// least 200 and below 400.
+	HTTPGet *kapi.HTTPGetAction `json:"httpGet,omitempty"`
+}
+
+// BlueGreenDeploymentStrategyParams are the input to the BlueGreen deployment
+// strategy.
+type BlueGreenDeploymentStrategyParams struct {
+	// ServiceName is the name of the Service whose selector is switched over to
+	// the new deployment once it is running at full size.
+	ServiceName string `json:"serviceName"`
+	// RouteName is the name of a Route which is pointed at a Service of the new
+	// deployment when the switch-over happens. That Service is named after the
+	// deployment and has the ports of the Service named by ServiceName. Optional.
+	RouteName string `json:"routeName,omitempty"`
+	// TimeoutSeconds is the time to wait for updates before giving up. If the
+	// value is nil, a default will be used.
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+	// KeepPreviousSeconds is the time the deployment config controller keeps
+	// the previous deployment at its size after the switch-over, during which a
+	// rollback only has to switch the Service back. Defaults to 600 seconds.
+	KeepPreviousSeconds *int64 `json:"keepPreviousSeconds,omitempty"`
+	// Pre is a lifecycle hook which is executed before the deployment process
+	// begins. All LifecycleHookFailurePolicy values are supported.
+	Pre *LifecycleHook `json:"pre,omitempty"`
+	// Mid is a lifecycle hook which is executed once the new deployment is
+	// running at full size, before the Service is switched over to it. All
+	// LifecycleHookFailurePolicy values are supported.
+	Mid *LifecycleHook `json:"mid,omitempty"`
+	// Post is a lifecycle hook which is executed after the strategy has
+	// finished all deployment logic. All LifecycleHookFailurePolicy values are supported.
+	Post *LifecycleHook `json:"post,omitempty"`
+}
+
+// OrderedDeploymentStrategyParams are the input to the Ordered deployment
+// strategy. The pods of the previous deployment are removed one at a time in
+// the order of their names, each followed by a new pod.
+type OrderedDeploymentStrategyParams struct {
+	// TimeoutSeconds is the time to wait for each replacement pod to become
+	// ready before giving up. If the value is nil, a default will be used.
+	TimeoutSeconds *int64 `json:"timeoutSeconds,omitempty"`
+	// ReadinessCheck is a command run in a new pod after each replacement pod
+	// has become ready. The name of the replacement pod is available to the
+	// command in OPENSHIFT_DEPLOYMENT_POD_NAME. The rollout moves on to the next
+	// pod only if the command succeeds, otherwise the rollout is paused: the
+	// deployment fails, but the pods replaced so far are left running until the
+	// deployment is retried, which continues with the next pod, or replaced by a
+	// newer deployment.
+	ReadinessCheck *ExecNewPodHook `json:"readinessCheck,omitempty"`
+	// Pre is a lifecycle hook which is executed before the deployment process
+	// begins. All LifecycleHookFailurePolicy values are supported.
+	Pre *LifecycleHook `json:"pre,omitempty"`
+	// Post is a lifecycle hook which is executed after the strategy has
+	// finished all deployment logic. All LifecycleHookFailurePolicy values are supported.
+	Post *LifecycleHook `json:"post,omitempty"`
+}
+
 // These constants represent keys used for correlating objects related to deployments.
 const (
 	// DeploymentConfigAnnotation is an annotation name used to correlate a deployment with the
@@ -272,6 +412,20 @@ type DeploymentConfigSpec struct {
 	// changes or changes in the template caused by other triggers.
 	Paused bool `json:"paused,omitempty"`
 
+	// AutoRollback indicates that a failed deployment of this config is rolled back to the last
+	// complete deployment automatically.
+	AutoRollback bool `json:"autoRollback,omitempty"`
+
+	// RevisionHistoryLimit is the number of old deployments of this config to keep. Older
+	// deployments which are scaled down are deleted by the deployment config controller. The last
+	// complete deployment is always kept. When unset, no deployments are deleted.
+	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
+
+	// DeploymentWindow restricts when new deployments triggered by image change, resource change
+	// or config change triggers may start. Triggered deployments outside of the window are kept
+	// pending until it opens. When unset, deployments start as soon as they are triggered.
+	DeploymentWindow *DeploymentWindow `json:"deploymentWindow,omitempty"`
+
 	// Selector is a label query over pods that should match the Replicas count.
 	Selector map[string]string `json:"selector,omitempty"`
 
@@ -299,6 +453,63 @@ type DeploymentConfigStatus struct {
 	// Details are the reasons for the update to this deployment config.
 	// This could be based on a change made by the user or caused by an automatic trigger
 	Details *DeploymentDetails `json:"details,omitempty"`
+	// Conditions represent the latest available observations of the current state of the
+	// deployment config.
+	Conditions []DeploymentCondition `json:"conditions,omitempty"`
+	// PendingDeployment describes a triggered deployment which waits for the deployment window
+	// to open.
+	PendingDeployment *PendingDeployment `json:"pendingDeployment,omitempty"`
+}
+
+// DeploymentWindow describes the times at which new deployments of a deployment config may start.
+type DeploymentWindow struct {
+	// Schedule is a cron expression (minute, hour, day of month, month and day of week) matching
+	// the minutes in which new deployments may start, e.g. "* 2-4 * * 6" for Saturdays between
+	// 2:00 and 4:59.
+	Schedule string `json:"schedule"`
+	// TimeZone is the name of the time zone the schedule is evaluated in, e.g. "Europe/Prague".
+	// Defaults to UTC.
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// PendingDeployment describes a triggered deployment which waits for the deployment window to open.
+type PendingDeployment struct {
+	// Causes are the causes of the pending deployment.
+	Causes []DeploymentCause `json:"causes"`
+	// ScheduledTime is the time the deployment window opens next.
+	ScheduledTime unversioned.Time `json:"scheduledTime"`
+}
+
+// DeploymentConditionType describes the state of a deployment config at a certain point.
+type DeploymentConditionType string
+
+// These are valid conditions of a deployment config.
+const (
+	// DeploymentAvailable means the deployment config is available, ie. at least the minimum
+	// available replicas required are up and running.
+	DeploymentAvailable DeploymentConditionType = "Available"
+	// DeploymentProgressing means the deployment config is progressing. Progress for a
+	// deployment config is considered when a new deployment is created and its pods are
+	// rolled out. A complete deployment leaves the condition true, a failed deployment
+	// turns it to false.
+	DeploymentProgressing DeploymentConditionType = "Progressing"
+	// DeploymentReplicaFailure is added to a deployment config when the pods of its latest
+	// deployment fail to be created.
+	DeploymentReplicaFailure DeploymentConditionType = "ReplicaFailure"
+)
+
+// DeploymentCondition describes the state of a deployment config at a certain point.
+type DeploymentCondition struct {
+	// Type of deployment condition.
+	Type DeploymentConditionType `json:"type"`
+	// Status of the condition, one of True, False, Unknown.
+	Status kapi.ConditionStatus `json:"status"`
+	// The last time the condition transitioned from one status to another.
+	LastTransitionTime unversioned.Time `json:"lastTransitionTime,omitempty"`
+	// The reason for the condition's last transition.
+	Reason string `json:"reason,omitempty"`
+	// A human readable message indicating details about the transition.
+	Message string `json:"message,omitempty"`
 }
 
 // DeploymentTriggerPolicy describes a policy for a single trigger that results in a new deployment.
@@ -307,6 +518,8 @@ type DeploymentTriggerPolicy struct {
 	Type DeploymentTriggerType `json:"type,omitempty"`
 	// ImageChangeParams represents the parameters for the ImageChange trigger.
 	ImageChangeParams *DeploymentTriggerImageChangeParams `json:"imageChangeParams,omitempty"`
+	// ResourceChangeParams represents the parameters for the ResourceChange trigger.
+	ResourceChangeParams *DeploymentTriggerResourceChangeParams `json:"resourceChangeParams,omitempty"`
 }
 
 // DeploymentTriggerType refers to a specific DeploymentTriggerPolicy implementation.
@@ -319,6 +532,12 @@ const (
 	// DeploymentTriggerOnConfigChange will create new deployments in response to changes to
 	// the ControllerTemplate of a DeploymentConfig.
 	DeploymentTriggerOnConfigChange DeploymentTriggerType = "ConfigChange"
+	// DeploymentTriggerOnResourceChange will create new deployments in response to changes to
+	// a Secret or a ConfigMap in the namespace of a DeploymentConfig.
+	DeploymentTriggerOnResourceChange DeploymentTriggerType = "ResourceChange"
+	// DeploymentTriggerOnAutoRollback is only used as the cause of a deployment which rolls back a
+	// failed deployment of a config with AutoRollback set; it can't be used as a trigger.
+	DeploymentTriggerOnAutoRollback DeploymentTriggerType = "AutoRollback"
 )
 
 // DeploymentTriggerImageChangeParams represents the parameters to the ImageChange trigger.
@@ -338,6 +557,16 @@ type DeploymentTriggerImageChangeParams struct {
 	LastTriggeredImage string `json:"lastTriggeredImage,omitempty"`
 }
 
+// DeploymentTriggerResourceChangeParams represents the parameters to the ResourceChange trigger.
+type DeploymentTriggerResourceChangeParams struct {
+	// From is a reference to the Secret or ConfigMap to watch for changes. From.Kind and From.Name
+	// are required and the object must be in the namespace of the deployment config.
+	From kapi.ObjectReference `json:"from"`
+	// LastTriggeredResourceVersion is the resource version of the object which was last observed
+	// by the trigger.
+	LastTriggeredResourceVersion string `json:"lastTriggeredResourceVersion,omitempty"`
+}
+
 // DeploymentDetails captures information about the causes of a deployment.
 type DeploymentDetails struct {
 	// Message is the user specified change message, if this deployment was triggered manually by the user
@@ -352,6 +581,12 @@ type DeploymentCause struct {
 	Type DeploymentTriggerType `json:"type"`
 	// ImageTrigger contains the image trigger details, if this trigger was fired based on an image change
 	ImageTrigger *DeploymentCauseImageTrigger `json:"imageTrigger,omitempty"`
+	// AutoRollback contains the details of an automatic rollback, if this deployment rolled back a
+	// failed deployment
+	AutoRollback *DeploymentCauseAutoRollback `json:"autoRollback,omitempty"`
+	// ResourceTrigger contains the details of the changed Secret or ConfigMap, if this trigger was
+	// fired based on a resource change
+	ResourceTrigger *DeploymentCauseResourceTrigger `json:"resourceTrigger,omitempty"`
 }
 
 // DeploymentCauseImageTrigger represents details about the cause of a deployment originating
@@ -362,6 +597,22 @@ type DeploymentCauseImageTrigger struct {
 	From kapi.ObjectReference `json:"from"`
 }
 
+// DeploymentCauseAutoRollback represents details about the cause of a deployment which rolled
+// back a failed deployment
+type DeploymentCauseAutoRollback struct {
+	// FailedDeployment is a reference to the failed deployment (a ReplicationController) which
+	// was rolled back.
+	FailedDeployment kapi.ObjectReference `json:"failedDeployment"`
+}
+
+// DeploymentCauseResourceTrigger represents details about the cause of a deployment originating
+// from a resource change trigger
+type DeploymentCauseResourceTrigger struct {
+	// From is a reference to the changed Secret or ConfigMap which triggered a deployment,
+	// including the resource version of the change.
+	From kapi.ObjectReference `json:"from"`
+}
+
 // DeploymentConfigList is a collection of deployment configs.
 type DeploymentConfigList struct {
 	unversioned.TypeMeta `json:",inline"`
@@ -123,6 +123,54 @@ func Convert_api_RollingDeploymentStrategyParams_To_v1beta3_RollingDeploymentStr
 	return nil
 }
 
+func Convert_v1beta3_DeploymentStrategy_To_api_DeploymentStrategy(in *DeploymentStrategy, out *newer.DeploymentStrategy, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_api_DeploymentStrategy_To_v1beta3_DeploymentStrategy(in *newer.DeploymentStrategy, out *DeploymentStrategy, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_v1beta3_DeploymentConfigSpec_To_api_DeploymentConfigSpec(in *DeploymentConfigSpec, out *newer.DeploymentConfigSpec, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_api_DeploymentConfigSpec_To_v1beta3_DeploymentConfigSpec(in *newer.DeploymentConfigSpec, out *DeploymentConfigSpec, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_v1beta3_DeploymentConfigStatus_To_api_DeploymentConfigStatus(in *DeploymentConfigStatus, out *newer.DeploymentConfigStatus, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_api_DeploymentConfigStatus_To_v1beta3_DeploymentConfigStatus(in *newer.DeploymentConfigStatus, out *DeploymentConfigStatus, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_v1beta3_DeploymentCause_To_api_DeploymentCause(in *DeploymentCause, out *newer.DeploymentCause, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_api_DeploymentCause_To_v1beta3_DeploymentCause(in *newer.DeploymentCause, out *DeploymentCause, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_v1beta3_DeploymentTriggerPolicy_To_api_DeploymentTriggerPolicy(in *DeploymentTriggerPolicy, out *newer.DeploymentTriggerPolicy, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_api_DeploymentTriggerPolicy_To_v1beta3_DeploymentTriggerPolicy(in *newer.DeploymentTriggerPolicy, out *DeploymentTriggerPolicy, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_v1beta3_LifecycleHook_To_api_LifecycleHook(in *LifecycleHook, out *newer.LifecycleHook, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
+func Convert_api_LifecycleHook_To_v1beta3_LifecycleHook(in *newer.LifecycleHook, out *LifecycleHook, s conversion.Scope) error {
+	return s.DefaultConvert(in, out, conversion.IgnoreMissingFields)
+}
+
 func addConversionFuncs(scheme *runtime.Scheme) {
 	err := scheme.AddConversionFuncs(
 		Convert_v1beta3_DeploymentTriggerImageChangeParams_To_api_DeploymentTriggerImageChangeParams,
@@ -130,6 +178,24 @@ func addConversionFuncs(scheme *runtime.Scheme) {
 
 		Convert_v1beta3_RollingDeploymentStrategyParams_To_api_RollingDeploymentStrategyParams,
 		Convert_api_RollingDeploymentStrategyParams_To_v1beta3_RollingDeploymentStrategyParams,
+
+		Convert_v1beta3_DeploymentStrategy_To_api_DeploymentStrategy,
+		Convert_api_DeploymentStrategy_To_v1beta3_DeploymentStrategy,
+
+		Convert_v1beta3_DeploymentConfigSpec_To_api_DeploymentConfigSpec,
+		Convert_api_DeploymentConfigSpec_To_v1beta3_DeploymentConfigSpec,
+
+		Convert_v1beta3_DeploymentConfigStatus_To_api_DeploymentConfigStatus,
+		Convert_api_DeploymentConfigStatus_To_v1beta3_DeploymentConfigStatus,
+
+		Convert_v1beta3_DeploymentCause_To_api_DeploymentCause,
+		Convert_api_DeploymentCause_To_v1beta3_DeploymentCause,
+
+		Convert_v1beta3_DeploymentTriggerPolicy_To_api_DeploymentTriggerPolicy,
+		Convert_api_DeploymentTriggerPolicy_To_v1beta3_DeploymentTriggerPolicy,
+
+		Convert_v1beta3_LifecycleHook_To_api_LifecycleHook,
+		Convert_api_LifecycleHook_To_v1beta3_LifecycleHook,
 	)
 	if err != nil {
 		panic(err)
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	unversionedvalidation "k8s.io/kubernetes/pkg/api/unversioned/validation"
@@ -16,6 +18,7 @@ import (
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	imageval "github.com/openshift/origin/pkg/image/api/validation"
+	"github.com/openshift/origin/pkg/util/cron"
 )
 
 func ValidateDeploymentConfig(config *deployapi.DeploymentConfig) field.ErrorList {
@@ -51,9 +54,32 @@ func ValidateDeploymentConfigSpec(spec deployapi.DeploymentConfigSpec) field.Err
 	if len(spec.Selector) == 0 {
 		allErrs = append(allErrs, field.Invalid(specPath.Child("selector"), spec.Selector, "selector cannot be empty"))
 	}
+	if spec.RevisionHistoryLimit != nil && *spec.RevisionHistoryLimit < 0 {
+		allErrs = append(allErrs, field.Invalid(specPath.Child("revisionHistoryLimit"), *spec.RevisionHistoryLimit, "revisionHistoryLimit cannot be negative"))
+	}
+	if spec.DeploymentWindow != nil {
+		allErrs = append(allErrs, validateDeploymentWindow(spec.DeploymentWindow, specPath.Child("deploymentWindow"))...)
+	}
 	return allErrs
 }
 
+func validateDeploymentWindow(window *deployapi.DeploymentWindow, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if len(window.Schedule) == 0 {
+		errs = append(errs, field.Required(fldPath.Child("schedule"), ""))
+	} else if _, err := cron.Parse(window.Schedule); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("schedule"), window.Schedule, err.Error()))
+	}
+	if len(window.TimeZone) > 0 {
+		if _, err := time.LoadLocation(window.TimeZone); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child("timeZone"), window.TimeZone, "unknown time zone"))
+		}
+	}
+
+	return errs
+}
+
 func getContainerImageNames(template *kapi.PodTemplateSpec) []string {
 	originalContainerImageNames := make([]string, len(template.Spec.Containers))
 	for i := range template.Spec.Containers {
@@ -186,6 +212,24 @@ func validateDeploymentStrategy(strategy *deployapi.DeploymentStrategy, pod *kap
 		} else {
 			errs = append(errs, validateRollingParams(strategy.RollingParams, pod, fldPath.Child("rollingParams"))...)
 		}
+	case deployapi.DeploymentStrategyTypeCanary:
+		if strategy.CanaryParams == nil {
+			errs = append(errs, field.Required(fldPath.Child("canaryParams"), ""))
+		} else {
+			errs = append(errs, validateCanaryParams(strategy.CanaryParams, pod, fldPath.Child("canaryParams"))...)
+		}
+	case deployapi.DeploymentStrategyTypeBlueGreen:
+		if strategy.BlueGreenParams == nil {
+			errs = append(errs, field.Required(fldPath.Child("blueGreenParams"), ""))
+		} else {
+			errs = append(errs, validateBlueGreenParams(strategy.BlueGreenParams, pod, fldPath.Child("blueGreenParams"))...)
+		}
+	case deployapi.DeploymentStrategyTypeOrdered:
+		if strategy.OrderedParams == nil {
+			errs = append(errs, field.Required(fldPath.Child("orderedParams"), ""))
+		} else {
+			errs = append(errs, validateOrderedParams(strategy.OrderedParams, pod, fldPath.Child("orderedParams"))...)
+		}
 	case deployapi.DeploymentStrategyTypeCustom:
 		if strategy.CustomParams == nil {
 			errs = append(errs, field.Required(fldPath.Child("customParams"), ""))
@@ -231,9 +275,11 @@ func validateRecreateParams(params *deployapi.RecreateDeploymentStrategyParams,
 
 	if params.Pre != nil {
 		errs = append(errs, validateLifecycleHook(params.Pre, pod, fldPath.Child("pre"))...)
+		errs = append(errs, validateExecInPodTarget(params.Pre, deployapi.ExecInPodTargetNew, fldPath.Child("pre"), "the new deployment has no pods before the pre hook runs")...)
 	}
 	if params.Mid != nil {
 		errs = append(errs, validateLifecycleHook(params.Mid, pod, fldPath.Child("mid"))...)
+		errs = append(errs, validateExecInPodTarget(params.Mid, deployapi.ExecInPodTargetPrevious, fldPath.Child("mid"), "the previous deployment is scaled down before the mid hook runs")...)
 	}
 	if params.Post != nil {
 		errs = append(errs, validateLifecycleHook(params.Post, pod, fldPath.Child("post"))...)
@@ -249,9 +295,22 @@ func validateLifecycleHook(hook *deployapi.LifecycleHook, pod *kapi.PodSpec, fld
 		errs = append(errs, field.Required(fldPath.Child("failurePolicy"), ""))
 	}
 
+	actions := 0
+	if hook.ExecNewPod != nil {
+		actions++
+	}
+	if len(hook.TagImages) > 0 {
+		actions++
+	}
+	if hook.ExecInPod != nil {
+		actions++
+	}
+
 	switch {
-	case hook.ExecNewPod != nil && len(hook.TagImages) > 0:
-		errs = append(errs, field.Invalid(fldPath, "<hook>", "only one of 'execNewPod' of 'tagImages' may be specified"))
+	case actions > 1:
+		errs = append(errs, field.Invalid(fldPath, "<hook>", "only one of 'execNewPod', 'tagImages' or 'execInPod' may be specified"))
+	case hook.ExecInPod != nil:
+		errs = append(errs, validateExecInPod(hook.ExecInPod, pod, fldPath.Child("execInPod"))...)
 	case hook.ExecNewPod != nil:
 		errs = append(errs, validateExecNewPod(hook.ExecNewPod, fldPath.Child("execNewPod"))...)
 	case len(hook.TagImages) > 0:
@@ -271,7 +330,44 @@ func validateLifecycleHook(hook *deployapi.LifecycleHook, pod *kapi.PodSpec, fld
 			}
 		}
 	default:
-		errs = append(errs, field.Invalid(fldPath, "<empty>", "One of execNewPod or tagImages must be specified"))
+		errs = append(errs, field.Invalid(fldPath, "<empty>", "One of execNewPod, tagImages or execInPod must be specified"))
+	}
+
+	return errs
+}
+
+func validateExecInPod(hook *deployapi.ExecInPodHook, pod *kapi.PodSpec, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if len(hook.Command) == 0 {
+		errs = append(errs, field.Required(fldPath.Child("command"), ""))
+	}
+
+	if len(hook.ContainerName) == 0 {
+		errs = append(errs, field.Required(fldPath.Child("containerName"), ""))
+	} else if pod != nil {
+		found := false
+		for _, container := range pod.Containers {
+			if container.Name == hook.ContainerName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			errs = append(errs, field.Invalid(fldPath.Child("containerName"), hook.ContainerName, "must be the name of a container in the pod template"))
+		}
+	}
+
+	switch hook.Target {
+	case deployapi.ExecInPodTargetPrevious, deployapi.ExecInPodTargetNew:
+	case "":
+		errs = append(errs, field.Required(fldPath.Child("target"), ""))
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("target"), hook.Target, []string{string(deployapi.ExecInPodTargetPrevious), string(deployapi.ExecInPodTargetNew)}))
+	}
+
+	if hook.TimeoutSeconds != nil && *hook.TimeoutSeconds < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("timeoutSeconds"), *hook.TimeoutSeconds, "must be at least 1"))
 	}
 
 	return errs
@@ -326,6 +422,15 @@ func validateHookVolumes(volumes []string, fldPath *field.Path) field.ErrorList
 	return errs
 }
 
+// validateExecInPodTarget rejects an ExecInPod hook that runs in the pods of
+// target, for hooks that run when target has no pods.
+func validateExecInPodTarget(hook *deployapi.LifecycleHook, target deployapi.ExecInPodTarget, fldPath *field.Path, msg string) field.ErrorList {
+	if hook.ExecInPod == nil || hook.ExecInPod.Target != target {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(fldPath.Child("execInPod", "target"), target, msg)}
+}
+
 func validateRollingParams(params *deployapi.RollingDeploymentStrategyParams, pod *kapi.PodSpec, fldPath *field.Path) field.ErrorList {
 	errs := field.ErrorList{}
 
@@ -361,6 +466,47 @@ func validateRollingParams(params *deployapi.RollingDeploymentStrategyParams, po
 
 	if params.Pre != nil {
 		errs = append(errs, validateLifecycleHook(params.Pre, pod, fldPath.Child("pre"))...)
+		errs = append(errs, validateExecInPodTarget(params.Pre, deployapi.ExecInPodTargetNew, fldPath.Child("pre"), "the new deployment has no pods before the pre hook runs")...)
+	}
+	if params.Post != nil {
+		errs = append(errs, validateLifecycleHook(params.Post, pod, fldPath.Child("post"))...)
+	}
+
+	return errs
+}
+
+func validateCanaryParams(params *deployapi.CanaryDeploymentStrategyParams, pod *kapi.PodSpec, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if params.Percent != nil && (*params.Percent < 1 || *params.Percent > 100) {
+		errs = append(errs, field.Invalid(fldPath.Child("percent"), *params.Percent, "must be between 1 and 100 (inclusive)"))
+	}
+
+	if params.BakeSeconds != nil && *params.BakeSeconds < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("bakeSeconds"), *params.BakeSeconds, "must be >=0"))
+	}
+
+	if params.TimeoutSeconds != nil && *params.TimeoutSeconds < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("timeoutSeconds"), *params.TimeoutSeconds, "must be >0"))
+	}
+
+	if gate := params.HealthGate; gate != nil {
+		gatePath := fldPath.Child("healthGate")
+		switch {
+		case gate.ExecNewPod != nil && gate.HTTPGet != nil:
+			errs = append(errs, field.Invalid(gatePath, "<gate>", "only one of 'execNewPod' or 'httpGet' may be specified"))
+		case gate.ExecNewPod != nil:
+			errs = append(errs, validateExecNewPod(gate.ExecNewPod, gatePath.Child("execNewPod"))...)
+		case gate.HTTPGet != nil:
+			errs = append(errs, validateHTTPGetAction(gate.HTTPGet, gatePath.Child("httpGet"))...)
+		default:
+			errs = append(errs, field.Invalid(gatePath, "<empty>", "one of execNewPod or httpGet must be specified"))
+		}
+	}
+
+	if params.Pre != nil {
+		errs = append(errs, validateLifecycleHook(params.Pre, pod, fldPath.Child("pre"))...)
+		errs = append(errs, validateExecInPodTarget(params.Pre, deployapi.ExecInPodTargetNew, fldPath.Child("pre"), "the new deployment has no pods before the pre hook runs")...)
 	}
 	if params.Post != nil {
 		errs = append(errs, validateLifecycleHook(params.Post, pod, fldPath.Child("post"))...)
@@ -369,6 +515,91 @@ func validateRollingParams(params *deployapi.RollingDeploymentStrategyParams, po
 	return errs
 }
 
+func validateBlueGreenParams(params *deployapi.BlueGreenDeploymentStrategyParams, pod *kapi.PodSpec, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if len(params.ServiceName) == 0 {
+		errs = append(errs, field.Required(fldPath.Child("serviceName"), ""))
+	} else if ok, msg := validation.ValidateServiceName(params.ServiceName, false); !ok {
+		errs = append(errs, field.Invalid(fldPath.Child("serviceName"), params.ServiceName, msg))
+	}
+
+	if len(params.RouteName) > 0 && !kvalidation.IsDNS1123Subdomain(params.RouteName) {
+		errs = append(errs, field.Invalid(fldPath.Child("routeName"), params.RouteName, "must be a valid route name"))
+	}
+
+	if params.TimeoutSeconds != nil && *params.TimeoutSeconds < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("timeoutSeconds"), *params.TimeoutSeconds, "must be >0"))
+	}
+
+	if params.KeepPreviousSeconds != nil {
+		if keep := *params.KeepPreviousSeconds; keep < 0 || keep >= deployapi.MaxDeploymentDurationSeconds {
+			errs = append(errs, field.Invalid(fldPath.Child("keepPreviousSeconds"), keep, fmt.Sprintf("must be >=0 and <%d", deployapi.MaxDeploymentDurationSeconds)))
+		}
+	}
+
+	if params.Pre != nil {
+		errs = append(errs, validateLifecycleHook(params.Pre, pod, fldPath.Child("pre"))...)
+		errs = append(errs, validateExecInPodTarget(params.Pre, deployapi.ExecInPodTargetNew, fldPath.Child("pre"), "the new deployment has no pods before the pre hook runs")...)
+	}
+	if params.Mid != nil {
+		errs = append(errs, validateLifecycleHook(params.Mid, pod, fldPath.Child("mid"))...)
+	}
+	if params.Post != nil {
+		errs = append(errs, validateLifecycleHook(params.Post, pod, fldPath.Child("post"))...)
+	}
+
+	return errs
+}
+
+func validateOrderedParams(params *deployapi.OrderedDeploymentStrategyParams, pod *kapi.PodSpec, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if params.TimeoutSeconds != nil && *params.TimeoutSeconds < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("timeoutSeconds"), *params.TimeoutSeconds, "must be >0"))
+	}
+
+	if params.ReadinessCheck != nil {
+		errs = append(errs, validateExecNewPod(params.ReadinessCheck, fldPath.Child("readinessCheck"))...)
+	}
+
+	if params.Pre != nil {
+		errs = append(errs, validateLifecycleHook(params.Pre, pod, fldPath.Child("pre"))...)
+		errs = append(errs, validateExecInPodTarget(params.Pre, deployapi.ExecInPodTargetNew, fldPath.Child("pre"), "the new deployment has no pods before the pre hook runs")...)
+	}
+	if params.Post != nil {
+		errs = append(errs, validateLifecycleHook(params.Post, pod, fldPath.Child("post"))...)
+	}
+
+	return errs
+}
+
+func validateHTTPGetAction(action *kapi.HTTPGetAction, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	if len(action.Path) > 0 && !strings.HasPrefix(action.Path, "/") {
+		errs = append(errs, field.Invalid(fldPath.Child("path"), action.Path, "must be an absolute path"))
+	}
+
+	if len(action.Host) > 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("host"), action.Host, "must be empty, the canary pods are probed at their IP"))
+	}
+
+	if action.Port.Type == intstr.Int && !kvalidation.IsValidPortNum(action.Port.IntValue()) {
+		errs = append(errs, field.Invalid(fldPath.Child("port"), action.Port, validation.PortRangeErrorMsg))
+	} else if action.Port.Type == intstr.String && !kvalidation.IsValidPortName(action.Port.StrVal) {
+		errs = append(errs, field.Invalid(fldPath.Child("port"), action.Port.StrVal, validation.PortNameErrorMsg))
+	}
+
+	switch action.Scheme {
+	case "", kapi.URISchemeHTTP, kapi.URISchemeHTTPS:
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("scheme"), action.Scheme, []string{string(kapi.URISchemeHTTP), string(kapi.URISchemeHTTPS)}))
+	}
+
+	return errs
+}
+
 func validateTrigger(trigger *deployapi.DeploymentTriggerPolicy, fldPath *field.Path) field.ErrorList {
 	errs := field.ErrorList{}
 
@@ -384,6 +615,37 @@ func validateTrigger(trigger *deployapi.DeploymentTriggerPolicy, fldPath *field.
 		}
 	}
 
+	if trigger.Type == deployapi.DeploymentTriggerOnResourceChange {
+		if trigger.ResourceChangeParams == nil {
+			errs = append(errs, field.Required(fldPath.Child("resourceChangeParams"), ""))
+		} else {
+			errs = append(errs, validateResourceChangeParams(trigger.ResourceChangeParams, fldPath.Child("resourceChangeParams"))...)
+		}
+	}
+
+	return errs
+}
+
+func validateResourceChangeParams(params *deployapi.DeploymentTriggerResourceChangeParams, fldPath *field.Path) field.ErrorList {
+	errs := field.ErrorList{}
+
+	fromPath := fldPath.Child("from")
+	switch params.From.Kind {
+	case "Secret", "ConfigMap":
+	case "":
+		errs = append(errs, field.Required(fromPath.Child("kind"), ""))
+	default:
+		errs = append(errs, field.NotSupported(fromPath.Child("kind"), params.From.Kind, []string{"Secret", "ConfigMap"}))
+	}
+	if len(params.From.Name) == 0 {
+		errs = append(errs, field.Required(fromPath.Child("name"), ""))
+	} else if !kvalidation.IsDNS1123Subdomain(params.From.Name) {
+		errs = append(errs, field.Invalid(fromPath.Child("name"), params.From.Name, "name must be a valid subdomain"))
+	}
+	if len(params.From.Namespace) != 0 {
+		errs = append(errs, field.Invalid(fromPath.Child("namespace"), params.From.Namespace, "the object must be in the namespace of the deployment config"))
+	}
+
 	return errs
 }
 
@@ -62,6 +62,48 @@ func rollingConfigMax(maxSurge, maxUnavailable intstr.IntOrString) api.Deploymen
 	}
 }
 
+func canaryConfig(modify func(*api.CanaryDeploymentStrategyParams)) api.DeploymentConfig {
+	strategy := test.OkCanaryStrategy()
+	modify(strategy.CanaryParams)
+	return api.DeploymentConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+		Spec: api.DeploymentConfigSpec{
+			Triggers: manualTrigger(),
+			Strategy: strategy,
+			Template: test.OkPodTemplate(),
+			Selector: test.OkSelector(),
+		},
+	}
+}
+
+func blueGreenConfig(modify func(*api.BlueGreenDeploymentStrategyParams)) api.DeploymentConfig {
+	strategy := test.OkBlueGreenStrategy()
+	modify(strategy.BlueGreenParams)
+	return api.DeploymentConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+		Spec: api.DeploymentConfigSpec{
+			Triggers: manualTrigger(),
+			Strategy: strategy,
+			Template: test.OkPodTemplate(),
+			Selector: test.OkSelector(),
+		},
+	}
+}
+
+func orderedConfig(modify func(*api.OrderedDeploymentStrategyParams)) api.DeploymentConfig {
+	strategy := test.OkOrderedStrategy()
+	modify(strategy.OrderedParams)
+	return api.DeploymentConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+		Spec: api.DeploymentConfigSpec{
+			Triggers: manualTrigger(),
+			Strategy: strategy,
+			Template: test.OkPodTemplate(),
+			Selector: test.OkSelector(),
+		},
+	}
+}
+
 func TestValidateDeploymentConfigOK(t *testing.T) {
 	errs := ValidateDeploymentConfig(&api.DeploymentConfig{
 		ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
@@ -246,6 +288,138 @@ func TestValidateDeploymentConfigMissingFields(t *testing.T) {
 			field.ErrorTypeRequired,
 			"spec.triggers[0].imageChangeParams.containerNames",
 		},
+		"missing Trigger resourceChangeParams": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Triggers: []api.DeploymentTriggerPolicy{
+						{
+							Type: api.DeploymentTriggerOnResourceChange,
+						},
+					},
+					Selector: test.OkSelector(),
+					Strategy: test.OkStrategy(),
+					Template: test.OkPodTemplate(),
+				},
+			},
+			field.ErrorTypeRequired,
+			"spec.triggers[0].resourceChangeParams",
+		},
+		"invalid Trigger resourceChangeParams.from.kind": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Triggers: []api.DeploymentTriggerPolicy{
+						{
+							Type: api.DeploymentTriggerOnResourceChange,
+							ResourceChangeParams: &api.DeploymentTriggerResourceChangeParams{
+								From: kapi.ObjectReference{
+									Kind: "Pod",
+									Name: "foo",
+								},
+							},
+						},
+					},
+					Selector: test.OkSelector(),
+					Strategy: test.OkStrategy(),
+					Template: test.OkPodTemplate(),
+				},
+			},
+			field.ErrorTypeNotSupported,
+			"spec.triggers[0].resourceChangeParams.from.kind",
+		},
+		"missing Trigger resourceChangeParams.from.name": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Triggers: []api.DeploymentTriggerPolicy{
+						{
+							Type: api.DeploymentTriggerOnResourceChange,
+							ResourceChangeParams: &api.DeploymentTriggerResourceChangeParams{
+								From: kapi.ObjectReference{
+									Kind: "Secret",
+								},
+							},
+						},
+					},
+					Selector: test.OkSelector(),
+					Strategy: test.OkStrategy(),
+					Template: test.OkPodTemplate(),
+				},
+			},
+			field.ErrorTypeRequired,
+			"spec.triggers[0].resourceChangeParams.from.name",
+		},
+		"invalid Trigger resourceChangeParams.from.namespace": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Triggers: []api.DeploymentTriggerPolicy{
+						{
+							Type: api.DeploymentTriggerOnResourceChange,
+							ResourceChangeParams: &api.DeploymentTriggerResourceChangeParams{
+								From: kapi.ObjectReference{
+									Kind:      "ConfigMap",
+									Name:      "foo",
+									Namespace: "other",
+								},
+							},
+						},
+					},
+					Selector: test.OkSelector(),
+					Strategy: test.OkStrategy(),
+					Template: test.OkPodTemplate(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.triggers[0].resourceChangeParams.from.namespace",
+		},
+		"missing deploymentWindow.schedule": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas:         1,
+					DeploymentWindow: &api.DeploymentWindow{TimeZone: "UTC"},
+					Selector:         test.OkSelector(),
+					Strategy:         test.OkStrategy(),
+					Template:         test.OkPodTemplate(),
+				},
+			},
+			field.ErrorTypeRequired,
+			"spec.deploymentWindow.schedule",
+		},
+		"invalid deploymentWindow.schedule": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas:         1,
+					DeploymentWindow: &api.DeploymentWindow{Schedule: "* 25 * * *"},
+					Selector:         test.OkSelector(),
+					Strategy:         test.OkStrategy(),
+					Template:         test.OkPodTemplate(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.deploymentWindow.schedule",
+		},
+		"invalid deploymentWindow.timeZone": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas:         1,
+					DeploymentWindow: &api.DeploymentWindow{Schedule: "* 2-4 * * 6", TimeZone: "Mars/Olympus_Mons"},
+					Selector:         test.OkSelector(),
+					Strategy:         test.OkStrategy(),
+					Template:         test.OkPodTemplate(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.deploymentWindow.timeZone",
+		},
 		"missing strategy.type": {
 			api.DeploymentConfig{
 				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
@@ -553,6 +727,156 @@ func TestValidateDeploymentConfigMissingFields(t *testing.T) {
 			field.ErrorTypeInvalid,
 			"spec.strategy.recreateParams.post",
 		},
+		"can't have both execInPod and execNewPod": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Post: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyRetry,
+								ExecNewPod:    &api.ExecNewPodHook{},
+								ExecInPod:     &api.ExecInPodHook{},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.strategy.recreateParams.post",
+		},
+		"missing spec.strategy.after.execInPod.command": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Post: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyRetry,
+								ExecInPod: &api.ExecInPodHook{
+									ContainerName: "container1",
+									Target:        api.ExecInPodTargetNew,
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeRequired,
+			"spec.strategy.recreateParams.post.execInPod.command",
+		},
+		"invalid spec.strategy.after.execInPod.containerName": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Post: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyRetry,
+								ExecInPod: &api.ExecInPodHook{
+									Command:       []string{"cmd"},
+									ContainerName: "missing",
+									Target:        api.ExecInPodTargetNew,
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.strategy.recreateParams.post.execInPod.containerName",
+		},
+		"invalid spec.strategy.after.execInPod.target": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Post: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyRetry,
+								ExecInPod: &api.ExecInPodHook{
+									Command:       []string{"cmd"},
+									ContainerName: "container1",
+									Target:        "Other",
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeNotSupported,
+			"spec.strategy.recreateParams.post.execInPod.target",
+		},
+		"new execInPod target in spec.strategy.rollingParams.pre": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRolling,
+						RollingParams: &api.RollingDeploymentStrategyParams{
+							IntervalSeconds:     mkint64p(1),
+							UpdatePeriodSeconds: mkint64p(1),
+							TimeoutSeconds:      mkint64p(20),
+							MaxSurge:            intstr.FromInt(1),
+							Pre: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyRetry,
+								ExecInPod: &api.ExecInPodHook{
+									Command:       []string{"cmd"},
+									ContainerName: "container1",
+									Target:        api.ExecInPodTargetNew,
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.strategy.rollingParams.pre.execInPod.target",
+		},
+		"previous execInPod target in spec.strategy.recreateParams.mid": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Replicas: 1,
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeRecreate,
+						RecreateParams: &api.RecreateDeploymentStrategyParams{
+							Mid: &api.LifecycleHook{
+								FailurePolicy: api.LifecycleHookFailurePolicyRetry,
+								ExecInPod: &api.ExecInPodHook{
+									Command:       []string{"cmd"},
+									ContainerName: "container1",
+									Target:        api.ExecInPodTargetPrevious,
+								},
+							},
+						},
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeInvalid,
+			"spec.strategy.recreateParams.mid.execInPod.target",
+		},
 		"invalid spec.strategy.rollingParams.intervalSeconds": {
 			rollingConfig(-20, 1, 1),
 			field.ErrorTypeInvalid,
@@ -635,6 +959,151 @@ func TestValidateDeploymentConfigMissingFields(t *testing.T) {
 			field.ErrorTypeInvalid,
 			"spec.strategy.rollingParams.maxSurge",
 		},
+		"missing spec.strategy.canaryParams": {
+			api.DeploymentConfig{
+				ObjectMeta: kapi.ObjectMeta{Name: "foo", Namespace: "bar"},
+				Spec: api.DeploymentConfigSpec{
+					Triggers: manualTrigger(),
+					Strategy: api.DeploymentStrategy{
+						Type: api.DeploymentStrategyTypeCanary,
+					},
+					Template: test.OkPodTemplate(),
+					Selector: test.OkSelector(),
+				},
+			},
+			field.ErrorTypeRequired,
+			"spec.strategy.canaryParams",
+		},
+		"invalid spec.strategy.canaryParams.percent": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {
+				percent := int32(101)
+				p.Percent = &percent
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.canaryParams.percent",
+		},
+		"invalid spec.strategy.canaryParams.bakeSeconds": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {
+				p.BakeSeconds = mkint64p(-1)
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.canaryParams.bakeSeconds",
+		},
+		"invalid spec.strategy.canaryParams.timeoutSeconds": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {
+				p.TimeoutSeconds = mkint64p(0)
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.canaryParams.timeoutSeconds",
+		},
+		"empty spec.strategy.canaryParams.healthGate": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {
+				p.HealthGate = &api.CanaryHealthGate{}
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.canaryParams.healthGate",
+		},
+		"both exec and http spec.strategy.canaryParams.healthGate": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {
+				p.HealthGate.ExecNewPod = &api.ExecNewPodHook{Command: []string{"cmd"}, ContainerName: "container"}
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.canaryParams.healthGate",
+		},
+		"missing spec.strategy.canaryParams.healthGate.execNewPod.command": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {
+				p.HealthGate = &api.CanaryHealthGate{ExecNewPod: &api.ExecNewPodHook{ContainerName: "container"}}
+			}),
+			field.ErrorTypeRequired,
+			"spec.strategy.canaryParams.healthGate.execNewPod.command",
+		},
+		"invalid spec.strategy.canaryParams.healthGate.httpGet.port": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {
+				p.HealthGate.HTTPGet.Port = intstr.FromInt(0)
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.canaryParams.healthGate.httpGet.port",
+		},
+		"invalid spec.strategy.canaryParams.healthGate.httpGet.path": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {
+				p.HealthGate.HTTPGet.Path = "healthz"
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.canaryParams.healthGate.httpGet.path",
+		},
+		"invalid spec.strategy.canaryParams.healthGate.httpGet.host": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {
+				p.HealthGate.HTTPGet.Host = "example.com"
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.canaryParams.healthGate.httpGet.host",
+		},
+		"valid spec.strategy.canaryParams": {
+			canaryConfig(func(p *api.CanaryDeploymentStrategyParams) {}),
+			"",
+			"",
+		},
+		"missing spec.strategy.blueGreenParams.serviceName": {
+			blueGreenConfig(func(p *api.BlueGreenDeploymentStrategyParams) {
+				p.ServiceName = ""
+			}),
+			field.ErrorTypeRequired,
+			"spec.strategy.blueGreenParams.serviceName",
+		},
+		"invalid spec.strategy.blueGreenParams.serviceName": {
+			blueGreenConfig(func(p *api.BlueGreenDeploymentStrategyParams) {
+				p.ServiceName = "Front_End"
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.blueGreenParams.serviceName",
+		},
+		"invalid spec.strategy.blueGreenParams.routeName": {
+			blueGreenConfig(func(p *api.BlueGreenDeploymentStrategyParams) {
+				p.RouteName = "Front_End"
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.blueGreenParams.routeName",
+		},
+		"invalid spec.strategy.blueGreenParams.keepPreviousSeconds": {
+			blueGreenConfig(func(p *api.BlueGreenDeploymentStrategyParams) {
+				p.KeepPreviousSeconds = mkint64p(-1)
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.blueGreenParams.keepPreviousSeconds",
+		},
+		"valid spec.strategy.blueGreenParams": {
+			blueGreenConfig(func(p *api.BlueGreenDeploymentStrategyParams) {
+				p.RouteName = "frontend"
+			}),
+			"",
+			"",
+		},
+		"valid spec.strategy.orderedParams": {
+			orderedConfig(func(p *api.OrderedDeploymentStrategyParams) {}),
+			"",
+			"",
+		},
+		"invalid spec.strategy.orderedParams.timeoutSeconds": {
+			orderedConfig(func(p *api.OrderedDeploymentStrategyParams) {
+				p.TimeoutSeconds = mkint64p(0)
+			}),
+			field.ErrorTypeInvalid,
+			"spec.strategy.orderedParams.timeoutSeconds",
+		},
+		"missing spec.strategy.orderedParams.readinessCheck.command": {
+			orderedConfig(func(p *api.OrderedDeploymentStrategyParams) {
+				p.ReadinessCheck.Command = nil
+			}),
+			field.ErrorTypeRequired,
+			"spec.strategy.orderedParams.readinessCheck.command",
+		},
+		"valid spec.strategy.orderedParams without a readiness check": {
+			orderedConfig(func(p *api.OrderedDeploymentStrategyParams) {
+				p.ReadinessCheck = nil
+			}),
+			"",
+			"",
+		},
 	}
 
 	for testName, v := range errorCases {
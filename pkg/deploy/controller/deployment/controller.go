@@ -120,8 +120,17 @@ func (c *DeploymentController) Handle(deployment *kapi.ReplicationController) er
 		deploymentPod, err := c.podClient.createPod(deployment.Namespace, podTemplate)
 		// Retry on error.
 		if err != nil {
+			// Record the failure on the deployment so that the deployment config
+			// controller can report it as a ReplicaFailure condition.
+			if deployutil.DeploymentReplicaFailureFor(deployment) != err.Error() {
+				deployment.Annotations[deployapi.DeploymentReplicaFailureAnnotation] = err.Error()
+				if _, updateErr := c.deploymentClient.updateDeployment(deployment.Namespace, deployment); updateErr != nil {
+					glog.V(4).Infof("Couldn't record the deployer pod failure for %s: %v", deployutil.LabelForDeployment(deployment), updateErr)
+				}
+			}
 			return actionableError(fmt.Sprintf("couldn't create deployer pod for %s: %v", deployutil.LabelForDeployment(deployment), err))
 		}
+		delete(deployment.Annotations, deployapi.DeploymentReplicaFailureAnnotation)
 		deployment.Annotations[deployapi.DeploymentPodAnnotation] = deploymentPod.Name
 		nextStatus = deployapi.DeploymentStatusPending
 		glog.V(4).Infof("Created deployer pod %s for deployment %s", deploymentPod.Name, deployutil.LabelForDeployment(deployment))
@@ -210,6 +210,13 @@ func TestHandle_createPodFail(t *testing.T) {
 	if _, isFatal := err.(fatalError); isFatal {
 		t.Fatalf("expected a nonfatal error, got a %#v", err)
 	}
+
+	if updatedDeployment == nil {
+		t.Fatalf("expected the deployment to be updated with the replica failure")
+	}
+	if e, a := "Failed to create pod "+deployutil.DeployerPodNameForDeployment(deployment.Name), deployutil.DeploymentReplicaFailureFor(updatedDeployment); e != a {
+		t.Fatalf("expected replica failure %q, got %q", e, a)
+	}
 }
 
 // TestHandle_deployerPodAlreadyExists ensures that attempts to create a
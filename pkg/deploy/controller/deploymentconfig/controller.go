@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"reflect"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/golang/glog"
 
@@ -12,14 +14,19 @@ import (
 	"k8s.io/kubernetes/pkg/client/cache"
 	"k8s.io/kubernetes/pkg/client/record"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	kcontroller "k8s.io/kubernetes/pkg/controller"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/intstr"
 	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/util/workqueue"
 
 	osclient "github.com/openshift/origin/pkg/client"
 	oscache "github.com/openshift/origin/pkg/client/cache"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/deploy/prune"
+	"github.com/openshift/origin/pkg/deploy/registry/rollback"
 	deployutil "github.com/openshift/origin/pkg/deploy/util"
 )
 
@@ -142,6 +149,19 @@ func (c *DeploymentConfigController) Handle(config *deployapi.DeploymentConfig)
 		if !deployutil.IsTerminatedDeployment(latestDeployment) {
 			return c.updateStatus(config, existingDeployments)
 		}
+		// If the rollout of the latest deployment is paused, leave the pods
+		// replaced so far running until it is retried.
+		if deployutil.IsRolloutPaused(latestDeployment) {
+			return c.updateStatus(config, existingDeployments)
+		}
+		// If the latest deployment failed and the config asks for it, roll
+		// back to the last complete deployment.
+		if shouldAutoRollback(config, latestDeployment) {
+			if target := lastCompleteDeployment(config, existingDeployments); target != nil {
+				return c.autoRollback(config, latestDeployment, target)
+			}
+			glog.V(4).Infof("No complete deployment to roll back failed deployment %q to", deployutil.LabelForDeployment(latestDeployment))
+		}
 		return c.reconcileDeployments(existingDeployments, config)
 	}
 	// If the config is paused we shouldn't create new deployments for it.
@@ -163,11 +183,18 @@ func (c *DeploymentConfigController) Handle(config *deployapi.DeploymentConfig)
 			return c.updateStatus(config, existingDeployments)
 		}
 		c.recorder.Eventf(config, kapi.EventTypeWarning, "DeploymentCreationFailed", "Couldn't deploy version %d: %s", config.Status.LatestVersion, err)
+		// The creation failure is reported in the Progressing condition, the
+		// error returned below causes a retry.
+		condition := deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionFalse, deployapi.FailedReplicationControllerCreateReason, err.Error())
+		if statusErr := c.updateStatus(config, existingDeployments, *condition); statusErr != nil {
+			glog.V(2).Infof("Couldn't report the failed deployment for %q: %v", deployutil.LabelForDeploymentConfig(config), statusErr)
+		}
 		return fmt.Errorf("couldn't create deployment for deployment config %s: %v", deployutil.LabelForDeploymentConfig(config), err)
 	}
 	c.recorder.Eventf(config, kapi.EventTypeNormal, "DeploymentCreated", "Created new deployment %q for version %d", created.Name, config.Status.LatestVersion)
 
-	return c.updateStatus(config, existingDeployments)
+	condition := deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionTrue, deployapi.NewReplicationControllerReason, fmt.Sprintf("Created new replication controller %q for version %d", created.Name, config.Status.LatestVersion))
+	return c.updateStatus(config, existingDeployments, *condition)
 }
 
 // reconcileDeployments reconciles existing deployment replica counts which
@@ -258,6 +285,17 @@ func (c *DeploymentConfigController) reconcileDeployments(existingDeployments []
 		glog.V(4).Infof("Synced deploymentConfig %q replicas from %d to %d based on %s", deployutil.LabelForDeploymentConfig(config), oldReplicas, activeReplicas, source)
 	}
 
+	// A BlueGreen config keeps its previous deployment at its size for a while
+	// after switching over to the latest deployment.
+	var keptDeployment *kapi.ReplicationController
+	var keepFor time.Duration
+	if activeDeploymentIsLatest {
+		var err error
+		if keptDeployment, keepFor, err = c.keepPreviousDeployment(config, existingDeployments, activeDeployment); err != nil {
+			return err
+		}
+	}
+
 	// Reconcile deployments. The active deployment follows the config, and all
 	// other deployments should be scaled to zero.
 	var updatedDeployments []kapi.ReplicationController
@@ -269,8 +307,11 @@ func (c *DeploymentConfigController) reconcileDeployments(existingDeployments []
 
 		oldReplicaCount := deployment.Spec.Replicas
 		newReplicaCount := int32(0)
-		if isActiveDeployment {
+		switch {
+		case isActiveDeployment:
 			newReplicaCount = activeReplicas
+		case keptDeployment != nil && deployment.Name == keptDeployment.Name:
+			newReplicaCount = oldReplicaCount
 		}
 		if config.Spec.Test {
 			glog.V(4).Infof("Deployment config %q is test and deployment %q will be scaled down", deployutil.LabelForDeploymentConfig(config), deployutil.LabelForDeployment(&deployment))
@@ -306,11 +347,201 @@ func (c *DeploymentConfigController) reconcileDeployments(existingDeployments []
 		updatedDeployments = append(updatedDeployments, toAppend)
 	}
 
+	if config.Spec.RevisionHistoryLimit != nil {
+		updatedDeployments = c.pruneDeployments(config, updatedDeployments)
+	}
+
+	// Scale the kept deployment down once it is no longer kept.
+	if keptDeployment != nil {
+		if key, err := kcontroller.KeyFunc(config); err == nil {
+			c.queue.AddAfter(key, keepFor)
+		}
+	}
+
 	return c.updateStatus(config, updatedDeployments)
 }
 
-func (c *DeploymentConfigController) updateStatus(config *deployapi.DeploymentConfig, deployments []kapi.ReplicationController) error {
-	newStatus, err := c.calculateStatus(*config, deployments)
+// keepPreviousDeployment returns the previous deployment of a BlueGreen config
+// if it is still kept at its size after the switch-over to active, and how much
+// longer it is kept. The deployment is kept for KeepPreviousSeconds from the
+// first time it is seen here, which is recorded on the deployment.
+func (c *DeploymentConfigController) keepPreviousDeployment(config *deployapi.DeploymentConfig, deployments []kapi.ReplicationController, active *kapi.ReplicationController) (*kapi.ReplicationController, time.Duration, error) {
+	params := config.Spec.Strategy.BlueGreenParams
+	if config.Spec.Strategy.Type != deployapi.DeploymentStrategyTypeBlueGreen || params == nil || params.KeepPreviousSeconds == nil || config.Spec.Test {
+		return nil, 0, nil
+	}
+	activeVersion := deployutil.DeploymentVersionFor(active)
+	var previous *kapi.ReplicationController
+	for i := range deployments {
+		deployment := &deployments[i]
+		version := deployutil.DeploymentVersionFor(deployment)
+		if version >= activeVersion || deployment.Spec.Replicas == 0 || deployutil.DeploymentStatusFor(deployment) != deployapi.DeploymentStatusComplete {
+			continue
+		}
+		if previous == nil || version > deployutil.DeploymentVersionFor(previous) {
+			previous = deployment
+		}
+	}
+	if previous == nil {
+		return nil, 0, nil
+	}
+
+	now := time.Now()
+	keepUntil, err := time.Parse(time.RFC3339, previous.Annotations[deployapi.DeploymentKeepUntilAnnotation])
+	if err != nil {
+		keepUntil = now.Add(time.Duration(*params.KeepPreviousSeconds) * time.Second)
+		copied, err := deploymentCopy(previous)
+		if err != nil {
+			return nil, 0, err
+		}
+		copied.Annotations[deployapi.DeploymentKeepUntilAnnotation] = keepUntil.Format(time.RFC3339)
+		updated, err := c.rn.ReplicationControllers(copied.Namespace).Update(copied)
+		if err != nil {
+			return nil, 0, err
+		}
+		*previous = *updated
+		glog.V(4).Infof("Keeping deployment %q at its size until %s", deployutil.LabelForDeployment(previous), keepUntil.Format(time.RFC3339))
+	}
+	if !now.Before(keepUntil) {
+		return nil, 0, nil
+	}
+	return previous, keepUntil.Sub(now), nil
+}
+
+// pruneDeployments deletes the old deployments of config past its
+// RevisionHistoryLimit and returns the deployments which are left.
+func (c *DeploymentConfigController) pruneDeployments(config *deployapi.DeploymentConfig, deployments []kapi.ReplicationController) []kapi.ReplicationController {
+	candidates := []*kapi.ReplicationController{}
+	for i := range deployments {
+		candidates = append(candidates, &deployments[i])
+	}
+	pruned := sets.NewString()
+	handler := func(deployment *kapi.ReplicationController) error {
+		if err := c.rn.ReplicationControllers(deployment.Namespace).Delete(deployment.Name); err != nil && !errors.IsNotFound(err) {
+			return err
+		}
+		glog.V(4).Infof("Pruned deployment %q past the revision history limit of %q", deployutil.LabelForDeployment(deployment), deployutil.LabelForDeploymentConfig(config))
+		pruned.Insert(deployment.Name)
+		return nil
+	}
+	tasker := prune.NewRevisionHistoryPruneTasker([]*deployapi.DeploymentConfig{config}, candidates, handler)
+	if err := tasker.PruneTask(); err != nil {
+		c.recorder.Eventf(config, kapi.EventTypeWarning, "DeploymentCleanupFailed", "Couldn't clean up old deployments: %v", err)
+	}
+
+	remaining := []kapi.ReplicationController{}
+	for _, deployment := range deployments {
+		if !pruned.Has(deployment.Name) {
+			remaining = append(remaining, deployment)
+		}
+	}
+	return remaining
+}
+
+// autoRollback updates the config to the template of target, which is the last
+// complete deployment, and starts a new deployment of it. The new version is
+// started by a status update, which records its cause pointing at the failed
+// deployment together with the version, so a rollback is never left recorded
+// as a manual deployment. If the status update fails, the latest deployment is
+// still the failed one and the rollback is retried. The automatic image change
+// triggers of the config are disabled so the failed image isn't deployed again
+// right away.
+func (c *DeploymentConfigController) autoRollback(config *deployapi.DeploymentConfig, failed, target *kapi.ReplicationController) error {
+	to, err := deployutil.DecodeDeploymentConfig(target, c.codec)
+	if err != nil {
+		return fatalError(fmt.Sprintf("couldn't decode deployment config from deployment %s: %v", deployutil.LabelForDeployment(target), err))
+	}
+	generator := &rollback.RollbackGenerator{}
+	rolledback, err := generator.GenerateRollback(config, to, &deployapi.DeploymentConfigRollbackSpec{
+		From:            kapi.ObjectReference{Name: target.Name},
+		IncludeTemplate: true,
+	})
+	if err != nil {
+		return fatalError(fmt.Sprintf("couldn't roll back deployment config %s: %v", deployutil.LabelForDeploymentConfig(config), err))
+	}
+
+	// Only the template is rolled back by the update, the version is bumped by
+	// the status update below.
+	rolledback.Status.LatestVersion = config.Status.LatestVersion
+	updated, err := c.dn.DeploymentConfigs(config.Namespace).Update(rolledback)
+	if err != nil {
+		c.recorder.Eventf(config, kapi.EventTypeWarning, "AutoRollbackFailed", "Couldn't roll back failed deployment %q to %q: %v", failed.Name, target.Name, err)
+		return err
+	}
+	updated.Status.LatestVersion++
+	updated.Status.PendingDeployment = nil
+	updated.Status.Details = &deployapi.DeploymentDetails{
+		Message: fmt.Sprintf("rolled back automatically to %s after %s failed", target.Name, failed.Name),
+		Causes: []deployapi.DeploymentCause{{
+			Type: deployapi.DeploymentTriggerOnAutoRollback,
+			AutoRollback: &deployapi.DeploymentCauseAutoRollback{
+				FailedDeployment: kapi.ObjectReference{
+					Kind:      "ReplicationController",
+					Namespace: failed.Namespace,
+					Name:      failed.Name,
+				},
+			},
+		}},
+	}
+	if updated, err = c.dn.DeploymentConfigs(updated.Namespace).UpdateStatus(updated); err != nil {
+		c.recorder.Eventf(config, kapi.EventTypeWarning, "AutoRollbackFailed", "Couldn't record the rollback of failed deployment %q to %q: %v", failed.Name, target.Name, err)
+		return err
+	}
+	c.recorder.Eventf(config, kapi.EventTypeNormal, "AutoRollback", "Rolled back failed deployment %q to %q as version %d", failed.Name, target.Name, updated.Status.LatestVersion)
+
+	disabled := []string{}
+	for _, trigger := range config.Spec.Triggers {
+		if trigger.Type == deployapi.DeploymentTriggerOnImageChange && trigger.ImageChangeParams.Automatic {
+			disabled = append(disabled, trigger.ImageChangeParams.From.Name)
+		}
+	}
+	if len(disabled) > 0 {
+		c.recorder.Eventf(config, kapi.EventTypeWarning, "ImageTriggersDisabled", "Disabled the image change triggers for %s after rolling back; re-enable them with: oc deploy %s --enable-triggers", strings.Join(disabled, ", "), config.Name)
+	}
+	return nil
+}
+
+// shouldAutoRollback returns true if config asks for failed deployments to be
+// rolled back and latest failed. Cancelled deployments and deployments which
+// were already automatic rollbacks aren't rolled back.
+func shouldAutoRollback(config *deployapi.DeploymentConfig, latest *kapi.ReplicationController) bool {
+	if !config.Spec.AutoRollback || config.Spec.Paused {
+		return false
+	}
+	if deployutil.DeploymentStatusFor(latest) != deployapi.DeploymentStatusFailed || deployutil.IsDeploymentCancelled(latest) {
+		return false
+	}
+	if details := config.Status.Details; details != nil {
+		for _, cause := range details.Causes {
+			if cause.Type == deployapi.DeploymentTriggerOnAutoRollback {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// lastCompleteDeployment returns the most recent complete deployment older
+// than the latest version of config, or nil if there is none.
+func lastCompleteDeployment(config *deployapi.DeploymentConfig, deployments []kapi.ReplicationController) *kapi.ReplicationController {
+	var last *kapi.ReplicationController
+	for i := range deployments {
+		deployment := &deployments[i]
+		version := deployutil.DeploymentVersionFor(deployment)
+		if version >= config.Status.LatestVersion || deployutil.DeploymentStatusFor(deployment) != deployapi.DeploymentStatusComplete {
+			continue
+		}
+		if last == nil || version > deployutil.DeploymentVersionFor(last) {
+			last = deployment
+		}
+	}
+	return last
+}
+
+// updateStatus updates the status of config from its deployments. Any
+// additional conditions replace the conditions calculated from the deployments.
+func (c *DeploymentConfigController) updateStatus(config *deployapi.DeploymentConfig, deployments []kapi.ReplicationController, additional ...deployapi.DeploymentCondition) error {
+	newStatus, err := c.calculateStatus(*config, deployments, additional...)
 	if err != nil {
 		glog.V(2).Infof("Cannot calculate the status for %q: %v", deployutil.LabelForDeploymentConfig(config), err)
 		return err
@@ -331,7 +562,7 @@ func (c *DeploymentConfigController) updateStatus(config *deployapi.DeploymentCo
 	return nil
 }
 
-func (c *DeploymentConfigController) calculateStatus(config deployapi.DeploymentConfig, deployments []kapi.ReplicationController) (deployapi.DeploymentConfigStatus, error) {
+func (c *DeploymentConfigController) calculateStatus(config deployapi.DeploymentConfig, deployments []kapi.ReplicationController, additional ...deployapi.DeploymentCondition) (deployapi.DeploymentConfigStatus, error) {
 	// TODO: Implement MinReadySeconds for deploymentconfigs: https://github.com/openshift/origin/issues/7114
 	minReadSeconds := int32(0)
 	selector := labels.Set(config.Spec.Selector).AsSelector()
@@ -344,9 +575,11 @@ func (c *DeploymentConfigController) calculateStatus(config deployapi.Deployment
 	// UpdatedReplicas represents the replicas that use the deployment config template which means
 	// we should inform about the replicas of the latest deployment and not the active.
 	latestReplicas := int32(0)
-	for _, deployment := range deployments {
-		if deployment.Name == deployutil.LatestDeploymentNameForConfig(&config) {
-			updatedDeployment := []kapi.ReplicationController{deployment}
+	var latest *kapi.ReplicationController
+	for i := range deployments {
+		if deployments[i].Name == deployutil.LatestDeploymentNameForConfig(&config) {
+			latest = &deployments[i]
+			updatedDeployment := []kapi.ReplicationController{deployments[i]}
 			latestReplicas = deployutil.GetStatusReplicaCountForDeployments(updatedDeployment)
 			break
 		}
@@ -354,7 +587,7 @@ func (c *DeploymentConfigController) calculateStatus(config deployapi.Deployment
 
 	total := deployutil.GetReplicaCountForDeployments(deployments)
 
-	return deployapi.DeploymentConfigStatus{
+	status := deployapi.DeploymentConfigStatus{
 		LatestVersion:       config.Status.LatestVersion,
 		Details:             config.Status.Details,
 		ObservedGeneration:  config.Generation,
@@ -362,7 +595,98 @@ func (c *DeploymentConfigController) calculateStatus(config deployapi.Deployment
 		UpdatedReplicas:     latestReplicas,
 		AvailableReplicas:   available,
 		UnavailableReplicas: total - available,
-	}, nil
+		// the pending deployment is cleared when the deployment is started
+		PendingDeployment: config.Status.PendingDeployment,
+	}
+	if len(config.Status.Conditions) > 0 {
+		status.Conditions = make([]deployapi.DeploymentCondition, len(config.Status.Conditions))
+		copy(status.Conditions, config.Status.Conditions)
+	}
+	updateConditions(&config, &status, latest)
+	for _, condition := range additional {
+		deployutil.SetDeploymentCondition(&status, condition)
+	}
+	return status, nil
+}
+
+// updateConditions sets the conditions of status from the available replicas
+// and the phase of the latest deployment, which may not exist yet.
+func updateConditions(config *deployapi.DeploymentConfig, status *deployapi.DeploymentConfigStatus, latest *kapi.ReplicationController) {
+	if status.AvailableReplicas >= minAvailable(config) {
+		condition := deployutil.NewDeploymentCondition(deployapi.DeploymentAvailable, kapi.ConditionTrue, deployapi.MinimumReplicasAvailableReason, "Deployment config has minimum availability.")
+		deployutil.SetDeploymentCondition(status, *condition)
+	} else {
+		condition := deployutil.NewDeploymentCondition(deployapi.DeploymentAvailable, kapi.ConditionFalse, deployapi.MinimumReplicasUnavailableReason, "Deployment config does not have minimum availability.")
+		deployutil.SetDeploymentCondition(status, *condition)
+	}
+
+	if status.LatestVersion == 0 {
+		return
+	}
+	// A Progressing condition left from the previous deployment must not be
+	// taken for the state of the latest one.
+	if latest == nil {
+		progressing := deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionUnknown, deployapi.ReplicationControllerPendingReason, fmt.Sprintf("Waiting for replication controller %q to be created for version %d", deployutil.LatestDeploymentNameForConfig(config), status.LatestVersion))
+		if config.Spec.Paused {
+			progressing = deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionUnknown, deployapi.PausedConfigReason, "Deployment config is paused")
+		}
+		deployutil.SetDeploymentCondition(status, *progressing)
+		deployutil.RemoveDeploymentCondition(status, deployapi.DeploymentReplicaFailure)
+		return
+	}
+
+	var progressing *deployapi.DeploymentCondition
+	switch deployutil.DeploymentStatusFor(latest) {
+	case deployapi.DeploymentStatusNew, deployapi.DeploymentStatusPending:
+		progressing = deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionTrue, deployapi.NewReplicationControllerReason, fmt.Sprintf("Created new replication controller %q for version %d", latest.Name, status.LatestVersion))
+	case deployapi.DeploymentStatusRunning:
+		progressing = deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionTrue, deployapi.ReplicationControllerUpdatedReason, fmt.Sprintf("Replication controller %q is being rolled out", latest.Name))
+	case deployapi.DeploymentStatusComplete:
+		progressing = deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionTrue, deployapi.NewReplicationControllerAvailableReason, fmt.Sprintf("Replication controller %q successfully rolled out", latest.Name))
+	case deployapi.DeploymentStatusFailed:
+		if deployutil.IsDeploymentCancelled(latest) {
+			progressing = deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionFalse, deployapi.RolloutCancelledReason, fmt.Sprintf("Rollout of replication controller %q was cancelled", latest.Name))
+			break
+		}
+		if deployutil.IsRolloutPaused(latest) {
+			progressing = deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionUnknown, deployapi.RolloutPausedReason, fmt.Sprintf("Rollout of replication controller %q is paused until it is retried", latest.Name))
+			break
+		}
+		message := fmt.Sprintf("Replication controller %q has failed progressing", latest.Name)
+		if reason := deployutil.DeploymentStatusReasonFor(latest); len(reason) > 0 {
+			message = fmt.Sprintf("%s: %s", message, reason)
+		}
+		progressing = deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionFalse, deployapi.RolloutFailedReason, message)
+	}
+	if config.Spec.Paused && (progressing == nil || progressing.Status != kapi.ConditionFalse) {
+		progressing = deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionUnknown, deployapi.PausedConfigReason, "Deployment config is paused")
+	}
+	if progressing != nil {
+		deployutil.SetDeploymentCondition(status, *progressing)
+	}
+
+	if failure := deployutil.DeploymentReplicaFailureFor(latest); len(failure) > 0 {
+		condition := deployutil.NewDeploymentCondition(deployapi.DeploymentReplicaFailure, kapi.ConditionTrue, deployapi.FailedCreateReason, failure)
+		deployutil.SetDeploymentCondition(status, *condition)
+	} else {
+		deployutil.RemoveDeploymentCondition(status, deployapi.DeploymentReplicaFailure)
+	}
+}
+
+// minAvailable returns the number of replicas of config which must be
+// available for the deployment config to be available.
+func minAvailable(config *deployapi.DeploymentConfig) int32 {
+	if config.Spec.Test {
+		return 0
+	}
+	maxUnavailable := 0
+	if params := config.Spec.Strategy.RollingParams; params != nil {
+		maxUnavailable, _ = intstr.GetValueFromIntOrPercent(&params.MaxUnavailable, int(config.Spec.Replicas), false)
+	}
+	if min := config.Spec.Replicas - int32(maxUnavailable); min > 0 {
+		return min
+	}
+	return 0
 }
 
 func (c *DeploymentConfigController) handleErr(err error, key interface{}) {
@@ -1,17 +1,24 @@
 package deploymentconfig
 
 import (
+	"fmt"
+	"reflect"
 	"sort"
 	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/client/record"
 	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
 	"k8s.io/kubernetes/pkg/controller/framework"
 	"k8s.io/kubernetes/pkg/runtime"
 	"k8s.io/kubernetes/pkg/util/diff"
+	"k8s.io/kubernetes/pkg/util/workqueue"
 	"k8s.io/kubernetes/pkg/watch"
 
 	"github.com/openshift/origin/pkg/client/testclient"
@@ -727,3 +734,624 @@ func TestHandleScenarios(t *testing.T) {
 func newInt32(i int32) *int32 {
 	return &i
 }
+
+func TestHandle_autoRollback(t *testing.T) {
+	mkdeployment := func(version int64, image string, status deployapi.DeploymentStatus, cancelled bool) *kapi.ReplicationController {
+		config := deploytest.OkDeploymentConfig(version)
+		config.Spec.Template.Spec.Containers[0].Image = image
+		deployment, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+		deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(status)
+		if cancelled {
+			deployment.Annotations[deployapi.DeploymentCancelledAnnotation] = deployapi.DeploymentCancelledAnnotationValue
+		}
+		return deployment
+	}
+
+	tests := []struct {
+		name         string
+		autoRollback bool
+		// cause is the cause of the latest deployment
+		cause       deployapi.DeploymentTriggerType
+		deployments []*kapi.ReplicationController
+		// expectedImage is the image of the rolled back config, if a rollback
+		// is expected
+		expectedImage string
+	}{
+		{
+			name:         "failed deployment is rolled back",
+			autoRollback: true,
+			deployments: []*kapi.ReplicationController{
+				mkdeployment(1, "first", deployapi.DeploymentStatusComplete, false),
+				mkdeployment(2, "second", deployapi.DeploymentStatusComplete, false),
+				mkdeployment(3, "third", deployapi.DeploymentStatusFailed, false),
+			},
+			expectedImage: "second",
+		},
+		{
+			name:         "failed deployment is rolled back past older failures",
+			autoRollback: true,
+			deployments: []*kapi.ReplicationController{
+				mkdeployment(1, "first", deployapi.DeploymentStatusComplete, false),
+				mkdeployment(2, "second", deployapi.DeploymentStatusFailed, false),
+				mkdeployment(3, "third", deployapi.DeploymentStatusFailed, false),
+			},
+			expectedImage: "first",
+		},
+		{
+			name: "auto rollback is disabled",
+			deployments: []*kapi.ReplicationController{
+				mkdeployment(1, "first", deployapi.DeploymentStatusComplete, false),
+				mkdeployment(2, "second", deployapi.DeploymentStatusFailed, false),
+			},
+		},
+		{
+			name:         "cancelled deployment is not rolled back",
+			autoRollback: true,
+			deployments: []*kapi.ReplicationController{
+				mkdeployment(1, "first", deployapi.DeploymentStatusComplete, false),
+				mkdeployment(2, "second", deployapi.DeploymentStatusFailed, true),
+			},
+		},
+		{
+			name:         "failed rollback is not rolled back again",
+			autoRollback: true,
+			cause:        deployapi.DeploymentTriggerOnAutoRollback,
+			deployments: []*kapi.ReplicationController{
+				mkdeployment(1, "first", deployapi.DeploymentStatusComplete, false),
+				mkdeployment(2, "second", deployapi.DeploymentStatusFailed, false),
+				mkdeployment(3, "first", deployapi.DeploymentStatusFailed, false),
+			},
+		},
+		{
+			name:         "no complete deployment to roll back to",
+			autoRollback: true,
+			deployments: []*kapi.ReplicationController{
+				mkdeployment(1, "first", deployapi.DeploymentStatusFailed, false),
+			},
+		},
+	}
+
+	for _, test := range tests {
+		var updated, statusUpdated *deployapi.DeploymentConfig
+		oc := &testclient.Fake{}
+		oc.AddReactor("update", "deploymentconfigs", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			config := action.(ktestclient.UpdateAction).GetObject().(*deployapi.DeploymentConfig)
+			if action.GetSubresource() == "" {
+				updated = config
+			} else {
+				statusUpdated = config
+			}
+			return true, config, nil
+		})
+		kc := &ktestclient.Fake{}
+		kc.AddReactor("update", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			return true, action.(ktestclient.UpdateAction).GetObject(), nil
+		})
+
+		recorder := &record.FakeRecorder{Events: make(chan string, 10)}
+		c := &DeploymentConfigController{
+			dn:       oc,
+			rn:       kc,
+			codec:    kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion),
+			recorder: recorder,
+		}
+		c.rcStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		c.podStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		for _, deployment := range test.deployments {
+			c.rcStore.Add(deployment)
+		}
+
+		latestVersion := int64(len(test.deployments))
+		config := deploytest.OkDeploymentConfig(latestVersion)
+		config.Spec.AutoRollback = test.autoRollback
+		config.Spec.Template.Spec.Containers[0].Image = "latest"
+		if len(test.cause) > 0 {
+			config.Status.Details = &deployapi.DeploymentDetails{Causes: []deployapi.DeploymentCause{{Type: test.cause}}}
+		}
+
+		if err := c.Handle(config); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if len(test.expectedImage) == 0 {
+			if statusUpdated != nil && statusUpdated.Status.LatestVersion != latestVersion {
+				t.Errorf("%s: unexpected rollback to version %d", test.name, statusUpdated.Status.LatestVersion)
+			}
+			continue
+		}
+		if updated == nil || statusUpdated == nil {
+			t.Errorf("%s: expected the config to be rolled back", test.name)
+			continue
+		}
+		if e, a := test.expectedImage, updated.Spec.Template.Spec.Containers[0].Image; e != a {
+			t.Errorf("%s: expected the template of image %s, got %s", test.name, e, a)
+		}
+		// the version is bumped by the status update along with the cause,
+		// updates of the config can't set the cause
+		if e, a := latestVersion, updated.Status.LatestVersion; e != a {
+			t.Errorf("%s: expected the update to keep version %d, got %d", test.name, e, a)
+		}
+		if e, a := latestVersion+1, statusUpdated.Status.LatestVersion; e != a {
+			t.Errorf("%s: expected version %d, got %d", test.name, e, a)
+		}
+		details := statusUpdated.Status.Details
+		if details == nil || len(details.Causes) != 1 || details.Causes[0].AutoRollback == nil {
+			t.Errorf("%s: expected an automatic rollback cause, got %#v", test.name, details)
+			continue
+		}
+		if e, a := deployutil.LatestDeploymentNameForConfig(config), details.Causes[0].AutoRollback.FailedDeployment.Name; e != a {
+			t.Errorf("%s: expected the cause to point at %s, got %s", test.name, e, a)
+		}
+		if actions := oc.Actions(); len(actions) != 2 {
+			t.Errorf("%s: expected an update of the config and of its status, got %#v", test.name, actions)
+		}
+		disabledEvent := false
+		for len(recorder.Events) > 0 {
+			if strings.Contains(<-recorder.Events, "ImageTriggersDisabled") {
+				disabledEvent = true
+			}
+		}
+		if !disabledEvent {
+			t.Errorf("%s: expected an event about the disabled image change triggers", test.name)
+		}
+	}
+}
+
+// TestHandle_autoRollbackStatusUpdateFailure ensures that a rollback whose
+// status update failed is retried, rather than left recorded as a manual
+// deployment.
+func TestHandle_autoRollbackStatusUpdateFailure(t *testing.T) {
+	mkdeployment := func(version int64, image string, status deployapi.DeploymentStatus) *kapi.ReplicationController {
+		config := deploytest.OkDeploymentConfig(version)
+		config.Spec.Template.Spec.Containers[0].Image = image
+		deployment, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+		deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(status)
+		return deployment
+	}
+
+	var updated, statusUpdated *deployapi.DeploymentConfig
+	failStatusUpdate := true
+	oc := &testclient.Fake{}
+	oc.AddReactor("update", "deploymentconfigs", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		config := action.(ktestclient.UpdateAction).GetObject().(*deployapi.DeploymentConfig)
+		if action.GetSubresource() == "" {
+			updated = config
+			return true, config, nil
+		}
+		if failStatusUpdate {
+			return true, nil, kerrors.NewConflict(deployapi.Resource("deploymentconfigs"), config.Name, fmt.Errorf("conflict"))
+		}
+		statusUpdated = config
+		return true, config, nil
+	})
+
+	c := &DeploymentConfigController{
+		dn:       oc,
+		rn:       &ktestclient.Fake{},
+		codec:    kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion),
+		recorder: &record.FakeRecorder{Events: make(chan string, 10)},
+	}
+	c.rcStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c.podStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c.rcStore.Add(mkdeployment(1, "first", deployapi.DeploymentStatusComplete))
+	c.rcStore.Add(mkdeployment(2, "second", deployapi.DeploymentStatusFailed))
+
+	config := deploytest.OkDeploymentConfig(2)
+	config.Spec.AutoRollback = true
+	config.Spec.Template.Spec.Containers[0].Image = "second"
+
+	if err := c.Handle(config); err == nil {
+		t.Fatalf("expected the failed status update to be returned")
+	}
+	if updated == nil || updated.Status.LatestVersion != 2 {
+		t.Fatalf("expected the template to be rolled back without a new version, got %#v", updated)
+	}
+
+	// the config is left at the failed version with the rolled back template,
+	// so the rollback is retried
+	failStatusUpdate = false
+	if err := c.Handle(updated); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if statusUpdated == nil || statusUpdated.Status.LatestVersion != 3 {
+		t.Fatalf("expected the rollback to be retried, got %#v", statusUpdated)
+	}
+	if details := statusUpdated.Status.Details; details == nil || len(details.Causes) != 1 || details.Causes[0].Type != deployapi.DeploymentTriggerOnAutoRollback {
+		t.Errorf("expected an automatic rollback cause, got %#v", details)
+	}
+	if e, a := "first", statusUpdated.Spec.Template.Spec.Containers[0].Image; e != a {
+		t.Errorf("expected the template of image %s, got %s", e, a)
+	}
+}
+
+// TestHandle_pausedRollout ensures that the deployments of a config whose
+// latest deployment failed with its rollout paused are neither scaled nor
+// rolled back.
+func TestHandle_pausedRollout(t *testing.T) {
+	mkdeployment := func(version int64, replicas int32, status deployapi.DeploymentStatus) *kapi.ReplicationController {
+		deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(version), kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+		deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(status)
+		deployment.Spec.Replicas = replicas
+		return deployment
+	}
+	previous := mkdeployment(1, 2, deployapi.DeploymentStatusComplete)
+	latest := mkdeployment(2, 1, deployapi.DeploymentStatusFailed)
+	latest.Annotations[deployapi.DeploymentStatusReasonAnnotation] = deployapi.DeploymentFailedRolloutPaused
+
+	var updatedConfig *deployapi.DeploymentConfig
+	oc := &testclient.Fake{}
+	oc.AddReactor("update", "deploymentconfigs", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		config := action.(ktestclient.UpdateAction).GetObject().(*deployapi.DeploymentConfig)
+		if action.GetSubresource() == "" {
+			updatedConfig = config
+		}
+		return true, config, nil
+	})
+	kc := &ktestclient.Fake{}
+	kc.AddReactor("update", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		t.Errorf("unexpected update of deployment %s", action.(ktestclient.UpdateAction).GetObject().(*kapi.ReplicationController).Name)
+		return true, action.(ktestclient.UpdateAction).GetObject(), nil
+	})
+
+	c := &DeploymentConfigController{
+		dn:       oc,
+		rn:       kc,
+		codec:    kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion),
+		recorder: &record.FakeRecorder{},
+	}
+	c.rcStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c.podStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c.rcStore.Add(previous)
+	c.rcStore.Add(latest)
+
+	config := deploytest.OkDeploymentConfig(2)
+	config.Spec.AutoRollback = true
+	config.Spec.Replicas = 3
+
+	if err := c.Handle(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updatedConfig != nil {
+		t.Errorf("unexpected update of the config: %#v", updatedConfig)
+	}
+}
+
+func TestHandle_pendingDeploymentPreserved(t *testing.T) {
+	deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(deployapi.DeploymentStatusComplete)
+
+	var updatedStatus *deployapi.DeploymentConfig
+	oc := &testclient.Fake{}
+	oc.AddReactor("update", "deploymentconfigs", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		config := action.(ktestclient.UpdateAction).GetObject().(*deployapi.DeploymentConfig)
+		if action.GetSubresource() == "status" {
+			updatedStatus = config
+		}
+		return true, config, nil
+	})
+
+	c := &DeploymentConfigController{
+		dn:       oc,
+		rn:       &ktestclient.Fake{},
+		codec:    kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion),
+		recorder: &record.FakeRecorder{},
+	}
+	c.rcStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c.podStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+	c.rcStore.Add(deployment)
+
+	pending := &deployapi.PendingDeployment{
+		Causes:        []deployapi.DeploymentCause{{Type: deployapi.DeploymentTriggerOnConfigChange}},
+		ScheduledTime: unversioned.NewTime(time.Now().Add(time.Hour)),
+	}
+	config := deploytest.OkDeploymentConfig(1)
+	config.Status.PendingDeployment = pending
+
+	if err := c.Handle(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updatedStatus == nil {
+		t.Fatalf("expected the status of the config to be updated")
+	}
+	if !kapi.Semantic.DeepEqual(updatedStatus.Status.PendingDeployment, pending) {
+		t.Errorf("expected the pending deployment to be kept, got %#v", updatedStatus.Status.PendingDeployment)
+	}
+}
+
+func TestHandle_revisionHistoryLimit(t *testing.T) {
+	now := time.Now()
+	deployments := []*kapi.ReplicationController{}
+	for version := int64(1); version <= 4; version++ {
+		deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(version), kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+		deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(deployapi.DeploymentStatusComplete)
+		deployment.CreationTimestamp = unversioned.NewTime(now.Add(time.Duration(version) * time.Minute))
+		deployments = append(deployments, deployment)
+	}
+
+	tests := []struct {
+		name     string
+		limit    *int32
+		expected []string
+	}{
+		{
+			name:     "no limit",
+			expected: []string{},
+		},
+		{
+			name:     "old deployments past the limit are deleted",
+			limit:    newInt32(1),
+			expected: []string{"config-1", "config-2"},
+		},
+	}
+
+	for _, test := range tests {
+		deleted := []string{}
+		kc := &ktestclient.Fake{}
+		kc.AddReactor("update", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			return true, action.(ktestclient.UpdateAction).GetObject(), nil
+		})
+		kc.AddReactor("delete", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			deleted = append(deleted, action.(ktestclient.DeleteAction).GetName())
+			return true, nil, nil
+		})
+		oc := &testclient.Fake{}
+		oc.AddReactor("update", "deploymentconfigs", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			return true, action.(ktestclient.UpdateAction).GetObject(), nil
+		})
+
+		c := &DeploymentConfigController{
+			dn:       oc,
+			rn:       kc,
+			codec:    kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion),
+			recorder: &record.FakeRecorder{},
+		}
+		c.rcStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		c.podStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		for _, deployment := range deployments {
+			copied, _ := deploymentCopy(deployment)
+			c.rcStore.Add(copied)
+		}
+
+		config := deploytest.OkDeploymentConfig(4)
+		config.Spec.RevisionHistoryLimit = test.limit
+		if err := c.Handle(config); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		sort.Strings(deleted)
+		if !reflect.DeepEqual(deleted, test.expected) {
+			t.Errorf("%s: expected %v to be deleted, got %v", test.name, test.expected, deleted)
+		}
+	}
+}
+
+func TestHandle_blueGreenKeepsPreviousDeployment(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name      string
+		keepUntil string
+
+		expectedReplicas int32
+		expectedRequeue  bool
+	}{
+		{
+			name:             "previous deployment kept after the switch-over",
+			expectedReplicas: 1,
+			expectedRequeue:  true,
+		},
+		{
+			name:             "previous deployment still kept",
+			keepUntil:        now.Add(time.Minute).Format(time.RFC3339),
+			expectedReplicas: 1,
+			expectedRequeue:  true,
+		},
+		{
+			name:             "previous deployment no longer kept",
+			keepUntil:        now.Add(-time.Minute).Format(time.RFC3339),
+			expectedReplicas: 0,
+		},
+	}
+
+	for _, test := range tests {
+		updated := map[string]*kapi.ReplicationController{}
+		kc := &ktestclient.Fake{}
+		kc.AddReactor("update", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			deployment := action.(ktestclient.UpdateAction).GetObject().(*kapi.ReplicationController)
+			updated[deployment.Name] = deployment
+			return true, deployment, nil
+		})
+		oc := &testclient.Fake{}
+		oc.AddReactor("update", "deploymentconfigs", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			return true, action.(ktestclient.UpdateAction).GetObject(), nil
+		})
+
+		queue := &fakeDelayingQueue{RateLimitingInterface: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+		c := &DeploymentConfigController{
+			dn:       oc,
+			rn:       kc,
+			queue:    queue,
+			codec:    kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion),
+			recorder: &record.FakeRecorder{},
+		}
+		c.rcStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+		c.podStore.Indexer = cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc})
+
+		config := deploytest.OkDeploymentConfig(2)
+		config.Spec.Strategy = deploytest.OkBlueGreenStrategy()
+		for version := int64(1); version <= 2; version++ {
+			versioned := deploytest.OkDeploymentConfig(version)
+			versioned.Spec.Strategy = config.Spec.Strategy
+			deployment, _ := deployutil.MakeDeployment(versioned, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+			deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(deployapi.DeploymentStatusComplete)
+			deployment.Annotations[deployapi.DeploymentReplicasAnnotation] = "1"
+			deployment.Spec.Replicas = 1
+			if version == 1 && len(test.keepUntil) > 0 {
+				deployment.Annotations[deployapi.DeploymentKeepUntilAnnotation] = test.keepUntil
+			}
+			c.rcStore.Add(deployment)
+		}
+
+		if err := c.Handle(config); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if _, scaled := updated["config-2"]; scaled {
+			t.Errorf("%s: unexpected update of the latest deployment", test.name)
+		}
+		previous, ok := updated["config-1"]
+		switch {
+		case test.expectedReplicas == 0 && (!ok || previous.Spec.Replicas != 0):
+			t.Errorf("%s: expected the previous deployment to be scaled down", test.name)
+		case test.expectedReplicas > 0 && ok && previous.Spec.Replicas != test.expectedReplicas:
+			t.Errorf("%s: expected the previous deployment to be kept at %d, got %d", test.name, test.expectedReplicas, previous.Spec.Replicas)
+		case len(test.keepUntil) == 0 && (!ok || len(previous.Annotations[deployapi.DeploymentKeepUntilAnnotation]) == 0):
+			t.Errorf("%s: expected the time the previous deployment is kept to be recorded", test.name)
+		}
+		// the config is requeued for when the previous deployment is no longer kept
+		if requeued := len(queue.delays) > 0; requeued != test.expectedRequeue {
+			t.Errorf("%s: expected requeued to be %t, got %t", test.name, test.expectedRequeue, requeued)
+		}
+		for _, delay := range queue.delays {
+			if delay <= 0 || delay > time.Minute {
+				t.Errorf("%s: unexpected requeue after %s", test.name, delay)
+			}
+		}
+		queue.ShutDown()
+	}
+}
+
+// fakeDelayingQueue records the delays of the items added to it after a delay.
+type fakeDelayingQueue struct {
+	workqueue.RateLimitingInterface
+	delays []time.Duration
+}
+
+func (q *fakeDelayingQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.delays = append(q.delays, duration)
+}
+
+func TestUpdateConditions(t *testing.T) {
+	tests := []struct {
+		name      string
+		paused    bool
+		available int32
+		status    deployapi.DeploymentStatus
+		cancelled bool
+		failure   string
+
+		expectedAvailable   kapi.ConditionStatus
+		expectedProgressing kapi.ConditionStatus
+		expectedReason      string
+		expectedFailure     bool
+	}{
+		{
+			name:                "new deployment",
+			status:              deployapi.DeploymentStatusNew,
+			expectedAvailable:   kapi.ConditionFalse,
+			expectedProgressing: kapi.ConditionTrue,
+			expectedReason:      deployapi.NewReplicationControllerReason,
+		},
+		{
+			name:                "running deployment",
+			available:           1,
+			status:              deployapi.DeploymentStatusRunning,
+			expectedAvailable:   kapi.ConditionFalse,
+			expectedProgressing: kapi.ConditionTrue,
+			expectedReason:      deployapi.ReplicationControllerUpdatedReason,
+		},
+		{
+			name:                "complete deployment",
+			available:           2,
+			status:              deployapi.DeploymentStatusComplete,
+			expectedAvailable:   kapi.ConditionTrue,
+			expectedProgressing: kapi.ConditionTrue,
+			expectedReason:      deployapi.NewReplicationControllerAvailableReason,
+		},
+		{
+			name:                "failed deployment",
+			available:           2,
+			status:              deployapi.DeploymentStatusFailed,
+			expectedAvailable:   kapi.ConditionTrue,
+			expectedProgressing: kapi.ConditionFalse,
+			expectedReason:      deployapi.RolloutFailedReason,
+		},
+		{
+			name:                "cancelled deployment",
+			status:              deployapi.DeploymentStatusFailed,
+			cancelled:           true,
+			expectedAvailable:   kapi.ConditionFalse,
+			expectedProgressing: kapi.ConditionFalse,
+			expectedReason:      deployapi.RolloutCancelledReason,
+		},
+		{
+			name:                "paused config",
+			paused:              true,
+			status:              deployapi.DeploymentStatusRunning,
+			expectedAvailable:   kapi.ConditionFalse,
+			expectedProgressing: kapi.ConditionUnknown,
+			expectedReason:      deployapi.PausedConfigReason,
+		},
+		{
+			name:                "deployer pod cannot be created",
+			status:              deployapi.DeploymentStatusNew,
+			failure:             "pods \"config-1-deploy\" is forbidden: exceeded quota",
+			expectedAvailable:   kapi.ConditionFalse,
+			expectedProgressing: kapi.ConditionTrue,
+			expectedReason:      deployapi.NewReplicationControllerReason,
+			expectedFailure:     true,
+		},
+	}
+
+	for _, test := range tests {
+		config := deploytest.OkDeploymentConfig(1)
+		config.Spec.Replicas = 2
+		config.Spec.Paused = test.paused
+		deployment, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+		deployment.Annotations[deployapi.DeploymentStatusAnnotation] = string(test.status)
+		if test.cancelled {
+			deployment.Annotations[deployapi.DeploymentCancelledAnnotation] = deployapi.DeploymentCancelledAnnotationValue
+		}
+		if len(test.failure) > 0 {
+			deployment.Annotations[deployapi.DeploymentReplicaFailureAnnotation] = test.failure
+		}
+		status := &deployapi.DeploymentConfigStatus{LatestVersion: 1, AvailableReplicas: test.available}
+
+		updateConditions(config, status, deployment)
+
+		if available := deployutil.GetDeploymentCondition(*status, deployapi.DeploymentAvailable); available == nil || available.Status != test.expectedAvailable {
+			t.Errorf("%s: expected Available to be %s, got %#v", test.name, test.expectedAvailable, available)
+		}
+		progressing := deployutil.GetDeploymentCondition(*status, deployapi.DeploymentProgressing)
+		if progressing == nil || progressing.Status != test.expectedProgressing || progressing.Reason != test.expectedReason {
+			t.Errorf("%s: expected Progressing to be %s with reason %s, got %#v", test.name, test.expectedProgressing, test.expectedReason, progressing)
+		}
+		failure := deployutil.GetDeploymentCondition(*status, deployapi.DeploymentReplicaFailure)
+		if test.expectedFailure && (failure == nil || failure.Message != test.failure) {
+			t.Errorf("%s: expected a ReplicaFailure condition with message %q, got %#v", test.name, test.failure, failure)
+		}
+		if !test.expectedFailure && failure != nil {
+			t.Errorf("%s: unexpected ReplicaFailure condition %#v", test.name, failure)
+		}
+	}
+}
+
+func TestUpdateConditionsLatestNotCreated(t *testing.T) {
+	config := deploytest.OkDeploymentConfig(2)
+	status := &deployapi.DeploymentConfigStatus{
+		LatestVersion: 2,
+		Conditions: []deployapi.DeploymentCondition{
+			*deployutil.NewDeploymentCondition(deployapi.DeploymentProgressing, kapi.ConditionTrue, deployapi.NewReplicationControllerAvailableReason, "Replication controller \"config-1\" successfully rolled out"),
+		},
+	}
+
+	updateConditions(config, status, nil)
+
+	progressing := deployutil.GetDeploymentCondition(*status, deployapi.DeploymentProgressing)
+	if progressing == nil || progressing.Status != kapi.ConditionUnknown || progressing.Reason != deployapi.ReplicationControllerPendingReason {
+		t.Fatalf("expected Progressing to be reset while the latest deployment doesn't exist, got %#v", progressing)
+	}
+	if !strings.Contains(progressing.Message, deployutil.LatestDeploymentNameForConfig(config)) {
+		t.Errorf("expected the Progressing condition to refer to the latest deployment, got %q", progressing.Message)
+	}
+}
@@ -2,8 +2,10 @@ package generictrigger
 
 import (
 	"fmt"
+	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
 	"k8s.io/kubernetes/pkg/runtime"
 
@@ -21,6 +23,10 @@ type DeploymentTriggerController struct {
 	rn kclient.ReplicationControllersNamespacer
 	// codec is used for decoding a config out of a deployment.
 	codec runtime.Codec
+	// now returns the current time.
+	now func() time.Time
+	// requeueAt queues the config to be processed again at the given time.
+	requeueAt func(config *deployapi.DeploymentConfig, at time.Time)
 }
 
 // NewDeploymentTriggerController returns a new DeploymentTriggerController.
@@ -29,6 +35,9 @@ func NewDeploymentTriggerController(oc osclient.Interface, kc kclient.Interface,
 		dn:    oc,
 		rn:    kc,
 		codec: codec,
+		now:   time.Now,
+
+		requeueAt: func(*deployapi.DeploymentConfig, time.Time) {},
 	}
 }
 
@@ -54,11 +63,30 @@ func (c *DeploymentTriggerController) Handle(config *deployapi.DeploymentConfig)
 
 	canTrigger, causes := canTrigger(config, decoded)
 
+	// A deployment which was triggered outside of the deployment window is
+	// still due, even if nothing triggers it anymore.
+	if pending := config.Status.PendingDeployment; pending != nil {
+		canTrigger = true
+		causes = deployutil.MergeDeploymentCauses(pending.Causes, causes)
+	}
+
 	// Return if we cannot trigger a new deployment.
 	if !canTrigger {
 		return nil
 	}
 
+	// Deployments triggered outside of the deployment window wait for it to
+	// open. The initial deployment of a config is never held back.
+	if window := config.Spec.DeploymentWindow; window != nil && config.Status.LatestVersion > 0 {
+		open, next, err := deployutil.DeploymentWindowOpen(window, c.now())
+		if err != nil {
+			return fatalError(err.Error())
+		}
+		if !open {
+			return c.queue(config, causes, next)
+		}
+	}
+
 	return c.update(config, causes)
 }
 
@@ -93,6 +121,11 @@ func (c *DeploymentTriggerController) decodeFromLatest(config *deployapi.Deploym
 // that exist. Otherwise, this controller will wait for the images to land and be updated in the
 // triggers that point to them by the image change controller.
 //
+// Resource change triggers are processed next. The resource change controller records the
+// resource versions of the triggered Secrets and ConfigMaps in them, and a version which differs
+// from the one recorded in the latest deployment triggers a new deployment. The initial
+// deployment waits for all of them to record a version, so that later changes can be detected.
+//
 // Config change triggers are processed last. If all images are resolved and an automatic trigger
 // was updated, then it should be possible to trigger a new deployment without a config change
 // trigger. Otherwise, if a config change trigger exists and the config is not deployed yet or it
@@ -152,12 +185,46 @@ func canTrigger(config, decoded *deployapi.DeploymentConfig) (bool, []deployapi.
 		return false, nil
 	}
 
+	// RESOURCE CHANGE TRIGGERS
+	canTriggerByResourceChange := false
+	for _, t := range config.Spec.Triggers {
+		params := t.ResourceChangeParams
+		if t.Type != deployapi.DeploymentTriggerOnResourceChange || params == nil {
+			continue
+		}
+		current := params.LastTriggeredResourceVersion
+		if len(current) == 0 {
+			// We need to wait for the resource change controller to observe the resource
+			// before the initial deployment.
+			if config.Status.LatestVersion == 0 {
+				return false, nil
+			}
+			continue
+		}
+		if config.Status.LatestVersion == 0 || current == lastTriggeredResourceVersion(params.From, *decoded) {
+			continue
+		}
+
+		canTriggerByResourceChange = true
+		causes = append(causes, deployapi.DeploymentCause{
+			Type: deployapi.DeploymentTriggerOnResourceChange,
+			ResourceTrigger: &deployapi.DeploymentCauseResourceTrigger{
+				From: kapi.ObjectReference{
+					Kind:            params.From.Kind,
+					Namespace:       config.Namespace,
+					Name:            params.From.Name,
+					ResourceVersion: current,
+				},
+			},
+		})
+	}
+
 	// CONFIG CHANGE TRIGGERS
 	canTriggerByConfigChange := false
-	// Our deployment config has a config change trigger and no image change has triggered.
-	// If an image change had happened, it would be enough to start a new deployment without
+	// Our deployment config has a config change trigger and no image or resource change has
+	// triggered. If one had happened, it would be enough to start a new deployment without
 	// caring about the config change trigger.
-	if deployutil.HasChangeTrigger(config) && !canTriggerByImageChange {
+	if deployutil.HasChangeTrigger(config) && !canTriggerByImageChange && !canTriggerByResourceChange {
 		// This is the initial deployment or the config has a template change. We need to
 		// kick a new deployment.
 		if config.Status.LatestVersion == 0 || !kapi.Semantic.DeepEqual(config.Spec.Template, decoded.Spec.Template) {
@@ -166,7 +233,24 @@ func canTrigger(config, decoded *deployapi.DeploymentConfig) (bool, []deployapi.
 		}
 	}
 
-	return canTriggerByConfigChange || canTriggerByImageChange, causes
+	return canTriggerByConfigChange || canTriggerByImageChange || canTriggerByResourceChange, causes
+}
+
+// lastTriggeredResourceVersion returns the resource version recorded by the resource change
+// trigger of previous (the deployment config decoded from the latest deployment) which points
+// to from. The version is empty if previous had no such trigger; the first version observed
+// by a trigger added after the latest deployment then triggers a new deployment.
+func lastTriggeredResourceVersion(from kapi.ObjectReference, previous deployapi.DeploymentConfig) string {
+	for _, t := range previous.Spec.Triggers {
+		params := t.ResourceChangeParams
+		if t.Type != deployapi.DeploymentTriggerOnResourceChange || params == nil {
+			continue
+		}
+		if params.From.Kind == from.Kind && params.From.Name == from.Name {
+			return params.LastTriggeredResourceVersion
+		}
+	}
+	return ""
 }
 
 // triggeredByDifferentImage compares the provided image change parameters with those found in the
@@ -194,6 +278,26 @@ func (c *DeploymentTriggerController) update(config *deployapi.DeploymentConfig,
 	config.Status.LatestVersion++
 	config.Status.Details = new(deployapi.DeploymentDetails)
 	config.Status.Details.Causes = causes
+	config.Status.PendingDeployment = nil
 	_, err := c.dn.DeploymentConfigs(config.Namespace).UpdateStatus(config)
 	return err
 }
+
+// queue records a deployment with the provided causes as pending until the
+// deployment window of the config opens at next, and requeues the config for
+// that time so the deployment is started even if the window is shorter than the
+// resync period of the controller.
+func (c *DeploymentTriggerController) queue(config *deployapi.DeploymentConfig, causes []deployapi.DeploymentCause, next time.Time) error {
+	pending := &deployapi.PendingDeployment{
+		Causes:        causes,
+		ScheduledTime: unversioned.NewTime(next),
+	}
+	if !kapi.Semantic.DeepEqual(config.Status.PendingDeployment, pending) {
+		config.Status.PendingDeployment = pending
+		if _, err := c.dn.DeploymentConfigs(config.Namespace).UpdateStatus(config); err != nil {
+			return err
+		}
+	}
+	c.requeueAt(config, next)
+	return nil
+}
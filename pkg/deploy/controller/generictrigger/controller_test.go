@@ -2,6 +2,7 @@ package generictrigger
 
 import (
 	"testing"
+	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
 	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
@@ -158,6 +159,105 @@ func TestHandle_changeWithTemplateDiff(t *testing.T) {
 	}
 }
 
+// TestHandle_resourceChange ensures that a resource version recorded by a resource change
+// trigger which differs from the one in the latest deployment results in a version bump.
+func TestHandle_resourceChange(t *testing.T) {
+	trigger := func(version string) deployapi.DeploymentTriggerPolicy {
+		return deployapi.DeploymentTriggerPolicy{
+			Type: deployapi.DeploymentTriggerOnResourceChange,
+			ResourceChangeParams: &deployapi.DeploymentTriggerResourceChangeParams{
+				From:                         kapi.ObjectReference{Kind: "Secret", Name: "creds"},
+				LastTriggeredResourceVersion: version,
+			},
+		}
+	}
+
+	tests := []struct {
+		name            string
+		latestVersion   int64
+		deployedVersion string
+		version         string
+
+		expectedVersion int64
+		expectedCause   deployapi.DeploymentTriggerType
+	}{
+		{
+			name:          "initial deployment waits for the resource to be observed",
+			latestVersion: 0,
+		},
+		{
+			name:            "initial deployment once the resource is observed",
+			latestVersion:   0,
+			version:         "1",
+			expectedVersion: 1,
+			expectedCause:   deployapi.DeploymentTriggerOnConfigChange,
+		},
+		{
+			name:            "unchanged resource",
+			latestVersion:   1,
+			deployedVersion: "1",
+			version:         "1",
+		},
+		{
+			name:            "changed resource",
+			latestVersion:   1,
+			deployedVersion: "1",
+			version:         "2",
+			expectedVersion: 2,
+			expectedCause:   deployapi.DeploymentTriggerOnResourceChange,
+		},
+	}
+
+	for _, test := range tests {
+		fake := &testclient.Fake{}
+		kFake := &ktestclient.Fake{}
+
+		deployed := testapi.OkDeploymentConfig(test.latestVersion)
+		deployed.Namespace = kapi.NamespaceDefault
+		deployed.Spec.Triggers = []deployapi.DeploymentTriggerPolicy{testapi.OkConfigChangeTrigger(), trigger(test.deployedVersion)}
+		deployment, _ := deployutil.MakeDeployment(deployed, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+		config := testapi.OkDeploymentConfig(test.latestVersion)
+		config.Namespace = kapi.NamespaceDefault
+		config.Spec.Triggers = []deployapi.DeploymentTriggerPolicy{testapi.OkConfigChangeTrigger(), trigger(test.version)}
+
+		var updated *deployapi.DeploymentConfig
+		fake.PrependReactor("update", "deploymentconfigs/status", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			updated = action.(ktestclient.UpdateAction).GetObject().(*deployapi.DeploymentConfig)
+			return true, updated, nil
+		})
+		kFake.PrependReactor("get", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			return true, deployment, nil
+		})
+
+		controller := NewDeploymentTriggerController(fake, kFake, codec)
+		if err := controller.Handle(config); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if test.expectedVersion == 0 {
+			if updated != nil {
+				t.Errorf("%s: unexpected update to version %d", test.name, updated.Status.LatestVersion)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected config to be updated", test.name)
+			continue
+		}
+		if e, a := test.expectedVersion, updated.Status.LatestVersion; e != a {
+			t.Errorf("%s: expected update to latestversion=%d, got %d", test.name, e, a)
+		}
+		if details := updated.Status.Details; details == nil || len(details.Causes) != 1 || details.Causes[0].Type != test.expectedCause {
+			t.Errorf("%s: expected a single %s cause, got %#v", test.name, test.expectedCause, details)
+			continue
+		}
+		if cause := updated.Status.Details.Causes[0].ResourceTrigger; test.expectedCause == deployapi.DeploymentTriggerOnResourceChange && (cause == nil || cause.From.ResourceVersion != test.version) {
+			t.Errorf("%s: expected the cause to point at version %s, got %#v", test.name, test.version, cause)
+		}
+	}
+}
+
 // TestHandle_waitForImageController tests an initial deployment with unresolved image. The config
 // change controller should never increment latestVersion, thus trigger a deployment for this config.
 func TestHandle_waitForImageController(t *testing.T) {
@@ -258,6 +358,197 @@ func TestHandle_automaticImageUpdates(t *testing.T) {
 	}
 }
 
+// TestHandle_deploymentWindow ensures that deployments triggered outside of
+// the deployment window are queued and started once the window opens.
+func TestHandle_deploymentWindow(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2016, time.June, 15, 10, 30, 0, 0, time.UTC)
+	pendingCause := deployapi.DeploymentCause{Type: deployapi.DeploymentTriggerManual}
+
+	tests := []struct {
+		name     string
+		schedule string
+		pending  *deployapi.PendingDeployment
+		modify   bool
+
+		expectedVersion   int64
+		expectedPending   bool
+		expectedScheduled time.Time
+		expectedCauses    int
+	}{
+		{
+			name:              "template change outside of the window",
+			schedule:          "* 2-4 * * *",
+			modify:            true,
+			expectedVersion:   1,
+			expectedPending:   true,
+			expectedScheduled: time.Date(2016, time.June, 16, 2, 0, 0, 0, time.UTC),
+			expectedCauses:    1,
+		},
+		{
+			name:            "template change inside of the window",
+			schedule:        "* 10 * * 3",
+			modify:          true,
+			expectedVersion: 2,
+			expectedCauses:  1,
+		},
+		{
+			name:            "pending deployment once the window opens",
+			schedule:        "* 10 * * 3",
+			pending:         &deployapi.PendingDeployment{Causes: []deployapi.DeploymentCause{pendingCause}},
+			expectedVersion: 2,
+			expectedCauses:  1,
+		},
+		{
+			name:              "pending deployment merged with a new change",
+			schedule:          "* 2-4 * * *",
+			pending:           &deployapi.PendingDeployment{Causes: []deployapi.DeploymentCause{pendingCause}},
+			modify:            true,
+			expectedVersion:   1,
+			expectedPending:   true,
+			expectedScheduled: time.Date(2016, time.June, 16, 2, 0, 0, 0, time.UTC),
+			expectedCauses:    2,
+		},
+	}
+
+	for _, test := range tests {
+		fake := &testclient.Fake{}
+		kFake := &ktestclient.Fake{}
+
+		config := testapi.OkDeploymentConfig(1)
+		config.Namespace = kapi.NamespaceDefault
+		config.Spec.Triggers = []deployapi.DeploymentTriggerPolicy{testapi.OkConfigChangeTrigger()}
+		deployment, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+		config.Spec.DeploymentWindow = &deployapi.DeploymentWindow{Schedule: test.schedule}
+		config.Status.PendingDeployment = test.pending
+		if test.modify {
+			config.Spec.Template.Labels["newkey"] = "value"
+		}
+
+		var updated *deployapi.DeploymentConfig
+		fake.PrependReactor("update", "deploymentconfigs/status", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			updated = action.(ktestclient.UpdateAction).GetObject().(*deployapi.DeploymentConfig)
+			return true, updated, nil
+		})
+		kFake.PrependReactor("get", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			return true, deployment, nil
+		})
+
+		controller := NewDeploymentTriggerController(fake, kFake, codec)
+		controller.now = func() time.Time { return now }
+
+		if err := controller.Handle(config); err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected the config status to be updated", test.name)
+			continue
+		}
+		if e, a := test.expectedVersion, updated.Status.LatestVersion; e != a {
+			t.Errorf("%s: expected latest version %d, got %d", test.name, e, a)
+		}
+
+		var causes []deployapi.DeploymentCause
+		if test.expectedPending {
+			pending := updated.Status.PendingDeployment
+			if pending == nil {
+				t.Errorf("%s: expected a pending deployment", test.name)
+				continue
+			}
+			if e, a := test.expectedScheduled, pending.ScheduledTime.Time; !e.Equal(a) {
+				t.Errorf("%s: expected the deployment to be scheduled for %v, got %v", test.name, e, a)
+			}
+			causes = pending.Causes
+		} else {
+			if updated.Status.PendingDeployment != nil {
+				t.Errorf("%s: unexpected pending deployment: %#v", test.name, updated.Status.PendingDeployment)
+			}
+			if updated.Status.Details == nil {
+				t.Errorf("%s: expected deployment details to be set", test.name)
+				continue
+			}
+			causes = updated.Status.Details.Causes
+		}
+		if e, a := test.expectedCauses, len(causes); e != a {
+			t.Errorf("%s: expected %d causes, got %d: %#v", test.name, e, a, causes)
+		}
+	}
+}
+
+// TestHandle_shortDeploymentWindow ensures that a config with a deployment
+// pending for a window shorter than the resync period is requeued for the time
+// the window opens, and deployed then.
+func TestHandle_shortDeploymentWindow(t *testing.T) {
+	now := time.Date(2016, time.June, 15, 10, 30, 20, 0, time.UTC)
+	opens := time.Date(2016, time.June, 15, 10, 31, 0, 0, time.UTC)
+
+	fake := &testclient.Fake{}
+	kFake := &ktestclient.Fake{}
+
+	config := testapi.OkDeploymentConfig(1)
+	config.Namespace = kapi.NamespaceDefault
+	config.Spec.Triggers = []deployapi.DeploymentTriggerPolicy{testapi.OkConfigChangeTrigger()}
+	deployment, _ := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	// A window open for a single minute.
+	config.Spec.DeploymentWindow = &deployapi.DeploymentWindow{Schedule: "31 10 * * *"}
+	config.Spec.Template.Labels["newkey"] = "value"
+
+	var updated *deployapi.DeploymentConfig
+	fake.PrependReactor("update", "deploymentconfigs/status", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		updated = action.(ktestclient.UpdateAction).GetObject().(*deployapi.DeploymentConfig)
+		return true, updated, nil
+	})
+	kFake.PrependReactor("get", "replicationcontrollers", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		return true, deployment, nil
+	})
+
+	controller := NewDeploymentTriggerController(fake, kFake, codec)
+	controller.now = func() time.Time { return now }
+	var requeued []time.Time
+	controller.requeueAt = func(config *deployapi.DeploymentConfig, at time.Time) {
+		requeued = append(requeued, at)
+	}
+
+	if err := controller.Handle(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated == nil || updated.Status.PendingDeployment == nil {
+		t.Fatalf("expected a pending deployment, got %#v", updated)
+	}
+	if len(requeued) != 1 || !requeued[0].Equal(opens) {
+		t.Fatalf("expected the config to be requeued for %v, got %v", opens, requeued)
+	}
+
+	// Handling the config again before the window opens doesn't update it, but
+	// still requeues it.
+	updated, config = nil, updated
+	if err := controller.Handle(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated != nil {
+		t.Errorf("unexpected update: %#v", updated)
+	}
+	if len(requeued) != 2 || !requeued[1].Equal(opens) {
+		t.Errorf("expected the config to be requeued for %v, got %v", opens, requeued)
+	}
+
+	// The requeued config is deployed while the window is open.
+	now = requeued[0]
+	if err := controller.Handle(config); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated == nil {
+		t.Fatalf("expected the config to be updated")
+	}
+	if e, a := int64(2), updated.Status.LatestVersion; e != a {
+		t.Errorf("expected latest version %d, got %d", e, a)
+	}
+	if updated.Status.PendingDeployment != nil {
+		t.Errorf("unexpected pending deployment: %#v", updated.Status.PendingDeployment)
+	}
+}
+
 func TestCanTrigger(t *testing.T) {
 	tests := []struct {
 		name string
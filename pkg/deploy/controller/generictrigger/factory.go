@@ -1,9 +1,12 @@
 package generictrigger
 
 import (
+	"fmt"
+	"sync"
 	"time"
 
 	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/client/cache"
 	"k8s.io/kubernetes/pkg/client/record"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
@@ -44,6 +47,7 @@ func (factory *DeploymentTriggerControllerFactory) Create() controller.RunnableC
 	eventBroadcaster.StartRecordingToSink(factory.KubeClient.Events(""))
 
 	triggerController := NewDeploymentTriggerController(factory.Client, factory.KubeClient, factory.Codec)
+	triggerController.requeueAt = factory.requeueAt(queue)
 
 	return &controller.RetryController{
 		Queue: queue,
@@ -68,3 +72,46 @@ func (factory *DeploymentTriggerControllerFactory) Create() controller.RunnableC
 		},
 	}
 }
+
+// requeueAt returns a function adding a config back to the queue at the given
+// time, so pending deployments are started once their deployment window opens
+// instead of on the next resync, which could miss short windows. The config is
+// read again when the time comes, and a config is only requeued once for the
+// same time.
+func (factory *DeploymentTriggerControllerFactory) requeueAt(queue *cache.FIFO) func(*deployapi.DeploymentConfig, time.Time) {
+	var lock sync.Mutex
+	scheduled := make(map[string]time.Time)
+
+	return func(config *deployapi.DeploymentConfig, at time.Time) {
+		key, err := cache.MetaNamespaceKeyFunc(config)
+		if err != nil {
+			utilruntime.HandleError(err)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		if next, ok := scheduled[key]; ok && next.Equal(at) {
+			return
+		}
+		scheduled[key] = at
+
+		namespace, name := config.Namespace, config.Name
+		time.AfterFunc(at.Sub(time.Now()), func() {
+			lock.Lock()
+			if next, ok := scheduled[key]; ok && next.Equal(at) {
+				delete(scheduled, key)
+			}
+			lock.Unlock()
+
+			config, err := factory.Client.DeploymentConfigs(namespace).Get(name)
+			if kerrors.IsNotFound(err) {
+				return
+			}
+			if err != nil {
+				utilruntime.HandleError(fmt.Errorf("couldn't requeue deployment config %s: %v", key, err))
+				return
+			}
+			queue.AddIfNotPresent(config)
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package resourcechange
+
+import (
+	"fmt"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+)
+
+// ResourceChangeController records the resource versions of triggered Secrets and ConfigMaps
+// in the resource change triggers of deployment configs. The trigger controller starts a new
+// deployment of a config once a trigger records a resource version which differs from the one
+// in its latest deployment.
+//
+// Use the ResourceChangeControllerFactory to create this controller.
+type ResourceChangeController struct {
+	listDeploymentConfigs func(namespace string) ([]*deployapi.DeploymentConfig, error)
+	client                client.DeploymentConfigsNamespacer
+}
+
+// fatalError is an error which can't be retried.
+type fatalError string
+
+func (e fatalError) Error() string {
+	return fmt.Sprintf("fatal error handling resource: %s", string(e))
+}
+
+// Handle processes resource change triggers associated with a Secret or a ConfigMap.
+func (c *ResourceChangeController) Handle(obj runtime.Object) error {
+	var kind string
+	switch obj.(type) {
+	case *kapi.Secret:
+		kind = "Secret"
+	case *kapi.ConfigMap:
+		kind = "ConfigMap"
+	default:
+		return fatalError(fmt.Sprintf("unsupported resource %T", obj))
+	}
+	meta, err := kapi.ObjectMetaFor(obj)
+	if err != nil {
+		return fatalError(err.Error())
+	}
+	label := fmt.Sprintf("%s %s/%s", kind, meta.Namespace, meta.Name)
+
+	configs, err := c.listDeploymentConfigs(meta.Namespace)
+	if err != nil {
+		return fmt.Errorf("couldn't get list of deployment configs while handling %s: %v", label, err)
+	}
+
+	anyFailed := false
+	for _, config := range configs {
+		if !detectResourceChange(config, kind, meta) {
+			continue
+		}
+		glog.V(4).Infof("Detected a change to %s for deployment config %q", label, deployutil.LabelForDeploymentConfig(config))
+		if _, err := c.client.DeploymentConfigs(config.Namespace).Update(config); err != nil {
+			anyFailed = true
+			glog.V(2).Infof("Couldn't update deployment config %q: %v", deployutil.LabelForDeploymentConfig(config), err)
+		}
+	}
+
+	// The configs which failed to update are retried with the resource.
+	if anyFailed {
+		return fmt.Errorf("couldn't update some deployment configs for trigger on %s", label)
+	}
+	return nil
+}
+
+// detectResourceChange records the resource version of the object in the resource change
+// triggers of config which point to it. It returns true if any trigger was updated.
+func detectResourceChange(config *deployapi.DeploymentConfig, kind string, meta *kapi.ObjectMeta) bool {
+	changed := false
+	for _, trigger := range config.Spec.Triggers {
+		params := trigger.ResourceChangeParams
+		if trigger.Type != deployapi.DeploymentTriggerOnResourceChange || params == nil {
+			continue
+		}
+		if params.From.Kind != kind || params.From.Name != meta.Name {
+			continue
+		}
+		if params.LastTriggeredResourceVersion == meta.ResourceVersion {
+			continue
+		}
+		params.LastTriggeredResourceVersion = meta.ResourceVersion
+		changed = true
+	}
+	return changed
+}
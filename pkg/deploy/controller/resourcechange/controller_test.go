@@ -0,0 +1,141 @@
+package resourcechange
+
+import (
+	"fmt"
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	testapi "github.com/openshift/origin/pkg/deploy/api/test"
+)
+
+func resourceChangeConfig(kind, name, lastResourceVersion string) *deployapi.DeploymentConfig {
+	config := testapi.OkDeploymentConfig(1)
+	config.Namespace = kapi.NamespaceDefault
+	config.Spec.Triggers = []deployapi.DeploymentTriggerPolicy{
+		{
+			Type: deployapi.DeploymentTriggerOnResourceChange,
+			ResourceChangeParams: &deployapi.DeploymentTriggerResourceChangeParams{
+				From:                         kapi.ObjectReference{Kind: kind, Name: name},
+				LastTriggeredResourceVersion: lastResourceVersion,
+			},
+		},
+	}
+	return config
+}
+
+func TestHandle(t *testing.T) {
+	secret := &kapi.Secret{ObjectMeta: kapi.ObjectMeta{Name: "creds", Namespace: kapi.NamespaceDefault, ResourceVersion: "2"}}
+	configMap := &kapi.ConfigMap{ObjectMeta: kapi.ObjectMeta{Name: "creds", Namespace: kapi.NamespaceDefault, ResourceVersion: "2"}}
+
+	tests := []struct {
+		name      string
+		obj       runtime.Object
+		config    func() *deployapi.DeploymentConfig
+		updateErr error
+
+		expectedUpdate bool
+		expectedErr    bool
+	}{
+		{
+			name:   "unrelated trigger",
+			obj:    secret,
+			config: func() *deployapi.DeploymentConfig { return resourceChangeConfig("ConfigMap", "creds", "1") },
+		},
+		{
+			name:   "unchanged secret",
+			obj:    secret,
+			config: func() *deployapi.DeploymentConfig { return resourceChangeConfig("Secret", "creds", "2") },
+		},
+		{
+			name:           "first observation",
+			obj:            secret,
+			config:         func() *deployapi.DeploymentConfig { return resourceChangeConfig("Secret", "creds", "") },
+			expectedUpdate: true,
+		},
+		{
+			name: "paused config",
+			obj:  secret,
+			config: func() *deployapi.DeploymentConfig {
+				config := resourceChangeConfig("Secret", "creds", "1")
+				config.Spec.Paused = true
+				return config
+			},
+			expectedUpdate: true,
+		},
+		{
+			name:           "changed secret",
+			obj:            secret,
+			config:         func() *deployapi.DeploymentConfig { return resourceChangeConfig("Secret", "creds", "1") },
+			expectedUpdate: true,
+		},
+		{
+			name:           "changed config map",
+			obj:            configMap,
+			config:         func() *deployapi.DeploymentConfig { return resourceChangeConfig("ConfigMap", "creds", "1") },
+			expectedUpdate: true,
+		},
+		{
+			name:           "update conflict is retried",
+			obj:            secret,
+			config:         func() *deployapi.DeploymentConfig { return resourceChangeConfig("Secret", "creds", "1") },
+			updateErr:      kerrors.NewConflict(deployapi.Resource("deploymentconfigs"), "config", fmt.Errorf("conflict")),
+			expectedUpdate: true,
+			expectedErr:    true,
+		},
+	}
+
+	for _, test := range tests {
+		var updated *deployapi.DeploymentConfig
+		fake := &testclient.Fake{}
+		fake.AddReactor("update", "*", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+			if action.GetResource() != "deploymentconfigs" {
+				t.Errorf("%s: unexpected update of %s", test.name, action.GetResource())
+			}
+			updated = action.(ktestclient.UpdateAction).GetObject().(*deployapi.DeploymentConfig)
+			return true, updated, test.updateErr
+		})
+
+		controller := &ResourceChangeController{
+			listDeploymentConfigs: func(namespace string) ([]*deployapi.DeploymentConfig, error) {
+				return []*deployapi.DeploymentConfig{test.config()}, nil
+			},
+			client: fake,
+		}
+
+		err := controller.Handle(test.obj)
+		if test.expectedErr {
+			if err == nil {
+				t.Errorf("%s: expected an error", test.name)
+			} else if _, isFatal := err.(fatalError); isFatal {
+				t.Errorf("%s: expected a retryable error, got %v", test.name, err)
+			}
+		} else if err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+			continue
+		}
+
+		if !test.expectedUpdate {
+			if len(fake.Actions()) > 0 {
+				t.Errorf("%s: unexpected actions: %v", test.name, fake.Actions())
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected the deployment config to be updated", test.name)
+			continue
+		}
+		if e, a := "2", updated.Spec.Triggers[0].ResourceChangeParams.LastTriggeredResourceVersion; e != a {
+			t.Errorf("%s: expected the last triggered resource version to be %q, got %q", test.name, e, a)
+		}
+		// the trigger controller starts the new deployment
+		if e, a := int64(1), updated.Status.LatestVersion; e != a {
+			t.Errorf("%s: expected latest version %d, got %d", test.name, e, a)
+		}
+	}
+}
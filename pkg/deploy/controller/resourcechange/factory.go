@@ -0,0 +1,219 @@
+package resourcechange
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/flowcontrol"
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+	"k8s.io/kubernetes/pkg/util/wait"
+	"k8s.io/kubernetes/pkg/watch"
+
+	osclient "github.com/openshift/origin/pkg/client"
+	controller "github.com/openshift/origin/pkg/controller"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+)
+
+// ResourceChangeControllerFactory can create a ResourceChangeController which
+// watches the changes to the Secrets and ConfigMaps referenced by resource change
+// triggers.
+type ResourceChangeControllerFactory struct {
+	// Client is an OpenShift client.
+	Client osclient.Interface
+	// KubeClient is a Kubernetes client.
+	KubeClient kclient.Interface
+}
+
+// resourceResyncPeriod is how often the Secrets and ConfigMaps are listed again. Changes
+// are delivered by their watches, so relisting them only recovers from missed events.
+const resourceResyncPeriod = 30 * time.Minute
+
+// Create creates a ResourceChangeController.
+func (factory *ResourceChangeControllerFactory) Create() controller.RunnableController {
+	deploymentConfigLW := &cache.ListWatch{
+		ListFunc: func(options kapi.ListOptions) (runtime.Object, error) {
+			return factory.Client.DeploymentConfigs(kapi.NamespaceAll).List(options)
+		},
+		WatchFunc: func(options kapi.ListOptions) (watch.Interface, error) {
+			return factory.Client.DeploymentConfigs(kapi.NamespaceAll).Watch(options)
+		},
+	}
+	// Configs are indexed by namespace as every change looks up the configs
+	// of its namespace, and by the resources their triggers reference.
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{
+		cache.NamespaceIndex:       cache.MetaNamespaceIndexFunc,
+		resourceChangeTriggerIndex: resourceChangeTriggerIndexFunc,
+	})
+	deploymentConfigReflector := cache.NewReflector(deploymentConfigLW, &deployapi.DeploymentConfig{}, indexer, 2*time.Minute)
+	deploymentConfigReflector.Run()
+
+	// Secrets and ConfigMaps share a queue, so keys have to include the kind.
+	queue := cache.NewFIFO(resourceKeyFunc)
+
+	// Secrets and ConfigMaps can't be watched by name, so all of them are watched, but
+	// only the metadata of those referenced by a trigger is queued. The memory used by
+	// the controller is then proportional to the referenced resources, rather than to
+	// all the Secrets and ConfigMaps of the cluster.
+	referenced := &referencedResourceStore{Store: queue, indexer: indexer}
+	secretLW := &cache.ListWatch{
+		ListFunc: func(options kapi.ListOptions) (runtime.Object, error) {
+			return factory.KubeClient.Secrets(kapi.NamespaceAll).List(options)
+		},
+		WatchFunc: func(options kapi.ListOptions) (watch.Interface, error) {
+			return factory.KubeClient.Secrets(kapi.NamespaceAll).Watch(options)
+		},
+	}
+	configMapLW := &cache.ListWatch{
+		ListFunc: func(options kapi.ListOptions) (runtime.Object, error) {
+			return factory.KubeClient.ConfigMaps(kapi.NamespaceAll).List(options)
+		},
+		WatchFunc: func(options kapi.ListOptions) (watch.Interface, error) {
+			return factory.KubeClient.ConfigMaps(kapi.NamespaceAll).Watch(options)
+		},
+	}
+	go func() {
+		// The resources are filtered with the configs, so wait for them to be listed.
+		wait.PollInfinite(100*time.Millisecond, func() (bool, error) {
+			return len(deploymentConfigReflector.LastSyncResourceVersion()) > 0, nil
+		})
+		cache.NewReflector(secretLW, &kapi.Secret{}, referenced, resourceResyncPeriod).Run()
+		cache.NewReflector(configMapLW, &kapi.ConfigMap{}, referenced, resourceResyncPeriod).Run()
+	}()
+
+	changeController := &ResourceChangeController{
+		listDeploymentConfigs: func(namespace string) ([]*deployapi.DeploymentConfig, error) {
+			objs, err := indexer.ByIndex(cache.NamespaceIndex, namespace)
+			if err != nil {
+				return nil, err
+			}
+			configs := []*deployapi.DeploymentConfig{}
+			for _, obj := range objs {
+				config := obj.(*deployapi.DeploymentConfig)
+				// The controller records resource versions in the configs, so hand out
+				// copies rather than the cached objects.
+				copied, err := kapi.Scheme.DeepCopy(config)
+				if err != nil {
+					return nil, err
+				}
+				configs = append(configs, copied.(*deployapi.DeploymentConfig))
+			}
+			return configs, nil
+		},
+		client: factory.Client,
+	}
+
+	return &controller.RetryController{
+		Queue: queue,
+		RetryManager: controller.NewQueueRetryManager(
+			queue,
+			resourceKeyFunc,
+			func(obj interface{}, err error, retries controller.Retry) bool {
+				utilruntime.HandleError(err)
+				if _, isFatal := err.(fatalError); isFatal {
+					return false
+				}
+				return retries.Count < 5
+			},
+			flowcontrol.NewTokenBucketRateLimiter(1, 10),
+		),
+		Handle: func(obj interface{}) error {
+			return changeController.Handle(obj.(runtime.Object))
+		},
+	}
+}
+
+// resourceKeyFunc returns a key for a Secret or a ConfigMap which is unique across both kinds.
+func resourceKeyFunc(obj interface{}) (string, error) {
+	key, err := cache.MetaNamespaceKeyFunc(obj)
+	if err != nil {
+		return "", err
+	}
+	switch obj.(type) {
+	case *kapi.Secret:
+		return "secret/" + key, nil
+	case *kapi.ConfigMap:
+		return "configmap/" + key, nil
+	}
+	return "", fmt.Errorf("unsupported resource %T", obj)
+}
+
+// resourceChangeTriggerIndex indexes deployment configs by the keys of the Secrets and
+// ConfigMaps their resource change triggers reference.
+const resourceChangeTriggerIndex = "resourceChangeTrigger"
+
+// resourceChangeTriggerIndexFunc returns the keys of the resources referenced by the
+// resource change triggers of a deployment config, as returned by resourceKeyFunc.
+func resourceChangeTriggerIndexFunc(obj interface{}) ([]string, error) {
+	config, ok := obj.(*deployapi.DeploymentConfig)
+	if !ok {
+		return nil, fmt.Errorf("unsupported object %T", obj)
+	}
+	keys := []string{}
+	for _, trigger := range config.Spec.Triggers {
+		params := trigger.ResourceChangeParams
+		if trigger.Type != deployapi.DeploymentTriggerOnResourceChange || params == nil {
+			continue
+		}
+		keys = append(keys, strings.ToLower(params.From.Kind)+"/"+config.Namespace+"/"+params.From.Name)
+	}
+	return keys, nil
+}
+
+// referencedResourceStore wraps the queue of the controller and only adds to it the
+// metadata of the Secrets and ConfigMaps referenced by the deployment configs of indexer.
+type referencedResourceStore struct {
+	cache.Store
+	indexer cache.Indexer
+}
+
+// Add queues obj if it is referenced.
+func (s *referencedResourceStore) Add(obj interface{}) error {
+	if obj, ok := s.referenced(obj); ok {
+		return s.Store.Add(obj)
+	}
+	return nil
+}
+
+// Update queues obj if it is referenced.
+func (s *referencedResourceStore) Update(obj interface{}) error {
+	if obj, ok := s.referenced(obj); ok {
+		return s.Store.Update(obj)
+	}
+	return nil
+}
+
+// Replace queues the referenced resources of list. Secrets and ConfigMaps share the
+// queue, so the queued resources of the other kind must not be replaced.
+func (s *referencedResourceStore) Replace(list []interface{}, resourceVersion string) error {
+	for _, obj := range list {
+		if err := s.Add(obj); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// referenced returns a copy of obj holding only its metadata, and true if a deployment
+// config references it.
+func (s *referencedResourceStore) referenced(obj interface{}) (interface{}, bool) {
+	key, err := resourceKeyFunc(obj)
+	if err != nil {
+		return nil, false
+	}
+	configs, err := s.indexer.ByIndex(resourceChangeTriggerIndex, key)
+	if err != nil || len(configs) == 0 {
+		return nil, false
+	}
+	switch t := obj.(type) {
+	case *kapi.Secret:
+		return &kapi.Secret{ObjectMeta: t.ObjectMeta}, true
+	case *kapi.ConfigMap:
+		return &kapi.ConfigMap{ObjectMeta: t.ObjectMeta}, true
+	}
+	return nil, false
+}
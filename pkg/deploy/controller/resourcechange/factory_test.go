@@ -0,0 +1,46 @@
+package resourcechange
+
+import (
+	"testing"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/client/cache"
+)
+
+func TestReferencedResourceStore(t *testing.T) {
+	indexer := cache.NewIndexer(cache.MetaNamespaceKeyFunc, cache.Indexers{resourceChangeTriggerIndex: resourceChangeTriggerIndexFunc})
+	if err := indexer.Add(resourceChangeConfig("Secret", "creds", "")); err != nil {
+		t.Fatal(err)
+	}
+	queue := cache.NewFIFO(resourceKeyFunc)
+	store := &referencedResourceStore{Store: queue, indexer: indexer}
+
+	referenced := &kapi.Secret{
+		ObjectMeta: kapi.ObjectMeta{Name: "creds", Namespace: kapi.NamespaceDefault, ResourceVersion: "2"},
+		Data:       map[string][]byte{"password": []byte("secret")},
+	}
+	objs := []interface{}{
+		referenced,
+		// same name in another namespace
+		&kapi.Secret{ObjectMeta: kapi.ObjectMeta{Name: "creds", Namespace: "other"}},
+		// same name and namespace but another kind
+		&kapi.ConfigMap{ObjectMeta: kapi.ObjectMeta{Name: "creds", Namespace: kapi.NamespaceDefault}},
+	}
+	if err := store.Replace(objs, "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := store.Add(&kapi.Secret{ObjectMeta: kapi.ObjectMeta{Name: "other", Namespace: kapi.NamespaceDefault}}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if keys := queue.ListKeys(); len(keys) != 1 || keys[0] != "secret/default/creds" {
+		t.Fatalf("expected only the referenced secret to be queued, got %v", keys)
+	}
+	queued := queue.Pop().(*kapi.Secret)
+	if queued.ResourceVersion != "2" {
+		t.Errorf("expected the metadata of the secret to be queued, got %#v", queued.ObjectMeta)
+	}
+	if queued.Data != nil {
+		t.Errorf("expected the data of the secret not to be queued")
+	}
+}
@@ -53,6 +53,21 @@ func NewPruneTasker(deploymentConfigs []*deployapi.DeploymentConfig, deployments
 	}
 }
 
+// NewRevisionHistoryPruneTasker returns a PruneTasker that prunes the deployments of
+// deploymentConfigs past their RevisionHistoryLimit
+func NewRevisionHistoryPruneTasker(deploymentConfigs []*deployapi.DeploymentConfig, deployments []*kapi.ReplicationController, handler PruneFunc) PruneTasker {
+	filter := &andFilter{
+		filterPredicates: []FilterPredicate{
+			FilterDeploymentsPredicate,
+		},
+	}
+	dataSet := NewDataSet(deploymentConfigs, filter.Filter(deployments))
+	return &pruneTask{
+		resolver: NewRevisionHistoryResolver(dataSet),
+		handler:  handler,
+	}
+}
+
 // PruneTask will visit each item in the prunable set and invoke the associated handler
 func (t *pruneTask) PruneTask() error {
 	deployments, err := t.resolver.Resolve()
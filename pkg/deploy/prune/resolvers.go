@@ -123,3 +123,60 @@ func (o *perDeploymentConfigResolver) Resolve() ([]*kapi.ReplicationController,
 	}
 	return results, nil
 }
+
+type revisionHistoryResolver struct {
+	dataSet DataSet
+}
+
+// NewRevisionHistoryResolver returns a Resolver that selects the inactive, zero-replica deployments
+// of each config past its RevisionHistoryLimit. The latest deployment and the last complete
+// deployment of a config are never selected.
+func NewRevisionHistoryResolver(dataSet DataSet) Resolver {
+	return &revisionHistoryResolver{dataSet: dataSet}
+}
+
+func (o *revisionHistoryResolver) Resolve() ([]*kapi.ReplicationController, error) {
+	deploymentConfigs, err := o.dataSet.ListDeploymentConfigs()
+	if err != nil {
+		return nil, err
+	}
+
+	inactiveStates := sets.NewString(string(deployapi.DeploymentStatusComplete), string(deployapi.DeploymentStatusFailed))
+
+	results := []*kapi.ReplicationController{}
+	for _, deploymentConfig := range deploymentConfigs {
+		if deploymentConfig.Spec.RevisionHistoryLimit == nil {
+			continue
+		}
+		deployments, err := o.dataSet.ListDeploymentsByDeploymentConfig(deploymentConfig)
+		if err != nil {
+			return nil, err
+		}
+		sort.Sort(deployutil.ByMostRecent(deployments))
+
+		latestName := deployutil.LatestDeploymentNameForConfig(deploymentConfig)
+		lastCompleteName := ""
+		oldDeployments := []*kapi.ReplicationController{}
+		for _, deployment := range deployments {
+			status := deployutil.DeploymentStatusFor(deployment)
+			if len(lastCompleteName) == 0 && status == deployapi.DeploymentStatusComplete {
+				lastCompleteName = deployment.Name
+			}
+			if deployment.Name != latestName && inactiveStates.Has(string(status)) {
+				oldDeployments = append(oldDeployments, deployment)
+			}
+		}
+
+		limit := int(*deploymentConfig.Spec.RevisionHistoryLimit)
+		if limit >= len(oldDeployments) {
+			continue
+		}
+		for _, deployment := range oldDeployments[limit:] {
+			if deployment.Name == lastCompleteName || !FilterZeroReplicaSize(deployment) {
+				continue
+			}
+			results = append(results, deployment)
+		}
+	}
+	return results, nil
+}
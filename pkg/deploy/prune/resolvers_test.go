@@ -187,3 +187,74 @@ func TestPerDeploymentConfigResolver(t *testing.T) {
 		}
 	}
 }
+
+func TestRevisionHistoryResolver(t *testing.T) {
+	statuses := []deployapi.DeploymentStatus{
+		deployapi.DeploymentStatusComplete,
+		deployapi.DeploymentStatusComplete,
+		deployapi.DeploymentStatusFailed,
+		deployapi.DeploymentStatusComplete,
+		deployapi.DeploymentStatusFailed,
+		deployapi.DeploymentStatusFailed,
+	}
+
+	tests := []struct {
+		name          string
+		limit         *int32
+		expectedNames sets.String
+	}{
+		{
+			name:          "no limit",
+			expectedNames: sets.NewString(),
+		},
+		{
+			name:          "limit above the number of deployments",
+			limit:         newInt32(10),
+			expectedNames: sets.NewString(),
+		},
+		{
+			name:          "limit keeps the last complete deployment",
+			limit:         newInt32(1),
+			expectedNames: sets.NewString("config-2", "config-3"),
+		},
+		{
+			name:          "zero limit",
+			limit:         newInt32(0),
+			expectedNames: sets.NewString("config-2", "config-3", "config-5"),
+		},
+	}
+
+	now := unversioned.Now()
+	for _, test := range tests {
+		deploymentConfig := mockDeploymentConfig("a", "config")
+		deploymentConfig.Status.LatestVersion = int64(len(statuses))
+		deploymentConfig.Spec.RevisionHistoryLimit = test.limit
+
+		deployments := []*kapi.ReplicationController{}
+		for i, status := range statuses {
+			deployment := withStatus(mockDeployment("a", fmt.Sprintf("config-%d", i+1), deploymentConfig), status)
+			deployment = withCreated(deployment, unversioned.NewTime(now.Time.Add(time.Duration(i)*time.Hour)))
+			deployments = append(deployments, deployment)
+		}
+		// the oldest deployment is still scaled up
+		withSize(deployments[0], 2)
+
+		dataSet := NewDataSet([]*deployapi.DeploymentConfig{deploymentConfig}, deployments)
+		results, err := NewRevisionHistoryResolver(dataSet).Resolve()
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", test.name, err)
+			continue
+		}
+		foundNames := sets.String{}
+		for _, result := range results {
+			foundNames.Insert(result.Name)
+		}
+		if !foundNames.Equal(test.expectedNames) {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expectedNames.List(), foundNames.List())
+		}
+	}
+}
+
+func newInt32(i int32) *int32 {
+	return &i
+}
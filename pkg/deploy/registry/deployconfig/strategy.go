@@ -71,10 +71,22 @@ func (strategy) PrepareForUpdate(obj, old runtime.Object) {
 		delete(newDc.Annotations, api.DeploymentInstantiatedAnnotation)
 	}
 
-	// oc deploy --latest from old clients
+	// oc deploy --latest from old clients, and oc rollback. Only users bump the
+	// version through updates, controllers start deployments through status
+	// updates along with their causes, so the causes given by the client aren't
+	// trusted and the deployment is recorded as manual.
 	// TODO: Remove once we drop support for older clients
 	if newVersion == oldVersion+1 {
 		newDc.Status.LatestVersion = newVersion
+		newDc.Status.Details = &api.DeploymentDetails{
+			Causes: []api.DeploymentCause{{Type: api.DeploymentTriggerManual}},
+		}
+	}
+
+	// A deployment started by the update supersedes the one waiting for the
+	// deployment window to open.
+	if newDc.Status.LatestVersion != oldVersion {
+		newDc.Status.PendingDeployment = nil
 	}
 
 	// Any changes to the spec or labels, increment the generation number, any changes
@@ -117,6 +129,12 @@ func (statusStrategy) PrepareForUpdate(obj, old runtime.Object) {
 	oldDc := old.(*api.DeploymentConfig)
 	newDc.Spec = oldDc.Spec
 	newDc.Labels = oldDc.Labels
+
+	// Controllers start deployments by bumping the latest version through the
+	// status, which the deployment config controller has yet to observe.
+	if newDc.Status.LatestVersion != oldDc.Status.LatestVersion {
+		newDc.Generation = oldDc.Generation + 1
+	}
 }
 
 // ValidateUpdate is the default update validation for an end user updating status.
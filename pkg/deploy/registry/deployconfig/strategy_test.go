@@ -71,6 +71,18 @@ func TestPrepareForUpdate(t *testing.T) {
 			after:    afterDeploymentByNewClient(),
 			expected: expectedAfterByNewClient(),
 		},
+		{
+			name:     "forged automatic rollback cause",
+			prev:     prevDeployment(),
+			after:    afterDeploymentWithForgedCause(),
+			expected: expectedAfterWithForgedCause(),
+		},
+		{
+			name:     "new client update with a pending deployment",
+			prev:     prevDeploymentWithPendingDeployment(),
+			after:    afterDeploymentWithPendingDeploymentByNewClient(),
+			expected: expectedAfterByNewClient(),
+		},
 		{
 			name:     "spec change",
 			prev:     prevDeployment(),
@@ -121,6 +133,25 @@ func afterDeploymentByOldClient() *deployapi.DeploymentConfig {
 func expectedAfterByOldClient() *deployapi.DeploymentConfig {
 	dc := afterDeploymentByOldClient()
 	dc.Generation++
+	dc.Status.Details = &deployapi.DeploymentDetails{Causes: []deployapi.DeploymentCause{{Type: deployapi.DeploymentTriggerManual}}}
+	return dc
+}
+
+// afterDeploymentWithForgedCause is a deployment updated by a client claiming the new version is an
+// automatic rollback.
+func afterDeploymentWithForgedCause() *deployapi.DeploymentConfig {
+	dc := afterDeploymentByOldClient()
+	dc.Spec.Template.Spec.Containers[0].Image = "previous"
+	dc.Status.Details = &deployapi.DeploymentDetails{Causes: []deployapi.DeploymentCause{{Type: deployapi.DeploymentTriggerOnAutoRollback}}}
+	return dc
+}
+
+// expectedAfterWithForgedCause is the object we expect from the update hook after an update with a
+// forged cause, which is replaced by a manual one.
+func expectedAfterWithForgedCause() *deployapi.DeploymentConfig {
+	dc := afterDeploymentWithForgedCause()
+	dc.Generation++
+	dc.Status.Details = &deployapi.DeploymentDetails{Causes: []deployapi.DeploymentCause{{Type: deployapi.DeploymentTriggerManual}}}
 	return dc
 }
 
@@ -138,3 +169,61 @@ func expectedAfterByNewClient() *deployapi.DeploymentConfig {
 	dc.Generation++
 	return dc
 }
+
+// prevDeploymentWithPendingDeployment is an old object with a deployment
+// waiting for the deployment window to open.
+func prevDeploymentWithPendingDeployment() *deployapi.DeploymentConfig {
+	dc := prevDeployment()
+	dc.Status.PendingDeployment = &deployapi.PendingDeployment{
+		Causes: []deployapi.DeploymentCause{{Type: deployapi.DeploymentTriggerOnConfigChange}},
+	}
+	return dc
+}
+
+// afterDeploymentWithPendingDeploymentByNewClient is a deployment with a
+// pending deployment updated by a new oc client.
+func afterDeploymentWithPendingDeploymentByNewClient() *deployapi.DeploymentConfig {
+	dc := prevDeploymentWithPendingDeployment()
+	dc.Annotations[deployapi.DeploymentInstantiatedAnnotation] = deployapi.DeploymentInstantiatedAnnotationValue
+	return dc
+}
+
+// TestStatusPrepareForUpdate ensures that status updates bump the generation
+// only when they start a new deployment.
+func TestStatusPrepareForUpdate(t *testing.T) {
+	tests := []struct {
+		name string
+
+		after              func(*deployapi.DeploymentConfig)
+		expectedGeneration int64
+	}{
+		{
+			name:               "status change",
+			after:              func(dc *deployapi.DeploymentConfig) { dc.Status.ObservedGeneration = 4 },
+			expectedGeneration: 4,
+		},
+		{
+			name:               "spec change",
+			after:              func(dc *deployapi.DeploymentConfig) { dc.Spec.Replicas++ },
+			expectedGeneration: 4,
+		},
+		{
+			name:               "latest version change",
+			after:              func(dc *deployapi.DeploymentConfig) { dc.Status.LatestVersion++ },
+			expectedGeneration: 5,
+		},
+	}
+
+	for _, test := range tests {
+		prev := prevDeployment()
+		after := prevDeployment()
+		test.after(after)
+		StatusStrategy.PrepareForUpdate(after, prev)
+		if after.Generation != test.expectedGeneration {
+			t.Errorf("%s: expected generation %d, got %d", test.name, test.expectedGeneration, after.Generation)
+		}
+		if !reflect.DeepEqual(prev.Spec, after.Spec) {
+			t.Errorf("%s: expected the spec to be persisted", test.name)
+		}
+	}
+}
@@ -0,0 +1,318 @@
+package bluegreen
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/kubectl"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/client"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	strat "github.com/openshift/origin/pkg/deploy/strategy"
+	stratsupport "github.com/openshift/origin/pkg/deploy/strategy/support"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+)
+
+// BlueGreenDeploymentStrategy is a Strategy which brings the new deployment up
+// to full size next to the previous one and then switches a Service over to
+// it. The strategy leaves the previous deployment at its size; the deployment
+// config controller keeps it for KeepPreviousSeconds after the switch-over, so
+// that a rollback only has to switch the Service back.
+type BlueGreenDeploymentStrategy struct {
+	// out and errOut control where output is sent during the strategy
+	out, errOut io.Writer
+	// until is a condition that, if reached, will cause the strategy to exit early
+	until string
+	// getReplicationController knows how to get a replication controller.
+	getReplicationController func(namespace, name string) (*kapi.ReplicationController, error)
+	// getUpdateAcceptor returns an UpdateAcceptor to verify the pods of the
+	// deployment become ready.
+	getUpdateAcceptor func(timeout time.Duration) strat.UpdateAcceptor
+	// services is used to switch the Service over to the new deployment.
+	services kclient.ServicesNamespacer
+	// routes is used to point the Route at the Service of the new deployment.
+	routes client.RoutesNamespacer
+	// scaler is used to scale replication controllers.
+	scaler kubectl.Scaler
+	// decoder is used to decode DeploymentConfigs contained in deployments.
+	decoder runtime.Decoder
+	// hookExecutor can execute a lifecycle hook.
+	hookExecutor hookExecutor
+	// retryTimeout is how long to wait for the replica count update to succeed
+	// before giving up.
+	retryTimeout time.Duration
+	// retryPeriod is how often to try updating the replica count.
+	retryPeriod time.Duration
+}
+
+// AcceptorInterval is how often the UpdateAcceptor should check for
+// readiness.
+const AcceptorInterval = 1 * time.Second
+
+// NewBlueGreenDeploymentStrategy makes a BlueGreenDeploymentStrategy backed by
+// a real HookExecutor and client.
+func NewBlueGreenDeploymentStrategy(client kclient.Interface, oclient client.Interface, executor stratsupport.PodExecutor, decoder runtime.Decoder, out, errOut io.Writer, until string) *BlueGreenDeploymentStrategy {
+	if out == nil {
+		out = ioutil.Discard
+	}
+	if errOut == nil {
+		errOut = ioutil.Discard
+	}
+	scaler, _ := kubectl.ScalerFor(kapi.Kind("ReplicationController"), client)
+	return &BlueGreenDeploymentStrategy{
+		out:    out,
+		errOut: errOut,
+		until:  until,
+		getReplicationController: func(namespace, name string) (*kapi.ReplicationController, error) {
+			return client.ReplicationControllers(namespace).Get(name)
+		},
+		getUpdateAcceptor: func(timeout time.Duration) strat.UpdateAcceptor {
+			return stratsupport.NewAcceptNewlyObservedReadyPods(out, client, timeout, AcceptorInterval)
+		},
+		services:     client,
+		routes:       oclient,
+		scaler:       scaler,
+		decoder:      decoder,
+		hookExecutor: stratsupport.NewHookExecutor(client, oclient, executor, os.Stdout, decoder),
+		retryTimeout: 120 * time.Second,
+		retryPeriod:  1 * time.Second,
+	}
+}
+
+// Deploy brings to up to full size and switches the Service, and the Route if
+// there is one, over to it. from is left running.
+func (s *BlueGreenDeploymentStrategy) Deploy(from *kapi.ReplicationController, to *kapi.ReplicationController, desiredReplicas int) error {
+	config, err := deployutil.DecodeDeploymentConfig(to, s.decoder)
+	if err != nil {
+		return fmt.Errorf("couldn't decode config from deployment %s: %v", to.Name, err)
+	}
+
+	params := config.Spec.Strategy.BlueGreenParams
+	retryParams := kubectl.NewRetryParams(s.retryPeriod, s.retryTimeout)
+	waitParams := kubectl.NewRetryParams(s.retryPeriod, s.retryTimeout)
+
+	// Execute any pre-hook.
+	if params.Pre != nil {
+		if err := s.hookExecutor.Execute(params.Pre, from, to, deployapi.PreHookPodSuffix, "pre"); err != nil {
+			return fmt.Errorf("pre hook failed: %s", err)
+		}
+	}
+
+	if s.until == "pre" {
+		return strat.NewConditionReachedErr("pre hook succeeded")
+	}
+
+	// Bring the new deployment up to full size next to the previous one.
+	if desiredReplicas > 0 {
+		fmt.Fprintf(s.out, "--> Scaling %s to %d\n", to.Name, desiredReplicas)
+		updatedTo, err := s.scaleAndWait(to, desiredReplicas, retryParams, waitParams)
+		if err != nil {
+			return fmt.Errorf("couldn't scale %s to %d: %v", to.Name, desiredReplicas, err)
+		}
+		to = updatedTo
+		updateAcceptor := s.getUpdateAcceptor(time.Duration(*params.TimeoutSeconds) * time.Second)
+		if err := updateAcceptor.Accept(to); err != nil {
+			return fmt.Errorf("update acceptor rejected %s: %v", to.Name, err)
+		}
+	}
+
+	if params.Mid != nil {
+		if err := s.hookExecutor.Execute(params.Mid, from, to, deployapi.MidHookPodSuffix, "mid"); err != nil {
+			return fmt.Errorf("mid hook failed: %s", err)
+		}
+	}
+
+	if s.until == "mid" {
+		return strat.NewConditionReachedErr("mid hook succeeded")
+	}
+
+	// Switch the Service, and the Route if there is one, over to the new
+	// deployment.
+	fmt.Fprintf(s.out, "--> Switching service %s over to %s\n", params.ServiceName, to.Name)
+	previous, err := SwitchService(s.services, to.Namespace, params.ServiceName, to)
+	if err != nil {
+		return err
+	}
+	if len(params.RouteName) > 0 {
+		if _, err := PointRoute(s.services, s.routes, to.Namespace, params.RouteName, params.ServiceName, to); err != nil {
+			// Leave the service as it was, the route keeps sending traffic to it.
+			if _, revertErr := switchServiceSelector(s.services, to.Namespace, params.ServiceName, previous); revertErr != nil {
+				fmt.Fprintf(s.errOut, "error: couldn't switch service %s back: %v\n", params.ServiceName, revertErr)
+			}
+			return err
+		}
+	}
+
+	if s.until == "100%" {
+		return strat.NewConditionReachedErr(fmt.Sprintf("Reached %s", s.until))
+	}
+
+	// The deployment config controller keeps the previous deployment around
+	// so that a rollback is instant, and scales it down afterwards.
+	if from != nil {
+		fmt.Fprintf(s.out, "--> Keeping %s at its size for %s\n", from.Name, time.Duration(*params.KeepPreviousSeconds)*time.Second)
+	}
+	if len(params.RouteName) > 0 {
+		s.deleteDeploymentServices(config, from, to)
+	}
+
+	// Execute any post-hook.
+	if params.Post != nil {
+		if err := s.hookExecutor.Execute(params.Post, from, to, deployapi.PostHookPodSuffix, "post"); err != nil {
+			return fmt.Errorf("post hook failed: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// PointRoute makes the named Route send all of its traffic to a Service of
+// deployment, which is created with the ports of the Service serviceName if it
+// doesn't exist yet. It returns the name of the Service the Route pointed at.
+func PointRoute(services kclient.ServicesNamespacer, routes client.RoutesNamespacer, namespace, name, serviceName string, deployment *kapi.ReplicationController) (string, error) {
+	if err := ensureDeploymentService(services, namespace, serviceName, deployment); err != nil {
+		return "", err
+	}
+	route, err := routes.Routes(namespace).Get(name)
+	if err != nil {
+		return "", fmt.Errorf("couldn't get route %s: %v", name, err)
+	}
+	previous := route.Spec.To.Name
+	if previous == deployment.Name {
+		return previous, nil
+	}
+	route.Spec.To = kapi.ObjectReference{Kind: "Service", Name: deployment.Name}
+	if _, err := routes.Routes(namespace).Update(route); err != nil {
+		return "", fmt.Errorf("couldn't point route %s at service %s: %v", name, deployment.Name, err)
+	}
+	return previous, nil
+}
+
+// ensureDeploymentService creates the Service of deployment, named after it and
+// selecting only its pods, with the ports of the Service serviceName.
+func ensureDeploymentService(client kclient.ServicesNamespacer, namespace, serviceName string, deployment *kapi.ReplicationController) error {
+	if _, err := client.Services(namespace).Get(deployment.Name); err == nil {
+		return nil
+	} else if !kerrors.IsNotFound(err) {
+		return fmt.Errorf("couldn't get service %s: %v", deployment.Name, err)
+	}
+	service, err := client.Services(namespace).Get(serviceName)
+	if err != nil {
+		return fmt.Errorf("couldn't get service %s: %v", serviceName, err)
+	}
+	ports := []kapi.ServicePort{}
+	for _, port := range service.Spec.Ports {
+		port.NodePort = 0
+		ports = append(ports, port)
+	}
+	selector := map[string]string{}
+	for k, v := range deployment.Spec.Selector {
+		selector[k] = v
+	}
+	deploymentService := &kapi.Service{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: deployment.Name,
+			Labels: map[string]string{
+				deployapi.DeploymentConfigLabel: deployutil.DeploymentConfigNameFor(deployment),
+				deployapi.DeploymentLabel:       deployment.Name,
+			},
+		},
+		Spec: kapi.ServiceSpec{
+			Selector: selector,
+			Ports:    ports,
+		},
+	}
+	if _, err := client.Services(namespace).Create(deploymentService); err != nil && !kerrors.IsAlreadyExists(err) {
+		return fmt.Errorf("couldn't create service %s: %v", deployment.Name, err)
+	}
+	return nil
+}
+
+// deleteDeploymentServices deletes the Services of the deployments of config
+// other than from and to, which the Route no longer points at.
+func (s *BlueGreenDeploymentStrategy) deleteDeploymentServices(config *deployapi.DeploymentConfig, from, to *kapi.ReplicationController) {
+	keep := sets.NewString(to.Name)
+	if from != nil {
+		keep.Insert(from.Name)
+	}
+	selector := labels.Set{deployapi.DeploymentConfigLabel: config.Name}.AsSelector()
+	services, err := s.services.Services(to.Namespace).List(kapi.ListOptions{LabelSelector: selector})
+	if err != nil {
+		fmt.Fprintf(s.errOut, "error: couldn't list the services of %s: %v\n", config.Name, err)
+		return
+	}
+	for _, service := range services.Items {
+		if keep.Has(service.Name) || service.Labels[deployapi.DeploymentLabel] != service.Name {
+			continue
+		}
+		if err := s.services.Services(to.Namespace).Delete(service.Name); err != nil && !kerrors.IsNotFound(err) {
+			fmt.Fprintf(s.errOut, "error: couldn't delete service %s: %v\n", service.Name, err)
+		}
+	}
+}
+
+// SwitchService replaces the selector of the named Service with the selector
+// of deployment, so that the Service only sends traffic to the pods of that
+// deployment. It returns the previous selector of the Service.
+func SwitchService(client kclient.ServicesNamespacer, namespace, name string, deployment *kapi.ReplicationController) (map[string]string, error) {
+	selector := map[string]string{}
+	for k, v := range deployment.Spec.Selector {
+		selector[k] = v
+	}
+	return switchServiceSelector(client, namespace, name, selector)
+}
+
+func switchServiceSelector(client kclient.ServicesNamespacer, namespace, name string, selector map[string]string) (map[string]string, error) {
+	service, err := client.Services(namespace).Get(name)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't get service %s: %v", name, err)
+	}
+	previous := service.Spec.Selector
+	if reflect.DeepEqual(previous, selector) {
+		return previous, nil
+	}
+	service.Spec.Selector = selector
+	if _, err := client.Services(namespace).Update(service); err != nil {
+		return nil, fmt.Errorf("couldn't switch service %s: %v", name, err)
+	}
+	return previous, nil
+}
+
+func (s *BlueGreenDeploymentStrategy) scaleAndWait(deployment *kapi.ReplicationController, replicas int, retry *kubectl.RetryParams, wait *kubectl.RetryParams) (*kapi.ReplicationController, error) {
+	if int32(replicas) == deployment.Spec.Replicas && int32(replicas) == deployment.Status.Replicas {
+		return deployment, nil
+	}
+	if err := s.scaler.Scale(deployment.Namespace, deployment.Name, uint(replicas), &kubectl.ScalePrecondition{Size: -1, ResourceVersion: ""}, retry, wait); err != nil {
+		return nil, err
+	}
+	updatedDeployment, err := s.getReplicationController(deployment.Namespace, deployment.Name)
+	if err != nil {
+		return nil, err
+	}
+	return updatedDeployment, nil
+}
+
+// hookExecutor knows how to execute a deployment lifecycle hook.
+type hookExecutor interface {
+	Execute(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error
+}
+
+// hookExecutorImpl is a pluggable hookExecutor.
+type hookExecutorImpl struct {
+	executeFunc func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error
+}
+
+// Execute executes the provided lifecycle hook
+func (i *hookExecutorImpl) Execute(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
+	return i.executeFunc(hook, from, deployment, suffix, label)
+}
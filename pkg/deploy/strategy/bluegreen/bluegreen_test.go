@@ -0,0 +1,314 @@
+package bluegreen
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deploytest "github.com/openshift/origin/pkg/deploy/api/test"
+	scalertest "github.com/openshift/origin/pkg/deploy/scaler/test"
+	strat "github.com/openshift/origin/pkg/deploy/strategy"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+	routeapi "github.com/openshift/origin/pkg/route/api"
+
+	_ "github.com/openshift/origin/pkg/api/install"
+)
+
+func TestBlueGreen_switchesService(t *testing.T) {
+	config := deploytest.OkBlueGreenStrategy()
+	config.BlueGreenParams.Mid = &deployapi.LifecycleHook{
+		FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
+		ExecNewPod:    &deployapi.ExecNewPodHook{Command: []string{"/bin/true"}, ContainerName: "container1"},
+	}
+	from, to := blueGreenDeployments(t, config)
+	scaler := &scalertest.FakeScaler{}
+	kc := fakeServices(serviceFor(from))
+	strategy := newTestStrategy(to, scaler, kc, fakeRoutes(nil))
+	var hooks []string
+	strategy.hookExecutor = &hookExecutorImpl{
+		executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
+			hooks = append(hooks, label)
+			if label == "mid" && !reflect.DeepEqual(getService(t, kc).Spec.Selector, from.Spec.Selector) {
+				t.Errorf("expected the service to be switched after the mid hook")
+			}
+			return nil
+		},
+	}
+
+	if err := strategy.Deploy(from, to, 3); err != nil {
+		t.Fatalf("unexpected deploy error: %v", err)
+	}
+	if e, a := to.Spec.Selector, getService(t, kc).Spec.Selector; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the service selector %v, got %v", e, a)
+	}
+	if e, a := []string{"mid"}, hooks; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected hooks %v, got %v", e, a)
+	}
+	// the previous deployment is left for the deployment config controller
+	expected := []scalertest.ScaleEvent{{Name: to.Name, Size: 3}}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+}
+
+func TestBlueGreen_pointsRoute(t *testing.T) {
+	config := deploytest.OkBlueGreenStrategy()
+	config.BlueGreenParams.RouteName = "www"
+	from, to := blueGreenDeployments(t, config)
+	service := serviceFor(from)
+	service.Spec.Ports = []kapi.ServicePort{{Name: "http", Port: 80, NodePort: 30080}}
+	stale := &kapi.Service{ObjectMeta: kapi.ObjectMeta{
+		Name:      "config-0",
+		Namespace: to.Namespace,
+		Labels:    map[string]string{deployapi.DeploymentConfigLabel: "config", deployapi.DeploymentLabel: "config-0"},
+	}}
+	kc := fakeServices(service, stale)
+	oc := fakeRoutes(&routeapi.Route{
+		ObjectMeta: kapi.ObjectMeta{Name: "www", Namespace: to.Namespace},
+		Spec:       routeapi.RouteSpec{To: kapi.ObjectReference{Kind: "Service", Name: "frontend"}},
+	})
+	strategy := newTestStrategy(to, &scalertest.FakeScaler{}, kc, oc)
+
+	if err := strategy.Deploy(from, to, 3); err != nil {
+		t.Fatalf("unexpected deploy error: %v", err)
+	}
+	route, err := oc.Routes(to.Namespace).Get("www")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if route.Spec.To.Name != to.Name {
+		t.Errorf("expected the route to point at %s, got %s", to.Name, route.Spec.To.Name)
+	}
+	deploymentService, err := kc.Services(to.Namespace).Get(to.Name)
+	if err != nil {
+		t.Fatalf("expected a service for %s: %v", to.Name, err)
+	}
+	if e, a := to.Spec.Selector, deploymentService.Spec.Selector; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the service selector %v, got %v", e, a)
+	}
+	if e, a := []kapi.ServicePort{{Name: "http", Port: 80}}, deploymentService.Spec.Ports; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the service ports %v, got %v", e, a)
+	}
+	if _, err := kc.Services(to.Namespace).Get(stale.Name); !kerrors.IsNotFound(err) {
+		t.Errorf("expected the service of an older deployment to be deleted, got %v", err)
+	}
+
+	// a rollback points the route back at the service of the previous deployment
+	previous, err := PointRoute(kc, oc, from.Namespace, "www", "frontend", from)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if previous != to.Name {
+		t.Errorf("expected the route to have pointed at %s, got %s", to.Name, previous)
+	}
+	if route, _ := oc.Routes(to.Namespace).Get("www"); route.Spec.To.Name != from.Name {
+		t.Errorf("expected the route to point at %s, got %s", from.Name, route.Spec.To.Name)
+	}
+}
+
+func TestBlueGreen_routeFailureSwitchesServiceBack(t *testing.T) {
+	config := deploytest.OkBlueGreenStrategy()
+	config.BlueGreenParams.RouteName = "missing"
+	from, to := blueGreenDeployments(t, config)
+	scaler := &scalertest.FakeScaler{}
+	kc := fakeServices(serviceFor(from))
+	strategy := newTestStrategy(to, scaler, kc, fakeRoutes(nil))
+
+	if err := strategy.Deploy(from, to, 3); err == nil {
+		t.Fatalf("expected a deploy error")
+	}
+	if e, a := from.Spec.Selector, getService(t, kc).Spec.Selector; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the service selector to be switched back to %v, got %v", e, a)
+	}
+	for _, event := range scaler.Events {
+		if event.Name == from.Name {
+			t.Errorf("unexpected scale of %s: %v", from.Name, event)
+		}
+	}
+}
+
+func TestBlueGreen_untilMid(t *testing.T) {
+	from, to := blueGreenDeployments(t, deploytest.OkBlueGreenStrategy())
+	kc := fakeServices(serviceFor(from))
+	strategy := newTestStrategy(to, &scalertest.FakeScaler{}, kc, fakeRoutes(nil))
+	strategy.until = "mid"
+
+	err := strategy.Deploy(from, to, 3)
+	if !strat.IsConditionReached(err) {
+		t.Fatalf("expected the condition to be reached, got %v", err)
+	}
+	if e, a := from.Spec.Selector, getService(t, kc).Spec.Selector; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the service not to be switched, got %v", a)
+	}
+}
+
+func TestSwitchService(t *testing.T) {
+	from, to := blueGreenDeployments(t, deploytest.OkBlueGreenStrategy())
+	kc := fakeServices(serviceFor(to))
+
+	previous, err := SwitchService(kc, from.Namespace, "frontend", from)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(previous, to.Spec.Selector) {
+		t.Errorf("expected the previous selector %v, got %v", to.Spec.Selector, previous)
+	}
+	if e, a := from.Spec.Selector, getService(t, kc).Spec.Selector; !reflect.DeepEqual(e, a) {
+		t.Errorf("expected the service selector %v, got %v", e, a)
+	}
+	if _, err := SwitchService(kc, from.Namespace, "missing", from); err == nil {
+		t.Errorf("expected an error for a missing service")
+	}
+}
+
+func blueGreenDeployments(t *testing.T, s deployapi.DeploymentStrategy) (*kapi.ReplicationController, *kapi.ReplicationController) {
+	oldConfig := deploytest.OkDeploymentConfig(1)
+	oldConfig.Spec.Strategy = s
+	from, err := deployutil.MakeDeployment(oldConfig, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from.Spec.Replicas, from.Status.Replicas = 3, 3
+	config := deploytest.OkDeploymentConfig(2)
+	config.Spec.Strategy = s
+	to, err := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return from, to
+}
+
+func serviceFor(deployment *kapi.ReplicationController) *kapi.Service {
+	selector := map[string]string{}
+	for k, v := range deployment.Spec.Selector {
+		selector[k] = v
+	}
+	return &kapi.Service{
+		ObjectMeta: kapi.ObjectMeta{Name: "frontend", Namespace: deployment.Namespace},
+		Spec:       kapi.ServiceSpec{Selector: selector},
+	}
+}
+
+// fakeServices returns a client which keeps the changes to services.
+func fakeServices(services ...*kapi.Service) *ktestclient.Fake {
+	byName := map[string]*kapi.Service{}
+	for _, service := range services {
+		byName[service.Name] = service
+	}
+	kc := &ktestclient.Fake{}
+	kc.AddReactor("get", "services", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		name := action.(ktestclient.GetAction).GetName()
+		service, ok := byName[name]
+		if !ok {
+			return true, nil, kerrors.NewNotFound(kapi.Resource("services"), name)
+		}
+		copied, err := kapi.Scheme.DeepCopy(service)
+		return true, copied.(*kapi.Service), err
+	})
+	kc.AddReactor("list", "services", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		selector := action.(ktestclient.ListAction).GetListRestrictions().Labels
+		list := &kapi.ServiceList{}
+		for _, service := range byName {
+			if selector.Matches(labels.Set(service.Labels)) {
+				list.Items = append(list.Items, *service)
+			}
+		}
+		return true, list, nil
+	})
+	kc.AddReactor("create", "services", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		service := action.(ktestclient.CreateAction).GetObject().(*kapi.Service)
+		byName[service.Name] = service
+		return true, service, nil
+	})
+	kc.AddReactor("update", "services", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		service := action.(ktestclient.UpdateAction).GetObject().(*kapi.Service)
+		byName[service.Name] = service
+		return true, service, nil
+	})
+	kc.AddReactor("delete", "services", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		delete(byName, action.(ktestclient.DeleteAction).GetName())
+		return true, nil, nil
+	})
+	return kc
+}
+
+// fakeRoutes returns a client which keeps the updates of route, if any.
+func fakeRoutes(route *routeapi.Route) *testclient.Fake {
+	oc := &testclient.Fake{}
+	oc.AddReactor("get", "routes", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		name := action.(ktestclient.GetAction).GetName()
+		if route == nil || name != route.Name {
+			return true, nil, kerrors.NewNotFound(routeapi.Resource("routes"), name)
+		}
+		copied, err := kapi.Scheme.DeepCopy(route)
+		return true, copied.(*routeapi.Route), err
+	})
+	oc.AddReactor("update", "routes", func(action ktestclient.Action) (handled bool, ret runtime.Object, err error) {
+		route = action.(ktestclient.UpdateAction).GetObject().(*routeapi.Route)
+		return true, route, nil
+	})
+	return oc
+}
+
+func getService(t *testing.T, kc *ktestclient.Fake) *kapi.Service {
+	service, err := kc.Services(kapi.NamespaceDefault).Get("frontend")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return service
+}
+
+func newTestStrategy(deployment *kapi.ReplicationController, scaler *scalertest.FakeScaler, kc *ktestclient.Fake, oc *testclient.Fake) *BlueGreenDeploymentStrategy {
+	return &BlueGreenDeploymentStrategy{
+		out:          &bytes.Buffer{},
+		errOut:       &bytes.Buffer{},
+		decoder:      kapi.Codecs.UniversalDecoder(),
+		retryTimeout: 1 * time.Second,
+		retryPeriod:  1 * time.Millisecond,
+		getReplicationController: func(namespace, name string) (*kapi.ReplicationController, error) {
+			// reflect the last scale of the deployment
+			updated := *deployment
+			for _, event := range scaler.Events {
+				if event.Name == name {
+					updated.Spec.Replicas, updated.Status.Replicas = int32(event.Size), int32(event.Size)
+				}
+			}
+			return &updated, nil
+		},
+		getUpdateAcceptor: getUpdateAcceptor,
+		services:          kc,
+		routes:            oc,
+		scaler:            scaler,
+		hookExecutor: &hookExecutorImpl{
+			executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
+				return nil
+			},
+		},
+	}
+}
+
+func getUpdateAcceptor(timeout time.Duration) strat.UpdateAcceptor {
+	return &testAcceptor{
+		acceptFn: func(deployment *kapi.ReplicationController) error {
+			return nil
+		},
+	}
+}
+
+type testAcceptor struct {
+	acceptFn func(*kapi.ReplicationController) error
+}
+
+func (t *testAcceptor) Accept(deployment *kapi.ReplicationController) error {
+	return t.acceptFn(deployment)
+}
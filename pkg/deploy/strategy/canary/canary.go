@@ -0,0 +1,367 @@
+package canary
+
+import (
+	"crypto/tls"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/kubectl"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/probe"
+	httprobe "k8s.io/kubernetes/pkg/probe/http"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/intstr"
+	utilnet "k8s.io/kubernetes/pkg/util/net"
+
+	"github.com/openshift/origin/pkg/client"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	strat "github.com/openshift/origin/pkg/deploy/strategy"
+	stratsupport "github.com/openshift/origin/pkg/deploy/strategy/support"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+)
+
+// CanaryDeploymentStrategy is a Strategy which first rolls out a fraction of
+// the new deployment next to the previous one. Once the canary pods have run
+// for the bake period, their health is checked. A healthy canary is scaled up
+// to the desired replica count and the previous deployment is scaled down to
+// zero. An unhealthy canary is scaled back to zero, leaving the previous
+// deployment untouched, and the deployment fails.
+type CanaryDeploymentStrategy struct {
+	// out and errOut control where output is sent during the strategy
+	out, errOut io.Writer
+	// until is a condition that, if reached, will cause the strategy to exit early
+	until string
+	// getReplicationController knows how to get a replication controller.
+	getReplicationController func(namespace, name string) (*kapi.ReplicationController, error)
+	// getUpdateAcceptor returns an UpdateAcceptor to verify the pods of the
+	// deployment become ready.
+	getUpdateAcceptor func(timeout time.Duration) strat.UpdateAcceptor
+	// listPods returns the pods of a deployment.
+	listPods func(deployment *kapi.ReplicationController) ([]kapi.Pod, error)
+	// probe performs an HTTP health check.
+	probe func(url *url.URL, headers http.Header, timeout time.Duration) (probe.Result, string, error)
+	// sleep waits for the bake period.
+	sleep func(time.Duration)
+	// scaler is used to scale replication controllers.
+	scaler kubectl.Scaler
+	// decoder is used to decode DeploymentConfigs contained in deployments.
+	decoder runtime.Decoder
+	// hookExecutor can execute a lifecycle hook.
+	hookExecutor hookExecutor
+	// retryTimeout is how long to wait for the replica count update to succeed
+	// before giving up.
+	retryTimeout time.Duration
+	// retryPeriod is how often to try updating the replica count.
+	retryPeriod time.Duration
+}
+
+// AcceptorInterval is how often the UpdateAcceptor should check for
+// readiness.
+const AcceptorInterval = 1 * time.Second
+
+// ProbeTimeout is how long an HTTP health gate waits for each canary pod to
+// respond.
+const ProbeTimeout = 10 * time.Second
+
+// NewCanaryDeploymentStrategy makes a CanaryDeploymentStrategy backed by a
+// real HookExecutor and client.
+func NewCanaryDeploymentStrategy(client kclient.Interface, tagClient client.ImageStreamTagsNamespacer, executor stratsupport.PodExecutor, decoder runtime.Decoder, out, errOut io.Writer, until string) *CanaryDeploymentStrategy {
+	if out == nil {
+		out = ioutil.Discard
+	}
+	if errOut == nil {
+		errOut = ioutil.Discard
+	}
+	scaler, _ := kubectl.ScalerFor(kapi.Kind("ReplicationController"), client)
+	return &CanaryDeploymentStrategy{
+		out:    out,
+		errOut: errOut,
+		until:  until,
+		getReplicationController: func(namespace, name string) (*kapi.ReplicationController, error) {
+			return client.ReplicationControllers(namespace).Get(name)
+		},
+		getUpdateAcceptor: func(timeout time.Duration) strat.UpdateAcceptor {
+			return stratsupport.NewAcceptNewlyObservedReadyPods(out, client, timeout, AcceptorInterval)
+		},
+		listPods: func(deployment *kapi.ReplicationController) ([]kapi.Pod, error) {
+			selector := labels.Set(deployment.Spec.Selector).AsSelector()
+			list, err := client.Pods(deployment.Namespace).List(kapi.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		},
+		probe:        probeHTTP,
+		sleep:        time.Sleep,
+		scaler:       scaler,
+		decoder:      decoder,
+		hookExecutor: stratsupport.NewHookExecutor(client, tagClient, executor, os.Stdout, decoder),
+		retryTimeout: 120 * time.Second,
+		retryPeriod:  1 * time.Second,
+	}
+}
+
+// Deploy rolls out a canary of to, checks its health and then either
+// completes the deployment or scales the canary back and fails.
+func (s *CanaryDeploymentStrategy) Deploy(from *kapi.ReplicationController, to *kapi.ReplicationController, desiredReplicas int) error {
+	config, err := deployutil.DecodeDeploymentConfig(to, s.decoder)
+	if err != nil {
+		return fmt.Errorf("couldn't decode config from deployment %s: %v", to.Name, err)
+	}
+
+	params := config.Spec.Strategy.CanaryParams
+	retryParams := kubectl.NewRetryParams(s.retryPeriod, s.retryTimeout)
+	waitParams := kubectl.NewRetryParams(s.retryPeriod, s.retryTimeout)
+	updateAcceptor := s.getUpdateAcceptor(time.Duration(*params.TimeoutSeconds) * time.Second)
+
+	// Execute any pre-hook.
+	if params.Pre != nil {
+		if err := s.hookExecutor.Execute(params.Pre, from, to, deployapi.PreHookPodSuffix, "pre"); err != nil {
+			return fmt.Errorf("pre hook failed: %s", err)
+		}
+	}
+
+	if s.until == "pre" {
+		return strat.NewConditionReachedErr("pre hook succeeded")
+	}
+
+	if desiredReplicas > 0 {
+		canaryReplicas := CanaryReplicas(desiredReplicas, *params.Percent)
+		fmt.Fprintf(s.out, "--> Scaling %s to %d canary replicas\n", to.Name, canaryReplicas)
+		updatedTo, err := s.scaleAndWait(to, canaryReplicas, retryParams, waitParams)
+		if err != nil {
+			return fmt.Errorf("couldn't scale %s to %d: %v", to.Name, canaryReplicas, err)
+		}
+		to = updatedTo
+		if err := updateAcceptor.Accept(to); err != nil {
+			return s.abort(to, fmt.Errorf("update acceptor rejected %s: %v", to.Name, err))
+		}
+
+		if strat.PercentageBetween(s.until, 1, 99) {
+			return strat.NewConditionReachedErr(fmt.Sprintf("Reached %s (canary running)", s.until))
+		}
+
+		if bake := time.Duration(*params.BakeSeconds) * time.Second; bake > 0 {
+			fmt.Fprintf(s.out, "--> Waiting %s before checking the health of the canary\n", bake)
+			s.sleep(bake)
+		}
+		if err := s.checkHealth(params.HealthGate, to); err != nil {
+			return s.abort(to, fmt.Errorf("canary of %s is unhealthy: %v", to.Name, err))
+		}
+		fmt.Fprintf(s.out, "--> Canary of %s is healthy, completing the rollout\n", to.Name)
+
+		if to.Spec.Replicas != int32(desiredReplicas) {
+			fmt.Fprintf(s.out, "--> Scaling %s to %d\n", to.Name, desiredReplicas)
+			updatedTo, err := s.scaleAndWait(to, desiredReplicas, retryParams, waitParams)
+			if err != nil {
+				return fmt.Errorf("couldn't scale %s to %d: %v", to.Name, desiredReplicas, err)
+			}
+			to = updatedTo
+			if err := updateAcceptor.Accept(to); err != nil {
+				return fmt.Errorf("update acceptor rejected %s: %v", to.Name, err)
+			}
+		}
+	}
+
+	// Scale down the from deployment.
+	if from != nil {
+		fmt.Fprintf(s.out, "--> Scaling %s down to zero\n", from.Name)
+		if _, err := s.scaleAndWait(from, 0, retryParams, waitParams); err != nil {
+			return fmt.Errorf("couldn't scale %s to 0: %v", from.Name, err)
+		}
+	}
+
+	if s.until == "100%" {
+		return strat.NewConditionReachedErr(fmt.Sprintf("Reached %s", s.until))
+	}
+
+	// Execute any post-hook.
+	if params.Post != nil {
+		if err := s.hookExecutor.Execute(params.Post, from, to, deployapi.PostHookPodSuffix, "post"); err != nil {
+			return fmt.Errorf("post hook failed: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// abort scales the canary back to zero and returns the reason the deployment
+// failed.
+func (s *CanaryDeploymentStrategy) abort(to *kapi.ReplicationController, reason error) error {
+	fmt.Fprintf(s.out, "--> %v\n", reason)
+	fmt.Fprintf(s.out, "--> Scaling %s back to zero\n", to.Name)
+	retryParams := kubectl.NewRetryParams(s.retryPeriod, s.retryTimeout)
+	if _, err := s.scaleAndWait(to, 0, retryParams, retryParams); err != nil {
+		return fmt.Errorf("%v; couldn't scale %s back to 0: %v", reason, to.Name, err)
+	}
+	return reason
+}
+
+// checkHealth returns an error if the canary pods are no longer ready or fail
+// the health gate.
+func (s *CanaryDeploymentStrategy) checkHealth(gate *deployapi.CanaryHealthGate, to *kapi.ReplicationController) error {
+	pods, err := s.listPods(to)
+	if err != nil {
+		return fmt.Errorf("couldn't list the canary pods: %v", err)
+	}
+	ready := []kapi.Pod{}
+	for _, pod := range pods {
+		if !kapi.IsPodReady(&pod) {
+			return fmt.Errorf("pod %s is not ready", pod.Name)
+		}
+		ready = append(ready, pod)
+	}
+	if len(ready) == 0 {
+		return fmt.Errorf("no canary pods are running")
+	}
+
+	switch {
+	case gate == nil:
+		return nil
+	case gate.ExecNewPod != nil:
+		hook := &deployapi.LifecycleHook{
+			FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
+			ExecNewPod:    gate.ExecNewPod,
+		}
+		return s.hookExecutor.Execute(hook, nil, to, deployapi.CanaryHookPodSuffix, "canary")
+	case gate.HTTPGet != nil:
+		for i := range ready {
+			if err := s.probePod(gate.HTTPGet, &ready[i]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// probePod performs the HTTP health check against a canary pod.
+func (s *CanaryDeploymentStrategy) probePod(action *kapi.HTTPGetAction, pod *kapi.Pod) error {
+	port, err := resolvePort(action.Port, pod)
+	if err != nil {
+		return fmt.Errorf("couldn't probe pod %s: %v", pod.Name, err)
+	}
+	// only the pod is probed, at its IP, whatever host the action names
+	host := pod.Status.PodIP
+	if len(host) == 0 {
+		return fmt.Errorf("couldn't probe pod %s: the pod has no IP", pod.Name)
+	}
+	scheme := strings.ToLower(string(action.Scheme))
+	if len(scheme) == 0 {
+		scheme = "http"
+	}
+	path := action.Path
+	if len(path) == 0 {
+		path = "/"
+	}
+	u := &url.URL{Scheme: scheme, Host: net.JoinHostPort(host, strconv.Itoa(port))}
+	if parsed, err := url.Parse(path); err == nil {
+		u.Path, u.RawQuery = parsed.Path, parsed.RawQuery
+	} else {
+		u.Path = path
+	}
+	headers := http.Header{}
+	for _, header := range action.HTTPHeaders {
+		headers.Add(header.Name, header.Value)
+	}
+
+	result, output, err := s.probe(u, headers, ProbeTimeout)
+	if err != nil {
+		return fmt.Errorf("probe of pod %s at %s failed: %v", pod.Name, u, err)
+	}
+	if result != probe.Success {
+		return fmt.Errorf("probe of pod %s at %s failed: %s", pod.Name, u, output)
+	}
+	return nil
+}
+
+// probeTransport is the transport of the HTTP health checks of canary pods.
+var probeTransport = utilnet.SetTransportDefaults(&http.Transport{
+	TLSClientConfig:   &tls.Config{InsecureSkipVerify: true},
+	DisableKeepAlives: true,
+})
+
+// probeHTTP performs an HTTP health check at u. Unlike the probes of the
+// kubelet, it doesn't follow redirects to other hosts than the one of u.
+func probeHTTP(u *url.URL, headers http.Header, timeout time.Duration) (probe.Result, string, error) {
+	client := &http.Client{
+		Timeout:   timeout,
+		Transport: probeTransport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if req.URL.Host != u.Host {
+				return fmt.Errorf("refusing to follow the redirect to %s, only the pod is probed", req.URL.Host)
+			}
+			if len(via) >= 10 {
+				return fmt.Errorf("stopped after %d redirects", len(via))
+			}
+			return nil
+		},
+	}
+	return httprobe.DoHTTPProbe(u, headers, client)
+}
+
+// resolvePort returns the number of a port of the pod, which may be named.
+func resolvePort(port intstr.IntOrString, pod *kapi.Pod) (int, error) {
+	if port.Type == intstr.Int {
+		return port.IntValue(), nil
+	}
+	for _, container := range pod.Spec.Containers {
+		for _, p := range container.Ports {
+			if p.Name == port.StrVal {
+				return int(p.ContainerPort), nil
+			}
+		}
+	}
+	return 0, fmt.Errorf("no container port is named %q", port.StrVal)
+}
+
+// CanaryReplicas returns the number of replicas of a canary which is percent
+// of the desired replicas, rounded up.
+func CanaryReplicas(desiredReplicas int, percent int32) int {
+	replicas := (desiredReplicas*int(percent) + 99) / 100
+	if replicas < 1 {
+		replicas = 1
+	}
+	if replicas > desiredReplicas {
+		replicas = desiredReplicas
+	}
+	return replicas
+}
+
+func (s *CanaryDeploymentStrategy) scaleAndWait(deployment *kapi.ReplicationController, replicas int, retry *kubectl.RetryParams, wait *kubectl.RetryParams) (*kapi.ReplicationController, error) {
+	if int32(replicas) == deployment.Spec.Replicas && int32(replicas) == deployment.Status.Replicas {
+		return deployment, nil
+	}
+	if err := s.scaler.Scale(deployment.Namespace, deployment.Name, uint(replicas), &kubectl.ScalePrecondition{Size: -1, ResourceVersion: ""}, retry, wait); err != nil {
+		return nil, err
+	}
+	updatedDeployment, err := s.getReplicationController(deployment.Namespace, deployment.Name)
+	if err != nil {
+		return nil, err
+	}
+	return updatedDeployment, nil
+}
+
+// hookExecutor knows how to execute a deployment lifecycle hook.
+type hookExecutor interface {
+	Execute(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error
+}
+
+// hookExecutorImpl is a pluggable hookExecutor.
+type hookExecutorImpl struct {
+	executeFunc func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error
+}
+
+// Execute executes the provided lifecycle hook
+func (i *hookExecutorImpl) Execute(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
+	return i.executeFunc(hook, from, deployment, suffix, label)
+}
@@ -0,0 +1,279 @@
+package canary
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/probe"
+	"k8s.io/kubernetes/pkg/util/intstr"
+
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deploytest "github.com/openshift/origin/pkg/deploy/api/test"
+	scalertest "github.com/openshift/origin/pkg/deploy/scaler/test"
+	strat "github.com/openshift/origin/pkg/deploy/strategy"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+
+	_ "github.com/openshift/origin/pkg/api/install"
+)
+
+func TestCanaryReplicas(t *testing.T) {
+	tests := []struct {
+		desired  int
+		percent  int32
+		expected int
+	}{
+		{desired: 10, percent: 10, expected: 1},
+		{desired: 10, percent: 25, expected: 3},
+		{desired: 3, percent: 10, expected: 1},
+		{desired: 1, percent: 50, expected: 1},
+		{desired: 4, percent: 100, expected: 4},
+	}
+	for _, test := range tests {
+		if got := CanaryReplicas(test.desired, test.percent); got != test.expected {
+			t.Errorf("expected %d%% of %d to be %d canary replicas, got %d", test.percent, test.desired, test.expected, got)
+		}
+	}
+}
+
+func TestCanary_promoted(t *testing.T) {
+	from, to := canaryDeployments(t, deploytest.OkCanaryStrategy())
+	scaler := &scalertest.FakeScaler{}
+	strategy := newTestStrategy(to, scaler, readyPods(2))
+	var probed []string
+	strategy.probe = func(u *url.URL, headers http.Header, timeout time.Duration) (probe.Result, string, error) {
+		probed = append(probed, u.String())
+		return probe.Success, "", nil
+	}
+	var baked time.Duration
+	strategy.sleep = func(d time.Duration) { baked = d }
+
+	if err := strategy.Deploy(from, to, 10); err != nil {
+		t.Fatalf("unexpected deploy error: %v", err)
+	}
+	if baked != 60*time.Second {
+		t.Errorf("expected to bake for 60s, got %s", baked)
+	}
+	if e, a := []string{"http://10.0.0.0:8080/healthz", "http://10.0.0.1:8080/healthz"}, probed; fmt.Sprint(e) != fmt.Sprint(a) {
+		t.Errorf("expected probes of %v, got %v", e, a)
+	}
+	expected := []scalertest.ScaleEvent{{Name: to.Name, Size: 2}, {Name: to.Name, Size: 10}, {Name: from.Name, Size: 0}}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+}
+
+func TestCanary_abortedOnProbeFailure(t *testing.T) {
+	from, to := canaryDeployments(t, deploytest.OkCanaryStrategy())
+	scaler := &scalertest.FakeScaler{}
+	strategy := newTestStrategy(to, scaler, readyPods(2))
+	strategy.probe = func(u *url.URL, headers http.Header, timeout time.Duration) (probe.Result, string, error) {
+		return probe.Failure, "HTTP probe failed with statuscode: 500", nil
+	}
+
+	err := strategy.Deploy(from, to, 10)
+	if err == nil {
+		t.Fatalf("expected a deploy error")
+	}
+	t.Logf("got expected error: %v", err)
+	expected := []scalertest.ScaleEvent{{Name: to.Name, Size: 2}, {Name: to.Name, Size: 0}}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+}
+
+func TestCanary_abortedOnUnreadyPod(t *testing.T) {
+	from, to := canaryDeployments(t, deploytest.OkCanaryStrategy())
+	scaler := &scalertest.FakeScaler{}
+	pods := readyPods(2)
+	pods[1].Status.Conditions[0].Status = kapi.ConditionFalse
+	strategy := newTestStrategy(to, scaler, pods)
+	strategy.probe = func(u *url.URL, headers http.Header, timeout time.Duration) (probe.Result, string, error) {
+		t.Errorf("unexpected probe of %s", u)
+		return probe.Success, "", nil
+	}
+
+	if err := strategy.Deploy(from, to, 10); err == nil {
+		t.Fatalf("expected a deploy error")
+	}
+	expected := []scalertest.ScaleEvent{{Name: to.Name, Size: 2}, {Name: to.Name, Size: 0}}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+}
+
+func TestCanary_execHealthGate(t *testing.T) {
+	for _, healthy := range []bool{true, false} {
+		config := deploytest.OkCanaryStrategy()
+		config.CanaryParams.HealthGate = &deployapi.CanaryHealthGate{
+			ExecNewPod: &deployapi.ExecNewPodHook{Command: []string{"/bin/check"}, ContainerName: "container1"},
+		}
+		from, to := canaryDeployments(t, config)
+		scaler := &scalertest.FakeScaler{}
+		strategy := newTestStrategy(to, scaler, readyPods(1))
+		var suffix string
+		strategy.hookExecutor = &hookExecutorImpl{
+			executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, s, label string) error {
+				suffix = s
+				if !healthy {
+					return fmt.Errorf("exit code 1")
+				}
+				return nil
+			},
+		}
+
+		err := strategy.Deploy(from, to, 3)
+		if suffix != deployapi.CanaryHookPodSuffix {
+			t.Errorf("expected the health gate to run as a hook, got suffix %q", suffix)
+		}
+		if healthy && err != nil {
+			t.Errorf("unexpected deploy error: %v", err)
+		}
+		if !healthy && err == nil {
+			t.Errorf("expected a deploy error")
+		}
+		last := scaler.Events[len(scaler.Events)-1]
+		if healthy && (last.Name != from.Name || last.Size != 0) {
+			t.Errorf("expected %s to be scaled down, got %v", from.Name, scaler.Events)
+		}
+		if !healthy && (last.Name != to.Name || last.Size != 0) {
+			t.Errorf("expected %s to be scaled back, got %v", to.Name, scaler.Events)
+		}
+	}
+}
+
+func TestCanary_untilCanary(t *testing.T) {
+	from, to := canaryDeployments(t, deploytest.OkCanaryStrategy())
+	scaler := &scalertest.FakeScaler{}
+	strategy := newTestStrategy(to, scaler, readyPods(2))
+	strategy.until = "20%"
+
+	err := strategy.Deploy(from, to, 10)
+	if !strat.IsConditionReached(err) {
+		t.Fatalf("expected the condition to be reached, got %v", err)
+	}
+	if len(scaler.Events) != 1 {
+		t.Errorf("expected only the canary to be scaled, got %v", scaler.Events)
+	}
+}
+
+func TestProbeHTTP_redirects(t *testing.T) {
+	other := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request to another host: %s", r.URL)
+	}))
+	defer other.Close()
+	pod := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/healthz":
+			http.Redirect(w, r, "/ready", http.StatusFound)
+		case "/ready":
+			w.WriteHeader(http.StatusOK)
+		default:
+			http.Redirect(w, r, other.URL+"/healthz", http.StatusFound)
+		}
+	}))
+	defer pod.Close()
+
+	u, _ := url.Parse(pod.URL + "/healthz")
+	if result, output, err := probeHTTP(u, nil, time.Second); err != nil || result != probe.Success {
+		t.Errorf("expected a redirect on the pod to be followed, got %s (%s): %v", result, output, err)
+	}
+	u, _ = url.Parse(pod.URL + "/elsewhere")
+	if result, _, _ := probeHTTP(u, nil, time.Second); result == probe.Success {
+		t.Errorf("expected a redirect to another host to fail the probe")
+	}
+}
+
+func TestResolvePort(t *testing.T) {
+	pod := &kapi.Pod{Spec: kapi.PodSpec{Containers: []kapi.Container{{Ports: []kapi.ContainerPort{{Name: "http", ContainerPort: 8080}}}}}}
+	if port, err := resolvePort(intstr.FromString("http"), pod); err != nil || port != 8080 {
+		t.Errorf("expected port 8080, got %d: %v", port, err)
+	}
+	if port, err := resolvePort(intstr.FromInt(9090), pod); err != nil || port != 9090 {
+		t.Errorf("expected port 9090, got %d: %v", port, err)
+	}
+	if _, err := resolvePort(intstr.FromString("metrics"), pod); err == nil {
+		t.Errorf("expected an error for an unknown port name")
+	}
+}
+
+func canaryDeployments(t *testing.T, s deployapi.DeploymentStrategy) (*kapi.ReplicationController, *kapi.ReplicationController) {
+	oldConfig := deploytest.OkDeploymentConfig(1)
+	oldConfig.Spec.Strategy = s
+	from, err := deployutil.MakeDeployment(oldConfig, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from.Spec.Replicas, from.Status.Replicas = 10, 10
+	config := deploytest.OkDeploymentConfig(2)
+	config.Spec.Strategy = s
+	to, err := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return from, to
+}
+
+func newTestStrategy(deployment *kapi.ReplicationController, scaler *scalertest.FakeScaler, pods []kapi.Pod) *CanaryDeploymentStrategy {
+	return &CanaryDeploymentStrategy{
+		out:          &bytes.Buffer{},
+		errOut:       &bytes.Buffer{},
+		decoder:      kapi.Codecs.UniversalDecoder(),
+		retryTimeout: 1 * time.Second,
+		retryPeriod:  1 * time.Millisecond,
+		getReplicationController: func(namespace, name string) (*kapi.ReplicationController, error) {
+			// reflect the last scale of the deployment
+			updated := *deployment
+			for _, event := range scaler.Events {
+				if event.Name == name {
+					updated.Spec.Replicas, updated.Status.Replicas = int32(event.Size), int32(event.Size)
+				}
+			}
+			return &updated, nil
+		},
+		getUpdateAcceptor: getUpdateAcceptor,
+		listPods: func(deployment *kapi.ReplicationController) ([]kapi.Pod, error) {
+			return pods, nil
+		},
+		probe: func(u *url.URL, headers http.Header, timeout time.Duration) (probe.Result, string, error) {
+			return probe.Success, "", nil
+		},
+		sleep:  func(time.Duration) {},
+		scaler: scaler,
+	}
+}
+
+func readyPods(n int) []kapi.Pod {
+	pods := []kapi.Pod{}
+	for i := 0; i < n; i++ {
+		pods = append(pods, kapi.Pod{
+			ObjectMeta: kapi.ObjectMeta{Name: fmt.Sprintf("canary-%d", i)},
+			Status: kapi.PodStatus{
+				PodIP:      fmt.Sprintf("10.0.0.%d", i),
+				Conditions: []kapi.PodCondition{{Type: kapi.PodReady, Status: kapi.ConditionTrue}},
+			},
+		})
+	}
+	return pods
+}
+
+func getUpdateAcceptor(timeout time.Duration) strat.UpdateAcceptor {
+	return &testAcceptor{
+		acceptFn: func(deployment *kapi.ReplicationController) error {
+			return nil
+		},
+	}
+}
+
+type testAcceptor struct {
+	acceptFn func(*kapi.ReplicationController) error
+}
+
+func (t *testAcceptor) Accept(deployment *kapi.ReplicationController) error {
+	return t.acceptFn(deployment)
+}
@@ -0,0 +1,330 @@
+package ordered
+
+import (
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/kubectl"
+	"k8s.io/kubernetes/pkg/labels"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	"github.com/openshift/origin/pkg/client"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	strat "github.com/openshift/origin/pkg/deploy/strategy"
+	stratsupport "github.com/openshift/origin/pkg/deploy/strategy/support"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+)
+
+// OrderedDeploymentStrategy is a Strategy which replaces the pods of the
+// previous deployment strictly one at a time. The pods of the previous
+// deployment are removed in the order of their names, and after each removal
+// a new pod is started and has to become ready and pass the readiness check
+// before the next pod is replaced. If a new pod doesn't become ready or fails
+// the readiness check, the rollout is paused: the deployment is marked as
+// paused and fails, and the deployment config controller leaves the pods
+// replaced so far running. Retrying the deployment continues with the next
+// pod.
+type OrderedDeploymentStrategy struct {
+	// out and errOut control where output is sent during the strategy
+	out, errOut io.Writer
+	// until is a condition that, if reached, will cause the strategy to exit early
+	until string
+	// getReplicationController knows how to get a replication controller.
+	getReplicationController func(namespace, name string) (*kapi.ReplicationController, error)
+	// getUpdateAcceptor returns an UpdateAcceptor to verify the pods of the
+	// deployment become ready.
+	getUpdateAcceptor func(timeout time.Duration) strat.UpdateAcceptor
+	// listPods returns the pods of a deployment.
+	listPods func(deployment *kapi.ReplicationController) ([]kapi.Pod, error)
+	// deletePod deletes a pod.
+	deletePod func(namespace, name string) error
+	// pauseDeployment marks a deployment as paused.
+	pauseDeployment func(namespace, name string) error
+	// scaler is used to scale replication controllers.
+	scaler kubectl.Scaler
+	// decoder is used to decode DeploymentConfigs contained in deployments.
+	decoder runtime.Decoder
+	// hookExecutor can execute a lifecycle hook.
+	hookExecutor hookExecutor
+	// retryTimeout is how long to wait for the replica count update to succeed
+	// before giving up.
+	retryTimeout time.Duration
+	// retryPeriod is how often to try updating the replica count.
+	retryPeriod time.Duration
+}
+
+// AcceptorInterval is how often the UpdateAcceptor should check for
+// readiness.
+const AcceptorInterval = 1 * time.Second
+
+// NewOrderedDeploymentStrategy makes an OrderedDeploymentStrategy backed by a
+// real HookExecutor and client.
+func NewOrderedDeploymentStrategy(client kclient.Interface, oclient client.Interface, executor stratsupport.PodExecutor, decoder runtime.Decoder, out, errOut io.Writer, until string) *OrderedDeploymentStrategy {
+	if out == nil {
+		out = ioutil.Discard
+	}
+	if errOut == nil {
+		errOut = ioutil.Discard
+	}
+	scaler, _ := kubectl.ScalerFor(kapi.Kind("ReplicationController"), client)
+	return &OrderedDeploymentStrategy{
+		out:    out,
+		errOut: errOut,
+		until:  until,
+		getReplicationController: func(namespace, name string) (*kapi.ReplicationController, error) {
+			return client.ReplicationControllers(namespace).Get(name)
+		},
+		getUpdateAcceptor: func(timeout time.Duration) strat.UpdateAcceptor {
+			return stratsupport.NewAcceptNewlyObservedReadyPods(out, client, timeout, AcceptorInterval)
+		},
+		listPods: func(deployment *kapi.ReplicationController) ([]kapi.Pod, error) {
+			selector := labels.Set(deployment.Spec.Selector).AsSelector()
+			list, err := client.Pods(deployment.Namespace).List(kapi.ListOptions{LabelSelector: selector})
+			if err != nil {
+				return nil, err
+			}
+			return list.Items, nil
+		},
+		deletePod: func(namespace, name string) error {
+			return client.Pods(namespace).Delete(name, nil)
+		},
+		pauseDeployment: func(namespace, name string) error {
+			return kclient.RetryOnConflict(kclient.DefaultRetry, func() error {
+				deployment, err := client.ReplicationControllers(namespace).Get(name)
+				if err != nil {
+					return err
+				}
+				deployment.Annotations[deployapi.DeploymentStatusReasonAnnotation] = deployapi.DeploymentFailedRolloutPaused
+				_, err = client.ReplicationControllers(namespace).Update(deployment)
+				return err
+			})
+		},
+		scaler:       scaler,
+		decoder:      decoder,
+		hookExecutor: stratsupport.NewHookExecutor(client, oclient, executor, os.Stdout, decoder),
+		retryTimeout: 120 * time.Second,
+		retryPeriod:  1 * time.Second,
+	}
+}
+
+// Deploy replaces the pods of from with the pods of to one at a time until to
+// has desiredReplicas and from is scaled down to zero.
+func (s *OrderedDeploymentStrategy) Deploy(from *kapi.ReplicationController, to *kapi.ReplicationController, desiredReplicas int) error {
+	config, err := deployutil.DecodeDeploymentConfig(to, s.decoder)
+	if err != nil {
+		return fmt.Errorf("couldn't decode config from deployment %s: %v", to.Name, err)
+	}
+
+	params := config.Spec.Strategy.OrderedParams
+	retryParams := kubectl.NewRetryParams(s.retryPeriod, s.retryTimeout)
+	waitParams := kubectl.NewRetryParams(s.retryPeriod, s.retryTimeout)
+	updateAcceptor := s.getUpdateAcceptor(time.Duration(*params.TimeoutSeconds) * time.Second)
+
+	// Execute any pre-hook.
+	if params.Pre != nil {
+		if err := s.hookExecutor.Execute(params.Pre, from, to, deployapi.PreHookPodSuffix, "pre"); err != nil {
+			return fmt.Errorf("pre hook failed: %s", err)
+		}
+	}
+
+	if s.until == "pre" {
+		return strat.NewConditionReachedErr("pre hook succeeded")
+	}
+
+	// The replica counts are taken from the deployments rather than counted
+	// from zero, so that a retried deployment whose rollout was paused
+	// continues with the next pod.
+	for {
+		oldReplicas := 0
+		if from != nil {
+			oldReplicas = int(from.Spec.Replicas)
+		}
+		newReplicas := int(to.Spec.Replicas)
+		if oldReplicas == 0 && newReplicas >= desiredReplicas {
+			break
+		}
+
+		if oldReplicas > 0 {
+			updatedFrom, err := s.removeOldPod(from, retryParams, waitParams)
+			if err != nil {
+				return err
+			}
+			from = updatedFrom
+		}
+
+		if newReplicas >= desiredReplicas {
+			continue
+		}
+		existing, err := s.listPods(to)
+		if err != nil {
+			return fmt.Errorf("couldn't list the pods of %s: %v", to.Name, err)
+		}
+		replicas := newReplicas + 1
+		fmt.Fprintf(s.out, "--> Scaling %s to %d\n", to.Name, replicas)
+		updatedTo, err := s.scaleAndWait(to, replicas, retryParams, waitParams)
+		if err != nil {
+			return fmt.Errorf("couldn't scale %s to %d: %v", to.Name, replicas, err)
+		}
+		to = updatedTo
+		if err := updateAcceptor.Accept(to); err != nil {
+			return s.pause(to, fmt.Errorf("update acceptor rejected %s: %v", to.Name, err))
+		}
+
+		if params.ReadinessCheck != nil {
+			pod, err := s.newPod(to, existing)
+			if err != nil {
+				return err
+			}
+			if err := s.checkReadiness(params.ReadinessCheck, to, pod); err != nil {
+				return s.pause(to, fmt.Errorf("readiness check of pod %s failed: %v", pod.Name, err))
+			}
+		}
+
+		if percent, ok := strat.Percentage(s.until); ok && percent < 100 && replicas*100 >= percent*desiredReplicas {
+			return strat.NewConditionReachedErr(fmt.Sprintf("Reached %s (%d of %d pods replaced)", s.until, replicas, desiredReplicas))
+		}
+	}
+
+	if s.until == "100%" {
+		return strat.NewConditionReachedErr(fmt.Sprintf("Reached %s", s.until))
+	}
+
+	// Execute any post-hook.
+	if params.Post != nil {
+		if err := s.hookExecutor.Execute(params.Post, from, to, deployapi.PostHookPodSuffix, "post"); err != nil {
+			return fmt.Errorf("post hook failed: %s", err)
+		}
+	}
+
+	return nil
+}
+
+// removeOldPod deletes the first running pod of from in the order of pod names
+// and scales from down by one. The replication controller may start a
+// replacement for the deleted pod before it observes the lower replica count,
+// but such a pod isn't running yet and is the first one the replication
+// controller removes when it scales down.
+func (s *OrderedDeploymentStrategy) removeOldPod(from *kapi.ReplicationController, retry, wait *kubectl.RetryParams) (*kapi.ReplicationController, error) {
+	pods, err := s.listPods(from)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the pods of %s: %v", from.Name, err)
+	}
+	active := []kapi.Pod{}
+	for _, pod := range pods {
+		if pod.DeletionTimestamp == nil {
+			active = append(active, pod)
+		}
+	}
+	sort.Sort(podsByName(active))
+
+	if len(active) > 0 {
+		pod := active[0]
+		fmt.Fprintf(s.out, "--> Removing pod %s of %s\n", pod.Name, from.Name)
+		if err := s.deletePod(pod.Namespace, pod.Name); err != nil && !kerrors.IsNotFound(err) {
+			return nil, fmt.Errorf("couldn't delete pod %s: %v", pod.Name, err)
+		}
+	}
+
+	replicas := int(from.Spec.Replicas) - 1
+	fmt.Fprintf(s.out, "--> Scaling %s down to %d\n", from.Name, replicas)
+	updated, err := s.scaleAndWait(from, replicas, retry, wait)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't scale %s to %d: %v", from.Name, replicas, err)
+	}
+	return updated, nil
+}
+
+// newPod returns the pod of to which isn't one of the existing pods.
+func (s *OrderedDeploymentStrategy) newPod(to *kapi.ReplicationController, existing []kapi.Pod) (*kapi.Pod, error) {
+	known := map[string]bool{}
+	for _, pod := range existing {
+		known[pod.Name] = true
+	}
+	pods, err := s.listPods(to)
+	if err != nil {
+		return nil, fmt.Errorf("couldn't list the pods of %s: %v", to.Name, err)
+	}
+	sort.Sort(podsByName(pods))
+	for i := range pods {
+		if !known[pods[i].Name] && pods[i].DeletionTimestamp == nil {
+			return &pods[i], nil
+		}
+	}
+	return nil, fmt.Errorf("couldn't find the new pod of %s", to.Name)
+}
+
+// checkReadiness runs the readiness check for a new pod as a hook. The name of
+// the pod is passed to the check in OPENSHIFT_DEPLOYMENT_POD_NAME.
+func (s *OrderedDeploymentStrategy) checkReadiness(check *deployapi.ExecNewPodHook, to *kapi.ReplicationController, pod *kapi.Pod) error {
+	env := append([]kapi.EnvVar{}, check.Env...)
+	env = append(env, kapi.EnvVar{Name: "OPENSHIFT_DEPLOYMENT_POD_NAME", Value: pod.Name})
+	hook := &deployapi.LifecycleHook{
+		FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
+		ExecNewPod: &deployapi.ExecNewPodHook{
+			Command:       check.Command,
+			Env:           env,
+			ContainerName: check.ContainerName,
+			Volumes:       check.Volumes,
+		},
+	}
+	// Every check needs its own hook pod, so the suffix contains the generated
+	// part of the name of the checked pod.
+	suffix := fmt.Sprintf("%s-%s", deployapi.ReadinessCheckPodSuffix, strings.TrimPrefix(pod.Name, to.Name+"-"))
+	return s.hookExecutor.Execute(hook, nil, to, suffix, "readiness")
+}
+
+// pause marks the rollout of the deployment as paused, so that the pods
+// replaced so far are left running once the deployment fails, and returns the
+// reason the rollout stopped.
+func (s *OrderedDeploymentStrategy) pause(deployment *kapi.ReplicationController, reason error) error {
+	fmt.Fprintf(s.out, "--> %v\n", reason)
+	fmt.Fprintf(s.out, "--> Pausing the rollout of %s\n", deployment.Name)
+	if err := s.pauseDeployment(deployment.Namespace, deployment.Name); err != nil {
+		return fmt.Errorf("%v; couldn't pause the rollout of %s: %v", reason, deployment.Name, err)
+	}
+	return fmt.Errorf("rollout paused: %v; retry the deployment to continue", reason)
+}
+
+func (s *OrderedDeploymentStrategy) scaleAndWait(deployment *kapi.ReplicationController, replicas int, retry *kubectl.RetryParams, wait *kubectl.RetryParams) (*kapi.ReplicationController, error) {
+	if int32(replicas) == deployment.Spec.Replicas && int32(replicas) == deployment.Status.Replicas {
+		return deployment, nil
+	}
+	if err := s.scaler.Scale(deployment.Namespace, deployment.Name, uint(replicas), &kubectl.ScalePrecondition{Size: -1, ResourceVersion: ""}, retry, wait); err != nil {
+		return nil, err
+	}
+	updatedDeployment, err := s.getReplicationController(deployment.Namespace, deployment.Name)
+	if err != nil {
+		return nil, err
+	}
+	return updatedDeployment, nil
+}
+
+// podsByName sorts pods by their names.
+type podsByName []kapi.Pod
+
+func (p podsByName) Len() int           { return len(p) }
+func (p podsByName) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
+func (p podsByName) Less(i, j int) bool { return p[i].Name < p[j].Name }
+
+// hookExecutor knows how to execute a deployment lifecycle hook.
+type hookExecutor interface {
+	Execute(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error
+}
+
+// hookExecutorImpl is a pluggable hookExecutor.
+type hookExecutorImpl struct {
+	executeFunc func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error
+}
+
+// Execute executes the provided lifecycle hook
+func (i *hookExecutorImpl) Execute(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
+	return i.executeFunc(hook, from, deployment, suffix, label)
+}
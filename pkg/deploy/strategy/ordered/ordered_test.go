@@ -0,0 +1,256 @@
+package ordered
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	deploytest "github.com/openshift/origin/pkg/deploy/api/test"
+	scalertest "github.com/openshift/origin/pkg/deploy/scaler/test"
+	strat "github.com/openshift/origin/pkg/deploy/strategy"
+	deployutil "github.com/openshift/origin/pkg/deploy/util"
+
+	_ "github.com/openshift/origin/pkg/api/install"
+)
+
+func TestOrdered_replacesPodsInOrder(t *testing.T) {
+	from, to := orderedDeployments(t, deploytest.OkOrderedStrategy(), 3)
+	scaler := &scalertest.FakeScaler{}
+	strategy, deleted := newTestStrategy(from, to, scaler, []string{"old-c", "old-a", "old-b"})
+	var checked []string
+	strategy.hookExecutor = &hookExecutorImpl{
+		executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
+			env := hook.ExecNewPod.Env
+			checked = append(checked, fmt.Sprintf("%s=%s %s", env[len(env)-1].Name, env[len(env)-1].Value, suffix))
+			return nil
+		},
+	}
+	strategy.pauseDeployment = func(namespace, name string) error {
+		t.Errorf("unexpected pause of %s", name)
+		return nil
+	}
+
+	if err := strategy.Deploy(from, to, 3); err != nil {
+		t.Fatalf("unexpected deploy error: %v", err)
+	}
+
+	if e, a := []string{"old-a", "old-b", "old-c"}, *deleted; fmt.Sprint(e) != fmt.Sprint(a) {
+		t.Errorf("expected pods to be deleted in order %v, got %v", e, a)
+	}
+	expected := []scalertest.ScaleEvent{
+		{Name: from.Name, Size: 2}, {Name: to.Name, Size: 1},
+		{Name: from.Name, Size: 1}, {Name: to.Name, Size: 2},
+		{Name: from.Name, Size: 0}, {Name: to.Name, Size: 3},
+	}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+	expectedChecks := []string{
+		"OPENSHIFT_DEPLOYMENT_POD_NAME=config-2-0 hook-ready-0",
+		"OPENSHIFT_DEPLOYMENT_POD_NAME=config-2-1 hook-ready-1",
+		"OPENSHIFT_DEPLOYMENT_POD_NAME=config-2-2 hook-ready-2",
+	}
+	if fmt.Sprint(checked) != fmt.Sprint(expectedChecks) {
+		t.Errorf("expected readiness checks %v, got %v", expectedChecks, checked)
+	}
+}
+
+func TestOrdered_pausedOnFailedReadinessCheck(t *testing.T) {
+	from, to := orderedDeployments(t, deploytest.OkOrderedStrategy(), 3)
+	scaler := &scalertest.FakeScaler{}
+	strategy, _ := newTestStrategy(from, to, scaler, []string{"old-a", "old-b", "old-c"})
+	strategy.hookExecutor = &hookExecutorImpl{
+		executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
+			return fmt.Errorf("cluster health is red")
+		},
+	}
+	paused := ""
+	strategy.pauseDeployment = func(namespace, name string) error {
+		paused = name
+		return nil
+	}
+
+	err := strategy.Deploy(from, to, 3)
+	if err == nil {
+		t.Fatalf("expected a deploy error")
+	}
+	t.Logf("got expected error: %v", err)
+	if paused != to.Name {
+		t.Errorf("expected the rollout of %s to be paused, got %q", to.Name, paused)
+	}
+	expected := []scalertest.ScaleEvent{{Name: from.Name, Size: 2}, {Name: to.Name, Size: 1}}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+}
+
+func TestOrdered_continuesRetriedDeployment(t *testing.T) {
+	strategy := deploytest.OkOrderedStrategy()
+	strategy.OrderedParams.ReadinessCheck = nil
+	from, to := orderedDeployments(t, strategy, 2)
+	to.Spec.Replicas, to.Status.Replicas = 1, 1
+	scaler := &scalertest.FakeScaler{}
+	s, deleted := newTestStrategy(from, to, scaler, []string{"old-b", "old-c"})
+
+	if err := s.Deploy(from, to, 3); err != nil {
+		t.Fatalf("unexpected deploy error: %v", err)
+	}
+	if e, a := []string{"old-b", "old-c"}, *deleted; fmt.Sprint(e) != fmt.Sprint(a) {
+		t.Errorf("expected pods to be deleted in order %v, got %v", e, a)
+	}
+	expected := []scalertest.ScaleEvent{
+		{Name: from.Name, Size: 1}, {Name: to.Name, Size: 2},
+		{Name: from.Name, Size: 0}, {Name: to.Name, Size: 3},
+	}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+}
+
+func TestOrdered_scaleUpAndDown(t *testing.T) {
+	strategy := deploytest.OkOrderedStrategy()
+	strategy.OrderedParams.ReadinessCheck = nil
+
+	// More old pods than desired replicas.
+	from, to := orderedDeployments(t, strategy, 3)
+	scaler := &scalertest.FakeScaler{}
+	s, _ := newTestStrategy(from, to, scaler, []string{"old-a", "old-b", "old-c"})
+	if err := s.Deploy(from, to, 1); err != nil {
+		t.Fatalf("unexpected deploy error: %v", err)
+	}
+	expected := []scalertest.ScaleEvent{{Name: from.Name, Size: 2}, {Name: to.Name, Size: 1}, {Name: from.Name, Size: 1}, {Name: from.Name, Size: 0}}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+
+	// No previous deployment.
+	_, to = orderedDeployments(t, strategy, 0)
+	scaler = &scalertest.FakeScaler{}
+	s, _ = newTestStrategy(nil, to, scaler, nil)
+	if err := s.Deploy(nil, to, 2); err != nil {
+		t.Fatalf("unexpected deploy error: %v", err)
+	}
+	expected = []scalertest.ScaleEvent{{Name: to.Name, Size: 1}, {Name: to.Name, Size: 2}}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+}
+
+func TestOrdered_until(t *testing.T) {
+	strategy := deploytest.OkOrderedStrategy()
+	strategy.OrderedParams.ReadinessCheck = nil
+	from, to := orderedDeployments(t, strategy, 4)
+	scaler := &scalertest.FakeScaler{}
+	s, _ := newTestStrategy(from, to, scaler, []string{"old-a", "old-b", "old-c", "old-d"})
+	s.until = "50%"
+
+	err := s.Deploy(from, to, 4)
+	if !strat.IsConditionReached(err) {
+		t.Fatalf("expected the condition to be reached, got %v", err)
+	}
+	expected := []scalertest.ScaleEvent{
+		{Name: from.Name, Size: 3}, {Name: to.Name, Size: 1},
+		{Name: from.Name, Size: 2}, {Name: to.Name, Size: 2},
+	}
+	if fmt.Sprint(scaler.Events) != fmt.Sprint(expected) {
+		t.Errorf("expected scale events %v, got %v", expected, scaler.Events)
+	}
+}
+
+func orderedDeployments(t *testing.T, s deployapi.DeploymentStrategy, oldReplicas int32) (*kapi.ReplicationController, *kapi.ReplicationController) {
+	oldConfig := deploytest.OkDeploymentConfig(1)
+	oldConfig.Spec.Strategy = s
+	from, err := deployutil.MakeDeployment(oldConfig, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	from.Spec.Replicas, from.Status.Replicas = oldReplicas, oldReplicas
+	config := deploytest.OkDeploymentConfig(2)
+	config.Spec.Strategy = s
+	to, err := deployutil.MakeDeployment(config, kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return from, to
+}
+
+// newTestStrategy returns a strategy whose deployments reflect the scale
+// events. The pods of to are named after their index, the pods of from are
+// oldPods less the deleted ones, which are returned.
+func newTestStrategy(from, to *kapi.ReplicationController, scaler *scalertest.FakeScaler, oldPods []string) (*OrderedDeploymentStrategy, *[]string) {
+	deleted := []string{}
+	replicasOf := func(deployment *kapi.ReplicationController) int32 {
+		replicas := deployment.Spec.Replicas
+		for _, event := range scaler.Events {
+			if event.Name == deployment.Name {
+				replicas = int32(event.Size)
+			}
+		}
+		return replicas
+	}
+	strategy := &OrderedDeploymentStrategy{
+		out:          &bytes.Buffer{},
+		errOut:       &bytes.Buffer{},
+		decoder:      kapi.Codecs.UniversalDecoder(),
+		retryTimeout: 1 * time.Second,
+		retryPeriod:  1 * time.Millisecond,
+		getReplicationController: func(namespace, name string) (*kapi.ReplicationController, error) {
+			deployment := to
+			if from != nil && name == from.Name {
+				deployment = from
+			}
+			updated := *deployment
+			updated.Spec.Replicas = replicasOf(deployment)
+			updated.Status.Replicas = updated.Spec.Replicas
+			return &updated, nil
+		},
+		getUpdateAcceptor: func(timeout time.Duration) strat.UpdateAcceptor {
+			return &testAcceptor{acceptFn: func(*kapi.ReplicationController) error { return nil }}
+		},
+		listPods: func(deployment *kapi.ReplicationController) ([]kapi.Pod, error) {
+			pods := []kapi.Pod{}
+			if deployment.Name == to.Name {
+				for i := int32(0); i < replicasOf(to); i++ {
+					pods = append(pods, kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: fmt.Sprintf("%s-%d", to.Name, i)}})
+				}
+				return pods, nil
+			}
+			for _, name := range oldPods {
+				isDeleted := false
+				for _, d := range deleted {
+					isDeleted = isDeleted || d == name
+				}
+				if !isDeleted {
+					pods = append(pods, kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: name}})
+				}
+			}
+			return pods, nil
+		},
+		deletePod: func(namespace, name string) error {
+			deleted = append(deleted, name)
+			return nil
+		},
+		pauseDeployment: func(namespace, name string) error {
+			return nil
+		},
+		scaler: scaler,
+		hookExecutor: &hookExecutorImpl{
+			executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
+				return nil
+			},
+		},
+	}
+	return strategy, &deleted
+}
+
+type testAcceptor struct {
+	acceptFn func(*kapi.ReplicationController) error
+}
+
+func (t *testAcceptor) Accept(deployment *kapi.ReplicationController) error {
+	return t.acceptFn(deployment)
+}
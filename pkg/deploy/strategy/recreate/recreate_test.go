@@ -68,7 +68,7 @@ func TestRecreate_deploymentPreHookSuccess(t *testing.T) {
 		},
 		getUpdateAcceptor: getUpdateAcceptor,
 		hookExecutor: &hookExecutorImpl{
-			executeFunc: func(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, suffix, label string) error {
+			executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
 				hookExecuted = true
 				return nil
 			},
@@ -102,7 +102,7 @@ func TestRecreate_deploymentPreHookFail(t *testing.T) {
 		},
 		getUpdateAcceptor: getUpdateAcceptor,
 		hookExecutor: &hookExecutorImpl{
-			executeFunc: func(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, suffix, label string) error {
+			executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
 				return fmt.Errorf("hook execution failure")
 			},
 		},
@@ -136,7 +136,7 @@ func TestRecreate_deploymentMidHookSuccess(t *testing.T) {
 		},
 		getUpdateAcceptor: getUpdateAcceptor,
 		hookExecutor: &hookExecutorImpl{
-			executeFunc: func(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, suffix, label string) error {
+			executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
 				hookExecuted = true
 				return nil
 			},
@@ -170,7 +170,7 @@ func TestRecreate_deploymentMidHookFail(t *testing.T) {
 		},
 		getUpdateAcceptor: getUpdateAcceptor,
 		hookExecutor: &hookExecutorImpl{
-			executeFunc: func(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, suffix, label string) error {
+			executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
 				return fmt.Errorf("hook execution failure")
 			},
 		},
@@ -203,7 +203,7 @@ func TestRecreate_deploymentPostHookSuccess(t *testing.T) {
 		},
 		getUpdateAcceptor: getUpdateAcceptor,
 		hookExecutor: &hookExecutorImpl{
-			executeFunc: func(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, suffix, label string) error {
+			executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
 				hookExecuted = true
 				return nil
 			},
@@ -238,7 +238,7 @@ func TestRecreate_deploymentPostHookFail(t *testing.T) {
 		},
 		getUpdateAcceptor: getUpdateAcceptor,
 		hookExecutor: &hookExecutorImpl{
-			executeFunc: func(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, suffix, label string) error {
+			executeFunc: func(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
 				hookExecuted = true
 				return fmt.Errorf("post hook failure")
 			},
@@ -3,6 +3,8 @@ package support
 import (
 	"fmt"
 	"io"
+	"net/http"
+	"strings"
 	"sync"
 	"time"
 
@@ -11,11 +13,16 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	kerrors "k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/client/restclient"
 	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/client/unversioned/remotecommand"
 	"k8s.io/kubernetes/pkg/fields"
+	remotecommandserver "k8s.io/kubernetes/pkg/kubelet/server/remotecommand"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
 	utilerrors "k8s.io/kubernetes/pkg/util/errors"
+	"k8s.io/kubernetes/pkg/util/httpstream"
+	"k8s.io/kubernetes/pkg/util/httpstream/spdy"
 	"k8s.io/kubernetes/pkg/util/sets"
 	"k8s.io/kubernetes/pkg/util/wait"
 	"k8s.io/kubernetes/pkg/watch"
@@ -40,12 +47,18 @@ type HookExecutor struct {
 	out io.Writer
 	// podLogStream provides a reader for a pod's logs.
 	podLogStream func(namespace, name string, opts *kapi.PodLogOptions) (io.ReadCloser, error)
+	// listPods lists the pods in namespace matching selector.
+	listPods func(namespace string, selector labels.Selector) (*kapi.PodList, error)
+	// execInPod runs command in a container of a running pod, streaming its
+	// output to out, until the command exits or stop is closed.
+	execInPod func(namespace, name, container string, command []string, out io.Writer, stop <-chan struct{}) error
 	// decoder is used for encoding/decoding.
 	decoder runtime.Decoder
 }
 
-// NewHookExecutor makes a HookExecutor from a client.
-func NewHookExecutor(client kclient.PodsNamespacer, tags client.ImageStreamTagsNamespacer, out io.Writer, decoder runtime.Decoder) *HookExecutor {
+// NewHookExecutor makes a HookExecutor from a client. ExecInPod hooks are run
+// through executor.
+func NewHookExecutor(client kclient.PodsNamespacer, tags client.ImageStreamTagsNamespacer, executor PodExecutor, out io.Writer, decoder runtime.Decoder) *HookExecutor {
 	return &HookExecutor{
 		tags: tags,
 		podClient: &HookExecutorPodClientImpl{
@@ -59,20 +72,27 @@ func NewHookExecutor(client kclient.PodsNamespacer, tags client.ImageStreamTagsN
 		podLogStream: func(namespace, name string, opts *kapi.PodLogOptions) (io.ReadCloser, error) {
 			return client.Pods(namespace).GetLogs(name, opts).Stream()
 		},
-		out:     out,
-		decoder: decoder,
+		listPods: func(namespace string, selector labels.Selector) (*kapi.PodList, error) {
+			return client.Pods(namespace).List(kapi.ListOptions{LabelSelector: selector})
+		},
+		execInPod: executor.Exec,
+		out:       out,
+		decoder:   decoder,
 	}
 }
 
 // Execute executes hook in the context of deployment. The suffix is used to
-// distinguish the kind of hook (e.g. pre, post).
-func (e *HookExecutor) Execute(hook *deployapi.LifecycleHook, deployment *kapi.ReplicationController, suffix, label string) error {
+// distinguish the kind of hook (e.g. pre, post). from is the previous
+// deployment being replaced, if any.
+func (e *HookExecutor) Execute(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, suffix, label string) error {
 	var err error
 	switch {
 	case len(hook.TagImages) > 0:
 		err = e.tagImages(hook, deployment, suffix, label)
 	case hook.ExecNewPod != nil:
 		err = e.executeExecNewPod(hook, deployment, suffix, label)
+	case hook.ExecInPod != nil:
+		err = e.executeExecInPod(hook, from, deployment, label)
 	}
 
 	if err == nil {
@@ -241,6 +261,152 @@ waitLoop:
 	return nil
 }
 
+// executeExecInPod executes an ExecInPod hook by running the hook command
+// through the exec API in the running pods of either the previous deployment
+// (from) or the new deployment. Unless the hook asks for all pods, the command
+// is run in a single pod. Output of the command is streamed to the deployer log.
+func (e *HookExecutor) executeExecInPod(hook *deployapi.LifecycleHook, from, deployment *kapi.ReplicationController, label string) error {
+	exec := hook.ExecInPod
+
+	target := deployment
+	if exec.Target == deployapi.ExecInPodTargetPrevious {
+		if from == nil {
+			return fmt.Errorf("there is no previous deployment of %s", deployment.Name)
+		}
+		target = from
+	}
+
+	configName := deployutil.DeploymentConfigNameFor(deployment)
+	if len(configName) == 0 {
+		return fmt.Errorf("couldn't find the deployment config of %s", deployment.Name)
+	}
+	configReq, err := labels.NewRequirement(deployapi.DeploymentConfigLabel, labels.EqualsOperator, sets.NewString(configName))
+	if err != nil {
+		return err
+	}
+	deploymentReq, err := labels.NewRequirement(deployapi.DeploymentLabel, labels.EqualsOperator, sets.NewString(target.Name))
+	if err != nil {
+		return err
+	}
+	selector := labels.NewSelector().Add(*configReq, *deploymentReq)
+
+	list, err := e.listPods(deployment.Namespace, selector)
+	if err != nil {
+		return fmt.Errorf("couldn't list pods for %s: %v", target.Name, err)
+	}
+	pods := []kapi.Pod{}
+	for _, pod := range list.Items {
+		if pod.Status.Phase == kapi.PodRunning && pod.DeletionTimestamp == nil {
+			pods = append(pods, pod)
+		}
+	}
+	if len(pods) == 0 {
+		return fmt.Errorf("no running pods found for the %s deployment %s", strings.ToLower(string(exec.Target)), target.Name)
+	}
+	if !exec.AllPods {
+		pods = pods[:1]
+	}
+
+	timeout := time.Duration(deployapi.DefaultExecInPodTimeoutSeconds) * time.Second
+	if exec.TimeoutSeconds != nil {
+		timeout = time.Duration(*exec.TimeoutSeconds) * time.Second
+	}
+
+	for _, pod := range pods {
+		fmt.Fprintf(e.out, "--> %s: Running hook in pod %s ...\n", label, pod.Name)
+		stop := make(chan struct{})
+		result := make(chan error, 1)
+		go func(name string) {
+			result <- e.execInPod(pod.Namespace, name, exec.ContainerName, exec.Command, e.out, stop)
+		}(pod.Name)
+		select {
+		case err := <-result:
+			if err != nil {
+				return fmt.Errorf("hook failed in pod %s: %v", pod.Name, err)
+			}
+		case <-time.After(timeout):
+			// End the command and wait for the exec to return.
+			close(stop)
+			<-result
+			return fmt.Errorf("hook in pod %s did not finish within %s", pod.Name, timeout)
+		}
+	}
+	fmt.Fprintf(e.out, "--> %s: Success\n", label)
+	return nil
+}
+
+// PodExecutor runs commands in the containers of running pods.
+type PodExecutor interface {
+	// Exec runs command in a container of the named pod, streaming its output
+	// to out, until the command exits or stop is closed.
+	Exec(namespace, name, container string, command []string, out io.Writer, stop <-chan struct{}) error
+}
+
+// NewPodExecutor returns a PodExecutor which runs commands through the exec API
+// of the server client talks to. config is the configuration client was made
+// from.
+func NewPodExecutor(client *kclient.Client, config *restclient.Config) PodExecutor {
+	return &podExecutor{client: client, config: config}
+}
+
+type podExecutor struct {
+	client *kclient.Client
+	config *restclient.Config
+}
+
+// Exec runs the command in a terminal, so that closing the connection when stop
+// is closed hangs up the terminal and ends the command.
+func (e *podExecutor) Exec(namespace, name, container string, command []string, out io.Writer, stop <-chan struct{}) error {
+	req := e.client.RESTClient.Post().
+		Resource("pods").
+		Name(name).
+		Namespace(namespace).
+		SubResource("exec").
+		Param("container", container)
+	req.VersionedParams(&kapi.PodExecOptions{
+		Container: container,
+		Command:   command,
+		Stdout:    true,
+		TTY:       true,
+	}, kapi.ParameterCodec)
+
+	tlsConfig, err := restclient.TLSConfigFor(e.config)
+	if err != nil {
+		return err
+	}
+	upgrader := &stoppableUpgrader{UpgradeRoundTripper: spdy.NewRoundTripper(tlsConfig), stop: stop}
+	transport, err := restclient.HTTPWrappersForConfig(e.config, upgrader)
+	if err != nil {
+		return err
+	}
+	executor, err := remotecommand.NewStreamExecutor(upgrader, func(http.RoundTripper) http.RoundTripper { return transport }, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+	return executor.Stream(remotecommandserver.SupportedStreamingProtocols, nil, out, nil, true)
+}
+
+// stoppableUpgrader closes the connections it upgrades once stop is closed.
+type stoppableUpgrader struct {
+	httpstream.UpgradeRoundTripper
+	stop <-chan struct{}
+}
+
+func (u *stoppableUpgrader) NewConnection(resp *http.Response) (httpstream.Connection, error) {
+	conn, err := u.UpgradeRoundTripper.NewConnection(resp)
+	if err != nil {
+		return nil, err
+	}
+	go func() {
+		select {
+		case <-u.stop:
+			conn.Close()
+		case <-conn.CloseChan():
+		}
+	}()
+	return conn, nil
+}
+
 // readPodLogs streams logs from pod to out. It signals wg when
 // done.
 func (e *HookExecutor) readPodLogs(pod *kapi.Pod, wg *sync.WaitGroup) {
@@ -14,6 +14,7 @@ import (
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/resource"
 	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/util/diff"
 	"k8s.io/kubernetes/pkg/util/sets"
 
@@ -147,6 +148,148 @@ func TestHookExecutor_executeExecNewPodFailed(t *testing.T) {
 	t.Logf("got expected error: %s", err)
 }
 
+func TestHookExecutor_executeExecInPod(t *testing.T) {
+	runningPod := func(name, deployment string) kapi.Pod {
+		return kapi.Pod{
+			ObjectMeta: kapi.ObjectMeta{
+				Name:      name,
+				Namespace: kapi.NamespaceDefault,
+				Labels: map[string]string{
+					deployapi.DeploymentConfigLabel: "config",
+					deployapi.DeploymentLabel:       deployment,
+				},
+			},
+			Status: kapi.PodStatus{Phase: kapi.PodRunning},
+		}
+	}
+	pendingPod := runningPod("pending", "config-1")
+	pendingPod.Status.Phase = kapi.PodPending
+
+	tests := []struct {
+		name    string
+		target  deployapi.ExecInPodTarget
+		allPods bool
+		pods    []kapi.Pod
+		execErr error
+		block   bool
+		noFrom  bool
+
+		expectedSelector string
+		expectedPods     []string
+		expectedErr      bool
+	}{
+		{
+			name:             "single pod of the new deployment",
+			target:           deployapi.ExecInPodTargetNew,
+			pods:             []kapi.Pod{pendingPod, runningPod("new-1", "config-1"), runningPod("new-2", "config-1")},
+			expectedSelector: "deployment=config-1,deploymentconfig=config",
+			expectedPods:     []string{"new-1"},
+		},
+		{
+			name:             "all pods of the previous deployment",
+			target:           deployapi.ExecInPodTargetPrevious,
+			allPods:          true,
+			pods:             []kapi.Pod{runningPod("old-1", "config-0"), runningPod("old-2", "config-0")},
+			expectedSelector: "deployment=config-0,deploymentconfig=config",
+			expectedPods:     []string{"old-1", "old-2"},
+		},
+		{
+			name:         "no previous deployment",
+			target:       deployapi.ExecInPodTargetPrevious,
+			noFrom:       true,
+			pods:         []kapi.Pod{runningPod("new-1", "config-1")},
+			expectedPods: []string{},
+			expectedErr:  true,
+		},
+		{
+			name:             "no running pods",
+			target:           deployapi.ExecInPodTargetNew,
+			pods:             []kapi.Pod{pendingPod},
+			expectedSelector: "deployment=config-1,deploymentconfig=config",
+			expectedPods:     []string{},
+			expectedErr:      true,
+		},
+		{
+			name:             "command fails",
+			target:           deployapi.ExecInPodTargetNew,
+			allPods:          true,
+			pods:             []kapi.Pod{runningPod("new-1", "config-1"), runningPod("new-2", "config-1")},
+			execErr:          fmt.Errorf("command terminated with exit code 1"),
+			expectedSelector: "deployment=config-1,deploymentconfig=config",
+			expectedPods:     []string{"new-1"},
+			expectedErr:      true,
+		},
+		{
+			name:             "command times out",
+			target:           deployapi.ExecInPodTargetNew,
+			pods:             []kapi.Pod{runningPod("new-1", "config-1")},
+			block:            true,
+			expectedSelector: "deployment=config-1,deploymentconfig=config",
+			expectedPods:     []string{"new-1"},
+			expectedErr:      true,
+		},
+	}
+
+	for _, test := range tests {
+		timeout := int64(1)
+		hook := &deployapi.LifecycleHook{
+			FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
+			ExecInPod: &deployapi.ExecInPodHook{
+				Command:        []string{"/bin/true"},
+				ContainerName:  "container1",
+				Target:         test.target,
+				AllPods:        test.allPods,
+				TimeoutSeconds: &timeout,
+			},
+		}
+		deployment, _ := deployutil.MakeDeployment(deploytest.OkDeploymentConfig(1), kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+		var from *kapi.ReplicationController
+		if !test.noFrom {
+			from, _ = deployutil.MakeDeployment(deploytest.OkDeploymentConfig(0), kapi.Codecs.LegacyCodec(deployapi.SchemeGroupVersion))
+		}
+
+		selector := ""
+		execPods := []string{}
+		stopped := false
+		executor := &HookExecutor{
+			out: ioutil.Discard,
+			listPods: func(namespace string, s labels.Selector) (*kapi.PodList, error) {
+				selector = s.String()
+				return &kapi.PodList{Items: test.pods}, nil
+			},
+			execInPod: func(namespace, name, container string, command []string, out io.Writer, stop <-chan struct{}) error {
+				execPods = append(execPods, name)
+				if container != "container1" {
+					t.Errorf("%s: unexpected container %q", test.name, container)
+				}
+				if test.block {
+					<-stop
+					stopped = true
+					return fmt.Errorf("connection closed")
+				}
+				return test.execErr
+			},
+		}
+
+		err := executor.executeExecInPod(hook, from, deployment, "test")
+		if test.block && !stopped {
+			t.Errorf("%s: expected the command to be stopped", test.name)
+		}
+		if test.expectedErr && err == nil {
+			t.Errorf("%s: expected an error", test.name)
+		}
+		if !test.expectedErr && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if selector != test.expectedSelector {
+			t.Errorf("%s: expected selector %q, got %q", test.name, test.expectedSelector, selector)
+		}
+		if !reflect.DeepEqual(execPods, test.expectedPods) {
+			t.Errorf("%s: expected the hook to run in %v, got %v", test.name, test.expectedPods, execPods)
+		}
+	}
+}
+
 func TestHookExecutor_makeHookPodInvalidContainerRef(t *testing.T) {
 	hook := &deployapi.LifecycleHook{
 		FailurePolicy: deployapi.LifecycleHookFailurePolicyAbort,
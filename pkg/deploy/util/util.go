@@ -5,13 +5,16 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/labels"
 	"k8s.io/kubernetes/pkg/runtime"
 	kdeplutil "k8s.io/kubernetes/pkg/util/deployment"
 
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/util/cron"
 	"github.com/openshift/origin/pkg/util/namer"
 )
 
@@ -265,6 +268,10 @@ func DeploymentStatusReasonFor(obj runtime.Object) string {
 	return annotationFor(obj, deployapi.DeploymentStatusReasonAnnotation)
 }
 
+func DeploymentReplicaFailureFor(obj runtime.Object) string {
+	return annotationFor(obj, deployapi.DeploymentReplicaFailureAnnotation)
+}
+
 func DeploymentDesiredReplicas(obj runtime.Object) (int32, bool) {
 	return int32AnnotationFor(obj, deployapi.DesiredReplicasAnnotation)
 }
@@ -290,6 +297,14 @@ func IsDeploymentCancelled(deployment *api.ReplicationController) bool {
 	return strings.EqualFold(value, deployapi.DeploymentCancelledAnnotationValue)
 }
 
+// IsRolloutPaused returns true if the deployment failed with its rollout
+// paused. The replica counts of the deployments of its config are left as
+// they are until the deployment is retried or replaced.
+func IsRolloutPaused(deployment *api.ReplicationController) bool {
+	return DeploymentStatusFor(deployment) == deployapi.DeploymentStatusFailed &&
+		DeploymentStatusReasonFor(deployment) == deployapi.DeploymentFailedRolloutPaused
+}
+
 func Instantiate(dc *deployapi.DeploymentConfig) {
 	if dc.Annotations == nil {
 		dc.Annotations = make(map[string]string)
@@ -340,6 +355,104 @@ func CanTransitionPhase(current, next deployapi.DeploymentStatus) bool {
 	return false
 }
 
+// NewDeploymentCondition creates a new deployment condition which transitioned now.
+func NewDeploymentCondition(condType deployapi.DeploymentConditionType, status api.ConditionStatus, reason, message string) *deployapi.DeploymentCondition {
+	return &deployapi.DeploymentCondition{
+		Type:               condType,
+		Status:             status,
+		LastTransitionTime: unversioned.Now(),
+		Reason:             reason,
+		Message:            message,
+	}
+}
+
+// GetDeploymentCondition returns the condition with the provided type, or nil
+// if the status doesn't have one.
+func GetDeploymentCondition(status deployapi.DeploymentConfigStatus, condType deployapi.DeploymentConditionType) *deployapi.DeploymentCondition {
+	for i := range status.Conditions {
+		if status.Conditions[i].Type == condType {
+			return &status.Conditions[i]
+		}
+	}
+	return nil
+}
+
+// SetDeploymentCondition adds condition to status, replacing any condition of
+// the same type. The last transition time is kept if the status of the
+// condition doesn't change.
+func SetDeploymentCondition(status *deployapi.DeploymentConfigStatus, condition deployapi.DeploymentCondition) {
+	current := GetDeploymentCondition(*status, condition.Type)
+	if current != nil && current.Status == condition.Status {
+		if current.Reason == condition.Reason && current.Message == condition.Message {
+			return
+		}
+		condition.LastTransitionTime = current.LastTransitionTime
+	}
+	if current != nil {
+		*current = condition
+		return
+	}
+	status.Conditions = append(status.Conditions, condition)
+}
+
+// RemoveDeploymentCondition removes the condition with the provided type from
+// status.
+func RemoveDeploymentCondition(status *deployapi.DeploymentConfigStatus, condType deployapi.DeploymentConditionType) {
+	conditions := []deployapi.DeploymentCondition{}
+	for _, c := range status.Conditions {
+		if c.Type != condType {
+			conditions = append(conditions, c)
+		}
+	}
+	if len(conditions) == 0 {
+		conditions = nil
+	}
+	status.Conditions = conditions
+}
+
+// DeploymentWindowOpen returns whether window is open at t and, if it is not,
+// the time it opens next.
+func DeploymentWindowOpen(window *deployapi.DeploymentWindow, t time.Time) (bool, time.Time, error) {
+	schedule, err := cron.Parse(window.Schedule)
+	if err != nil {
+		return false, time.Time{}, fmt.Errorf("invalid deployment window schedule %q: %v", window.Schedule, err)
+	}
+	location := time.UTC
+	if len(window.TimeZone) > 0 {
+		if location, err = time.LoadLocation(window.TimeZone); err != nil {
+			return false, time.Time{}, fmt.Errorf("invalid deployment window time zone %q: %v", window.TimeZone, err)
+		}
+	}
+	t = t.In(location)
+	if schedule.Matches(t) {
+		return true, time.Time{}, nil
+	}
+	next := schedule.Next(t)
+	if next.IsZero() {
+		return false, time.Time{}, fmt.Errorf("deployment window %q never opens", window.Schedule)
+	}
+	return false, next, nil
+}
+
+// MergeDeploymentCauses returns existing with the causes which aren't part
+// of it yet appended.
+func MergeDeploymentCauses(existing, causes []deployapi.DeploymentCause) []deployapi.DeploymentCause {
+	merged := append([]deployapi.DeploymentCause{}, existing...)
+	for _, cause := range causes {
+		found := false
+		for _, e := range existing {
+			if api.Semantic.DeepEqual(cause, e) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			merged = append(merged, cause)
+		}
+	}
+	return merged
+}
+
 // annotationFor returns the annotation with key for obj.
 func annotationFor(obj runtime.Object, key string) string {
 	meta, err := api.ObjectMetaFor(obj)
@@ -0,0 +1,20 @@
+// Package api contains the types exchanged with the administrative endpoints of the integrated
+// Docker registry. It is kept apart from the registry server so that clients of these endpoints
+// don't depend on the server.
+package api
+
+// GarbageCollectionReport lists the blobs and uploads deleted by a garbage collection, or the ones
+// which would have been deleted by a dry run.
+type GarbageCollectionReport struct {
+	DryRun  bool                   `json:"dryRun"`
+	Blobs   []GarbageCollectedBlob `json:"blobs,omitempty"`
+	Uploads []string               `json:"uploads,omitempty"`
+	// Errors lists the errors which prevented the deletion of some blobs or uploads.
+	Errors []string `json:"errors,omitempty"`
+}
+
+// GarbageCollectedBlob is a blob deleted by a garbage collection.
+type GarbageCollectedBlob struct {
+	Digest string `json:"digest"`
+	Size   int64  `json:"size"`
+}
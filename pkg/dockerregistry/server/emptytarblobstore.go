@@ -0,0 +1,72 @@
+package server
+
+import (
+	"bytes"
+	"net/http"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// emptyTarBlobStore wraps a distribution.BlobStore and serves the empty layer referenced by the schema1
+// manifests converted from schema2 manifests from memory, so that pulling them doesn't write to the
+// storage of the registry.
+type emptyTarBlobStore struct {
+	distribution.BlobStore
+}
+
+var _ distribution.BlobStore = &emptyTarBlobStore{}
+
+// emptyTarDescriptor describes gzippedEmptyTar.
+var emptyTarDescriptor = distribution.Descriptor{
+	MediaType: imageapi.MediaTypeDockerSchema2Layer,
+	Size:      int64(len(gzippedEmptyTar)),
+	Digest:    digestSHA256GzippedEmptyTar,
+}
+
+// Stat describes the empty layer without looking it up in the wrapped store.
+func (bs *emptyTarBlobStore) Stat(ctx context.Context, dgst digest.Digest) (distribution.Descriptor, error) {
+	if dgst == digestSHA256GzippedEmptyTar {
+		return emptyTarDescriptor, nil
+	}
+	return bs.BlobStore.Stat(ctx, dgst)
+}
+
+// Get returns the content of the empty layer without reading it from the wrapped store.
+func (bs *emptyTarBlobStore) Get(ctx context.Context, dgst digest.Digest) ([]byte, error) {
+	if dgst == digestSHA256GzippedEmptyTar {
+		return gzippedEmptyTar, nil
+	}
+	return bs.BlobStore.Get(ctx, dgst)
+}
+
+// Open opens the empty layer without reading it from the wrapped store.
+func (bs *emptyTarBlobStore) Open(ctx context.Context, dgst digest.Digest) (distribution.ReadSeekCloser, error) {
+	if dgst == digestSHA256GzippedEmptyTar {
+		return nopCloser{bytes.NewReader(gzippedEmptyTar)}, nil
+	}
+	return bs.BlobStore.Open(ctx, dgst)
+}
+
+// ServeBlob serves the empty layer onto w without reading it from the wrapped store.
+func (bs *emptyTarBlobStore) ServeBlob(ctx context.Context, w http.ResponseWriter, req *http.Request, dgst digest.Digest) error {
+	if dgst != digestSHA256GzippedEmptyTar {
+		return bs.BlobStore.ServeBlob(ctx, w, req, dgst)
+	}
+	setResponseHeaders(w, emptyTarDescriptor.Size, emptyTarDescriptor.MediaType, dgst)
+	http.ServeContent(w, req, "", time.Time{}, bytes.NewReader(gzippedEmptyTar))
+	return nil
+}
+
+// nopCloser turns a bytes.Reader into a distribution.ReadSeekCloser.
+type nopCloser struct {
+	*bytes.Reader
+}
+
+func (nopCloser) Close() error {
+	return nil
+}
@@ -0,0 +1,62 @@
+package server
+
+import (
+	"bytes"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+)
+
+// failingBlobStore fails every operation on the blob store.
+type failingBlobStore struct {
+	distribution.BlobStore
+}
+
+func (failingBlobStore) Stat(ctx context.Context, dgst digest.Digest) (distribution.Descriptor, error) {
+	return distribution.Descriptor{}, distribution.ErrBlobUnknown
+}
+
+func TestEmptyTarBlobStore(t *testing.T) {
+	ctx := context.Background()
+	bs := &emptyTarBlobStore{BlobStore: failingBlobStore{}}
+
+	desc, err := bs.Stat(ctx, digestSHA256GzippedEmptyTar)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if desc.Size != int64(len(gzippedEmptyTar)) || desc.Digest != digestSHA256GzippedEmptyTar {
+		t.Errorf("unexpected descriptor: %#v", desc)
+	}
+	if _, err := bs.Stat(ctx, digest.Digest("sha256:b194de3772ebbcdc8f244f663669799ac1cb141834b7cb8b69100285d357a2b0")); err != distribution.ErrBlobUnknown {
+		t.Errorf("expected other blobs to be looked up in the wrapped store, got %v", err)
+	}
+
+	r, err := bs.Open(ctx, digestSHA256GzippedEmptyTar)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	content, err := ioutil.ReadAll(r)
+	if err != nil || !bytes.Equal(content, gzippedEmptyTar) {
+		t.Errorf("unexpected content %v: %v", content, err)
+	}
+
+	w := httptest.NewRecorder()
+	req, err := http.NewRequest("GET", "/v2/test/app/blobs/"+digestSHA256GzippedEmptyTar.String(), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := bs.ServeBlob(ctx, w, req, digestSHA256GzippedEmptyTar); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if w.Code != http.StatusOK || !bytes.Equal(w.Body.Bytes(), gzippedEmptyTar) {
+		t.Errorf("unexpected response %d: %v", w.Code, w.Body.Bytes())
+	}
+	if w.Header().Get("Docker-Content-Digest") != digestSHA256GzippedEmptyTar.String() {
+		t.Errorf("unexpected headers: %v", w.Header())
+	}
+}
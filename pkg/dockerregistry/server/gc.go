@@ -0,0 +1,306 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/docker/distribution"
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/registry/api/errcode"
+	"github.com/docker/distribution/registry/handlers"
+	"github.com/docker/distribution/registry/storage"
+	storagedriver "github.com/docker/distribution/registry/storage/driver"
+	"github.com/docker/distribution/registry/storage/driver/factory"
+	gorillahandlers "github.com/gorilla/handlers"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/flowcontrol"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	"github.com/openshift/origin/pkg/client"
+	registryapi "github.com/openshift/origin/pkg/dockerregistry/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const (
+	// defaultGCKeepYoungerThan is the default minimum age of the blobs and uploads the garbage
+	// collection deletes. Younger ones may belong to pushes in progress.
+	defaultGCKeepYoungerThan = time.Hour
+	// defaultGCDeletionsPerSecond is the default rate the garbage collection deletes at.
+	defaultGCDeletionsPerSecond = 10
+)
+
+// gcLock makes sure a single garbage collection runs at a time in this registry process. The
+// registries sharing a storage don't know about each other's collections, so the collection must
+// only be requested from one of them at a time.
+var gcLock = make(chan struct{}, 1)
+
+// GarbageCollectionDispatcher takes the request context and builds the appropriate handler for
+// handling garbage collection requests.
+func GarbageCollectionDispatcher(ctx *handlers.Context, r *http.Request) http.Handler {
+	gcHandler := &gcHandler{
+		Context: ctx,
+	}
+
+	return gorillahandlers.MethodHandler{
+		"POST": http.HandlerFunc(gcHandler.Collect),
+	}
+}
+
+// gcHandler handles http operations on the garbage of the registry.
+type gcHandler struct {
+	*handlers.Context
+}
+
+// Collect deletes the blobs no image references and the leftovers of uploads. Unless the dryRun
+// parameter is false, it only reports what it would delete.
+func (gh *gcHandler) Collect(w http.ResponseWriter, req *http.Request) {
+	defer req.Body.Close()
+
+	gc, err := gh.newGarbageCollector(req)
+	if err != nil {
+		gh.Errors = append(gh.Errors, errcode.ErrorCodeUnsupported.WithDetail(err.Error()))
+		return
+	}
+
+	select {
+	case gcLock <- struct{}{}:
+		defer func() { <-gcLock }()
+	default:
+		gh.Errors = append(gh.Errors, errcode.ErrorCodeUnavailable.WithDetail("a garbage collection is already running"))
+		return
+	}
+
+	report, err := gc.collect()
+	if err != nil {
+		gh.Errors = append(gh.Errors, errcode.ErrorCodeUnknown.WithDetail(err.Error()))
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(report); err != nil {
+		context.GetLogger(gh).Errorf("error writing the garbage collection report: %v", err)
+	}
+}
+
+func (gh *gcHandler) newGarbageCollector(req *http.Request) (*garbageCollector, error) {
+	userClient, ok := UserClientFrom(gh)
+	if !ok {
+		return nil, fmt.Errorf("no user client in the request context")
+	}
+	enumerator, err := storage.RegistryBlobEnumerator(gh.Namespace())
+	if err != nil {
+		return nil, err
+	}
+	deleter, err := storage.RegistryBlobDeleter(gh.Namespace())
+	if err != nil {
+		return nil, err
+	}
+	// the registry doesn't expose its storage driver, create one from its configuration the way its
+	// upload purger does
+	driver, err := factory.Create(gh.Config.Storage.Type(), gh.Config.Storage.Parameters())
+	if err != nil {
+		return nil, err
+	}
+
+	gc := &garbageCollector{
+		ctx:             gh,
+		driver:          driver,
+		enumerator:      enumerator,
+		deleter:         deleter,
+		images:          userClient.Images(),
+		dryRun:          true,
+		keepYoungerThan: defaultGCKeepYoungerThan,
+		now:             time.Now,
+	}
+	rate := float64(defaultGCDeletionsPerSecond)
+
+	query := req.URL.Query()
+	if value := query.Get("dryRun"); len(value) > 0 {
+		if gc.dryRun, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("invalid dryRun: %v", err)
+		}
+	}
+	if value := query.Get("keepYoungerThan"); len(value) > 0 {
+		if gc.keepYoungerThan, err = time.ParseDuration(value); err != nil || gc.keepYoungerThan < 0 {
+			return nil, fmt.Errorf("invalid keepYoungerThan %q", value)
+		}
+	}
+	if value := query.Get("deletionsPerSecond"); len(value) > 0 {
+		if rate, err = strconv.ParseFloat(value, 32); err != nil || rate <= 0 {
+			return nil, fmt.Errorf("invalid deletionsPerSecond %q", value)
+		}
+	}
+	gc.limiter = flowcontrol.NewTokenBucketRateLimiter(float32(rate), 1)
+	return gc, nil
+}
+
+// garbageCollector marks the blobs referenced by the images of the cluster and by the manifests
+// stored in the registry, and sweeps the other blobs of the global blob store. Blobs, layer links
+// and uploads younger than keepYoungerThan are kept, as they may belong to images being pushed.
+type garbageCollector struct {
+	ctx        context.Context
+	driver     storagedriver.StorageDriver
+	enumerator distribution.BlobEnumerator
+	deleter    distribution.BlobDeleter
+	images     client.ImageInterface
+	limiter    flowcontrol.RateLimiter
+
+	dryRun          bool
+	keepYoungerThan time.Duration
+	now             func() time.Time
+}
+
+func (gc *garbageCollector) collect() (*registryapi.GarbageCollectionReport, error) {
+	defer gc.limiter.Stop()
+	log := context.GetLogger(gc.ctx)
+	report := &registryapi.GarbageCollectionReport{DryRun: gc.dryRun}
+	olderThan := gc.now().Add(-gc.keepYoungerThan)
+
+	marked, err := gc.mark(olderThan)
+	if err != nil {
+		return nil, err
+	}
+	log.Infof("garbage collection: %d blobs are referenced", marked.Len())
+
+	candidates := []registryapi.GarbageCollectedBlob{}
+	err = gc.enumerator.Enumerate(gc.ctx, func(dgst digest.Digest) error {
+		if marked.Has(dgst.String()) {
+			return nil
+		}
+		blobPath, err := storage.BlobDataPath(dgst)
+		if err != nil {
+			return err
+		}
+		info, err := gc.driver.Stat(gc.ctx, blobPath)
+		if err != nil {
+			if _, ok := err.(storagedriver.PathNotFoundError); ok {
+				return nil
+			}
+			return err
+		}
+		if info.ModTime().After(olderThan) {
+			return nil
+		}
+		candidates = append(candidates, registryapi.GarbageCollectedBlob{Digest: dgst.String(), Size: info.Size()})
+		return nil
+	})
+	if err != nil && err != io.EOF {
+		return nil, fmt.Errorf("unable to enumerate the blobs: %v", err)
+	}
+
+	if len(candidates) > 0 && !gc.dryRun {
+		// images may have been created since the blobs were marked, check them again right before
+		// deleting anything
+		if marked, err = gc.mark(olderThan); err != nil {
+			return nil, err
+		}
+	}
+	for _, blob := range candidates {
+		if marked.Has(blob.Digest) {
+			continue
+		}
+		if !gc.dryRun {
+			gc.limiter.Accept()
+			if err := gc.deleter.Delete(gc.ctx, digest.Digest(blob.Digest)); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("error deleting blob %s: %v", blob.Digest, err))
+				continue
+			}
+		}
+		report.Blobs = append(report.Blobs, blob)
+	}
+
+	uploads, errs := storage.PurgeUploads(gc.ctx, gc.driver, olderThan, false)
+	for _, err := range errs {
+		if _, ok := err.(storagedriver.PathNotFoundError); ok {
+			continue
+		}
+		report.Errors = append(report.Errors, fmt.Sprintf("error listing uploads: %v", err))
+	}
+	for _, upload := range uploads {
+		if !gc.dryRun {
+			gc.limiter.Accept()
+			if err := gc.driver.Delete(gc.ctx, upload); err != nil {
+				report.Errors = append(report.Errors, fmt.Sprintf("error deleting upload %s: %v", upload, err))
+				continue
+			}
+		}
+		report.Uploads = append(report.Uploads, upload)
+	}
+
+	log.Infof("garbage collection: dryRun=%t, %d blobs, %d uploads, %d errors", gc.dryRun, len(report.Blobs), len(report.Uploads), len(report.Errors))
+	return report, nil
+}
+
+// mark returns the digests of the blobs referenced by images or by the manifests and signatures
+// stored in the repositories, and of the blobs linked to a repository after olderThan. A push
+// reusing a blob only links it to the repository until its manifest is put, so its blobs aren't
+// referenced by anything else yet.
+func (gc *garbageCollector) mark(olderThan time.Time) (sets.String, error) {
+	marked := sets.NewString(digestSHA256GzippedEmptyTar.String())
+
+	images, err := gc.images.List(kapi.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("unable to list images: %v", err)
+	}
+	for i := range images.Items {
+		markImage(marked, &images.Items[i])
+	}
+
+	root, err := storage.RepositoriesRootPath()
+	if err != nil {
+		return nil, err
+	}
+	err = storage.Walk(gc.ctx, gc.driver, root, func(info storagedriver.FileInfo) error {
+		name := path.Base(info.Path())
+		if info.IsDir() {
+			// uploads are handled separately
+			if name == "_uploads" {
+				return storage.ErrSkipDir
+			}
+			return nil
+		}
+		if name != "link" {
+			return nil
+		}
+		// old layer links don't keep blobs, the images using them do
+		if strings.Contains(info.Path(), "/_layers/") && !info.ModTime().After(olderThan) {
+			return nil
+		}
+		content, err := gc.driver.GetContent(gc.ctx, info.Path())
+		if err != nil {
+			return err
+		}
+		if dgst, err := digest.ParseDigest(string(content)); err == nil {
+			marked.Insert(dgst.String())
+		}
+		return nil
+	})
+	if err != nil {
+		if _, ok := err.(storagedriver.PathNotFoundError); !ok {
+			return nil, fmt.Errorf("unable to walk the repositories: %v", err)
+		}
+	}
+	return marked, nil
+}
+
+// markImage adds the digests of the manifest, configuration and layers of image to marked.
+func markImage(marked sets.String, image *imageapi.Image) {
+	marked.Insert(image.Name)
+	for _, layer := range image.DockerImageLayers {
+		marked.Insert(layer.Name)
+	}
+	if len(image.DockerImageConfig) > 0 {
+		manifest := imageapi.DockerImageManifest{}
+		if err := json.Unmarshal([]byte(image.DockerImageManifest), &manifest); err == nil && len(manifest.Config.Digest) > 0 {
+			marked.Insert(manifest.Config.Digest)
+		}
+	}
+}
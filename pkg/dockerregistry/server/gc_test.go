@@ -0,0 +1,134 @@
+package server
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/registry/storage"
+	"github.com/docker/distribution/registry/storage/driver/inmemory"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/util/flowcontrol"
+
+	"github.com/openshift/origin/pkg/client/testclient"
+	registryapi "github.com/openshift/origin/pkg/dockerregistry/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func TestGarbageCollector(t *testing.T) {
+	ctx := context.Background()
+	driver := inmemory.New()
+	registry, err := storage.NewRegistry(ctx, driver, storage.EnableDelete)
+	if err != nil {
+		t.Fatal(err)
+	}
+	repo, err := registry.Repository(ctx, "test/app")
+	if err != nil {
+		t.Fatal(err)
+	}
+	put := func(content string) digest.Digest {
+		desc, err := repo.Blobs(ctx).Put(ctx, "application/octet-stream", []byte(content))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return desc.Digest
+	}
+	layer := put("layer")
+	config := put("config")
+	signature := put("signature")
+	orphan := put("orphan")
+
+	// an upload which was never finished
+	if _, err := repo.Blobs(ctx).Create(ctx); err != nil {
+		t.Fatal(err)
+	}
+	// the signatures of manifests are linked from the repository
+	root, err := storage.RepositoriesRootPath()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signatureLink := root + "/test/app/_manifests/revisions/sha256/" + layer.Hex() + "/signatures/sha256/" + signature.Hex() + "/link"
+	if err := driver.PutContent(ctx, signatureLink, []byte(signature)); err != nil {
+		t.Fatal(err)
+	}
+
+	image := &imageapi.Image{
+		ObjectMeta:          kapi.ObjectMeta{Name: "sha256:0000000000000000000000000000000000000000000000000000000000000000"},
+		DockerImageManifest: `{"schemaVersion":2,"config":{"digest":"` + config.String() + `"}}`,
+		DockerImageConfig:   "{}",
+		DockerImageLayers:   []imageapi.ImageLayer{{Name: layer.String()}},
+	}
+	enumerator, err := storage.RegistryBlobEnumerator(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	deleter, err := storage.RegistryBlobDeleter(registry)
+	if err != nil {
+		t.Fatal(err)
+	}
+	newGC := func(dryRun bool, now time.Time) *garbageCollector {
+		return &garbageCollector{
+			ctx:             ctx,
+			driver:          driver,
+			enumerator:      enumerator,
+			deleter:         deleter,
+			images:          testclient.NewSimpleFake(image).Images(),
+			limiter:         flowcontrol.NewFakeAlwaysRateLimiter(),
+			dryRun:          dryRun,
+			keepYoungerThan: time.Hour,
+			now:             func() time.Time { return now },
+		}
+	}
+
+	// young blobs and uploads are kept
+	report, err := newGC(false, time.Now()).collect()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(report.Blobs) != 0 || len(report.Uploads) != 0 {
+		t.Errorf("unexpected report: %#v", report)
+	}
+
+	later := time.Now().Add(2 * time.Hour)
+	for _, dryRun := range []bool{true, false} {
+		report, err := newGC(dryRun, later).collect()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		expected := []registryapi.GarbageCollectedBlob{{Digest: orphan.String(), Size: int64(len("orphan"))}}
+		if report.DryRun != dryRun || !reflect.DeepEqual(report.Blobs, expected) || len(report.Uploads) != 1 || len(report.Errors) != 0 {
+			t.Errorf("dryRun=%t: unexpected report: %#v", dryRun, report)
+		}
+	}
+
+	for _, dgst := range []digest.Digest{layer, config, signature, orphan} {
+		_, err := registry.Blobs().Stat(ctx, dgst)
+		if deleted := err != nil; deleted != (dgst == orphan) {
+			t.Errorf("%s: unexpected blob state: %v", dgst, err)
+		}
+	}
+	if report, err := newGC(true, later).collect(); err != nil || len(report.Blobs) != 0 || len(report.Uploads) != 0 {
+		t.Errorf("expected nothing left to collect: %#v %v", report, err)
+	}
+
+	// a push in progress may reuse an old blob no image references yet, by linking it to another
+	// repository
+	reused := put("reused")
+	linkedAfter := time.Now()
+	time.Sleep(time.Millisecond)
+	layerLink := root + "/test/other/_layers/sha256/" + reused.Hex() + "/link"
+	if err := driver.PutContent(ctx, layerLink, []byte(reused)); err != nil {
+		t.Fatal(err)
+	}
+	if report, err := newGC(true, linkedAfter.Add(time.Hour)).collect(); err != nil || len(report.Blobs) != 0 {
+		t.Errorf("expected the newly linked blob to be kept: %#v %v", report, err)
+	}
+	report, err = newGC(true, later).collect()
+	expected := []registryapi.GarbageCollectedBlob{{Digest: reused.String(), Size: int64(len("reused"))}}
+	if err != nil || !reflect.DeepEqual(report.Blobs, expected) {
+		t.Errorf("expected the blob to be collected once the link is old: %#v %v", report, err)
+	}
+}
@@ -0,0 +1,197 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/libtrust"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// gzippedEmptyTar is a gzipped empty tar archive. Schema1 manifests reference it in place of the
+// layers of history entries which didn't create one.
+var gzippedEmptyTar = []byte{
+	31, 139, 8, 0, 0, 9, 110, 136, 0, 255, 98, 24, 5, 163, 96, 20, 140, 88,
+	0, 8, 0, 0, 255, 255, 46, 175, 181, 239, 0, 4, 0, 0,
+}
+
+// digestSHA256GzippedEmptyTar is the digest of gzippedEmptyTar.
+const digestSHA256GzippedEmptyTar = digest.Digest("sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4")
+
+// newSchema1SigningKey returns the key signing the schema1 manifests converted from schema2 manifests,
+// loaded from the file given by the schema1signingkeyfile option or by signingKeyFile, which overrides
+// it. As in upstream registries, a key is generated when none is configured, which makes replicas of
+// the registry sign the manifests of the same image with different keys.
+func newSchema1SigningKey(ctx context.Context, signingKeyFile string, options map[string]interface{}) (libtrust.PrivateKey, error) {
+	path := signingKeyFile
+	if len(path) == 0 {
+		if value, ok := options["schema1signingkeyfile"].(string); ok {
+			path = value
+		}
+	}
+	if len(path) == 0 {
+		context.GetLogger(ctx).Warn("no schema1 signing key file configured, signing converted manifests with a generated key")
+		return libtrust.GenerateECP256PrivateKey()
+	}
+	key, err := libtrust.LoadKeyFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load the schema1 signing key from %s: %v", path, err)
+	}
+	context.GetLogger(ctx).Infof("signing converted manifests with the schema1 signing key from %s", path)
+	return key, nil
+}
+
+// v1Compatibility is the v1 compatibility information of the schema1 history entries which
+// correspond to the history of a schema2 image configuration.
+type v1Compatibility struct {
+	ID              string    `json:"id"`
+	Parent          string    `json:"parent,omitempty"`
+	Comment         string    `json:"comment,omitempty"`
+	Created         time.Time `json:"created"`
+	ContainerConfig struct {
+		Cmd []string
+	} `json:"container_config,omitempty"`
+	Author    string `json:"author,omitempty"`
+	ThrowAway bool   `json:"throwaway,omitempty"`
+}
+
+// convertSchema2Manifest converts the schema2 manifest of image to a schema1 manifest of the
+// repository name with the given tag, signed by key. History entries which didn't create a layer
+// reference gzippedEmptyTar, which is served by emptyTarBlobStore.
+func convertSchema2Manifest(image *imageapi.Image, name, tag string, key libtrust.PrivateKey) (*schema1.SignedManifest, error) {
+	manifest := imageapi.DockerImageManifest{}
+	if err := json.Unmarshal([]byte(image.DockerImageManifest), &manifest); err != nil {
+		return nil, err
+	}
+	if len(image.DockerImageConfig) == 0 {
+		return nil, fmt.Errorf("the configuration of image %s is unknown", image.Name)
+	}
+	config := imageapi.DockerImageConfig{}
+	if err := json.Unmarshal([]byte(image.DockerImageConfig), &config); err != nil {
+		return nil, err
+	}
+
+	history := config.History
+	if len(history) == 0 {
+		// images without history get a history entry for each layer
+		history = make([]imageapi.DockerConfigHistory, len(manifest.Layers))
+		for i := range history {
+			history[i].Created = config.Created
+		}
+	}
+	layers := 0
+	for _, h := range history {
+		if !h.EmptyLayer {
+			layers++
+		}
+	}
+	if layers != len(manifest.Layers) {
+		return nil, fmt.Errorf("the history of image %s describes %d layers, but its manifest has %d", image.Name, layers, len(manifest.Layers))
+	}
+	if len(history) == 0 {
+		return nil, fmt.Errorf("image %s has no layers", image.Name)
+	}
+
+	// schema1 lists the layers and their history from the highest to the lowest
+	fsLayers := make([]schema1.FSLayer, len(history))
+	v1History := make([]schema1.History, len(history))
+	parent := ""
+	layer := 0
+	for i, h := range history {
+		blobSum := digestSHA256GzippedEmptyTar
+		if !h.EmptyLayer {
+			blobSum = digest.Digest(manifest.Layers[layer].Digest)
+			layer++
+		}
+		index := len(history) - i - 1
+		fsLayers[index].BlobSum = blobSum
+
+		if i == len(history)-1 {
+			// the highest entry holds the image configuration
+			id, err := digest.FromBytes([]byte(blobSum.Hex() + " " + parent + " " + image.DockerImageConfig))
+			if err != nil {
+				return nil, err
+			}
+			compatibility, err := v1ConfigFromConfig([]byte(image.DockerImageConfig), id.Hex(), parent, h.EmptyLayer)
+			if err != nil {
+				return nil, err
+			}
+			v1History[index].V1Compatibility = string(compatibility)
+			break
+		}
+
+		id, err := digest.FromBytes([]byte(blobSum.Hex() + " " + parent))
+		if err != nil {
+			return nil, err
+		}
+		entry := v1Compatibility{
+			ID:        id.Hex(),
+			Parent:    parent,
+			Comment:   h.Comment,
+			Created:   h.Created.Time,
+			Author:    h.Author,
+			ThrowAway: h.EmptyLayer,
+		}
+		entry.ContainerConfig.Cmd = []string{h.CreatedBy}
+		compatibility, err := json.Marshal(&entry)
+		if err != nil {
+			return nil, err
+		}
+		v1History[index].V1Compatibility = string(compatibility)
+		parent = entry.ID
+	}
+
+	signed, err := schema1.Sign(&schema1.Manifest{
+		Versioned:    schema1.SchemaVersion,
+		Name:         name,
+		Tag:          tag,
+		Architecture: config.Architecture,
+		FSLayers:     fsLayers,
+		History:      v1History,
+	}, key)
+	if err != nil {
+		return nil, err
+	}
+	return signed, nil
+}
+
+// v1ConfigFromConfig turns a schema2 image configuration into the v1 compatibility information of
+// the highest schema1 history entry.
+func v1ConfigFromConfig(config []byte, id, parent string, throwAway bool) ([]byte, error) {
+	fields := map[string]*json.RawMessage{}
+	if err := json.Unmarshal(config, &fields); err != nil {
+		return nil, err
+	}
+	// these fields don't exist in v1 images
+	delete(fields, "rootfs")
+	delete(fields, "history")
+
+	set := func(name string, value interface{}) error {
+		data, err := json.Marshal(value)
+		if err != nil {
+			return err
+		}
+		raw := json.RawMessage(data)
+		fields[name] = &raw
+		return nil
+	}
+	if err := set("id", id); err != nil {
+		return nil, err
+	}
+	if len(parent) > 0 {
+		if err := set("parent", parent); err != nil {
+			return nil, err
+		}
+	}
+	if throwAway {
+		if err := set("throwaway", true); err != nil {
+			return nil, err
+		}
+	}
+	return json.Marshal(fields)
+}
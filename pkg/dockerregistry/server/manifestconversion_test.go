@@ -0,0 +1,160 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/docker/distribution/context"
+	"github.com/docker/distribution/digest"
+	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/libtrust"
+
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const (
+	testSchema2Manifest = `{
+   "schemaVersion": 2,
+   "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+   "config": {
+      "mediaType": "application/vnd.docker.container.image.v1+json",
+      "size": 400,
+      "digest": "sha256:2d24f826cb16146e2016ff349a8a33ed5830f3b938d45c0f82943f4ab8c097e7"
+   },
+   "layers": [
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 1024,
+         "digest": "sha256:b194de3772ebbcdc8f244f663669799ac1cb141834b7cb8b69100285d357a2b0"
+      },
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 2048,
+         "digest": "sha256:c937c4bb1c1a21cc6d94340812262c6472092028972ae69b551b1a70d4276171"
+      }
+   ]
+}`
+	testSchema2Config = `{"architecture":"amd64","config":{"Cmd":["/bin/sh"]},"created":"2016-04-01T12:00:00Z","os":"linux",` +
+		`"rootfs":{"type":"layers","diff_ids":["sha256:1","sha256:2"]},` +
+		`"history":[{"created":"2016-04-01T11:00:00Z","created_by":"ADD file:1 in /"},` +
+		`{"created":"2016-04-01T11:30:00Z","created_by":"ENV A=B","empty_layer":true},` +
+		`{"created":"2016-04-01T12:00:00Z","created_by":"RUN make"}]}`
+)
+
+func TestGzippedEmptyTarDigest(t *testing.T) {
+	dgst, err := digest.FromBytes(gzippedEmptyTar)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dgst != digestSHA256GzippedEmptyTar {
+		t.Errorf("unexpected digest of the empty tar: %s", dgst)
+	}
+}
+
+func TestConvertSchema2Manifest(t *testing.T) {
+	image := &imageapi.Image{
+		DockerImageManifest:          testSchema2Manifest,
+		DockerImageManifestMediaType: imageapi.MediaTypeDockerSchema2Manifest,
+		DockerImageConfig:            testSchema2Config,
+	}
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	signed, err := convertSchema2Manifest(image, "test/app", "latest", key)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if signed.Name != "test/app" || signed.Tag != "latest" || signed.Architecture != "amd64" {
+		t.Errorf("unexpected manifest: %#v", signed.Manifest)
+	}
+	if _, err := schema1.Verify(signed); err != nil {
+		t.Errorf("unexpected signature error: %v", err)
+	}
+
+	expectedLayers := []digest.Digest{
+		"sha256:c937c4bb1c1a21cc6d94340812262c6472092028972ae69b551b1a70d4276171",
+		digestSHA256GzippedEmptyTar,
+		"sha256:b194de3772ebbcdc8f244f663669799ac1cb141834b7cb8b69100285d357a2b0",
+	}
+	if len(signed.FSLayers) != len(expectedLayers) || len(signed.History) != len(expectedLayers) {
+		t.Fatalf("unexpected layers: %#v %#v", signed.FSLayers, signed.History)
+	}
+	for i, layer := range signed.FSLayers {
+		if layer.BlobSum != expectedLayers[i] {
+			t.Errorf("layer %d: expected %s, got %s", i, expectedLayers[i], layer.BlobSum)
+		}
+	}
+
+	entries := make([]map[string]interface{}, len(signed.History))
+	for i, h := range signed.History {
+		if err := json.Unmarshal([]byte(h.V1Compatibility), &entries[i]); err != nil {
+			t.Fatalf("history %d: %v", i, err)
+		}
+	}
+	// each entry is the parent of the one above it
+	for i := 0; i < len(entries)-1; i++ {
+		if entries[i]["parent"] != entries[i+1]["id"] {
+			t.Errorf("history %d: unexpected parent %v", i, entries[i]["parent"])
+		}
+	}
+	if _, ok := entries[len(entries)-1]["parent"]; ok {
+		t.Errorf("unexpected parent of the lowest entry: %v", entries[len(entries)-1])
+	}
+	if entries[1]["throwaway"] != true || entries[0]["throwaway"] != nil {
+		t.Errorf("expected only the empty layer to be thrown away: %v", entries)
+	}
+	// the highest entry holds the configuration without the fields unknown to v1 images
+	if entries[0]["architecture"] != "amd64" || entries[0]["rootfs"] != nil || entries[0]["history"] != nil {
+		t.Errorf("unexpected configuration: %v", entries[0])
+	}
+
+	image.DockerImageConfig = `{"history":[{"created_by":"ADD file:1 in /"}]}`
+	if _, err := convertSchema2Manifest(image, "test/app", "latest", key); err == nil {
+		t.Errorf("expected an error for a history not matching the layers")
+	}
+}
+
+func TestNewSchema1SigningKey(t *testing.T) {
+	dir, err := ioutil.TempDir("", "schema1-signing-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "key.json")
+	if err := libtrust.SaveKey(path, key); err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+
+	loaded, err := newSchema1SigningKey(ctx, "", map[string]interface{}{"schema1signingkeyfile": path})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.KeyID() != key.KeyID() {
+		t.Errorf("expected the key from the option to be loaded, got %s", loaded.KeyID())
+	}
+
+	loaded, err = newSchema1SigningKey(ctx, path, map[string]interface{}{"schema1signingkeyfile": filepath.Join(dir, "missing.json")})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if loaded.KeyID() != key.KeyID() {
+		t.Errorf("expected the key from the environment to be loaded, got %s", loaded.KeyID())
+	}
+
+	if _, err := newSchema1SigningKey(ctx, filepath.Join(dir, "missing.json"), nil); err == nil {
+		t.Errorf("expected an error for a missing key file")
+	}
+
+	if generated, err := newSchema1SigningKey(ctx, "", nil); err != nil || generated == nil {
+		t.Errorf("expected a key to be generated, got %v", err)
+	}
+}
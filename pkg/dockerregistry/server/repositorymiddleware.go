@@ -11,6 +11,7 @@ import (
 	"github.com/docker/distribution/context"
 	"github.com/docker/distribution/digest"
 	"github.com/docker/distribution/manifest/schema1"
+	"github.com/docker/distribution/registry/api/errcode"
 	repomw "github.com/docker/distribution/registry/middleware/repository"
 	"github.com/docker/libtrust"
 
@@ -40,6 +41,12 @@ const (
 	// objects. It takes a valid time duration string (e.g. "2m"). If empty, you get the default timeout. If
 	// zero (e.g. "0m"), caching is disabled.
 	ProjectCacheTTLEnvVar = "REGISTRY_MIDDLEWARE_REPOSITORY_OPENSHIFT_PROJECTCACHETTL"
+
+	// Schema1SigningKeyFileEnvVar is an environment variable specifying the file holding the private key
+	// which signs the schema1 manifests converted from schema2 manifests for older clients, for instance
+	// one mounted from a secret shared by all the replicas of the registry. It overrides the
+	// schema1signingkeyfile option of the openshift middleware.
+	Schema1SigningKeyFileEnvVar = "REGISTRY_MIDDLEWARE_REPOSITORY_OPENSHIFT_SCHEMA1SIGNINGKEYFILE"
 )
 
 var (
@@ -55,6 +62,9 @@ var (
 	// quotaEnforcing contains shared caches of quota objects keyed by project name. Will be initialized
 	// only if the quota is enforced. See EnforceQuotaEnvVar.
 	quotaEnforcing *quotaEnforcingConfig
+	// schema1SigningKey signs the schema1 manifests converted from schema2 manifests. Will be loaded
+	// when the middleware is first initialized. See Schema1SigningKeyFileEnvVar.
+	schema1SigningKey libtrust.PrivateKey
 )
 
 func init() {
@@ -75,6 +85,11 @@ func init() {
 			if quotaEnforcing == nil {
 				quotaEnforcing = newQuotaEnforcingConfig(ctx, os.Getenv(EnforceQuotaEnvVar), os.Getenv(ProjectCacheTTLEnvVar), options)
 			}
+			if schema1SigningKey == nil {
+				if schema1SigningKey, err = newSchema1SigningKey(ctx, os.Getenv(Schema1SigningKeyFileEnvVar), options); err != nil {
+					return nil, err
+				}
+			}
 			return newRepositoryWithClient(registryOSClient, kClient, kClient, ctx, repo, options)
 		},
 	)
@@ -106,9 +121,12 @@ type repository struct {
 	// having to check every potential upstream repository when a blob request is made. The cache is useful only
 	// when session affinity is on for the registry, but in practice the first pull will fill the cache.
 	cachedLayers digestToRepositoryCache
+	// schema1SigningKey signs the schema1 manifests converted from schema2 manifests
+	schema1SigningKey libtrust.PrivateKey
 }
 
 var _ distribution.ManifestService = &repository{}
+var _ distribution.RawManifestService = &repository{}
 
 // newRepositoryWithClient returns a new repository middleware.
 func newRepositoryWithClient(
@@ -139,15 +157,16 @@ func newRepositoryWithClient(
 	return &repository{
 		Repository: repo,
 
-		ctx:              ctx,
-		quotaClient:      quotaClient,
-		limitClient:      limitClient,
-		registryOSClient: registryOSClient,
-		registryAddr:     registryAddr,
-		namespace:        nameParts[0],
-		name:             nameParts[1],
-		pullthrough:      pullthrough,
-		cachedLayers:     cachedLayers,
+		ctx:               ctx,
+		quotaClient:       quotaClient,
+		limitClient:       limitClient,
+		registryOSClient:  registryOSClient,
+		registryAddr:      registryAddr,
+		namespace:         nameParts[0],
+		name:              nameParts[1],
+		pullthrough:       pullthrough,
+		cachedLayers:      cachedLayers,
+		schema1SigningKey: schema1SigningKey,
 	}, nil
 }
 
@@ -166,7 +185,7 @@ func (r *repository) Blobs(ctx context.Context) distribution.BlobStore {
 	repo := repository(*r)
 	repo.ctx = ctx
 
-	bs := r.Repository.Blobs(ctx)
+	var bs distribution.BlobStore = &emptyTarBlobStore{BlobStore: r.Repository.Blobs(ctx)}
 
 	if !quotaEnforcing.enforcementDisabled {
 		bs = &quotaRestrictedBlobStore{
@@ -224,19 +243,36 @@ func (r *repository) ExistsByTag(tag string) (bool, error) {
 
 // Get retrieves the manifest with digest `dgst`.
 func (r *repository) Get(dgst digest.Digest) (*schema1.SignedManifest, error) {
+	return signedManifest(r.GetRaw(dgst))
+}
+
+// GetRaw retrieves the manifest with digest `dgst`, or with the tag passed with
+// distribution.WithTag, in one of the media types passed with distribution.WithManifestMediaTypes.
+func (r *repository) GetRaw(dgst digest.Digest, options ...distribution.ManifestServiceOption) (string, []byte, error) {
+	tag, accept, options := splitRawManifestOptions(options)
+	if len(tag) > 0 {
+		return r.getRawByTag(tag, accept, options...)
+	}
+
 	if _, err := r.getImageStreamImage(dgst); err != nil {
 		context.GetLogger(r.ctx).Errorf("error retrieving ImageStreamImage %s/%s@%s: %v", r.namespace, r.name, dgst.String(), err)
-		return nil, err
+		return "", nil, err
 	}
 
 	image, err := r.getImage(dgst)
 	if err != nil {
 		context.GetLogger(r.ctx).Errorf("error retrieving image %s: %v", dgst.String(), err)
-		return nil, err
+		return "", nil, err
+	}
+
+	// a converted manifest would have another digest than the requested one
+	if mediaType := manifestMediaType(image); mediaType != imageapi.MediaTypeDockerSchema1SignedManifest && !acceptsMediaType(accept, mediaType) {
+		context.GetLogger(r.ctx).Errorf("the manifest of image %s is a %s the client doesn't accept", dgst.String(), mediaType)
+		return "", nil, distribution.ErrManifestUnknownRevision{Name: r.Name(), Revision: dgst}
 	}
 
 	ref := imageapi.DockerImageReference{Namespace: r.namespace, Name: r.name, Registry: r.registryAddr}
-	return r.manifestFromImageWithCachedLayers(image, ref.DockerClientDefaults().Exact())
+	return r.manifestFromImageWithCachedLayers(image, ref.DockerClientDefaults().Exact(), "", accept)
 }
 
 // Enumerate retrieves digests of manifest revisions in particular repository
@@ -246,9 +282,34 @@ func (r *repository) Enumerate() ([]digest.Digest, error) {
 
 // GetByTag retrieves the named manifest with the provided tag
 func (r *repository) GetByTag(tag string, options ...distribution.ManifestServiceOption) (*schema1.SignedManifest, error) {
+	return signedManifest(r.getRawByTag(tag, nil, options...))
+}
+
+// splitRawManifestOptions returns the tag and the accepted media types passed in options, along with
+// the remaining options.
+func splitRawManifestOptions(options []distribution.ManifestServiceOption) (string, []string, []distribution.ManifestServiceOption) {
+	var (
+		tag    string
+		accept []string
+		rest   []distribution.ManifestServiceOption
+	)
+	for _, option := range options {
+		switch opt := option.(type) {
+		case distribution.WithTagOption:
+			tag = opt.Tag
+		case distribution.WithManifestMediaTypesOption:
+			accept = opt.MediaTypes
+		default:
+			rest = append(rest, option)
+		}
+	}
+	return tag, accept, rest
+}
+
+func (r *repository) getRawByTag(tag string, accept []string, options ...distribution.ManifestServiceOption) (string, []byte, error) {
 	for _, opt := range options {
-		if err := opt(r); err != nil {
-			return nil, err
+		if err := opt.Apply(r); err != nil {
+			return "", nil, err
 		}
 	}
 
@@ -257,7 +318,7 @@ func (r *repository) GetByTag(tag string, options ...distribution.ManifestServic
 	if err != nil {
 		// TODO: typed errors
 		context.GetLogger(r.ctx).Errorf("error getting ImageStreamTag %q: %v", tag, err)
-		return nil, err
+		return "", nil, err
 	}
 	image := &imageStreamTag.Image
 
@@ -272,44 +333,44 @@ func (r *repository) GetByTag(tag string, options ...distribution.ManifestServic
 
 	// if we have a local manifest, use it
 	if len(image.DockerImageManifest) > 0 {
-		return r.manifestFromImageWithCachedLayers(image, cacheName)
+		return r.manifestFromImageWithCachedLayers(image, cacheName, tag, accept)
 	}
 
 	dgst, err := digest.ParseDigest(imageStreamTag.Image.Name)
 	if err != nil {
 		context.GetLogger(r.ctx).Errorf("error parsing digest %q: %v", imageStreamTag.Image.Name, err)
-		return nil, err
+		return "", nil, err
 	}
 
 	if localImage, err := r.getImage(dgst); err != nil {
 		// if the image is managed by OpenShift and we cannot load the image, report an error
 		if image.Annotations[imageapi.ManagedByOpenShiftAnnotation] == "true" {
 			context.GetLogger(r.ctx).Errorf("error getting image %q: %v", dgst.String(), err)
-			return nil, err
+			return "", nil, err
 		}
 	} else {
 		// if we have a local manifest, use it
 		if len(localImage.DockerImageManifest) > 0 {
-			return r.manifestFromImageWithCachedLayers(localImage, cacheName)
+			return r.manifestFromImageWithCachedLayers(localImage, cacheName, tag, accept)
 		}
 	}
 
 	// allow pullthrough to be disabled
 	if !r.pullthrough {
-		return nil, distribution.ErrManifestBlobUnknown{Digest: dgst}
+		return "", nil, distribution.ErrManifestBlobUnknown{Digest: dgst}
 	}
 
 	// check the previous error here
 	if referenceErr != nil {
 		context.GetLogger(r.ctx).Errorf("error parsing image %q: %v", image.DockerImageReference, referenceErr)
-		return nil, referenceErr
+		return "", nil, referenceErr
 	}
 
-	return r.pullthroughGetByTag(image, ref, cacheName, options...)
+	return r.pullthroughGetByTag(image, ref, cacheName, accept, options...)
 }
 
 // pullthroughGetByTag attempts to load the given image manifest from the remote server defined by ref, using cacheName to store any cached layers.
-func (r *repository) pullthroughGetByTag(image *imageapi.Image, ref imageapi.DockerImageReference, cacheName string, options ...distribution.ManifestServiceOption) (*schema1.SignedManifest, error) {
+func (r *repository) pullthroughGetByTag(image *imageapi.Image, ref imageapi.DockerImageReference, cacheName string, accept []string, options ...distribution.ManifestServiceOption) (string, []byte, error) {
 	defaultRef := ref.DockerClientDefaults()
 
 	retriever := r.importContext()
@@ -317,14 +378,19 @@ func (r *repository) pullthroughGetByTag(image *imageapi.Image, ref imageapi.Doc
 	repo, err := retriever.Repository(r.ctx, defaultRef.RegistryURL(), defaultRef.RepositoryName(), false)
 	if err != nil {
 		context.GetLogger(r.ctx).Errorf("error getting remote repository for image %q: %v", image.DockerImageReference, err)
-		return nil, err
+		return "", nil, err
 	}
 
 	// get a manifest context
-	manifests, err := repo.Manifests(r.ctx)
+	ms, err := repo.Manifests(r.ctx)
 	if err != nil {
 		context.GetLogger(r.ctx).Errorf("error getting manifests for image %q: %v", image.DockerImageReference, err)
-		return nil, err
+		return "", nil, err
+	}
+	manifests, ok := ms.(distribution.RawManifestService)
+	if !ok {
+		context.GetLogger(r.ctx).Errorf("error getting manifests for image %q: the remote repository can't retrieve raw manifests", image.DockerImageReference)
+		return "", nil, distribution.ErrUnsupported
 	}
 
 	// fetch this by image
@@ -332,26 +398,27 @@ func (r *repository) pullthroughGetByTag(image *imageapi.Image, ref imageapi.Doc
 		dgst, err := digest.ParseDigest(ref.ID)
 		if err != nil {
 			context.GetLogger(r.ctx).Errorf("error getting manifests for image %q: %v", image.DockerImageReference, err)
-			return nil, err
+			return "", nil, err
 		}
-		manifest, err := manifests.Get(dgst)
+		mediaType, content, err := manifests.GetRaw(dgst, distribution.WithManifestMediaTypes(accept))
 		if err != nil {
 			context.GetLogger(r.ctx).Errorf("error getting manifest from remote server for image %q: %v", image.DockerImageReference, err)
-			return nil, err
+			return "", nil, err
 		}
-		r.rememberLayers(manifest, cacheName)
-		return manifest, nil
+		r.rememberLayers(content, cacheName)
+		return mediaType, content, nil
 	}
 
 	// fetch this by tag
-	manifest, err := manifests.GetByTag(ref.Tag, options...)
+	options = append([]distribution.ManifestServiceOption{distribution.WithTag(ref.Tag), distribution.WithManifestMediaTypes(accept)}, options...)
+	mediaType, content, err := manifests.GetRaw("", options...)
 	if err != nil {
 		context.GetLogger(r.ctx).Errorf("error getting manifest from remote server for image %q: %v", image.DockerImageReference, err)
-		return nil, err
+		return "", nil, err
 	}
 
-	r.rememberLayers(manifest, cacheName)
-	return manifest, nil
+	r.rememberLayers(content, cacheName)
+	return mediaType, content, nil
 }
 
 // Put creates or updates the named manifest.
@@ -369,26 +436,103 @@ func (r *repository) Put(manifest *schema1.SignedManifest) error {
 	}
 
 	// Upload to openshift
-	ism := imageapi.ImageStreamMapping{
+	image := r.newImage(dgst, imageapi.MediaTypeDockerSchema1SignedManifest, manifest.Raw)
+	if err := r.fillImageWithMetadata(image); err != nil {
+		return err
+	}
+	if err := r.createImageStreamMapping(manifest.Tag, image); err != nil {
+		return err
+	}
+
+	// Grab each json signature and store them.
+	signatures, err := manifest.Signatures()
+	if err != nil {
+		return err
+	}
+
+	for _, signature := range signatures {
+		if err := r.Signatures().Put(dgst, signature); err != nil {
+			context.GetLogger(r.ctx).Errorf("error storing signature: %s", err)
+			return err
+		}
+	}
+
+	return nil
+}
+
+// PutRaw creates or updates the manifest with the tag passed with distribution.WithTag. Besides the
+// signed schema1 manifests handled by Put, only schema2 manifests pushed by tag are supported,
+// because image stream mappings require a tag. Manifest lists can't be pushed, since the images
+// they list are pushed by digest, and images imported from manifest lists are resolved to their
+// linux/amd64 image.
+func (r *repository) PutRaw(mediaType string, content []byte, options ...distribution.ManifestServiceOption) error {
+	tag, _, _ := splitRawManifestOptions(options)
+	if mediaType != imageapi.MediaTypeDockerSchema2Manifest {
+		context.GetLogger(r.ctx).Errorf("error storing manifest: manifests of type %s are not supported", mediaType)
+		return errcode.ErrorCodeUnsupported.WithDetail(fmt.Sprintf("manifests of type %s can't be pushed to the integrated registry", mediaType))
+	}
+	if len(tag) == 0 {
+		context.GetLogger(r.ctx).Errorf("error storing manifest: %s manifests must be pushed by tag", mediaType)
+		return errcode.ErrorCodeUnsupported.WithDetail(fmt.Sprintf("manifests of type %s must be pushed to the integrated registry by tag", mediaType))
+	}
+
+	dgst, err := digest.FromBytes(content)
+	if err != nil {
+		return err
+	}
+	manifest := imageapi.DockerImageManifest{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return err
+	}
+
+	// the image configuration is needed to fill the image metadata and to convert the manifest for
+	// clients which don't accept schema2 manifests
+	configDigest, err := digest.ParseDigest(manifest.Config.Digest)
+	if err != nil {
+		return distribution.ErrManifestVerification{err}
+	}
+	config, err := r.Repository.Blobs(r.ctx).Get(r.ctx, configDigest)
+	if err != nil {
+		context.GetLogger(r.ctx).Errorf("failed to get the configuration %s of image %s: %v", configDigest, dgst, err)
+		if err == distribution.ErrBlobUnknown {
+			return distribution.ErrManifestVerification{distribution.ErrManifestBlobUnknown{Digest: configDigest}}
+		}
+		return err
+	}
+
+	image := r.newImage(dgst, mediaType, content)
+	image.DockerImageConfig = string(config)
+	if err := r.fillImageWithMetadata(image); err != nil {
+		return err
+	}
+	return r.createImageStreamMapping(tag, image)
+}
+
+// newImage returns the image of a manifest pushed to the repository.
+func (r *repository) newImage(dgst digest.Digest, mediaType string, manifest []byte) *imageapi.Image {
+	return &imageapi.Image{
 		ObjectMeta: kapi.ObjectMeta{
-			Namespace: r.namespace,
-			Name:      r.name,
-		},
-		Tag: manifest.Tag,
-		Image: imageapi.Image{
-			ObjectMeta: kapi.ObjectMeta{
-				Name: dgst.String(),
-				Annotations: map[string]string{
-					imageapi.ManagedByOpenShiftAnnotation: "true",
-				},
+			Name: dgst.String(),
+			Annotations: map[string]string{
+				imageapi.ManagedByOpenShiftAnnotation: "true",
 			},
-			DockerImageReference: fmt.Sprintf("%s/%s/%s@%s", r.registryAddr, r.namespace, r.name, dgst.String()),
-			DockerImageManifest:  string(manifest.Raw),
 		},
+		DockerImageReference:         fmt.Sprintf("%s/%s/%s@%s", r.registryAddr, r.namespace, r.name, dgst.String()),
+		DockerImageManifest:          string(manifest),
+		DockerImageManifestMediaType: mediaType,
 	}
+}
 
-	if err := r.fillImageWithMetadata(manifest, &ism.Image); err != nil {
-		return err
+// createImageStreamMapping tags image into the image stream of the repository, which is created if it
+// doesn't exist yet.
+func (r *repository) createImageStreamMapping(tag string, image *imageapi.Image) error {
+	ism := imageapi.ImageStreamMapping{
+		ObjectMeta: kapi.ObjectMeta{
+			Namespace: r.namespace,
+			Name:      r.name,
+		},
+		Tag:   tag,
+		Image: *image,
 	}
 
 	if err := r.registryOSClient.ImageStreamMappings(r.namespace).Create(&ism); err != nil {
@@ -442,26 +586,13 @@ func (r *repository) Put(manifest *schema1.SignedManifest) error {
 		}
 	}
 
-	// Grab each json signature and store them.
-	signatures, err := manifest.Signatures()
-	if err != nil {
-		return err
-	}
-
-	for _, signature := range signatures {
-		if err := r.Signatures().Put(dgst, signature); err != nil {
-			context.GetLogger(r.ctx).Errorf("error storing signature: %s", err)
-			return err
-		}
-	}
-
 	return nil
 }
 
 // fillImageWithMetadata fills a given image with metadata. Also correct layer sizes with blob sizes. Newer
 // Docker client versions don't set layer sizes in the manifest at all. Origin master needs correct layer
 // sizes for proper image quota support. That's why we need to fill the metadata in the registry.
-func (r *repository) fillImageWithMetadata(manifest *schema1.SignedManifest, image *imageapi.Image) error {
+func (r *repository) fillImageWithMetadata(image *imageapi.Image) error {
 	if err := imageapi.ImageWithMetadata(image); err != nil {
 		return err
 	}
@@ -472,8 +603,7 @@ func (r *repository) fillImageWithMetadata(manifest *schema1.SignedManifest, ima
 	blobs := r.Blobs(r.ctx)
 	for i := range image.DockerImageLayers {
 		layer := &image.DockerImageLayers[i]
-		// DockerImageLayers represents manifest.Manifest.FSLayers in reversed order
-		desc, err := blobs.Stat(r.ctx, manifest.Manifest.FSLayers[len(image.DockerImageLayers)-i-1].BlobSum)
+		desc, err := blobs.Stat(r.ctx, digest.Digest(layer.Name))
 		if err != nil {
 			context.GetLogger(r.ctx).Errorf("failed to stat blobs %s of image %s", layer.Name, image.DockerImageReference)
 			return err
@@ -537,29 +667,66 @@ func (r *repository) getImageStreamImage(dgst digest.Digest) (*imageapi.ImageStr
 	return r.registryOSClient.ImageStreamImages(r.namespace).Get(r.name, dgst.String())
 }
 
-// rememberLayers caches the provided layers
-func (r *repository) rememberLayers(manifest *schema1.SignedManifest, cacheName string) {
+// rememberLayers caches the layers and the image configuration referenced by the manifest content
+func (r *repository) rememberLayers(content []byte, cacheName string) {
 	if !r.pullthrough {
 		return
 	}
+	manifest := imageapi.DockerImageManifest{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return
+	}
 	// remember the layers in the cache as an optimization to avoid searching all remote repositories
 	for _, layer := range manifest.FSLayers {
-		r.cachedLayers.RememberDigest(layer.BlobSum, cacheName)
+		r.cachedLayers.RememberDigest(digest.Digest(layer.DockerBlobSum), cacheName)
+	}
+	for _, layer := range manifest.Layers {
+		r.cachedLayers.RememberDigest(digest.Digest(layer.Digest), cacheName)
+	}
+	if len(manifest.Config.Digest) > 0 {
+		r.cachedLayers.RememberDigest(digest.Digest(manifest.Config.Digest), cacheName)
 	}
 }
 
 // manifestFromImageWithCachedLayers loads the image and then caches any located layers
-func (r *repository) manifestFromImageWithCachedLayers(image *imageapi.Image, cacheName string) (*schema1.SignedManifest, error) {
-	manifest, err := r.manifestFromImage(image)
+func (r *repository) manifestFromImageWithCachedLayers(image *imageapi.Image, cacheName, tag string, accept []string) (string, []byte, error) {
+	mediaType, content, err := r.manifestFromImage(image, tag, accept)
 	if err != nil {
-		return nil, err
+		return "", nil, err
 	}
-	r.rememberLayers(manifest, cacheName)
-	return manifest, nil
+	r.rememberLayers(content, cacheName)
+	return mediaType, content, nil
 }
 
-// manifestFromImage converts an Image to a SignedManifest.
-func (r *repository) manifestFromImage(image *imageapi.Image) (*schema1.SignedManifest, error) {
+// manifestFromImage returns the manifest of image in one of the accepted media types. Schema2 manifests
+// are converted to signed schema1 manifests with the given tag for clients which don't accept them.
+func (r *repository) manifestFromImage(image *imageapi.Image, tag string, accept []string) (string, []byte, error) {
+	switch mediaType := manifestMediaType(image); mediaType {
+	case imageapi.MediaTypeDockerSchema1SignedManifest:
+		sm, err := r.signedManifestFromImage(image)
+		if err != nil {
+			return "", nil, err
+		}
+		return mediaType, sm.Raw, nil
+	case imageapi.MediaTypeDockerSchema2Manifest:
+		if acceptsMediaType(accept, mediaType) {
+			return mediaType, []byte(image.DockerImageManifest), nil
+		}
+		sm, err := convertSchema2Manifest(image, r.Name(), tag, r.schema1SigningKey)
+		if err != nil {
+			return "", nil, err
+		}
+		return imageapi.MediaTypeDockerSchema1SignedManifest, sm.Raw, nil
+	default:
+		if acceptsMediaType(accept, mediaType) {
+			return mediaType, []byte(image.DockerImageManifest), nil
+		}
+		return "", nil, fmt.Errorf("the manifest of image %s is a %s the client doesn't accept", image.Name, mediaType)
+	}
+}
+
+// signedManifestFromImage converts an Image with a schema1 manifest to a SignedManifest.
+func (r *repository) signedManifestFromImage(image *imageapi.Image) (*schema1.SignedManifest, error) {
 	dgst, err := digest.ParseDigest(image.Name)
 	if err != nil {
 		return nil, err
@@ -598,3 +765,44 @@ func (r *repository) manifestFromImage(image *imageapi.Image) (*schema1.SignedMa
 	}
 	return &sm, err
 }
+
+// manifestMediaType returns the media type of the manifest of image.
+func manifestMediaType(image *imageapi.Image) string {
+	mediaType := image.DockerImageManifestMediaType
+	if len(mediaType) == 0 {
+		manifest := imageapi.DockerImageManifest{}
+		if err := json.Unmarshal([]byte(image.DockerImageManifest), &manifest); err == nil && manifest.SchemaVersion == 2 {
+			mediaType = manifest.MediaType
+		}
+	}
+	switch mediaType {
+	case "", imageapi.MediaTypeDockerSchema1Manifest:
+		return imageapi.MediaTypeDockerSchema1SignedManifest
+	}
+	return mediaType
+}
+
+// acceptsMediaType returns true if mediaType is one of the accepted media types.
+func acceptsMediaType(accept []string, mediaType string) bool {
+	for _, accepted := range accept {
+		if accepted == mediaType {
+			return true
+		}
+	}
+	return false
+}
+
+// signedManifest parses the content of a signed schema1 manifest.
+func signedManifest(mediaType string, content []byte, err error) (*schema1.SignedManifest, error) {
+	if err != nil {
+		return nil, err
+	}
+	if mediaType != imageapi.MediaTypeDockerSchema1SignedManifest {
+		return nil, fmt.Errorf("unexpected manifest type %s", mediaType)
+	}
+	sm := &schema1.SignedManifest{}
+	if err := json.Unmarshal(content, sm); err != nil {
+		return nil, err
+	}
+	return sm, nil
+}
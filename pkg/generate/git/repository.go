@@ -28,6 +28,7 @@ type Repository interface {
 	CloneBare(dir string, url string) error
 	CloneMirror(dir string, url string) error
 	Fetch(dir string) error
+	FetchRef(dir, ref string) error
 	Checkout(dir string, ref string) error
 	SubmoduleUpdate(dir string, init, recursive bool) error
 	Archive(dir, ref, format string, w io.Writer) error
@@ -260,6 +261,12 @@ func (r *repository) Fetch(location string) error {
 	return err
 }
 
+// FetchRef fetches the given ref from the origin remote into FETCH_HEAD
+func (r *repository) FetchRef(location, ref string) error {
+	_, _, err := r.git(location, "fetch", "origin", ref)
+	return err
+}
+
 // Archive creates a archive of the Git repo at directory location at commit ref and with the given Git format,
 // and then writes that to the provided io.Writer
 func (r *repository) Archive(location, ref, format string, w io.Writer) error {
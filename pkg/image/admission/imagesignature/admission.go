@@ -0,0 +1,365 @@
+package imagesignature
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"time"
+
+	"github.com/docker/distribution/digest"
+	"github.com/golang/glog"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	clientset "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	buildutil "github.com/openshift/origin/pkg/build/util"
+	"github.com/openshift/origin/pkg/client"
+	oadmission "github.com/openshift/origin/pkg/cmd/server/admission"
+	configlatest "github.com/openshift/origin/pkg/cmd/server/api/latest"
+	"github.com/openshift/origin/pkg/image/admission/imagesignature/api"
+	"github.com/openshift/origin/pkg/image/admission/imagesignature/api/validation"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// PluginName is the name of the image signature verification admission plugin.
+const PluginName = "ImageSignatureVerification"
+
+func init() {
+	admission.RegisterPlugin(PluginName, func(c clientset.Interface, config io.Reader) (admission.Interface, error) {
+		pluginConfig, err := ReadConfig(config)
+		if err != nil {
+			return nil, err
+		}
+		if pluginConfig == nil {
+			glog.Infof("Admission plugin %q is not configured so it will be disabled.", PluginName)
+			return nil, nil
+		}
+		return NewImageSignatureVerification(c, pluginConfig)
+	})
+}
+
+// ReadConfig reads the configuration of the plugin, and returns nil if there is none.
+func ReadConfig(reader io.Reader) (*api.ImageSignatureVerificationConfig, error) {
+	if reader == nil || reflect.ValueOf(reader).IsNil() {
+		return nil, nil
+	}
+	obj, err := configlatest.ReadYAML(reader)
+	if err != nil {
+		return nil, err
+	}
+	if obj == nil {
+		return nil, nil
+	}
+	config, ok := obj.(*api.ImageSignatureVerificationConfig)
+	if !ok {
+		return nil, fmt.Errorf("unexpected config object: %#v", obj)
+	}
+	if errs := validation.ValidateImageSignatureVerificationConfig(config); len(errs) > 0 {
+		return nil, errs.ToAggregate()
+	}
+	return config, nil
+}
+
+type imageSignatureVerification struct {
+	*admission.Handler
+	scopes     []*verificationScope
+	client     client.Interface
+	kubeClient clientset.Interface
+	// now returns the current time.
+	now func() time.Time
+}
+
+var _ = oadmission.WantsOpenshiftClient(&imageSignatureVerification{})
+var _ = oadmission.Validator(&imageSignatureVerification{})
+
+// NewImageSignatureVerification returns an admission plugin which verifies that the images of
+// new or updated pods and builds carry a signature trusted for the registry scope they belong
+// to. The plugin only verifies signatures, the results are recorded in the conditions of the
+// image signatures by the controller created by NewVerificationControllerFactory.
+func NewImageSignatureVerification(kubeClient clientset.Interface, config *api.ImageSignatureVerificationConfig) (admission.Interface, error) {
+	scopes, err := newVerificationScopes(config)
+	if err != nil {
+		return nil, err
+	}
+	return &imageSignatureVerification{
+		Handler:    admission.NewHandler(admission.Create, admission.Update),
+		scopes:     scopes,
+		kubeClient: kubeClient,
+		now:        time.Now,
+	}, nil
+}
+
+func (a *imageSignatureVerification) SetOpenshiftClient(c client.Interface) {
+	a.client = c
+}
+
+func (a *imageSignatureVerification) Validate() error {
+	if a.client == nil {
+		return fmt.Errorf("%s needs an Openshift client", PluginName)
+	}
+	return nil
+}
+
+var (
+	podsResource         = kapi.Resource("pods")
+	buildsResource       = buildapi.Resource("builds")
+	buildConfigsResource = buildapi.Resource("buildconfigs")
+)
+
+// Admit verifies the images of pods and builds which are created, including the builds cloned
+// or instantiated from build configs, and the images which are changed by an update. Nothing is
+// written, since the request may still be rejected by a later admission plugin.
+func (a *imageSignatureVerification) Admit(attr admission.Attributes) error {
+	switch attr.GetResource().GroupResource() {
+	case podsResource:
+		pod, ok := attr.GetObject().(*kapi.Pod)
+		if !ok || len(attr.GetSubresource()) > 0 {
+			return nil
+		}
+		oldImages, err := a.oldPodImages(attr)
+		if err != nil {
+			return admission.NewForbidden(attr, err)
+		}
+		for _, container := range pod.Spec.Containers {
+			// images which didn't change were verified when they were set
+			if image, ok := oldImages[container.Name]; ok && image == container.Image {
+				continue
+			}
+			if err := a.admitPullSpec(attr, container.Image); err != nil {
+				return err
+			}
+		}
+		return nil
+	case buildsResource, buildConfigsResource:
+		from, err := a.buildInput(attr)
+		if err != nil {
+			return admission.NewForbidden(attr, err)
+		}
+		if from == nil {
+			return nil
+		}
+		if attr.GetOperation() == admission.Update {
+			old, err := a.client.Builds(attr.GetNamespace()).Get(attr.GetName())
+			if err != nil && !kerrors.IsNotFound(err) {
+				return admission.NewForbidden(attr, err)
+			}
+			if err == nil && reflect.DeepEqual(buildutil.GetInputReference(old.Spec.Strategy), from) {
+				return nil
+			}
+		}
+		return a.admitBuildInput(attr, from)
+	}
+	return nil
+}
+
+// oldPodImages returns the images of the containers of the pod being updated, by container name.
+// The admission attributes don't carry the old object, so it is retrieved from the server.
+func (a *imageSignatureVerification) oldPodImages(attr admission.Attributes) (map[string]string, error) {
+	if attr.GetOperation() != admission.Update {
+		return nil, nil
+	}
+	old, err := a.kubeClient.Core().Pods(attr.GetNamespace()).Get(attr.GetName())
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	images := make(map[string]string)
+	for _, container := range old.Spec.Containers {
+		images[container.Name] = container.Image
+	}
+	return images, nil
+}
+
+// buildInput returns the image a build being created starts from, or nil if it doesn't use one.
+func (a *imageSignatureVerification) buildInput(attr admission.Attributes) (*kapi.ObjectReference, error) {
+	switch obj := attr.GetObject().(type) {
+	case *buildapi.Build:
+		return buildutil.GetInputReference(obj.Spec.Strategy), nil
+	case *buildapi.BuildRequest:
+		if attr.GetResource().GroupResource() == buildConfigsResource && obj.From != nil {
+			return obj.From, nil
+		}
+		if attr.GetResource().GroupResource() == buildsResource {
+			build, err := a.client.Builds(attr.GetNamespace()).Get(obj.Name)
+			if err != nil {
+				return nil, err
+			}
+			return buildutil.GetInputReference(build.Spec.Strategy), nil
+		}
+		config, err := a.client.BuildConfigs(attr.GetNamespace()).Get(obj.Name)
+		if err != nil {
+			return nil, err
+		}
+		return buildutil.GetInputReference(config.Spec.Strategy), nil
+	}
+	return nil, nil
+}
+
+func (a *imageSignatureVerification) admitBuildInput(attr admission.Attributes, from *kapi.ObjectReference) error {
+	namespace := from.Namespace
+	if len(namespace) == 0 {
+		namespace = attr.GetNamespace()
+	}
+	var imageName string
+	switch from.Kind {
+	case "DockerImage":
+		return a.admitPullSpec(attr, from.Name)
+	case "ImageStreamTag":
+		name, tag, ok := imageapi.SplitImageStreamTag(from.Name)
+		if !ok {
+			return nil
+		}
+		tagged, err := a.client.ImageStreamTags(namespace).Get(name, tag)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return admission.NewForbidden(attr, err)
+		}
+		if err == nil {
+			imageName = tagged.Image.Name
+		}
+	case "ImageStreamImage":
+		name, id, ok := imageapi.SplitImageStreamImage(from.Name)
+		if !ok {
+			return nil
+		}
+		streamImage, err := a.client.ImageStreamImages(namespace).Get(name, id)
+		if err != nil && !kerrors.IsNotFound(err) {
+			return admission.NewForbidden(attr, err)
+		}
+		if err == nil {
+			imageName = streamImage.Image.Name
+		}
+	default:
+		return nil
+	}
+
+	description := fmt.Sprintf("%s %s/%s", from.Kind, namespace, from.Name)
+	if len(imageName) == 0 {
+		// The image can't be verified, but it isn't known which scope it would belong to either.
+		glog.V(4).Infof("Unable to verify the signatures of %s: it doesn't point to an image", description)
+		return nil
+	}
+	image, err := a.getImage(imageName)
+	if err != nil {
+		return admission.NewForbidden(attr, err)
+	}
+	if image == nil {
+		return nil
+	}
+	ref, err := imageapi.ParseDockerImageReference(image.DockerImageReference)
+	if err != nil {
+		return nil
+	}
+	return a.admitImage(attr, description, scopeFor(a.scopes, ref), image)
+}
+
+// admitPullSpec verifies the image pullSpec points to. Images pulled by digest are looked up
+// by name, images pulled by tag only when they are tagged into an image stream of the
+// integrated registry.
+//
+// A tag may point to another image by the time it is pulled, so images of scopes whose policy
+// is to reject untrusted images must be pulled by digest. Images pulled by tag are only
+// verified for scopes which warn about untrusted images.
+func (a *imageSignatureVerification) admitPullSpec(attr admission.Attributes, pullSpec string) error {
+	ref, err := imageapi.ParseDockerImageReference(pullSpec)
+	if err != nil {
+		return nil
+	}
+	image, err := a.resolvePullSpec(ref)
+	if err != nil {
+		return admission.NewForbidden(attr, err)
+	}
+	refs := []imageapi.DockerImageReference{ref}
+	if image != nil {
+		if imageRef, err := imageapi.ParseDockerImageReference(image.DockerImageReference); err == nil {
+			refs = append(refs, imageRef)
+		}
+	}
+	scope := scopeFor(a.scopes, refs...)
+	if scope != nil && scope.Policy == api.SignatureVerificationPolicyReject && len(ref.ID) == 0 {
+		return admission.NewForbidden(attr, fmt.Errorf("image %s must be pulled by digest, since images of %s must carry a trusted signature", pullSpec, scope.Scope))
+	}
+	return a.admitImage(attr, fmt.Sprintf("image %s", pullSpec), scope, image)
+}
+
+func (a *imageSignatureVerification) resolvePullSpec(ref imageapi.DockerImageReference) (*imageapi.Image, error) {
+	if len(ref.ID) > 0 {
+		if _, err := digest.ParseDigest(ref.ID); err != nil {
+			return nil, nil
+		}
+		return a.getImage(ref.ID)
+	}
+	if len(ref.Namespace) == 0 {
+		return nil, nil
+	}
+	stream, err := a.client.ImageStreams(ref.Namespace).Get(ref.Name)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if stream.Status.DockerImageRepository != ref.AsRepository().Exact() {
+		return nil, nil
+	}
+	tag := ref.Tag
+	if len(tag) == 0 {
+		tag = imageapi.DefaultImageTag
+	}
+	event := imageapi.LatestTaggedImage(stream, tag)
+	if event == nil {
+		return nil, nil
+	}
+	return a.getImage(event.Image)
+}
+
+// getImage returns the image with the given name, or nil if it doesn't exist.
+func (a *imageSignatureVerification) getImage(name string) (*imageapi.Image, error) {
+	image, err := a.client.Images().Get(name)
+	if kerrors.IsNotFound(err) {
+		return nil, nil
+	}
+	return image, err
+}
+
+// admitImage verifies the signatures of image against the trust roots of scope. The image is
+// admitted if any of its signatures is trusted, otherwise the policy of the scope decides.
+func (a *imageSignatureVerification) admitImage(attr admission.Attributes, description string, scope *verificationScope, image *imageapi.Image) error {
+	if scope == nil {
+		return nil
+	}
+	if image == nil {
+		return a.reject(attr, scope, fmt.Errorf("%s can't be resolved to an image, so its signatures can't be verified", description))
+	}
+
+	now := a.now()
+	accepted := false
+	for i := range image.Signatures {
+		if verifySignature(image, &image.Signatures[i], scope, now).accepted() {
+			accepted = true
+			break
+		}
+	}
+
+	if accepted {
+		return nil
+	}
+	if len(image.Signatures) == 0 {
+		return a.reject(attr, scope, fmt.Errorf("%s has no signatures, but images of %s must be signed", description, scope.Scope))
+	}
+	return a.reject(attr, scope, fmt.Errorf("%s has no signature trusted for %s", description, scope.Scope))
+}
+
+// reject rejects the request with err, unless the policy of scope is to only warn about images
+// without a trusted signature.
+func (a *imageSignatureVerification) reject(attr admission.Attributes, scope *verificationScope, err error) error {
+	if scope.Policy == api.SignatureVerificationPolicyWarn {
+		glog.Warningf("Admitting %s %s/%s: %v", attr.GetKind().Kind, attr.GetNamespace(), attr.GetName(), err)
+		return nil
+	}
+	return admission.NewForbidden(attr, err)
+}
@@ -0,0 +1,324 @@
+package imagesignature
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/kubernetes/pkg/admission"
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	clientsetfake "k8s.io/kubernetes/pkg/client/clientset_generated/internalclientset/fake"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client/testclient"
+	"github.com/openshift/origin/pkg/image/admission/imagesignature/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+func newTestPlugin(t *testing.T, policy api.SignatureVerificationPolicy, objects ...runtime.Object) (*imageSignatureVerification, *testclient.Fake) {
+	var kubeObjects []runtime.Object
+	for _, obj := range objects {
+		if _, ok := obj.(*kapi.Pod); ok {
+			kubeObjects = append(kubeObjects, obj)
+		}
+	}
+	plugin, err := NewImageSignatureVerification(clientsetfake.NewSimpleClientset(kubeObjects...), &api.ImageSignatureVerificationConfig{
+		Scopes: []api.SignatureVerificationScope{
+			{
+				Scope:       "registry.example.com",
+				Policy:      policy,
+				GPGKeyrings: []string{filepath.Join("testdata", "signer.asc")},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := testclient.NewSimpleFake()
+	client.PrependReactor("get", "*", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		name := action.(ktestclient.GetAction).GetName()
+		for _, obj := range objects {
+			meta, err := kapi.ObjectMetaFor(obj)
+			if err != nil {
+				return true, nil, err
+			}
+			if meta.Name == name && action.GetResource() == resourceFor(obj) {
+				return true, obj, nil
+			}
+		}
+		return true, nil, kerrors.NewNotFound(kapi.Resource(action.GetResource()), name)
+	})
+	plugin.(*imageSignatureVerification).SetOpenshiftClient(client)
+	plugin.(*imageSignatureVerification).now = func() time.Time { return testNow }
+	return plugin.(*imageSignatureVerification), client
+}
+
+func resourceFor(obj runtime.Object) string {
+	switch obj.(type) {
+	case *imageapi.Image:
+		return "images"
+	case *imageapi.ImageStream:
+		return "imagestreams"
+	case *imageapi.ImageStreamTag:
+		return "imagestreamtags"
+	case *buildapi.Build:
+		return "builds"
+	case *buildapi.BuildConfig:
+		return "buildconfigs"
+	}
+	return ""
+}
+
+func signedImage(t *testing.T, signatures ...string) *imageapi.Image {
+	image := &imageapi.Image{
+		ObjectMeta:           kapi.ObjectMeta{Name: testDigest},
+		DockerImageReference: "registry.example.com/app/web@" + testDigest,
+	}
+	for _, file := range signatures {
+		image.Signatures = append(image.Signatures, testSignature(t, file))
+	}
+	return image
+}
+
+func testPod(images ...string) *kapi.Pod {
+	pod := &kapi.Pod{ObjectMeta: kapi.ObjectMeta{Name: "pod", Namespace: "test"}}
+	for i, image := range images {
+		pod.Spec.Containers = append(pod.Spec.Containers, kapi.Container{Name: fmt.Sprintf("c%d", i), Image: image})
+	}
+	return pod
+}
+
+func podAttributes(images ...string) admission.Attributes {
+	return admission.NewAttributesRecord(testPod(images...), kapi.Kind("Pod").WithVersion("version"), "test", "pod", kapi.Resource("pods").WithVersion("version"), "", admission.Create, nil)
+}
+
+// updatedImage returns the image the verification results were recorded in, if any.
+func updatedImage(client *testclient.Fake) *imageapi.Image {
+	for _, action := range client.Actions() {
+		if action.Matches("update", "images") {
+			return action.(ktestclient.UpdateAction).GetObject().(*imageapi.Image)
+		}
+	}
+	return nil
+}
+
+func TestAdmit_pods(t *testing.T) {
+	pullSpec := "registry.example.com/app/web@" + testDigest
+	tests := []struct {
+		name     string
+		policy   api.SignatureVerificationPolicy
+		image    *imageapi.Image
+		pullSpec string
+		allowed  bool
+	}{
+		{name: "trusted", image: signedImage(t, "trusted.sig"), pullSpec: pullSpec, allowed: true},
+		{name: "one trusted signature", image: signedImage(t, "untrusted.sig", "trusted.sig"), pullSpec: pullSpec, allowed: true},
+		{name: "untrusted", image: signedImage(t, "untrusted.sig"), pullSpec: pullSpec},
+		{name: "expired", image: signedImage(t, "expired.sig"), pullSpec: pullSpec},
+		{name: "unsigned", image: signedImage(t), pullSpec: pullSpec},
+		{name: "unsigned with warn policy", policy: api.SignatureVerificationPolicyWarn, image: signedImage(t), pullSpec: pullSpec, allowed: true},
+		{name: "unknown image", image: &imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: "sha256:other"}}, pullSpec: pullSpec},
+		{name: "by tag", image: signedImage(t, "trusted.sig"), pullSpec: "registry.example.com/app/web:v1"},
+		{name: "by tag with warn policy", policy: api.SignatureVerificationPolicyWarn, image: signedImage(t), pullSpec: "registry.example.com/app/web:v1", allowed: true},
+		{name: "out of scope", image: signedImage(t), pullSpec: "docker.io/library/busybox:latest", allowed: true},
+	}
+	for _, test := range tests {
+		policy := test.policy
+		if len(policy) == 0 {
+			policy = api.SignatureVerificationPolicyReject
+		}
+		plugin, client := newTestPlugin(t, policy, test.image)
+		err := plugin.Admit(podAttributes(test.pullSpec))
+		if test.allowed && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !test.allowed && (err == nil || !kerrors.IsForbidden(err)) {
+			t.Errorf("%s: expected the pod to be forbidden, got %v", test.name, err)
+		}
+		// the results are recorded by the controller, not by the plugin
+		if updated := updatedImage(client); updated != nil {
+			t.Errorf("%s: unexpected image update: %#v", test.name, updated)
+		}
+	}
+}
+
+func TestAdmit_podByTag(t *testing.T) {
+	plugin, _ := newTestPlugin(t, api.SignatureVerificationPolicyReject, signedImage(t, "trusted.sig"))
+	err := plugin.Admit(podAttributes("registry.example.com/app/web:v1"))
+	if err == nil || !kerrors.IsForbidden(err) || !strings.Contains(err.Error(), "must be pulled by digest") {
+		t.Errorf("expected the pod to be forbidden, got %v", err)
+	}
+}
+
+func TestAdmit_podUpdate(t *testing.T) {
+	unchanged := "registry.example.com/app/web@" + testDigest
+	changed := "registry.example.com/app/web@sha256:other"
+	tests := []struct {
+		name    string
+		old     []string
+		images  []string
+		allowed bool
+	}{
+		{name: "unchanged", old: []string{unchanged}, images: []string{unchanged}, allowed: true},
+		{name: "changed to an unsigned image", old: []string{unchanged}, images: []string{changed}},
+		{name: "added container", old: []string{unchanged}, images: []string{unchanged, changed}},
+	}
+	for _, test := range tests {
+		// the image in use is unsigned, so it would be rejected if it were verified again
+		unsigned := signedImage(t)
+		other := &imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: "sha256:other"}}
+		plugin, _ := newTestPlugin(t, api.SignatureVerificationPolicyReject, unsigned, other, testPod(test.old...))
+		attrs := admission.NewAttributesRecord(testPod(test.images...), kapi.Kind("Pod").WithVersion("version"), "test", "pod", kapi.Resource("pods").WithVersion("version"), "", admission.Update, nil)
+		err := plugin.Admit(attrs)
+		if test.allowed && err != nil {
+			t.Errorf("%s: unexpected error: %v", test.name, err)
+		}
+		if !test.allowed && (err == nil || !kerrors.IsForbidden(err)) {
+			t.Errorf("%s: expected the pod to be forbidden, got %v", test.name, err)
+		}
+	}
+}
+
+func TestAdmit_buildUpdate(t *testing.T) {
+	build := func(image string) *buildapi.Build {
+		return &buildapi.Build{
+			ObjectMeta: kapi.ObjectMeta{Name: "build", Namespace: "test"},
+			Spec: buildapi.BuildSpec{
+				CommonSpec: buildapi.CommonSpec{
+					Strategy: buildapi.BuildStrategy{
+						SourceStrategy: &buildapi.SourceBuildStrategy{
+							From: kapi.ObjectReference{Kind: "DockerImage", Name: image},
+						},
+					},
+				},
+			},
+		}
+	}
+	unchanged := "registry.example.com/app/web@" + testDigest
+	plugin, _ := newTestPlugin(t, api.SignatureVerificationPolicyReject, signedImage(t), build(unchanged))
+
+	attrs := admission.NewAttributesRecord(build(unchanged), buildapi.Kind("Build").WithVersion("version"), "test", "build", buildapi.Resource("builds").WithVersion("version"), "", admission.Update, nil)
+	if err := plugin.Admit(attrs); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	attrs = admission.NewAttributesRecord(build("registry.example.com/app/web@sha256:other"), buildapi.Kind("Build").WithVersion("version"), "test", "build", buildapi.Resource("builds").WithVersion("version"), "", admission.Update, nil)
+	if err := plugin.Admit(attrs); err == nil || !kerrors.IsForbidden(err) {
+		t.Errorf("expected the build to be forbidden, got %v", err)
+	}
+}
+
+func TestVerificationController(t *testing.T) {
+	scopes, err := newVerificationScopes(&api.ImageSignatureVerificationConfig{
+		Scopes: []api.SignatureVerificationScope{
+			{
+				Scope:       "registry.example.com",
+				Policy:      api.SignatureVerificationPolicyReject,
+				GPGKeyrings: []string{filepath.Join("testdata", "signer.asc")},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	client := testclient.NewSimpleFake()
+	c := &VerificationController{images: client, scopes: scopes, now: func() time.Time { return testNow }}
+
+	original := signedImage(t, "untrusted.sig", "trusted.sig")
+	if err := c.Next(original); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(original.Signatures[0].Conditions) != 0 {
+		t.Errorf("the image from the queue was modified")
+	}
+	image := updatedImage(client)
+	if image == nil || len(image.Signatures) != 2 {
+		t.Fatalf("expected the image to be updated: %#v", image)
+	}
+	for i, expected := range []kapi.ConditionStatus{kapi.ConditionFalse, kapi.ConditionTrue} {
+		found := false
+		for _, condition := range image.Signatures[i].Conditions {
+			if condition.Type == imageapi.SignatureTrusted {
+				found = true
+				if condition.Status != expected {
+					t.Errorf("signature %d: unexpected condition %#v", i, condition)
+				}
+			}
+		}
+		if !found {
+			t.Errorf("signature %d: missing %s condition", i, imageapi.SignatureTrusted)
+		}
+	}
+	// unchanged results aren't written again
+	client.ClearActions()
+	if err := c.Next(image); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated := updatedImage(client); updated != nil {
+		t.Errorf("unexpected image update: %#v", updated)
+	}
+
+	// images outside of the scopes are left alone
+	unscoped := signedImage(t, "trusted.sig")
+	unscoped.DockerImageReference = "docker.io/library/busybox@" + testDigest
+	if err := c.Next(unscoped); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated := updatedImage(client); updated != nil {
+		t.Errorf("unexpected image update: %#v", updated)
+	}
+}
+
+func TestAdmit_imageStreamTag(t *testing.T) {
+	image := signedImage(t, "trusted.sig")
+	stream := &imageapi.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Name: "web", Namespace: "test"},
+		Status: imageapi.ImageStreamStatus{
+			DockerImageRepository: "172.30.1.1:5000/test/web",
+			Tags: map[string]imageapi.TagEventList{
+				"latest": {Items: []imageapi.TagEvent{{DockerImageReference: "172.30.1.1:5000/test/web@" + testDigest, Image: testDigest}}},
+			},
+		},
+	}
+
+	// the scope of an image pulled from the integrated registry is the one of the image source
+	plugin, _ := newTestPlugin(t, api.SignatureVerificationPolicyReject, image, stream)
+	if err := plugin.Admit(podAttributes("172.30.1.1:5000/test/web@" + testDigest)); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	// so images of a scope which rejects untrusted images must be pulled by digest
+	if err := plugin.Admit(podAttributes("172.30.1.1:5000/test/web:latest")); err == nil || !kerrors.IsForbidden(err) {
+		t.Errorf("expected the pod to be forbidden, got %v", err)
+	}
+
+	unsigned := signedImage(t)
+	plugin, _ = newTestPlugin(t, api.SignatureVerificationPolicyWarn, unsigned, stream)
+	if err := plugin.Admit(podAttributes("172.30.1.1:5000/test/web:latest")); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	tag := &imageapi.ImageStreamTag{ObjectMeta: kapi.ObjectMeta{Name: "web:latest", Namespace: "test"}, Image: *unsigned}
+	config := &buildapi.BuildConfig{
+		ObjectMeta: kapi.ObjectMeta{Name: "build", Namespace: "test"},
+		Spec: buildapi.BuildConfigSpec{
+			CommonSpec: buildapi.CommonSpec{
+				Strategy: buildapi.BuildStrategy{
+					SourceStrategy: &buildapi.SourceBuildStrategy{
+						From: kapi.ObjectReference{Kind: "ImageStreamTag", Name: "web:latest"},
+					},
+				},
+			},
+		},
+	}
+	plugin, _ = newTestPlugin(t, api.SignatureVerificationPolicyReject, unsigned, tag, config)
+	request := &buildapi.BuildRequest{ObjectMeta: kapi.ObjectMeta{Name: "build"}}
+	attrs := admission.NewAttributesRecord(request, buildapi.Kind("BuildRequest").WithVersion("version"), "test", "build", buildapi.Resource("buildconfigs").WithVersion("version"), "instantiate", admission.Create, nil)
+	err := plugin.Admit(attrs)
+	if err == nil || !kerrors.IsForbidden(err) || !strings.Contains(err.Error(), "ImageStreamTag test/web:latest") {
+		t.Errorf("expected the build to be forbidden, got %v", err)
+	}
+}
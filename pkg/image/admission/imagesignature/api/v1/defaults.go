@@ -0,0 +1,20 @@
+package v1
+
+import (
+	"k8s.io/kubernetes/pkg/runtime"
+)
+
+func SetDefaults_SignatureVerificationScope(obj *SignatureVerificationScope) {
+	if len(obj.Policy) == 0 {
+		obj.Policy = SignatureVerificationPolicyReject
+	}
+}
+
+func addDefaultingFuncs(scheme *runtime.Scheme) {
+	err := scheme.AddDefaultingFuncs(
+		SetDefaults_SignatureVerificationScope,
+	)
+	if err != nil {
+		panic(err)
+	}
+}
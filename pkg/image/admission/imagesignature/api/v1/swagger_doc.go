@@ -0,0 +1,27 @@
+package v1
+
+// This file contains methods that can be used by the go-restful package to generate Swagger
+// documentation for the object types found in 'types.go' This file is automatically generated
+// by hack/update-generated-swagger-descriptions.sh and should be run after a full build of OpenShift.
+// ==== DO NOT EDIT THIS FILE MANUALLY ====
+
+var map_ImageSignatureVerificationConfig = map[string]string{
+	"":       "ImageSignatureVerificationConfig is the configuration for the image signature verification admission plugin. Images of pods and builds which are pulled from one of the configured scopes must carry at least one signature which verifies against the trust roots of the scope.",
+	"scopes": "Scopes lists registry scopes along with their trust roots. The most specific scope matching an image reference applies. Images outside of all scopes are not verified.",
+}
+
+func (ImageSignatureVerificationConfig) SwaggerDoc() map[string]string {
+	return map_ImageSignatureVerificationConfig
+}
+
+var map_SignatureVerificationScope = map[string]string{
+	"":                       "SignatureVerificationScope defines the trust roots for the images of a registry scope and what happens to images without a trusted signature.",
+	"scope":                  "Scope is a registry host, optionally followed by a repository namespace or a repository (e.g. \"registry.access.redhat.com\" or \"docker.io/openshift/origin\").",
+	"policy":                 "Policy is the action taken on images of the scope without a trusted signature, either \"Reject\" (the default) or \"Warn\".",
+	"gpgKeyrings":            "GPGKeyrings lists files of binary or ASCII armored GPG public keys trusted to sign images of the scope.",
+	"certificateAuthorities": "CertificateAuthorities lists PEM encoded files of certificate authorities trusted to issue certificates which sign images of the scope.",
+}
+
+func (SignatureVerificationScope) SwaggerDoc() map[string]string {
+	return map_SignatureVerificationScope
+}
@@ -0,0 +1,45 @@
+package v1
+
+import (
+	"k8s.io/kubernetes/pkg/api/unversioned"
+)
+
+// ImageSignatureVerificationConfig is the configuration for the image signature verification
+// admission plugin. Images of pods and builds which are pulled from one of the configured scopes
+// must carry at least one signature which verifies against the trust roots of the scope.
+type ImageSignatureVerificationConfig struct {
+	unversioned.TypeMeta `json:",inline"`
+
+	// Scopes lists registry scopes along with their trust roots. The most specific scope matching
+	// an image reference applies. Images outside of all scopes are not verified.
+	Scopes []SignatureVerificationScope `json:"scopes"`
+}
+
+// SignatureVerificationScope defines the trust roots for the images of a registry scope and what
+// happens to images without a trusted signature.
+type SignatureVerificationScope struct {
+	// Scope is a registry host, optionally followed by a repository namespace or a repository
+	// (e.g. "registry.access.redhat.com" or "docker.io/openshift/origin").
+	Scope string `json:"scope"`
+	// Policy is the action taken on images of the scope without a trusted signature, either
+	// "Reject" (the default) or "Warn".
+	Policy SignatureVerificationPolicy `json:"policy,omitempty"`
+	// GPGKeyrings lists files of binary or ASCII armored GPG public keys trusted to sign images
+	// of the scope.
+	GPGKeyrings []string `json:"gpgKeyrings,omitempty"`
+	// CertificateAuthorities lists PEM encoded files of certificate authorities trusted to issue
+	// certificates which sign images of the scope.
+	CertificateAuthorities []string `json:"certificateAuthorities,omitempty"`
+}
+
+// SignatureVerificationPolicy is the action taken on images without a trusted signature.
+type SignatureVerificationPolicy string
+
+const (
+	// SignatureVerificationPolicyReject rejects pods and builds using images without a trusted
+	// signature.
+	SignatureVerificationPolicyReject SignatureVerificationPolicy = "Reject"
+	// SignatureVerificationPolicyWarn admits pods and builds using images without a trusted
+	// signature, but logs a warning about them.
+	SignatureVerificationPolicyWarn SignatureVerificationPolicy = "Warn"
+)
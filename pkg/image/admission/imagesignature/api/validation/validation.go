@@ -0,0 +1,73 @@
+package validation
+
+import (
+	"strings"
+
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/image/admission/imagesignature/api"
+)
+
+// ValidateImageSignatureVerificationConfig validates the configuration of the image signature
+// verification admission plugin.
+func ValidateImageSignatureVerificationConfig(config *api.ImageSignatureVerificationConfig) field.ErrorList {
+	allErrs := field.ErrorList{}
+	scopes := sets.NewString()
+	for i, scope := range config.Scopes {
+		path := field.NewPath("scopes").Index(i)
+		allErrs = append(allErrs, ValidateSignatureVerificationScope(scope, path)...)
+		if scopes.Has(scope.Scope) {
+			allErrs = append(allErrs, field.Duplicate(path.Child("scope"), scope.Scope))
+		}
+		scopes.Insert(scope.Scope)
+	}
+	return allErrs
+}
+
+// ValidateSignatureVerificationScope validates a single scope of the image signature verification
+// configuration.
+func ValidateSignatureVerificationScope(scope api.SignatureVerificationScope, path *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+	switch {
+	case len(scope.Scope) == 0:
+		allErrs = append(allErrs, field.Required(path.Child("scope"), ""))
+	case !validScope(scope.Scope):
+		allErrs = append(allErrs, field.Invalid(path.Child("scope"), scope.Scope, "must be a registry host optionally followed by a repository namespace or a repository"))
+	}
+	switch scope.Policy {
+	case api.SignatureVerificationPolicyReject, api.SignatureVerificationPolicyWarn:
+	case "":
+		allErrs = append(allErrs, field.Required(path.Child("policy"), ""))
+	default:
+		allErrs = append(allErrs, field.NotSupported(path.Child("policy"), scope.Policy, []string{string(api.SignatureVerificationPolicyReject), string(api.SignatureVerificationPolicyWarn)}))
+	}
+	if len(scope.GPGKeyrings) == 0 && len(scope.CertificateAuthorities) == 0 {
+		allErrs = append(allErrs, field.Required(path.Child("gpgKeyrings"), "at least one GPG keyring or certificate authority must be specified"))
+	}
+	for i, file := range scope.GPGKeyrings {
+		if len(file) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("gpgKeyrings").Index(i), ""))
+		}
+	}
+	for i, file := range scope.CertificateAuthorities {
+		if len(file) == 0 {
+			allErrs = append(allErrs, field.Required(path.Child("certificateAuthorities").Index(i), ""))
+		}
+	}
+	return allErrs
+}
+
+// validScope returns true if scope is a registry host, optionally with a port, followed by any
+// number of repository path segments.
+func validScope(scope string) bool {
+	for i, segment := range strings.Split(scope, "/") {
+		if len(segment) == 0 || strings.ContainsAny(segment, "@ \t") {
+			return false
+		}
+		if i > 0 && strings.Contains(segment, ":") {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"testing"
+
+	"k8s.io/kubernetes/pkg/util/validation/field"
+
+	"github.com/openshift/origin/pkg/image/admission/imagesignature/api"
+)
+
+func TestValidateImageSignatureVerificationConfig(t *testing.T) {
+	valid := api.SignatureVerificationScope{
+		Scope:       "registry.example.com/app",
+		Policy:      api.SignatureVerificationPolicyReject,
+		GPGKeyrings: []string{"/etc/origin/master/signers.gpg"},
+	}
+	tests := []struct {
+		name     string
+		scopes   []api.SignatureVerificationScope
+		errType  field.ErrorType
+		errField string
+	}{
+		{name: "empty"},
+		{name: "valid", scopes: []api.SignatureVerificationScope{valid}},
+		{
+			name: "registry with port and certificate authorities",
+			scopes: []api.SignatureVerificationScope{
+				{Scope: "registry.example.com:5000", Policy: api.SignatureVerificationPolicyWarn, CertificateAuthorities: []string{"ca.crt"}},
+			},
+		},
+		{
+			name:     "missing scope",
+			scopes:   []api.SignatureVerificationScope{{Policy: api.SignatureVerificationPolicyReject, GPGKeyrings: []string{"keyring"}}},
+			errType:  field.ErrorTypeRequired,
+			errField: "scopes[0].scope",
+		},
+		{
+			name:     "invalid scope",
+			scopes:   []api.SignatureVerificationScope{{Scope: "registry.example.com/app:latest", Policy: api.SignatureVerificationPolicyReject, GPGKeyrings: []string{"keyring"}}},
+			errType:  field.ErrorTypeInvalid,
+			errField: "scopes[0].scope",
+		},
+		{
+			name:     "unknown policy",
+			scopes:   []api.SignatureVerificationScope{{Scope: "registry.example.com", Policy: "Allow", GPGKeyrings: []string{"keyring"}}},
+			errType:  field.ErrorTypeNotSupported,
+			errField: "scopes[0].policy",
+		},
+		{
+			name:     "no trust roots",
+			scopes:   []api.SignatureVerificationScope{{Scope: "registry.example.com", Policy: api.SignatureVerificationPolicyReject}},
+			errType:  field.ErrorTypeRequired,
+			errField: "scopes[0].gpgKeyrings",
+		},
+		{
+			name:     "empty keyring",
+			scopes:   []api.SignatureVerificationScope{{Scope: "registry.example.com", Policy: api.SignatureVerificationPolicyReject, GPGKeyrings: []string{""}}},
+			errType:  field.ErrorTypeRequired,
+			errField: "scopes[0].gpgKeyrings[0]",
+		},
+		{
+			name:     "duplicate scope",
+			scopes:   []api.SignatureVerificationScope{valid, valid},
+			errType:  field.ErrorTypeDuplicate,
+			errField: "scopes[1].scope",
+		},
+	}
+	for _, test := range tests {
+		errs := ValidateImageSignatureVerificationConfig(&api.ImageSignatureVerificationConfig{Scopes: test.scopes})
+		if len(test.errType) == 0 {
+			if len(errs) > 0 {
+				t.Errorf("%s: unexpected errors: %v", test.name, errs)
+			}
+			continue
+		}
+		if len(errs) != 1 {
+			t.Errorf("%s: expected one error, got %v", test.name, errs)
+			continue
+		}
+		if errs[0].Type != test.errType || errs[0].Field != test.errField {
+			t.Errorf("%s: unexpected error: %v", test.name, errs[0])
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package imagesignature
+
+import (
+	"fmt"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	kerrors "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/flowcontrol"
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+	"k8s.io/kubernetes/pkg/watch"
+
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/controller"
+	"github.com/openshift/origin/pkg/image/admission/imagesignature/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// VerificationControllerFactory can create a VerificationController.
+type VerificationControllerFactory struct {
+	Client client.Interface
+	// Config is the configuration of the image signature verification admission plugin.
+	Config         *api.ImageSignatureVerificationConfig
+	ResyncInterval time.Duration
+}
+
+// Create creates a VerificationController.
+func (f *VerificationControllerFactory) Create() (controller.RunnableController, error) {
+	scopes, err := newVerificationScopes(f.Config)
+	if err != nil {
+		return nil, err
+	}
+
+	lw := &cache.ListWatch{
+		ListFunc: func(options kapi.ListOptions) (runtime.Object, error) {
+			return f.Client.Images().List(options)
+		},
+		WatchFunc: func(options kapi.ListOptions) (watch.Interface, error) {
+			return f.Client.Images().Watch(options)
+		},
+	}
+	q := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(lw, &imageapi.Image{}, q, f.ResyncInterval).Run()
+
+	c := &VerificationController{
+		images: f.Client,
+		scopes: scopes,
+		now:    time.Now,
+	}
+
+	return &controller.RetryController{
+		Queue: q,
+		RetryManager: controller.NewQueueRetryManager(
+			q,
+			cache.MetaNamespaceKeyFunc,
+			func(obj interface{}, err error, retries controller.Retry) bool {
+				utilruntime.HandleError(err)
+				return retries.Count < 5
+			},
+			flowcontrol.NewTokenBucketRateLimiter(1, 10),
+		),
+		Handle: func(obj interface{}) error {
+			return c.Next(obj.(*imageapi.Image))
+		},
+	}, nil
+}
+
+// VerificationController records the results of verifying the signatures of images against
+// the trust roots of the registry scopes the images belong to in the conditions of the
+// signatures. The results are recorded apart from the admission plugin, so that only the
+// images are written and requests rejected by the admission chain have no side effects.
+type VerificationController struct {
+	images client.ImagesInterfacer
+	scopes []*verificationScope
+	// now returns the current time.
+	now func() time.Time
+}
+
+// Next verifies the signatures of image and records the results if they changed.
+func (c *VerificationController) Next(image *imageapi.Image) error {
+	if len(image.Signatures) == 0 {
+		return nil
+	}
+	ref, err := imageapi.ParseDockerImageReference(image.DockerImageReference)
+	if err != nil {
+		return nil
+	}
+	scope := scopeFor(c.scopes, ref)
+	if scope == nil {
+		return nil
+	}
+
+	copied, err := kapi.Scheme.DeepCopy(image)
+	if err != nil {
+		return err
+	}
+	updated := copied.(*imageapi.Image)
+	if !recordVerification(updated, scope, c.now()) {
+		return nil
+	}
+	if _, err := c.images.Images().Update(updated); err != nil && !kerrors.IsNotFound(err) {
+		return fmt.Errorf("unable to record the signature verification results of image %s: %v", image.Name, err)
+	}
+	return nil
+}
@@ -0,0 +1,52 @@
+/*
+Package imagesignature contains the ImageSignatureVerification admission
+control plugin. This plugin verifies that the images used by new pods and
+builds carry at least one signature which verifies against the trust roots
+an administrator configured for the registry scope the image belongs to.
+
+Pod images pulled by digest are looked up by their digest, images pulled by
+tag only when the tag belongs to an image stream of the integrated registry.
+Since a tag may point to another image by the time it is pulled, images of
+scopes with the Reject policy must be pulled by digest. Builds are checked for
+the image their strategy starts from. Builds starting from an ImageStreamTag
+are checked for the image the tag points to when the build is created.
+
+The plugin doesn't write anything. When the plugin is configured, the master
+also runs a controller verifying the signatures of all images in the
+configured scopes, and it records the results in the conditions of the image
+signatures.
+
+Signatures of the AtomicImageV1 type are supported, either as signed GPG
+messages verified against GPG keyrings or as JSON web signatures with an x509
+certificate chain verified against certificate authorities.
+
+Configuration
+
+The plugin is configured via an ImageSignatureVerificationConfig object in the
+origin and kubernetes Master configs:
+
+admissionConfig:
+  pluginConfig:
+    ImageSignatureVerification:
+      configuration:
+        apiVersion: v1
+        kind: ImageSignatureVerificationConfig
+        scopes:
+        - scope: registry.access.redhat.com
+          policy: Reject
+          gpgKeyrings:
+          - /etc/origin/master/redhat-release.gpg
+        - scope: registry.example.com/team
+          policy: Warn
+          certificateAuthorities:
+          - /etc/origin/master/signing-ca.crt
+...
+kubernetesMasterConfig:
+  admissionConfig:
+    pluginConfig:
+      ImageSignatureVerification:
+        configuration:
+          ...
+*/
+
+package imagesignature
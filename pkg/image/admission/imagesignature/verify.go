@@ -0,0 +1,380 @@
+package imagesignature
+
+import (
+	"bytes"
+	"crypto/x509"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"strings"
+	"time"
+
+	"github.com/docker/libtrust"
+	"golang.org/x/crypto/openpgp"
+	"golang.org/x/crypto/openpgp/armor"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+
+	"github.com/openshift/origin/pkg/image/admission/imagesignature/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+const (
+	// atomicSignatureType is the type of the signed payload of AtomicImageV1 signatures.
+	atomicSignatureType = "atomic container signature"
+	// maxPGPMessageSize limits the size of the content of signed OpenPGP messages.
+	maxPGPMessageSize = 4 * 1024 * 1024
+)
+
+// atomicSignaturePayload is the signed payload of AtomicImageV1 signatures.
+type atomicSignaturePayload struct {
+	Critical struct {
+		Type  string `json:"type"`
+		Image struct {
+			DockerManifestDigest string `json:"docker-manifest-digest"`
+		} `json:"image"`
+		Identity struct {
+			DockerReference string `json:"docker-reference"`
+		} `json:"identity"`
+	} `json:"critical"`
+	Optional map[string]interface{} `json:"optional"`
+}
+
+// verificationScope is a registry scope along with its loaded trust roots.
+type verificationScope struct {
+	api.SignatureVerificationScope
+
+	keyring     openpgp.EntityList
+	authorities *x509.CertPool
+}
+
+// newVerificationScope loads the trust roots of scope.
+func newVerificationScope(scope api.SignatureVerificationScope) (*verificationScope, error) {
+	s := &verificationScope{SignatureVerificationScope: scope}
+	for _, file := range scope.GPGKeyrings {
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read GPG keyring for scope %q: %v", scope.Scope, err)
+		}
+		keyring, err := readPGPKeyring(data)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read GPG keyring %s for scope %q: %v", file, scope.Scope, err)
+		}
+		s.keyring = append(s.keyring, keyring...)
+	}
+	for _, file := range scope.CertificateAuthorities {
+		if s.authorities == nil {
+			s.authorities = x509.NewCertPool()
+		}
+		data, err := ioutil.ReadFile(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to read certificate authorities for scope %q: %v", scope.Scope, err)
+		}
+		if !s.authorities.AppendCertsFromPEM(data) {
+			return nil, fmt.Errorf("no certificates found in %s for scope %q", file, scope.Scope)
+		}
+	}
+	return s, nil
+}
+
+// newVerificationScopes loads the trust roots of the scopes of config.
+func newVerificationScopes(config *api.ImageSignatureVerificationConfig) ([]*verificationScope, error) {
+	scopes := []*verificationScope{}
+	for _, scope := range config.Scopes {
+		s, err := newVerificationScope(scope)
+		if err != nil {
+			return nil, err
+		}
+		scopes = append(scopes, s)
+	}
+	return scopes, nil
+}
+
+// scopeFor returns the most specific of scopes matching any of refs, or nil if none does.
+func scopeFor(scopes []*verificationScope, refs ...imageapi.DockerImageReference) *verificationScope {
+	var match *verificationScope
+	for _, scope := range scopes {
+		for _, ref := range refs {
+			if scope.matches(ref) && (match == nil || len(scope.Scope) > len(match.Scope)) {
+				match = scope
+			}
+		}
+	}
+	return match
+}
+
+// readPGPKeyring reads the keys of a binary or ASCII armored GPG keyring.
+func readPGPKeyring(data []byte) (openpgp.EntityList, error) {
+	if isPGPArmored(data) {
+		return openpgp.ReadArmoredKeyRing(bytes.NewReader(data))
+	}
+	return openpgp.ReadKeyRing(bytes.NewReader(data))
+}
+
+// isPGPArmored returns true if data is ASCII armored.
+func isPGPArmored(data []byte) bool {
+	return bytes.HasPrefix(bytes.TrimSpace(data), []byte("-----BEGIN PGP"))
+}
+
+// matches returns true if the repository of ref is within the scope.
+func (s *verificationScope) matches(ref imageapi.DockerImageReference) bool {
+	name := ref.DockerClientDefaults().AsRepository().Exact()
+	return name == s.Scope || strings.HasPrefix(name, s.Scope+"/")
+}
+
+// signatureVerification is the result of verifying an image signature.
+type signatureVerification struct {
+	// trusted is true if the signature was made by a key or certificate trusted for the scope
+	// and it claims an identity within the scope.
+	trusted bool
+	// forImage is true if the signature claims the digest of the verified image.
+	forImage bool
+	// expired is true if the signature, or the certificate which made it, has expired.
+	expired bool
+	// reason and message explain why the signature isn't trusted.
+	reason, message string
+
+	identity string
+	claims   map[string]string
+	created  *unversioned.Time
+	issuedBy *imageapi.SignatureIssuer
+	issuedTo *imageapi.SignatureSubject
+}
+
+// accepted returns true if the signature proves that the image is trusted.
+func (v *signatureVerification) accepted() bool {
+	return v.trusted && v.forImage && !v.expired
+}
+
+// verifySignature verifies signature of image against the trust roots of scope.
+func verifySignature(image *imageapi.Image, signature *imageapi.ImageSignature, scope *verificationScope, now time.Time) *signatureVerification {
+	v := &signatureVerification{}
+	if signature.Type != imageapi.ImageSignatureTypeAtomicImageV1 {
+		v.reason, v.message = "UnsupportedType", fmt.Sprintf("signatures of type %q can't be verified", signature.Type)
+		return v
+	}
+
+	var (
+		content []byte
+		err     error
+	)
+	if bytes.HasPrefix(bytes.TrimSpace(signature.Content), []byte("{")) {
+		content, err = verifyX509Signature(signature.Content, scope, v)
+	} else {
+		content, err = verifyPGPSignature(signature.Content, scope, now, v)
+	}
+	if err != nil {
+		v.reason, v.message = "VerificationFailed", err.Error()
+		return v
+	}
+
+	payload := &atomicSignaturePayload{}
+	if err := json.Unmarshal(content, payload); err != nil || payload.Critical.Type != atomicSignatureType {
+		v.reason, v.message = "InvalidContent", "the signed content isn't an atomic container signature"
+		return v
+	}
+	v.forImage = payload.Critical.Image.DockerManifestDigest == image.Name
+	v.identity = payload.Critical.Identity.DockerReference
+	if ref, err := imageapi.ParseDockerImageReference(v.identity); err != nil || !scope.matches(ref) {
+		v.reason, v.message = "IdentityOutOfScope", fmt.Sprintf("the signed identity %q is outside of %s", v.identity, scope.Scope)
+		return v
+	}
+	for key, value := range payload.Optional {
+		if v.claims == nil {
+			v.claims = make(map[string]string)
+		}
+		v.claims[key] = fmt.Sprint(value)
+	}
+	if v.created == nil {
+		if timestamp, ok := payload.Optional["timestamp"].(float64); ok {
+			created := unversioned.NewTime(time.Unix(int64(timestamp), 0))
+			v.created = &created
+		}
+	}
+	v.trusted = true
+	v.reason, v.message = "Verified", fmt.Sprintf("verified against the trust roots of %s", scope.Scope)
+	return v
+}
+
+// verifyPGPSignature verifies a signed OpenPGP message against the GPG keyrings of scope and
+// returns the signed content.
+func verifyPGPSignature(content []byte, scope *verificationScope, now time.Time, v *signatureVerification) ([]byte, error) {
+	if len(scope.keyring) == 0 {
+		return nil, fmt.Errorf("no GPG keys are trusted for %s", scope.Scope)
+	}
+	var message io.Reader = bytes.NewReader(content)
+	if isPGPArmored(content) {
+		block, err := armor.Decode(message)
+		if err != nil {
+			return nil, err
+		}
+		message = block.Body
+	}
+	details, err := openpgp.ReadMessage(message, scope.keyring, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if !details.IsSigned {
+		return nil, errors.New("not a signed OpenPGP message")
+	}
+	if details.SignedBy == nil {
+		return nil, fmt.Errorf("the OpenPGP signature was made by an unknown key 0x%016x", details.SignedByKeyId)
+	}
+	// the signature is only verified once the whole content has been read
+	data, err := ioutil.ReadAll(io.LimitReader(details.UnverifiedBody, maxPGPMessageSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxPGPMessageSize {
+		return nil, errors.New("the OpenPGP message is too large")
+	}
+	if details.SignatureError != nil {
+		return nil, details.SignatureError
+	}
+	signature := details.Signature
+	if signature == nil {
+		return nil, errors.New("only version 4 OpenPGP signatures are supported")
+	}
+
+	created := unversioned.NewTime(signature.CreationTime)
+	v.created = &created
+	v.issuedTo = &imageapi.SignatureSubject{PublicKeyID: fmt.Sprintf("0x%016x", details.SignedBy.PublicKey.KeyId)}
+	if lifetime := signature.SigLifetimeSecs; lifetime != nil && *lifetime != 0 {
+		if now.After(signature.CreationTime.Add(time.Duration(*lifetime) * time.Second)) {
+			v.expired = true
+		}
+	}
+	return data, nil
+}
+
+// verifyX509Signature verifies a JSON web signature with an x509 certificate chain against the
+// certificate authorities of scope and returns the signed content.
+func verifyX509Signature(content []byte, scope *verificationScope, v *signatureVerification) ([]byte, error) {
+	if scope.authorities == nil {
+		return nil, fmt.Errorf("no certificate authorities are trusted for %s", scope.Scope)
+	}
+	signature, err := libtrust.ParseJWS(content)
+	if err != nil {
+		return nil, err
+	}
+	chains, err := signature.VerifyChains(scope.authorities)
+	if invalid, ok := err.(x509.CertificateInvalidError); ok && invalid.Reason == x509.Expired {
+		v.expired = true
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(chains) == 0 {
+		return nil, errors.New("the signature has no certificate chain")
+	}
+	leaf := chains[0][0]
+	v.issuedTo = &imageapi.SignatureSubject{
+		SignatureGenericEntity: entityFor(leaf.Subject.CommonName, leaf.Subject.Organization),
+	}
+	v.issuedBy = &imageapi.SignatureIssuer{
+		SignatureGenericEntity: entityFor(leaf.Issuer.CommonName, leaf.Issuer.Organization),
+	}
+	return signature.Payload()
+}
+
+func entityFor(commonName string, organizations []string) imageapi.SignatureGenericEntity {
+	entity := imageapi.SignatureGenericEntity{CommonName: commonName}
+	if len(organizations) > 0 {
+		entity.Organization = organizations[0]
+	}
+	return entity
+}
+
+// recordVerification verifies the signatures of image against the trust roots of scope and
+// records the results in their conditions. It returns true if any signature was changed.
+func recordVerification(image *imageapi.Image, scope *verificationScope, now time.Time) bool {
+	changed := false
+	for i := range image.Signatures {
+		signature := &image.Signatures[i]
+		changed = updateSignature(signature, verifySignature(image, signature, scope, now), unversioned.NewTime(now)) || changed
+	}
+	return changed
+}
+
+// updateSignature records the verification result in the conditions and details of signature.
+// It returns true if the signature was changed. Conditions whose status, reason and message
+// didn't change are left untouched.
+func updateSignature(signature *imageapi.ImageSignature, v *signatureVerification, now unversioned.Time) bool {
+	changed := false
+	trusted := kapi.ConditionFalse
+	if v.trusted && !v.expired {
+		trusted = kapi.ConditionTrue
+	}
+	changed = setSignatureCondition(signature, imageapi.SignatureCondition{
+		Type:    imageapi.SignatureTrusted,
+		Status:  trusted,
+		Reason:  v.reason,
+		Message: v.message,
+	}, now) || changed
+
+	forImage := imageapi.SignatureCondition{Type: imageapi.SignatureForImage, Status: kapi.ConditionFalse, Reason: "DigestMismatch"}
+	switch {
+	case len(v.identity) == 0:
+		forImage.Status, forImage.Reason = kapi.ConditionUnknown, "NotVerified"
+	case v.forImage:
+		forImage.Status, forImage.Reason = kapi.ConditionTrue, "DigestMatches"
+	}
+	changed = setSignatureCondition(signature, forImage, now) || changed
+
+	expired := imageapi.SignatureCondition{Type: imageapi.SignatureExpired, Status: kapi.ConditionFalse}
+	if v.expired {
+		expired.Status, expired.Reason = kapi.ConditionTrue, "Expired"
+	}
+	changed = setSignatureCondition(signature, expired, now) || changed
+
+	// details are only recorded for signatures which were made by a trusted signer
+	details := imageapi.ImageSignature{}
+	if v.trusted {
+		details.ImageIdentity = v.identity
+		details.SignedClaims = v.claims
+		details.Created = v.created
+		details.IssuedBy = v.issuedBy
+		details.IssuedTo = v.issuedTo
+	}
+	current := imageapi.ImageSignature{
+		ImageIdentity: signature.ImageIdentity,
+		SignedClaims:  signature.SignedClaims,
+		Created:       signature.Created,
+		IssuedBy:      signature.IssuedBy,
+		IssuedTo:      signature.IssuedTo,
+	}
+	if !kapi.Semantic.DeepEqual(current, details) {
+		signature.ImageIdentity = details.ImageIdentity
+		signature.SignedClaims = details.SignedClaims
+		signature.Created = details.Created
+		signature.IssuedBy = details.IssuedBy
+		signature.IssuedTo = details.IssuedTo
+		changed = true
+	}
+	return changed
+}
+
+// setSignatureCondition sets condition on signature and returns true if it changed.
+func setSignatureCondition(signature *imageapi.ImageSignature, condition imageapi.SignatureCondition, now unversioned.Time) bool {
+	condition.LastProbeTime = now
+	condition.LastTransitionTime = now
+	for i := range signature.Conditions {
+		existing := &signature.Conditions[i]
+		if existing.Type != condition.Type {
+			continue
+		}
+		if existing.Status == condition.Status && existing.Reason == condition.Reason && existing.Message == condition.Message {
+			return false
+		}
+		if existing.Status == condition.Status {
+			condition.LastTransitionTime = existing.LastTransitionTime
+		}
+		*existing = condition
+		return true
+	}
+	signature.Conditions = append(signature.Conditions, condition)
+	return true
+}
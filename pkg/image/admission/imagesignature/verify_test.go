@@ -0,0 +1,222 @@
+package imagesignature
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/docker/libtrust"
+	libtrusttestutil "github.com/docker/libtrust/testutil"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+
+	"github.com/openshift/origin/pkg/image/admission/imagesignature/api"
+	imageapi "github.com/openshift/origin/pkg/image/api"
+)
+
+// The signatures in testdata were made with gpg over testPayload by the key exported to
+// signer.asc (trusted.sig and expired.sig, which expired on 2026-01-02) and by another key
+// (untrusted.sig).
+const (
+	testDigest  = "sha256:2d2f2d7a41e0fd7c9f5c5bd1bf4bf1e0e37cdfbb0f3fbc5d83dc0d0eb2b5e7c1"
+	testPayload = `{"critical":{"type":"atomic container signature","image":{"docker-manifest-digest":"` + testDigest + `"},"identity":{"docker-reference":"registry.example.com/app/web:v1"}},"optional":{"creator":"test"}}`
+	testKeyID   = "0xf5edd6ac3b1dd11e"
+)
+
+var testNow = time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func testSignature(t *testing.T, file string) imageapi.ImageSignature {
+	content, err := ioutil.ReadFile(filepath.Join("testdata", file))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return imageapi.ImageSignature{Type: imageapi.ImageSignatureTypeAtomicImageV1, Content: content}
+}
+
+func gpgScope(t *testing.T, scope string) *verificationScope {
+	s, err := newVerificationScope(api.SignatureVerificationScope{
+		Scope:       scope,
+		Policy:      api.SignatureVerificationPolicyReject,
+		GPGKeyrings: []string{filepath.Join("testdata", "signer.asc")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestVerifySignature_gpg(t *testing.T) {
+	image := &imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: testDigest}}
+	tests := []struct {
+		name      string
+		signature string
+		scope     string
+		image     *imageapi.Image
+		accepted  bool
+		trusted   bool
+		forImage  bool
+		expired   bool
+		reason    string
+	}{
+		{name: "trusted", signature: "trusted.sig", scope: "registry.example.com", image: image, accepted: true, trusted: true, forImage: true, reason: "Verified"},
+		{name: "repository scope", signature: "trusted.sig", scope: "registry.example.com/app/web", image: image, accepted: true, trusted: true, forImage: true, reason: "Verified"},
+		{name: "unknown key", signature: "untrusted.sig", scope: "registry.example.com", image: image, reason: "VerificationFailed"},
+		{name: "expired", signature: "expired.sig", scope: "registry.example.com", image: image, trusted: true, forImage: true, expired: true, reason: "Verified"},
+		{name: "other image", signature: "trusted.sig", scope: "registry.example.com", image: &imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: "sha256:other"}}, trusted: true, reason: "Verified"},
+		{name: "identity out of scope", signature: "trusted.sig", scope: "registry.example.com/other", image: image, forImage: true, reason: "IdentityOutOfScope"},
+	}
+	for _, test := range tests {
+		signature := testSignature(t, test.signature)
+		v := verifySignature(test.image, &signature, gpgScope(t, test.scope), testNow)
+		if v.accepted() != test.accepted || v.trusted != test.trusted || v.forImage != test.forImage || v.expired != test.expired || v.reason != test.reason {
+			t.Errorf("%s: unexpected verification: %#v", test.name, v)
+			continue
+		}
+		if !v.trusted {
+			continue
+		}
+		if v.issuedTo == nil || v.issuedTo.PublicKeyID != testKeyID {
+			t.Errorf("%s: unexpected signer: %#v", test.name, v.issuedTo)
+		}
+		if v.identity != "registry.example.com/app/web:v1" || v.claims["creator"] != "test" || v.created == nil {
+			t.Errorf("%s: unexpected details: %#v", test.name, v)
+		}
+	}
+}
+
+func TestVerifySignature_x509(t *testing.T) {
+	dir, err := ioutil.TempDir("", "imagesignature")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	trustedCA, trustedCAKey := newTestCA(t, filepath.Join(dir, "trusted.crt"))
+	otherCA, otherCAKey := newTestCA(t, filepath.Join(dir, "other.crt"))
+	scope, err := newVerificationScope(api.SignatureVerificationScope{
+		Scope:                  "registry.example.com",
+		Policy:                 api.SignatureVerificationPolicyReject,
+		CertificateAuthorities: []string{filepath.Join(dir, "trusted.crt")},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	image := &imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: testDigest}}
+
+	signature := imageapi.ImageSignature{Type: imageapi.ImageSignatureTypeAtomicImageV1, Content: signX509(t, trustedCA, trustedCAKey)}
+	v := verifySignature(image, &signature, scope, testNow)
+	if !v.accepted() {
+		t.Fatalf("expected the signature to be accepted: %#v", v)
+	}
+	if v.issuedTo == nil || v.issuedTo.CommonName != "Trust Cert" || v.issuedBy == nil || v.issuedBy.CommonName != "CA Root" {
+		t.Errorf("unexpected signer: %#v %#v", v.issuedTo, v.issuedBy)
+	}
+
+	signature = imageapi.ImageSignature{Type: imageapi.ImageSignatureTypeAtomicImageV1, Content: signX509(t, otherCA, otherCAKey)}
+	if v := verifySignature(image, &signature, scope, testNow); v.trusted || v.reason != "VerificationFailed" {
+		t.Errorf("expected a signature by an untrusted authority to fail: %#v", v)
+	}
+
+	// GPG signatures can't be verified against certificate authorities
+	signature = testSignature(t, "trusted.sig")
+	if v := verifySignature(image, &signature, scope, testNow); v.trusted {
+		t.Errorf("expected a GPG signature to fail: %#v", v)
+	}
+}
+
+func newTestCA(t *testing.T, file string) (*x509.Certificate, libtrust.PrivateKey) {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ca, err := libtrusttestutil.GenerateTrustCA(key.CryptoPublicKey(), key.CryptoPrivateKey())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.Raw}), 0600); err != nil {
+		t.Fatal(err)
+	}
+	return ca, key
+}
+
+func signX509(t *testing.T, ca *x509.Certificate, caKey libtrust.PrivateKey) []byte {
+	key, err := libtrust.GenerateECP256PrivateKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	cert, err := libtrusttestutil.GenerateTrustCert(key.CryptoPublicKey(), caKey.CryptoPrivateKey(), ca)
+	if err != nil {
+		t.Fatal(err)
+	}
+	js, err := libtrust.NewJSONSignature([]byte(testPayload))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := js.SignWithChain(key, []*x509.Certificate{cert}); err != nil {
+		t.Fatal(err)
+	}
+	content, err := js.JWS()
+	if err != nil {
+		t.Fatal(err)
+	}
+	return content
+}
+
+func TestUpdateSignature(t *testing.T) {
+	signature := testSignature(t, "trusted.sig")
+	image := &imageapi.Image{ObjectMeta: kapi.ObjectMeta{Name: testDigest}}
+	scope := gpgScope(t, "registry.example.com")
+
+	first := unversioned.NewTime(testNow)
+	if !updateSignature(&signature, verifySignature(image, &signature, scope, testNow), first) {
+		t.Fatalf("expected the signature to change")
+	}
+	expected := map[string]kapi.ConditionStatus{
+		imageapi.SignatureTrusted:  kapi.ConditionTrue,
+		imageapi.SignatureForImage: kapi.ConditionTrue,
+		imageapi.SignatureExpired:  kapi.ConditionFalse,
+	}
+	if len(signature.Conditions) != len(expected) {
+		t.Fatalf("unexpected conditions: %#v", signature.Conditions)
+	}
+	for _, condition := range signature.Conditions {
+		if expected[string(condition.Type)] != condition.Status {
+			t.Errorf("unexpected condition: %#v", condition)
+		}
+	}
+	if signature.IssuedTo == nil || signature.IssuedTo.PublicKeyID != testKeyID || signature.ImageIdentity != "registry.example.com/app/web:v1" {
+		t.Errorf("unexpected signature details: %#v", signature)
+	}
+
+	// the same result doesn't change the signature
+	second := unversioned.NewTime(testNow.Add(time.Hour))
+	if updateSignature(&signature, verifySignature(image, &signature, scope, testNow), second) {
+		t.Errorf("expected the signature not to change")
+	}
+
+	// a different result updates the conditions and clears the details
+	untrusted := gpgScope(t, "registry.example.com/other")
+	if !updateSignature(&signature, verifySignature(image, &signature, untrusted, testNow), second) {
+		t.Fatalf("expected the signature to change")
+	}
+	for _, condition := range signature.Conditions {
+		switch condition.Type {
+		case imageapi.SignatureTrusted:
+			if condition.Status != kapi.ConditionFalse || condition.Reason != "IdentityOutOfScope" || !condition.LastTransitionTime.Equal(second) {
+				t.Errorf("unexpected condition: %#v", condition)
+			}
+		case imageapi.SignatureForImage:
+			if condition.Status != kapi.ConditionTrue || !condition.LastTransitionTime.Equal(first) {
+				t.Errorf("unexpected condition: %#v", condition)
+			}
+		}
+	}
+	if signature.IssuedTo != nil || len(signature.ImageIdentity) > 0 {
+		t.Errorf("expected the signature details to be cleared: %#v", signature)
+	}
+}
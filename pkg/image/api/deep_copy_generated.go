@@ -14,11 +14,17 @@ func init() {
 	if err := api.Scheme.AddGeneratedDeepCopyFuncs(
 		DeepCopy_api_Descriptor,
 		DeepCopy_api_DockerConfig,
+		DeepCopy_api_DockerConfigHistory,
+		DeepCopy_api_DockerConfigRootFS,
 		DeepCopy_api_DockerFSLayer,
 		DeepCopy_api_DockerHistory,
 		DeepCopy_api_DockerImage,
+		DeepCopy_api_DockerImageConfig,
 		DeepCopy_api_DockerImageManifest,
 		DeepCopy_api_DockerImageReference,
+		DeepCopy_api_DockerManifestDescriptor,
+		DeepCopy_api_DockerManifestList,
+		DeepCopy_api_DockerPlatform,
 		DeepCopy_api_DockerV1CompatibilityImage,
 		DeepCopy_api_DockerV1CompatibilityImageSize,
 		DeepCopy_api_Image,
@@ -49,6 +55,7 @@ func init() {
 		DeepCopy_api_TagEventList,
 		DeepCopy_api_TagImportPolicy,
 		DeepCopy_api_TagReference,
+		DeepCopy_api_TagRetentionPolicy,
 	); err != nil {
 		// if one of the deep copy functions is malformed, detect it immediately.
 		panic(err)
@@ -167,6 +174,29 @@ func DeepCopy_api_DockerConfig(in DockerConfig, out *DockerConfig, c *conversion
 	return nil
 }
 
+func DeepCopy_api_DockerConfigHistory(in DockerConfigHistory, out *DockerConfigHistory, c *conversion.Cloner) error {
+	if err := unversioned.DeepCopy_unversioned_Time(in.Created, &out.Created, c); err != nil {
+		return err
+	}
+	out.Author = in.Author
+	out.CreatedBy = in.CreatedBy
+	out.Comment = in.Comment
+	out.EmptyLayer = in.EmptyLayer
+	return nil
+}
+
+func DeepCopy_api_DockerConfigRootFS(in DockerConfigRootFS, out *DockerConfigRootFS, c *conversion.Cloner) error {
+	out.Type = in.Type
+	if in.DiffIDs != nil {
+		in, out := in.DiffIDs, &out.DiffIDs
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.DiffIDs = nil
+	}
+	return nil
+}
+
 func DeepCopy_api_DockerFSLayer(in DockerFSLayer, out *DockerFSLayer, c *conversion.Cloner) error {
 	out.DockerBlobSum = in.DockerBlobSum
 	return nil
@@ -207,6 +237,54 @@ func DeepCopy_api_DockerImage(in DockerImage, out *DockerImage, c *conversion.Cl
 	return nil
 }
 
+func DeepCopy_api_DockerImageConfig(in DockerImageConfig, out *DockerImageConfig, c *conversion.Cloner) error {
+	out.ID = in.ID
+	out.Parent = in.Parent
+	out.Comment = in.Comment
+	if err := unversioned.DeepCopy_unversioned_Time(in.Created, &out.Created, c); err != nil {
+		return err
+	}
+	out.Container = in.Container
+	if err := DeepCopy_api_DockerConfig(in.ContainerConfig, &out.ContainerConfig, c); err != nil {
+		return err
+	}
+	out.DockerVersion = in.DockerVersion
+	out.Author = in.Author
+	if in.Config != nil {
+		in, out := in.Config, &out.Config
+		*out = new(DockerConfig)
+		if err := DeepCopy_api_DockerConfig(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Config = nil
+	}
+	out.Architecture = in.Architecture
+	out.Size = in.Size
+	out.OS = in.OS
+	if in.RootFS != nil {
+		in, out := in.RootFS, &out.RootFS
+		*out = new(DockerConfigRootFS)
+		if err := DeepCopy_api_DockerConfigRootFS(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.RootFS = nil
+	}
+	if in.History != nil {
+		in, out := in.History, &out.History
+		*out = make([]DockerConfigHistory, len(in))
+		for i := range in {
+			if err := DeepCopy_api_DockerConfigHistory(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.History = nil
+	}
+	return nil
+}
+
 func DeepCopy_api_DockerImageManifest(in DockerImageManifest, out *DockerImageManifest, c *conversion.Cloner) error {
 	out.SchemaVersion = in.SchemaVersion
 	out.MediaType = in.MediaType
@@ -261,6 +339,55 @@ func DeepCopy_api_DockerImageReference(in DockerImageReference, out *DockerImage
 	return nil
 }
 
+func DeepCopy_api_DockerManifestDescriptor(in DockerManifestDescriptor, out *DockerManifestDescriptor, c *conversion.Cloner) error {
+	out.MediaType = in.MediaType
+	out.Size = in.Size
+	out.Digest = in.Digest
+	if err := DeepCopy_api_DockerPlatform(in.Platform, &out.Platform, c); err != nil {
+		return err
+	}
+	return nil
+}
+
+func DeepCopy_api_DockerManifestList(in DockerManifestList, out *DockerManifestList, c *conversion.Cloner) error {
+	out.SchemaVersion = in.SchemaVersion
+	out.MediaType = in.MediaType
+	if in.Manifests != nil {
+		in, out := in.Manifests, &out.Manifests
+		*out = make([]DockerManifestDescriptor, len(in))
+		for i := range in {
+			if err := DeepCopy_api_DockerManifestDescriptor(in[i], &(*out)[i], c); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Manifests = nil
+	}
+	return nil
+}
+
+func DeepCopy_api_DockerPlatform(in DockerPlatform, out *DockerPlatform, c *conversion.Cloner) error {
+	out.Architecture = in.Architecture
+	out.OS = in.OS
+	out.OSVersion = in.OSVersion
+	if in.OSFeatures != nil {
+		in, out := in.OSFeatures, &out.OSFeatures
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.OSFeatures = nil
+	}
+	out.Variant = in.Variant
+	if in.Features != nil {
+		in, out := in.Features, &out.Features
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.Features = nil
+	}
+	return nil
+}
+
 func DeepCopy_api_DockerV1CompatibilityImage(in DockerV1CompatibilityImage, out *DockerV1CompatibilityImage, c *conversion.Cloner) error {
 	out.ID = in.ID
 	out.Parent = in.Parent
@@ -306,6 +433,8 @@ func DeepCopy_api_Image(in Image, out *Image, c *conversion.Cloner) error {
 	}
 	out.DockerImageMetadataVersion = in.DockerImageMetadataVersion
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 	if in.DockerImageLayers != nil {
 		in, out := in.DockerImageLayers, &out.DockerImageLayers
 		*out = make([]ImageLayer, len(in))
@@ -609,6 +738,15 @@ func DeepCopy_api_ImageStreamSpec(in ImageStreamSpec, out *ImageStreamSpec, c *c
 	} else {
 		out.Tags = nil
 	}
+	if in.Retention != nil {
+		in, out := in.Retention, &out.Retention
+		*out = new(TagRetentionPolicy)
+		if err := DeepCopy_api_TagRetentionPolicy(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Retention = nil
+	}
 	return nil
 }
 
@@ -775,6 +913,16 @@ func DeepCopy_api_TagEventCondition(in TagEventCondition, out *TagEventCondition
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.Generation = in.Generation
+	if in.NextScheduledImport != nil {
+		in, out := in.NextScheduledImport, &out.NextScheduledImport
+		*out = new(unversioned.Time)
+		if err := unversioned.DeepCopy_unversioned_Time(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.NextScheduledImport = nil
+	}
+	out.Failures = in.Failures
 	return nil
 }
 
@@ -807,6 +955,13 @@ func DeepCopy_api_TagEventList(in TagEventList, out *TagEventList, c *conversion
 func DeepCopy_api_TagImportPolicy(in TagImportPolicy, out *TagImportPolicy, c *conversion.Cloner) error {
 	out.Insecure = in.Insecure
 	out.Scheduled = in.Scheduled
+	if in.IntervalSeconds != nil {
+		in, out := in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.IntervalSeconds = nil
+	}
 	return nil
 }
 
@@ -843,3 +998,28 @@ func DeepCopy_api_TagReference(in TagReference, out *TagReference, c *conversion
 	}
 	return nil
 }
+
+func DeepCopy_api_TagRetentionPolicy(in TagRetentionPolicy, out *TagRetentionPolicy, c *conversion.Cloner) error {
+	if in.KeepLast != nil {
+		in, out := in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = *in
+	} else {
+		out.KeepLast = nil
+	}
+	if in.KeepYoungerThanSeconds != nil {
+		in, out := in.KeepYoungerThanSeconds, &out.KeepYoungerThanSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.KeepYoungerThanSeconds = nil
+	}
+	if in.PreserveTags != nil {
+		in, out := in.PreserveTags, &out.PreserveTags
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.PreserveTags = nil
+	}
+	return nil
+}
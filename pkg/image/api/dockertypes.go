@@ -4,6 +4,23 @@ import (
 	"k8s.io/kubernetes/pkg/api/unversioned"
 )
 
+const (
+	// MediaTypeDockerSchema1Manifest is the media type of unsigned schema1 Docker image manifests.
+	MediaTypeDockerSchema1Manifest = "application/vnd.docker.distribution.manifest.v1+json"
+	// MediaTypeDockerSchema1SignedManifest is the media type of signed schema1 Docker image manifests.
+	MediaTypeDockerSchema1SignedManifest = "application/vnd.docker.distribution.manifest.v1+prettyjws"
+	// MediaTypeDockerSchema2Manifest is the media type of schema2 Docker image manifests.
+	MediaTypeDockerSchema2Manifest = "application/vnd.docker.distribution.manifest.v2+json"
+	// MediaTypeDockerSchema2ManifestList is the media type of Docker manifest lists, which reference
+	// the manifests of an image for several platforms.
+	MediaTypeDockerSchema2ManifestList = "application/vnd.docker.distribution.manifest.list.v2+json"
+	// MediaTypeDockerSchema2Config is the media type of the image configuration referenced by schema2
+	// manifests.
+	MediaTypeDockerSchema2Config = "application/vnd.docker.container.image.v1+json"
+	// MediaTypeDockerSchema2Layer is the media type of the layers referenced by schema2 manifests.
+	MediaTypeDockerSchema2Layer = "application/vnd.docker.image.rootfs.diff.tar.gzip"
+)
+
 // DockerImage is the type representing a docker image and its various properties when
 // retrieved from the Docker client API.
 type DockerImage struct {
@@ -87,6 +104,31 @@ type DockerImageManifest struct {
 	Config Descriptor   `json:"config"`
 }
 
+// DockerManifestList is a list of the manifests of an image for several platforms.
+type DockerManifestList struct {
+	SchemaVersion int                        `json:"schemaVersion"`
+	MediaType     string                     `json:"mediaType,omitempty"`
+	Manifests     []DockerManifestDescriptor `json:"manifests"`
+}
+
+// DockerManifestDescriptor references the manifest of an image for a platform in a manifest list.
+type DockerManifestDescriptor struct {
+	MediaType string         `json:"mediaType,omitempty"`
+	Size      int64          `json:"size,omitempty"`
+	Digest    string         `json:"digest,omitempty"`
+	Platform  DockerPlatform `json:"platform"`
+}
+
+// DockerPlatform describes the platform an image of a manifest list runs on.
+type DockerPlatform struct {
+	Architecture string   `json:"architecture"`
+	OS           string   `json:"os"`
+	OSVersion    string   `json:"os.version,omitempty"`
+	OSFeatures   []string `json:"os.features,omitempty"`
+	Variant      string   `json:"variant,omitempty"`
+	Features     []string `json:"features,omitempty"`
+}
+
 // DockerFSLayer is a container struct for BlobSums defined in an image manifest
 type DockerFSLayer struct {
 	// DockerBlobSum is the tarsum of the referenced filesystem image layer
@@ -121,3 +163,37 @@ type DockerV1CompatibilityImage struct {
 type DockerV1CompatibilityImageSize struct {
 	Size int64 `json:"size,omitempty"`
 }
+
+// DockerImageConfig is the configuration of an image referenced by a schema2 manifest.
+type DockerImageConfig struct {
+	ID              string                `json:"id,omitempty"`
+	Parent          string                `json:"parent,omitempty"`
+	Comment         string                `json:"comment,omitempty"`
+	Created         unversioned.Time      `json:"created"`
+	Container       string                `json:"container,omitempty"`
+	ContainerConfig DockerConfig          `json:"container_config,omitempty"`
+	DockerVersion   string                `json:"docker_version,omitempty"`
+	Author          string                `json:"author,omitempty"`
+	Config          *DockerConfig         `json:"config,omitempty"`
+	Architecture    string                `json:"architecture,omitempty"`
+	Size            int64                 `json:"size,omitempty"`
+	OS              string                `json:"os,omitempty"`
+	RootFS          *DockerConfigRootFS   `json:"rootfs,omitempty"`
+	History         []DockerConfigHistory `json:"history,omitempty"`
+}
+
+// DockerConfigRootFS lists the layers of an image by the digests of their uncompressed content.
+type DockerConfigRootFS struct {
+	Type    string   `json:"type"`
+	DiffIDs []string `json:"diff_ids,omitempty"`
+}
+
+// DockerConfigHistory describes how a layer of an image was created. Entries with EmptyLayer set
+// didn't create a layer.
+type DockerConfigHistory struct {
+	Created    unversioned.Time `json:"created"`
+	Author     string           `json:"author,omitempty"`
+	CreatedBy  string           `json:"created_by,omitempty"`
+	Comment    string           `json:"comment,omitempty"`
+	EmptyLayer bool             `json:"empty_layer,omitempty"`
+}
@@ -7,6 +7,7 @@ import (
 	"regexp"
 	"sort"
 	"strings"
+	"time"
 
 	"k8s.io/kubernetes/pkg/api/errors"
 	"k8s.io/kubernetes/pkg/api/unversioned"
@@ -28,6 +29,10 @@ const (
 	// DockerDefaultV2Registry is the host name of the default v2 registry
 	DockerDefaultV2Registry = "registry-1." + DockerDefaultRegistry
 
+	// MaxScheduledImportBackoff is the longest time scheduled imports of a tag which keep failing are
+	// delayed by.
+	MaxScheduledImportBackoff = 24 * time.Hour
+
 	// containerImageEntrypointAnnotationFormatKey is a format used to identify the entrypoint of a particular
 	// container in a pod template. It is a JSON array of strings.
 	containerImageEntrypointAnnotationFormatKey = "openshift.io/container.%s.image.entrypoint"
@@ -382,11 +387,18 @@ func ManifestMatchesImage(image *Image, newManifest []byte) (bool, error) {
 	if err != nil {
 		return false, err
 	}
-	sm := schema1.SignedManifest{Raw: newManifest}
-	raw, err := sm.Payload()
-	if err != nil {
+	manifest := DockerImageManifest{}
+	if err := json.Unmarshal(newManifest, &manifest); err != nil {
 		return false, err
 	}
+	raw := newManifest
+	if manifest.SchemaVersion == 1 {
+		// the digest of a schema1 manifest excludes its signatures
+		sm := schema1.SignedManifest{Raw: newManifest}
+		if raw, err = sm.Payload(); err != nil {
+			return false, err
+		}
+	}
 	if _, err := v.Write(raw); err != nil {
 		return false, err
 	}
@@ -469,8 +481,44 @@ func ImageWithMetadata(image *Image) error {
 			image.DockerImageMetadata.Size = v1Metadata.Size
 		}
 	case 2:
-		// TODO: need to prepare for this
-		return fmt.Errorf("unrecognized Docker image manifest schema %d for %q (%s)", manifest.SchemaVersion, image.Name, image.DockerImageReference)
+		if manifest.MediaType == MediaTypeDockerSchema2ManifestList {
+			// manifest lists reference the images of several platforms and have no metadata of their own
+			return nil
+		}
+		if len(image.DockerImageConfig) == 0 {
+			return fmt.Errorf("dockerImageConfig must be set for Docker image manifest schema %d for %q (%s)", manifest.SchemaVersion, image.Name, image.DockerImageReference)
+		}
+
+		config := DockerImageConfig{}
+		if err := json.Unmarshal([]byte(image.DockerImageConfig), &config); err != nil {
+			return err
+		}
+
+		// schema2 layers are already ordered from the lowest to the highest
+		image.DockerImageLayers = make([]ImageLayer, len(manifest.Layers))
+		layerSet := sets.NewString()
+		size := int64(0)
+		for i, layer := range manifest.Layers {
+			image.DockerImageLayers[i].Name = layer.Digest
+			image.DockerImageLayers[i].LayerSize = layer.Size
+			// count layers shared by several entries just once
+			if !layerSet.Has(layer.Digest) {
+				size += layer.Size
+				layerSet.Insert(layer.Digest)
+			}
+		}
+
+		image.DockerImageMetadata.ID = manifest.Config.Digest
+		image.DockerImageMetadata.Parent = config.Parent
+		image.DockerImageMetadata.Comment = config.Comment
+		image.DockerImageMetadata.Created = config.Created
+		image.DockerImageMetadata.Container = config.Container
+		image.DockerImageMetadata.ContainerConfig = config.ContainerConfig
+		image.DockerImageMetadata.DockerVersion = config.DockerVersion
+		image.DockerImageMetadata.Author = config.Author
+		image.DockerImageMetadata.Config = config.Config
+		image.DockerImageMetadata.Architecture = config.Architecture
+		image.DockerImageMetadata.Size = size
 	default:
 		return fmt.Errorf("unrecognized Docker image manifest schema %d for %q (%s)", manifest.SchemaVersion, image.Name, image.DockerImageReference)
 	}
@@ -792,6 +840,40 @@ func SetTagConditions(stream *ImageStream, tag string, conditions ...TagEventCon
 	stream.Status.Tags[tag] = tagEvents
 }
 
+// TagImportCondition returns the ImportSuccess condition of the given tag, or nil if the tag has none.
+func TagImportCondition(stream *ImageStream, tag string) *TagEventCondition {
+	conditions := stream.Status.Tags[tag].Conditions
+	for i := range conditions {
+		if conditions[i].Type == ImportSuccess {
+			return &conditions[i]
+		}
+	}
+	return nil
+}
+
+// ScheduledImportInterval returns the time to wait before the next scheduled import of a tag with the
+// given import policy after the given number of consecutive failures. The interval is at least minimum,
+// and doubles with each failure up to MaxScheduledImportBackoff, unless the interval of the policy is
+// even longer.
+func ScheduledImportInterval(policy TagImportPolicy, minimum time.Duration, failures int32) time.Duration {
+	interval := minimum
+	if policy.IntervalSeconds != nil {
+		if seconds := time.Duration(*policy.IntervalSeconds) * time.Second; seconds > interval {
+			interval = seconds
+		}
+	}
+	if interval >= MaxScheduledImportBackoff {
+		return interval
+	}
+	for i := int32(0); i < failures; i++ {
+		interval *= 2
+		if interval >= MaxScheduledImportBackoff {
+			return MaxScheduledImportBackoff
+		}
+	}
+	return interval
+}
+
 // LatestObservedTagGeneration returns the generation value for the given tag that has been observed by the controller
 // monitoring the image stream. If the tag has not been observed, the generation is zero.
 func LatestObservedTagGeneration(stream *ImageStream, tag string) int64 {
@@ -6,6 +6,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/docker/distribution/digest"
+
 	kapi "k8s.io/kubernetes/pkg/api"
 	"k8s.io/kubernetes/pkg/api/unversioned"
 	"k8s.io/kubernetes/pkg/util/diff"
@@ -741,6 +743,49 @@ func TestImageWithMetadata(t *testing.T) {
 				},
 			},
 		},
+		"schema2 without config": {
+			image: Image{
+				DockerImageManifest: schema2Manifest,
+			},
+			expectError: true,
+		},
+		"schema2": {
+			image: Image{
+				DockerImageManifest: schema2Manifest,
+				DockerImageConfig:   schema2Config,
+			},
+			expectedImage: Image{
+				DockerImageManifest: schema2Manifest,
+				DockerImageConfig:   schema2Config,
+				DockerImageLayers: []ImageLayer{
+					{Name: "sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4", LayerSize: 32},
+					{Name: "sha256:b194de3772ebbcdc8f244f663669799ac1cb141834b7cb8b69100285d357a2b0", LayerSize: 1024},
+					{Name: "sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4", LayerSize: 32},
+				},
+				DockerImageMetadata: DockerImage{
+					ID:           "sha256:2d24f826cb16146e2016ff349a8a33ed5830f3b938d45c0f82943f4ab8c097e7",
+					Created:      unversioned.Date(2016, 4, 1, 12, 0, 0, 0, time.UTC),
+					Author:       "tester",
+					Config:       &DockerConfig{Cmd: []string{"/bin/sh"}},
+					Architecture: "amd64",
+					Size:         1056,
+				},
+			},
+		},
+		"schema2 manifest list": {
+			image: Image{
+				DockerImageManifest: `{"schemaVersion": 2, "mediaType": "application/vnd.docker.distribution.manifest.list.v2+json", "manifests": []}`,
+			},
+			expectedImage: Image{
+				DockerImageManifest: `{"schemaVersion": 2, "mediaType": "application/vnd.docker.distribution.manifest.list.v2+json", "manifests": []}`,
+			},
+		},
+		"unknown schema": {
+			image: Image{
+				DockerImageManifest: `{"schemaVersion": 3}`,
+			},
+			expectError: true,
+		},
 	}
 
 	for name, test := range tests {
@@ -759,6 +804,50 @@ func TestImageWithMetadata(t *testing.T) {
 	}
 }
 
+const (
+	schema2Manifest = `{
+   "schemaVersion": 2,
+   "mediaType": "application/vnd.docker.distribution.manifest.v2+json",
+   "config": {
+      "mediaType": "application/vnd.docker.container.image.v1+json",
+      "size": 180,
+      "digest": "sha256:2d24f826cb16146e2016ff349a8a33ed5830f3b938d45c0f82943f4ab8c097e7"
+   },
+   "layers": [
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 32,
+         "digest": "sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4"
+      },
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 1024,
+         "digest": "sha256:b194de3772ebbcdc8f244f663669799ac1cb141834b7cb8b69100285d357a2b0"
+      },
+      {
+         "mediaType": "application/vnd.docker.image.rootfs.diff.tar.gzip",
+         "size": 32,
+         "digest": "sha256:a3ed95caeb02ffe68cdd9fd84406680ae93d633cb16422d00e8a7c22955b46d4"
+      }
+   ]
+}`
+	schema2Config = `{"architecture":"amd64","author":"tester","config":{"Cmd":["/bin/sh"]},"created":"2016-04-01T12:00:00Z","os":"linux","rootfs":{"type":"layers","diff_ids":["sha256:1","sha256:2","sha256:1"]}}`
+)
+
+func TestManifestMatchesImage(t *testing.T) {
+	schema2Digest, err := digest.FromBytes([]byte(schema2Manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	image := &Image{ObjectMeta: kapi.ObjectMeta{Name: schema2Digest.String()}}
+	if ok, err := ManifestMatchesImage(image, []byte(schema2Manifest)); err != nil || !ok {
+		t.Errorf("expected the schema2 manifest to match: %t %v", ok, err)
+	}
+	if ok, err := ManifestMatchesImage(image, []byte(schema2Config)); err != nil || ok {
+		t.Errorf("expected other content not to match: %t %v", ok, err)
+	}
+}
+
 func TestLatestTaggedImage(t *testing.T) {
 	tests := []struct {
 		tag            string
@@ -1452,3 +1541,25 @@ func TestTagsChanged(t *testing.T) {
 		}
 	}
 }
+
+func TestScheduledImportInterval(t *testing.T) {
+	hour, day := int64(3600), int64(2*24*3600)
+	tests := map[string]struct {
+		policy   TagImportPolicy
+		failures int32
+		expected time.Duration
+	}{
+		"minimum":               {expected: 15 * time.Minute},
+		"shorter than minimum":  {policy: TagImportPolicy{IntervalSeconds: new(int64)}, expected: 15 * time.Minute},
+		"interval":              {policy: TagImportPolicy{IntervalSeconds: &hour}, expected: time.Hour},
+		"backoff":               {policy: TagImportPolicy{IntervalSeconds: &hour}, failures: 3, expected: 8 * time.Hour},
+		"maximum backoff":       {policy: TagImportPolicy{IntervalSeconds: &hour}, failures: 100, expected: MaxScheduledImportBackoff},
+		"longer than maximum":   {policy: TagImportPolicy{IntervalSeconds: &day}, expected: 48 * time.Hour},
+		"no backoff past limit": {policy: TagImportPolicy{IntervalSeconds: &day}, failures: 2, expected: 48 * time.Hour},
+	}
+	for name, test := range tests {
+		if actual := ScheduledImportInterval(test.policy, 15*time.Minute, test.failures); actual != test.expected {
+			t.Errorf("%s: expected %s, got %s", name, test.expected, actual)
+		}
+	}
+}
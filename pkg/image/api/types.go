@@ -64,6 +64,11 @@ type Image struct {
 	DockerImageMetadataVersion string
 	// The raw JSON of the manifest
 	DockerImageManifest string
+	// DockerImageManifestMediaType is the media type of the manifest, which if empty is derived from
+	// the schema version of the manifest.
+	DockerImageManifestMediaType string
+	// DockerImageConfig is the raw JSON of the image configuration referenced by schema2 manifests.
+	DockerImageConfig string
 	// DockerImageLayers represents the layers in the image. May not be set if the image does not define that data.
 	DockerImageLayers []ImageLayer
 	// Signatures holds all signatures of the image.
@@ -198,6 +203,20 @@ type ImageStreamSpec struct {
 	DockerImageRepository string
 	// Tags map arbitrary string values to specific image locators
 	Tags map[string]TagReference
+	// Retention limits the history of the tags kept in the status of this stream. If nil, the history
+	// is kept until images are pruned.
+	Retention *TagRetentionPolicy
+}
+
+// TagRetentionPolicy controls which entries of the history of the tags of an image stream are kept.
+// The current image of a tag is always kept, older entries are kept if any of the rules keeps them.
+type TagRetentionPolicy struct {
+	// KeepLast is the number of most recent entries kept per tag, including the current one.
+	KeepLast *int32
+	// KeepYoungerThanSeconds keeps the entries created less than this number of seconds ago.
+	KeepYoungerThanSeconds *int64
+	// PreserveTags lists shell patterns of the names of the tags whose history is never dropped.
+	PreserveTags []string
 }
 
 // TagReference specifies optional annotations for images using this tag and an optional reference to
@@ -230,6 +249,9 @@ type TagImportPolicy struct {
 	Insecure bool
 	// Scheduled indicates to the server that this tag should be periodically checked to ensure it is up to date, and imported
 	Scheduled bool
+	// IntervalSeconds is the minimum number of seconds between two scheduled imports of this tag. The interval
+	// configured for the cluster is used if it is unset or smaller.
+	IntervalSeconds *int64
 }
 
 // ImageStreamStatus contains information about the state of this image stream.
@@ -286,6 +308,11 @@ type TagEventCondition struct {
 	// This value is set to zero for older versions of streams, which means that no generation
 	// was recorded.
 	Generation int64
+	// NextScheduledImport is the earliest time a scheduled tag will be imported again.
+	NextScheduledImport *unversioned.Time
+	// Failures is the number of consecutive imports of a scheduled tag which failed. The interval between
+	// scheduled imports doubles with each failure.
+	Failures int32
 }
 
 // ImageStreamMapping represents a mapping from a single tag to a Docker image as
@@ -70,6 +70,8 @@ func init() {
 		Convert_api_TagImportPolicy_To_v1_TagImportPolicy,
 		Convert_v1_TagReference_To_api_TagReference,
 		Convert_api_TagReference_To_v1_TagReference,
+		Convert_v1_TagRetentionPolicy_To_api_TagRetentionPolicy,
+		Convert_api_TagRetentionPolicy_To_v1_TagRetentionPolicy,
 	); err != nil {
 		// if one of the conversion functions is malformed, detect it immediately.
 		panic(err)
@@ -1065,6 +1067,16 @@ func autoConvert_v1_TagEventCondition_To_api_TagEventCondition(in *TagEventCondi
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.Generation = in.Generation
+	if in.NextScheduledImport != nil {
+		in, out := &in.NextScheduledImport, &out.NextScheduledImport
+		*out = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NextScheduledImport = nil
+	}
+	out.Failures = in.Failures
 	return nil
 }
 
@@ -1081,6 +1093,16 @@ func autoConvert_api_TagEventCondition_To_v1_TagEventCondition(in *image_api.Tag
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.Generation = in.Generation
+	if in.NextScheduledImport != nil {
+		in, out := &in.NextScheduledImport, &out.NextScheduledImport
+		*out = new(unversioned.Time)
+		if err := api.Convert_unversioned_Time_To_unversioned_Time(*in, *out, s); err != nil {
+			return err
+		}
+	} else {
+		out.NextScheduledImport = nil
+	}
+	out.Failures = in.Failures
 	return nil
 }
 
@@ -1091,6 +1113,13 @@ func Convert_api_TagEventCondition_To_v1_TagEventCondition(in *image_api.TagEven
 func autoConvert_v1_TagImportPolicy_To_api_TagImportPolicy(in *TagImportPolicy, out *image_api.TagImportPolicy, s conversion.Scope) error {
 	out.Insecure = in.Insecure
 	out.Scheduled = in.Scheduled
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.IntervalSeconds = nil
+	}
 	return nil
 }
 
@@ -1101,6 +1130,13 @@ func Convert_v1_TagImportPolicy_To_api_TagImportPolicy(in *TagImportPolicy, out
 func autoConvert_api_TagImportPolicy_To_v1_TagImportPolicy(in *image_api.TagImportPolicy, out *TagImportPolicy, s conversion.Scope) error {
 	out.Insecure = in.Insecure
 	out.Scheduled = in.Scheduled
+	if in.IntervalSeconds != nil {
+		in, out := &in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.IntervalSeconds = nil
+	}
 	return nil
 }
 
@@ -1185,3 +1221,61 @@ func autoConvert_api_TagReference_To_v1_TagReference(in *image_api.TagReference,
 func Convert_api_TagReference_To_v1_TagReference(in *image_api.TagReference, out *TagReference, s conversion.Scope) error {
 	return autoConvert_api_TagReference_To_v1_TagReference(in, out, s)
 }
+
+func autoConvert_v1_TagRetentionPolicy_To_api_TagRetentionPolicy(in *TagRetentionPolicy, out *image_api.TagRetentionPolicy, s conversion.Scope) error {
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	} else {
+		out.KeepLast = nil
+	}
+	if in.KeepYoungerThanSeconds != nil {
+		in, out := &in.KeepYoungerThanSeconds, &out.KeepYoungerThanSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.KeepYoungerThanSeconds = nil
+	}
+	if in.PreserveTags != nil {
+		in, out := &in.PreserveTags, &out.PreserveTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.PreserveTags = nil
+	}
+	return nil
+}
+
+func Convert_v1_TagRetentionPolicy_To_api_TagRetentionPolicy(in *TagRetentionPolicy, out *image_api.TagRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_v1_TagRetentionPolicy_To_api_TagRetentionPolicy(in, out, s)
+}
+
+func autoConvert_api_TagRetentionPolicy_To_v1_TagRetentionPolicy(in *image_api.TagRetentionPolicy, out *TagRetentionPolicy, s conversion.Scope) error {
+	if in.KeepLast != nil {
+		in, out := &in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = **in
+	} else {
+		out.KeepLast = nil
+	}
+	if in.KeepYoungerThanSeconds != nil {
+		in, out := &in.KeepYoungerThanSeconds, &out.KeepYoungerThanSeconds
+		*out = new(int64)
+		**out = **in
+	} else {
+		out.KeepYoungerThanSeconds = nil
+	}
+	if in.PreserveTags != nil {
+		in, out := &in.PreserveTags, &out.PreserveTags
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	} else {
+		out.PreserveTags = nil
+	}
+	return nil
+}
+
+func Convert_api_TagRetentionPolicy_To_v1_TagRetentionPolicy(in *image_api.TagRetentionPolicy, out *TagRetentionPolicy, s conversion.Scope) error {
+	return autoConvert_api_TagRetentionPolicy_To_v1_TagRetentionPolicy(in, out, s)
+}
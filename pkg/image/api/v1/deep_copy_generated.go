@@ -43,6 +43,7 @@ func init() {
 		DeepCopy_v1_TagEventCondition,
 		DeepCopy_v1_TagImportPolicy,
 		DeepCopy_v1_TagReference,
+		DeepCopy_v1_TagRetentionPolicy,
 	); err != nil {
 		// if one of the deep copy functions is malformed, detect it immediately.
 		panic(err)
@@ -71,6 +72,8 @@ func DeepCopy_v1_Image(in Image, out *Image, c *conversion.Cloner) error {
 	}
 	out.DockerImageMetadataVersion = in.DockerImageMetadataVersion
 	out.DockerImageManifest = in.DockerImageManifest
+	out.DockerImageManifestMediaType = in.DockerImageManifestMediaType
+	out.DockerImageConfig = in.DockerImageConfig
 	if in.DockerImageLayers != nil {
 		in, out := in.DockerImageLayers, &out.DockerImageLayers
 		*out = make([]ImageLayer, len(in))
@@ -371,6 +374,15 @@ func DeepCopy_v1_ImageStreamSpec(in ImageStreamSpec, out *ImageStreamSpec, c *co
 	} else {
 		out.Tags = nil
 	}
+	if in.Retention != nil {
+		in, out := in.Retention, &out.Retention
+		*out = new(TagRetentionPolicy)
+		if err := DeepCopy_v1_TagRetentionPolicy(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.Retention = nil
+	}
 	return nil
 }
 
@@ -562,12 +574,29 @@ func DeepCopy_v1_TagEventCondition(in TagEventCondition, out *TagEventCondition,
 	out.Reason = in.Reason
 	out.Message = in.Message
 	out.Generation = in.Generation
+	if in.NextScheduledImport != nil {
+		in, out := in.NextScheduledImport, &out.NextScheduledImport
+		*out = new(unversioned.Time)
+		if err := unversioned.DeepCopy_unversioned_Time(*in, *out, c); err != nil {
+			return err
+		}
+	} else {
+		out.NextScheduledImport = nil
+	}
+	out.Failures = in.Failures
 	return nil
 }
 
 func DeepCopy_v1_TagImportPolicy(in TagImportPolicy, out *TagImportPolicy, c *conversion.Cloner) error {
 	out.Insecure = in.Insecure
 	out.Scheduled = in.Scheduled
+	if in.IntervalSeconds != nil {
+		in, out := in.IntervalSeconds, &out.IntervalSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.IntervalSeconds = nil
+	}
 	return nil
 }
 
@@ -604,3 +633,28 @@ func DeepCopy_v1_TagReference(in TagReference, out *TagReference, c *conversion.
 	}
 	return nil
 }
+
+func DeepCopy_v1_TagRetentionPolicy(in TagRetentionPolicy, out *TagRetentionPolicy, c *conversion.Cloner) error {
+	if in.KeepLast != nil {
+		in, out := in.KeepLast, &out.KeepLast
+		*out = new(int32)
+		**out = *in
+	} else {
+		out.KeepLast = nil
+	}
+	if in.KeepYoungerThanSeconds != nil {
+		in, out := in.KeepYoungerThanSeconds, &out.KeepYoungerThanSeconds
+		*out = new(int64)
+		**out = *in
+	} else {
+		out.KeepYoungerThanSeconds = nil
+	}
+	if in.PreserveTags != nil {
+		in, out := in.PreserveTags, &out.PreserveTags
+		*out = make([]string, len(in))
+		copy(*out, in)
+	} else {
+		out.PreserveTags = nil
+	}
+	return nil
+}
@@ -19,14 +19,16 @@ func (DockerImageReference) SwaggerDoc() map[string]string {
 }
 
 var map_Image = map[string]string{
-	"":                           "Image is an immutable representation of a Docker image and metadata at a point in time.",
-	"metadata":                   "Standard object's metadata.",
-	"dockerImageReference":       "DockerImageReference is the string that can be used to pull this image.",
-	"dockerImageMetadata":        "DockerImageMetadata contains metadata about this image",
-	"dockerImageMetadataVersion": "DockerImageMetadataVersion conveys the version of the object, which if empty defaults to \"1.0\"",
-	"dockerImageManifest":        "DockerImageManifest is the raw JSON of the manifest",
-	"dockerImageLayers":          "DockerImageLayers represents the layers in the image. May not be set if the image does not define that data.",
-	"signatures":                 "Signatures holds all signatures of the image.",
+	"":                             "Image is an immutable representation of a Docker image and metadata at a point in time.",
+	"metadata":                     "Standard object's metadata.",
+	"dockerImageReference":         "DockerImageReference is the string that can be used to pull this image.",
+	"dockerImageMetadata":          "DockerImageMetadata contains metadata about this image",
+	"dockerImageMetadataVersion":   "DockerImageMetadataVersion conveys the version of the object, which if empty defaults to \"1.0\"",
+	"dockerImageManifest":          "DockerImageManifest is the raw JSON of the manifest",
+	"dockerImageManifestMediaType": "DockerImageManifestMediaType is the media type of the manifest, which if empty is derived from the schema version of the manifest.",
+	"dockerImageConfig":            "DockerImageConfig is the raw JSON of the image configuration referenced by schema2 manifests.",
+	"dockerImageLayers":            "DockerImageLayers represents the layers in the image. May not be set if the image does not define that data.",
+	"signatures":                   "Signatures holds all signatures of the image.",
 }
 
 func (Image) SwaggerDoc() map[string]string {
@@ -171,6 +173,7 @@ var map_ImageStreamSpec = map[string]string{
 	"": "ImageStreamSpec represents options for ImageStreams.",
 	"dockerImageRepository": "DockerImageRepository is optional, if specified this stream is backed by a Docker repository on this server",
 	"tags":                  "Tags map arbitrary string values to specific image locators",
+	"retention":             "Retention limits the history of the tags kept in the status of this stream. If nil, the history is kept until images are pruned.",
 }
 
 func (ImageStreamSpec) SwaggerDoc() map[string]string {
@@ -297,13 +300,15 @@ func (TagEvent) SwaggerDoc() map[string]string {
 }
 
 var map_TagEventCondition = map[string]string{
-	"":                   "TagEventCondition contains condition information for a tag event.",
-	"type":               "Type of tag event condition, currently only ImportSuccess",
-	"status":             "Status of the condition, one of True, False, Unknown.",
-	"lastTransitionTime": "LastTransitionTIme is the time the condition transitioned from one status to another.",
-	"reason":             "Reason is a brief machine readable explanation for the condition's last transition.",
-	"message":            "Message is a human readable description of the details about last transition, complementing reason.",
-	"generation":         "Generation is the spec tag generation that this status corresponds to",
+	"":                    "TagEventCondition contains condition information for a tag event.",
+	"type":                "Type of tag event condition, currently only ImportSuccess",
+	"status":              "Status of the condition, one of True, False, Unknown.",
+	"lastTransitionTime":  "LastTransitionTIme is the time the condition transitioned from one status to another.",
+	"reason":              "Reason is a brief machine readable explanation for the condition's last transition.",
+	"message":             "Message is a human readable description of the details about last transition, complementing reason.",
+	"generation":          "Generation is the spec tag generation that this status corresponds to",
+	"nextScheduledImport": "NextScheduledImport is the earliest time a scheduled tag will be imported again.",
+	"failures":            "Failures is the number of consecutive imports of a scheduled tag which failed. The interval between scheduled imports doubles with each failure.",
 }
 
 func (TagEventCondition) SwaggerDoc() map[string]string {
@@ -311,9 +316,10 @@ func (TagEventCondition) SwaggerDoc() map[string]string {
 }
 
 var map_TagImportPolicy = map[string]string{
-	"":          "TagImportPolicy describes the tag import policy",
-	"insecure":  "Insecure is true if the server may bypass certificate verification or connect directly over HTTP during image import.",
-	"scheduled": "Scheduled indicates to the server that this tag should be periodically checked to ensure it is up to date, and imported",
+	"":                "TagImportPolicy describes the tag import policy",
+	"insecure":        "Insecure is true if the server may bypass certificate verification or connect directly over HTTP during image import.",
+	"scheduled":       "Scheduled indicates to the server that this tag should be periodically checked to ensure it is up to date, and imported",
+	"intervalSeconds": "IntervalSeconds is the minimum number of seconds between two scheduled imports of this tag. The interval configured for the cluster is used if it is unset or smaller.",
 }
 
 func (TagImportPolicy) SwaggerDoc() map[string]string {
@@ -333,3 +339,14 @@ var map_TagReference = map[string]string{
 func (TagReference) SwaggerDoc() map[string]string {
 	return map_TagReference
 }
+
+var map_TagRetentionPolicy = map[string]string{
+	"":                       "TagRetentionPolicy controls which entries of the history of the tags of an image stream are kept. The current image of a tag is always kept, older entries are kept if any of the rules keeps them.",
+	"keepLast":               "KeepLast is the number of most recent entries kept per tag, including the current one.",
+	"keepYoungerThanSeconds": "KeepYoungerThanSeconds keeps the entries created less than this number of seconds ago.",
+	"preserveTags":           "PreserveTags lists shell patterns of the names of the tags whose history is never dropped.",
+}
+
+func (TagRetentionPolicy) SwaggerDoc() map[string]string {
+	return map_TagRetentionPolicy
+}
@@ -30,6 +30,11 @@ type Image struct {
 	DockerImageMetadataVersion string `json:"dockerImageMetadataVersion,omitempty"`
 	// DockerImageManifest is the raw JSON of the manifest
 	DockerImageManifest string `json:"dockerImageManifest,omitempty"`
+	// DockerImageManifestMediaType is the media type of the manifest, which if empty is derived from
+	// the schema version of the manifest.
+	DockerImageManifestMediaType string `json:"dockerImageManifestMediaType,omitempty"`
+	// DockerImageConfig is the raw JSON of the image configuration referenced by schema2 manifests.
+	DockerImageConfig string `json:"dockerImageConfig,omitempty"`
 	// DockerImageLayers represents the layers in the image. May not be set if the image does not define that data.
 	DockerImageLayers []ImageLayer `json:"dockerImageLayers"`
 	// Signatures holds all signatures of the image.
@@ -148,6 +153,18 @@ type ImageStreamSpec struct {
 	DockerImageRepository string `json:"dockerImageRepository,omitempty"`
 	// Tags map arbitrary string values to specific image locators
 	Tags []TagReference `json:"tags,omitempty"`
+	// Retention limits the history of the tags kept in the status of this stream. If nil, the history is kept until images are pruned.
+	Retention *TagRetentionPolicy `json:"retention,omitempty"`
+}
+
+// TagRetentionPolicy controls which entries of the history of the tags of an image stream are kept. The current image of a tag is always kept, older entries are kept if any of the rules keeps them.
+type TagRetentionPolicy struct {
+	// KeepLast is the number of most recent entries kept per tag, including the current one.
+	KeepLast *int32 `json:"keepLast,omitempty"`
+	// KeepYoungerThanSeconds keeps the entries created less than this number of seconds ago.
+	KeepYoungerThanSeconds *int64 `json:"keepYoungerThanSeconds,omitempty"`
+	// PreserveTags lists shell patterns of the names of the tags whose history is never dropped.
+	PreserveTags []string `json:"preserveTags,omitempty"`
 }
 
 // TagReference specifies optional annotations for images using this tag and an optional reference to an ImageStreamTag, ImageStreamImage, or DockerImage this tag should track.
@@ -173,6 +190,9 @@ type TagImportPolicy struct {
 	Insecure bool `json:"insecure,omitempty"`
 	// Scheduled indicates to the server that this tag should be periodically checked to ensure it is up to date, and imported
 	Scheduled bool `json:"scheduled,omitempty"`
+	// IntervalSeconds is the minimum number of seconds between two scheduled imports of this tag. The interval
+	// configured for the cluster is used if it is unset or smaller.
+	IntervalSeconds *int64 `json:"intervalSeconds,omitempty"`
 }
 
 // ImageStreamStatus contains information about the state of this image stream.
@@ -229,6 +249,11 @@ type TagEventCondition struct {
 	Message string `json:"message,omitempty"`
 	// Generation is the spec tag generation that this status corresponds to
 	Generation int64 `json:"generation"`
+	// NextScheduledImport is the earliest time a scheduled tag will be imported again.
+	NextScheduledImport *unversioned.Time `json:"nextScheduledImport,omitempty"`
+	// Failures is the number of consecutive imports of a scheduled tag which failed. The interval between
+	// scheduled imports doubles with each failure.
+	Failures int32 `json:"failures,omitempty"`
 }
 
 // ImageStreamMapping represents a mapping from a single tag to a Docker image as
@@ -2,6 +2,7 @@ package validation
 
 import (
 	"fmt"
+	"path"
 	"regexp"
 
 	"github.com/docker/distribution/reference"
@@ -81,7 +82,7 @@ func validateImageSignature(signature *api.ImageSignature, fldPath *field.Path)
 		cond := &signature.Conditions[i]
 		if cond.Type == api.SignatureTrusted && (trustedCondition == nil || !cond.LastProbeTime.Before(trustedCondition.LastProbeTime)) {
 			trustedCondition = cond
-		} else if cond.Type == api.SignatureForImage && forImageCondition == nil || !cond.LastProbeTime.Before(forImageCondition.LastProbeTime) {
+		} else if cond.Type == api.SignatureForImage && (forImageCondition == nil || !cond.LastProbeTime.Before(forImageCondition.LastProbeTime)) {
 			forImageCondition = cond
 		}
 	}
@@ -145,6 +146,9 @@ func ValidateImageStream(stream *api.ImageStream) field.ErrorList {
 		path := field.NewPath("spec", "tags").Key(tag)
 		result = append(result, ValidateImageStreamTagReference(tagRef, path)...)
 	}
+	if stream.Spec.Retention != nil {
+		result = append(result, ValidateTagRetentionPolicy(stream.Spec.Retention, field.NewPath("spec", "retention"))...)
+	}
 	for tag, history := range stream.Status.Tags {
 		for i, tagEvent := range history.Items {
 			if len(tagEvent.DockerImageReference) == 0 {
@@ -156,6 +160,26 @@ func ValidateImageStream(stream *api.ImageStream) field.ErrorList {
 	return result
 }
 
+// ValidateTagRetentionPolicy ensures that a given tag retention policy is valid.
+func ValidateTagRetentionPolicy(policy *api.TagRetentionPolicy, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if policy.KeepLast == nil && policy.KeepYoungerThanSeconds == nil {
+		errs = append(errs, field.Required(fldPath, "keepLast or keepYoungerThanSeconds is required"))
+	}
+	if policy.KeepLast != nil && *policy.KeepLast < 1 {
+		errs = append(errs, field.Invalid(fldPath.Child("keepLast"), *policy.KeepLast, "must be greater than zero"))
+	}
+	if policy.KeepYoungerThanSeconds != nil && *policy.KeepYoungerThanSeconds < 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("keepYoungerThanSeconds"), *policy.KeepYoungerThanSeconds, "must be greater than or equal to zero"))
+	}
+	for i, pattern := range policy.PreserveTags {
+		if _, err := path.Match(pattern, ""); err != nil {
+			errs = append(errs, field.Invalid(fldPath.Child("preserveTags").Index(i), pattern, err.Error()))
+		}
+	}
+	return errs
+}
+
 // ValidateImageStreamTagReference ensures that a given tag reference is valid.
 func ValidateImageStreamTagReference(tagRef api.TagReference, fldPath *field.Path) field.ErrorList {
 	var errs field.ErrorList
@@ -176,6 +200,21 @@ func ValidateImageStreamTagReference(tagRef api.TagReference, fldPath *field.Pat
 			errs = append(errs, field.Required(fldPath.Child("from", "kind"), "valid values are 'DockerImage', 'ImageStreamImage', 'ImageStreamTag'"))
 		}
 	}
+	errs = append(errs, validateTagImportPolicy(tagRef.ImportPolicy, fldPath.Child("importPolicy"))...)
+	return errs
+}
+
+// validateTagImportPolicy ensures that the interval of a tag import policy is only set for scheduled imports.
+func validateTagImportPolicy(policy api.TagImportPolicy, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if policy.IntervalSeconds != nil {
+		switch {
+		case !policy.Scheduled:
+			errs = append(errs, field.Invalid(fldPath.Child("intervalSeconds"), *policy.IntervalSeconds, "only scheduled imports may have an interval"))
+		case *policy.IntervalSeconds <= 0:
+			errs = append(errs, field.Invalid(fldPath.Child("intervalSeconds"), *policy.IntervalSeconds, "must be greater than zero"))
+		}
+	}
 	return errs
 }
 
@@ -283,6 +322,7 @@ func ValidateImageStreamImport(isi *api.ImageStreamImport) field.ErrorList {
 					}
 				}
 			}
+			errs = append(errs, validateTagImportPolicy(spec.ImportPolicy, imagesPath.Index(i).Child("importPolicy"))...)
 		default:
 			errs = append(errs, field.Invalid(imagesPath.Index(i).Child("from", "kind"), from.Kind, "only DockerImage is supported"))
 		}
@@ -130,6 +130,29 @@ func TestValidateImageSignature(t *testing.T) {
 			expected: field.ErrorList{},
 		},
 
+		{
+			name: "valid expired",
+			signature: api.ImageSignature{
+				Type:    "valid",
+				Content: []byte("blob"),
+				Conditions: []api.SignatureCondition{
+					{
+						Type:   api.SignatureExpired,
+						Status: kapi.ConditionTrue,
+					},
+					{
+						Type:   api.SignatureTrusted,
+						Status: kapi.ConditionFalse,
+					},
+					{
+						Type:   api.SignatureForImage,
+						Status: kapi.ConditionTrue,
+					},
+				},
+			},
+			expected: field.ErrorList{},
+		},
+
 		{
 			name: "missing type",
 			signature: api.ImageSignature{
@@ -338,6 +361,7 @@ func TestValidateImageStream(t *testing.T) {
 		dockerImageRepository string
 		specTags              map[string]api.TagReference
 		statusTags            map[string]api.TagEventList
+		retention             *api.TagRetentionPolicy
 		expected              field.ErrorList
 	}{
 		"missing name": {
@@ -476,6 +500,32 @@ func TestValidateImageStream(t *testing.T) {
 				field.Invalid(field.NewPath("spec", "tags").Key("otherimage").Child("importPolicy", "scheduled"), true, "only tags pointing to Docker repositories may be scheduled for background import"),
 			},
 		},
+		"import interval of a tag which is not scheduled": {
+			namespace: "namespace",
+			name:      "foo",
+			specTags: map[string]api.TagReference{
+				"tag": {
+					From:         &kapi.ObjectReference{Kind: "DockerImage", Name: "abc"},
+					ImportPolicy: api.TagImportPolicy{IntervalSeconds: newInt64(60)},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "tags").Key("tag").Child("importPolicy", "intervalSeconds"), int64(60), "only scheduled imports may have an interval"),
+			},
+		},
+		"invalid import interval": {
+			namespace: "namespace",
+			name:      "foo",
+			specTags: map[string]api.TagReference{
+				"tag": {
+					From:         &kapi.ObjectReference{Kind: "DockerImage", Name: "abc"},
+					ImportPolicy: api.TagImportPolicy{Scheduled: true, IntervalSeconds: newInt64(0)},
+				},
+			},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "tags").Key("tag").Child("importPolicy", "intervalSeconds"), int64(0), "must be greater than zero"),
+			},
+		},
 		"valid": {
 			namespace: "namespace",
 			name:      "foo",
@@ -517,6 +567,30 @@ func TestValidateImageStream(t *testing.T) {
 			name:      name191Char,
 			expected:  field.ErrorList{},
 		},
+		"valid retention": {
+			namespace: "namespace",
+			name:      "foo",
+			retention: &api.TagRetentionPolicy{KeepLast: newInt32(3), PreserveTags: []string{"v*"}},
+			expected:  field.ErrorList{},
+		},
+		"empty retention": {
+			namespace: "namespace",
+			name:      "foo",
+			retention: &api.TagRetentionPolicy{PreserveTags: []string{"latest"}},
+			expected: field.ErrorList{
+				field.Required(field.NewPath("spec", "retention"), "keepLast or keepYoungerThanSeconds is required"),
+			},
+		},
+		"invalid retention": {
+			namespace: "namespace",
+			name:      "foo",
+			retention: &api.TagRetentionPolicy{KeepLast: newInt32(0), KeepYoungerThanSeconds: newInt64(-1), PreserveTags: []string{"v[1"}},
+			expected: field.ErrorList{
+				field.Invalid(field.NewPath("spec", "retention", "keepLast"), int32(0), "must be greater than zero"),
+				field.Invalid(field.NewPath("spec", "retention", "keepYoungerThanSeconds"), int64(-1), "must be greater than or equal to zero"),
+				field.Invalid(field.NewPath("spec", "retention", "preserveTags").Index(0), "v[1", "syntax error in pattern"),
+			},
+		},
 		"max name and namespace length exceeded": {
 			namespace: namespace63Char,
 			name:      name192Char,
@@ -534,7 +608,8 @@ func TestValidateImageStream(t *testing.T) {
 			},
 			Spec: api.ImageStreamSpec{
 				DockerImageRepository: test.dockerImageRepository,
-				Tags: test.specTags,
+				Tags:                  test.specTags,
+				Retention:             test.retention,
 			},
 			Status: api.ImageStreamStatus{
 				Tags: test.statusTags,
@@ -804,3 +879,11 @@ func TestValidateImageStreamImport(t *testing.T) {
 		}
 	}
 }
+
+func newInt32(i int32) *int32 {
+	return &i
+}
+
+func newInt64(i int64) *int64 {
+	return &i
+}
@@ -2,6 +2,7 @@ package controller
 
 import (
 	"errors"
+	"time"
 
 	"github.com/golang/glog"
 
@@ -16,6 +17,9 @@ var ErrNotImportable = errors.New("the specified stream cannot be imported")
 
 type ImportController struct {
 	streams client.ImageStreamsNamespacer
+	// scheduleWindow is how early a scheduled import may run before the time recorded in the
+	// condition of the tag, so that checks which happen periodically don't miss it by a few moments.
+	scheduleWindow time.Duration
 }
 
 // Notifier provides information about when the controller makes a decision
@@ -90,15 +94,29 @@ func needsScheduling(stream *api.ImageStream) bool {
 	return false
 }
 
-// resetScheduledTags artificially increments the generation on the tags that should be imported.
-func resetScheduledTags(stream *api.ImageStream) {
+// scheduledImportDue returns true if the next import of a scheduled tag, as recorded by the last import,
+// is due before the given time.
+func scheduledImportDue(stream *api.ImageStream, tag string, before time.Time) bool {
+	condition := api.TagImportCondition(stream, tag)
+	if condition == nil || condition.NextScheduledImport == nil {
+		return true
+	}
+	return !before.Before(condition.NextScheduledImport.Time)
+}
+
+// resetScheduledTags artificially increments the generation on the scheduled tags whose import is due
+// before the given time, and returns the number of tags that should be imported.
+func resetScheduledTags(stream *api.ImageStream, before time.Time) int {
 	next := stream.Generation + 1
+	count := 0
 	for tag, tagRef := range stream.Spec.Tags {
-		if tagImportable(tagRef) && tagRef.ImportPolicy.Scheduled {
+		if tagImportable(tagRef) && tagRef.ImportPolicy.Scheduled && scheduledImportDue(stream, tag, before) {
 			tagRef.Generation = &next
 			stream.Spec.Tags[tag] = tagRef
+			count++
 		}
 	}
+	return count
 }
 
 // retryCount is the number of times to retry on a conflict when updating an image stream
@@ -188,7 +206,10 @@ func (c *ImportController) NextTimed(stream *api.ImageStream) error {
 	if !needsScheduling(stream) {
 		return ErrNotImportable
 	}
-	resetScheduledTags(stream)
+	if resetScheduledTags(stream, time.Now().Add(c.scheduleWindow)) == 0 {
+		glog.V(5).Infof("No scheduled import of stream %s/%s is due yet", stream.Namespace, stream.Name)
+		return nil
+	}
 
 	glog.V(3).Infof("Scheduled import of stream %s/%s...", stream.Namespace, stream.Name)
 
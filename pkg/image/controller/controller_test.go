@@ -330,7 +330,7 @@ func TestControllerExternalRepo(t *testing.T) {
 
 func TestScheduledImport(t *testing.T) {
 	fake := &client.Fake{}
-	b := newScheduled(true, fake, 1, nil, nil)
+	b := newScheduled(true, fake, 1, nil, nil, 0)
 
 	one := int64(1)
 	stream := &api.ImageStream{
@@ -424,3 +424,57 @@ func TestScheduledImport(t *testing.T) {
 		t.Fatalf("should have left scheduled: %#v", b.scheduler)
 	}
 }
+
+func TestScheduledImportNotDue(t *testing.T) {
+	one := int64(1)
+	next := unversioned.NewTime(time.Now().Add(time.Hour))
+	stream := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: "test", Namespace: "other", UID: "1", ResourceVersion: "1",
+			Annotations: map[string]string{api.DockerImageRepositoryCheckAnnotation: "done"},
+			Generation:  1,
+		},
+		Spec: api.ImageStreamSpec{
+			Tags: map[string]api.TagReference{
+				"default": {
+					From:         &kapi.ObjectReference{Kind: "DockerImage", Name: "mysql:latest"},
+					Generation:   &one,
+					ImportPolicy: api.TagImportPolicy{Scheduled: true},
+				},
+			},
+		},
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{
+				"default": {
+					Items: []api.TagEvent{{Generation: 1}},
+					Conditions: []api.TagEventCondition{{
+						Type:                api.ImportSuccess,
+						Status:              kapi.ConditionFalse,
+						Generation:          1,
+						Failures:            2,
+						NextScheduledImport: &next,
+					}},
+				},
+			},
+		},
+	}
+
+	fake := client.NewSimpleFake(stream)
+	c := ImportController{streams: fake}
+	if err := c.NextTimedByName("other", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Actions()) != 1 || !fake.Actions()[0].Matches("get", "imagestreams") {
+		t.Fatalf("expected no import before the next scheduled import: %#v", fake.Actions())
+	}
+
+	// the import is due when the next scheduled import falls within the window
+	fake = client.NewSimpleFake(stream, &api.ImageStreamImport{ObjectMeta: kapi.ObjectMeta{Name: "test"}})
+	c = ImportController{streams: fake, scheduleWindow: 2 * time.Hour}
+	if err := c.NextTimedByName("other", "test"); err != nil {
+		t.Fatal(err)
+	}
+	if len(fake.Actions()) != 2 || !fake.Actions()[1].Matches("create", "imagestreamimports") {
+		t.Fatalf("expected the stream to be imported: %#v", fake.Actions())
+	}
+}
@@ -51,7 +51,7 @@ func (f *ImportControllerFactory) Create() (controller.RunnableController, contr
 	bucketQPS := 1.0 / float32(seconds) * float32(buckets)
 
 	limiter := flowcontrol.NewTokenBucketRateLimiter(bucketQPS, 1)
-	b := newScheduled(f.ScheduleEnabled, f.Client, buckets, limiter, f.ImportRateLimiter)
+	b := newScheduled(f.ScheduleEnabled, f.Client, buckets, limiter, f.ImportRateLimiter, f.MinimumCheckInterval/2)
 
 	// instantiate an importer for changes that happen to the image stream
 	changed := &controller.RetryController{
@@ -85,13 +85,15 @@ type scheduled struct {
 	controller  *ImportController
 }
 
-// newScheduled initializes a scheduled import object and sets its scheduler. Limiter is optional.
-func newScheduled(enabled bool, client client.ImageStreamsNamespacer, buckets int, bucketLimiter, importLimiter flowcontrol.RateLimiter) *scheduled {
+// newScheduled initializes a scheduled import object and sets its scheduler. Limiter is optional. Scheduled
+// tags are imported when their next import is due within scheduleWindow.
+func newScheduled(enabled bool, client client.ImageStreamsNamespacer, buckets int, bucketLimiter, importLimiter flowcontrol.RateLimiter, scheduleWindow time.Duration) *scheduled {
 	b := &scheduled{
 		enabled:     enabled,
 		rateLimiter: importLimiter,
 		controller: &ImportController{
-			streams: client,
+			streams:        client,
+			scheduleWindow: scheduleWindow,
 		},
 	}
 	b.scheduler = controller.NewScheduler(buckets, bucketLimiter, b.HandleTimed)
@@ -0,0 +1,341 @@
+package controller
+
+import (
+	"path"
+	"time"
+
+	"github.com/golang/glog"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	apierrs "k8s.io/kubernetes/pkg/api/errors"
+	"k8s.io/kubernetes/pkg/client/cache"
+	kclient "k8s.io/kubernetes/pkg/client/unversioned"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/flowcontrol"
+	utilruntime "k8s.io/kubernetes/pkg/util/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+	"k8s.io/kubernetes/pkg/watch"
+
+	buildutil "github.com/openshift/origin/pkg/build/util"
+	"github.com/openshift/origin/pkg/client"
+	"github.com/openshift/origin/pkg/controller"
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+// RetentionControllerFactory can create a RetentionController.
+type RetentionControllerFactory struct {
+	Client         client.Interface
+	KubeClient     kclient.Interface
+	ResyncInterval time.Duration
+}
+
+// Create creates a RetentionController.
+func (f *RetentionControllerFactory) Create() controller.RunnableController {
+	lw := &cache.ListWatch{
+		ListFunc: func(options kapi.ListOptions) (runtime.Object, error) {
+			return f.Client.ImageStreams(kapi.NamespaceAll).List(options)
+		},
+		WatchFunc: func(options kapi.ListOptions) (watch.Interface, error) {
+			return f.Client.ImageStreams(kapi.NamespaceAll).Watch(options)
+		},
+	}
+	q := cache.NewFIFO(cache.MetaNamespaceKeyFunc)
+	cache.NewReflector(lw, &api.ImageStream{}, q, f.ResyncInterval).Run()
+
+	c := &RetentionController{
+		streams:    f.Client,
+		pods:       f.KubeClient,
+		rcs:        f.KubeClient,
+		extensions: f.KubeClient.Extensions(),
+		dcs:        f.Client,
+		bcs:        f.Client,
+		builds:     f.Client,
+		now:        time.Now,
+	}
+
+	return &controller.RetryController{
+		Queue: q,
+		RetryManager: controller.NewQueueRetryManager(
+			q,
+			cache.MetaNamespaceKeyFunc,
+			func(obj interface{}, err error, retries controller.Retry) bool {
+				utilruntime.HandleError(err)
+				return retries.Count < 5
+			},
+			flowcontrol.NewTokenBucketRateLimiter(1, 10),
+		),
+		Handle: func(obj interface{}) error {
+			return c.Next(obj.(*api.ImageStream))
+		},
+	}
+}
+
+// imagesInUseTTL is how long the images in use are reused for the streams trimmed after them. All
+// the streams are queued at once on every resync, so they are listed about once per resync.
+const imagesInUseTTL = time.Minute
+
+// RetentionController removes the entries of the tag histories of image streams which are no longer
+// kept by the retention policies of the streams.
+type RetentionController struct {
+	streams    client.ImageStreamsNamespacer
+	pods       kclient.PodsNamespacer
+	rcs        kclient.ReplicationControllersNamespacer
+	extensions kclient.ExtensionsInterface
+	dcs        client.DeploymentConfigsNamespacer
+	bcs        client.BuildConfigsNamespacer
+	builds     client.BuildsNamespacer
+	now        func() time.Time
+
+	// inUse caches the images in use listed at inUseListed. The streams are handled one at a
+	// time, so it needs no lock.
+	inUse       sets.String
+	inUseListed time.Time
+}
+
+// Next trims the tag history of the given image stream according to its retention policy. The
+// stream is fetched again and trimmed a limited number of times if the update conflicts.
+func (c *RetentionController) Next(stream *api.ImageStream) error {
+	if stream.Spec.Retention == nil {
+		return nil
+	}
+	// the images in use are only looked up once the policy removes some events
+	if updated, err := trimTagHistory(stream, stream.Spec.Retention, sets.NewString(), c.now()); err != nil || updated == nil {
+		return err
+	}
+	inUse, err := c.imagesInUse()
+	if err != nil {
+		return err
+	}
+
+	for i := 0; ; i++ {
+		if stream.Spec.Retention == nil {
+			return nil
+		}
+		updated, err := trimTagHistory(stream, stream.Spec.Retention, inUse, c.now())
+		if err != nil {
+			return err
+		}
+		if updated == nil {
+			return nil
+		}
+		glog.V(3).Infof("Trimming the tag history of stream %s/%s", stream.Namespace, stream.Name)
+		_, err = c.streams.ImageStreams(stream.Namespace).UpdateStatus(updated)
+		switch {
+		case err == nil, apierrs.IsNotFound(err):
+			return nil
+		case !apierrs.IsConflict(err) || i >= retryCount:
+			return err
+		}
+		if stream, err = c.streams.ImageStreams(stream.Namespace).Get(stream.Name); err != nil {
+			if apierrs.IsNotFound(err) {
+				return nil
+			}
+			return err
+		}
+	}
+}
+
+// imagesInUse returns the names of the images referenced by the workloads, builds and image streams
+// of all namespaces, as listed by listImagesInUse. Those may be pulled by digest through the tag
+// events of the image, from the namespace of the stream or from any namespace allowed to pull
+// from it, so the events must be kept. The images are listed again once imagesInUseTTL passed.
+func (c *RetentionController) imagesInUse() (sets.String, error) {
+	if c.inUse != nil && c.now().Sub(c.inUseListed) < imagesInUseTTL {
+		return c.inUse, nil
+	}
+	inUse, err := c.listImagesInUse()
+	if err != nil {
+		return nil, err
+	}
+	c.inUse, c.inUseListed = inUse, c.now()
+	return inUse, nil
+}
+
+// listImagesInUse lists the images in use from the pods, replication controllers, deployments,
+// replica sets, daemon sets, jobs, deployment configs, build configs, builds and image stream spec
+// tags of all namespaces, which the image pruner treats as references to images as well.
+func (c *RetentionController) listImagesInUse() (sets.String, error) {
+	inUse := sets.NewString()
+	pods, err := c.pods.Pods(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		addPodSpecImages(inUse, &pods.Items[i].Spec)
+	}
+	rcs, err := c.rcs.ReplicationControllers(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range rcs.Items {
+		if template := rcs.Items[i].Spec.Template; template != nil {
+			addPodSpecImages(inUse, &template.Spec)
+		}
+	}
+	if err := c.listExtensionsImagesInUse(inUse); err != nil {
+		return nil, err
+	}
+	dcs, err := c.dcs.DeploymentConfigs(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range dcs.Items {
+		if template := dcs.Items[i].Spec.Template; template != nil {
+			addPodSpecImages(inUse, &template.Spec)
+		}
+	}
+	bcs, err := c.bcs.BuildConfigs(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range bcs.Items {
+		addReferenceImage(inUse, buildutil.GetInputReference(bcs.Items[i].Spec.Strategy))
+	}
+	builds, err := c.builds.Builds(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range builds.Items {
+		addReferenceImage(inUse, buildutil.GetInputReference(builds.Items[i].Spec.Strategy))
+	}
+	streams, err := c.streams.ImageStreams(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range streams.Items {
+		for _, tag := range streams.Items[i].Spec.Tags {
+			addReferenceImage(inUse, tag.From)
+		}
+	}
+	return inUse, nil
+}
+
+// listExtensionsImagesInUse adds the images in use from the deployments, replica sets, daemon sets
+// and jobs of all namespaces to inUse. Their API group may be disabled on the master, in which case
+// they are ignored.
+func (c *RetentionController) listExtensionsImagesInUse(inUse sets.String) error {
+	deployments, err := c.extensions.Deployments(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	if deployments != nil {
+		for i := range deployments.Items {
+			addPodSpecImages(inUse, &deployments.Items[i].Spec.Template.Spec)
+		}
+	}
+	replicaSets, err := c.extensions.ReplicaSets(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	if replicaSets != nil {
+		for i := range replicaSets.Items {
+			addPodSpecImages(inUse, &replicaSets.Items[i].Spec.Template.Spec)
+		}
+	}
+	daemonSets, err := c.extensions.DaemonSets(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	if daemonSets != nil {
+		for i := range daemonSets.Items {
+			addPodSpecImages(inUse, &daemonSets.Items[i].Spec.Template.Spec)
+		}
+	}
+	jobs, err := c.extensions.Jobs(kapi.NamespaceAll).List(kapi.ListOptions{})
+	if err != nil && !apierrs.IsNotFound(err) {
+		return err
+	}
+	if jobs != nil {
+		for i := range jobs.Items {
+			addPodSpecImages(inUse, &jobs.Items[i].Spec.Template.Spec)
+		}
+	}
+	return nil
+}
+
+// addPodSpecImages adds the images the containers of spec reference by digest to images.
+func addPodSpecImages(images sets.String, spec *kapi.PodSpec) {
+	for _, container := range spec.Containers {
+		if ref, err := api.ParseDockerImageReference(container.Image); err == nil && len(ref.ID) > 0 {
+			images.Insert(ref.ID)
+		}
+	}
+}
+
+// addReferenceImage adds the image from references by digest to images, either through an image
+// stream image or a pull spec.
+func addReferenceImage(images sets.String, from *kapi.ObjectReference) {
+	if from == nil {
+		return
+	}
+	switch from.Kind {
+	case "ImageStreamImage":
+		if _, id, err := api.ParseImageStreamImageName(from.Name); err == nil {
+			images.Insert(id)
+		}
+	case "DockerImage":
+		if ref, err := api.ParseDockerImageReference(from.Name); err == nil && len(ref.ID) > 0 {
+			images.Insert(ref.ID)
+		}
+	}
+}
+
+// trimTagHistory returns a copy of stream without the tag events the policy doesn't keep, or nil
+// if the policy keeps all of them. The current event of each tag is always kept, the others are kept
+// if they are among the policy.KeepLast most recent ones or younger than
+// policy.KeepYoungerThanSeconds. The histories of the tags matching policy.PreserveTags are never
+// trimmed, and the events of the images in inUse are always kept.
+func trimTagHistory(stream *api.ImageStream, policy *api.TagRetentionPolicy, inUse sets.String, now time.Time) (*api.ImageStream, error) {
+	keep := func(i int, event api.TagEvent) bool {
+		if i == 0 || inUse.Has(event.Image) {
+			return true
+		}
+		if policy.KeepLast != nil && i < int(*policy.KeepLast) {
+			return true
+		}
+		if policy.KeepYoungerThanSeconds != nil && now.Sub(event.Created.Time) < time.Duration(*policy.KeepYoungerThanSeconds)*time.Second {
+			return true
+		}
+		return false
+	}
+
+	trimmed := map[string]api.TagEventList{}
+	for tag, history := range stream.Status.Tags {
+		if preserved(tag, policy.PreserveTags) {
+			continue
+		}
+		items := []api.TagEvent{}
+		for i, event := range history.Items {
+			if keep(i, event) {
+				items = append(items, event)
+			}
+		}
+		if len(items) == len(history.Items) {
+			continue
+		}
+		trimmed[tag] = api.TagEventList{Items: items, Conditions: history.Conditions}
+	}
+	if len(trimmed) == 0 {
+		return nil, nil
+	}
+
+	copied, err := kapi.Scheme.DeepCopy(stream)
+	if err != nil {
+		return nil, err
+	}
+	updated := copied.(*api.ImageStream)
+	for tag, history := range trimmed {
+		updated.Status.Tags[tag] = history
+	}
+	return updated, nil
+}
+
+// preserved returns true if tag matches one of the patterns.
+func preserved(tag string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if ok, _ := path.Match(pattern, tag); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,354 @@
+package controller
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+	"k8s.io/kubernetes/pkg/apis/extensions"
+	ktestclient "k8s.io/kubernetes/pkg/client/unversioned/testclient"
+	"k8s.io/kubernetes/pkg/runtime"
+	"k8s.io/kubernetes/pkg/util/sets"
+
+	buildapi "github.com/openshift/origin/pkg/build/api"
+	"github.com/openshift/origin/pkg/client/testclient"
+	deployapi "github.com/openshift/origin/pkg/deploy/api"
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+func tagEvents(now time.Time, ages ...time.Duration) []api.TagEvent {
+	events := []api.TagEvent{}
+	for i, age := range ages {
+		events = append(events, api.TagEvent{
+			Created:              unversioned.NewTime(now.Add(-age)),
+			DockerImageReference: "registry/test/app@sha256:" + string(rune('a'+i)),
+			Image:                "sha256:" + string(rune('a'+i)),
+		})
+	}
+	return events
+}
+
+func TestTrimTagHistory(t *testing.T) {
+	now := time.Now()
+	two, hour := int32(2), int64(3600)
+	history := tagEvents(now, 3*time.Hour, 2*time.Hour, 30*time.Minute, 4*time.Hour)
+
+	tests := map[string]struct {
+		policy   api.TagRetentionPolicy
+		inUse    sets.String
+		expected []api.TagEvent
+	}{
+		"keep last": {
+			policy:   api.TagRetentionPolicy{KeepLast: &two},
+			expected: history[:2],
+		},
+		"keep younger than": {
+			policy:   api.TagRetentionPolicy{KeepYoungerThanSeconds: &hour},
+			expected: []api.TagEvent{history[0], history[2]},
+		},
+		"keep last or younger than": {
+			policy:   api.TagRetentionPolicy{KeepLast: &two, KeepYoungerThanSeconds: &hour},
+			expected: history[:3],
+		},
+		"the current image is always kept": {
+			policy:   api.TagRetentionPolicy{KeepYoungerThanSeconds: new(int64)},
+			expected: history[:1],
+		},
+		"preserved tag": {
+			policy:   api.TagRetentionPolicy{KeepLast: &two, PreserveTags: []string{"v*"}},
+			expected: history,
+		},
+		"images in use are kept": {
+			policy:   api.TagRetentionPolicy{KeepLast: &two},
+			inUse:    sets.NewString(history[3].Image),
+			expected: []api.TagEvent{history[0], history[1], history[3]},
+		},
+	}
+	for name, test := range tests {
+		stream := &api.ImageStream{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "app"},
+			Status: api.ImageStreamStatus{
+				Tags: map[string]api.TagEventList{
+					"v1": {Items: history},
+				},
+			},
+		}
+		inUse := test.inUse
+		if inUse == nil {
+			inUse = sets.NewString()
+		}
+		updated, err := trimTagHistory(stream, &test.policy, inUse, now)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if len(test.expected) == len(history) {
+			if updated != nil {
+				t.Errorf("%s: expected no update, got %#v", name, updated.Status.Tags)
+			}
+			continue
+		}
+		if updated == nil {
+			t.Errorf("%s: expected an update", name)
+			continue
+		}
+		if items := updated.Status.Tags["v1"].Items; !reflect.DeepEqual(items, test.expected) {
+			t.Errorf("%s: unexpected history: %#v", name, items)
+		}
+		if len(stream.Status.Tags["v1"].Items) != len(history) {
+			t.Errorf("%s: the stream was modified", name)
+		}
+	}
+}
+
+func TestRetentionControllerNext(t *testing.T) {
+	now := time.Now()
+	one := int32(1)
+	stream := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "app"},
+		Spec: api.ImageStreamSpec{
+			Retention: &api.TagRetentionPolicy{KeepLast: &one, PreserveTags: []string{"stable"}},
+		},
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{
+				"latest": {Items: tagEvents(now, time.Hour, 2*time.Hour)},
+				"stable": {Items: tagEvents(now, time.Hour, 2*time.Hour)},
+			},
+		},
+	}
+	fake := testclient.NewSimpleFake()
+	var updated *api.ImageStream
+	fake.PrependReactor("update", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		updated = action.(ktestclient.CreateAction).GetObject().(*api.ImageStream)
+		return true, updated, nil
+	})
+	kfake := ktestclient.NewSimpleFake()
+	c := &RetentionController{streams: fake, pods: kfake, rcs: kfake, extensions: kfake.Extensions(), dcs: fake, bcs: fake, builds: fake, now: func() time.Time { return now }}
+
+	if err := c.Next(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated == nil {
+		t.Fatalf("expected the status to be updated: %#v", fake.Actions())
+	}
+	if len(updated.Status.Tags["latest"].Items) != 1 || len(updated.Status.Tags["stable"].Items) != 2 {
+		t.Errorf("unexpected tags: %#v", updated.Status.Tags)
+	}
+
+	// streams without a retention policy are left alone
+	fake.ClearActions()
+	kfake.ClearActions()
+	stream.Spec.Retention = nil
+	if err := c.Next(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actions := append(fake.Actions(), kfake.Actions()...); len(actions) != 0 {
+		t.Errorf("unexpected actions: %#v", actions)
+	}
+}
+
+func TestRetentionControllerImagesInUse(t *testing.T) {
+	now := time.Now()
+	zero := int32(0)
+	history := tagEvents(now, time.Hour, 2*time.Hour, 3*time.Hour, 4*time.Hour, 5*time.Hour, 6*time.Hour, 7*time.Hour, 8*time.Hour)
+	pullSpec := func(i int) string {
+		return history[i].DockerImageReference
+	}
+	stream := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "app"},
+		Spec: api.ImageStreamSpec{
+			Retention: &api.TagRetentionPolicy{KeepLast: &zero},
+		},
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{
+				"latest": {Items: history},
+			},
+		},
+	}
+	podSpec := func(image string) kapi.PodSpec {
+		return kapi.PodSpec{Containers: []kapi.Container{{Name: "app", Image: image}}}
+	}
+
+	kfake := ktestclient.NewSimpleFake(
+		// a consumer in another namespace pulling the image through the stream
+		&kapi.Pod{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "other", Name: "pod"},
+			Spec:       podSpec(pullSpec(1)),
+		},
+		&kapi.ReplicationController{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "rc"},
+			Spec:       kapi.ReplicationControllerSpec{Template: &kapi.PodTemplateSpec{Spec: podSpec(pullSpec(2))}},
+		},
+		&extensions.Deployment{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "deployment"},
+			Spec:       extensions.DeploymentSpec{Template: kapi.PodTemplateSpec{Spec: podSpec(pullSpec(5))}},
+		},
+	)
+	fake := testclient.NewSimpleFake(
+		&deployapi.DeploymentConfig{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "dc"},
+			Spec:       deployapi.DeploymentConfigSpec{Template: &kapi.PodTemplateSpec{Spec: podSpec(pullSpec(3))}},
+		},
+		&buildapi.Build{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "build"},
+			Spec: buildapi.BuildSpec{
+				CommonSpec: buildapi.CommonSpec{
+					Strategy: buildapi.BuildStrategy{
+						SourceStrategy: &buildapi.SourceBuildStrategy{
+							From: kapi.ObjectReference{Kind: "ImageStreamImage", Name: "app@" + history[4].Image},
+						},
+					},
+				},
+			},
+		},
+		&buildapi.BuildConfig{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "other", Name: "bc"},
+			Spec: buildapi.BuildConfigSpec{
+				CommonSpec: buildapi.CommonSpec{
+					Strategy: buildapi.BuildStrategy{
+						DockerStrategy: &buildapi.DockerBuildStrategy{
+							From: &kapi.ObjectReference{Kind: "ImageStreamImage", Namespace: "test", Name: "app@" + history[6].Image},
+						},
+					},
+				},
+			},
+		},
+		// another stream tagging the image of an event
+		&api.ImageStream{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "other", Name: "app"},
+			Spec: api.ImageStreamSpec{
+				Tags: map[string]api.TagReference{
+					"pinned": {Name: "pinned", From: &kapi.ObjectReference{Kind: "ImageStreamImage", Namespace: "test", Name: "app@" + history[7].Image}},
+				},
+			},
+		},
+	)
+	var updated *api.ImageStream
+	fake.PrependReactor("update", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		updated = action.(ktestclient.CreateAction).GetObject().(*api.ImageStream)
+		return true, updated, nil
+	})
+	c := &RetentionController{streams: fake, pods: kfake, rcs: kfake, extensions: kfake.Extensions(), dcs: fake, bcs: fake, builds: fake, now: func() time.Time { return now }}
+
+	// every event is in use, either as the current image of the tag or by a pod, a replication
+	// controller, a deployment config, a build, a deployment, a build config or another stream
+	if err := c.Next(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated != nil {
+		t.Fatalf("expected no update, got %#v", updated.Status.Tags)
+	}
+	for _, action := range append(kfake.Actions(), fake.Actions()...) {
+		if action.GetVerb() == "list" && action.GetNamespace() != kapi.NamespaceAll {
+			t.Errorf("expected %s to be listed in all namespaces, got %q", action.GetResource(), action.GetNamespace())
+		}
+	}
+
+	// images no longer in use are removed
+	fake.PrependReactor("list", "builds", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, &buildapi.BuildList{}, nil
+	})
+	if err := c.Next(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated == nil {
+		t.Fatalf("expected the status to be updated: %#v", fake.Actions())
+	}
+	if items := updated.Status.Tags["latest"].Items; !reflect.DeepEqual(items, append(history[:4:4], history[5:]...)) {
+		t.Errorf("unexpected history: %#v", items)
+	}
+}
+
+func TestRetentionControllerImagesInUseListedOncePerResync(t *testing.T) {
+	now := time.Now()
+	one := int32(1)
+	stream := func(name string) *api.ImageStream {
+		return &api.ImageStream{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: name},
+			Spec:       api.ImageStreamSpec{Retention: &api.TagRetentionPolicy{KeepLast: &one}},
+			Status: api.ImageStreamStatus{
+				Tags: map[string]api.TagEventList{"latest": {Items: tagEvents(now, time.Hour, 2*time.Hour)}},
+			},
+		}
+	}
+	fake := testclient.NewSimpleFake()
+	fake.PrependReactor("update", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		return true, action.(ktestclient.UpdateAction).GetObject(), nil
+	})
+	kfake := ktestclient.NewSimpleFake()
+	c := &RetentionController{streams: fake, pods: kfake, rcs: kfake, extensions: kfake.Extensions(), dcs: fake, bcs: fake, builds: fake, now: func() time.Time { return now }}
+
+	podLists := func() int {
+		count := 0
+		for _, action := range kfake.Actions() {
+			if action.GetVerb() == "list" && action.GetResource() == "pods" {
+				count++
+			}
+		}
+		return count
+	}
+
+	for _, name := range []string{"app", "db"} {
+		if err := c.Next(stream(name)); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if count := podLists(); count != 1 {
+		t.Errorf("expected the pods to be listed once for the streams trimmed together, got %d", count)
+	}
+
+	now = now.Add(imagesInUseTTL)
+	if err := c.Next(stream("web")); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count := podLists(); count != 2 {
+		t.Errorf("expected the pods to be listed again on the next resync, got %d", count)
+	}
+}
+
+func TestRetentionControllerBuildConfigImagesInUse(t *testing.T) {
+	now := time.Now()
+	one := int32(1)
+	history := tagEvents(now, time.Hour, 2*time.Hour, 3*time.Hour)
+	stream := &api.ImageStream{
+		ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "app"},
+		Spec:       api.ImageStreamSpec{Retention: &api.TagRetentionPolicy{KeepLast: &one}},
+		Status: api.ImageStreamStatus{
+			Tags: map[string]api.TagEventList{"latest": {Items: history}},
+		},
+	}
+	fake := testclient.NewSimpleFake(
+		&buildapi.BuildConfig{
+			ObjectMeta: kapi.ObjectMeta{Namespace: "test", Name: "bc"},
+			Spec: buildapi.BuildConfigSpec{
+				CommonSpec: buildapi.CommonSpec{
+					Strategy: buildapi.BuildStrategy{
+						SourceStrategy: &buildapi.SourceBuildStrategy{
+							From: kapi.ObjectReference{Kind: "DockerImage", Name: history[2].DockerImageReference},
+						},
+					},
+				},
+			},
+		},
+	)
+	var updated *api.ImageStream
+	fake.PrependReactor("update", "imagestreams", func(action ktestclient.Action) (bool, runtime.Object, error) {
+		updated = action.(ktestclient.UpdateAction).GetObject().(*api.ImageStream)
+		return true, updated, nil
+	})
+	kfake := ktestclient.NewSimpleFake()
+	c := &RetentionController{streams: fake, pods: kfake, rcs: kfake, extensions: kfake.Extensions(), dcs: fake, bcs: fake, builds: fake, now: func() time.Time { return now }}
+
+	if err := c.Next(stream); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if updated == nil {
+		t.Fatalf("expected the status to be updated: %#v", fake.Actions())
+	}
+	// the event the build config is built from is kept although the policy trims it
+	if items := updated.Status.Tags["latest"].Items; !reflect.DeepEqual(items, []api.TagEvent{history[0], history[2]}) {
+		t.Errorf("unexpected history: %#v", items)
+	}
+}
@@ -188,6 +188,104 @@ func schema1ToImage(manifest *schema1.SignedManifest, d digest.Digest) (*api.Ima
 	return image, nil
 }
 
+// manifestMediaTypes are the manifest media types accepted from remote registries.
+var manifestMediaTypes = []string{
+	api.MediaTypeDockerSchema2Manifest,
+	api.MediaTypeDockerSchema2ManifestList,
+	api.MediaTypeDockerSchema1SignedManifest,
+	api.MediaTypeDockerSchema1Manifest,
+}
+
+// imageManifestMediaTypes are the media types of the manifests of single images.
+var imageManifestMediaTypes = []string{
+	api.MediaTypeDockerSchema2Manifest,
+	api.MediaTypeDockerSchema1SignedManifest,
+	api.MediaTypeDockerSchema1Manifest,
+}
+
+// defaultPlatform is the platform of the image that is imported when a manifest list is imported.
+var defaultPlatform = api.DockerPlatform{OS: "linux", Architecture: "amd64"}
+
+// manifestToImage converts a manifest retrieved from repo to an image. The configuration of schema2
+// manifests is retrieved along with them and manifest lists are resolved to the manifest of the
+// default platform. The digest d is optional.
+func manifestToImage(ctx gocontext.Context, repo distribution.Repository, manifests distribution.RawManifestService, content []byte, d digest.Digest) (*api.Image, error) {
+	list := api.DockerManifestList{}
+	if err := json.Unmarshal(content, &list); err != nil {
+		return nil, fmt.Errorf("unable to parse the image manifest: %v", err)
+	}
+	if list.SchemaVersion != 2 || list.MediaType != api.MediaTypeDockerSchema2ManifestList {
+		return imageManifestToImage(ctx, repo, content, d)
+	}
+
+	for _, manifest := range list.Manifests {
+		if manifest.Platform.OS != defaultPlatform.OS || manifest.Platform.Architecture != defaultPlatform.Architecture {
+			continue
+		}
+		dgst, err := digest.ParseDigest(manifest.Digest)
+		if err != nil {
+			return nil, err
+		}
+		// manifest lists can't be nested
+		_, content, err := manifests.GetRaw(dgst, distribution.WithManifestMediaTypes(imageManifestMediaTypes))
+		if err != nil {
+			return nil, err
+		}
+		return imageManifestToImage(ctx, repo, content, dgst)
+	}
+	return nil, fmt.Errorf("the manifest list has no image for %s/%s", defaultPlatform.OS, defaultPlatform.Architecture)
+}
+
+// imageManifestToImage converts a schema1 or schema2 manifest retrieved from repo to an image.
+func imageManifestToImage(ctx gocontext.Context, repo distribution.Repository, content []byte, d digest.Digest) (*api.Image, error) {
+	manifest := api.DockerImageManifest{}
+	if err := json.Unmarshal(content, &manifest); err != nil {
+		return nil, fmt.Errorf("unable to parse the image manifest: %v", err)
+	}
+	switch {
+	case manifest.SchemaVersion == 1:
+		signed := &schema1.SignedManifest{}
+		if err := json.Unmarshal(content, signed); err != nil {
+			return nil, err
+		}
+		return schema1ToImage(signed, d)
+	case manifest.SchemaVersion == 2 && manifest.MediaType == api.MediaTypeDockerSchema2Manifest:
+		dgst, err := digest.ParseDigest(manifest.Config.Digest)
+		if err != nil {
+			return nil, fmt.Errorf("the image configuration digest is invalid: %v", err)
+		}
+		config, err := repo.Blobs(ctx).Get(ctx, dgst)
+		if err != nil {
+			return nil, fmt.Errorf("unable to retrieve the image configuration %s: %v", dgst, err)
+		}
+		return schema2ToImage(content, config, d)
+	}
+	return nil, fmt.Errorf("unrecognized Docker image manifest schema %d (%s)", manifest.SchemaVersion, manifest.MediaType)
+}
+
+// schema2ToImage converts a schema2 manifest and the image configuration it references to an image.
+func schema2ToImage(manifest, config []byte, d digest.Digest) (*api.Image, error) {
+	if len(d) == 0 {
+		var err error
+		if d, err = digest.FromBytes(manifest); err != nil {
+			return nil, fmt.Errorf("unable to create digest from image bytes: %v", err)
+		}
+	}
+	image := &api.Image{
+		ObjectMeta: kapi.ObjectMeta{
+			Name: d.String(),
+		},
+		DockerImageManifest:          string(manifest),
+		DockerImageManifestMediaType: api.MediaTypeDockerSchema2Manifest,
+		DockerImageConfig:            string(config),
+		DockerImageMetadataVersion:   "1.0",
+	}
+	if err := api.ImageWithMetadata(image); err != nil {
+		return nil, err
+	}
+	return image, nil
+}
+
 func schema0ToImage(dockerImage *dockerregistry.Image, id string) (*api.Image, error) {
 	var baseImage api.DockerImage
 	if err := kapi.Scheme.Convert(&dockerImage.Image, &baseImage); err != nil {
@@ -347,6 +445,61 @@ func (r retryManifest) Get(dgst digest.Digest) (*schema1.SignedManifest, error)
 	}
 }
 
+// GetRaw retrieves the manifest identified by the digest, or by the tag passed with
+// distribution.WithTag, in one of the accepted media types. Remote manifest services which can't
+// retrieve raw manifests return signed schema1 manifests.
+func (r retryManifest) GetRaw(dgst digest.Digest, options ...distribution.ManifestServiceOption) (string, []byte, error) {
+	raw, ok := r.ManifestService.(distribution.RawManifestService)
+	if !ok {
+		return schema1ManifestService{r}.GetRaw(dgst, options...)
+	}
+	for {
+		if mediaType, content, err := raw.GetRaw(dgst, options...); r.repo.shouldRetry(err) {
+			continue
+		} else {
+			return mediaType, content, err
+		}
+	}
+}
+
+// PutRaw stores the manifest content of the given media type.
+func (r retryManifest) PutRaw(mediaType string, content []byte, options ...distribution.ManifestServiceOption) error {
+	raw, ok := r.ManifestService.(distribution.RawManifestService)
+	if !ok {
+		return distribution.ErrUnsupported
+	}
+	return raw.PutRaw(mediaType, content, options...)
+}
+
+// schema1ManifestService adapts a manifest service which only handles signed schema1 manifests to a
+// distribution.RawManifestService.
+type schema1ManifestService struct {
+	distribution.ManifestService
+}
+
+// GetRaw retrieves the signed schema1 manifest identified by the digest, or by the tag passed with
+// distribution.WithTag.
+func (s schema1ManifestService) GetRaw(dgst digest.Digest, options ...distribution.ManifestServiceOption) (string, []byte, error) {
+	for _, option := range options {
+		if opt, ok := option.(distribution.WithTagOption); ok {
+			return signedManifestContent(s.GetByTag(opt.Tag))
+		}
+	}
+	return signedManifestContent(s.Get(dgst))
+}
+
+// PutRaw is not supported.
+func (s schema1ManifestService) PutRaw(mediaType string, content []byte, options ...distribution.ManifestServiceOption) error {
+	return distribution.ErrUnsupported
+}
+
+func signedManifestContent(manifest *schema1.SignedManifest, err error) (string, []byte, error) {
+	if err != nil {
+		return "", nil, err
+	}
+	return api.MediaTypeDockerSchema1SignedManifest, manifest.Raw, nil
+}
+
 // Enumerate returns an array of manifest revisions in repository.
 func (r retryManifest) Enumerate() ([]digest.Digest, error) {
 	for {
@@ -76,6 +76,8 @@ type mockBlobStore struct {
 	distribution.BlobStore
 
 	statErr, serveErr, openErr error
+
+	content map[digest.Digest][]byte
 }
 
 func (r *mockBlobStore) Stat(ctx context.Context, dgst digest.Digest) (distribution.Descriptor, error) {
@@ -90,6 +92,28 @@ func (r *mockBlobStore) Open(ctx context.Context, dgst digest.Digest) (distribut
 	return nil, r.openErr
 }
 
+func (r *mockBlobStore) Get(ctx context.Context, dgst digest.Digest) ([]byte, error) {
+	content, ok := r.content[dgst]
+	if !ok {
+		return nil, distribution.ErrBlobUnknown
+	}
+	return content, nil
+}
+
+// mockRawManifests serves raw manifests by digest.
+type mockRawManifests map[digest.Digest]string
+
+func (m mockRawManifests) GetRaw(dgst digest.Digest, options ...distribution.ManifestServiceOption) (string, []byte, error) {
+	content, ok := m[dgst]
+	if !ok {
+		return "", nil, distribution.ErrManifestUnknownRevision{Revision: dgst}
+	}
+	return "", []byte(content), nil
+}
+func (m mockRawManifests) PutRaw(mediaType string, content []byte, options ...distribution.ManifestServiceOption) error {
+	return fmt.Errorf("not implemented")
+}
+
 func TestSchema1ToImage(t *testing.T) {
 	m := &schema1.SignedManifest{}
 	if err := json.Unmarshal([]byte(etcdManifest), m); err != nil {
@@ -104,6 +128,53 @@ func TestSchema1ToImage(t *testing.T) {
 	}
 }
 
+const (
+	testSchema2Manifest = `{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.v2+json",` +
+		`"config":{"mediaType":"application/vnd.docker.container.image.v1+json","size":120,"digest":"sha256:2d24f826cb16146e2016ff349a8a33ed5830f3b938d45c0f82943f4ab8c097e7"},` +
+		`"layers":[{"mediaType":"application/vnd.docker.image.rootfs.diff.tar.gzip","size":1024,"digest":"sha256:b194de3772ebbcdc8f244f663669799ac1cb141834b7cb8b69100285d357a2b0"}]}`
+	testSchema2Config = `{"architecture":"amd64","config":{"Cmd":["/bin/sh"]},"created":"2016-04-01T12:00:00Z","os":"linux"}`
+)
+
+func TestManifestToImage(t *testing.T) {
+	manifestDigest, err := digest.FromBytes([]byte(testSchema2Manifest))
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDigest := digest.Digest("sha256:2d24f826cb16146e2016ff349a8a33ed5830f3b938d45c0f82943f4ab8c097e7")
+	list := `{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.list.v2+json","manifests":[` +
+		`{"mediaType":"application/vnd.docker.distribution.manifest.v2+json","digest":"sha256:ppc64le","platform":{"architecture":"ppc64le","os":"linux"}},` +
+		`{"mediaType":"application/vnd.docker.distribution.manifest.v2+json","digest":"` + manifestDigest.String() + `","platform":{"architecture":"amd64","os":"linux"}}]}`
+
+	repo := &mockRepository{blobs: &mockBlobStore{content: map[digest.Digest][]byte{configDigest: []byte(testSchema2Config)}}}
+	manifests := mockRawManifests{manifestDigest: testSchema2Manifest}
+
+	for name, content := range map[string]string{"manifest": testSchema2Manifest, "manifest list": list} {
+		image, err := manifestToImage(context.Background(), repo, manifests, []byte(content), "")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if image.Name != manifestDigest.String() || image.DockerImageManifest != testSchema2Manifest || image.DockerImageConfig != testSchema2Config {
+			t.Errorf("%s: unexpected image: %#v", name, image)
+		}
+		if image.DockerImageManifestMediaType != api.MediaTypeDockerSchema2Manifest {
+			t.Errorf("%s: unexpected media type: %s", name, image.DockerImageManifestMediaType)
+		}
+		if image.DockerImageMetadata.ID != configDigest.String() || image.DockerImageMetadata.Size != 1024 || len(image.DockerImageLayers) != 1 {
+			t.Errorf("%s: unexpected metadata: %#v", name, image.DockerImageMetadata)
+		}
+	}
+
+	repo.blobs.content = nil
+	if _, err := manifestToImage(context.Background(), repo, manifests, []byte(testSchema2Manifest), ""); err == nil {
+		t.Errorf("expected an error for a missing image configuration")
+	}
+	noDefault := `{"schemaVersion":2,"mediaType":"application/vnd.docker.distribution.manifest.list.v2+json","manifests":[]}`
+	if _, err := manifestToImage(context.Background(), repo, manifests, []byte(noDefault), ""); err == nil {
+		t.Errorf("expected an error for a manifest list without an image of the default platform")
+	}
+}
+
 func TestDockerV1Fallback(t *testing.T) {
 	var uri *url.URL
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
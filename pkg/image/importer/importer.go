@@ -325,6 +325,11 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 		return
 	}
 
+	manifests, ok := s.(distribution.RawManifestService)
+	if !ok {
+		manifests = schema1ManifestService{s}
+	}
+
 	// if repository import is requested (MaximumTags), attempt to load the tags, sort them, and request the first N
 	if count := repository.MaximumTags; count > 0 || count == -1 {
 		tags, err := s.Tags()
@@ -372,7 +377,7 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 			continue
 		}
 		limiter.Accept()
-		m, err := s.Get(d)
+		_, m, err := manifests.GetRaw(d, distribution.WithManifestMediaTypes(manifestMediaTypes))
 		if err != nil {
 			glog.V(5).Infof("unable to access digest %q for repository %#v: %#v", d, repository, err)
 			switch {
@@ -388,7 +393,7 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 			importDigest.Err = err
 			continue
 		}
-		importDigest.Image, err = schema1ToImage(m, d)
+		importDigest.Image, err = manifestToImage(ctx, repo, manifests, m, d)
 		if err != nil {
 			importDigest.Err = err
 			continue
@@ -405,7 +410,7 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 			continue
 		}
 		limiter.Accept()
-		m, err := s.GetByTag(importTag.Name)
+		_, m, err := manifests.GetRaw("", distribution.WithTag(importTag.Name), distribution.WithManifestMediaTypes(manifestMediaTypes))
 		if err != nil {
 			glog.V(5).Infof("unable to access tag %q for repository %#v: %#v", importTag.Name, repository, err)
 			switch {
@@ -421,7 +426,7 @@ func importRepositoryFromDocker(ctx gocontext.Context, retriever RepositoryRetri
 			importTag.Err = err
 			continue
 		}
-		importTag.Image, err = schema1ToImage(m, "")
+		importTag.Image, err = manifestToImage(ctx, repo, manifests, m, "")
 		if err != nil {
 			importTag.Err = err
 			continue
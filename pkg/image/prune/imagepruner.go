@@ -4,6 +4,8 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"time"
 
 	"github.com/docker/distribution/registry/api/errcode"
@@ -24,6 +26,7 @@ import (
 	"github.com/openshift/origin/pkg/client"
 	deployapi "github.com/openshift/origin/pkg/deploy/api"
 	deploygraph "github.com/openshift/origin/pkg/deploy/graph/nodes"
+	registryapi "github.com/openshift/origin/pkg/dockerregistry/api"
 	imageapi "github.com/openshift/origin/pkg/image/api"
 	imagegraph "github.com/openshift/origin/pkg/image/graph/nodes"
 )
@@ -88,6 +91,15 @@ type ManifestPruner interface {
 	PruneManifest(registryClient *http.Client, registryURL, repo, manifest string) error
 }
 
+// RegistryGarbageCollector knows how to make the Docker registry delete the
+// blobs no image references.
+type RegistryGarbageCollector interface {
+	// CollectGarbage uses registryClient to ask the registry at registryURL to
+	// delete the blobs and uploads no image references, returning what was
+	// deleted.
+	CollectGarbage(registryClient *http.Client, registryURL string) (*registryapi.GarbageCollectionReport, error)
+}
+
 // ImageRegistryPrunerOptions contains the fields used to initialize a new
 // ImageRegistryPruner.
 type ImageRegistryPrunerOptions struct {
@@ -130,8 +142,9 @@ type ImageRegistryPruner interface {
 	// Prune uses imagePruner, streamPruner, layerPruner, blobPruner, and
 	// manifestPruner to remove images that have been identified as candidates
 	// for pruning based on the ImageRegistryPruner's internal pruning algorithm.
-	// Please see NewImageRegistryPruner for details on the algorithm.
-	Prune(imagePruner ImagePruner, streamPruner ImageStreamPruner, layerPruner LayerPruner, blobPruner BlobPruner, manifestPruner ManifestPruner) error
+	// Please see NewImageRegistryPruner for details on the algorithm. If
+	// garbageCollector is not nil, it is invoked once the images are pruned.
+	Prune(imagePruner ImagePruner, streamPruner ImageStreamPruner, layerPruner LayerPruner, blobPruner BlobPruner, manifestPruner ManifestPruner, garbageCollector RegistryGarbageCollector) error
 }
 
 // imageRegistryPruner implements ImageRegistryPruner.
@@ -728,7 +741,7 @@ func (p *imageRegistryPruner) determineRegistry(imageNodes []*imagegraph.ImageNo
 // Run identifies images eligible for pruning, invoking imagePruneFunc for each
 // image, and then it identifies layers eligible for pruning, invoking
 // layerPruneFunc for each registry URL that has layers that can be pruned.
-func (p *imageRegistryPruner) Prune(imagePruner ImagePruner, streamPruner ImageStreamPruner, layerPruner LayerPruner, blobPruner BlobPruner, manifestPruner ManifestPruner) error {
+func (p *imageRegistryPruner) Prune(imagePruner ImagePruner, streamPruner ImageStreamPruner, layerPruner LayerPruner, blobPruner BlobPruner, manifestPruner ManifestPruner, garbageCollector RegistryGarbageCollector) error {
 	allNodes := p.g.Nodes()
 
 	imageNodes := getImageNodes(allNodes)
@@ -765,7 +778,15 @@ func (p *imageRegistryPruner) Prune(imagePruner ImagePruner, streamPruner ImageS
 	}
 
 	errs = append(errs, pruneImages(p.g, prunableImageNodes, imagePruner)...)
-	return kerrors.NewAggregate(errs)
+	if len(errs) > 0 || garbageCollector == nil {
+		return kerrors.NewAggregate(errs)
+	}
+
+	// the blobs of the pruned images are unreferenced now
+	if _, err := garbageCollector.CollectGarbage(p.registryClient, registryURL); err != nil {
+		return fmt.Errorf("error collecting the garbage of the registry: %v", err)
+	}
+	return nil
 }
 
 // layerIsPrunable returns true if the layer is not referenced by any images.
@@ -1008,3 +1029,77 @@ func (p *deletingManifestPruner) PruneManifest(registryClient *http.Client, regi
 	glog.V(4).Infof("Pruning manifest for registry %q, repo %q, manifest %q", registryURL, repoName, manifest)
 	return deleteFromRegistry(registryClient, fmt.Sprintf("%s/v2/%s/manifests/%s", registryURL, repoName, manifest))
 }
+
+// registryGarbageCollector asks the registry to delete the blobs no image
+// references.
+type registryGarbageCollector struct {
+	dryRun          bool
+	keepYoungerThan time.Duration
+}
+
+var _ RegistryGarbageCollector = &registryGarbageCollector{}
+
+// NewRegistryGarbageCollector creates a new registryGarbageCollector. Blobs and
+// uploads younger than keepYoungerThan are kept. If dryRun is true, the
+// registry only reports what it would delete.
+func NewRegistryGarbageCollector(dryRun bool, keepYoungerThan time.Duration) RegistryGarbageCollector {
+	return &registryGarbageCollector{
+		dryRun:          dryRun,
+		keepYoungerThan: keepYoungerThan,
+	}
+}
+
+func (c *registryGarbageCollector) CollectGarbage(registryClient *http.Client, registryURL string) (*registryapi.GarbageCollectionReport, error) {
+	query := url.Values{}
+	query.Set("dryRun", strconv.FormatBool(c.dryRun))
+	query.Set("keepYoungerThan", c.keepYoungerThan.String())
+
+	collect := func(url string) (*registryapi.GarbageCollectionReport, error) {
+		req, err := http.NewRequest("POST", url, nil)
+		if err != nil {
+			return nil, fmt.Errorf("error creating request: %v", err)
+		}
+
+		glog.V(4).Infof("Sending request to registry")
+		resp, err := registryClient.Do(req)
+		if err != nil {
+			return nil, fmt.Errorf("error sending request: %v", err)
+		}
+		defer resp.Body.Close()
+
+		if resp.StatusCode != http.StatusOK {
+			var response errcode.Errors
+			if err := json.NewDecoder(resp.Body).Decode(&response); err != nil || len(response) == 0 {
+				return nil, fmt.Errorf("unexpected status: %s", resp.Status)
+			}
+			return nil, &response
+		}
+		report := &registryapi.GarbageCollectionReport{}
+		if err := json.NewDecoder(resp.Body).Decode(report); err != nil {
+			return nil, fmt.Errorf("error reading the garbage collection report: %v", err)
+		}
+		return report, nil
+	}
+
+	var err error
+	for _, proto := range []string{"https", "http"} {
+		url := fmt.Sprintf("%s://%s/admin/gc?%s", proto, registryURL, query.Encode())
+		glog.V(4).Infof("Trying %s", url)
+		var report *registryapi.GarbageCollectionReport
+		report, err = collect(url)
+		if err == nil {
+			if len(report.Errors) > 0 {
+				return report, fmt.Errorf("the registry failed to delete some blobs or uploads")
+			}
+			return report, nil
+		}
+
+		if _, ok := err.(*errcode.Errors); ok {
+			// we got a response back from the registry, so return it
+			return nil, err
+		}
+
+		glog.V(4).Infof("Error with %s for %s: %v", proto, registryURL, err)
+	}
+	return nil, err
+}
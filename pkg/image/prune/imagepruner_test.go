@@ -691,7 +691,7 @@ func TestImagePruning(t *testing.T) {
 		blobPruner := &fakeBlobPruner{invocations: sets.NewString()}
 		manifestPruner := &fakeManifestPruner{invocations: sets.NewString()}
 
-		p.Prune(imagePruner, streamPruner, layerPruner, blobPruner, manifestPruner)
+		p.Prune(imagePruner, streamPruner, layerPruner, blobPruner, manifestPruner, nil)
 
 		expectedDeletions := sets.NewString(test.expectedDeletions...)
 		if !reflect.DeepEqual(expectedDeletions, imagePruner.invocations) {
@@ -775,6 +775,39 @@ func TestDeletingNotFoundLayerPruner(t *testing.T) {
 	}
 }
 
+func TestRegistryGarbageCollector(t *testing.T) {
+	flag.Lookup("v").Value.Set(fmt.Sprint(*logLevel))
+
+	var actions []string
+	client := fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+		actions = append(actions, req.Method+":"+req.URL.String())
+		if req.URL.Scheme == "https" {
+			return nil, errors.New("no TLS")
+		}
+		body := `{"dryRun":true,"blobs":[{"digest":"sha256:orphan","size":10}],"uploads":["/upload"]}`
+		return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(bytes.NewReader([]byte(body)))}, nil
+	})
+	report, err := NewRegistryGarbageCollector(true, time.Hour).CollectGarbage(client, "registry1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !report.DryRun || len(report.Blobs) != 1 || report.Blobs[0].Digest != "sha256:orphan" || len(report.Uploads) != 1 {
+		t.Errorf("unexpected report: %#v", report)
+	}
+	if !reflect.DeepEqual(actions, []string{"POST:https://registry1/admin/gc?dryRun=true&keepYoungerThan=1h0m0s",
+		"POST:http://registry1/admin/gc?dryRun=true&keepYoungerThan=1h0m0s"}) {
+		t.Errorf("Unexpected actions %v", actions)
+	}
+
+	client = fake.CreateHTTPClient(func(req *http.Request) (*http.Response, error) {
+		body := `{"errors":[{"code":"UNAVAILABLE","message":"service unavailable"}]}`
+		return &http.Response{StatusCode: http.StatusServiceUnavailable, Body: ioutil.NopCloser(bytes.NewReader([]byte(body)))}, nil
+	})
+	if _, err := NewRegistryGarbageCollector(false, time.Hour).CollectGarbage(client, "registry1"); err == nil {
+		t.Errorf("expected an error")
+	}
+}
+
 func TestRegistryPruning(t *testing.T) {
 	flag.Lookup("v").Value.Set(fmt.Sprint(*logLevel))
 
@@ -900,7 +933,7 @@ func TestRegistryPruning(t *testing.T) {
 		blobPruner := &fakeBlobPruner{invocations: sets.NewString()}
 		manifestPruner := &fakeManifestPruner{invocations: sets.NewString()}
 
-		p.Prune(imagePruner, streamPruner, layerPruner, blobPruner, manifestPruner)
+		p.Prune(imagePruner, streamPruner, layerPruner, blobPruner, manifestPruner, nil)
 
 		if !reflect.DeepEqual(test.expectedLayerDeletions, layerPruner.invocations) {
 			t.Errorf("%s: expected layer deletions %#v, got %#v", name, test.expectedLayerDeletions, layerPruner.invocations)
@@ -373,6 +373,41 @@ func TestUpdateResetsMetadata(t *testing.T) {
 				DockerImageMetadata:  api.DockerImage{ID: "foo"},
 				DockerImageManifest:  etcdManifest,
 			},
+		},
+		// signatures can't be added, but their verification results can be updated
+		{
+			expect: func(image *api.Image) bool {
+				if len(image.Signatures) != 1 {
+					t.Errorf("unexpected signatures: %#v", image.Signatures)
+					return false
+				}
+				if len(image.Signatures[0].Conditions) != 2 || image.Signatures[0].ImageIdentity != "openshift/ruby-19-centos" {
+					t.Errorf("unexpected signature: %#v", image.Signatures[0])
+					return false
+				}
+				return true
+			},
+			existing: &api.Image{
+				ObjectMeta:           kapi.ObjectMeta{Name: "foo", ResourceVersion: "1"},
+				DockerImageReference: "openshift/ruby-19-centos",
+				Signatures:           []api.ImageSignature{{Type: api.ImageSignatureTypeAtomicImageV1, Content: []byte("signature")}},
+			},
+			image: &api.Image{
+				ObjectMeta:           kapi.ObjectMeta{Name: "foo", ResourceVersion: "1"},
+				DockerImageReference: "openshift/ruby-19-centos",
+				Signatures: []api.ImageSignature{
+					{
+						Type:    api.ImageSignatureTypeAtomicImageV1,
+						Content: []byte("signature"),
+						Conditions: []api.SignatureCondition{
+							{Type: api.SignatureTrusted, Status: kapi.ConditionTrue},
+							{Type: api.SignatureForImage, Status: kapi.ConditionTrue},
+						},
+						ImageIdentity: "openshift/ruby-19-centos",
+					},
+					{Type: api.ImageSignatureTypeAtomicImageV1, Content: []byte("other")},
+				},
+			},
 		}}
 
 	for i, test := range testCases {
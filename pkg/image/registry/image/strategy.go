@@ -1,6 +1,7 @@
 package image
 
 import (
+	"bytes"
 	"fmt"
 
 	kapi "k8s.io/kubernetes/pkg/api"
@@ -64,7 +65,8 @@ func (imageStrategy) Canonicalize(obj runtime.Object) {
 // PrepareForUpdate clears fields that are not allowed to be set by end users on update.
 // It extracts the latest info from the manifest and sets that on the object. It allows a user
 // to update the manifest so that it matches the digest (in case an older server stored a manifest
-// that was malformed, it can always be corrected).
+// that was malformed, it can always be corrected). Signatures cannot be added or removed, but
+// the verification results of existing signatures may be updated.
 func (imageStrategy) PrepareForUpdate(obj, old runtime.Object) {
 	newImage := obj.(*api.Image)
 	oldImage := old.(*api.Image)
@@ -73,7 +75,9 @@ func (imageStrategy) PrepareForUpdate(obj, old runtime.Object) {
 	newImage.DockerImageMetadata = oldImage.DockerImageMetadata
 	newImage.DockerImageMetadataVersion = oldImage.DockerImageMetadataVersion
 	newImage.DockerImageLayers = oldImage.DockerImageLayers
-	newImage.Signatures = oldImage.Signatures
+	newImage.DockerImageManifestMediaType = oldImage.DockerImageManifestMediaType
+	newImage.DockerImageConfig = oldImage.DockerImageConfig
+	newImage.Signatures = updatedSignatureDetails(oldImage.Signatures, newImage.Signatures)
 
 	// allow an image update that results in the manifest matching the digest (the name)
 	newManifest := newImage.DockerImageManifest
@@ -111,6 +115,26 @@ func (imageStrategy) clearSignatureDetails(image *api.Image) {
 	}
 }
 
+// updatedSignatureDetails returns the old signatures with the conditions and details of the
+// matching new signatures. New signatures which don't match any old one are ignored.
+func updatedSignatureDetails(old, new []api.ImageSignature) []api.ImageSignature {
+	if len(old) == 0 {
+		return old
+	}
+	signatures := make([]api.ImageSignature, len(old))
+	for i := range old {
+		signatures[i] = old[i]
+		for _, signature := range new {
+			if signature.Type != old[i].Type || !bytes.Equal(signature.Content, old[i].Content) {
+				continue
+			}
+			signatures[i] = signature
+			break
+		}
+	}
+	return signatures
+}
+
 // MatchImage returns a generic matcher for a given label and field selector.
 func MatchImage(label labels.Selector, field fields.Selector) generic.Matcher {
 	return generic.MatcherFunc(func(obj runtime.Object) (bool, error) {
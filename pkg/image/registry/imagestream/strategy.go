@@ -540,6 +540,7 @@ func (StatusStrategy) PrepareForUpdate(obj, old runtime.Object) {
 
 	stream.Spec.Tags = oldStream.Spec.Tags
 	stream.Spec.DockerImageRepository = oldStream.Spec.DockerImageRepository
+	stream.Spec.Retention = oldStream.Spec.Retention
 
 	updateObservedGenerationForStatusUpdate(stream, oldStream)
 }
@@ -41,15 +41,19 @@ type REST struct {
 	transport         http.RoundTripper
 	insecureTransport http.RoundTripper
 	clientFn          ImporterDockerRegistryFunc
+	// minimumScheduledInterval is the shortest interval between two scheduled imports of a tag.
+	minimumScheduledInterval time.Duration
 }
 
 // NewREST returns a REST storage implementation that handles importing images. The clientFn argument is optional
 // if v1 Docker Registry importing is not required. Insecure transport is optional, and both transports should not
-// include client certs unless you wish to allow the entire cluster to import using those certs.
+// include client certs unless you wish to allow the entire cluster to import using those certs. The next imports of
+// scheduled tags are recorded in their conditions, at least minimumScheduledInterval after the current import.
 func NewREST(importFn ImporterFunc, streams imagestream.Registry, internalStreams rest.CreaterUpdater,
 	images rest.Creater, secrets client.ImageStreamSecretsNamespacer,
 	transport, insecureTransport http.RoundTripper,
 	clientFn ImporterDockerRegistryFunc,
+	minimumScheduledInterval time.Duration,
 ) *REST {
 	return &REST{
 		importFn:          importFn,
@@ -60,6 +64,8 @@ func NewREST(importFn ImporterFunc, streams imagestream.Registry, internalStream
 		transport:         transport,
 		insecureTransport: insecureTransport,
 		clientFn:          clientFn,
+
+		minimumScheduledInterval: minimumScheduledInterval,
 	}
 }
 
@@ -177,7 +183,7 @@ func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 
 	if spec := isi.Spec.Repository; spec != nil {
 		for i, status := range isi.Status.Repository.Images {
-			if checkImportFailure(status, stream, status.Tag, nextGeneration, now) {
+			if checkImportFailure(status, stream, status.Tag, nextGeneration, now, r.minimumScheduledInterval) {
 				continue
 			}
 
@@ -214,7 +220,7 @@ func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 
 		// record a failure condition
 		status := isi.Status.Images[i]
-		if checkImportFailure(status, stream, tag, nextGeneration, now) {
+		if checkImportFailure(status, stream, tag, nextGeneration, now, r.minimumScheduledInterval) {
 			// ensure that we have a spec tag set
 			ensureSpecTag(stream, tag, spec.From.Name, spec.ImportPolicy, false)
 			continue
@@ -261,7 +267,7 @@ func (r *REST) Create(ctx kapi.Context, obj runtime.Object) (runtime.Object, err
 	return isi, nil
 }
 
-func checkImportFailure(status api.ImageImportStatus, stream *api.ImageStream, tag string, nextGeneration int64, now unversioned.Time) bool {
+func checkImportFailure(status api.ImageImportStatus, stream *api.ImageStream, tag string, nextGeneration int64, now unversioned.Time, minimumScheduledInterval time.Duration) bool {
 	if status.Image != nil && status.Status.Status == unversioned.StatusSuccess {
 		return false
 	}
@@ -289,17 +295,53 @@ func checkImportFailure(status api.ImageImportStatus, stream *api.ImageStream, t
 		}
 	}
 
-	if !api.HasTagCondition(stream, tag, condition) {
+	// scheduled tags back off after each consecutive failure
+	previous := api.TagImportCondition(stream, tag)
+	tagRef, scheduled := stream.Spec.Tags[tag]
+	scheduled = scheduled && tagRef.ImportPolicy.Scheduled
+	if scheduled {
+		condition.Failures = 1
+		if previous != nil && previous.Status == kapi.ConditionFalse {
+			condition.Failures = previous.Failures + 1
+		}
+		next := unversioned.NewTime(now.Add(api.ScheduledImportInterval(tagRef.ImportPolicy, minimumScheduledInterval, condition.Failures)))
+		condition.NextScheduledImport = &next
+	}
+
+	switch {
+	case !api.HasTagCondition(stream, tag, condition):
 		api.SetTagConditions(stream, tag, condition)
 		if tagRef, ok := stream.Spec.Tags[tag]; ok {
 			zero := int64(0)
 			tagRef.Generation = &zero
 			stream.Spec.Tags[tag] = tagRef
 		}
+	case scheduled:
+		// the failure did not transition the condition, but the last error and the backoff are recorded
+		condition.LastTransitionTime = previous.LastTransitionTime
+		api.SetTagConditions(stream, tag, condition)
 	}
 	return true
 }
 
+// newScheduledImportCondition returns the condition of a scheduled tag which was imported successfully, recording
+// when it will be imported again.
+func newScheduledImportCondition(stream *api.ImageStream, tag string, policy api.TagImportPolicy, gen int64, now unversioned.Time, minimumScheduledInterval time.Duration) api.TagEventCondition {
+	next := unversioned.NewTime(now.Add(api.ScheduledImportInterval(policy, minimumScheduledInterval, 0)))
+	c := api.TagEventCondition{
+		Type:       api.ImportSuccess,
+		Status:     kapi.ConditionTrue,
+		Generation: gen,
+
+		LastTransitionTime:  now,
+		NextScheduledImport: &next,
+	}
+	if previous := api.TagImportCondition(stream, tag); previous != nil && previous.Status == kapi.ConditionTrue {
+		c.LastTransitionTime = previous.LastTransitionTime
+	}
+	return c
+}
+
 // ensureSpecTag guarantees that the spec tag is set with the provided from and importPolicy. If reset is passed,
 // the tag will be overwritten.
 func ensureSpecTag(stream *api.ImageStream, tag, from string, importPolicy api.TagImportPolicy, reset bool) api.TagReference {
@@ -357,9 +399,12 @@ func (r *REST) importSuccessful(
 
 	// import or reuse the image, and ensure tag conditions are set
 	importErr, alreadyImported := importedImages[image.Name]
-	if importErr != nil {
+	switch {
+	case importErr != nil:
 		api.SetTagConditions(stream, tag, newImportFailedCondition(importErr, nextGeneration, now))
-	} else {
+	case importPolicy.Scheduled:
+		api.SetTagConditions(stream, tag, newScheduledImportCondition(stream, tag, importPolicy, nextGeneration, now, r.minimumScheduledInterval))
+	default:
 		api.SetTagConditions(stream, tag)
 	}
 
@@ -0,0 +1,52 @@
+package imagestreamimport
+
+import (
+	"testing"
+	"time"
+
+	kapi "k8s.io/kubernetes/pkg/api"
+	"k8s.io/kubernetes/pkg/api/unversioned"
+
+	"github.com/openshift/origin/pkg/image/api"
+)
+
+func TestCheckImportFailureScheduledBackoff(t *testing.T) {
+	stream := &api.ImageStream{
+		Spec: api.ImageStreamSpec{
+			Tags: map[string]api.TagReference{
+				"latest": {
+					From:         &kapi.ObjectReference{Kind: "DockerImage", Name: "mysql:latest"},
+					ImportPolicy: api.TagImportPolicy{Scheduled: true},
+				},
+			},
+		},
+	}
+	failure := api.ImageImportStatus{
+		Status: unversioned.Status{Status: unversioned.StatusFailure, Reason: unversioned.StatusReasonNotFound, Message: "not found"},
+	}
+	start := unversioned.Now()
+
+	for i, expected := range []time.Duration{30 * time.Minute, time.Hour, 2 * time.Hour} {
+		now := unversioned.NewTime(start.Add(time.Duration(i) * time.Minute))
+		if !checkImportFailure(failure, stream, "latest", 1, now, 15*time.Minute) {
+			t.Fatalf("%d: expected a failure", i)
+		}
+		condition := api.TagImportCondition(stream, "latest")
+		if condition == nil || condition.Status != kapi.ConditionFalse || condition.Message != "not found" {
+			t.Fatalf("%d: unexpected condition: %#v", i, condition)
+		}
+		if condition.Failures != int32(i+1) || condition.NextScheduledImport == nil || !condition.NextScheduledImport.Time.Equal(now.Add(expected)) {
+			t.Errorf("%d: unexpected backoff: %d %v", i, condition.Failures, condition.NextScheduledImport)
+		}
+		if !condition.LastTransitionTime.Time.Equal(start.Time) {
+			t.Errorf("%d: unexpected transition: %v", i, condition.LastTransitionTime)
+		}
+	}
+
+	// a successful import resets the backoff
+	now := unversioned.NewTime(start.Add(time.Hour))
+	condition := newScheduledImportCondition(stream, "latest", stream.Spec.Tags["latest"].ImportPolicy, 2, now, 15*time.Minute)
+	if condition.Status != kapi.ConditionTrue || condition.Failures != 0 || !condition.NextScheduledImport.Time.Equal(now.Add(15*time.Minute)) || !condition.LastTransitionTime.Time.Equal(now.Time) {
+		t.Errorf("unexpected condition: %#v", condition)
+	}
+}
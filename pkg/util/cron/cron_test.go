@@ -0,0 +1,94 @@
+package cron
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		spec        string
+		expectedErr bool
+	}{
+		{spec: "* * * * *"},
+		{spec: "*/15 2-4 1,15 * 1-5"},
+		{spec: "0 22 * * 7"},
+		{spec: "5/10 * * * *"},
+		{spec: "* * * *", expectedErr: true},
+		{spec: "60 * * * *", expectedErr: true},
+		{spec: "* 24 * * *", expectedErr: true},
+		{spec: "* * 0 * *", expectedErr: true},
+		{spec: "* * * 13 *", expectedErr: true},
+		{spec: "* * * * 8", expectedErr: true},
+		{spec: "5-1 * * * *", expectedErr: true},
+		{spec: "*/0 * * * *", expectedErr: true},
+		{spec: "a * * * *", expectedErr: true},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.spec)
+		if test.expectedErr && err == nil {
+			t.Errorf("%q: expected an error", test.spec)
+		}
+		if !test.expectedErr && err != nil {
+			t.Errorf("%q: unexpected error: %v", test.spec, err)
+		}
+	}
+}
+
+func TestScheduleNext(t *testing.T) {
+	// A Wednesday.
+	now := time.Date(2016, time.June, 15, 10, 30, 20, 0, time.UTC)
+
+	tests := []struct {
+		spec            string
+		expectedMatches bool
+		expectedNext    time.Time
+	}{
+		{
+			spec:            "* * * * *",
+			expectedMatches: true,
+			expectedNext:    time.Date(2016, time.June, 15, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			spec:            "* 10-11 * * 3",
+			expectedMatches: true,
+			expectedNext:    time.Date(2016, time.June, 15, 10, 31, 0, 0, time.UTC),
+		},
+		{
+			spec:            "*/15 * * * *",
+			expectedMatches: true,
+			expectedNext:    time.Date(2016, time.June, 15, 10, 45, 0, 0, time.UTC),
+		},
+		{
+			spec:         "* 2-4 * * 6",
+			expectedNext: time.Date(2016, time.June, 18, 2, 0, 0, 0, time.UTC),
+		},
+		{
+			spec:         "0 0 1 1 *",
+			expectedNext: time.Date(2017, time.January, 1, 0, 0, 0, 0, time.UTC),
+		},
+		{
+			// Either the day of the month or the day of the week has to match.
+			spec:         "0 12 20 * 0",
+			expectedNext: time.Date(2016, time.June, 15, 12, 0, 0, 0, time.UTC).AddDate(0, 0, 4),
+		},
+		{
+			spec: "0 0 30 2 *",
+		},
+	}
+
+	for _, test := range tests {
+		schedule, err := Parse(test.spec)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", test.spec, err)
+			continue
+		}
+		if e, a := test.expectedMatches, schedule.Matches(now); e != a {
+			t.Errorf("%q: expected matches to be %t, got %t", test.spec, e, a)
+		}
+		if e, a := test.expectedNext, schedule.Next(now); !e.Equal(a) {
+			t.Errorf("%q: expected next to be %v, got %v", test.spec, e, a)
+		}
+	}
+}
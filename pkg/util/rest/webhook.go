@@ -92,9 +92,28 @@ type WebHookHandler struct {
 var _ http.Handler = &WebHookHandler{}
 
 func (h *WebHookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if err := h.handler.ServeHTTP(w, r, h.ctx, h.name, h.options.Path); err != nil {
+	rw := &responseWriter{ResponseWriter: w}
+	if err := h.handler.ServeHTTP(rw, r, h.ctx, h.name, h.options.Path); err != nil {
 		h.responder.Error(err)
 		return
 	}
-	w.WriteHeader(http.StatusOK)
+	if !rw.written {
+		w.WriteHeader(http.StatusOK)
+	}
+}
+
+// responseWriter records whether a HookHandler wrote its own response.
+type responseWriter struct {
+	http.ResponseWriter
+	written bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.written = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(data []byte) (int, error) {
+	w.written = true
+	return w.ResponseWriter.Write(data)
 }